@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "bookings" ADD COLUMN     "bufferMinutes" INTEGER NOT NULL DEFAULT 0;
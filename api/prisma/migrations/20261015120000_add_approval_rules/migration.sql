@@ -0,0 +1,24 @@
+-- AlterTable
+ALTER TABLE "locations" ADD COLUMN     "requiresApproval" BOOLEAN NOT NULL DEFAULT false;
+
+-- CreateTable
+CREATE TABLE "approval_rules" (
+    "id" TEXT NOT NULL,
+    "locationId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "maxDurationMinutes" INTEGER,
+    "outsideCoreHours" BOOLEAN NOT NULL DEFAULT false,
+    "coreStart" TEXT NOT NULL DEFAULT '09:00',
+    "coreEnd" TEXT NOT NULL DEFAULT '16:00',
+    "enabled" BOOLEAN NOT NULL DEFAULT true,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "approval_rules_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "approval_rules_locationId_idx" ON "approval_rules"("locationId");
+
+-- AddForeignKey
+ALTER TABLE "approval_rules" ADD CONSTRAINT "approval_rules_locationId_fkey" FOREIGN KEY ("locationId") REFERENCES "locations"("id") ON DELETE CASCADE ON UPDATE CASCADE;
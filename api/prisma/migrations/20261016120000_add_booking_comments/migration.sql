@@ -0,0 +1,19 @@
+-- CreateTable
+CREATE TABLE "booking_comments" (
+    "id" TEXT NOT NULL,
+    "bookingId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "message" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "booking_comments_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "booking_comments_bookingId_createdAt_idx" ON "booking_comments"("bookingId", "createdAt");
+
+-- AddForeignKey
+ALTER TABLE "booking_comments" ADD CONSTRAINT "booking_comments_bookingId_fkey" FOREIGN KEY ("bookingId") REFERENCES "bookings"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "booking_comments" ADD CONSTRAINT "booking_comments_userId_fkey" FOREIGN KEY ("userId") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,6 @@
+-- CreateEnum
+CREATE TYPE "RoomSetup" AS ENUM ('THEATRE', 'BOARDROOM', 'U_SHAPE');
+
+-- AlterTable
+ALTER TABLE "bookings" ADD COLUMN     "setup" "RoomSetup",
+ADD COLUMN     "setupNotes" TEXT;
@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "bookings" ADD COLUMN     "idempotencyKey" TEXT;
+
+-- CreateIndex
+CREATE UNIQUE INDEX "bookings_userId_idempotencyKey_key" ON "bookings"("userId", "idempotencyKey");
@@ -0,0 +1,41 @@
+-- AlterTable
+ALTER TABLE "bookings" ADD COLUMN     "groupId" TEXT;
+
+-- CreateTable
+CREATE TABLE "zones" (
+    "id" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "zones_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "zone_rooms" (
+    "id" TEXT NOT NULL,
+    "zoneId" TEXT NOT NULL,
+    "roomId" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "zone_rooms_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "bookings_groupId_idx" ON "bookings"("groupId");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "zones_name_key" ON "zones"("name");
+
+-- CreateIndex
+CREATE INDEX "zone_rooms_roomId_idx" ON "zone_rooms"("roomId");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "zone_rooms_zoneId_roomId_key" ON "zone_rooms"("zoneId", "roomId");
+
+-- AddForeignKey
+ALTER TABLE "zone_rooms" ADD CONSTRAINT "zone_rooms_zoneId_fkey" FOREIGN KEY ("zoneId") REFERENCES "zones"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "zone_rooms" ADD CONSTRAINT "zone_rooms_roomId_fkey" FOREIGN KEY ("roomId") REFERENCES "rooms"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,24 @@
+-- CreateTable
+CREATE TABLE "location_services" (
+    "id" TEXT NOT NULL,
+    "locationId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "category" TEXT NOT NULL DEFAULT 'other',
+    "description" TEXT,
+    "quantity" INTEGER,
+    "contact" TEXT,
+    "roomId" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "location_services_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "location_services_locationId_idx" ON "location_services"("locationId");
+
+-- AddForeignKey
+ALTER TABLE "location_services" ADD CONSTRAINT "location_services_locationId_fkey" FOREIGN KEY ("locationId") REFERENCES "locations"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "location_services" ADD CONSTRAINT "location_services_roomId_fkey" FOREIGN KEY ("roomId") REFERENCES "rooms"("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,9 @@
+-- CreateEnum
+CREATE TYPE "RoomType" AS ENUM ('ROOM', 'DESK');
+
+-- AlterTable
+ALTER TABLE "rooms" ADD COLUMN "type" "RoomType" NOT NULL DEFAULT 'ROOM',
+ADD COLUMN "floor" TEXT;
+
+-- CreateIndex
+CREATE INDEX "rooms_type_floor_idx" ON "rooms"("type", "floor");
@@ -0,0 +1,54 @@
+-- CreateTable
+CREATE TABLE "priority_rules" (
+    "id" TEXT NOT NULL,
+    "locationId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "minNoticeHours" INTEGER NOT NULL DEFAULT 24,
+    "enabled" BOOLEAN NOT NULL DEFAULT true,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "priority_rules_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "booking_displacements" (
+    "id" TEXT NOT NULL,
+    "bookingId" TEXT NOT NULL,
+    "bumpedById" TEXT NOT NULL,
+    "reason" TEXT,
+    "fromRoomId" TEXT NOT NULL,
+    "fromStartTime" TIMESTAMP(3) NOT NULL,
+    "fromEndTime" TIMESTAMP(3) NOT NULL,
+    "toRoomId" TEXT NOT NULL,
+    "toStartTime" TIMESTAMP(3) NOT NULL,
+    "toEndTime" TIMESTAMP(3) NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "booking_displacements_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "priority_rules_locationId_userId_key" ON "priority_rules"("locationId", "userId");
+
+-- CreateIndex
+CREATE INDEX "priority_rules_locationId_idx" ON "priority_rules"("locationId");
+
+-- CreateIndex
+CREATE INDEX "booking_displacements_bookingId_idx" ON "booking_displacements"("bookingId");
+
+-- CreateIndex
+CREATE INDEX "booking_displacements_createdAt_idx" ON "booking_displacements"("createdAt");
+
+-- AddForeignKey
+ALTER TABLE "priority_rules" ADD CONSTRAINT "priority_rules_locationId_fkey" FOREIGN KEY ("locationId") REFERENCES "locations"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "priority_rules" ADD CONSTRAINT "priority_rules_userId_fkey" FOREIGN KEY ("userId") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "booking_displacements" ADD CONSTRAINT "booking_displacements_bookingId_fkey" FOREIGN KEY ("bookingId") REFERENCES "bookings"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "booking_displacements" ADD CONSTRAINT "booking_displacements_bumpedById_fkey" FOREIGN KEY ("bumpedById") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,9 @@
+-- AlterTable
+ALTER TABLE "users" ADD COLUMN "department" TEXT;
+
+-- AlterTable
+ALTER TABLE "rooms" ADD COLUMN "departments" TEXT[],
+ADD COLUMN "ownerId" TEXT;
+
+-- AddForeignKey
+ALTER TABLE "rooms" ADD CONSTRAINT "rooms_ownerId_fkey" FOREIGN KEY ("ownerId") REFERENCES "users"("id") ON DELETE SET NULL ON UPDATE CASCADE;
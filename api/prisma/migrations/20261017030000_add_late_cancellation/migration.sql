@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "locations" ADD COLUMN "lateCancelMinutes" INTEGER;
+
+-- AlterTable
+ALTER TABLE "bookings" ADD COLUMN "lateCancellation" BOOLEAN NOT NULL DEFAULT false;
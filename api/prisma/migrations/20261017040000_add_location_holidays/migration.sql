@@ -0,0 +1,19 @@
+-- AlterTable
+ALTER TABLE "locations" ADD COLUMN "closedWeekdays" INTEGER[] DEFAULT ARRAY[]::INTEGER[];
+
+-- CreateTable
+CREATE TABLE "location_holidays" (
+    "id" TEXT NOT NULL,
+    "locationId" TEXT NOT NULL,
+    "date" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "location_holidays_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "location_holidays_locationId_date_key" ON "location_holidays"("locationId", "date");
+
+-- AddForeignKey
+ALTER TABLE "location_holidays" ADD CONSTRAINT "location_holidays_locationId_fkey" FOREIGN KEY ("locationId") REFERENCES "locations"("id") ON DELETE CASCADE ON UPDATE CASCADE;
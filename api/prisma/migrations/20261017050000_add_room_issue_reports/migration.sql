@@ -0,0 +1,27 @@
+-- AlterEnum
+ALTER TYPE "FeedbackStatus" ADD VALUE 'IN_PROGRESS' BEFORE 'RESOLVED';
+
+-- CreateEnum
+CREATE TYPE "FeedbackSeverity" AS ENUM ('LOW', 'MEDIUM', 'HIGH');
+
+-- AlterTable
+ALTER TABLE "room_feedback" ADD COLUMN "severity" "FeedbackSeverity" NOT NULL DEFAULT 'MEDIUM';
+
+-- CreateTable
+CREATE TABLE "feedback_attachments" (
+    "id" TEXT NOT NULL,
+    "feedbackId" TEXT NOT NULL,
+    "fileName" TEXT NOT NULL,
+    "contentType" TEXT NOT NULL,
+    "size" INTEGER NOT NULL,
+    "url" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "feedback_attachments_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "feedback_attachments_feedbackId_idx" ON "feedback_attachments"("feedbackId");
+
+-- AddForeignKey
+ALTER TABLE "feedback_attachments" ADD CONSTRAINT "feedback_attachments_feedbackId_fkey" FOREIGN KEY ("feedbackId") REFERENCES "room_feedback"("id") ON DELETE CASCADE ON UPDATE CASCADE;
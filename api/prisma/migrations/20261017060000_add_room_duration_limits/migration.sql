@@ -0,0 +1,3 @@
+-- AlterTable
+ALTER TABLE "rooms" ADD COLUMN "minDurationMinutes" INTEGER,
+ADD COLUMN "maxDurationMinutes" INTEGER;
@@ -55,6 +55,7 @@ async function main() {
 			firstName: "John",
 			lastName: "Doe",
 			role: "USER",
+			department: "Engineering",
 		},
 	});
 
@@ -67,6 +68,7 @@ async function main() {
 			firstName: "Jane",
 			lastName: "Smith",
 			role: "USER",
+			department: "Sales",
 		},
 	});
 
@@ -84,6 +86,7 @@ async function main() {
 			country: "Norway",
 			timezone: "Europe/Oslo",
 			description: "Miles Stavanger office",
+			lateCancelMinutes: 60,
 		},
 	});
 
@@ -211,30 +214,41 @@ async function main() {
 			capacity: 8,
 			amenities: ["whiteboard", "video_conference", "tv"],
 			description: "Team collaboration room",
+			floor: "2",
+			wing: "North",
 		},
 		{
 			name: "Tenkeboksen",
 			capacity: 2,
 			amenities: ["whiteboard"],
 			description: "Quiet space for focused thinking",
+			floor: "2",
+			wing: "North",
+			departments: ["Engineering"],
 		},
 		{
 			name: "Spill & Chill",
 			capacity: 6,
 			amenities: ["tv", "gaming_console", "bean_bags"],
 			description: "Recreation and relaxation room",
+			floor: "2",
+			wing: "South",
 		},
 		{
 			name: "Skagen",
 			capacity: 10,
 			amenities: ["projector", "whiteboard", "video_conference", "tv"],
 			description: "Main conference room",
+			floor: "1",
+			wing: "South",
 		},
 		{
 			name: "På hjørna",
 			capacity: 4,
 			amenities: ["whiteboard", "monitor"],
 			description: "Corner meeting room",
+			floor: "1",
+			wing: "North",
 		},
 	];
 
@@ -12,13 +12,20 @@ import swaggerUi from "swagger-ui-express";
 import { errorHandler } from "./middleware/errorHandler";
 
 // Import routes
+import announcementRoutes from "./routes/announcement.routes";
 import authRoutes from "./routes/auth.routes";
 import bookingRoutes from "./routes/booking.routes";
 import calendarRoutes from "./routes/calendar.routes";
 import feedbackRoutes from "./routes/feedback.routes";
 import locationRoutes from "./routes/location.routes";
 import mcpRoutes from "./routes/mcp.routes";
+import metaRoutes from "./routes/meta.routes";
+import reportRoutes from "./routes/report.routes";
 import roomRoutes from "./routes/room.routes";
+import subscriptionRoutes from "./routes/subscription.routes";
+import timeSlotRoutes from "./routes/timeslot.routes";
+import webhookRoutes from "./routes/webhook.routes";
+import zoneRoutes from "./routes/zone.routes";
 
 // Load environment variables
 dotenv.config();
@@ -66,6 +73,13 @@ app.use("/api/bookings", bookingRoutes);
 app.use("/api/calendar", calendarRoutes);
 app.use("/api/feedback", feedbackRoutes);
 app.use("/api/mcp", mcpRoutes);
+app.use("/api/meta", metaRoutes);
+app.use("/api/announcements", announcementRoutes);
+app.use("/api/subscriptions", subscriptionRoutes);
+app.use("/api/webhooks", webhookRoutes);
+app.use("/api/zones", zoneRoutes);
+app.use("/api/time-slots", timeSlotRoutes);
+app.use("/api/reports", reportRoutes);
 
 // 404 handler
 app.use((_req: Request, res: Response) => {
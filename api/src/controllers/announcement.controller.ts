@@ -0,0 +1,14 @@
+import type { Request, Response } from "express";
+
+// Announcements are configured per deployment, separated by "|"
+const ANNOUNCEMENTS = (process.env.ANNOUNCEMENTS || "")
+	.split("|")
+	.map((message) => message.trim())
+	.filter((message) => message.length > 0);
+
+export const getAnnouncements = async (
+	_req: Request,
+	res: Response,
+): Promise<void> => {
+	res.json({ announcements: ANNOUNCEMENTS.map((message) => ({ message })) });
+};
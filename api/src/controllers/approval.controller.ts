@@ -0,0 +1,163 @@
+import type { Request, Response } from "express";
+import { z } from "zod";
+import { applyApprovalRules, parseClock } from "../utils/approval";
+import prisma from "../utils/prisma";
+
+const clockSchema = z
+	.string()
+	.refine((clock) => parseClock(clock) !== null, "Use HH:MM, e.g. 09:00");
+
+const approvalRuleSchema = z.object({
+	name: z.string().min(1),
+	maxDurationMinutes: z.number().int().min(1).nullable().optional(),
+	outsideCoreHours: z.boolean().optional(),
+	coreStart: clockSchema.optional(),
+	coreEnd: clockSchema.optional(),
+	enabled: z.boolean().optional(),
+});
+
+const updateApprovalRuleSchema = approvalRuleSchema.partial();
+
+// Core hours must be a window within one day
+const validCoreHours = (coreStart: string, coreEnd: string): boolean =>
+	(parseClock(coreStart) ?? 0) < (parseClock(coreEnd) ?? 0);
+
+export const getApprovalRules = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		const location = await prisma.location.findUnique({
+			where: { id },
+			include: { approvalRules: { orderBy: { createdAt: "asc" } } },
+		});
+
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		res.json({
+			requiresApproval: location.requiresApproval,
+			rules: location.approvalRules,
+		});
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch approval rules" });
+	}
+};
+
+export const createApprovalRule = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const data = approvalRuleSchema.parse(req.body);
+
+		if (!validCoreHours(data.coreStart ?? "09:00", data.coreEnd ?? "16:00")) {
+			res.status(400).json({ error: "Core hours must end after they start" });
+			return;
+		}
+
+		const location = await prisma.location.findUnique({ where: { id } });
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		const rule = await prisma.approvalRule.create({
+			data: { ...data, locationId: id },
+		});
+
+		// Waiting bookings the new rule covers no longer need a manager
+		const approved = await applyApprovalRules(id);
+
+		res.status(201).json({
+			message: "Approval rule created successfully",
+			rule,
+			approved,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to create approval rule" });
+	}
+};
+
+export const updateApprovalRule = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id, ruleId } = req.params;
+		const data = updateApprovalRuleSchema.parse(req.body);
+
+		const existing = await prisma.approvalRule.findFirst({
+			where: { id: ruleId, locationId: id },
+		});
+
+		if (!existing) {
+			res.status(404).json({ error: "Approval rule not found" });
+			return;
+		}
+
+		if (
+			!validCoreHours(
+				data.coreStart ?? existing.coreStart,
+				data.coreEnd ?? existing.coreEnd,
+			)
+		) {
+			res.status(400).json({ error: "Core hours must end after they start" });
+			return;
+		}
+
+		const rule = await prisma.approvalRule.update({
+			where: { id: ruleId },
+			data,
+		});
+
+		const approved = await applyApprovalRules(id);
+
+		res.json({
+			message: "Approval rule updated successfully",
+			rule,
+			approved,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to update approval rule" });
+	}
+};
+
+export const deleteApprovalRule = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id, ruleId } = req.params;
+
+		const { count } = await prisma.approvalRule.deleteMany({
+			where: { id: ruleId, locationId: id },
+		});
+
+		if (count === 0) {
+			res.status(404).json({ error: "Approval rule not found" });
+			return;
+		}
+
+		res.json({ message: "Approval rule deleted successfully" });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to delete approval rule" });
+	}
+};
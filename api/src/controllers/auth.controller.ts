@@ -140,6 +140,7 @@ export const me = async (req: Request, res: Response): Promise<void> => {
 				firstName: true,
 				lastName: true,
 				role: true,
+				department: true,
 				createdAt: true,
 				managedLocations: {
 					include: {
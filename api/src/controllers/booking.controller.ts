@@ -1,7 +1,36 @@
-import type { Prisma } from "@prisma/client";
+import crypto from "node:crypto";
+import { Prisma } from "@prisma/client";
 import type { Request, Response } from "express";
 import { z } from "zod";
+import { forbidden } from "../middleware/authorize";
+import { type ApprovalDecision, decideApproval } from "../utils/approval";
+import {
+	sendBookingBumpedNotification,
+	sendSetupRequestNotification,
+} from "../utils/email";
+import { durationLimitMessage } from "../utils/bookingLength";
+import { closureMessage, findClosure } from "../utils/holidays";
+import {
+	checkLateCancellation,
+	type LateCancellation,
+	lateCancellationWarning,
+} from "../utils/lateCancel";
 import prisma from "../utils/prisma";
+import { bookingHours, getQuota, quotaEnabled } from "../utils/quota";
+import {
+	canBookRoom,
+	loadAccessUser,
+	roomRestricted,
+} from "../utils/roomAccess";
+import { emitBookingEvent, type WebhookEvent } from "../utils/webhook";
+
+// Longest buffer a booking can hold after its end time
+const MAX_BUFFER_MINUTES = 60;
+
+// Longest setup notes for facilities
+const MAX_SETUP_NOTES = 500;
+
+const roomSetupSchema = z.enum(["THEATRE", "BOARDROOM", "U_SHAPE"]);
 
 const createBookingSchema = z.object({
 	roomId: z.string(),
@@ -9,24 +38,35 @@ const createBookingSchema = z.object({
 	endTime: z.string().datetime(),
 	title: z.string().min(1),
 	description: z.string().optional(),
+	bufferMinutes: z.number().int().min(0).max(MAX_BUFFER_MINUTES).optional(),
+	setup: roomSetupSchema.optional(),
+	setupNotes: z.string().max(MAX_SETUP_NOTES).optional(),
+	// Admins record bookings that already happened, e.g. when importing
+	// history from a calendar
+	backfill: z.boolean().optional(),
 });
 
+const bookingStatusSchema = z.enum(["PENDING", "CONFIRMED", "CANCELLED"]);
+
 const updateBookingSchema = z.object({
 	startTime: z.string().datetime().optional(),
 	endTime: z.string().datetime().optional(),
 	title: z.string().min(1).optional(),
 	description: z.string().optional(),
-	status: z.enum(["PENDING", "CONFIRMED", "CANCELLED"]).optional(),
+	status: bookingStatusSchema.optional(),
+	// null clears a setup request
+	setup: roomSetupSchema.nullable().optional(),
+	setupNotes: z.string().max(MAX_SETUP_NOTES).nullable().optional(),
 });
 
-// Check if a time slot is available
-const isRoomAvailable = async (
+// Find a booking that overlaps the time slot, if any
+const findConflict = async (
 	roomId: string,
 	startTime: Date,
 	endTime: Date,
 	excludeBookingId?: string,
-): Promise<boolean> => {
-	const conflictingBooking = await prisma.booking.findFirst({
+) => {
+	return prisma.booking.findFirst({
 		where: {
 			roomId,
 			id: excludeBookingId ? { not: excludeBookingId } : undefined,
@@ -49,9 +89,118 @@ const isRoomAvailable = async (
 				},
 			],
 		},
+		orderBy: { startTime: "asc" },
+		select: {
+			id: true,
+			startTime: true,
+			endTime: true,
+			user: { select: { firstName: true, lastName: true } },
+		},
+	});
+};
+
+// The 409 body for a slot that is taken: what overlaps and whose it is, so
+// clients can say "taken by K. Hansen until 15:30". Titles stay private.
+const conflictResponse = (conflict: {
+	id: string;
+	startTime: Date;
+	endTime: Date;
+	user: { firstName: string; lastName: string };
+}) => ({
+	error: "Room is not available for the selected time slot",
+	conflict: {
+		bookingId: conflict.id,
+		startTime: conflict.startTime,
+		endTime: conflict.endTime,
+		owner: conflict.user,
+	},
+});
+
+// Shorten a requested buffer so it ends where the next booking starts.
+// Buffers only take time that is free when they are created.
+const grantableBuffer = async (
+	roomId: string,
+	endTime: Date,
+	requestedMinutes: number,
+): Promise<number> => {
+	if (requestedMinutes <= 0) {
+		return 0;
+	}
+
+	const bufferEnd = new Date(endTime.getTime() + requestedMinutes * 60_000);
+	const next = await prisma.booking.findFirst({
+		where: {
+			roomId,
+			status: { not: "CANCELLED" },
+			startTime: { gte: endTime, lt: bufferEnd },
+		},
+		orderBy: { startTime: "asc" },
+	});
+
+	if (!next) {
+		return requestedMinutes;
+	}
+	return Math.floor((next.startTime.getTime() - endTime.getTime()) / 60_000);
+};
+
+// Let a booking claim the buffers of bookings that end before it, shrinking
+// each buffer so it ends where the claiming booking starts
+const claimBuffers = async (
+	roomId: string,
+	startTime: Date,
+	endTime: Date,
+	excludeBookingId?: string,
+): Promise<void> => {
+	const earliest = new Date(startTime.getTime() - MAX_BUFFER_MINUTES * 60_000);
+	const candidates = await prisma.booking.findMany({
+		where: {
+			roomId,
+			id: excludeBookingId ? { not: excludeBookingId } : undefined,
+			status: { not: "CANCELLED" },
+			bufferMinutes: { gt: 0 },
+			endTime: { gte: earliest, lt: endTime },
+		},
+	});
+
+	for (const booking of candidates) {
+		const gap = Math.max(
+			0,
+			Math.floor((startTime.getTime() - booking.endTime.getTime()) / 60_000),
+		);
+		if (gap < booking.bufferMinutes) {
+			await prisma.booking.update({
+				where: { id: booking.id },
+				data: { bufferMinutes: gap },
+			});
+		}
+	}
+};
+
+type BookingForSetup = Parameters<typeof sendSetupRequestNotification>[0];
+
+// Route a booking's setup request to the managers of its location, who
+// look after the rooms there
+const notifySetupRequest = async (booking: BookingForSetup) => {
+	if (!booking.setup && !booking.setupNotes) {
+		return;
+	}
+	const managerLocations = await prisma.managerLocation.findMany({
+		where: { locationId: booking.room.locationId },
+		include: {
+			user: { select: { email: true, firstName: true, lastName: true } },
+		},
 	});
+	const managers = managerLocations.map((ml) => ml.user);
+	if (managers.length > 0) {
+		await sendSetupRequestNotification(booking, managers);
+	}
+};
 
-	return !conflictingBooking;
+// Tell webhooks about a booking change. Fire and forget - don't await.
+const notifyWebhooks = (event: WebhookEvent, bookingId: string) => {
+	emitBookingEvent(event, bookingId).catch((err) => {
+		console.error(`Failed to deliver ${event} webhooks:`, err);
+	});
 };
 
 export const getAllBookings = async (
@@ -59,7 +208,34 @@ export const getAllBookings = async (
 	res: Response,
 ): Promise<void> => {
 	try {
-		const { roomId, locationId, startDate, endDate } = req.query;
+		const {
+			roomId,
+			locationId,
+			startDate,
+			endDate,
+			updatedSince,
+			user,
+			status,
+			hasSetup,
+		} = req.query;
+
+		// status takes one status or a comma-separated list
+		const statuses = status
+			? z.array(bookingStatusSchema).safeParse(String(status).split(","))
+			: undefined;
+		if (statuses && !statuses.success) {
+			res.status(400).json({ error: "Invalid status" });
+			return;
+		}
+
+		// Delta sync: only bookings changed at or after the cursor (a sync
+		// token or timestamp). Inclusive so writes in the same millisecond as
+		// the last sync aren't missed; clients merge by ID.
+		const since = updatedSince ? new Date(updatedSince as string) : undefined;
+		if (since && Number.isNaN(since.getTime())) {
+			res.status(400).json({ error: "Invalid updatedSince" });
+			return;
+		}
 
 		// Build where clause based on user role
 		const whereClause: Prisma.BookingWhereInput = {};
@@ -70,16 +246,17 @@ export const getAllBookings = async (
 		}
 
 		// Managers can see bookings for their managed locations
+		let managedLocationIds: string[] | undefined;
 		if (req.user?.role === "MANAGER") {
 			const managedLocations = await prisma.managerLocation.findMany({
 				where: { userId: req.user.userId },
 				select: { locationId: true },
 			});
 
-			const locationIds = managedLocations.map((ml) => ml.locationId);
+			managedLocationIds = managedLocations.map((ml) => ml.locationId);
 
 			whereClause.room = {
-				locationId: { in: locationIds },
+				locationId: { in: managedLocationIds },
 			};
 		}
 
@@ -88,10 +265,14 @@ export const getAllBookings = async (
 			whereClause.roomId = roomId as string;
 		}
 
+		// Narrow to one location. A manager asking for a location they don't
+		// manage gets nothing rather than that location's bookings.
 		if (locationId) {
-			// Override with specific location filter
+			const allowed =
+				!managedLocationIds ||
+				managedLocationIds.includes(locationId as string);
 			whereClause.room = {
-				locationId: locationId as string,
+				locationId: allowed ? (locationId as string) : { in: [] },
 			};
 		}
 
@@ -110,6 +291,31 @@ export const getAllBookings = async (
 			}
 		}
 
+		if (since) {
+			whereClause.updatedAt = { gte: since };
+		}
+
+		if (statuses?.success) {
+			whereClause.status = { in: statuses.data };
+		}
+
+		// Bookings with a setup request, for facilities preparing rooms
+		if (hasSetup === "true") {
+			whereClause.OR = [
+				{ setup: { not: null } },
+				{ setupNotes: { not: null } },
+			];
+		}
+
+		// Match the booker's email or name. Regular users only ever see
+		// their own bookings, so the filter is ignored for them.
+		if (user && req.user?.role !== "USER") {
+			const term = { contains: String(user), mode: "insensitive" as const };
+			whereClause.user = {
+				OR: [{ email: term }, { firstName: term }, { lastName: term }],
+			};
+		}
+
 		const bookings = await prisma.booking.findMany({
 			where: whereClause,
 			include: {
@@ -137,12 +343,120 @@ export const getAllBookings = async (
 			orderBy: { startTime: "asc" },
 		});
 
-		res.json({ bookings });
+		// The next cursor is the newest change seen; with nothing new the
+		// caller keeps its cursor
+		const newest = bookings.reduce<Date | undefined>(
+			(latest, booking) =>
+				!latest || booking.updatedAt > latest ? booking.updatedAt : latest,
+			since,
+		);
+
+		res.json({ bookings, syncToken: newest?.toISOString() ?? null });
 	} catch (_error) {
 		res.status(500).json({ error: "Failed to fetch bookings" });
 	}
 };
 
+// Get the authenticated user's booking quota, optionally for the period containing ?date=
+export const getMyQuota = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const at = req.query.date ? new Date(String(req.query.date)) : new Date();
+		if (Number.isNaN(at.getTime())) {
+			res.status(400).json({ error: "Invalid date" });
+			return;
+		}
+
+		const quota = await getQuota(req.user?.userId || "", at);
+		res.json({ quota });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch quota" });
+	}
+};
+
+// Longest window ?startDate..?endDate the busy times endpoint covers
+const MAX_BUSY_DAYS = 31;
+
+// Get when colleagues are booked, by ?emails=a@x,b@x between ?startDate and
+// ?endDate, for finding a time that suits everyone. Only times are returned;
+// titles and rooms stay private.
+export const getBusyTimes = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const emails = String(req.query.emails || "")
+			.split(",")
+			.map((email) => email.trim().toLowerCase())
+			.filter(Boolean);
+		const start = new Date(String(req.query.startDate));
+		const end = new Date(String(req.query.endDate));
+
+		if (emails.length === 0) {
+			res.status(400).json({ error: "emails is required" });
+			return;
+		}
+		if (Number.isNaN(start.getTime()) || Number.isNaN(end.getTime())) {
+			res.status(400).json({ error: "startDate and endDate are required" });
+			return;
+		}
+		if (end <= start) {
+			res.status(400).json({ error: "endDate must be after startDate" });
+			return;
+		}
+		if (
+			end.getTime() - start.getTime() >
+			MAX_BUSY_DAYS * 24 * 60 * 60 * 1000
+		) {
+			res.status(400).json({
+				error: `The window can be at most ${MAX_BUSY_DAYS} days`,
+			});
+			return;
+		}
+
+		const users = await prisma.user.findMany({
+			where: { email: { in: emails } },
+			select: { id: true, email: true },
+		});
+		const unknown = emails.filter(
+			(email) => !users.some((user) => user.email.toLowerCase() === email),
+		);
+		if (unknown.length > 0) {
+			res
+				.status(404)
+				.json({ error: `No user with email ${unknown.join(", ")}` });
+			return;
+		}
+
+		const bookings = await prisma.booking.findMany({
+			where: {
+				userId: { in: users.map((user) => user.id) },
+				status: { not: "CANCELLED" },
+				startTime: { lt: end },
+				endTime: { gt: start },
+			},
+			select: {
+				startTime: true,
+				endTime: true,
+				user: { select: { email: true } },
+			},
+			orderBy: { startTime: "asc" },
+		});
+
+		res.json({
+			busy: bookings.map((booking) => ({
+				email: booking.user.email,
+				startTime: booking.startTime,
+				endTime: booking.endTime,
+			})),
+		});
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch busy times" });
+	}
+};
+
 export const getBookingById = async (
 	req: Request,
 	res: Response,
@@ -202,11 +516,71 @@ export const getBookingById = async (
 	}
 };
 
+// Longest Idempotency-Key accepted when creating a booking
+const MAX_IDEMPOTENCY_KEY_LENGTH = 255;
+
+const createdBookingInclude = {
+	room: {
+		include: {
+			location: true,
+		},
+	},
+	user: {
+		select: {
+			id: true,
+			email: true,
+			firstName: true,
+			lastName: true,
+		},
+	},
+} satisfies Prisma.BookingInclude;
+
+/**
+ * Answer a retried create with the booking the first attempt made, so a
+ * client that lost the response never books twice. Returns false when the
+ * caller has no booking with this key yet.
+ */
+const replayBooking = async (
+	res: Response,
+	userId: string,
+	idempotencyKey: string,
+): Promise<boolean> => {
+	const booking = await prisma.booking.findUnique({
+		where: { userId_idempotencyKey: { userId, idempotencyKey } },
+		include: createdBookingInclude,
+	});
+	if (!booking) {
+		return false;
+	}
+	res
+		.status(200)
+		.set("Idempotent-Replayed", "true")
+		.json({ message: "Booking already created", booking });
+	return true;
+};
+
 export const createBooking = async (
 	req: Request,
 	res: Response,
 ): Promise<void> => {
+	const userId = req.user?.userId || "";
+	const idempotencyKey = req.get("Idempotency-Key");
 	try {
+		if (idempotencyKey !== undefined) {
+			if (
+				idempotencyKey === "" ||
+				idempotencyKey.length > MAX_IDEMPOTENCY_KEY_LENGTH
+			) {
+				res.status(400).json({
+					error: `Idempotency-Key must be 1-${MAX_IDEMPOTENCY_KEY_LENGTH} characters`,
+				});
+				return;
+			}
+			if (await replayBooking(res, userId, idempotencyKey)) {
+				return;
+			}
+		}
+
 		const data = createBookingSchema.parse(req.body);
 		const startTime = new Date(data.startTime);
 		const endTime = new Date(data.endTime);
@@ -217,7 +591,12 @@ export const createBooking = async (
 			return;
 		}
 
-		if (startTime < new Date()) {
+		if (data.backfill && req.user?.role !== "ADMIN") {
+			forbidden(res, "Only admins can backfill bookings", ["ADMIN"]);
+			return;
+		}
+
+		if (startTime < new Date() && !data.backfill) {
 			res.status(400).json({ error: "Cannot book in the past" });
 			return;
 		}
@@ -237,46 +616,99 @@ export const createBooking = async (
 			return;
 		}
 
+		if (req.user && !canBookRoom(await loadAccessUser(req.user), room)) {
+			roomRestricted(res, room);
+			return;
+		}
+
+		const lengthError = durationLimitMessage(room, startTime, endTime);
+		if (lengthError) {
+			res.status(400).json({ error: lengthError });
+			return;
+		}
+
+		// Nothing is booked while the office is closed, except as history
+		const closure = data.backfill
+			? null
+			: await findClosure(data.roomId, startTime, endTime);
+		if (closure) {
+			res.status(400).json({ error: closureMessage(closure), closure });
+			return;
+		}
+
 		// Check availability
-		const available = await isRoomAvailable(data.roomId, startTime, endTime);
+		const conflict = await findConflict(data.roomId, startTime, endTime);
 
-		if (!available) {
-			res
-				.status(409)
-				.json({ error: "Room is not available for the selected time slot" });
+		if (conflict) {
+			res.status(409).json(conflictResponse(conflict));
 			return;
 		}
 
+		// Check the user's personal quota for the period the booking falls in
+		let warning: string | undefined;
+		if (quotaEnabled() && req.user && !data.backfill) {
+			const quota = await getQuota(req.user.userId, startTime);
+			const after = quota.usedHours + bookingHours(startTime, endTime);
+			if (after > quota.limitHours) {
+				const message = `Booking would use ${after.toFixed(1)}h of your ${quota.limitHours}h ${quota.period}ly quota (${quota.usedHours.toFixed(1)}h already used)`;
+				if (quota.policy === "block") {
+					res.status(403).json({ error: message, quota });
+					return;
+				}
+				warning = message;
+			}
+		}
+
+		// Hold only the part of the buffer that is free, and take over
+		// any buffers this booking lands in
+		const bufferMinutes = await grantableBuffer(
+			data.roomId,
+			endTime,
+			data.bufferMinutes ?? 0,
+		);
+		await claimBuffers(data.roomId, startTime, endTime);
+
+		// Locations that require approval hold bookings no rule covers.
+		// Backfilled bookings are history, so there is nothing to approve.
+		const approval: ApprovalDecision = data.backfill
+			? { status: "CONFIRMED" }
+			: await decideApproval(data.roomId, startTime, endTime);
+
 		// Create booking
 		const booking = await prisma.booking.create({
 			data: {
 				roomId: data.roomId,
-				userId: req.user?.userId || "",
+				userId,
 				startTime,
 				endTime,
 				title: data.title,
 				description: data.description,
+				bufferMinutes,
+				status: approval.status,
+				setup: data.setup,
+				setupNotes: data.setupNotes || undefined,
+				idempotencyKey,
 			},
-			include: {
-				room: {
-					include: {
-						location: true,
-					},
-				},
-				user: {
-					select: {
-						id: true,
-						email: true,
-						firstName: true,
-						lastName: true,
-					},
-				},
-			},
+			include: createdBookingInclude,
 		});
 
+		// Fire and forget - don't await
+		notifySetupRequest(booking).catch((err) => {
+			console.error("Failed to send setup request:", err);
+		});
+		// Integrations react to new meetings, not imported history
+		if (!data.backfill) {
+			notifyWebhooks("booking.created", booking.id);
+		}
+
 		res.status(201).json({
-			message: "Booking created successfully",
+			message:
+				approval.status === "PENDING"
+					? "Booking created and waiting for approval"
+					: "Booking created successfully",
 			booking,
+			warning,
+			approvedBy: approval.rule,
 		});
 	} catch (error) {
 		if (error instanceof z.ZodError) {
@@ -285,6 +717,15 @@ export const createBooking = async (
 				.json({ error: "Validation error", details: error.errors });
 			return;
 		}
+		// A concurrent retry with the same key got there first
+		if (
+			idempotencyKey &&
+			error instanceof Prisma.PrismaClientKnownRequestError &&
+			error.code === "P2002" &&
+			(await replayBooking(res, userId, idempotencyKey).catch(() => false))
+		) {
+			return;
+		}
 		res.status(500).json({ error: "Failed to create booking" });
 	}
 };
@@ -315,7 +756,12 @@ export const updateBooking = async (
 			req.user?.role === "USER" &&
 			existingBooking.userId !== req.user.userId
 		) {
-			res.status(403).json({ error: "Not authorized to update this booking" });
+			forbidden(
+				res,
+				"Not authorized to update this booking",
+				["ADMIN", "MANAGER"],
+				existingBooking.room.locationId,
+			);
 			return;
 		}
 
@@ -330,14 +776,34 @@ export const updateBooking = async (
 			});
 
 			if (!managerLocation) {
-				res
-					.status(403)
-					.json({ error: "Not authorized to update this booking" });
+				forbidden(
+					res,
+					"Not authorized to update this booking",
+					["ADMIN", "MANAGER"],
+					existingBooking.room.locationId,
+				);
 				return;
 			}
 		}
 
+		// Only managers approve; owners may still cancel
+		if (
+			req.user?.role === "USER" &&
+			data.status &&
+			data.status !== "CANCELLED" &&
+			data.status !== existingBooking.status
+		) {
+			forbidden(
+				res,
+				"Only managers can approve bookings",
+				["ADMIN", "MANAGER"],
+				existingBooking.room.locationId,
+			);
+			return;
+		}
+
 		// If updating time, check availability
+		let status = data.status;
 		if (data.startTime || data.endTime) {
 			const startTime = data.startTime
 				? new Date(data.startTime)
@@ -351,30 +817,66 @@ export const updateBooking = async (
 				return;
 			}
 
-			const available = await isRoomAvailable(
+			const lengthError = durationLimitMessage(
+				existingBooking.room,
+				startTime,
+				endTime,
+			);
+			if (lengthError) {
+				res.status(400).json({ error: lengthError });
+				return;
+			}
+
+			const closure = await findClosure(
+				existingBooking.roomId,
+				startTime,
+				endTime,
+			);
+			if (closure) {
+				res.status(400).json({ error: closureMessage(closure), closure });
+				return;
+			}
+
+			const conflict = await findConflict(
 				existingBooking.roomId,
 				startTime,
 				endTime,
 				id,
 			);
 
-			if (!available) {
-				res
-					.status(409)
-					.json({ error: "Room is not available for the selected time slot" });
+			if (conflict) {
+				res.status(409).json(conflictResponse(conflict));
 				return;
 			}
+
+			await claimBuffers(existingBooking.roomId, startTime, endTime, id);
+
+			// A moved booking needs approval again unless a rule covers it
+			if (req.user?.role === "USER" && existingBooking.status !== "CANCELLED") {
+				status = (
+					await decideApproval(existingBooking.roomId, startTime, endTime)
+				).status;
+			}
 		}
 
+		// Cancelling close to the start is recorded for reporting
+		const late =
+			status === "CANCELLED"
+				? await checkLateCancellation(existingBooking, req.user?.userId)
+				: null;
+
 		// Update booking
 		const booking = await prisma.booking.update({
 			where: { id },
 			data: {
+				lateCancellation: late ? true : undefined,
 				startTime: data.startTime ? new Date(data.startTime) : undefined,
 				endTime: data.endTime ? new Date(data.endTime) : undefined,
 				title: data.title,
 				description: data.description,
-				status: data.status,
+				status,
+				setup: data.setup,
+				setupNotes: data.setupNotes === "" ? null : data.setupNotes,
 			},
 			include: {
 				room: {
@@ -393,9 +895,36 @@ export const updateBooking = async (
 			},
 		});
 
+		// A changed setup request goes to facilities again
+		if (
+			(data.setup !== undefined && data.setup !== existingBooking.setup) ||
+			(data.setupNotes !== undefined &&
+				data.setupNotes !== existingBooking.setupNotes)
+		) {
+			// Fire and forget - don't await
+		notifySetupRequest(booking).catch((err) => {
+			console.error("Failed to send setup request:", err);
+		});
+		}
+
+		if (
+			booking.status === "CANCELLED" &&
+			existingBooking.status !== "CANCELLED"
+		) {
+			notifyWebhooks("booking.cancelled", booking.id);
+		} else if (
+			booking.status === "CONFIRMED" &&
+			existingBooking.status === "PENDING"
+		) {
+			notifyWebhooks("booking.approved", booking.id);
+		} else {
+			notifyWebhooks("booking.updated", booking.id);
+		}
+
 		res.json({
 			message: "Booking updated successfully",
 			booking,
+			warning: late ? lateCancellationWarning(late) : undefined,
 		});
 	} catch (error) {
 		if (error instanceof z.ZodError) {
@@ -433,7 +962,12 @@ export const deleteBooking = async (
 			req.user?.role === "USER" &&
 			existingBooking.userId !== req.user.userId
 		) {
-			res.status(403).json({ error: "Not authorized to delete this booking" });
+			forbidden(
+				res,
+				"Not authorized to delete this booking",
+				["ADMIN", "MANAGER"],
+				existingBooking.room.locationId,
+			);
 			return;
 		}
 
@@ -448,21 +982,510 @@ export const deleteBooking = async (
 			});
 
 			if (!managerLocation) {
-				res
-					.status(403)
-					.json({ error: "Not authorized to delete this booking" });
+				forbidden(
+					res,
+					"Not authorized to delete this booking",
+					["ADMIN", "MANAGER"],
+					existingBooking.room.locationId,
+				);
 				return;
 			}
 		}
 
-		// Soft delete by setting status to CANCELLED
+		// Soft delete by setting status to CANCELLED, noting whether it was
+		// late under the location's policy
+		const late = await checkLateCancellation(
+			existingBooking,
+			req.user?.userId,
+		);
 		await prisma.booking.update({
 			where: { id },
-			data: { status: "CANCELLED" },
+			data: { status: "CANCELLED", lateCancellation: late ? true : undefined },
 		});
+		if (existingBooking.status !== "CANCELLED") {
+			notifyWebhooks("booking.cancelled", id);
+		}
 
-		res.json({ message: "Booking cancelled successfully" });
+		res.json({
+			message: "Booking cancelled successfully",
+			lateCancellation: late !== null,
+			warning: late ? lateCancellationWarning(late) : undefined,
+		});
 	} catch (_error) {
 		res.status(500).json({ error: "Failed to cancel booking" });
 	}
 };
+
+const bumpBookingSchema = z.object({
+	// Where the displaced booking goes; the same room when left out
+	roomId: z.string().optional(),
+	startTime: z.string().datetime(),
+	endTime: z.string().datetime(),
+	reason: z.string().max(500).optional(),
+});
+
+// Why a user may not bump a booking at a location, or null when they may.
+// Admins and the location's managers always may; other users need an
+// enabled priority rule there and the booking must start after its notice.
+const bumpRefusal = async (
+	user: NonNullable<Request["user"]>,
+	locationId: string,
+	booking: { userId: string; startTime: Date },
+): Promise<string | null> => {
+	if (user.role === "ADMIN") {
+		return null;
+	}
+	if (user.role === "MANAGER") {
+		const managerLocation = await prisma.managerLocation.findUnique({
+			where: { userId_locationId: { userId: user.userId, locationId } },
+		});
+		if (managerLocation) {
+			return null;
+		}
+	}
+
+	const rule = await prisma.priorityRule.findUnique({
+		where: { locationId_userId: { locationId, userId: user.userId } },
+	});
+	if (!rule?.enabled) {
+		return "You have no priority rule at this location";
+	}
+
+	// Priority bookings can't bump each other
+	const ownerRule = await prisma.priorityRule.findUnique({
+		where: { locationId_userId: { locationId, userId: booking.userId } },
+	});
+	if (ownerRule?.enabled) {
+		return "The booking's owner has priority here too";
+	}
+
+	const notice = booking.startTime.getTime() - Date.now();
+	if (notice < rule.minNoticeHours * 3_600_000) {
+		return `Bookings can only be bumped ${rule.minNoticeHours}h or more before they start`;
+	}
+	return null;
+};
+
+/**
+ * Bump someone else's booking to free its slot: move it to another room or
+ * time, tell the owner, and record the displacement for the audit log.
+ */
+export const bumpBooking = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const data = bumpBookingSchema.parse(req.body);
+
+		const existing = await prisma.booking.findUnique({
+			where: { id },
+			include: { room: true },
+		});
+
+		if (!existing) {
+			res.status(404).json({ error: "Booking not found" });
+			return;
+		}
+
+		if (existing.status === "CANCELLED") {
+			res.status(400).json({ error: "Booking is cancelled" });
+			return;
+		}
+
+		if (existing.endTime <= new Date()) {
+			res.status(400).json({ error: "Booking is over" });
+			return;
+		}
+
+		const user = req.user as NonNullable<Request["user"]>;
+		if (existing.userId === user.userId) {
+			res
+				.status(400)
+				.json({ error: "Move your own booking by editing it instead" });
+			return;
+		}
+
+		const refusal = await bumpRefusal(
+			user,
+			existing.room.locationId,
+			existing,
+		);
+		if (refusal) {
+			forbidden(res, refusal, ["ADMIN", "MANAGER"], existing.room.locationId);
+			return;
+		}
+
+		const startTime = new Date(data.startTime);
+		const endTime = new Date(data.endTime);
+		if (startTime >= endTime) {
+			res.status(400).json({ error: "End time must be after start time" });
+			return;
+		}
+		if (startTime < new Date()) {
+			res.status(400).json({ error: "Cannot move a booking into the past" });
+			return;
+		}
+
+		const roomId = data.roomId ?? existing.roomId;
+		if (
+			roomId === existing.roomId &&
+			startTime < existing.endTime &&
+			endTime > existing.startTime
+		) {
+			res
+				.status(400)
+				.json({ error: "The new slot overlaps the one being freed" });
+			return;
+		}
+
+		const room = await prisma.room.findUnique({ where: { id: roomId } });
+		if (!room) {
+			res.status(404).json({ error: "Room not found" });
+			return;
+		}
+		if (!room.isActive) {
+			res.status(400).json({ error: "Room is not available for booking" });
+			return;
+		}
+		const lengthError = durationLimitMessage(room, startTime, endTime);
+		if (lengthError) {
+			res.status(400).json({ error: lengthError });
+			return;
+		}
+
+		const conflict = await findConflict(roomId, startTime, endTime, id);
+		if (conflict) {
+			res.status(409).json(conflictResponse(conflict));
+			return;
+		}
+
+		const reason = data.reason?.trim() || null;
+		const [booking, displacement] = await prisma.$transaction([
+			prisma.booking.update({
+				where: { id },
+				data: { roomId, startTime, endTime },
+				include: createdBookingInclude,
+			}),
+			prisma.bookingDisplacement.create({
+				data: {
+					bookingId: id,
+					bumpedById: user.userId,
+					reason,
+					fromRoomId: existing.roomId,
+					fromStartTime: existing.startTime,
+					fromEndTime: existing.endTime,
+					toRoomId: roomId,
+					toStartTime: startTime,
+					toEndTime: endTime,
+				},
+			}),
+		]);
+
+		// Audit log: who bumped whose booking, from where to where
+		console.log(
+			`[audit] bump booking=${id} owner=${booking.user.email} by=${user.email} (${user.userId}) from=${existing.roomId}@${existing.startTime.toISOString()} to=${roomId}@${startTime.toISOString()}${reason ? ` reason=${JSON.stringify(reason)}` : ""}`,
+		);
+
+		const bumpedBy = await prisma.user.findUnique({
+			where: { id: user.userId },
+			select: { email: true, firstName: true, lastName: true },
+		});
+		if (bumpedBy) {
+			// Fire and forget - don't await
+			sendBookingBumpedNotification(booking, {
+				fromRoomName: existing.room.name,
+				fromStartTime: existing.startTime,
+				fromEndTime: existing.endTime,
+				reason,
+				bumpedBy,
+			}).catch((err) => {
+				console.error("Failed to send bump notice:", err);
+			});
+		}
+		notifyWebhooks("booking.updated", id);
+
+		res.json({
+			message: "Booking moved successfully",
+			booking,
+			displacement,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to bump booking" });
+	}
+};
+
+// Whether a manager manages every one of the locations
+const managesLocations = async (
+	userId: string,
+	locationIds: string[],
+): Promise<boolean> => {
+	const managed = await prisma.managerLocation.count({
+		where: { userId, locationId: { in: locationIds } },
+	});
+	return managed === new Set(locationIds).size;
+};
+
+const createZoneBookingSchema = z.object({
+	startTime: z.string().datetime(),
+	endTime: z.string().datetime(),
+	title: z.string().min(1),
+	description: z.string().optional(),
+	// Book the free rooms even when others are taken
+	partial: z.boolean().optional(),
+});
+
+// How one room of a zone booking went
+interface ZoneBookingResult {
+	roomId: string;
+	roomName: string;
+	status: "booked" | "conflict" | "free";
+	booking?: Prisma.BookingGetPayload<{ include: typeof createdBookingInclude }>;
+	conflict?: ReturnType<typeof conflictResponse>["conflict"];
+}
+
+/**
+ * Book every active room in a zone for an event, as one group. Unless
+ * partial is set, nothing is booked when any room is taken, and the 409
+ * lists which. Each room gets a result either way. Zone bookings are for
+ * events, so quotas and buffers don't apply.
+ */
+export const createZoneBooking = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const data = createZoneBookingSchema.parse(req.body);
+		const startTime = new Date(data.startTime);
+		const endTime = new Date(data.endTime);
+
+		if (startTime >= endTime) {
+			res.status(400).json({ error: "End time must be after start time" });
+			return;
+		}
+
+		if (startTime < new Date()) {
+			res.status(400).json({ error: "Cannot book in the past" });
+			return;
+		}
+
+		const zone = await prisma.zone.findUnique({
+			where: { id: req.params.id },
+			include: {
+				rooms: {
+					include: { room: true },
+					orderBy: { room: { name: "asc" } },
+				},
+			},
+		});
+
+		if (!zone) {
+			res.status(404).json({ error: "Zone not found" });
+			return;
+		}
+
+		const rooms = zone.rooms.map((zr) => zr.room).filter((r) => r.isActive);
+		if (rooms.length === 0) {
+			res.status(400).json({ error: "Zone has no rooms that can be booked" });
+			return;
+		}
+
+		// Managers may book zones entirely within their locations
+		const locationIds = rooms.map((room) => room.locationId);
+		if (
+			req.user?.role === "MANAGER" &&
+			!(await managesLocations(req.user.userId, locationIds))
+		) {
+			forbidden(res, "Not authorized to book every room in this zone", [
+				"ADMIN",
+				"MANAGER",
+			]);
+			return;
+		}
+
+		// Every room in the zone is booked, so each must take a booking this long
+		for (const room of rooms) {
+			const lengthError = durationLimitMessage(room, startTime, endTime);
+			if (lengthError) {
+				res.status(400).json({ error: lengthError });
+				return;
+			}
+		}
+
+		// Closures are per location, so one room of each is enough to ask
+		for (const locationId of new Set(locationIds)) {
+			const room = rooms.find((r) => r.locationId === locationId);
+			const closure =
+				room && (await findClosure(room.id, startTime, endTime));
+			if (closure) {
+				res.status(400).json({ error: closureMessage(closure), closure });
+				return;
+			}
+		}
+
+		const results: ZoneBookingResult[] = [];
+		const free: typeof rooms = [];
+		for (const room of rooms) {
+			const conflict = await findConflict(room.id, startTime, endTime);
+			if (conflict) {
+				results.push({
+					roomId: room.id,
+					roomName: room.name,
+					status: "conflict",
+					conflict: conflictResponse(conflict).conflict,
+				});
+			} else {
+				free.push(room);
+			}
+		}
+
+		if (free.length === 0 || (free.length < rooms.length && !data.partial)) {
+			res.status(409).json({
+				error:
+					free.length === 0
+						? "No room in the zone is free for the selected time slot"
+						: "Not every room in the zone is free for the selected time slot",
+				results: [
+					...results,
+					...free.map(
+						(room): ZoneBookingResult => ({
+							roomId: room.id,
+							roomName: room.name,
+							status: "free",
+						}),
+					),
+				],
+			});
+			return;
+		}
+
+		const groupId = crypto.randomUUID();
+		const bookings = await prisma.$transaction(async (tx) => {
+			const created = [];
+			for (const room of free) {
+				const approval = await decideApproval(room.id, startTime, endTime);
+				created.push(
+					await tx.booking.create({
+						data: {
+							roomId: room.id,
+							userId: req.user?.userId || "",
+							startTime,
+							endTime,
+							title: data.title,
+							description: data.description,
+							status: approval.status,
+							groupId,
+						},
+						include: createdBookingInclude,
+					}),
+				);
+			}
+			return created;
+		});
+
+		for (const booking of bookings) {
+			await claimBuffers(booking.roomId, startTime, endTime, booking.id);
+			notifyWebhooks("booking.created", booking.id);
+			results.push({
+				roomId: booking.roomId,
+				roomName: booking.room.name,
+				status: "booked",
+				booking,
+			});
+		}
+		results.sort((a, b) => a.roomName.localeCompare(b.roomName));
+
+		res.status(201).json({
+			message: `Booked ${bookings.length} of ${rooms.length} rooms in ${zone.name}`,
+			groupId,
+			results,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to book zone" });
+	}
+};
+
+// Cancel every booking in a group, such as all the rooms of a zone booking
+export const cancelBookingGroup = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { groupId } = req.params;
+
+		const bookings = await prisma.booking.findMany({
+			where: { groupId, status: { not: "CANCELLED" } },
+			include: { room: true },
+		});
+
+		if (bookings.length === 0) {
+			res.status(404).json({ error: "No active bookings in this group" });
+			return;
+		}
+
+		// Whoever booked the group may cancel it, as may admins and managers
+		// of every location in it
+		const userId = req.user?.userId || "";
+		const owns = bookings.every((booking) => booking.userId === userId);
+		if (!owns && req.user?.role !== "ADMIN") {
+			const locationIds = bookings.map((booking) => booking.room.locationId);
+			if (
+				req.user?.role !== "MANAGER" ||
+				!(await managesLocations(userId, locationIds))
+			) {
+				forbidden(res, "Not authorized to cancel this booking group", [
+					"ADMIN",
+					"MANAGER",
+				]);
+				return;
+			}
+		}
+
+		// Late cancellations are recorded per booking, under each room's
+		// location policy
+		const lateIds: string[] = [];
+		let late: LateCancellation | null = null;
+		for (const booking of bookings) {
+			const lateHere = await checkLateCancellation(booking, userId);
+			if (lateHere) {
+				lateIds.push(booking.id);
+				late ??= lateHere;
+			}
+		}
+
+		await prisma.booking.updateMany({
+			where: { id: { in: bookings.map((booking) => booking.id) } },
+			data: { status: "CANCELLED" },
+		});
+		if (lateIds.length > 0) {
+			await prisma.booking.updateMany({
+				where: { id: { in: lateIds } },
+				data: { lateCancellation: true },
+			});
+		}
+		for (const booking of bookings) {
+			notifyWebhooks("booking.cancelled", booking.id);
+		}
+
+		res.json({
+			message: `Cancelled ${bookings.length} bookings`,
+			cancelled: bookings.length,
+			lateCancellations: lateIds.length,
+			warning: late ? lateCancellationWarning(late) : undefined,
+		});
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to cancel booking group" });
+	}
+};
@@ -0,0 +1,152 @@
+import type { Request, Response } from "express";
+import { z } from "zod";
+import prisma from "../utils/prisma";
+
+const createCommentSchema = z.object({
+	message: z.string().trim().min(1).max(500),
+});
+
+// Comments returned without ?limit=, and the most allowed
+const DEFAULT_COMMENT_LIMIT = 50;
+const MAX_COMMENT_LIMIT = 200;
+
+const authorSelect = {
+	id: true,
+	email: true,
+	firstName: true,
+	lastName: true,
+};
+
+type CommentWithAuthor = {
+	id: string;
+	bookingId: string;
+	message: string;
+	createdAt: Date;
+	user: { id: string; email: string; firstName: string; lastName: string };
+};
+
+const toComment = ({ user, ...comment }: CommentWithAuthor) => ({
+	...comment,
+	author: user,
+});
+
+/**
+ * Find the booking and check the caller may take part in its thread: the
+ * booker, anyone with a booking in the same room that day (so the next
+ * meeting can say it's running late), managers of the location and admins.
+ * Sends the error response and returns null otherwise.
+ */
+const findDiscussableBooking = async (req: Request, res: Response) => {
+	const booking = await prisma.booking.findUnique({
+		where: { id: req.params.id },
+		include: { room: { select: { locationId: true } } },
+	});
+	if (!booking) {
+		res.status(404).json({ error: "Booking not found" });
+		return null;
+	}
+
+	const userId = req.user?.userId as string;
+	if (req.user?.role === "ADMIN" || booking.userId === userId) {
+		return booking;
+	}
+
+	if (req.user?.role === "MANAGER") {
+		const managerLocation = await prisma.managerLocation.findUnique({
+			where: {
+				userId_locationId: { userId, locationId: booking.room.locationId },
+			},
+		});
+		if (managerLocation) {
+			return booking;
+		}
+	}
+
+	const dayStart = new Date(booking.startTime);
+	dayStart.setHours(0, 0, 0, 0);
+	const dayEnd = new Date(dayStart);
+	dayEnd.setDate(dayEnd.getDate() + 1);
+
+	const neighbour = await prisma.booking.findFirst({
+		where: {
+			roomId: booking.roomId,
+			userId,
+			status: { not: "CANCELLED" },
+			startTime: { lt: dayEnd },
+			endTime: { gt: dayStart },
+		},
+		select: { id: true },
+	});
+	if (!neighbour) {
+		res.status(403).json({ error: "Not authorized to discuss this booking" });
+		return null;
+	}
+	return booking;
+};
+
+export const getBookingComments = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const limit = req.query.limit
+			? Number(req.query.limit)
+			: DEFAULT_COMMENT_LIMIT;
+		if (!Number.isInteger(limit) || limit < 1 || limit > MAX_COMMENT_LIMIT) {
+			res
+				.status(400)
+				.json({ error: `limit must be between 1 and ${MAX_COMMENT_LIMIT}` });
+			return;
+		}
+
+		const booking = await findDiscussableBooking(req, res);
+		if (!booking) {
+			return;
+		}
+
+		// The latest comments, returned oldest first like a chat
+		const comments = await prisma.bookingComment.findMany({
+			where: { bookingId: booking.id },
+			include: { user: { select: authorSelect } },
+			orderBy: { createdAt: "desc" },
+			take: limit,
+		});
+
+		res.json({ comments: comments.reverse().map(toComment) });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch comments" });
+	}
+};
+
+export const createBookingComment = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const data = createCommentSchema.parse(req.body);
+
+		const booking = await findDiscussableBooking(req, res);
+		if (!booking) {
+			return;
+		}
+
+		const comment = await prisma.bookingComment.create({
+			data: {
+				bookingId: booking.id,
+				userId: req.user?.userId as string,
+				message: data.message,
+			},
+			include: { user: { select: authorSelect } },
+		});
+
+		res.status(201).json({ comment: toComment(comment) });
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to add comment" });
+	}
+};
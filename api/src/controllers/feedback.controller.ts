@@ -1,17 +1,67 @@
 import type { Request, Response } from "express";
 import { z } from "zod";
+import { forbidden } from "../middleware/authorize";
+import {
+	sendFeedbackNotification,
+	sendFeedbackStatusUpdate,
+} from "../utils/email";
 import prisma from "../utils/prisma";
 
+// Most attachments one report may describe
+const MAX_ATTACHMENTS = 10;
+
+// Metadata of a photo or file; the file itself isn't uploaded
+const attachmentSchema = z.object({
+	fileName: z.string().trim().min(1).max(255),
+	contentType: z.string().trim().min(1).max(100),
+	size: z.number().int().min(0),
+	url: z.string().url().optional(),
+});
+
 const createFeedbackSchema = z.object({
 	roomId: z.string().min(1),
 	message: z.string().min(1),
+	severity: z.enum(["LOW", "MEDIUM", "HIGH"]).optional(),
+	attachments: z.array(attachmentSchema).max(MAX_ATTACHMENTS).optional(),
 });
 
 const updateFeedbackStatusSchema = z.object({
-	status: z.enum(["OPEN", "RESOLVED", "DISMISSED"]),
+	status: z.enum(["OPEN", "IN_PROGRESS", "RESOLVED", "DISMISSED"]),
 	comment: z.string().min(1),
 });
 
+const feedbackStatusQuerySchema = z.object({
+	status: z.enum(["OPEN", "IN_PROGRESS", "RESOLVED", "DISMISSED"]).optional(),
+});
+
+const personSelect = {
+	id: true,
+	firstName: true,
+	lastName: true,
+	email: true,
+};
+
+// What every feedback response includes: the room, the reporter, who
+// last changed the status and the attachments
+const feedbackInclude = {
+	room: {
+		select: {
+			id: true,
+			name: true,
+			locationId: true,
+		},
+	},
+	user: { select: personSelect },
+	resolver: { select: personSelect },
+	attachments: { orderBy: { createdAt: "asc" as const } },
+};
+
+// Most severe first, then oldest first, so the queue reads top down
+const queueOrder = [
+	{ severity: "desc" as const },
+	{ createdAt: "asc" as const },
+];
+
 export const getAllFeedback = async (
 	req: Request,
 	res: Response,
@@ -23,35 +73,17 @@ export const getAllFeedback = async (
 			where: {
 				...(roomId ? { roomId: roomId as string } : {}),
 				...(status
-					? { status: status as "OPEN" | "RESOLVED" | "DISMISSED" }
+					? {
+							status: status as
+								| "OPEN"
+								| "IN_PROGRESS"
+								| "RESOLVED"
+								| "DISMISSED",
+						}
 					: {}),
 				...(userId ? { userId: userId as string } : {}),
 			},
-			include: {
-				room: {
-					select: {
-						id: true,
-						name: true,
-						locationId: true,
-					},
-				},
-				user: {
-					select: {
-						id: true,
-						firstName: true,
-						lastName: true,
-						email: true,
-					},
-				},
-				resolver: {
-					select: {
-						id: true,
-						firstName: true,
-						lastName: true,
-						email: true,
-					},
-				},
-			},
+			include: feedbackInclude,
 			orderBy: { createdAt: "desc" },
 		});
 
@@ -70,31 +102,7 @@ export const getRoomFeedback = async (
 
 		const feedback = await prisma.roomFeedback.findMany({
 			where: { roomId },
-			include: {
-				room: {
-					select: {
-						id: true,
-						name: true,
-						locationId: true,
-					},
-				},
-				user: {
-					select: {
-						id: true,
-						firstName: true,
-						lastName: true,
-						email: true,
-					},
-				},
-				resolver: {
-					select: {
-						id: true,
-						firstName: true,
-						lastName: true,
-						email: true,
-					},
-				},
-			},
+			include: feedbackInclude,
 			orderBy: { createdAt: "desc" },
 		});
 
@@ -104,6 +112,70 @@ export const getRoomFeedback = async (
 	}
 };
 
+// The caller's own reports, newest first, so they can follow them
+export const getMyFeedback = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		if (!req.user) {
+			res.status(401).json({ error: "Authentication required" });
+			return;
+		}
+
+		const { status } = feedbackStatusQuerySchema.parse(req.query);
+
+		const feedback = await prisma.roomFeedback.findMany({
+			where: { userId: req.user.userId, status },
+			include: feedbackInclude,
+			orderBy: { createdAt: "desc" },
+		});
+
+		res.json({ feedback });
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res.status(400).json({ error: "Invalid input", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to fetch feedback" });
+	}
+};
+
+// The issue queue of a location, for its managers. Without ?status= it
+// holds the reports still open or in progress.
+export const getLocationFeedback = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const { status } = feedbackStatusQuerySchema.parse(req.query);
+
+		const location = await prisma.location.findUnique({ where: { id } });
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		const feedback = await prisma.roomFeedback.findMany({
+			where: {
+				room: { locationId: id },
+				status: status ?? { in: ["OPEN", "IN_PROGRESS"] },
+			},
+			include: feedbackInclude,
+			orderBy: queueOrder,
+		});
+
+		res.json({ feedback });
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res.status(400).json({ error: "Invalid input", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to fetch feedback" });
+	}
+};
+
 export const createFeedback = async (
 	req: Request,
 	res: Response,
@@ -119,6 +191,19 @@ export const createFeedback = async (
 		// Verify room exists
 		const room = await prisma.room.findUnique({
 			where: { id: validatedData.roomId },
+			include: {
+				location: {
+					include: {
+						managers: {
+							include: {
+								user: {
+									select: { email: true, firstName: true, lastName: true },
+								},
+							},
+						},
+					},
+				},
+			},
 		});
 
 		if (!room) {
@@ -131,26 +216,24 @@ export const createFeedback = async (
 				roomId: validatedData.roomId,
 				userId: req.user.userId,
 				message: validatedData.message,
+				severity: validatedData.severity,
+				attachments: validatedData.attachments
+					? { create: validatedData.attachments }
+					: undefined,
 			},
-			include: {
-				room: {
-					select: {
-						id: true,
-						name: true,
-						locationId: true,
-					},
-				},
-				user: {
-					select: {
-						id: true,
-						firstName: true,
-						lastName: true,
-						email: true,
-					},
-				},
-			},
+			include: feedbackInclude,
 		});
 
+		// Tell the location's managers, without holding up the response
+		const managers = room.location.managers.map((m) => m.user);
+		if (managers.length > 0) {
+			sendFeedbackNotification({ ...feedback, room }, managers).catch(
+				(err) => {
+					console.error("Failed to send feedback notification:", err);
+				},
+			);
+		}
+
 		res.status(201).json({
 			message: "Feedback submitted successfully",
 			feedback,
@@ -180,6 +263,7 @@ export const updateFeedbackStatus = async (
 		// Verify feedback exists
 		const existingFeedback = await prisma.roomFeedback.findUnique({
 			where: { id },
+			include: { room: { include: { location: true } } },
 		});
 
 		if (!existingFeedback) {
@@ -187,6 +271,28 @@ export const updateFeedbackStatus = async (
 			return;
 		}
 
+		// Admins and managers of the room's location work the queue
+		const { locationId } = existingFeedback.room;
+		if (req.user.role !== "ADMIN") {
+			const managerLocation =
+				req.user.role === "MANAGER"
+					? await prisma.managerLocation.findUnique({
+							where: {
+								userId_locationId: { userId: req.user.userId, locationId },
+							},
+						})
+					: null;
+			if (!managerLocation) {
+				forbidden(
+					res,
+					"Not authorized to manage this location",
+					["ADMIN", "MANAGER"],
+					locationId,
+				);
+				return;
+			}
+		}
+
 		const feedback = await prisma.roomFeedback.update({
 			where: { id },
 			data: {
@@ -194,33 +300,22 @@ export const updateFeedbackStatus = async (
 				resolvedBy: req.user.userId,
 				resolutionComment: validatedData.comment,
 			},
-			include: {
-				room: {
-					select: {
-						id: true,
-						name: true,
-						locationId: true,
-					},
-				},
-				user: {
-					select: {
-						id: true,
-						firstName: true,
-						lastName: true,
-						email: true,
-					},
-				},
-				resolver: {
-					select: {
-						id: true,
-						firstName: true,
-						lastName: true,
-						email: true,
-					},
-				},
-			},
+			include: feedbackInclude,
 		});
 
+		// Tell the reporter, without holding up the response
+		if (feedback.resolver && existingFeedback.status !== feedback.status) {
+			sendFeedbackStatusUpdate(
+				{ ...feedback, room: existingFeedback.room },
+				feedback.user,
+				feedback.resolver,
+				existingFeedback.status,
+				feedback.status,
+			).catch((err) => {
+				console.error("Failed to send status update notification:", err);
+			});
+		}
+
 		res.json({
 			message: "Feedback status updated successfully",
 			feedback,
@@ -0,0 +1,125 @@
+import { Prisma } from "@prisma/client";
+import type { Request, Response } from "express";
+import { z } from "zod";
+import prisma from "../utils/prisma";
+
+// A calendar day, YYYY-MM-DD
+const dateSchema = z
+	.string()
+	.regex(/^\d{4}-\d{2}-\d{2}$/, "Expected a date like 2025-12-25")
+	.refine((date) => !Number.isNaN(Date.parse(`${date}T00:00:00Z`)), {
+		message: "Not a valid date",
+	});
+
+const locationHolidaySchema = z.object({
+	date: dateSchema,
+	name: z.string().min(1),
+});
+
+const holidaysQuerySchema = z.object({
+	from: dateSchema.optional(),
+	to: dateSchema.optional(),
+});
+
+export const getLocationHolidays = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const { from, to } = holidaysQuerySchema.parse(req.query);
+
+		const location = await prisma.location.findUnique({
+			where: { id },
+			select: { timezone: true, closedWeekdays: true },
+		});
+
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		const holidays = await prisma.locationHoliday.findMany({
+			where: { locationId: id, date: { gte: from, lte: to } },
+			orderBy: { date: "asc" },
+		});
+
+		res.json({
+			holidays,
+			closedWeekdays: location.closedWeekdays,
+			timezone: location.timezone,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to fetch location holidays" });
+	}
+};
+
+export const createLocationHoliday = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const data = locationHolidaySchema.parse(req.body);
+
+		const location = await prisma.location.findUnique({ where: { id } });
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		const holiday = await prisma.locationHoliday.create({
+			data: { ...data, locationId: id },
+		});
+
+		res.status(201).json({
+			message: "Location holiday created successfully",
+			holiday,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		if (
+			error instanceof Prisma.PrismaClientKnownRequestError &&
+			error.code === "P2002"
+		) {
+			res
+				.status(409)
+				.json({ error: "The location already has a holiday on that day" });
+			return;
+		}
+		res.status(500).json({ error: "Failed to create location holiday" });
+	}
+};
+
+export const deleteLocationHoliday = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id, holidayId } = req.params;
+
+		const { count } = await prisma.locationHoliday.deleteMany({
+			where: { id: holidayId, locationId: id },
+		});
+
+		if (count === 0) {
+			res.status(404).json({ error: "Location holiday not found" });
+			return;
+		}
+
+		res.json({ message: "Location holiday deleted successfully" });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to delete location holiday" });
+	}
+};
@@ -9,6 +9,12 @@ const createLocationSchema = z.object({
 	country: z.string().min(1),
 	timezone: z.string().default("UTC"),
 	description: z.string().optional(),
+	requiresApproval: z.boolean().optional(),
+	// Minutes before the start inside which a cancellation counts as late;
+	// null removes the policy
+	lateCancelMinutes: z.number().int().min(1).max(10080).nullable().optional(),
+	// Weekdays the office is closed every week, 0 = Sunday
+	closedWeekdays: z.array(z.number().int().min(0).max(6)).optional(),
 });
 
 const updateLocationSchema = createLocationSchema.partial();
@@ -144,6 +150,42 @@ export const deleteLocation = async (
 	}
 };
 
+// Who to ask for access to a location: its managers, for any signed-in user
+export const getLocationManagers = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const location = await prisma.location.findUnique({
+			where: { id: req.params.id },
+			select: {
+				managers: {
+					select: {
+						user: {
+							select: {
+								id: true,
+								email: true,
+								firstName: true,
+								lastName: true,
+							},
+						},
+					},
+					orderBy: { user: { lastName: "asc" } },
+				},
+			},
+		});
+
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		res.json({ managers: location.managers.map(({ user }) => user) });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch managers" });
+	}
+};
+
 export const assignManager = async (
 	req: Request,
 	res: Response,
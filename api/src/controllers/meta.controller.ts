@@ -0,0 +1,28 @@
+import type { Request, Response } from "express";
+import {
+	FEATURES,
+	type FeatureName,
+	isFeatureEnabled,
+} from "../utils/features";
+import { API_VERSION } from "../utils/version";
+
+export const getFeatures = async (
+	_req: Request,
+	res: Response,
+): Promise<void> => {
+	const names = Object.keys(FEATURES) as FeatureName[];
+	res.json({
+		features: names.map((name) => ({
+			name,
+			enabled: isFeatureEnabled(name),
+			description: FEATURES[name],
+		})),
+	});
+};
+
+export const getVersion = async (
+	_req: Request,
+	res: Response,
+): Promise<void> => {
+	res.json({ version: API_VERSION });
+};
@@ -0,0 +1,189 @@
+import type { Request, Response } from "express";
+import { z } from "zod";
+import prisma from "../utils/prisma";
+
+const userSelect = {
+	id: true,
+	email: true,
+	firstName: true,
+	lastName: true,
+} as const;
+
+const priorityRuleSchema = z.object({
+	email: z.string().email(),
+	name: z.string().min(1),
+	minNoticeHours: z.number().int().min(0).optional(),
+	enabled: z.boolean().optional(),
+});
+
+const updatePriorityRuleSchema = priorityRuleSchema
+	.omit({ email: true })
+	.partial();
+
+export const getPriorityRules = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		const location = await prisma.location.findUnique({
+			where: { id },
+			include: {
+				priorityRules: {
+					include: { user: { select: userSelect } },
+					orderBy: { createdAt: "asc" },
+				},
+			},
+		});
+
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		res.json({ rules: location.priorityRules });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch priority rules" });
+	}
+};
+
+export const createPriorityRule = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const { email, ...data } = priorityRuleSchema.parse(req.body);
+
+		const location = await prisma.location.findUnique({ where: { id } });
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		const user = await prisma.user.findUnique({ where: { email } });
+		if (!user) {
+			res.status(404).json({ error: `User ${email} not found` });
+			return;
+		}
+
+		const existing = await prisma.priorityRule.findUnique({
+			where: { locationId_userId: { locationId: id, userId: user.id } },
+		});
+		if (existing) {
+			res
+				.status(409)
+				.json({ error: `${email} already has a priority rule here` });
+			return;
+		}
+
+		const rule = await prisma.priorityRule.create({
+			data: { ...data, locationId: id, userId: user.id },
+			include: { user: { select: userSelect } },
+		});
+
+		res.status(201).json({
+			message: "Priority rule created successfully",
+			rule,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to create priority rule" });
+	}
+};
+
+export const updatePriorityRule = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id, ruleId } = req.params;
+		const data = updatePriorityRuleSchema.parse(req.body);
+
+		const existing = await prisma.priorityRule.findFirst({
+			where: { id: ruleId, locationId: id },
+		});
+
+		if (!existing) {
+			res.status(404).json({ error: "Priority rule not found" });
+			return;
+		}
+
+		const rule = await prisma.priorityRule.update({
+			where: { id: ruleId },
+			data,
+			include: { user: { select: userSelect } },
+		});
+
+		res.json({
+			message: "Priority rule updated successfully",
+			rule,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to update priority rule" });
+	}
+};
+
+export const deletePriorityRule = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id, ruleId } = req.params;
+
+		const { count } = await prisma.priorityRule.deleteMany({
+			where: { id: ruleId, locationId: id },
+		});
+
+		if (count === 0) {
+			res.status(404).json({ error: "Priority rule not found" });
+			return;
+		}
+
+		res.json({ message: "Priority rule deleted successfully" });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to delete priority rule" });
+	}
+};
+
+// The audit log of bookings bumped at a location, newest first
+export const getDisplacements = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		const displacements = await prisma.bookingDisplacement.findMany({
+			where: { booking: { room: { locationId: id } } },
+			include: {
+				bumpedBy: { select: userSelect },
+				booking: {
+					select: {
+						id: true,
+						title: true,
+						user: { select: userSelect },
+					},
+				},
+			},
+			orderBy: { createdAt: "desc" },
+			take: 200,
+		});
+
+		res.json({ displacements });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch displacements" });
+	}
+};
@@ -0,0 +1,331 @@
+import type { Request, Response } from "express";
+import { forbidden } from "../middleware/authorize";
+import prisma from "../utils/prisma";
+import { bookingHours } from "../utils/quota";
+
+// Longest period one report covers
+const MAX_REPORT_DAYS = 366;
+
+// Utilization is booked time over these hours on each weekday
+const WORKDAY_HOURS = 8;
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+interface Counts {
+	bookings: number;
+	cancelled: number;
+	lateCancelled: number;
+}
+
+const emptyCounts = (): Counts => ({
+	bookings: 0,
+	cancelled: 0,
+	lateCancelled: 0,
+});
+
+// Weekdays from start up to end, the days utilization is measured over
+const weekdaysBetween = (start: Date, end: Date): number => {
+	let days = 0;
+	for (let day = new Date(start); day < end; day.setDate(day.getDate() + 1)) {
+		if (day.getDay() !== 0 && day.getDay() !== 6) {
+			days++;
+		}
+	}
+	return days;
+};
+
+/**
+ * Room use over a period: booked hours and utilization per room, and
+ * bookings, cancellations and late cancellations per user and per team
+ * (department). Managers see their own locations only. Defaults to the
+ * last 30 days.
+ */
+export const getUtilizationReport = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const end = req.query.endDate
+			? new Date(String(req.query.endDate))
+			: new Date();
+		const start = req.query.startDate
+			? new Date(String(req.query.startDate))
+			: new Date(end.getTime() - 30 * DAY_MS);
+		const locationId = req.query.locationId
+			? String(req.query.locationId)
+			: undefined;
+
+		if (Number.isNaN(start.getTime()) || Number.isNaN(end.getTime())) {
+			res.status(400).json({ error: "startDate and endDate must be dates" });
+			return;
+		}
+		if (end <= start) {
+			res.status(400).json({ error: "endDate must be after startDate" });
+			return;
+		}
+		if (end.getTime() - start.getTime() > MAX_REPORT_DAYS * DAY_MS) {
+			res.status(400).json({
+				error: `A report can cover at most ${MAX_REPORT_DAYS} days`,
+			});
+			return;
+		}
+
+		// Managers report on the locations they manage
+		let locationIds: string[] | undefined = locationId
+			? [locationId]
+			: undefined;
+		if (req.user?.role === "MANAGER") {
+			const managed = (
+				await prisma.managerLocation.findMany({
+					where: { userId: req.user.userId },
+					select: { locationId: true },
+				})
+			).map((m) => m.locationId);
+			if (locationId && !managed.includes(locationId)) {
+				forbidden(
+					res,
+					"Not authorized to report on this location",
+					["ADMIN", "MANAGER"],
+					locationId,
+				);
+				return;
+			}
+			locationIds ??= managed;
+		}
+
+		const bookings = await prisma.booking.findMany({
+			where: {
+				startTime: { gte: start, lt: end },
+				room: locationIds ? { locationId: { in: locationIds } } : undefined,
+			},
+			select: {
+				startTime: true,
+				endTime: true,
+				status: true,
+				lateCancellation: true,
+				room: {
+					select: {
+						id: true,
+						name: true,
+						location: { select: { name: true } },
+					},
+				},
+				user: {
+					select: {
+						id: true,
+						email: true,
+						firstName: true,
+						lastName: true,
+						department: true,
+					},
+				},
+			},
+		});
+
+		const availableHours = weekdaysBetween(start, end) * WORKDAY_HOURS;
+		const totals = { ...emptyCounts(), bookedHours: 0 };
+		const rooms = new Map<
+			string,
+			{
+				roomId: string;
+				name: string;
+				location: string;
+				bookings: number;
+				bookedHours: number;
+			}
+		>();
+		const users = new Map<
+			string,
+			Counts & {
+				userId: string;
+				name: string;
+				email: string;
+				department: string | null;
+			}
+		>();
+		const teams = new Map<string, Counts & { department: string | null }>();
+
+		for (const booking of bookings) {
+			const cancelled = booking.status === "CANCELLED";
+			const user = users.get(booking.user.id) ?? {
+				...emptyCounts(),
+				userId: booking.user.id,
+				name: `${booking.user.firstName} ${booking.user.lastName}`,
+				email: booking.user.email,
+				department: booking.user.department,
+			};
+			users.set(booking.user.id, user);
+			const teamKey = booking.user.department ?? "";
+			const team = teams.get(teamKey) ?? {
+				...emptyCounts(),
+				department: booking.user.department,
+			};
+			teams.set(teamKey, team);
+
+			for (const counts of [totals, user, team]) {
+				counts.bookings++;
+				if (cancelled) {
+					counts.cancelled++;
+				}
+				if (booking.lateCancellation) {
+					counts.lateCancelled++;
+				}
+			}
+
+			if (!cancelled) {
+				const hours = bookingHours(booking.startTime, booking.endTime);
+				totals.bookedHours += hours;
+				const room = rooms.get(booking.room.id) ?? {
+					roomId: booking.room.id,
+					name: booking.room.name,
+					location: booking.room.location.name,
+					bookings: 0,
+					bookedHours: 0,
+				};
+				room.bookings++;
+				room.bookedHours += hours;
+				rooms.set(booking.room.id, room);
+			}
+		}
+
+		const byLateCancels = (a: Counts, b: Counts) =>
+			b.lateCancelled - a.lateCancelled || b.bookings - a.bookings;
+
+		res.json({
+			startDate: start,
+			endDate: end,
+			totals,
+			rooms: [...rooms.values()]
+				.map((room) => ({
+					...room,
+					utilization:
+						availableHours > 0 ? room.bookedHours / availableHours : 0,
+				}))
+				.sort((a, b) => b.bookedHours - a.bookedHours),
+			users: [...users.values()].sort(byLateCancels),
+			teams: [...teams.values()].sort(byLateCancels),
+		});
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to build utilization report" });
+	}
+};
+
+// Months of history in personal stats, this month included
+const STATS_MONTHS = 6;
+
+/**
+ * The signed-in user's own booking stats: meetings, hours and average
+ * length this month, the room they book most and meetings and hours per
+ * month over the last six months, oldest first. Cancelled bookings only
+ * count toward `cancelled`.
+ */
+export const getMyStats = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const now = new Date();
+		const monthStart = new Date(now.getFullYear(), now.getMonth(), 1);
+		const monthEnd = new Date(now.getFullYear(), now.getMonth() + 1, 1);
+		const trendStart = new Date(
+			now.getFullYear(),
+			now.getMonth() - (STATS_MONTHS - 1),
+			1,
+		);
+
+		const bookings = await prisma.booking.findMany({
+			where: {
+				userId: req.user?.userId,
+				startTime: { gte: trendStart, lt: monthEnd },
+			},
+			select: {
+				startTime: true,
+				endTime: true,
+				status: true,
+				room: {
+					select: {
+						id: true,
+						name: true,
+						location: { select: { name: true } },
+					},
+				},
+			},
+		});
+
+		const monthKey = (date: Date) =>
+			`${date.getFullYear()}-${String(date.getMonth() + 1).padStart(2, "0")}`;
+		const trend = Array.from({ length: STATS_MONTHS }, (_, i) => ({
+			month: monthKey(
+				new Date(trendStart.getFullYear(), trendStart.getMonth() + i, 1),
+			),
+			meetings: 0,
+			hours: 0,
+		}));
+		const month = { meetings: 0, hours: 0, cancelled: 0 };
+		const rooms = new Map<
+			string,
+			{
+				roomId: string;
+				name: string;
+				location: string;
+				bookings: number;
+				hours: number;
+			}
+		>();
+
+		for (const booking of bookings) {
+			const thisMonth = booking.startTime >= monthStart;
+			if (booking.status === "CANCELLED") {
+				if (thisMonth) {
+					month.cancelled++;
+				}
+				continue;
+			}
+
+			const hours = bookingHours(booking.startTime, booking.endTime);
+			const bucket = trend.find(
+				(entry) => entry.month === monthKey(booking.startTime),
+			);
+			if (bucket) {
+				bucket.meetings++;
+				bucket.hours += hours;
+			}
+			if (thisMonth) {
+				month.meetings++;
+				month.hours += hours;
+			}
+
+			const room = rooms.get(booking.room.id) ?? {
+				roomId: booking.room.id,
+				name: booking.room.name,
+				location: booking.room.location.name,
+				bookings: 0,
+				hours: 0,
+			};
+			room.bookings++;
+			room.hours += hours;
+			rooms.set(booking.room.id, room);
+		}
+
+		const mostUsedRoom =
+			[...rooms.values()].sort(
+				(a, b) => b.bookings - a.bookings || b.hours - a.hours,
+			)[0] ?? null;
+
+		res.json({
+			month: {
+				startDate: monthStart,
+				endDate: monthEnd,
+				...month,
+				averageMinutes:
+					month.meetings > 0
+						? Math.round((month.hours * 60) / month.meetings)
+						: 0,
+			},
+			mostUsedRoom,
+			trend,
+		});
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to build booking stats" });
+	}
+};
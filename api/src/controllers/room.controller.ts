@@ -1,6 +1,15 @@
+import type { Prisma } from "@prisma/client";
 import type { Request, Response } from "express";
 import { z } from "zod";
+import { forbidden } from "../middleware/authorize";
+import { sendRoomAccessRequest } from "../utils/email";
 import prisma from "../utils/prisma";
+import {
+	canBookRoom,
+	isRestricted,
+	loadAccessUser,
+	type RoomAccessUser,
+} from "../utils/roomAccess";
 
 const createRoomSchema = z.object({
 	name: z.string().min(1),
@@ -8,14 +17,70 @@ const createRoomSchema = z.object({
 	capacity: z.number().int().positive(),
 	description: z.string().optional(),
 	amenities: z.array(z.string()).default([]),
+	type: z.enum(["ROOM", "DESK"]).optional(),
+	floor: z.string().min(1).optional(),
+	wing: z.string().min(1).optional(),
+	// Departments allowed to book; empty leaves the room open to everyone
+	departments: z.array(z.string().min(1)).default([]),
+	ownerId: z.string().min(1).optional(),
+	minDurationMinutes: z.number().int().positive().optional(),
+	maxDurationMinutes: z.number().int().positive().optional(),
 });
 
+const DURATION_LIMITS_ERROR =
+	"minDurationMinutes must not be more than maxDurationMinutes";
+
+// Whether a room's booking length limits leave any length to book
+const durationLimitsValid = (
+	min: number | null | undefined,
+	max: number | null | undefined,
+) => min == null || max == null || min <= max;
+
 const updateRoomSchema = z.object({
 	name: z.string().min(1).optional(),
 	capacity: z.number().int().positive().optional(),
 	description: z.string().optional(),
 	amenities: z.array(z.string()).optional(),
 	isActive: z.boolean().optional(),
+	type: z.enum(["ROOM", "DESK"]).optional(),
+	floor: z.string().min(1).nullable().optional(),
+	wing: z.string().min(1).nullable().optional(),
+	departments: z.array(z.string().min(1)).optional(),
+	ownerId: z.string().min(1).nullable().optional(),
+	// null lifts a limit
+	minDurationMinutes: z.number().int().positive().nullable().optional(),
+	maxDurationMinutes: z.number().int().positive().nullable().optional(),
+});
+
+const mergeRoomSchema = z.object({
+	targetRoomId: z.string().min(1),
+	dryRun: z.boolean().default(false),
+});
+
+// Longest note someone can add to an access request
+const MAX_ACCESS_REQUEST_MESSAGE = 500;
+
+const accessRequestSchema = z.object({
+	message: z.string().max(MAX_ACCESS_REQUEST_MESSAGE).optional(),
+});
+
+// Adds whether the room is restricted and whether the caller can book it,
+// so pickers can lock rooms before anyone tries
+const withAccess = <
+	T extends {
+		id: string;
+		name: string;
+		locationId: string;
+		departments: string[];
+		ownerId: string | null;
+	},
+>(
+	room: T,
+	user: RoomAccessUser | null,
+) => ({
+	...room,
+	restricted: isRestricted(room),
+	canBook: canBookRoom(user, room),
 });
 
 export const getAllRooms = async (
@@ -23,10 +88,23 @@ export const getAllRooms = async (
 	res: Response,
 ): Promise<void> => {
 	try {
-		const { locationId } = req.query;
+		const { locationId, type, floor } = req.query;
+
+		// Without ?type= rooms and desks are both listed, so a desk can be
+		// looked up like any room
+		const where: Prisma.RoomWhereInput = {};
+		if (locationId) {
+			where.locationId = locationId as string;
+		}
+		if (type === "ROOM" || type === "DESK") {
+			where.type = type;
+		}
+		if (floor) {
+			where.floor = { equals: floor as string, mode: "insensitive" };
+		}
 
 		const rooms = await prisma.room.findMany({
-			where: locationId ? { locationId: locationId as string } : undefined,
+			where,
 			include: {
 				location: {
 					select: {
@@ -40,10 +118,11 @@ export const getAllRooms = async (
 					select: { bookings: true },
 				},
 			},
-			orderBy: { name: "asc" },
+			orderBy: [{ floor: "asc" }, { name: "asc" }],
 		});
 
-		res.json({ rooms });
+		const user = req.user ? await loadAccessUser(req.user) : null;
+		res.json({ rooms: rooms.map((room) => withAccess(room, user)) });
 	} catch (_error) {
 		res.status(500).json({ error: "Failed to fetch rooms" });
 	}
@@ -68,7 +147,8 @@ export const getRoomById = async (
 			return;
 		}
 
-		res.json({ room });
+		const user = req.user ? await loadAccessUser(req.user) : null;
+		res.json({ room: withAccess(room, user) });
 	} catch (_error) {
 		res.status(500).json({ error: "Failed to fetch room" });
 	}
@@ -81,6 +161,13 @@ export const createRoom = async (
 	try {
 		const data = createRoomSchema.parse(req.body);
 
+		if (
+			!durationLimitsValid(data.minDurationMinutes, data.maxDurationMinutes)
+		) {
+			res.status(400).json({ error: DURATION_LIMITS_ERROR });
+			return;
+		}
+
 		// Verify location exists
 		const location = await prisma.location.findUnique({
 			where: { id: data.locationId },
@@ -103,9 +190,12 @@ export const createRoom = async (
 			});
 
 			if (!managerLocation) {
-				res
-					.status(403)
-					.json({ error: "Not authorized to create rooms in this location" });
+				forbidden(
+					res,
+					"Not authorized to create rooms in this location",
+					["ADMIN", "MANAGER"],
+					data.locationId,
+				);
 				return;
 			}
 		}
@@ -140,6 +230,30 @@ export const updateRoom = async (
 		const { id } = req.params;
 		const data = updateRoomSchema.parse(req.body);
 
+		// A limit left out of the request keeps its stored value
+		if (
+			data.minDurationMinutes !== undefined ||
+			data.maxDurationMinutes !== undefined
+		) {
+			const existing = await prisma.room.findUnique({ where: { id } });
+			if (!existing) {
+				res.status(404).json({ error: "Room not found" });
+				return;
+			}
+			const min =
+				data.minDurationMinutes === undefined
+					? existing.minDurationMinutes
+					: data.minDurationMinutes;
+			const max =
+				data.maxDurationMinutes === undefined
+					? existing.maxDurationMinutes
+					: data.maxDurationMinutes;
+			if (!durationLimitsValid(min, max)) {
+				res.status(400).json({ error: DURATION_LIMITS_ERROR });
+				return;
+			}
+		}
+
 		const room = await prisma.room.update({
 			where: { id },
 			data,
@@ -234,3 +348,224 @@ export const getRoomAvailability = async (
 		res.status(500).json({ error: "Failed to fetch room availability" });
 	}
 };
+
+type MergeBooking = { id: string; startTime: Date; endTime: Date };
+
+// Pair each source booking with the active target booking it would overlap.
+// Back-to-back bookings don't conflict.
+const findMergeConflicts = <T extends MergeBooking>(
+	sourceBookings: T[],
+	targetBookings: T[],
+): { booking: T; conflictsWith: T }[] => {
+	const conflicts: { booking: T; conflictsWith: T }[] = [];
+	for (const booking of sourceBookings) {
+		const clash = targetBookings.find(
+			(other) =>
+				booking.startTime < other.endTime && booking.endTime > other.startTime,
+		);
+		if (clash) {
+			conflicts.push({ booking, conflictsWith: clash });
+		}
+	}
+	return conflicts;
+};
+
+// Move every booking from one room into another and retire the source room.
+// Refuses to merge while any active booking would overlap one in the target.
+export const mergeRoom = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const { targetRoomId, dryRun } = mergeRoomSchema.parse(req.body);
+
+		if (id === targetRoomId) {
+			res.status(400).json({ error: "Cannot merge a room into itself" });
+			return;
+		}
+
+		const [source, target] = await Promise.all([
+			prisma.room.findUnique({ where: { id } }),
+			prisma.room.findUnique({ where: { id: targetRoomId } }),
+		]);
+
+		if (!source || !target) {
+			res.status(404).json({ error: "Room not found" });
+			return;
+		}
+
+		if (!target.isActive) {
+			res
+				.status(400)
+				.json({ error: "Cannot merge into a room that has been retired" });
+			return;
+		}
+
+		const include = {
+			user: {
+				select: {
+					id: true,
+					firstName: true,
+					lastName: true,
+				},
+			},
+		};
+
+		const merge = await prisma.$transaction(async (tx) => {
+			const bookings = await tx.booking.findMany({
+				where: { roomId: id },
+				include,
+				orderBy: { startTime: "asc" },
+			});
+			const active = bookings.filter((b) => b.status !== "CANCELLED");
+
+			const targetBookings = active.length
+				? await tx.booking.findMany({
+						where: {
+							roomId: targetRoomId,
+							status: { not: "CANCELLED" },
+							endTime: { gt: active[0].startTime },
+						},
+						include,
+						orderBy: { startTime: "asc" },
+					})
+				: [];
+
+			const conflicts = findMergeConflicts(active, targetBookings);
+			const result = {
+				sourceRoomId: id,
+				targetRoomId,
+				dryRun,
+				bookings,
+				conflicts,
+			};
+
+			if (dryRun || conflicts.length > 0) {
+				return result;
+			}
+
+			await tx.booking.updateMany({
+				where: { roomId: id },
+				data: { roomId: targetRoomId },
+			});
+			await tx.room.update({
+				where: { id },
+				data: { isActive: false },
+			});
+
+			return {
+				...result,
+				bookings: bookings.map((b) => ({ ...b, roomId: targetRoomId })),
+			};
+		});
+
+		if (!dryRun && merge.conflicts.length > 0) {
+			res.status(409).json({
+				error:
+					"Bookings in the source room overlap bookings in the target room",
+				merge,
+			});
+			return;
+		}
+
+		res.json({
+			message: dryRun ? "Merge preview" : "Rooms merged successfully",
+			merge,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to merge rooms" });
+	}
+};
+
+// Ask a restricted room's owner for access, or its location's managers
+// when nobody owns it
+export const requestRoomAccess = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const data = accessRequestSchema.parse(req.body);
+
+		const room = await prisma.room.findUnique({
+			where: { id },
+			include: {
+				location: true,
+				owner: { select: { email: true, firstName: true, lastName: true } },
+			},
+		});
+
+		if (!room) {
+			res.status(404).json({ error: "Room not found" });
+			return;
+		}
+
+		const user = req.user as NonNullable<Request["user"]>;
+		if (canBookRoom(await loadAccessUser(user), room)) {
+			res.status(400).json({ error: "You can already book this room" });
+			return;
+		}
+
+		const recipients = room.owner
+			? [room.owner]
+			: (
+					await prisma.managerLocation.findMany({
+						where: { locationId: room.locationId },
+						include: {
+							user: {
+								select: { email: true, firstName: true, lastName: true },
+							},
+						},
+					})
+				).map((m) => m.user);
+
+		if (recipients.length === 0) {
+			res
+				.status(409)
+				.json({ error: "Nobody owns or manages this room to ask for access" });
+			return;
+		}
+
+		const requester = await prisma.user.findUnique({
+			where: { id: user.userId },
+			select: { email: true, firstName: true, lastName: true },
+		});
+		if (!requester) {
+			res.status(404).json({ error: "User not found" });
+			return;
+		}
+
+		// Fire and forget - don't await
+		sendRoomAccessRequest(
+			room,
+			requester,
+			recipients,
+			data.message?.trim() || null,
+		).catch((err) => {
+			console.error("Failed to send access request:", err);
+		});
+
+		res.status(202).json({
+			message: "Access request sent",
+			contacted: recipients.map((r) => ({
+				name: `${r.firstName} ${r.lastName}`,
+				email: r.email,
+			})),
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to request access" });
+	}
+};
@@ -0,0 +1,172 @@
+import type { Request, Response } from "express";
+import { z } from "zod";
+import prisma from "../utils/prisma";
+
+// Categories group the directory and pick its icons in the clients
+const serviceCategories = [
+	"parking",
+	"lockers",
+	"bike-room",
+	"showers",
+	"reception",
+	"other",
+] as const;
+
+// An empty string clears an optional text field
+const optionalText = z
+	.string()
+	.nullable()
+	.optional()
+	.transform((value) => (value === "" ? null : value));
+
+const locationServiceSchema = z.object({
+	name: z.string().min(1),
+	category: z.enum(serviceCategories).optional(),
+	description: optionalText,
+	quantity: z.number().int().min(0).nullable().optional(),
+	contact: optionalText,
+	roomId: optionalText,
+});
+
+const updateLocationServiceSchema = locationServiceSchema.partial();
+
+// A reservable service is booked through one of the location's own rooms
+const isRoomAtLocation = async (
+	roomId: string | null | undefined,
+	locationId: string,
+): Promise<boolean> => {
+	if (!roomId) {
+		return true;
+	}
+	const room = await prisma.room.findFirst({
+		where: { id: roomId, locationId },
+	});
+	return room !== null;
+};
+
+export const getLocationServices = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		const location = await prisma.location.findUnique({
+			where: { id },
+			include: {
+				services: { orderBy: [{ category: "asc" }, { name: "asc" }] },
+			},
+		});
+
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		res.json({ services: location.services });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch location services" });
+	}
+};
+
+export const createLocationService = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const data = locationServiceSchema.parse(req.body);
+
+		const location = await prisma.location.findUnique({ where: { id } });
+		if (!location) {
+			res.status(404).json({ error: "Location not found" });
+			return;
+		}
+
+		if (!(await isRoomAtLocation(data.roomId, id))) {
+			res.status(400).json({ error: "Room is not at this location" });
+			return;
+		}
+
+		const service = await prisma.locationService.create({
+			data: { ...data, locationId: id },
+		});
+
+		res.status(201).json({
+			message: "Location service created successfully",
+			service,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to create location service" });
+	}
+};
+
+export const updateLocationService = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id, serviceId } = req.params;
+		const data = updateLocationServiceSchema.parse(req.body);
+
+		const existing = await prisma.locationService.findFirst({
+			where: { id: serviceId, locationId: id },
+		});
+
+		if (!existing) {
+			res.status(404).json({ error: "Location service not found" });
+			return;
+		}
+
+		if (!(await isRoomAtLocation(data.roomId, id))) {
+			res.status(400).json({ error: "Room is not at this location" });
+			return;
+		}
+
+		const service = await prisma.locationService.update({
+			where: { id: serviceId },
+			data,
+		});
+
+		res.json({
+			message: "Location service updated successfully",
+			service,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to update location service" });
+	}
+};
+
+export const deleteLocationService = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id, serviceId } = req.params;
+
+		const { count } = await prisma.locationService.deleteMany({
+			where: { id: serviceId, locationId: id },
+		});
+
+		if (count === 0) {
+			res.status(404).json({ error: "Location service not found" });
+			return;
+		}
+
+		res.json({ message: "Location service deleted successfully" });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to delete location service" });
+	}
+};
@@ -0,0 +1,232 @@
+import type { Request, Response } from "express";
+import { z } from "zod";
+import prisma from "../utils/prisma";
+
+const createSubscriptionSchema = z
+	.object({
+		roomId: z.string().min(1).optional(),
+		email: z.string().email().optional(),
+	})
+	.refine((data) => Boolean(data.roomId) !== Boolean(data.email), {
+		message: "Give either roomId or email",
+	});
+
+// How far back the activity feed reaches without ?since=
+const DEFAULT_ACTIVITY_DAYS = 7;
+
+// Most activity items returned at once, newest first
+const ACTIVITY_LIMIT = 100;
+
+const followedUserSelect = {
+	id: true,
+	email: true,
+	firstName: true,
+	lastName: true,
+};
+
+const subscriptionInclude = {
+	room: {
+		select: {
+			id: true,
+			name: true,
+			location: { select: { id: true, name: true } },
+		},
+	},
+	followedUser: { select: followedUserSelect },
+};
+
+export const getSubscriptions = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const subscriptions = await prisma.subscription.findMany({
+			where: { userId: req.user?.userId },
+			include: subscriptionInclude,
+			orderBy: { createdAt: "asc" },
+		});
+
+		res.json({ subscriptions });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch subscriptions" });
+	}
+};
+
+export const createSubscription = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const userId = req.user?.userId as string;
+		const data = createSubscriptionSchema.parse(req.body);
+
+		let roomId: string | undefined;
+		let followedUserId: string | undefined;
+
+		if (data.roomId) {
+			const room = await prisma.room.findUnique({
+				where: { id: data.roomId },
+			});
+			if (!room) {
+				res.status(404).json({ error: "Room not found" });
+				return;
+			}
+			roomId = room.id;
+		} else {
+			const user = await prisma.user.findFirst({
+				where: { email: { equals: data.email, mode: "insensitive" } },
+			});
+			if (!user) {
+				res.status(404).json({ error: "User not found" });
+				return;
+			}
+			if (user.id === userId) {
+				res.status(400).json({ error: "You can't follow yourself" });
+				return;
+			}
+			followedUserId = user.id;
+		}
+
+		const existing = await prisma.subscription.findFirst({
+			where: { userId, roomId, followedUserId },
+		});
+		if (existing) {
+			res.status(409).json({ error: "Already following" });
+			return;
+		}
+
+		const subscription = await prisma.subscription.create({
+			data: { userId, roomId, followedUserId },
+			include: subscriptionInclude,
+		});
+
+		res.status(201).json({
+			message: "Subscription created successfully",
+			subscription,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to create subscription" });
+	}
+};
+
+export const deleteSubscription = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		// Only your own subscriptions
+		const { count } = await prisma.subscription.deleteMany({
+			where: { id, userId: req.user?.userId },
+		});
+
+		if (count === 0) {
+			res.status(404).json({ error: "Subscription not found" });
+			return;
+		}
+
+		res.json({ message: "Subscription deleted successfully" });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to delete subscription" });
+	}
+};
+
+// New and cancelled bookings in followed rooms or by followed colleagues,
+// newest first. ?since= (a sync token or timestamp) returns only what
+// changed from then; without it the last week is returned. Your own
+// bookings are left out, as are descriptions.
+export const getActivity = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const userId = req.user?.userId as string;
+		const since = req.query.since
+			? new Date(String(req.query.since))
+			: new Date(Date.now() - DEFAULT_ACTIVITY_DAYS * 24 * 60 * 60 * 1000);
+		if (Number.isNaN(since.getTime())) {
+			res.status(400).json({ error: "Invalid since" });
+			return;
+		}
+
+		const subscriptions = await prisma.subscription.findMany({
+			where: { userId },
+		});
+		const roomIds = subscriptions.flatMap((s) =>
+			s.roomId ? [s.roomId] : [],
+		);
+		const userIds = subscriptions.flatMap((s) =>
+			s.followedUserId ? [s.followedUserId] : [],
+		);
+
+		if (roomIds.length === 0 && userIds.length === 0) {
+			res.json({ activity: [], syncToken: since.toISOString() });
+			return;
+		}
+
+		const bookings = await prisma.booking.findMany({
+			where: {
+				updatedAt: { gte: since },
+				userId: { not: userId },
+				OR: [{ roomId: { in: roomIds } }, { userId: { in: userIds } }],
+			},
+			select: {
+				id: true,
+				roomId: true,
+				userId: true,
+				title: true,
+				startTime: true,
+				endTime: true,
+				status: true,
+				createdAt: true,
+				updatedAt: true,
+				room: {
+					select: {
+						id: true,
+						name: true,
+						location: { select: { id: true, name: true } },
+					},
+				},
+				user: { select: followedUserSelect },
+			},
+			orderBy: { updatedAt: "desc" },
+			take: ACTIVITY_LIMIT,
+		});
+
+		// Only creations and cancellations are news; other edits are skipped
+		const activity = bookings.flatMap(
+			({ createdAt, updatedAt, ...booking }) => {
+				let type: string;
+				if (booking.status === "CANCELLED") {
+					type = "booking.cancelled";
+				} else if (createdAt >= since) {
+					type = "booking.created";
+				} else {
+					return [];
+				}
+				return [
+					{
+						type,
+						time: updatedAt,
+						reason: roomIds.includes(booking.roomId) ? "room" : "user",
+						booking,
+					},
+				];
+			},
+		);
+
+		// The next cursor is the newest change seen, whether or not it was news
+		const newest = bookings[0]?.updatedAt ?? since;
+
+		res.json({ activity, syncToken: newest.toISOString() });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch activity" });
+	}
+};
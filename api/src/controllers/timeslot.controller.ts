@@ -0,0 +1,146 @@
+import { Prisma } from "@prisma/client";
+import type { Request, Response } from "express";
+import { z } from "zod";
+import prisma from "../utils/prisma";
+
+// Slot names are typed on the command line (miles book --slot standup)
+const slotName = z
+	.string()
+	.trim()
+	.toLowerCase()
+	.regex(
+		/^[a-z0-9][a-z0-9-]*$/,
+		"Use lowercase letters, digits and dashes, like lunch-and-learn",
+	);
+
+const clockTime = z
+	.string()
+	.regex(/^([01]\d|2[0-3]):[0-5]\d$/, "Use 24-hour HH:MM, like 09:15");
+
+const timeSlotSchema = z.object({
+	name: slotName,
+	description: z.string().optional(),
+	startTime: clockTime,
+	endTime: clockTime,
+});
+
+const updateTimeSlotSchema = timeSlotSchema.partial();
+
+// HH:MM strings compare in time order, so a slot can't run past midnight
+const endsAfterStart = (slot: { startTime: string; endTime: string }) =>
+	slot.endTime > slot.startTime;
+
+// Slot names are unique; a clash is the caller's mistake
+const isNameTaken = (error: unknown): boolean =>
+	error instanceof Prisma.PrismaClientKnownRequestError &&
+	error.code === "P2002";
+
+export const getTimeSlots = async (
+	_req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const slots = await prisma.timeSlot.findMany({
+			orderBy: [{ startTime: "asc" }, { name: "asc" }],
+		});
+
+		res.json({ slots });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch time slots" });
+	}
+};
+
+export const createTimeSlot = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const data = timeSlotSchema.parse(req.body);
+		if (!endsAfterStart(data)) {
+			res.status(400).json({ error: "endTime must be after startTime" });
+			return;
+		}
+
+		const slot = await prisma.timeSlot.create({ data });
+
+		res.status(201).json({
+			message: "Time slot created successfully",
+			slot,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		if (isNameTaken(error)) {
+			res
+				.status(409)
+				.json({ error: "A time slot with this name already exists" });
+			return;
+		}
+		res.status(500).json({ error: "Failed to create time slot" });
+	}
+};
+
+export const updateTimeSlot = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const data = updateTimeSlotSchema.parse(req.body);
+
+		const existing = await prisma.timeSlot.findUnique({ where: { id } });
+		if (!existing) {
+			res.status(404).json({ error: "Time slot not found" });
+			return;
+		}
+		if (!endsAfterStart({ ...existing, ...data })) {
+			res.status(400).json({ error: "endTime must be after startTime" });
+			return;
+		}
+
+		const slot = await prisma.timeSlot.update({ where: { id }, data });
+
+		res.json({
+			message: "Time slot updated successfully",
+			slot,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		if (isNameTaken(error)) {
+			res
+				.status(409)
+				.json({ error: "A time slot with this name already exists" });
+			return;
+		}
+		res.status(500).json({ error: "Failed to update time slot" });
+	}
+};
+
+export const deleteTimeSlot = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		const { count } = await prisma.timeSlot.deleteMany({ where: { id } });
+
+		if (count === 0) {
+			res.status(404).json({ error: "Time slot not found" });
+			return;
+		}
+
+		res.json({ message: "Time slot deleted successfully" });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to delete time slot" });
+	}
+};
@@ -0,0 +1,120 @@
+import type { Request, Response } from "express";
+import { z } from "zod";
+import prisma from "../utils/prisma";
+import {
+	deliverWebhook,
+	generateWebhookSecret,
+	sampleBooking,
+	WEBHOOK_EVENTS,
+} from "../utils/webhook";
+
+const createWebhookSchema = z.object({
+	url: z
+		.string()
+		.url()
+		.refine((url) => /^https?:\/\//.test(url), "Use an http(s) URL"),
+	events: z.array(z.enum(WEBHOOK_EVENTS)).min(1),
+});
+
+// The secret is only shown when a webhook is created
+const webhookSelect = {
+	id: true,
+	url: true,
+	events: true,
+	lastStatus: true,
+	lastDeliveryAt: true,
+	createdAt: true,
+	updatedAt: true,
+};
+
+export const getWebhooks = async (
+	_req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const webhooks = await prisma.webhook.findMany({
+			select: webhookSelect,
+			orderBy: { createdAt: "asc" },
+		});
+
+		res.json({ webhooks });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch webhooks" });
+	}
+};
+
+export const createWebhook = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const data = createWebhookSchema.parse(req.body);
+
+		const webhook = await prisma.webhook.create({
+			data: {
+				url: data.url,
+				events: [...new Set(data.events)],
+				secret: generateWebhookSecret(),
+			},
+		});
+
+		res.status(201).json({
+			message: "Webhook created successfully",
+			webhook,
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		res.status(500).json({ error: "Failed to create webhook" });
+	}
+};
+
+export const deleteWebhook = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		const { count } = await prisma.webhook.deleteMany({ where: { id } });
+
+		if (count === 0) {
+			res.status(404).json({ error: "Webhook not found" });
+			return;
+		}
+
+		res.json({ message: "Webhook deleted successfully" });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to delete webhook" });
+	}
+};
+
+// Send a sample booking.created payload, marked as a test, and report how
+// the receiver answered
+export const testWebhook = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		const webhook = await prisma.webhook.findUnique({ where: { id } });
+		if (!webhook) {
+			res.status(404).json({ error: "Webhook not found" });
+			return;
+		}
+
+		const delivery = await deliverWebhook(webhook, "booking.created", {
+			test: true,
+			booking: sampleBooking(),
+		});
+
+		res.json({ delivery });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to test webhook" });
+	}
+};
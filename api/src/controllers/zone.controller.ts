@@ -0,0 +1,191 @@
+import { Prisma } from "@prisma/client";
+import type { Request, Response } from "express";
+import { z } from "zod";
+import prisma from "../utils/prisma";
+
+const zoneSchema = z.object({
+	name: z.string().trim().min(1),
+	description: z.string().optional(),
+	roomIds: z.array(z.string().min(1)).min(1),
+});
+
+const updateZoneSchema = zoneSchema.partial();
+
+const zoneInclude = {
+	rooms: {
+		include: {
+			room: {
+				select: {
+					id: true,
+					name: true,
+					capacity: true,
+					isActive: true,
+					location: { select: { id: true, name: true } },
+				},
+			},
+		},
+		orderBy: { room: { name: "asc" } },
+	},
+} satisfies Prisma.ZoneInclude;
+
+type ZoneWithRooms = Prisma.ZoneGetPayload<{ include: typeof zoneInclude }>;
+
+// A zone with its rooms listed directly rather than through the join table
+const zoneResponse = (zone: ZoneWithRooms) => ({
+	id: zone.id,
+	name: zone.name,
+	description: zone.description,
+	rooms: zone.rooms.map((zr) => zr.room),
+	createdAt: zone.createdAt,
+	updatedAt: zone.updatedAt,
+});
+
+// The IDs that aren't rooms, if any
+const unknownRoomIds = async (roomIds: string[]): Promise<string[]> => {
+	const rooms = await prisma.room.findMany({
+		where: { id: { in: roomIds } },
+		select: { id: true },
+	});
+	const found = new Set(rooms.map((room) => room.id));
+	return roomIds.filter((id) => !found.has(id));
+};
+
+// Zone names are unique; a clash is the caller's mistake
+const isNameTaken = (error: unknown): boolean =>
+	error instanceof Prisma.PrismaClientKnownRequestError &&
+	error.code === "P2002";
+
+export const getZones = async (_req: Request, res: Response): Promise<void> => {
+	try {
+		const zones = await prisma.zone.findMany({
+			include: zoneInclude,
+			orderBy: { name: "asc" },
+		});
+
+		res.json({ zones: zones.map(zoneResponse) });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to fetch zones" });
+	}
+};
+
+export const createZone = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const data = zoneSchema.parse(req.body);
+		const roomIds = [...new Set(data.roomIds)];
+
+		const unknown = await unknownRoomIds(roomIds);
+		if (unknown.length > 0) {
+			res.status(400).json({ error: `Unknown rooms: ${unknown.join(", ")}` });
+			return;
+		}
+
+		const zone = await prisma.zone.create({
+			data: {
+				name: data.name,
+				description: data.description,
+				rooms: { create: roomIds.map((roomId) => ({ roomId })) },
+			},
+			include: zoneInclude,
+		});
+
+		res.status(201).json({
+			message: "Zone created successfully",
+			zone: zoneResponse(zone),
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		if (isNameTaken(error)) {
+			res.status(409).json({ error: "A zone with this name already exists" });
+			return;
+		}
+		res.status(500).json({ error: "Failed to create zone" });
+	}
+};
+
+// Change a zone. roomIds, when given, replaces the zone's rooms.
+export const updateZone = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+		const data = updateZoneSchema.parse(req.body);
+
+		const existing = await prisma.zone.findUnique({ where: { id } });
+		if (!existing) {
+			res.status(404).json({ error: "Zone not found" });
+			return;
+		}
+
+		const roomIds = data.roomIds ? [...new Set(data.roomIds)] : undefined;
+		if (roomIds) {
+			const unknown = await unknownRoomIds(roomIds);
+			if (unknown.length > 0) {
+				res
+					.status(400)
+					.json({ error: `Unknown rooms: ${unknown.join(", ")}` });
+				return;
+			}
+		}
+
+		const zone = await prisma.zone.update({
+			where: { id },
+			data: {
+				name: data.name,
+				description: data.description,
+				rooms: roomIds
+					? {
+							deleteMany: {},
+							create: roomIds.map((roomId) => ({ roomId })),
+						}
+					: undefined,
+			},
+			include: zoneInclude,
+		});
+
+		res.json({
+			message: "Zone updated successfully",
+			zone: zoneResponse(zone),
+		});
+	} catch (error) {
+		if (error instanceof z.ZodError) {
+			res
+				.status(400)
+				.json({ error: "Validation error", details: error.errors });
+			return;
+		}
+		if (isNameTaken(error)) {
+			res.status(409).json({ error: "A zone with this name already exists" });
+			return;
+		}
+		res.status(500).json({ error: "Failed to update zone" });
+	}
+};
+
+export const deleteZone = async (
+	req: Request,
+	res: Response,
+): Promise<void> => {
+	try {
+		const { id } = req.params;
+
+		const { count } = await prisma.zone.deleteMany({ where: { id } });
+
+		if (count === 0) {
+			res.status(404).json({ error: "Zone not found" });
+			return;
+		}
+
+		res.json({ message: "Zone deleted successfully" });
+	} catch (_error) {
+		res.status(500).json({ error: "Failed to delete zone" });
+	}
+};
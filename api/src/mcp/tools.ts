@@ -9,7 +9,15 @@ import {
 	sendFeedbackNotification,
 	sendFeedbackStatusUpdate,
 } from "../utils/email.js";
+import { decideApproval } from "../utils/approval.js";
+import {
+	checkLateCancellation,
+	lateCancellationWarning,
+} from "../utils/lateCancel.js";
+import { durationLimitMessage } from "../utils/bookingLength.js";
+import { closureMessage, findClosure } from "../utils/holidays.js";
 import prisma from "../utils/prisma.js";
+import { canBookRoom, loadAccessUser } from "../utils/roomAccess.js";
 
 // Tool schemas
 const createBookingSchema = z.object({
@@ -73,12 +81,13 @@ const createRoomFeedbackSchema = z.object({
 	userId: z.string(),
 	roomId: z.string(),
 	message: z.string(),
+	severity: z.enum(["LOW", "MEDIUM", "HIGH"]).optional(),
 });
 
 const updateFeedbackStatusSchema = z.object({
 	feedbackId: z.string(),
 	userId: z.string(),
-	status: z.enum(["OPEN", "RESOLVED", "DISMISSED"]),
+	status: z.enum(["OPEN", "IN_PROGRESS", "RESOLVED", "DISMISSED"]),
 	comment: z.string().min(1, "Resolution comment is required"),
 });
 
@@ -331,6 +340,12 @@ export function registerTools() {
 						description:
 							"Free-form feedback message describing the issue or suggestion",
 					},
+					severity: {
+						type: "string",
+						enum: ["LOW", "MEDIUM", "HIGH"],
+						description:
+							"How badly the issue gets in the way of using the room (default MEDIUM)",
+					},
 				},
 				required: ["userId", "roomId", "message"],
 			},
@@ -338,7 +353,7 @@ export function registerTools() {
 		{
 			name: "update_feedback_status",
 			description:
-				"Update the status of room feedback (OPEN, RESOLVED, DISMISSED). Anyone can update feedback status. A comment explaining the resolution is required.",
+				"Update the status of room feedback (OPEN, IN_PROGRESS, RESOLVED, DISMISSED). Anyone can update feedback status. A comment explaining the resolution is required.",
 			inputSchema: {
 				type: "object",
 				properties: {
@@ -352,7 +367,7 @@ export function registerTools() {
 					},
 					status: {
 						type: "string",
-						enum: ["OPEN", "RESOLVED", "DISMISSED"],
+						enum: ["OPEN", "IN_PROGRESS", "RESOLVED", "DISMISSED"],
 						description: "New status for the feedback",
 					},
 					comment: {
@@ -427,6 +442,26 @@ async function createBooking(args: unknown) {
 		};
 	}
 
+	if (
+		!canBookRoom(
+			await loadAccessUser({ userId: user.id, role: user.role }),
+			room,
+		)
+	) {
+		return {
+			content: [
+				{
+					type: "text",
+					text: JSON.stringify({
+						error: `${room.name} is restricted to ${room.departments.join(", ")}`,
+						roomId: room.id,
+						departments: room.departments,
+					}),
+				},
+			],
+		};
+	}
+
 	const startTime = new Date(data.startTime);
 	const endTime = new Date(data.endTime);
 
@@ -442,6 +477,30 @@ async function createBooking(args: unknown) {
 		};
 	}
 
+	const lengthError = durationLimitMessage(room, startTime, endTime);
+	if (lengthError) {
+		return {
+			content: [
+				{
+					type: "text",
+					text: JSON.stringify({ error: lengthError }),
+				},
+			],
+		};
+	}
+
+	const closure = await findClosure(data.roomId, startTime, endTime);
+	if (closure) {
+		return {
+			content: [
+				{
+					type: "text",
+					text: JSON.stringify({ error: closureMessage(closure), closure }),
+				},
+			],
+		};
+	}
+
 	// Check for conflicts
 	const conflict = await prisma.booking.findFirst({
 		where: {
@@ -485,6 +544,9 @@ async function createBooking(args: unknown) {
 		};
 	}
 
+	// Locations that require approval hold bookings no rule covers
+	const approval = await decideApproval(data.roomId, startTime, endTime);
+
 	// Create booking
 	const booking = await prisma.booking.create({
 		data: {
@@ -494,7 +556,7 @@ async function createBooking(args: unknown) {
 			endTime,
 			title: data.title,
 			description: data.description,
-			status: BookingStatus.CONFIRMED,
+			status: approval.status,
 		},
 		include: {
 			room: {
@@ -599,6 +661,18 @@ async function updateBooking(args: unknown) {
 			: booking.startTime;
 		const endTime = data.endTime ? new Date(data.endTime) : booking.endTime;
 
+		const lengthError = durationLimitMessage(booking.room, startTime, endTime);
+		if (lengthError) {
+			return {
+				content: [
+					{
+						type: "text",
+						text: JSON.stringify({ error: lengthError }),
+					},
+				],
+			};
+		}
+
 		const conflict = await prisma.booking.findFirst({
 			where: {
 				id: { not: data.bookingId },
@@ -752,10 +826,14 @@ async function cancelBooking(args: unknown) {
 		};
 	}
 
-	// Cancel booking
+	// Cancel booking, noting whether it was late under the location's policy
+	const late = await checkLateCancellation(booking, data.userId);
 	const cancelledBooking = await prisma.booking.update({
 		where: { id: data.bookingId },
-		data: { status: BookingStatus.CANCELLED },
+		data: {
+			status: BookingStatus.CANCELLED,
+			lateCancellation: late ? true : undefined,
+		},
 		include: {
 			room: {
 				include: {
@@ -780,6 +858,7 @@ async function cancelBooking(args: unknown) {
 				text: JSON.stringify({
 					success: true,
 					booking: cancelledBooking,
+					warning: late ? lateCancellationWarning(late) : undefined,
 				}),
 			},
 		],
@@ -1233,6 +1312,7 @@ async function createRoomFeedback(args: unknown) {
 			userId: data.userId,
 			roomId: data.roomId,
 			message: data.message,
+			severity: data.severity,
 			status: FeedbackStatus.OPEN,
 		},
 		include: {
@@ -1273,6 +1353,7 @@ async function createRoomFeedback(args: unknown) {
 						roomName: feedback.room.name,
 						location: feedback.room.location.name,
 						message: feedback.message,
+						severity: feedback.severity,
 						status: feedback.status,
 						submittedBy: `${feedback.user.firstName} ${feedback.user.lastName}`,
 						submittedAt: feedback.createdAt,
@@ -1,11 +1,16 @@
 import type { NextFunction, Request, Response } from "express";
 import { verifyToken } from "../utils/jwt";
+import prisma from "../utils/prisma";
 
-export const authenticate = (
+// Header an ADMIN sets to act as another user, e.g. for debugging complaints
+export const IMPERSONATE_HEADER = "x-impersonate-user";
+
+export const authenticate = async (
 	req: Request,
 	res: Response,
 	next: NextFunction,
-): void => {
+): Promise<void> => {
+	let payload: ReturnType<typeof verifyToken>;
 	try {
 		const authHeader = req.headers.authorization;
 
@@ -17,11 +22,63 @@ export const authenticate = (
 		}
 
 		const token = authHeader.substring(7);
-		const payload = verifyToken(token);
+		payload = verifyToken(token);
+	} catch (_error) {
+		res.status(401).json({ error: "Invalid or expired token" });
+		return;
+	}
 
+	const impersonate = req.header(IMPERSONATE_HEADER)?.trim();
+	if (!impersonate) {
 		req.user = payload;
 		next();
+		return;
+	}
+
+	if (payload.role !== "ADMIN") {
+		res.status(403).json({ error: "Only admins can impersonate users" });
+		return;
+	}
+
+	try {
+		const target = await prisma.user.findUnique({
+			where: { email: impersonate },
+			select: { id: true, email: true, role: true },
+		});
+
+		if (!target) {
+			res.status(404).json({ error: `User ${impersonate} not found` });
+			return;
+		}
+
+		req.user = { userId: target.id, email: target.email, role: target.role };
+		req.impersonator = payload;
+
+		// Audit log: record both the real and the effective identity
+		console.log(
+			`[audit] impersonation admin=${payload.email} (${payload.userId}) as=${target.email} (${target.id}) ${req.method} ${req.originalUrl}`,
+		);
+
+		next();
 	} catch (_error) {
-		res.status(401).json({ error: "Invalid or expired token" });
+		res.status(500).json({ error: "Impersonation lookup failed" });
+	}
+};
+
+// For public routes that answer signed-in users differently: sets req.user
+// when a valid token comes along and carries on as a guest otherwise
+export const identify = (
+	req: Request,
+	_res: Response,
+	next: NextFunction,
+): void => {
+	const authHeader = req.headers.authorization;
+	if (authHeader?.startsWith("Bearer ")) {
+		try {
+			req.user = verifyToken(authHeader.substring(7));
+		} catch (_error) {
+			// An expired token still gets the public answer
+		}
 	}
+	next();
 };
@@ -2,6 +2,20 @@ import type { Role } from "@prisma/client";
 import type { NextFunction, Request, Response } from "express";
 import prisma from "../utils/prisma";
 
+/**
+ * Refuse a request, saying which roles may make it and, for permissions
+ * scoped to a location, which one. Clients use these to tell people who
+ * can grant them access.
+ */
+export const forbidden = (
+	res: Response,
+	error: string,
+	requiredRoles: Role[],
+	locationId?: string,
+): void => {
+	res.status(403).json({ error, requiredRoles, locationId });
+};
+
 export const authorize = (...allowedRoles: Role[]) => {
 	return (req: Request, res: Response, next: NextFunction): void => {
 		if (!req.user) {
@@ -10,7 +24,7 @@ export const authorize = (...allowedRoles: Role[]) => {
 		}
 
 		if (!allowedRoles.includes(req.user.role)) {
-			res.status(403).json({ error: "Insufficient permissions" });
+			forbidden(res, "Insufficient permissions", allowedRoles);
 			return;
 		}
 
@@ -36,11 +50,11 @@ export const authorizeLocationManager = async (
 			return;
 		}
 
+		const locationId =
+			req.params.id || req.params.locationId || req.body.locationId;
+
 		// Check if user is a manager of this location
 		if (req.user.role === "MANAGER") {
-			const locationId =
-				req.params.id || req.params.locationId || req.body.locationId;
-
 			if (!locationId) {
 				res.status(400).json({ error: "Location ID required" });
 				return;
@@ -56,9 +70,12 @@ export const authorizeLocationManager = async (
 			});
 
 			if (!managerLocation) {
-				res
-					.status(403)
-					.json({ error: "Not authorized to manage this location" });
+				forbidden(
+					res,
+					"Not authorized to manage this location",
+					["ADMIN", "MANAGER"],
+					locationId,
+				);
 				return;
 			}
 
@@ -66,7 +83,12 @@ export const authorizeLocationManager = async (
 			return;
 		}
 
-		res.status(403).json({ error: "Insufficient permissions" });
+		forbidden(
+			res,
+			"Insufficient permissions",
+			["ADMIN", "MANAGER"],
+			locationId,
+		);
 	} catch (_error) {
 		res.status(500).json({ error: "Authorization check failed" });
 	}
@@ -119,7 +141,12 @@ export const authorizeRoomManager = async (
 			});
 
 			if (!managerLocation) {
-				res.status(403).json({ error: "Not authorized to manage this room" });
+				forbidden(
+					res,
+					"Not authorized to manage this room",
+					["ADMIN", "MANAGER"],
+					room.locationId,
+				);
 				return;
 			}
 
@@ -127,7 +154,7 @@ export const authorizeRoomManager = async (
 			return;
 		}
 
-		res.status(403).json({ error: "Insufficient permissions" });
+		forbidden(res, "Insufficient permissions", ["ADMIN", "MANAGER"]);
 	} catch (_error) {
 		res.status(500).json({ error: "Authorization check failed" });
 	}
@@ -0,0 +1,9 @@
+import { Router } from "express";
+import { getAnnouncements } from "../controllers/announcement.controller";
+import { authenticate } from "../middleware/auth";
+
+const router = Router();
+
+router.get("/", authenticate, getAnnouncements);
+
+export default router;
@@ -1,11 +1,19 @@
 import { Router } from "express";
 import {
+	bumpBooking,
+	cancelBookingGroup,
 	createBooking,
 	deleteBooking,
 	getAllBookings,
 	getBookingById,
+	getBusyTimes,
+	getMyQuota,
 	updateBooking,
 } from "../controllers/booking.controller";
+import {
+	createBookingComment,
+	getBookingComments,
+} from "../controllers/comment.controller";
 import { authenticate } from "../middleware/auth";
 
 const router = Router();
@@ -14,9 +22,15 @@ const router = Router();
 router.use(authenticate);
 
 router.get("/", getAllBookings);
+router.get("/quota", getMyQuota);
+router.get("/busy", getBusyTimes);
 router.get("/:id", getBookingById);
 router.post("/", createBooking);
 router.patch("/:id", updateBooking);
 router.delete("/:id", deleteBooking);
+router.post("/:id/bump", bumpBooking);
+router.delete("/groups/:groupId", cancelBookingGroup);
+router.get("/:id/comments", getBookingComments);
+router.post("/:id/comments", createBookingComment);
 
 export default router;
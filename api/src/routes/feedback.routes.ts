@@ -2,6 +2,7 @@ import { Router } from "express";
 import {
 	createFeedback,
 	getAllFeedback,
+	getMyFeedback,
 	getRoomFeedback,
 	updateFeedbackStatus,
 } from "../controllers/feedback.controller";
@@ -11,6 +12,7 @@ const router = Router();
 
 // All feedback routes require authentication
 router.get("/", authenticate, getAllFeedback);
+router.get("/mine", authenticate, getMyFeedback);
 router.get("/room/:roomId", authenticate, getRoomFeedback);
 router.post("/", authenticate, createFeedback);
 router.patch("/:id/status", authenticate, updateFeedbackStatus);
@@ -1,13 +1,39 @@
 import { Router } from "express";
+import {
+	createApprovalRule,
+	deleteApprovalRule,
+	getApprovalRules,
+	updateApprovalRule,
+} from "../controllers/approval.controller";
+import { getLocationFeedback } from "../controllers/feedback.controller";
+import {
+	createLocationHoliday,
+	deleteLocationHoliday,
+	getLocationHolidays,
+} from "../controllers/holiday.controller";
 import {
 	assignManager,
 	createLocation,
 	deleteLocation,
 	getAllLocations,
 	getLocationById,
+	getLocationManagers,
 	removeManager,
 	updateLocation,
 } from "../controllers/location.controller";
+import {
+	createLocationService,
+	deleteLocationService,
+	getLocationServices,
+	updateLocationService,
+} from "../controllers/service.controller";
+import {
+	createPriorityRule,
+	deletePriorityRule,
+	getDisplacements,
+	getPriorityRules,
+	updatePriorityRule,
+} from "../controllers/priority.controller";
 import { authenticate } from "../middleware/auth";
 import { authorize, authorizeLocationManager } from "../middleware/authorize";
 
@@ -24,6 +50,112 @@ router.delete("/:id", authenticate, authorize("ADMIN"), deleteLocation);
 // Admin or Manager of location routes
 router.patch("/:id", authenticate, authorizeLocationManager, updateLocation);
 
+// Auto-approval rules (Admin or Manager of location)
+router.get(
+	"/:id/approval-rules",
+	authenticate,
+	authorizeLocationManager,
+	getApprovalRules,
+);
+router.post(
+	"/:id/approval-rules",
+	authenticate,
+	authorizeLocationManager,
+	createApprovalRule,
+);
+router.patch(
+	"/:id/approval-rules/:ruleId",
+	authenticate,
+	authorizeLocationManager,
+	updateApprovalRule,
+);
+router.delete(
+	"/:id/approval-rules/:ruleId",
+	authenticate,
+	authorizeLocationManager,
+	deleteApprovalRule,
+);
+
+// Priority rules: who may bump bookings here, and the audit log of bumps
+// (Admin or Manager of location)
+router.get(
+	"/:id/priority-rules",
+	authenticate,
+	authorizeLocationManager,
+	getPriorityRules,
+);
+router.post(
+	"/:id/priority-rules",
+	authenticate,
+	authorizeLocationManager,
+	createPriorityRule,
+);
+router.patch(
+	"/:id/priority-rules/:ruleId",
+	authenticate,
+	authorizeLocationManager,
+	updatePriorityRule,
+);
+router.delete(
+	"/:id/priority-rules/:ruleId",
+	authenticate,
+	authorizeLocationManager,
+	deletePriorityRule,
+);
+router.get(
+	"/:id/displacements",
+	authenticate,
+	authorizeLocationManager,
+	getDisplacements,
+);
+
+// Services directory, public like the location itself
+router.get("/:id/services", getLocationServices);
+router.post(
+	"/:id/services",
+	authenticate,
+	authorizeLocationManager,
+	createLocationService,
+);
+router.patch(
+	"/:id/services/:serviceId",
+	authenticate,
+	authorizeLocationManager,
+	updateLocationService,
+);
+router.delete(
+	"/:id/services/:serviceId",
+	authenticate,
+	authorizeLocationManager,
+	deleteLocationService,
+);
+
+// Holidays and other closed days, public so every client can grey them out
+router.get("/:id/holidays", getLocationHolidays);
+router.post(
+	"/:id/holidays",
+	authenticate,
+	authorizeLocationManager,
+	createLocationHoliday,
+);
+router.delete(
+	"/:id/holidays/:holidayId",
+	authenticate,
+	authorizeLocationManager,
+	deleteLocationHoliday,
+);
+
+// Issues reported in the location's rooms (Admin or Manager of location)
+router.get(
+	"/:id/feedback",
+	authenticate,
+	authorizeLocationManager,
+	getLocationFeedback,
+);
+
+// Managers, listed for anyone who needs to ask them for access
+router.get("/:id/managers", authenticate, getLocationManagers);
+
 // Manager assignment (Admin only)
 router.post("/:id/managers", authenticate, authorize("ADMIN"), assignManager);
 router.delete(
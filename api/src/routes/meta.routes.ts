@@ -0,0 +1,10 @@
+import { Router } from "express";
+import { getFeatures, getVersion } from "../controllers/meta.controller";
+
+const router = Router();
+
+// Public, so clients can adapt before anyone signs in
+router.get("/features", getFeatures);
+router.get("/version", getVersion);
+
+export default router;
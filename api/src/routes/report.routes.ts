@@ -0,0 +1,19 @@
+import { Router } from "express";
+import {
+	getMyStats,
+	getUtilizationReport,
+} from "../controllers/report.controller";
+import { authenticate } from "../middleware/auth";
+import { authorize } from "../middleware/authorize";
+
+const router = Router();
+
+// Everyone signed in gets their own stats
+router.get("/me", authenticate, getMyStats);
+
+// Other reports are for admins and managers
+router.use(authenticate, authorize("ADMIN", "MANAGER"));
+
+router.get("/utilization", getUtilizationReport);
+
+export default router;
@@ -5,21 +5,29 @@ import {
 	getAllRooms,
 	getRoomAvailability,
 	getRoomById,
+	mergeRoom,
+	requestRoomAccess,
 	updateRoom,
 } from "../controllers/room.controller";
-import { authenticate } from "../middleware/auth";
+import { authenticate, identify } from "../middleware/auth";
 import { authorize, authorizeRoomManager } from "../middleware/authorize";
 
 const router = Router();
 
 // Public routes
-router.get("/", getAllRooms);
-router.get("/:id", getRoomById);
+router.get("/", identify, getAllRooms);
+router.get("/:id", identify, getRoomById);
 router.get("/:id/availability", getRoomAvailability);
 
+// Authenticated routes
+router.post("/:id/access-requests", authenticate, requestRoomAccess);
+
 // Admin or Manager routes
 router.post("/", authenticate, authorize("ADMIN", "MANAGER"), createRoom);
 router.patch("/:id", authenticate, authorizeRoomManager, updateRoom);
 router.delete("/:id", authenticate, authorizeRoomManager, deleteRoom);
 
+// Admin routes
+router.post("/:id/merge", authenticate, authorize("ADMIN"), mergeRoom);
+
 export default router;
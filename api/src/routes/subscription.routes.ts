@@ -0,0 +1,20 @@
+import { Router } from "express";
+import {
+	createSubscription,
+	deleteSubscription,
+	getActivity,
+	getSubscriptions,
+} from "../controllers/subscription.controller";
+import { authenticate } from "../middleware/auth";
+
+const router = Router();
+
+// All routes require authentication and act on your own subscriptions
+router.use(authenticate);
+
+router.get("/", getSubscriptions);
+router.get("/activity", getActivity);
+router.post("/", createSubscription);
+router.delete("/:id", deleteSubscription);
+
+export default router;
@@ -0,0 +1,23 @@
+import { Router } from "express";
+import {
+	createTimeSlot,
+	deleteTimeSlot,
+	getTimeSlots,
+	updateTimeSlot,
+} from "../controllers/timeslot.controller";
+import { authenticate } from "../middleware/auth";
+import { authorize } from "../middleware/authorize";
+
+const router = Router();
+
+// All routes require authentication
+router.use(authenticate);
+
+router.get("/", getTimeSlots);
+
+// Admin-only routes
+router.post("/", authorize("ADMIN"), createTimeSlot);
+router.patch("/:id", authorize("ADMIN"), updateTimeSlot);
+router.delete("/:id", authorize("ADMIN"), deleteTimeSlot);
+
+export default router;
@@ -0,0 +1,21 @@
+import { Router } from "express";
+import {
+	createWebhook,
+	deleteWebhook,
+	getWebhooks,
+	testWebhook,
+} from "../controllers/webhook.controller";
+import { authenticate } from "../middleware/auth";
+import { authorize } from "../middleware/authorize";
+
+const router = Router();
+
+// Webhooks see every booking, so only admins manage them
+router.use(authenticate, authorize("ADMIN"));
+
+router.get("/", getWebhooks);
+router.post("/", createWebhook);
+router.delete("/:id", deleteWebhook);
+router.post("/:id/test", testWebhook);
+
+export default router;
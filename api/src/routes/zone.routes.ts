@@ -0,0 +1,31 @@
+import { Router } from "express";
+import { createZoneBooking } from "../controllers/booking.controller";
+import {
+	createZone,
+	deleteZone,
+	getZones,
+	updateZone,
+} from "../controllers/zone.controller";
+import { authenticate } from "../middleware/auth";
+import { authorize } from "../middleware/authorize";
+
+const router = Router();
+
+// All routes require authentication
+router.use(authenticate);
+
+router.get("/", getZones);
+
+// Booking a whole zone for an event (Admin or Manager of its locations)
+router.post(
+	"/:id/bookings",
+	authorize("ADMIN", "MANAGER"),
+	createZoneBooking,
+);
+
+// Admin-only routes
+router.post("/", authorize("ADMIN"), createZone);
+router.patch("/:id", authorize("ADMIN"), updateZone);
+router.delete("/:id", authorize("ADMIN"), deleteZone);
+
+export default router;
@@ -8,6 +8,12 @@ declare global {
 				email: string;
 				role: Role;
 			};
+			// Set when an ADMIN is impersonating req.user
+			impersonator?: {
+				userId: string;
+				email: string;
+				role: Role;
+			};
 		}
 	}
 }
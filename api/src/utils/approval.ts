@@ -0,0 +1,156 @@
+import type { ApprovalRule, BookingStatus } from "@prisma/client";
+import prisma from "./prisma";
+
+export interface ApprovalDecision {
+	status: BookingStatus;
+	// Name of the rule that confirmed the booking, if one did
+	rule?: string;
+}
+
+// Parses "HH:MM" into minutes after midnight
+export const parseClock = (clock: string): number | null => {
+	const match = /^([01]\d|2[0-3]):([0-5]\d)$/.exec(clock);
+	if (!match) {
+		return null;
+	}
+	return Number(match[1]) * 60 + Number(match[2]);
+};
+
+// Weekday (0 = Sunday) and minutes after midnight of a time in a time zone
+const localClock = (
+	at: Date,
+	timeZone: string,
+): { weekday: number; minutes: number } => {
+	const parts = new Intl.DateTimeFormat("en-US", {
+		timeZone,
+		weekday: "short",
+		hour: "2-digit",
+		minute: "2-digit",
+		hourCycle: "h23",
+	}).formatToParts(at);
+	const part = (type: string) =>
+		parts.find((p) => p.type === type)?.value ?? "";
+
+	const weekdays = ["Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"];
+	return {
+		weekday: weekdays.indexOf(part("weekday")),
+		minutes: Number(part("hour")) * 60 + Number(part("minute")),
+	};
+};
+
+// Whether a booking touches core hours on a weekday in the location's time
+// zone. Bookings longer than a day always do.
+const touchesCoreHours = (
+	rule: ApprovalRule,
+	startTime: Date,
+	endTime: Date,
+	timeZone: string,
+): boolean => {
+	const coreStart = parseClock(rule.coreStart) ?? 0;
+	const coreEnd = parseClock(rule.coreEnd) ?? 24 * 60;
+	const duration = Math.ceil(
+		(endTime.getTime() - startTime.getTime()) / 60_000,
+	);
+	if (duration > 24 * 60) {
+		return true;
+	}
+
+	const start = localClock(startTime, timeZone);
+	for (let minute = 0; minute < duration; minute++) {
+		const clock = start.minutes + minute;
+		const weekday = (start.weekday + Math.floor(clock / (24 * 60))) % 7;
+		const time = clock % (24 * 60);
+		if (weekday >= 1 && weekday <= 5 && time >= coreStart && time < coreEnd) {
+			return true;
+		}
+	}
+	return false;
+};
+
+// Whether a booking meets every condition of a rule
+export const ruleMatches = (
+	rule: ApprovalRule,
+	startTime: Date,
+	endTime: Date,
+	timeZone: string,
+): boolean => {
+	if (!rule.enabled) {
+		return false;
+	}
+	const minutes = (endTime.getTime() - startTime.getTime()) / 60_000;
+	if (rule.maxDurationMinutes !== null && minutes > rule.maxDurationMinutes) {
+		return false;
+	}
+	if (
+		rule.outsideCoreHours &&
+		touchesCoreHours(rule, startTime, endTime, timeZone)
+	) {
+		return false;
+	}
+	return true;
+};
+
+// Decides whether a booking in a room is confirmed straight away or waits
+// for a manager. Only locations that require approval hold bookings back,
+// and their enabled rules let routine bookings through.
+export const decideApproval = async (
+	roomId: string,
+	startTime: Date,
+	endTime: Date,
+): Promise<ApprovalDecision> => {
+	const room = await prisma.room.findUnique({
+		where: { id: roomId },
+		select: {
+			location: { include: { approvalRules: { where: { enabled: true } } } },
+		},
+	});
+	const location = room?.location;
+	if (!location?.requiresApproval) {
+		return { status: "CONFIRMED" };
+	}
+
+	const rule = location.approvalRules.find((r) =>
+		ruleMatches(r, startTime, endTime, location.timezone),
+	);
+	return rule
+		? { status: "CONFIRMED", rule: rule.name }
+		: { status: "PENDING" };
+};
+
+// Confirms the upcoming pending bookings at a location that its rules now
+// cover, so the approval queue only holds what needs a person. Returns how
+// many were confirmed.
+export const applyApprovalRules = async (
+	locationId: string,
+): Promise<number> => {
+	const location = await prisma.location.findUnique({
+		where: { id: locationId },
+		include: { approvalRules: { where: { enabled: true } } },
+	});
+	if (!location || location.approvalRules.length === 0) {
+		return 0;
+	}
+
+	const pending = await prisma.booking.findMany({
+		where: {
+			status: "PENDING",
+			startTime: { gte: new Date() },
+			room: { locationId },
+		},
+		select: { id: true, startTime: true, endTime: true },
+	});
+	const covered = pending.filter((booking) =>
+		location.approvalRules.some((rule) =>
+			ruleMatches(rule, booking.startTime, booking.endTime, location.timezone),
+		),
+	);
+	if (covered.length === 0) {
+		return 0;
+	}
+
+	const { count } = await prisma.booking.updateMany({
+		where: { id: { in: covered.map((booking) => booking.id) } },
+		data: { status: "CONFIRMED" },
+	});
+	return count;
+};
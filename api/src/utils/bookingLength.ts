@@ -0,0 +1,25 @@
+// How long a room lets a booking be; null means no limit
+interface RoomDurationLimits {
+	name: string;
+	minDurationMinutes: number | null;
+	maxDurationMinutes: number | null;
+}
+
+/**
+ * Why a booking from startTime to endTime is too short or too long for the
+ * room, or null when the room takes a booking that long
+ */
+export const durationLimitMessage = (
+	room: RoomDurationLimits,
+	startTime: Date,
+	endTime: Date,
+): string | null => {
+	const minutes = (endTime.getTime() - startTime.getTime()) / 60_000;
+	if (room.minDurationMinutes !== null && minutes < room.minDurationMinutes) {
+		return `${room.name} takes bookings of at least ${room.minDurationMinutes} minutes`;
+	}
+	if (room.maxDurationMinutes !== null && minutes > room.maxDurationMinutes) {
+		return `${room.name} takes bookings of at most ${room.maxDurationMinutes} minutes`;
+	}
+	return null;
+};
@@ -60,6 +60,7 @@ export async function sendFeedbackNotification(
 			`   Room: ${feedback.room.name} at ${feedback.room.location.name}`,
 		);
 		console.log(`   Message: ${feedback.message}`);
+		console.log(`   Severity: ${feedback.severity}`);
 		console.log(
 			`   Reported by: ${feedback.user.firstName} ${feedback.user.lastName}`,
 		);
@@ -94,6 +95,7 @@ export async function sendFeedbackNotification(
     <div class="content">
       <p><strong>Room:</strong> ${feedback.room.name}</p>
       <p><strong>Location:</strong> ${feedback.room.location.name}</p>
+      <p><strong>Severity:</strong> ${feedback.severity}</p>
       <p><strong>Reported by:</strong> ${feedback.user.firstName} ${feedback.user.lastName} (${feedback.user.email})</p>
       <p><strong>Date:</strong> ${new Date(feedback.createdAt).toLocaleString(
 				"en-US",
@@ -135,6 +137,7 @@ New Room Feedback Received
 
 Room: ${feedback.room.name}
 Location: ${feedback.room.location.name}
+Severity: ${feedback.severity}
 Reported by: ${feedback.user.firstName} ${feedback.user.lastName} (${feedback.user.email})
 Date: ${new Date(feedback.createdAt).toLocaleString()}
 
@@ -194,6 +197,7 @@ export async function sendFeedbackStatusUpdate(
 
 	const statusEmoji: Record<string, string> = {
 		OPEN: "🔓",
+		IN_PROGRESS: "🔧",
 		RESOLVED: "✅",
 		DISMISSED: "❌",
 	};
@@ -258,3 +262,247 @@ export async function sendFeedbackStatusUpdate(
 		console.error("❌ Failed to send status update email:", error);
 	}
 }
+
+interface SetupRequest {
+	title: string;
+	startTime: Date;
+	endTime: Date;
+	setup: string | null;
+	setupNotes: string | null;
+	room: Room & {
+		location: Pick<Location, "name">;
+	};
+	user: {
+		email: string;
+		firstName: string;
+		lastName: string;
+	};
+}
+
+// How each setup reads in emails
+const setupLabels: Record<string, string> = {
+	THEATRE: "Theatre (rows of chairs facing the front)",
+	BOARDROOM: "Boardroom (one table, chairs around it)",
+	U_SHAPE: "U-shape (tables in a U, open end at the front)",
+};
+
+/**
+ * Send the location's managers a room setup request, so facilities can
+ * prepare the room before a booking
+ */
+export async function sendSetupRequestNotification(
+	booking: SetupRequest,
+	managers: ManagerInfo[],
+): Promise<void> {
+	const transporter = createTransporter();
+
+	const setup = booking.setup
+		? setupLabels[booking.setup] || booking.setup
+		: "No particular layout";
+	const when = `${booking.startTime.toLocaleString()} – ${booking.endTime.toLocaleTimeString()}`;
+
+	if (!transporter) {
+		console.log(
+			"📧 [EMAIL SIMULATION] Would send setup request to:",
+			managers.length,
+			"managers",
+		);
+		console.log(
+			`   Room: ${booking.room.name} at ${booking.room.location.name}`,
+		);
+		console.log(`   When: ${when}`);
+		console.log(`   Setup: ${setup}`);
+		if (booking.setupNotes) {
+			console.log(`   Notes: ${booking.setupNotes}`);
+		}
+		return;
+	}
+
+	const subject = `🪑 Room Setup Request: ${booking.room.name}`;
+	const fromAddress =
+		process.env.SMTP_FROM || `Miles Booking <${process.env.SMTP_USER}>`;
+
+	const textContent = `
+Room Setup Request
+
+Room: ${booking.room.name}
+Location: ${booking.room.location.name}
+Meeting: ${booking.title}
+When: ${when}
+Booked by: ${booking.user.firstName} ${booking.user.lastName} (${booking.user.email})
+
+Setup: ${setup}
+${booking.setupNotes ? `Notes:\n${booking.setupNotes}\n` : ""}
+Please have the room ready before the meeting starts.
+
+---
+Miles Booking System - Room Management
+  `;
+
+	try {
+		for (const manager of managers) {
+			await transporter.sendMail({
+				from: fromAddress,
+				to: manager.email,
+				subject,
+				text: textContent,
+			});
+
+			console.log(
+				`✅ Setup request sent to ${manager.firstName} ${manager.lastName} (${manager.email})`,
+			);
+		}
+	} catch (error) {
+		console.error("❌ Failed to send setup request emails:", error);
+		// Don't throw - we don't want email failures to block booking
+	}
+}
+
+interface BumpedBooking {
+	title: string;
+	startTime: Date;
+	endTime: Date;
+	room: Room & {
+		location: Pick<Location, "name">;
+	};
+	user: {
+		email: string;
+		firstName: string;
+		lastName: string;
+	};
+}
+
+interface BumpDetails {
+	fromRoomName: string;
+	fromStartTime: Date;
+	fromEndTime: Date;
+	reason: string | null;
+	bumpedBy: ManagerInfo;
+}
+
+/**
+ * Tell a booking's owner it was bumped to another room or time by someone
+ * with priority, and why
+ */
+export async function sendBookingBumpedNotification(
+	booking: BumpedBooking,
+	bump: BumpDetails,
+): Promise<void> {
+	const transporter = createTransporter();
+
+	const was = `${bump.fromRoomName}, ${bump.fromStartTime.toLocaleString()} – ${bump.fromEndTime.toLocaleTimeString()}`;
+	const now = `${booking.room.name}, ${booking.startTime.toLocaleString()} – ${booking.endTime.toLocaleTimeString()}`;
+	const by = `${bump.bumpedBy.firstName} ${bump.bumpedBy.lastName} (${bump.bumpedBy.email})`;
+
+	if (!transporter) {
+		console.log(
+			"📧 [EMAIL SIMULATION] Would send bump notice to:",
+			booking.user.email,
+		);
+		console.log(`   Booking: ${booking.title}`);
+		console.log(`   Was: ${was}`);
+		console.log(`   Now: ${now}`);
+		console.log(`   By: ${by}`);
+		if (bump.reason) {
+			console.log(`   Reason: ${bump.reason}`);
+		}
+		return;
+	}
+
+	const subject = `📅 Your booking was moved: ${booking.title}`;
+	const fromAddress =
+		process.env.SMTP_FROM || `Miles Booking <${process.env.SMTP_USER}>`;
+
+	const textContent = `
+Your booking was moved
+
+Hi ${booking.user.firstName},
+
+${by} needed the room and moved your booking "${booking.title}".
+
+Was: ${was}
+Now: ${now}
+Location: ${booking.room.location.name}
+${bump.reason ? `Reason: ${bump.reason}\n` : ""}
+If the new time doesn't work for you, cancel it and book another room.
+
+---
+Miles Booking System
+  `;
+
+	try {
+		await transporter.sendMail({
+			from: fromAddress,
+			to: booking.user.email,
+			subject,
+			text: textContent,
+		});
+
+		console.log(`✅ Bump notice sent to ${booking.user.email}`);
+	} catch (error) {
+		console.error("❌ Failed to send bump notice email:", error);
+		// Don't throw - we don't want email failures to block the bump
+	}
+}
+
+/**
+ * Ask a restricted room's owner, or its location's managers, to let
+ * someone book it
+ */
+export async function sendRoomAccessRequest(
+	room: Room & { location: Location },
+	requester: ManagerInfo,
+	recipients: ManagerInfo[],
+	message: string | null,
+): Promise<void> {
+	const transporter = createTransporter();
+	const to = recipients.map((r) => r.email);
+	const from = `${requester.firstName} ${requester.lastName} (${requester.email})`;
+
+	if (!transporter) {
+		console.log(
+			"📧 [EMAIL SIMULATION] Would send access request to:",
+			to.join(", "),
+		);
+		console.log(`   Room: ${room.name} (${room.location.name})`);
+		console.log(`   Restricted to: ${room.departments.join(", ")}`);
+		console.log(`   From: ${from}`);
+		if (message) {
+			console.log(`   Message: ${message}`);
+		}
+		return;
+	}
+
+	const subject = `🔒 Access request: ${room.name}`;
+	const fromAddress =
+		process.env.SMTP_FROM || `Miles Booking <${process.env.SMTP_USER}>`;
+
+	const textContent = `
+Room access request
+
+${from} would like to book ${room.name} at ${room.location.name}.
+
+The room is restricted to: ${room.departments.join(", ")}
+${message ? `\nMessage: ${message}\n` : ""}
+Reply to this email to answer them. To let them book it, add their
+department to the room.
+
+---
+Miles Booking System
+  `;
+
+	try {
+		await transporter.sendMail({
+			from: fromAddress,
+			to: to.join(", "),
+			replyTo: requester.email,
+			subject,
+			text: textContent,
+		});
+
+		console.log(`✅ Access request sent to ${to.join(", ")}`);
+	} catch (error) {
+		console.error("❌ Failed to send access request email:", error);
+		// Don't throw - the requester was already told it went out
+	}
+}
@@ -0,0 +1,19 @@
+// Client features a deployment can turn off, so one client binary works
+// across installations configured differently
+export const FEATURES = {
+	approvals: "Bookings at some locations wait for a manager's approval",
+	desks: "Hot desks booked for the day alongside meeting rooms",
+} as const;
+
+export type FeatureName = keyof typeof FEATURES;
+
+// Features are on unless listed in DISABLED_FEATURES, separated by ","
+const DISABLED = new Set(
+	(process.env.DISABLED_FEATURES || "")
+		.split(",")
+		.map((name) => name.trim().toLowerCase())
+		.filter((name) => name.length > 0),
+);
+
+export const isFeatureEnabled = (name: FeatureName): boolean =>
+	!DISABLED.has(name);
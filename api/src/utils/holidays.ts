@@ -0,0 +1,98 @@
+import prisma from "./prisma";
+
+// A day a location is closed on
+export interface Closure {
+	// The calendar day in the location's time zone, YYYY-MM-DD
+	date: string;
+	// The holiday's name, or e.g. "closed on Saturdays" for a weekly closure
+	reason: string;
+}
+
+const weekdayNames = [
+	"Sundays",
+	"Mondays",
+	"Tuesdays",
+	"Wednesdays",
+	"Thursdays",
+	"Fridays",
+	"Saturdays",
+];
+
+// The calendar day of a time in a time zone, YYYY-MM-DD
+export const localDate = (at: Date, timeZone: string): string =>
+	new Intl.DateTimeFormat("en-CA", {
+		timeZone,
+		year: "numeric",
+		month: "2-digit",
+		day: "2-digit",
+	}).format(at);
+
+// Weekday (0 = Sunday) of a YYYY-MM-DD day
+const weekdayOf = (date: string): number =>
+	new Date(`${date}T00:00:00Z`).getUTCDay();
+
+// The day after a YYYY-MM-DD day
+const nextDate = (date: string): string => {
+	const day = new Date(`${date}T00:00:00Z`);
+	day.setUTCDate(day.getUTCDate() + 1);
+	return day.toISOString().slice(0, 10);
+};
+
+/**
+ * The first day a booking from startTime to endTime would fall on while
+ * its room's location is closed, or null. Days are the location's own,
+ * so a booking at 23:30 UTC may already be on a holiday in Oslo.
+ */
+export const findClosure = async (
+	roomId: string,
+	startTime: Date,
+	endTime: Date,
+): Promise<Closure | null> => {
+	const room = await prisma.room.findUnique({
+		where: { id: roomId },
+		select: {
+			location: {
+				select: { id: true, timezone: true, closedWeekdays: true },
+			},
+		},
+	});
+	if (!room) {
+		return null;
+	}
+	const { location } = room;
+
+	const first = localDate(startTime, location.timezone);
+	// The end is exclusive: a booking ending at midnight doesn't touch
+	// the next day
+	const last = localDate(
+		new Date(Math.max(endTime.getTime() - 1, startTime.getTime())),
+		location.timezone,
+	);
+
+	const holidays = await prisma.locationHoliday.findMany({
+		where: { locationId: location.id, date: { gte: first, lte: last } },
+		orderBy: { date: "asc" },
+	});
+	const names = new Map(holidays.map((h) => [h.date, h.name]));
+
+	for (let date = first; date <= last; date = nextDate(date)) {
+		const name = names.get(date);
+		if (name) {
+			return { date, reason: name };
+		}
+		const weekday = weekdayOf(date);
+		if (location.closedWeekdays.includes(weekday)) {
+			return { date, reason: `closed on ${weekdayNames[weekday]}` };
+		}
+	}
+	return null;
+};
+
+// Explains a closure to the booker, e.g. "Office closed Dec 25 (Christmas Day)"
+export const closureMessage = (closure: Closure): string => {
+	const day = new Date(`${closure.date}T00:00:00Z`).toLocaleDateString(
+		"en-US",
+		{ timeZone: "UTC", month: "short", day: "numeric", year: "numeric" },
+	);
+	return `Office closed ${day} (${closure.reason})`;
+};
@@ -0,0 +1,61 @@
+import prisma from "./prisma";
+
+interface CancellableBooking {
+	userId: string;
+	startTime: Date;
+	endTime: Date;
+	status: string;
+	room: { locationId: string };
+}
+
+// A cancellation inside a location's late-cancellation window
+export interface LateCancellation {
+	// The location's window, e.g. cancelling less than 60 minutes before
+	policyMinutes: number;
+	// How long before the start the booking was cancelled; negative once
+	// it has started
+	minutesBefore: number;
+}
+
+/**
+ * Whether cancelling a booking now is late under its location's policy:
+ * less than lateCancelMinutes before it starts, and before it ends. Only
+ * the booker's own cancellations count; managers and admins cancelling
+ * for them don't.
+ */
+export const checkLateCancellation = async (
+	booking: CancellableBooking,
+	cancelledBy: string | undefined,
+	now = new Date(),
+): Promise<LateCancellation | null> => {
+	if (
+		booking.status === "CANCELLED" ||
+		booking.userId !== cancelledBy ||
+		booking.endTime <= now
+	) {
+		return null;
+	}
+
+	const location = await prisma.location.findUnique({
+		where: { id: booking.room.locationId },
+		select: { lateCancelMinutes: true },
+	});
+	const policyMinutes = location?.lateCancelMinutes;
+	if (!policyMinutes) {
+		return null;
+	}
+
+	const minutesBefore = Math.floor(
+		(booking.startTime.getTime() - now.getTime()) / 60000,
+	);
+	if (minutesBefore >= policyMinutes) {
+		return null;
+	}
+	return { policyMinutes, minutesBefore };
+};
+
+// Tells the booker their cancellation was recorded as late
+export const lateCancellationWarning = (late: LateCancellation): string =>
+	late.minutesBefore < 0
+		? `Cancelled after the booking started. Cancellations less than ${late.policyMinutes} minutes before the start are recorded as late`
+		: `Cancelled ${late.minutesBefore} minutes before the start. Cancellations less than ${late.policyMinutes} minutes before are recorded as late`;
@@ -0,0 +1,76 @@
+import prisma from "./prisma";
+
+export type QuotaPeriod = "week" | "month";
+export type QuotaPolicy = "block" | "warn";
+
+export interface Quota {
+	period: QuotaPeriod;
+	periodStart: Date;
+	periodEnd: Date;
+	limitHours: number;
+	usedHours: number;
+	policy: QuotaPolicy;
+}
+
+// Personal booking quota, configured per deployment
+const QUOTA_HOURS = Number(process.env.BOOKING_QUOTA_HOURS || "10");
+const QUOTA_PERIOD: QuotaPeriod =
+	process.env.BOOKING_QUOTA_PERIOD === "month" ? "month" : "week";
+const QUOTA_POLICY: QuotaPolicy =
+	process.env.BOOKING_QUOTA_POLICY === "warn" ? "warn" : "block";
+
+// Returns the quota period (weeks start on Monday) containing the given date
+const periodBounds = (at: Date): { start: Date; end: Date } => {
+	if (QUOTA_PERIOD === "month") {
+		const start = new Date(at.getFullYear(), at.getMonth(), 1);
+		const end = new Date(at.getFullYear(), at.getMonth() + 1, 1);
+		return { start, end };
+	}
+
+	const start = new Date(at.getFullYear(), at.getMonth(), at.getDate());
+	start.setDate(start.getDate() - ((start.getDay() + 6) % 7));
+	const end = new Date(start);
+	end.setDate(end.getDate() + 7);
+	return { start, end };
+};
+
+// Sums the user's active booking hours starting within the period containing `at`
+export const getQuota = async (
+	userId: string,
+	at: Date,
+	excludeBookingId?: string,
+): Promise<Quota> => {
+	const { start, end } = periodBounds(at);
+
+	const bookings = await prisma.booking.findMany({
+		where: {
+			userId,
+			id: excludeBookingId ? { not: excludeBookingId } : undefined,
+			status: { not: "CANCELLED" },
+			startTime: { gte: start, lt: end },
+		},
+		select: { startTime: true, endTime: true },
+	});
+
+	const usedMs = bookings.reduce(
+		(total, booking) =>
+			total + (booking.endTime.getTime() - booking.startTime.getTime()),
+		0,
+	);
+
+	return {
+		period: QUOTA_PERIOD,
+		periodStart: start,
+		periodEnd: end,
+		limitHours: QUOTA_HOURS,
+		usedHours: Math.round((usedMs / 3_600_000) * 100) / 100,
+		policy: QUOTA_POLICY,
+	};
+};
+
+// Hours a booking between startTime and endTime would add
+export const bookingHours = (startTime: Date, endTime: Date): number =>
+	(endTime.getTime() - startTime.getTime()) / 3_600_000;
+
+// Whether quotas are enforced at all (BOOKING_QUOTA_HOURS=0 disables them)
+export const quotaEnabled = (): boolean => QUOTA_HOURS > 0;
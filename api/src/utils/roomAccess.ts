@@ -0,0 +1,78 @@
+import type { Response } from "express";
+import type { JWTPayload } from "./jwt";
+import prisma from "./prisma";
+
+interface RestrictedRoom {
+	id: string;
+	name: string;
+	locationId: string;
+	departments: string[];
+	ownerId: string | null;
+}
+
+// Who is asking, as much as booking checks need to know
+export interface RoomAccessUser {
+	userId: string;
+	role: JWTPayload["role"];
+	department: string | null;
+	managedLocationIds: string[];
+}
+
+// Loads what room access checks need about a signed-in user
+export const loadAccessUser = async (
+	user: Pick<JWTPayload, "userId" | "role">,
+): Promise<RoomAccessUser> => {
+	const found = await prisma.user.findUnique({
+		where: { id: user.userId },
+		select: {
+			department: true,
+			managedLocations: { select: { locationId: true } },
+		},
+	});
+	return {
+		userId: user.userId,
+		role: user.role,
+		department: found?.department ?? null,
+		managedLocationIds: found?.managedLocations.map((m) => m.locationId) ?? [],
+	};
+};
+
+export const isRestricted = (room: { departments: string[] }): boolean =>
+	room.departments.length > 0;
+
+/**
+ * A restricted room can be booked by its departments, its owner, the
+ * managers of its location and admins. Guests can only book open rooms.
+ */
+export const canBookRoom = (
+	user: RoomAccessUser | null,
+	room: RestrictedRoom,
+): boolean => {
+	if (!isRestricted(room)) {
+		return true;
+	}
+	if (!user) {
+		return false;
+	}
+	if (
+		user.role === "ADMIN" ||
+		user.userId === room.ownerId ||
+		user.managedLocationIds.includes(room.locationId)
+	) {
+		return true;
+	}
+	const department = user.department?.toLowerCase();
+	return (
+		department !== undefined &&
+		room.departments.some((d) => d.toLowerCase() === department)
+	);
+};
+
+// Refuses a booking in a room the user may not book, naming who may
+export const roomRestricted = (res: Response, room: RestrictedRoom): void => {
+	res.status(403).json({
+		error: `${room.name} is restricted to ${room.departments.join(", ")}. Request access from the room owner to book it`,
+		roomId: room.id,
+		departments: room.departments,
+	});
+};
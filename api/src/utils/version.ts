@@ -0,0 +1,5 @@
+// The API's version, reported at /api/meta/version so clients can tell
+// before anything else whether they speak it. Bump the minor version for
+// additions clients may come to rely on, and the major version for changes
+// that break the clients out there.
+export const API_VERSION = "1.0.0";
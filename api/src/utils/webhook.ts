@@ -0,0 +1,166 @@
+import crypto from "node:crypto";
+import type { Webhook } from "@prisma/client";
+import prisma from "./prisma";
+
+// Booking lifecycle events webhooks can subscribe to
+export const WEBHOOK_EVENTS = [
+	"booking.created",
+	"booking.updated",
+	"booking.approved",
+	"booking.cancelled",
+] as const;
+
+export type WebhookEvent = (typeof WEBHOOK_EVENTS)[number];
+
+// How long a receiver gets to answer a delivery
+const DELIVERY_TIMEOUT_MS = 10_000;
+
+// What deliveries say about a booking. Descriptions stay out, as they may
+// be private or encrypted.
+const webhookBookingSelect = {
+	id: true,
+	title: true,
+	status: true,
+	startTime: true,
+	endTime: true,
+	roomId: true,
+	room: {
+		select: {
+			id: true,
+			name: true,
+			location: { select: { id: true, name: true } },
+		},
+	},
+	user: {
+		select: { id: true, email: true, firstName: true, lastName: true },
+	},
+	createdAt: true,
+	updatedAt: true,
+};
+
+export interface WebhookDelivery {
+	status: number; // HTTP status, 0 when the receiver never answered
+	ok: boolean;
+	durationMs: number;
+	error?: string;
+}
+
+// Generate the secret deliveries to a webhook are signed with
+export const generateWebhookSecret = (): string =>
+	`whsec_${crypto.randomBytes(24).toString("hex")}`;
+
+/**
+ * POST an event to a webhook. The body is signed with the webhook's secret:
+ * X-Miles-Signature is "sha256=" and the hex HMAC-SHA256 of the raw body.
+ */
+export async function deliverWebhook(
+	webhook: Webhook,
+	event: string,
+	payload: Record<string, unknown>,
+): Promise<WebhookDelivery> {
+	const id = crypto.randomUUID();
+	const body = JSON.stringify({
+		id,
+		event,
+		createdAt: new Date().toISOString(),
+		...payload,
+	});
+	const signature = crypto
+		.createHmac("sha256", webhook.secret)
+		.update(body)
+		.digest("hex");
+
+	const started = Date.now();
+	let status = 0;
+	let error: string | undefined;
+	try {
+		const response = await fetch(webhook.url, {
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+				"User-Agent": "Miles-Booking-Webhooks/1.0",
+				"X-Miles-Event": event,
+				"X-Miles-Delivery": id,
+				"X-Miles-Signature": `sha256=${signature}`,
+			},
+			body,
+			signal: AbortSignal.timeout(DELIVERY_TIMEOUT_MS),
+		});
+		status = response.status;
+	} catch (err) {
+		error = err instanceof Error ? err.message : String(err);
+	}
+
+	await prisma.webhook.update({
+		where: { id: webhook.id },
+		data: { lastStatus: status, lastDeliveryAt: new Date() },
+	});
+
+	return {
+		status,
+		ok: status >= 200 && status < 300,
+		durationMs: Date.now() - started,
+		error,
+	};
+}
+
+/**
+ * Tell every webhook subscribed to the event about a booking. Deliveries
+ * are made one at a time and failures are only logged; there are no
+ * retries.
+ */
+export async function emitBookingEvent(
+	event: WebhookEvent,
+	bookingId: string,
+): Promise<void> {
+	const webhooks = await prisma.webhook.findMany({
+		where: { events: { has: event } },
+	});
+	if (webhooks.length === 0) {
+		return;
+	}
+
+	const booking = await prisma.booking.findUnique({
+		where: { id: bookingId },
+		select: webhookBookingSelect,
+	});
+	if (!booking) {
+		return;
+	}
+
+	for (const webhook of webhooks) {
+		const delivery = await deliverWebhook(webhook, event, { booking });
+		if (!delivery.ok) {
+			console.error(
+				`Webhook ${webhook.id} failed for ${event}:`,
+				delivery.error || `HTTP ${delivery.status}`,
+			);
+		}
+	}
+}
+
+// A booking-shaped sample for test deliveries
+export const sampleBooking = () => {
+	const startTime = new Date(Date.now() + 24 * 60 * 60 * 1000);
+	return {
+		id: "sample-booking",
+		title: "Sample booking",
+		status: "CONFIRMED",
+		startTime,
+		endTime: new Date(startTime.getTime() + 60 * 60 * 1000),
+		roomId: "sample-room",
+		room: {
+			id: "sample-room",
+			name: "Sample room",
+			location: { id: "sample-location", name: "Sample location" },
+		},
+		user: {
+			id: "sample-user",
+			email: "sample@example.com",
+			firstName: "Sample",
+			lastName: "User",
+		},
+		createdAt: new Date(),
+		updatedAt: new Date(),
+	};
+};
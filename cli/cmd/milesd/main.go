@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	"github.com/miles/booking-cli/internal/commands"
+)
+
+func main() {
+	if err := commands.ExecuteDaemon(); err != nil {
+		os.Exit(1)
+	}
+}
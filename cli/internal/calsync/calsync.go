@@ -0,0 +1,286 @@
+// Package calsync pushes Miles room bookings to external calendar providers
+// (Google Calendar, Microsoft 365) as events.
+package calsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Event is a calendar event derived from a booking
+type Event struct {
+	Title       string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+}
+
+// CalendarEvent is an event read from an external calendar, for importing
+// into bookings
+type CalendarEvent struct {
+	ID          string
+	Title       string
+	Description string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	// Rooms the event was held in: the room resources that accepted it, or
+	// else its location
+	Rooms []string
+}
+
+// Busy is a period the user is busy in their external calendar
+type Busy struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Overlaps reports whether [start, end) collides with any busy period
+func Overlaps(busy []Busy, start, end time.Time) bool {
+	for _, b := range busy {
+		if b.Start.Before(end) && b.End.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// Provider creates, updates and deletes events in an external calendar and
+// reports the user's busy times
+type Provider interface {
+	// Name identifies the provider in the mapping store and messages
+	Name() string
+
+	// Create adds a new event and returns the provider's event ID
+	Create(ctx context.Context, event Event) (string, error)
+
+	// Update replaces an existing event
+	Update(ctx context.Context, eventID string, event Event) error
+
+	// Delete removes an event. Deleting an event that no longer exists is not an error.
+	Delete(ctx context.Context, eventID string) error
+
+	// BusyTimes returns the periods between start and end where the user is busy
+	BusyTimes(ctx context.Context, start, end time.Time) ([]Busy, error)
+}
+
+// ErrEventNotFound is returned by Update when the event was removed on the provider side
+var ErrEventNotFound = errors.New("event not found")
+
+// Options controls a sync run
+type Options struct {
+	// DeleteCancelled removes events for bookings that have been cancelled
+	DeleteCancelled bool
+
+	// DryRun reports what would change without calling the provider
+	DryRun bool
+
+	// Rooms maps room IDs to display names for the event location
+	Rooms map[string]string
+}
+
+// Result summarises a sync run
+type Result struct {
+	Created   int
+	Updated   int
+	Deleted   int
+	Unchanged int
+	Failed    []error
+}
+
+// Sync pushes confirmed bookings to the provider. The store records which
+// event belongs to which booking so repeated syncs update rather than
+// duplicate events.
+func Sync(ctx context.Context, provider Provider, store *Store, bookings []milesapi.Booking, opts Options) Result {
+	var result Result
+
+	for _, booking := range bookings {
+		if booking.Id == nil || booking.StartTime == nil || booking.EndTime == nil {
+			continue
+		}
+		id := *booking.Id
+		mapping, synced := store.Get(id)
+
+		if booking.Status != nil && *booking.Status == milesapi.BookingStatusCANCELLED {
+			if !synced || !opts.DeleteCancelled {
+				continue
+			}
+			if !opts.DryRun {
+				if err := provider.Delete(ctx, mapping.EventID); err != nil {
+					result.Failed = append(result.Failed, fmt.Errorf("delete event for booking %s: %w", id, err))
+					continue
+				}
+				store.Remove(id)
+			}
+			result.Deleted++
+			continue
+		}
+
+		if booking.Status != nil && *booking.Status != milesapi.BookingStatusCONFIRMED {
+			continue
+		}
+
+		event := eventFromBooking(booking, opts.Rooms)
+		fingerprint := fingerprintEvent(event)
+
+		if synced && mapping.Fingerprint == fingerprint {
+			result.Unchanged++
+			continue
+		}
+
+		if opts.DryRun {
+			if synced {
+				result.Updated++
+			} else {
+				result.Created++
+			}
+			continue
+		}
+
+		if synced {
+			err := provider.Update(ctx, mapping.EventID, event)
+			if err == nil {
+				store.Put(id, Mapping{EventID: mapping.EventID, Fingerprint: fingerprint})
+				result.Updated++
+				continue
+			}
+			if !errors.Is(err, ErrEventNotFound) {
+				result.Failed = append(result.Failed, fmt.Errorf("update event for booking %s: %w", id, err))
+				continue
+			}
+			// The event was deleted in the calendar - recreate it below
+		}
+
+		eventID, err := provider.Create(ctx, event)
+		if err != nil {
+			result.Failed = append(result.Failed, fmt.Errorf("create event for booking %s: %w", id, err))
+			continue
+		}
+		store.Put(id, Mapping{EventID: eventID, Fingerprint: fingerprint})
+		result.Created++
+	}
+
+	return result
+}
+
+// eventFromBooking converts a booking into a calendar event
+func eventFromBooking(booking milesapi.Booking, rooms map[string]string) Event {
+	event := Event{
+		Title: "Meeting room booking",
+		Start: booking.StartTime.UTC(),
+		End:   booking.EndTime.UTC(),
+	}
+	if booking.Title != nil && *booking.Title != "" {
+		event.Title = *booking.Title
+	}
+	if booking.Description != nil {
+		event.Description = *booking.Description
+	}
+	if booking.RoomId != nil {
+		event.Location = *booking.RoomId
+		if name, ok := rooms[*booking.RoomId]; ok {
+			event.Location = name
+		}
+	}
+	if booking.Id != nil {
+		if event.Description != "" {
+			event.Description += "\n\n"
+		}
+		event.Description += "Miles booking " + *booking.Id
+	}
+	return event
+}
+
+// fingerprintEvent hashes the synced fields so unchanged bookings are skipped
+func fingerprintEvent(event Event) string {
+	data, _ := json.Marshal(event)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Mapping links a booking to the event created for it
+type Mapping struct {
+	EventID     string `json:"eventId"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Store persists booking → event mappings for one provider as JSON
+type Store struct {
+	path     string
+	Mappings map[string]Mapping `json:"mappings"`
+}
+
+// Dir returns the directory holding sync state (~/.miles-cli/sync)
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".miles-cli", "sync"), nil
+}
+
+// OpenStore loads the mapping store for a provider, creating an empty one if needed
+func OpenStore(provider string) (*Store, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{
+		path:     filepath.Join(dir, provider+".json"),
+		Mappings: make(map[string]Mapping),
+	}
+
+	data, err := os.ReadFile(store.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state %s: %w", store.path, err)
+	}
+	if store.Mappings == nil {
+		store.Mappings = make(map[string]Mapping)
+	}
+	return store, nil
+}
+
+// Get returns the mapping for a booking
+func (s *Store) Get(bookingID string) (Mapping, bool) {
+	mapping, ok := s.Mappings[bookingID]
+	return mapping, ok
+}
+
+// Put records the mapping for a booking
+func (s *Store) Put(bookingID string, mapping Mapping) {
+	s.Mappings[bookingID] = mapping
+}
+
+// Remove forgets the mapping for a booking
+func (s *Store) Remove(bookingID string) {
+	delete(s.Mappings, bookingID)
+}
+
+// Save writes the store to disk
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create sync directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
@@ -0,0 +1,317 @@
+package calsync
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+const googleCalendarAPI = "https://www.googleapis.com/calendar/v3"
+
+// GoogleConfig configures the Google Calendar provider
+type GoogleConfig struct {
+	ClientID     string
+	ClientSecret string
+	// CalendarID defaults to the user's primary calendar
+	CalendarID string
+}
+
+// Google pushes events to Google Calendar
+type Google struct {
+	http     *http.Client
+	calendar string
+}
+
+// NewGoogle authorises against Google and returns a provider
+func NewGoogle(ctx context.Context, cfg GoogleConfig) (*Google, error) {
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("gcal_client_id is not configured")
+	}
+	conf := &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		Endpoint:     endpoints.Google,
+		Scopes: []string{
+			"https://www.googleapis.com/auth/calendar.events",
+			"https://www.googleapis.com/auth/calendar.freebusy",
+			"https://www.googleapis.com/auth/calendar.calendarlist.readonly",
+		},
+	}
+
+	client, err := authorize(ctx, "gcal", conf)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := cfg.CalendarID
+	if calendar == "" {
+		calendar = "primary"
+	}
+	return &Google{http: client, calendar: calendar}, nil
+}
+
+// Name implements Provider
+func (g *Google) Name() string { return "gcal" }
+
+type googleTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+type googleEvent struct {
+	ID          string     `json:"id,omitempty"`
+	Summary     string     `json:"summary"`
+	Description string     `json:"description,omitempty"`
+	Location    string     `json:"location,omitempty"`
+	Start       googleTime `json:"start"`
+	End         googleTime `json:"end"`
+}
+
+func toGoogleEvent(event Event) googleEvent {
+	return googleEvent{
+		Summary:     event.Title,
+		Description: event.Description,
+		Location:    event.Location,
+		Start:       googleTime{DateTime: event.Start.Format(time.RFC3339), TimeZone: "UTC"},
+		End:         googleTime{DateTime: event.End.Format(time.RFC3339), TimeZone: "UTC"},
+	}
+}
+
+func (g *Google) eventsURL() string {
+	return fmt.Sprintf("%s/calendars/%s/events", googleCalendarAPI, url.PathEscape(g.calendar))
+}
+
+// Create implements Provider
+func (g *Google) Create(ctx context.Context, event Event) (string, error) {
+	var created googleEvent
+	if err := doJSON(ctx, g.http, http.MethodPost, g.eventsURL(), toGoogleEvent(event), &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// Update implements Provider
+func (g *Google) Update(ctx context.Context, eventID string, event Event) error {
+	return doJSON(ctx, g.http, http.MethodPut, g.eventsURL()+"/"+url.PathEscape(eventID), toGoogleEvent(event), nil)
+}
+
+// Delete implements Provider
+func (g *Google) Delete(ctx context.Context, eventID string) error {
+	err := doJSON(ctx, g.http, http.MethodDelete, g.eventsURL()+"/"+url.PathEscape(eventID), nil, nil)
+	if err == ErrEventNotFound {
+		return nil
+	}
+	return err
+}
+
+type googleCalendarList struct {
+	Items []struct {
+		ID      string `json:"id"`
+		Summary string `json:"summary"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// SelectCalendar switches to another of the user's calendars, given its ID
+// or its name as shown in Google Calendar, e.g. "work"
+func (g *Google) SelectCalendar(ctx context.Context, nameOrID string) error {
+	if nameOrID == "" || nameOrID == "primary" || strings.Contains(nameOrID, "@") {
+		g.calendar = cmp.Or(nameOrID, "primary")
+		return nil
+	}
+
+	var names []string
+	pageToken := ""
+	for {
+		query := url.Values{}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		var list googleCalendarList
+		if err := doJSON(ctx, g.http, http.MethodGet, googleCalendarAPI+"/users/me/calendarList?"+query.Encode(), nil, &list); err != nil {
+			return fmt.Errorf("listing calendars failed (sign in again with --reauth if this worked before): %w", err)
+		}
+		for _, item := range list.Items {
+			if strings.EqualFold(item.Summary, nameOrID) || item.ID == nameOrID {
+				g.calendar = item.ID
+				return nil
+			}
+			names = append(names, item.Summary)
+		}
+		if pageToken = list.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+	return fmt.Errorf("no calendar named %q (you have: %s)", nameOrID, strings.Join(names, ", "))
+}
+
+type googleEventTime struct {
+	DateTime time.Time `json:"dateTime"`
+	Date     string    `json:"date"` // All-day events
+}
+
+type googleEventList struct {
+	Items []struct {
+		ID          string          `json:"id"`
+		Status      string          `json:"status"`
+		Summary     string          `json:"summary"`
+		Description string          `json:"description"`
+		Location    string          `json:"location"`
+		Start       googleEventTime `json:"start"`
+		End         googleEventTime `json:"end"`
+		Attendees   []struct {
+			DisplayName    string `json:"displayName"`
+			Email          string `json:"email"`
+			Resource       bool   `json:"resource"`
+			ResponseStatus string `json:"responseStatus"`
+		} `json:"attendees"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// Events lists the events starting between start and end, with recurring
+// events expanded, earliest first. Cancelled events are left out.
+func (g *Google) Events(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
+	var events []CalendarEvent
+	pageToken := ""
+	for {
+		query := url.Values{
+			"timeMin":      {start.UTC().Format(time.RFC3339)},
+			"timeMax":      {end.UTC().Format(time.RFC3339)},
+			"singleEvents": {"true"},
+			"orderBy":      {"startTime"},
+			"maxResults":   {"250"},
+		}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		var list googleEventList
+		if err := doJSON(ctx, g.http, http.MethodGet, g.eventsURL()+"?"+query.Encode(), nil, &list); err != nil {
+			return nil, err
+		}
+
+		for _, item := range list.Items {
+			if item.Status == "cancelled" {
+				continue
+			}
+			event := CalendarEvent{
+				ID:          item.ID,
+				Title:       item.Summary,
+				Description: item.Description,
+				Start:       item.Start.DateTime,
+				End:         item.End.DateTime,
+				AllDay:      item.Start.Date != "",
+			}
+			// A room that declined wasn't held
+			for _, attendee := range item.Attendees {
+				if attendee.Resource && attendee.ResponseStatus != "declined" {
+					event.Rooms = append(event.Rooms, cmp.Or(attendee.DisplayName, attendee.Email))
+				}
+			}
+			if len(event.Rooms) == 0 && strings.TrimSpace(item.Location) != "" {
+				event.Rooms = []string{strings.TrimSpace(item.Location)}
+			}
+			events = append(events, event)
+		}
+
+		if pageToken = list.NextPageToken; pageToken == "" {
+			return events, nil
+		}
+	}
+}
+
+type googleFreeBusyRequest struct {
+	TimeMin string `json:"timeMin"`
+	TimeMax string `json:"timeMax"`
+	Items   []struct {
+		ID string `json:"id"`
+	} `json:"items"`
+}
+
+type googleFreeBusyResponse struct {
+	Calendars map[string]struct {
+		Busy []struct {
+			Start time.Time `json:"start"`
+			End   time.Time `json:"end"`
+		} `json:"busy"`
+		Errors []struct {
+			Reason string `json:"reason"`
+		} `json:"errors"`
+	} `json:"calendars"`
+}
+
+// BusyTimes implements Provider using the free/busy query
+func (g *Google) BusyTimes(ctx context.Context, start, end time.Time) ([]Busy, error) {
+	req := googleFreeBusyRequest{
+		TimeMin: start.UTC().Format(time.RFC3339),
+		TimeMax: end.UTC().Format(time.RFC3339),
+	}
+	req.Items = append(req.Items, struct {
+		ID string `json:"id"`
+	}{ID: g.calendar})
+
+	var resp googleFreeBusyResponse
+	if err := doJSON(ctx, g.http, http.MethodPost, googleCalendarAPI+"/freeBusy", req, &resp); err != nil {
+		return nil, err
+	}
+
+	var busy []Busy
+	for _, calendar := range resp.Calendars {
+		if len(calendar.Errors) > 0 {
+			return nil, fmt.Errorf("free/busy query failed: %s", calendar.Errors[0].Reason)
+		}
+		for _, b := range calendar.Busy {
+			busy = append(busy, Busy{Start: b.Start, End: b.End})
+		}
+	}
+	return busy, nil
+}
+
+// doJSON sends an optional JSON body and decodes an optional JSON response.
+// 404 and 410 responses map to ErrEventNotFound.
+func doJSON(ctx context.Context, client *http.Client, method, endpoint string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return ErrEventNotFound
+	}
+	if resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s: %s: %s", method, endpoint, resp.Status, bytes.TrimSpace(data))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,500 @@
+package calsync
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar is the busy time in an iCalendar (.ics) file
+type Calendar struct {
+	events []icsEvent
+
+	// Unsupported counts recurring events whose rule can't be expanded.
+	// Only their first occurrence counts as busy.
+	Unsupported int
+}
+
+// icsEvent is a VEVENT reduced to what busy times need
+type icsEvent struct {
+	uid          string
+	start, end   time.Time
+	rule         *icsRule
+	exdates      []time.Time
+	recurrenceID time.Time // Set on an edited occurrence of a recurring event
+}
+
+// icsRule is the subset of RRULE that is expanded: DAILY and WEEKLY
+// with INTERVAL, COUNT, UNTIL and (for WEEKLY) BYDAY
+type icsRule struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+	byDay    []time.Weekday
+}
+
+// Events returns the number of events that make the user busy
+func (c *Calendar) Events() int {
+	return len(c.events)
+}
+
+// ParseICS reads the events from an iCalendar file. Events marked free
+// (TRANSP:TRANSPARENT) or cancelled are left out.
+func ParseICS(r io.Reader) (*Calendar, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &Calendar{}
+	var event *icsEvent
+	skip := false
+	sawCalendar := false
+
+	for n, line := range lines {
+		name, params, value := splitICSLine(line)
+		switch {
+		case name == "BEGIN" && value == "VCALENDAR":
+			sawCalendar = true
+		case name == "BEGIN" && value == "VEVENT":
+			event = &icsEvent{}
+			skip = false
+		case name == "END" && value == "VEVENT":
+			if event == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", n+1)
+			}
+			if !skip && !event.start.IsZero() {
+				if event.end.IsZero() || !event.end.After(event.start) {
+					// No length: nothing to be busy for
+					event = nil
+					continue
+				}
+				cal.events = append(cal.events, *event)
+			}
+			event = nil
+		case event == nil:
+			// Outside an event (time zones, alarms' parents, etc.)
+		case name == "UID":
+			event.uid = value
+		case name == "DTSTART":
+			start, allDay, err := parseICSTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTSTART: %w", n+1, err)
+			}
+			event.start = start
+			if allDay && event.end.IsZero() {
+				event.end = start.AddDate(0, 0, 1)
+			}
+		case name == "DTEND":
+			end, _, err := parseICSTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTEND: %w", n+1, err)
+			}
+			event.end = end
+		case name == "DURATION":
+			d, err := parseICSDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DURATION: %w", n+1, err)
+			}
+			if !event.start.IsZero() {
+				event.end = event.start.Add(d)
+			}
+		case name == "RRULE":
+			rule, ok := parseICSRule(value, params)
+			if !ok {
+				cal.Unsupported++
+				continue
+			}
+			event.rule = rule
+		case name == "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				t, _, err := parseICSTime(v, params)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: EXDATE: %w", n+1, err)
+				}
+				event.exdates = append(event.exdates, t)
+			}
+		case name == "RECURRENCE-ID":
+			t, _, err := parseICSTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: RECURRENCE-ID: %w", n+1, err)
+			}
+			event.recurrenceID = t
+		case name == "TRANSP" && strings.EqualFold(value, "TRANSPARENT"):
+			skip = true
+		case name == "STATUS" && strings.EqualFold(value, "CANCELLED"):
+			skip = true
+		}
+	}
+
+	if !sawCalendar {
+		return nil, fmt.Errorf("not an iCalendar file (no BEGIN:VCALENDAR)")
+	}
+
+	// Edited occurrences replace the original slot of their recurring event
+	for _, edited := range cal.events {
+		if edited.recurrenceID.IsZero() {
+			continue
+		}
+		for i := range cal.events {
+			if cal.events[i].uid == edited.uid && cal.events[i].rule != nil {
+				cal.events[i].exdates = append(cal.events[i].exdates, edited.recurrenceID)
+			}
+		}
+	}
+
+	return cal, nil
+}
+
+// BusyTimes returns the busy periods overlapping [start, end), sorted by start
+func (c *Calendar) BusyTimes(start, end time.Time) []Busy {
+	var busy []Busy
+	for _, event := range c.events {
+		event.occurrences(end, func(s, e time.Time) {
+			if s.Before(end) && e.After(start) {
+				busy = append(busy, Busy{Start: s, End: e})
+			}
+		})
+	}
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Start.Before(busy[j].Start) })
+	return busy
+}
+
+// occurrences calls fn for each occurrence starting before limit
+func (e icsEvent) occurrences(limit time.Time, fn func(start, end time.Time)) {
+	length := e.end.Sub(e.start)
+	if e.rule == nil {
+		fn(e.start, e.end)
+		return
+	}
+
+	emitted := 0
+	emit := func(start time.Time) bool {
+		if start.Before(e.start) {
+			return true
+		}
+		if !e.rule.until.IsZero() && start.After(e.rule.until) {
+			return false
+		}
+		if e.rule.count > 0 && emitted >= e.rule.count {
+			return false
+		}
+		emitted++
+		for _, ex := range e.exdates {
+			if ex.Equal(start) {
+				return true
+			}
+		}
+		fn(start, start.Add(length))
+		return true
+	}
+
+	switch e.rule.freq {
+	case "DAILY":
+		for t := e.start; t.Before(limit); t = t.AddDate(0, 0, e.rule.interval) {
+			if !emit(t) {
+				return
+			}
+		}
+	case "WEEKLY":
+		days := e.rule.byDay
+		if len(days) == 0 {
+			days = []time.Weekday{e.start.Weekday()}
+		}
+		// Weeks start on Monday (the RFC 5545 default)
+		offset := (int(e.start.Weekday()) + 6) % 7
+		week := e.start.AddDate(0, 0, -offset)
+		for ; week.Before(limit); week = week.AddDate(0, 0, 7*e.rule.interval) {
+			for _, day := range days {
+				t := week.AddDate(0, 0, (int(day)+6)%7)
+				if !t.Before(limit) {
+					continue
+				}
+				if !emit(t) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// unfoldICS splits a file into logical lines, joining folded continuations
+func unfoldICS(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// splitICSLine splits NAME;PARAM=VALUE:VALUE into its parts. Parameter
+// names are upper-cased; quoted parameter values may contain ':' and ';'.
+func splitICSLine(line string) (name string, params map[string]string, value string) {
+	params = map[string]string{}
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return strings.ToUpper(line), params, ""
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+	name = strings.ToUpper(parts[0])
+	for _, part := range parts[1:] {
+		if key, val, ok := strings.Cut(part, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+		}
+	}
+	return name, params, value
+}
+
+// parseICSTime parses a DATE or DATE-TIME value. UTC times end in Z, TZID
+// names the zone, and anything else is local time. allDay is true for DATE.
+func parseICSTime(value string, params map[string]string) (t time.Time, allDay bool, err error) {
+	value = strings.TrimSpace(value)
+
+	loc := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", value, time.Local)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	t, err = time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// parseICSDuration parses an RFC 5545 duration such as PT1H30M or P1D
+func parseICSDuration(value string) (time.Duration, error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P")
+	if s == value || s == "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	var d time.Duration
+	inTime := false
+	num := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+		case r == 'T':
+			inTime = true
+		default:
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			num = ""
+			switch {
+			case r == 'W' && !inTime:
+				d += time.Duration(n) * 7 * 24 * time.Hour
+			case r == 'D' && !inTime:
+				d += time.Duration(n) * 24 * time.Hour
+			case r == 'H' && inTime:
+				d += time.Duration(n) * time.Hour
+			case r == 'M' && inTime:
+				d += time.Duration(n) * time.Minute
+			case r == 'S' && inTime:
+				d += time.Duration(n) * time.Second
+			default:
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return d, nil
+}
+
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// parseICSRule parses an RRULE, reporting false for rules that can't be expanded
+func parseICSRule(value string, params map[string]string) (*icsRule, bool) {
+	rule := &icsRule{interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.freq = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, false
+			}
+			rule.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, false
+			}
+			rule.count = n
+		case "UNTIL":
+			until, _, err := parseICSTime(val, params)
+			if err != nil {
+				return nil, false
+			}
+			rule.until = until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := icsWeekdays[strings.ToUpper(day)]
+				if !ok {
+					// Ordinals like 1MO belong to MONTHLY/YEARLY rules
+					return nil, false
+				}
+				rule.byDay = append(rule.byDay, weekday)
+			}
+		case "WKST":
+		default:
+			// BYMONTH, BYSETPOS etc. narrow the set in ways not expanded here
+			return nil, false
+		}
+	}
+
+	switch rule.freq {
+	case "DAILY":
+		if len(rule.byDay) > 0 {
+			return nil, false
+		}
+		return rule, true
+	case "WEEKLY":
+		return rule, true
+	}
+	return nil, false
+}
+
+// ImportDir returns the directory holding imported calendars (~/.miles-cli/calendars)
+func ImportDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".miles-cli", "calendars"), nil
+}
+
+// ImportICS checks that an iCalendar file parses and stores a copy under its
+// base name, replacing an earlier import of the same name
+func ImportICS(path string) (string, *Calendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	cal, err := ParseICS(strings.NewReader(string(data)))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	dir, err := ImportDir()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", nil, fmt.Errorf("failed to create calendar directory: %w", err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if err := os.WriteFile(filepath.Join(dir, name+".ics"), data, 0o600); err != nil {
+		return "", nil, err
+	}
+	return name, cal, nil
+}
+
+// ImportedCalendars returns the names of the imported calendars
+func ImportedCalendars() ([]string, error) {
+	dir, err := ImportDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.ics"))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(file), ".ics"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// RemoveImported deletes an imported calendar
+func RemoveImported(name string) error {
+	dir, err := ImportDir()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, filepath.Base(name)+".ics"))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no imported calendar named %s", name)
+	}
+	return err
+}
+
+// ImportedBusy returns the busy periods in [start, end) across all imported
+// calendars, and the names of the calendars read
+func ImportedBusy(start, end time.Time) ([]Busy, []string, error) {
+	names, err := ImportedCalendars()
+	if err != nil {
+		return nil, nil, err
+	}
+	dir, err := ImportDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var busy []Busy
+	for _, name := range names {
+		f, err := os.Open(filepath.Join(dir, name+".ics"))
+		if err != nil {
+			return nil, nil, err
+		}
+		cal, err := ParseICS(f)
+		f.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse imported calendar %s: %w", name, err)
+		}
+		busy = append(busy, cal.BusyTimes(start, end)...)
+	}
+	return busy, names, nil
+}
@@ -0,0 +1,152 @@
+package calsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+)
+
+// authorize returns an HTTP client authorised for the provider. A cached
+// token is reused (and refreshed) when available; otherwise the user is sent
+// through the browser-based authorization code flow with a loopback redirect.
+func authorize(ctx context.Context, provider string, conf *oauth2.Config) (*http.Client, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	tokenPath := filepath.Join(dir, provider+"-token.json")
+
+	token, err := loadToken(tokenPath)
+	if err != nil {
+		token, err = authorizeInteractive(ctx, conf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	source := conf.TokenSource(ctx, token)
+	fresh, err := source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh %s token (run with --reauth to sign in again): %w", provider, err)
+	}
+	if err := saveToken(tokenPath, fresh); err != nil {
+		return nil, err
+	}
+
+	return oauth2.NewClient(ctx, source), nil
+}
+
+// HasToken reports whether a provider has a cached token, i.e. whether
+// connecting to it can go ahead without a browser sign-in
+func HasToken(provider string) bool {
+	dir, err := Dir()
+	if err != nil {
+		return false
+	}
+	_, err = loadToken(filepath.Join(dir, provider+"-token.json"))
+	return err == nil
+}
+
+// ForgetToken removes the cached token for a provider
+func ForgetToken(provider string) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, provider+"-token.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// authorizeInteractive runs the authorization code flow with PKCE, listening
+// on a random loopback port for the redirect.
+func authorizeInteractive(ctx context.Context, conf *oauth2.Config) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start local callback server: %w", err)
+	}
+	defer listener.Close()
+
+	conf.RedirectURL = fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	state := oauth2.GenerateVerifier()
+	verifier := oauth2.GenerateVerifier()
+
+	type callback struct {
+		code string
+		err  error
+	}
+	results := make(chan callback, 1)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/callback" {
+			http.NotFound(w, r)
+			return
+		}
+		query := r.URL.Query()
+		switch {
+		case query.Get("error") != "":
+			results <- callback{err: fmt.Errorf("authorization denied: %s", query.Get("error"))}
+		case query.Get("state") != state:
+			results <- callback{err: errors.New("authorization failed: state mismatch")}
+		default:
+			results <- callback{code: query.Get("code")}
+		}
+		fmt.Fprintln(w, "Miles CLI is authorised. You can close this window.")
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := conf.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))
+	fmt.Println("Open this URL in your browser to authorise calendar access:")
+	fmt.Println()
+	fmt.Println("  " + authURL)
+	fmt.Println()
+	fmt.Println("Waiting for authorisation...")
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		token, err := conf.Exchange(ctx, result.code, oauth2.VerifierOption(verifier))
+		if err != nil {
+			return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+		}
+		return token, nil
+	}
+}
+
+func loadToken(path string) (*oauth2.Token, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func saveToken(path string, token *oauth2.Token) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create sync directory: %w", err)
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
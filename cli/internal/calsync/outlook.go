@@ -0,0 +1,176 @@
+package calsync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+const graphAPI = "https://graph.microsoft.com/v1.0"
+
+// OutlookConfig configures the Microsoft 365 / Outlook provider
+type OutlookConfig struct {
+	ClientID string
+	// Tenant defaults to "common"
+	Tenant string
+	// CalendarID defaults to the user's default calendar
+	CalendarID string
+}
+
+// Outlook pushes events to an Outlook calendar via Microsoft Graph
+type Outlook struct {
+	http     *http.Client
+	calendar string
+}
+
+// NewOutlook authorises against Microsoft and returns a provider
+func NewOutlook(ctx context.Context, cfg OutlookConfig) (*Outlook, error) {
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("outlook_client_id is not configured")
+	}
+	tenant := cfg.Tenant
+	if tenant == "" {
+		tenant = "common"
+	}
+	conf := &oauth2.Config{
+		ClientID: cfg.ClientID,
+		Endpoint: endpoints.AzureAD(tenant),
+		Scopes:   []string{"offline_access", "Calendars.ReadWrite"},
+	}
+
+	client, err := authorize(ctx, "outlook", conf)
+	if err != nil {
+		return nil, err
+	}
+	return &Outlook{http: client, calendar: cfg.CalendarID}, nil
+}
+
+// Name implements Provider
+func (o *Outlook) Name() string { return "outlook" }
+
+type graphBody struct {
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+type graphTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+type graphLocation struct {
+	DisplayName string `json:"displayName"`
+}
+
+type graphEvent struct {
+	ID       string        `json:"id,omitempty"`
+	Subject  string        `json:"subject"`
+	Body     graphBody     `json:"body"`
+	Location graphLocation `json:"location"`
+	Start    graphTime     `json:"start"`
+	End      graphTime     `json:"end"`
+}
+
+func toGraphEvent(event Event) graphEvent {
+	const layout = "2006-01-02T15:04:05"
+	return graphEvent{
+		Subject:  event.Title,
+		Body:     graphBody{ContentType: "text", Content: event.Description},
+		Location: graphLocation{DisplayName: event.Location},
+		Start:    graphTime{DateTime: event.Start.UTC().Format(layout), TimeZone: "UTC"},
+		End:      graphTime{DateTime: event.End.UTC().Format(layout), TimeZone: "UTC"},
+	}
+}
+
+func (o *Outlook) eventsURL() string {
+	if o.calendar == "" {
+		return graphAPI + "/me/events"
+	}
+	return fmt.Sprintf("%s/me/calendars/%s/events", graphAPI, url.PathEscape(o.calendar))
+}
+
+func (o *Outlook) calendarViewURL() string {
+	if o.calendar == "" {
+		return graphAPI + "/me/calendarView"
+	}
+	return fmt.Sprintf("%s/me/calendars/%s/calendarView", graphAPI, url.PathEscape(o.calendar))
+}
+
+func (o *Outlook) eventURL(eventID string) string {
+	return graphAPI + "/me/events/" + url.PathEscape(eventID)
+}
+
+// Create implements Provider
+func (o *Outlook) Create(ctx context.Context, event Event) (string, error) {
+	var created graphEvent
+	if err := doJSON(ctx, o.http, http.MethodPost, o.eventsURL(), toGraphEvent(event), &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// Update implements Provider
+func (o *Outlook) Update(ctx context.Context, eventID string, event Event) error {
+	return doJSON(ctx, o.http, http.MethodPatch, o.eventURL(eventID), toGraphEvent(event), nil)
+}
+
+// Delete implements Provider
+func (o *Outlook) Delete(ctx context.Context, eventID string) error {
+	err := doJSON(ctx, o.http, http.MethodDelete, o.eventURL(eventID), nil, nil)
+	if err == ErrEventNotFound {
+		return nil
+	}
+	return err
+}
+
+type graphCalendarView struct {
+	Value []struct {
+		ShowAs string    `json:"showAs"`
+		Start  graphTime `json:"start"`
+		End    graphTime `json:"end"`
+	} `json:"value"`
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// BusyTimes implements Provider by listing events in the calendar view.
+// Events marked free or working elsewhere don't count as busy.
+func (o *Outlook) BusyTimes(ctx context.Context, start, end time.Time) ([]Busy, error) {
+	query := url.Values{}
+	query.Set("startDateTime", start.UTC().Format(time.RFC3339))
+	query.Set("endDateTime", end.UTC().Format(time.RFC3339))
+	query.Set("$select", "showAs,start,end")
+	query.Set("$top", "100")
+	next := o.calendarViewURL() + "?" + query.Encode()
+
+	// Graph returns calendar view times in UTC unless asked otherwise
+	const layout = "2006-01-02T15:04:05.9999999"
+
+	var busy []Busy
+	for next != "" {
+		var page graphCalendarView
+		if err := doJSON(ctx, o.http, http.MethodGet, next, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, event := range page.Value {
+			if event.ShowAs == "free" || event.ShowAs == "workingElsewhere" {
+				continue
+			}
+			eventStart, err := time.Parse(layout, event.Start.DateTime)
+			if err != nil {
+				continue
+			}
+			eventEnd, err := time.Parse(layout, event.End.DateTime)
+			if err != nil {
+				continue
+			}
+			busy = append(busy, Busy{Start: eventStart, End: eventEnd})
+		}
+		next = page.NextLink
+	}
+	return busy, nil
+}
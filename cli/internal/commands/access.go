@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var roomsRequestAccessCmd = &cobra.Command{
+	Use:   "request-access ROOM",
+	Short: "Ask for access to a room restricted to other departments",
+	Long: `Ask for access to a room restricted to other departments. The room's
+owner gets an email from you, or its location's managers when nobody owns
+it, and replies to you directly.
+
+'miles rooms' marks the rooms you can't book as locked.
+
+Examples:
+  miles rooms request-access ROOM123
+  miles rooms request-access ROOM123 -m "Our team runs its sprint reviews here"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRoomIDs,
+	RunE:              runRoomsRequestAccess,
+}
+
+var roomsAccessMessage string
+
+func init() {
+	roomsRequestAccessCmd.Flags().StringVarP(&roomsAccessMessage, "message", "m", "", "note for whoever grants access")
+	roomsCmd.AddCommand(roomsRequestAccessCmd)
+}
+
+func runRoomsRequestAccess(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return requestRoomAccess(ctx, client, args[0], roomsAccessMessage)
+}
+
+// requestRoomAccess sends an access request and says who it went to
+func requestRoomAccess(ctx context.Context, client config.API, roomID, message string) error {
+	contacted, err := client.RequestRoomAccess(ctx, roomID, message)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(contacted)
+	}
+
+	fmt.Println("✓ Access request sent to:")
+	for _, contact := range contacted {
+		fmt.Printf("  %s <%s>\n", contact.Name, contact.Email)
+	}
+	fmt.Println("They'll reply to you by email.")
+	return nil
+}
+
+// roomLocked reports whether the server says the user can't book a room.
+// Servers from before room restrictions leave every room open.
+func roomLocked(room milesapi.Room) bool {
+	return room.CanBook != nil && !*room.CanBook
+}
+
+// checkRoomAccess stops a booking the server would refuse because the room
+// is restricted to other departments
+func checkRoomAccess(room *milesapi.Room) error {
+	if room == nil || !roomLocked(*room) {
+		return nil
+	}
+	var departments []string
+	if room.Departments != nil {
+		departments = *room.Departments
+	}
+	return &config.RestrictedRoomError{
+		Message:     fmt.Sprintf("%s is restricted to %s", derefString(room.Name), strings.Join(departments, ", ")),
+		RoomID:      derefString(room.Id),
+		Departments: departments,
+	}
+}
+
+// printRoomAccessHint follows a booking refused for a restricted room with
+// how to ask for access
+func printRoomAccessHint(err error) {
+	var restricted *config.RestrictedRoomError
+	if !errors.As(err, &restricted) || restricted.RoomID == "" {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Hint: ask the room's owner for access with:")
+	fmt.Fprintf(os.Stderr, "  miles rooms request-access %s\n", restricted.RoomID)
+}
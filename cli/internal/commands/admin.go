@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Administrative tools (admins; approval rules also for managers)",
+}
+
+var adminMergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge duplicate records",
+}
+
+var adminMergeRoomCmd = &cobra.Command{
+	Use:   "room SRC DST",
+	Short: "Merge a duplicate room into another",
+	Long: `Move every booking from room SRC into room DST and retire SRC.
+
+The bookings that would move are shown first. Nothing changes if any active
+booking in SRC overlaps one in DST; cancel or move those bookings and try
+again. Use the Admin Panel in miles-booking for an interactive wizard.
+
+Examples:
+  miles admin merge room oslo-fjord-2 oslo-fjord
+  miles admin merge room oslo-fjord-2 oslo-fjord --dry-run
+  miles admin merge room oslo-fjord-2 oslo-fjord --yes`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runAdminMergeRoom,
+	ValidArgsFunction: completeMergeRoomIDs,
+}
+
+var mergeDryRun bool
+
+func init() {
+	adminMergeRoomCmd.Flags().BoolVar(&mergeDryRun, "dry-run", false, "show the impact without merging")
+
+	adminMergeCmd.AddCommand(adminMergeRoomCmd)
+	adminCmd.AddCommand(adminMergeCmd)
+}
+
+// completeMergeRoomIDs completes the source and target room IDs
+func completeMergeRoomIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) >= 2 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeRoomIDs(cmd, args, toComplete)
+}
+
+func runAdminMergeRoom(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	if err := requireRole(token, milesapi.ADMIN); err != nil {
+		return err
+	}
+
+	sourceID, targetID := args[0], args[1]
+	if sourceID == targetID {
+		return fmt.Errorf("cannot merge a room into itself")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// Always preview first so the impact is known before anything changes
+	preview, err := client.MergeRoom(ctx, sourceID, targetID, true)
+	if err != nil {
+		return err
+	}
+
+	if output != "json" {
+		printMergePreview(preview)
+	} else if mergeDryRun || len(preview.Conflicts) > 0 {
+		if err := outputJSON(preview); err != nil {
+			return err
+		}
+	}
+
+	if len(preview.Conflicts) > 0 {
+		return fmt.Errorf("%d booking(s) would overlap in %s; nothing was merged", len(preview.Conflicts), targetID)
+	}
+
+	if mergeDryRun {
+		if output != "json" {
+			fmt.Println("Dry run - nothing was changed.")
+		}
+		return nil
+	}
+
+	if !assumeYes {
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("Move %d booking(s) to %s and retire %s", len(preview.Bookings), targetID, sourceID),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			return fmt.Errorf("merge cancelled")
+		}
+	}
+
+	merge, err := client.MergeRoom(ctx, sourceID, targetID, false)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(merge)
+	}
+
+	fmt.Printf("\n✓ Moved %d booking(s) from %s to %s\n", len(merge.Bookings), sourceID, targetID)
+	fmt.Printf("✓ Room %s has been retired\n", sourceID)
+	return nil
+}
+
+// printMergePreview shows which bookings a merge moves and which would clash
+func printMergePreview(merge *milesapi.RoomMerge) {
+	fmt.Printf("Merge %s into %s\n\n", merge.SourceRoomId, merge.TargetRoomId)
+
+	if len(merge.Bookings) == 0 {
+		fmt.Printf("No bookings to move.\n\n")
+	} else {
+		fmt.Printf("%d booking(s) will move:\n", len(merge.Bookings))
+		for _, booking := range merge.Bookings {
+			fmt.Printf("  - %s\n", describeMergeBooking(booking))
+		}
+		fmt.Println()
+	}
+
+	if len(merge.Conflicts) > 0 {
+		fmt.Printf("✗ These bookings overlap bookings in %s:\n", merge.TargetRoomId)
+		for _, conflict := range merge.Conflicts {
+			fmt.Printf("  - %s\n", describeMergeBooking(conflict.Booking))
+			fmt.Printf("    clashes with %s\n", describeMergeBooking(conflict.ConflictsWith))
+		}
+		fmt.Println()
+	}
+}
+
+// describeMergeBooking formats a booking as a single line
+func describeMergeBooking(booking milesapi.Booking) string {
+	title := "Untitled"
+	if booking.Title != nil {
+		title = *booking.Title
+	}
+	id := ""
+	if booking.Id != nil {
+		id = *booking.Id
+	}
+	when := ""
+	if booking.StartTime != nil && booking.EndTime != nil {
+		when = booking.StartTime.Local().Format("2006-01-02 15:04") + " - " + booking.EndTime.Local().Format("15:04")
+	}
+	line := fmt.Sprintf("%s  %s  [%s]", title, when, id)
+	if booking.Status != nil && *booking.Status == milesapi.BookingStatusCANCELLED {
+		line += " (cancelled)"
+	}
+	return line
+}
@@ -0,0 +1,611 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/snippet"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var adminExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export bookings, rooms or locations for a data warehouse",
+	Long: `Export a whole dataset with stable, snake_case field names for loading
+into a data warehouse or BI tool. Admins get everyone's bookings.
+
+Entities:
+  bookings   every booking starting between --from and --to, in any
+             status, oldest first. Fetched a --page-days window at a time.
+  rooms      every room, desks included
+  locations  every location
+
+Formats:
+  ndjson       one JSON object per line
+  parquet-csv  CSV shaped for Parquet conversion: a header of the field
+               names, comma-separated, UTF-8 without a BOM, empty for
+               null, lists as JSON arrays
+
+Times are UTC in RFC 3339. Descriptions are left out.
+
+With --out, progress is saved after every window, so an export that stops
+halfway is continued with --resume; it keeps the entity, format and period
+it started with. Progress is shown on stderr when it is a terminal.
+
+Examples:
+  miles admin export --entity bookings --from 2025-01-01 --to 2026-01-01 --out bookings.ndjson
+  miles admin export --entity bookings --from 2025-01-01 --format parquet-csv --out bookings.csv
+  miles admin export --out bookings.ndjson --resume
+  miles admin export --entity rooms --format parquet-csv > rooms.csv`,
+	Args: cobra.NoArgs,
+	RunE: runAdminExport,
+}
+
+var (
+	dataExportEntity   string
+	dataExportFrom     string
+	dataExportTo       string
+	dataExportFormat   string
+	dataExportOut      string
+	dataExportResume   bool
+	dataExportPageDays int
+)
+
+// Export formats
+const (
+	exportNDJSON     = "ndjson"
+	exportParquetCSV = "parquet-csv"
+)
+
+// exportEntities are the datasets miles admin export can write
+var exportEntities = []string{"bookings", "rooms", "locations"}
+
+// Fields of each entity, in the order records list them
+var (
+	bookingExportColumns = []string{
+		"booking_id", "room_id", "room_name", "location_id", "location_name", "user_id",
+		"group_id", "title", "status", "start_time", "end_time", "duration_minutes",
+		"buffer_minutes", "setup", "late_cancellation", "created_at", "updated_at",
+	}
+	roomExportColumns = []string{
+		"room_id", "name", "type", "location_id", "location_name", "floor", "wing",
+		"capacity", "amenities", "departments", "restricted", "is_active",
+		"min_duration_minutes", "max_duration_minutes", "created_at", "updated_at",
+	}
+	locationExportColumns = []string{
+		"location_id", "name", "address", "city", "country", "timezone",
+		"requires_approval", "late_cancel_minutes", "closed_weekdays", "created_at", "updated_at",
+	}
+)
+
+// exportState is an unfinished export's progress, kept next to --out so
+// --resume can continue it
+type exportState struct {
+	Entity string    `json:"entity"`
+	Format string    `json:"format"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Next   time.Time `json:"next"`   // Start of the next window to fetch
+	Offset int64     `json:"offset"` // Bytes of --out written up to Next
+	Rows   int       `json:"rows"`
+}
+
+func init() {
+	adminExportCmd.Flags().StringVar(&dataExportEntity, "entity", "bookings", "what to export: "+strings.Join(exportEntities, ", "))
+	adminExportCmd.Flags().StringVar(&dataExportFrom, "from", "", "bookings starting from this date (required for bookings)")
+	adminExportCmd.Flags().StringVar(&dataExportTo, "to", "", "bookings starting before this date, exclusive (default: tomorrow)")
+	adminExportCmd.Flags().StringVar(&dataExportFormat, "format", exportNDJSON, "output format: ndjson or parquet-csv")
+	adminExportCmd.Flags().StringVar(&dataExportOut, "out", "", "write to this file instead of stdout; needed for --resume")
+	adminExportCmd.Flags().BoolVar(&dataExportResume, "resume", false, "continue an unfinished export to --out")
+	adminExportCmd.Flags().IntVar(&dataExportPageDays, "page-days", 7, "days of bookings fetched per request")
+
+	adminCmd.AddCommand(adminExportCmd)
+}
+
+func runAdminExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+	if err := requireRole(token, milesapi.ADMIN); err != nil {
+		return err
+	}
+
+	state, err := exportStateFromFlags()
+	if err != nil {
+		return err
+	}
+	if dataExportPageDays < 1 {
+		return fmt.Errorf("--page-days must be at least 1")
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	out, err := openExportOut(state)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if err := runExport(ctx, client, out, state); err != nil {
+		if dataExportOut != "" {
+			return fmt.Errorf("%w\nContinue with: miles admin export --out %s --resume", err, dataExportOut)
+		}
+		return err
+	}
+	if dataExportOut != "" {
+		os.Remove(exportStatePath(dataExportOut))
+	}
+	return out.Close()
+}
+
+// exportStateFromFlags returns where the export starts: the saved progress
+// with --resume, or a new export of what the flags ask for
+func exportStateFromFlags() (*exportState, error) {
+	if dataExportResume {
+		if dataExportOut == "" {
+			return nil, fmt.Errorf("--resume needs --out, the file the export was writing")
+		}
+		data, err := os.ReadFile(exportStatePath(dataExportOut))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no unfinished export to %s", dataExportOut)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var state exportState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("read %s: %w", exportStatePath(dataExportOut), err)
+		}
+		return &state, nil
+	}
+
+	if dataExportOut != "" {
+		if _, err := os.Stat(exportStatePath(dataExportOut)); err == nil {
+			return nil, fmt.Errorf("an export to %s is unfinished; continue it with --resume, or delete %s to start over",
+				dataExportOut, exportStatePath(dataExportOut))
+		}
+	}
+
+	state := &exportState{Entity: dataExportEntity, Format: dataExportFormat}
+	if !isExportEntity(state.Entity) {
+		return nil, fmt.Errorf("unknown --entity %q: use %s", state.Entity, strings.Join(exportEntities, ", "))
+	}
+	if state.Format != exportNDJSON && state.Format != exportParquetCSV {
+		return nil, fmt.Errorf("unknown --format %q: use ndjson or parquet-csv", state.Format)
+	}
+	if state.Entity != "bookings" {
+		return state, nil
+	}
+
+	now := time.Now()
+	if dataExportFrom == "" {
+		return nil, fmt.Errorf("--from is required for bookings")
+	}
+	from, err := snippet.ParseDate(dataExportFrom, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --from: %w", err)
+	}
+	to := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	if dataExportTo != "" {
+		if to, err = snippet.ParseDate(dataExportTo, now); err != nil {
+			return nil, fmt.Errorf("invalid --to: %w", err)
+		}
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("--to must be after --from")
+	}
+	state.From, state.To, state.Next = from, to, from
+	return state, nil
+}
+
+func isExportEntity(entity string) bool {
+	for _, e := range exportEntities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// exportStatePath is where the progress of an export to out is kept
+func exportStatePath(out string) string {
+	return out + ".export-state"
+}
+
+// exportOut is where records go: stdout, or --out with its progress saved
+// as the export goes
+type exportOut struct {
+	file *os.File
+	w    *bufio.Writer
+}
+
+// openExportOut opens --out, or stdout without it. A resumed export drops
+// whatever was written after the last saved progress.
+func openExportOut(state *exportState) (*exportOut, error) {
+	if dataExportOut == "" {
+		return &exportOut{w: bufio.NewWriter(os.Stdout)}, nil
+	}
+
+	flags := os.O_RDWR | os.O_CREATE | os.O_TRUNC
+	if dataExportResume {
+		flags = os.O_RDWR
+	}
+	file, err := os.OpenFile(dataExportOut, flags, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Truncate(state.Offset); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(state.Offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &exportOut{file: file, w: bufio.NewWriter(file)}, nil
+}
+
+// checkpoint flushes what's written and saves the progress up to it
+func (o *exportOut) checkpoint(state *exportState) error {
+	if err := o.w.Flush(); err != nil {
+		return err
+	}
+	if o.file == nil {
+		return nil
+	}
+	offset, err := o.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	state.Offset = offset
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(exportStatePath(o.file.Name()), data, 0o644)
+}
+
+func (o *exportOut) Close() error {
+	if err := o.w.Flush(); err != nil {
+		return err
+	}
+	if o.file == nil {
+		return nil
+	}
+	return o.file.Close()
+}
+
+// runExport writes the records of state's entity from where it stands
+func runExport(ctx context.Context, client config.API, out *exportOut, state *exportState) error {
+	lookup := newTemplateLookup(ctx, client)
+	records := newRecordWriter(out.w, state.Format)
+	progress := newExportProgress()
+	defer progress.done()
+
+	switch state.Entity {
+	case "rooms", "locations":
+		columns, rows, err := exportCatalog(ctx, client, lookup, state.Entity)
+		if err != nil {
+			return err
+		}
+		if err := records.header(columns); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := records.write(columns, row); err != nil {
+				return err
+			}
+		}
+		state.Rows = len(rows)
+		progress.update(state)
+		return records.flush()
+	}
+
+	if state.Offset == 0 {
+		if err := records.header(bookingExportColumns); err != nil {
+			return err
+		}
+	}
+	for state.Next.Before(state.To) {
+		end := state.Next.AddDate(0, 0, dataExportPageDays)
+		if end.After(state.To) {
+			end = state.To
+		}
+		bookings, err := client.GetBookingsBetween(ctx, state.Next, end)
+		if err != nil {
+			return err
+		}
+
+		// Bookings overlapping two windows belong to the one they start in
+		page := bookings[:0]
+		for _, booking := range bookings {
+			if booking.StartTime != nil && !booking.StartTime.Before(state.Next) && booking.StartTime.Before(end) {
+				page = append(page, booking)
+			}
+		}
+		sort.SliceStable(page, func(i, j int) bool {
+			if !page[i].StartTime.Equal(*page[j].StartTime) {
+				return page[i].StartTime.Before(*page[j].StartTime)
+			}
+			return derefString(page[i].Id) < derefString(page[j].Id)
+		})
+
+		for _, booking := range page {
+			row, err := bookingExportRow(booking, lookup)
+			if err != nil {
+				return err
+			}
+			if err := records.write(bookingExportColumns, row); err != nil {
+				return err
+			}
+		}
+		if err := records.flush(); err != nil {
+			return err
+		}
+		state.Rows += len(page)
+		state.Next = end
+		if err := out.checkpoint(state); err != nil {
+			return err
+		}
+		progress.update(state)
+	}
+	return nil
+}
+
+// exportCatalog returns the columns and records of rooms or locations
+func exportCatalog(ctx context.Context, client config.API, lookup *templateLookup, entity string) ([]string, [][]any, error) {
+	var rows [][]any
+	if entity == "locations" {
+		locations, err := client.GetLocations(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, location := range locations {
+			rows = append(rows, locationExportRow(location))
+		}
+		return locationExportColumns, rows, nil
+	}
+
+	rooms, err := client.GetRooms(ctx, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, room := range rooms {
+		location, err := lookup.location(room.LocationId)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows = append(rows, roomExportRow(room, location))
+	}
+	return roomExportColumns, rows, nil
+}
+
+// bookingExportRow returns a booking's fields in bookingExportColumns order
+func bookingExportRow(booking milesapi.Booking, lookup *templateLookup) ([]any, error) {
+	room, err := lookup.room(booking.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	location, err := lookup.location(room.LocationId)
+	if err != nil {
+		return nil, err
+	}
+
+	var duration any
+	if booking.StartTime != nil && booking.EndTime != nil {
+		duration = int(booking.EndTime.Sub(*booking.StartTime).Minutes())
+	}
+	var status, setup any
+	if booking.Status != nil {
+		status = string(*booking.Status)
+	}
+	if booking.Setup != nil {
+		setup = string(*booking.Setup)
+	}
+	return []any{
+		exportString(booking.Id), exportString(booking.RoomId), exportString(room.Name),
+		exportString(room.LocationId), exportString(location.Name), exportString(booking.UserId),
+		exportString(booking.GroupId), exportString(booking.Title), status,
+		exportTime(booking.StartTime), exportTime(booking.EndTime), duration,
+		exportInt(booking.BufferMinutes), setup, exportBool(booking.LateCancellation),
+		exportTime(booking.CreatedAt), exportTime(booking.UpdatedAt),
+	}, nil
+}
+
+// roomExportRow returns a room's fields in roomExportColumns order
+func roomExportRow(room milesapi.Room, location milesapi.Location) []any {
+	var roomType any
+	if room.Type != nil {
+		roomType = string(*room.Type)
+	}
+	return []any{
+		exportString(room.Id), exportString(room.Name), roomType, exportString(room.LocationId),
+		exportString(location.Name), exportString(room.Floor), exportString(room.Wing),
+		exportInt(room.Capacity), exportStrings(room.Amenities), exportStrings(room.Departments),
+		exportBool(room.Restricted), exportBool(room.IsActive),
+		exportInt(room.MinDurationMinutes), exportInt(room.MaxDurationMinutes),
+		exportTime(room.CreatedAt), exportTime(room.UpdatedAt),
+	}
+}
+
+// locationExportRow returns a location's fields in locationExportColumns order
+func locationExportRow(location milesapi.Location) []any {
+	var closed any
+	if location.ClosedWeekdays != nil {
+		closed = *location.ClosedWeekdays
+	}
+	return []any{
+		exportString(location.Id), exportString(location.Name), exportString(location.Address),
+		exportString(location.City), exportString(location.Country), exportString(location.Timezone),
+		exportBool(location.RequiresApproval), exportInt(location.LateCancelMinutes), closed,
+		exportTime(location.CreatedAt), exportTime(location.UpdatedAt),
+	}
+}
+
+// Optional fields are exported as null rather than their zero value
+
+func exportString(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func exportInt(n *int) any {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
+
+func exportBool(b *bool) any {
+	if b == nil {
+		return nil
+	}
+	return *b
+}
+
+func exportStrings(s *[]string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func exportTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// recordWriter writes records in an export format
+type recordWriter interface {
+	header(columns []string) error
+	write(columns []string, values []any) error
+	flush() error
+}
+
+func newRecordWriter(w io.Writer, format string) recordWriter {
+	if format == exportParquetCSV {
+		return &csvRecords{w: csv.NewWriter(w)}
+	}
+	return &ndjsonRecords{w: w}
+}
+
+// ndjsonRecords writes each record as a JSON object on its own line, with
+// fields in column order
+type ndjsonRecords struct {
+	w io.Writer
+}
+
+func (r *ndjsonRecords) header(columns []string) error { return nil }
+
+func (r *ndjsonRecords) write(columns []string, values []any) error {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		b.WriteString(strconv.Quote(column))
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(r.w, b.String())
+	return err
+}
+
+func (r *ndjsonRecords) flush() error { return nil }
+
+// csvRecords writes records as CSV for Parquet conversion
+type csvRecords struct {
+	w *csv.Writer
+}
+
+func (r *csvRecords) header(columns []string) error {
+	return r.w.Write(columns)
+}
+
+func (r *csvRecords) write(columns []string, values []any) error {
+	fields := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			fields[i] = v
+		case int:
+			fields[i] = strconv.Itoa(v)
+		case bool:
+			fields[i] = strconv.FormatBool(v)
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			fields[i] = string(data)
+		}
+	}
+	return r.w.Write(fields)
+}
+
+func (r *csvRecords) flush() error {
+	r.w.Flush()
+	return r.w.Error()
+}
+
+// exportProgress shows how far an export has come on stderr, when it is a
+// terminal
+type exportProgress struct {
+	show  bool
+	shown bool
+}
+
+func newExportProgress() *exportProgress {
+	return &exportProgress{show: term.IsTerminal(int(os.Stderr.Fd()))}
+}
+
+func (p *exportProgress) update(state *exportState) {
+	if !p.show {
+		return
+	}
+	p.shown = true
+	if state.Entity != "bookings" {
+		fmt.Fprintf(os.Stderr, "\rExported %d %s", state.Rows, state.Entity)
+		return
+	}
+	total := state.To.Sub(state.From)
+	done := state.Next.Sub(state.From)
+	fmt.Fprintf(os.Stderr, "\rExporting bookings: up to %s, %3.0f%%, %d rows ",
+		state.Next.Format(time.DateOnly), 100*done.Seconds()/total.Seconds(), state.Rows)
+}
+
+func (p *exportProgress) done() {
+	if p.shown {
+		fmt.Fprintln(os.Stderr)
+	}
+}
@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// aliasPlaceholder matches $1-$9 in an alias, or ${10} and beyond
+var aliasPlaceholder = regexp.MustCompile(`\$(?:(\d)|\{(\d+)\})`)
+
+// expandAliases replaces a command alias from "aliases" in the config file
+// with what it stands for, like git aliases:
+//
+//	aliases:
+//	  b: book -r warroom -t "Quick sync"
+//	  with: book -t "Meeting with $1" -r $2
+//
+// The alias is the first argument after any global flags. $1, $2... are
+// replaced by the arguments after it and $@ by all of them; arguments no
+// placeholder uses are appended. Aliases can't replace built-in commands.
+func expandAliases(args []string) ([]string, error) {
+	i := commandIndex(rootCmd, args)
+	if i < 0 {
+		return args, nil
+	}
+	// Shell completion asks with the command line after __complete
+	if args[i] == cobra.ShellCompRequestCmd || args[i] == cobra.ShellCompNoDescRequestCmd {
+		rest, err := expandAliases(args[i+1:])
+		if err != nil {
+			return args, nil
+		}
+		return append(append([]string{}, args[:i+1]...), rest...), nil
+	}
+
+	// Aliases live in config, which has to be read before cobra sees the
+	// arguments; commandIndex has found any --config by now
+	initConfig()
+	aliases := viper.GetStringMapString("aliases")
+	seen := map[string]bool{}
+	for {
+		name := args[i]
+		expansion, ok := aliases[strings.ToLower(name)]
+		if !ok {
+			return args, nil
+		}
+		if isBuiltinCommand(name) {
+			fmt.Fprintf(os.Stderr, "Warning: alias %q is ignored because %q is a built-in command\n", name, name)
+			return args, nil
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("alias %q expands to itself", name)
+		}
+		seen[name] = true
+
+		words, err := splitCommandLine(expansion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alias %q: %w", name, err)
+		}
+		if len(words) == 0 {
+			return nil, fmt.Errorf("alias %q is empty", name)
+		}
+		expanded, err := substituteAliasArgs(name, words, args[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		args = append(append([]string{}, args[:i]...), expanded...)
+	}
+}
+
+// substituteAliasArgs fills an alias's placeholders with args
+func substituteAliasArgs(name string, words, args []string) ([]string, error) {
+	used := make([]bool, len(args))
+	all := false
+	needed := 0
+	var out []string
+
+	for _, word := range words {
+		if word == "$@" {
+			all = true
+			out = append(out, args...)
+			continue
+		}
+		out = append(out, aliasPlaceholder.ReplaceAllStringFunc(word, func(placeholder string) string {
+			m := aliasPlaceholder.FindStringSubmatch(placeholder)
+			n, _ := strconv.Atoi(m[1] + m[2])
+			if n > len(args) {
+				needed = max(needed, n)
+				return placeholder
+			}
+			if n < 1 {
+				return placeholder
+			}
+			used[n-1] = true
+			return args[n-1]
+		}))
+	}
+	if needed > 0 {
+		return nil, fmt.Errorf("alias %q needs %d argument(s), got %d", name, needed, len(args))
+	}
+
+	if !all {
+		for i, arg := range args {
+			if !used[i] {
+				out = append(out, arg)
+			}
+		}
+	}
+	return out, nil
+}
+
+// commandIndex returns the index of the first argument that isn't a global
+// flag or a flag's value, or -1 when there is none. It also picks up
+// --config so aliases come from the right file.
+func commandIndex(cmd *cobra.Command, args []string) int {
+	flags := cmd.PersistentFlags()
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return i
+		}
+
+		var flag *pflag.Flag
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "--") {
+			flag = flags.Lookup(name)
+		} else if len(name) == 1 {
+			flag = flags.ShorthandLookup(name)
+		} else {
+			// -ojson
+			flag = flags.ShorthandLookup(name[:1])
+			hasValue = true
+		}
+		if flag == nil || flag.Value.Type() == "bool" || hasValue {
+			if flag != nil && flag.Name == "config" && hasValue {
+				cfgFile = value
+			}
+			continue
+		}
+		// The flag's value is the next argument
+		i++
+		if flag.Name == "config" && i < len(args) {
+			cfgFile = args[i]
+		}
+	}
+	return -1
+}
+
+// isBuiltinCommand reports whether name is one of miles's own commands
+func isBuiltinCommand(name string) bool {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help" || name == "completion"
+}
+
+// splitCommandLine splits an alias into words the way a shell would,
+// honouring single and double quotes and backslash escapes
+func splitCommandLine(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+				word.WriteRune(runes[i])
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,376 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/snippet"
+	"github.com/spf13/cobra"
+)
+
+// availabilityMaxDays is the longest window --from/--to may cover
+const availabilityMaxDays = 31
+
+var availabilityCmd = &cobra.Command{
+	Use:   "availability [ROOM_ID]",
+	Short: "Show when a room is free and when it's booked",
+	Long: `Show when a room is free and booked, without going through the booking
+flow. Each day gets an hour bar, followed by the free slots and bookings
+in order:
+
+  08 ░░░░████████░░░░░░░░░░░░████░░░░░░░░░░░░ 18
+  █ booked  ░ free  (one block = 15 min)
+
+The bar covers 08-18, widened to include bookings outside those hours.
+Free slots are counted within 08-18 unless --from or --to give a time.
+--date, --from and --to take a date or a day like "tomorrow" or
+"next friday"; --from and --to also take a time, e.g. "tomorrow 13:00".
+A date given to --to is included.
+
+-o json prints the free slots and bookings as one object, -o csv one row
+per slot.
+
+Examples:
+  miles availability ROOM123
+  miles availability -r ROOM123 -d 2025-10-20
+  miles availability -r ROOM123 --from monday --to friday
+  miles availability -r ROOM123 --from "tomorrow 12:00" --to "tomorrow 16:00" -o json`,
+	Aliases:           []string{"avail"},
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRoomIDs,
+	RunE:              runAvailability,
+}
+
+var (
+	availabilityDate string
+	availabilityRoom string
+	availabilityFrom string
+	availabilityTo   string
+)
+
+func init() {
+	availabilityCmd.Flags().StringVarP(&availabilityDate, "date", "d", "today", `day to show, e.g. 2025-10-20, "tomorrow" or "next friday"`)
+	availabilityCmd.Flags().StringVarP(&availabilityRoom, "room", "r", "", "room ID, instead of the argument")
+	availabilityCmd.Flags().StringVar(&availabilityFrom, "from", "", `start of the window, a day or a day and time (default today)`)
+	availabilityCmd.Flags().StringVar(&availabilityTo, "to", "", `end of the window; a day is included (default the end of the first day)`)
+	availabilityCmd.MarkFlagsMutuallyExclusive("date", "from")
+	availabilityCmd.MarkFlagsMutuallyExclusive("date", "to")
+	availabilityCmd.RegisterFlagCompletionFunc("room", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeRoomIDs(cmd, nil, toComplete)
+	})
+}
+
+// availabilitySlot is a stretch of the window: free, or taken by Booking
+type availabilitySlot struct {
+	Start   time.Time
+	End     time.Time
+	Booking *milesapi.Booking
+}
+
+// freeSlot is a free stretch of time as -o json prints it
+type freeSlot struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Minutes int       `json:"minutes"`
+}
+
+// availabilityReport is -o json output: the window asked about, when the
+// room is free in it and the bookings taking the rest
+type availabilityReport struct {
+	RoomID   string             `json:"roomId"`
+	RoomName string             `json:"roomName,omitempty"`
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Free     []freeSlot         `json:"free"`
+	Bookings []milesapi.Booking `json:"bookings"`
+}
+
+var availabilityCSVColumns = []csvColumn{
+	{"date", "Date"},
+	{"start", "Start"},
+	{"end", "End"},
+	{"status", "Status"},
+	{"minutes", "Minutes"},
+	{"title", "Title"},
+	{"booking_id", "BookingID"},
+}
+
+func runAvailability(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	roomID := availabilityRoom
+	if len(args) == 1 {
+		if roomID != "" && roomID != args[0] {
+			return fmt.Errorf("give the room either as an argument or with --room, not both")
+		}
+		roomID = args[0]
+	}
+	if roomID == "" {
+		return fmt.Errorf("which room? Give its ID as an argument or with --room (see 'miles rooms')")
+	}
+
+	from, to, workingHours, err := availabilityWindow(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	found, err := client.CheckRoomAvailability(ctx, roomID, from, to)
+	if err != nil {
+		return err
+	}
+	bookings := make([]milesapi.Booking, 0, len(found))
+	for _, booking := range found {
+		if booking.StartTime == nil || booking.EndTime == nil ||
+			booking.Status != nil && *booking.Status == milesapi.BookingStatusCANCELLED {
+			continue
+		}
+		bookings = append(bookings, booking)
+	}
+	sort.Slice(bookings, func(i, j int) bool {
+		return bookings[i].StartTime.Before(*bookings[j].StartTime)
+	})
+
+	free := freeSlots(bookings, availabilitySpans(from, to, workingHours))
+
+	name := ""
+	if room, err := findRoom(ctx, client, roomID); err == nil && room.Name != nil {
+		name = *room.Name
+	}
+
+	switch output {
+	case "json":
+		report := availabilityReport{RoomID: roomID, RoomName: name, From: from, To: to, Free: []freeSlot{}, Bookings: bookings}
+		for _, slot := range free {
+			report.Free = append(report.Free, freeSlot{Start: slot.Start, End: slot.End, Minutes: int(slot.End.Sub(slot.Start).Minutes())})
+		}
+		return outputJSON(report)
+	case "csv":
+		return outputAvailabilityCSV(availabilityTimeline(free, bookings))
+	}
+
+	if name == "" {
+		name = roomID
+	}
+	return outputAvailabilityTable(name, from, to, bookings, availabilityTimeline(free, bookings))
+}
+
+// availabilityWindow returns the window to look at from --date, or from
+// --from and --to, and whether free time is counted in working hours only,
+// which is when no time of day was given
+func availabilityWindow(cmd *cobra.Command, now time.Time) (from, to time.Time, workingHours bool, err error) {
+	if !cmd.Flags().Changed("from") && !cmd.Flags().Changed("to") {
+		day, err := snippet.ParseDate(availabilityDate, now)
+		if err != nil {
+			return from, to, false, fmt.Errorf("invalid --date: %w", err)
+		}
+		return day, day.AddDate(0, 0, 1), true, nil
+	}
+
+	from, fromIsDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), true
+	if availabilityFrom != "" {
+		if from, fromIsDay, err = parseAvailabilityBound(availabilityFrom, now); err != nil {
+			return from, to, false, fmt.Errorf("invalid --from: %w", err)
+		}
+	}
+
+	// Without --to, the rest of the first day
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	to, toIsDay := first.AddDate(0, 0, 1), fromIsDay
+	if availabilityTo != "" {
+		if to, toIsDay, err = parseAvailabilityBound(availabilityTo, now); err != nil {
+			return from, to, false, fmt.Errorf("invalid --to: %w", err)
+		}
+		if toIsDay {
+			// The whole of that day
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+
+	switch {
+	case !to.After(from):
+		return from, to, false, fmt.Errorf("--to must be after --from")
+	case to.Sub(from) > availabilityMaxDays*24*time.Hour:
+		return from, to, false, fmt.Errorf("--from and --to may cover at most %d days", availabilityMaxDays)
+	}
+	return from, to, fromIsDay && toIsDay, nil
+}
+
+// parseAvailabilityBound reads --from or --to: a day, or a day and a time
+// of day. isDay reports whether it was only a day, returned as midnight.
+func parseAvailabilityBound(text string, now time.Time) (t time.Time, isDay bool, err error) {
+	if day, err := snippet.ParseDate(text, now); err == nil {
+		return day, true, nil
+	}
+	parsed, err := snippet.Parse(text, now)
+	if err != nil {
+		return t, false, fmt.Errorf("can't read %q as a day or a day and time", text)
+	}
+	if parsed.Title != "" {
+		return t, false, fmt.Errorf("can't make sense of %q in %q", parsed.Title, text)
+	}
+	return parsed.Start, false, nil
+}
+
+// availabilitySpans splits [from, to) into the stretches free time is
+// counted in: each day's working hours, or the whole window
+func availabilitySpans(from, to time.Time, workingHours bool) []availabilitySlot {
+	if !workingHours {
+		return []availabilitySlot{{Start: from, End: to}}
+	}
+
+	var spans []availabilitySlot
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		start := time.Date(day.Year(), day.Month(), day.Day(), hourBarFrom, 0, 0, 0, day.Location())
+		end := time.Date(day.Year(), day.Month(), day.Day(), hourBarTo, 0, 0, 0, day.Location())
+		spans = append(spans, availabilitySlot{Start: start, End: end})
+	}
+	return spans
+}
+
+// freeSlots returns the parts of spans no booking takes. bookings must be
+// sorted by start.
+func freeSlots(bookings []milesapi.Booking, spans []availabilitySlot) []availabilitySlot {
+	var free []availabilitySlot
+	for _, span := range spans {
+		cursor := span.Start
+		for _, booking := range bookings {
+			start, end := *booking.StartTime, *booking.EndTime
+			if !end.After(cursor) || !start.Before(span.End) {
+				continue
+			}
+			if start.After(cursor) {
+				free = append(free, availabilitySlot{Start: cursor, End: start})
+			}
+			cursor = end
+		}
+		if cursor.Before(span.End) {
+			free = append(free, availabilitySlot{Start: cursor, End: span.End})
+		}
+	}
+	return free
+}
+
+// availabilityTimeline puts free slots and bookings in one list, by start
+func availabilityTimeline(free []availabilitySlot, bookings []milesapi.Booking) []availabilitySlot {
+	slots := append([]availabilitySlot(nil), free...)
+	for i := range bookings {
+		slots = append(slots, availabilitySlot{Start: *bookings[i].StartTime, End: *bookings[i].EndTime, Booking: &bookings[i]})
+	}
+	sort.SliceStable(slots, func(i, j int) bool {
+		return slots[i].Start.Before(slots[j].Start)
+	})
+	return slots
+}
+
+// outputAvailabilityTable prints an hour bar for each day of the window,
+// then the timeline
+func outputAvailabilityTable(name string, from, to time.Time, bookings []milesapi.Booking, slots []availabilitySlot) error {
+	local := from.Local()
+	firstDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	lastDay := to.Add(-time.Nanosecond).Local()
+	singleDay := lastDay.Before(firstDay.AddDate(0, 0, 1))
+
+	if singleDay {
+		fmt.Printf("%s, %s\n\n", name, firstDay.Format("Monday, January 2"))
+	} else {
+		fmt.Printf("%s, %s – %s\n\n", name, firstDay.Format("Mon Jan 2"), lastDay.Format("Mon Jan 2"))
+	}
+
+	var bar hourBar
+	for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		bar = hourBar{day: day, bookings: bookings}
+		if singleDay {
+			fmt.Printf("  %s\n", bar)
+		} else {
+			fmt.Printf("  %s  %s\n", day.Format("Mon 02"), bar)
+		}
+	}
+	fmt.Printf("  %s\n\n", bar.legend())
+
+	if len(slots) == 0 {
+		fmt.Println("No free time and no bookings in this window.")
+		return nil
+	}
+
+	// The day is in the heading when there's only one
+	columns := []tableColumn{
+		{header: "Day", width: 10, priority: 4},
+		{header: "From", width: 5, priority: 6},
+		{header: "To", width: 5, priority: 6},
+		{header: "Status", width: 6, priority: 5},
+		{header: "Length", width: 7, priority: 2},
+		{header: "Booking", width: 30, minWidth: 10, priority: 3},
+	}
+	var rows [][]string
+	for _, slot := range slots {
+		status, title := "Free", ""
+		if slot.Booking != nil {
+			status, title = "Booked", availabilityTitle(*slot.Booking)
+		}
+		rows = append(rows, []string{
+			slot.Start.Local().Format("Mon Jan 2"),
+			slot.Start.Local().Format("15:04"),
+			slot.End.Local().Format("15:04"),
+			status,
+			formatDuration(slot.End.Sub(slot.Start)),
+			title,
+		})
+	}
+	if singleDay {
+		columns = columns[1:]
+		for i := range rows {
+			rows[i] = rows[i][1:]
+		}
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+// outputAvailabilityCSV prints the timeline, a row per slot
+func outputAvailabilityCSV(slots []availabilitySlot) error {
+	w, err := newCSVWriter(os.Stdout, availabilityCSVColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, slot := range slots {
+		status, title, id := "free", "", ""
+		if slot.Booking != nil {
+			status, title, id = "booked", availabilityTitle(*slot.Booking), derefString(slot.Booking.Id)
+		}
+		w.Write([]string{
+			slot.Start.Local().Format(time.DateOnly),
+			slot.Start.Local().Format("15:04"),
+			slot.End.Local().Format("15:04"),
+			status,
+			strconv.Itoa(int(slot.End.Sub(slot.Start).Minutes())),
+			title,
+			id,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// availabilityTitle returns a booking's title, which the server may
+// withhold for other people's bookings
+func availabilityTitle(booking milesapi.Booking) string {
+	if booking.Title == nil || *booking.Title == "" {
+		return "Booked"
+	}
+	return *booking.Title
+}
@@ -0,0 +1,430 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load-test the API and report latency percentiles",
+	Long: `Send a batch of requests to one endpoint and report throughput and
+latency percentiles, to check how a server scales before rolling out.
+
+Endpoints:
+  locations      List locations
+  rooms          List rooms
+  bookings       List your bookings
+  availability   Room availability for the next week (--room, or the first room)
+  quota          Your booking quota
+  book           Create and cancel a booking in --room (needs --allow-writes)
+
+Every endpoint except book is read-only. book creates throwaway bookings
+more than a year ahead and cancels each straight away; each request is timed
+as the create and cancel together.
+
+You are asked to confirm before anything is sent. Press Ctrl+C to stop
+early and report on the requests that finished.
+
+Examples:
+  miles bench --endpoint rooms
+  miles bench --requests 500 --concurrency 20 --endpoint rooms
+  miles bench -e availability --room oslo-fjord -o json --yes
+  miles bench -e book --room test-room --allow-writes --requests 50`,
+	RunE: runBench,
+}
+
+var (
+	benchRequests    int
+	benchConcurrency int
+	benchEndpoint    string
+	benchRoomID      string
+	benchAllowWrites bool
+)
+
+func init() {
+	benchCmd.Flags().IntVarP(&benchRequests, "requests", "n", 100, "total number of requests")
+	benchCmd.Flags().IntVarP(&benchConcurrency, "concurrency", "c", 10, "requests in flight at once")
+	benchCmd.Flags().StringVarP(&benchEndpoint, "endpoint", "e", "rooms", "endpoint to hit: "+strings.Join(benchEndpointNames(), ", "))
+	benchCmd.Flags().StringVarP(&benchRoomID, "room", "r", "", "room for the availability and book endpoints")
+	benchCmd.Flags().BoolVar(&benchAllowWrites, "allow-writes", false, "allow endpoints that create data")
+
+	benchCmd.RegisterFlagCompletionFunc("room", completeRoomIDs)
+	benchCmd.RegisterFlagCompletionFunc("endpoint", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return benchEndpointNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// benchOp is an operation the benchmark can repeat. Call receives the
+// request's index so write endpoints can keep requests from colliding.
+type benchOp struct {
+	description string
+	auth        bool // Needs a login
+	write       bool // Creates data on the server
+	needsRoom   bool
+	call        func(ctx context.Context, client config.API, roomID string, i int) error
+}
+
+var benchEndpoints = map[string]benchOp{
+	"locations": {
+		description: "GET /api/locations",
+		call: func(ctx context.Context, client config.API, roomID string, i int) error {
+			_, err := client.GetLocations(ctx)
+			return err
+		},
+	},
+	"rooms": {
+		description: "GET /api/rooms",
+		call: func(ctx context.Context, client config.API, roomID string, i int) error {
+			_, err := client.GetRooms(ctx, "")
+			return err
+		},
+	},
+	"bookings": {
+		description: "GET /api/bookings",
+		auth:        true,
+		call: func(ctx context.Context, client config.API, roomID string, i int) error {
+			_, err := client.GetBookings(ctx)
+			return err
+		},
+	},
+	"availability": {
+		description: "GET /api/rooms/{id}/availability",
+		needsRoom:   true,
+		call: func(ctx context.Context, client config.API, roomID string, i int) error {
+			now := time.Now()
+			_, err := client.GetRoomAvailability(ctx, roomID, now, now.AddDate(0, 0, 7))
+			return err
+		},
+	},
+	"quota": {
+		description: "GET /api/bookings/quota",
+		auth:        true,
+		call: func(ctx context.Context, client config.API, roomID string, i int) error {
+			_, err := client.GetQuota(ctx, time.Now())
+			return err
+		},
+	},
+	"book": {
+		description: "POST /api/bookings + DELETE /api/bookings/{id}",
+		auth:        true,
+		write:       true,
+		needsRoom:   true,
+		call:        benchBook,
+	},
+}
+
+// benchBookStart is how far ahead throwaway bookings are made, well past
+// anything real
+const benchBookStart = 400 * 24 * time.Hour
+
+// benchBook creates a short booking in its own slot and cancels it
+func benchBook(ctx context.Context, client config.API, roomID string, i int) error {
+	base := time.Now().Add(benchBookStart).Truncate(24 * time.Hour)
+	start := base.Add(time.Duration(i) * 30 * time.Minute)
+	description := "Created by miles bench and cancelled immediately"
+
+	booking, err := client.CreateBooking(ctx, milesapi.BookingInput{
+		RoomId:      roomID,
+		StartTime:   start,
+		EndTime:     start.Add(15 * time.Minute),
+		Title:       "miles bench",
+		Description: &description,
+	})
+	if err != nil {
+		return err
+	}
+	if booking.Id == nil {
+		return fmt.Errorf("create booking returned no ID")
+	}
+	_, err = client.CancelBooking(ctx, *booking.Id)
+	return err
+}
+
+func benchEndpointNames() []string {
+	names := make([]string, 0, len(benchEndpoints))
+	for name := range benchEndpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// benchResult summarises a benchmark run
+type benchResult struct {
+	Endpoint    string         `json:"endpoint"`
+	Target      string         `json:"target"`
+	Requests    int            `json:"requests"`
+	Concurrency int            `json:"concurrency"`
+	Succeeded   int            `json:"succeeded"`
+	Failed      int            `json:"failed"`
+	Duration    time.Duration  `json:"durationNs"`
+	Throughput  float64        `json:"requestsPerSecond"`
+	Latency     benchLatency   `json:"latencyNs"`
+	Errors      map[string]int `json:"errors,omitempty"`
+	Interrupted bool           `json:"interrupted,omitempty"`
+}
+
+// benchLatency holds latency statistics over successful requests
+type benchLatency struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P95  time.Duration `json:"p95"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	endpoint, ok := benchEndpoints[benchEndpoint]
+	if !ok {
+		return fmt.Errorf("unknown endpoint %q (expected one of: %s)", benchEndpoint, strings.Join(benchEndpointNames(), ", "))
+	}
+	if benchRequests < 1 {
+		return fmt.Errorf("--requests must be at least 1")
+	}
+	if benchConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if endpoint.write && !benchAllowWrites {
+		return fmt.Errorf("the %s endpoint creates bookings; pass --allow-writes to run it", benchEndpoint)
+	}
+	if endpoint.write && benchRoomID == "" {
+		return fmt.Errorf("the %s endpoint needs --room; use a room nobody books", benchEndpoint)
+	}
+
+	token := getAuthToken()
+	if endpoint.auth && token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	roomID := benchRoomID
+	if endpoint.needsRoom && roomID == "" {
+		rooms, err := client.GetRooms(ctx, "")
+		if err != nil {
+			return err
+		}
+		if len(rooms) == 0 || rooms[0].Id == nil {
+			return fmt.Errorf("no rooms found; pass --room")
+		}
+		roomID = *rooms[0].Id
+	}
+
+	target := serverTarget()
+	if !assumeYes {
+		fmt.Printf("About to send %d request(s) to %s, %d at a time\n", benchRequests, target, benchConcurrency)
+		fmt.Printf("  Endpoint: %s (%s)\n", benchEndpoint, endpoint.description)
+		if endpoint.needsRoom {
+			fmt.Printf("  Room:     %s\n", roomID)
+		}
+		if endpoint.write {
+			fmt.Printf("  ⚠ Creates and cancels %d booking(s)\n", benchRequests)
+		}
+		fmt.Println()
+
+		prompt := promptui.Prompt{
+			Label:     "Start the benchmark",
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			return fmt.Errorf("benchmark cancelled")
+		}
+	}
+
+	// Stop handing out requests on Ctrl+C and report what finished
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	result := runBenchRequests(ctx, client, endpoint, roomID)
+	result.Endpoint = benchEndpoint
+	result.Target = target
+	result.Concurrency = benchConcurrency
+
+	if output == "json" {
+		return outputJSON(result)
+	}
+	printbenchResult(result)
+	return nil
+}
+
+// benchSample is the outcome of one request
+type benchSample struct {
+	latency time.Duration
+	err     error
+}
+
+// runBenchRequests sends benchRequests calls over benchConcurrency workers
+func runBenchRequests(ctx context.Context, client config.API, endpoint benchOp, roomID string) benchResult {
+	jobs := make(chan int)
+	samples := make(chan benchSample, benchConcurrency)
+
+	var wg sync.WaitGroup
+	for w := 0; w < benchConcurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				started := time.Now()
+				err := endpoint.call(ctx, client, roomID, i)
+				samples <- benchSample{latency: time.Since(started), err: err}
+			}
+		}()
+	}
+
+	started := time.Now()
+	go func() {
+		defer close(jobs)
+		for i := 0; i < benchRequests; i++ {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(samples)
+	}()
+
+	result := benchResult{Errors: map[string]int{}}
+	var latencies []time.Duration
+	for sample := range samples {
+		result.Requests++
+		if sample.err != nil {
+			result.Failed++
+			result.Errors[sample.err.Error()]++
+			continue
+		}
+		result.Succeeded++
+		latencies = append(latencies, sample.latency)
+	}
+	result.Duration = time.Since(started)
+	result.Interrupted = ctx.Err() != nil && result.Requests < benchRequests
+
+	if result.Duration > 0 {
+		result.Throughput = float64(result.Requests) / result.Duration.Seconds()
+	}
+	result.Latency = summarizeLatency(latencies)
+	return result
+}
+
+// summarizeLatency computes latency statistics using nearest-rank percentiles
+func summarizeLatency(latencies []time.Duration) benchLatency {
+	if len(latencies) == 0 {
+		return benchLatency{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p / 100 * float64(len(latencies))))
+		if rank < 1 {
+			rank = 1
+		}
+		return latencies[rank-1]
+	}
+
+	return benchLatency{
+		Min:  latencies[0],
+		Mean: total / time.Duration(len(latencies)),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P95:  percentile(95),
+		P99:  percentile(99),
+		Max:  latencies[len(latencies)-1],
+	}
+}
+
+// benchMaxErrors caps how many distinct errors are listed
+const benchMaxErrors = 5
+
+func printbenchResult(result benchResult) {
+	if result.Interrupted {
+		fmt.Printf("⚠ Interrupted after %d of %d request(s)\n\n", result.Requests, benchRequests)
+	}
+
+	fmt.Printf("Endpoint:    %s\n", result.Endpoint)
+	fmt.Printf("Target:      %s\n", result.Target)
+	fmt.Printf("Requests:    %d (%d concurrent)\n", result.Requests, result.Concurrency)
+	fmt.Printf("Succeeded:   %d\n", result.Succeeded)
+	fmt.Printf("Failed:      %d\n", result.Failed)
+	fmt.Printf("Duration:    %s\n", result.Duration.Round(time.Millisecond))
+	fmt.Printf("Throughput:  %.1f req/s\n", result.Throughput)
+
+	if result.Succeeded > 0 {
+		fmt.Println()
+		fmt.Println("Latency (successful requests)")
+		rows := []struct {
+			label string
+			value time.Duration
+		}{
+			{"min", result.Latency.Min},
+			{"mean", result.Latency.Mean},
+			{"p50", result.Latency.P50},
+			{"p90", result.Latency.P90},
+			{"p95", result.Latency.P95},
+			{"p99", result.Latency.P99},
+			{"max", result.Latency.Max},
+		}
+		for _, row := range rows {
+			fmt.Printf("  %-5s %10s\n", row.label, formatLatency(row.value))
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		type errorCount struct {
+			message string
+			count   int
+		}
+		var errs []errorCount
+		for message, count := range result.Errors {
+			errs = append(errs, errorCount{message, count})
+		}
+		sort.Slice(errs, func(i, j int) bool { return errs[i].count > errs[j].count })
+
+		fmt.Println()
+		fmt.Println("Errors")
+		for i, e := range errs {
+			if i == benchMaxErrors {
+				fmt.Printf("  ...and %d more kinds of error\n", len(errs)-benchMaxErrors)
+				break
+			}
+			fmt.Printf("  %5d × %s\n", e.count, e.message)
+		}
+	}
+}
+
+// formatLatency shows sub-second latencies in milliseconds
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -1,14 +1,24 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/manifoldco/promptui"
+	"github.com/miles/booking-cli/internal/calsync"
 	"github.com/miles/booking-cli/internal/config"
-	"github.com/miles/booking-cli/internal/generated"
+	"github.com/miles/booking-cli/internal/workflow"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/snippet"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
 )
 
 var bookCmd = &cobra.Command{
@@ -22,11 +32,15 @@ Interactive mode (no flags):
 One-liner mode (all flags):
   miles book -r ROOM123 -s "2025-10-19 14:00" -e "2025-10-19 15:00" -t "Team Meeting"
 
+From a line of text (confirm or correct what was understood):
+  miles book --from-text "Tue 14:00-15:00 design review with Anna"
+
 Time formats supported:
   "2025-10-19 14:00"           Simple format (recommended)
   "2025-10-19T14:00:00Z"       RFC3339 / ISO 8601
   "2025-10-19"                 Date only (defaults to 9 AM)
-  "15:00"                      Time only (uses today's date)
+  "15:00"                      Time only (today for -s, the start's day for -e)
+  "45m"                        Length, for -e
 
 Examples:
   # Interactive mode - prompts for each field
@@ -36,7 +50,32 @@ Examples:
   miles book -r ROOM123 -s "2025-10-19 14:00" -e "15:00" -t "1:1"
 
   # With description
-  miles book -r ROOM123 -s "2025-10-19 14:00" -e "15:00" -t "1:1" -d "Performance review"`,
+  miles book -r ROOM123 -s "2025-10-19 14:00" -e "15:00" -t "1:1" -d "Performance review"
+
+  # Book even if it overlaps one of your own meetings
+  miles book -r ROOM123 -s "2025-10-19 14:00" -e "15:00" -t "1:1" --force
+
+  # Show each check and what blocks the booking, for "why can't I book this?"
+  miles book -r ROOM123 -s "2025-10-19 14:00" -e "15:00" -t "1:1" --explain
+
+  # Skip suggested times when you're busy in Google Calendar
+  miles book --busy-calendar gcal
+
+  # Hold 10 minutes after the meeting in case it runs over
+  miles book -r ROOM123 -s "2025-10-19 14:00" -e "15:00" -t "1:1" --buffer 10m
+
+  # Book a meeting copied from chat, in a known room
+  miles book --from-text "tomorrow 9:00 for 30m standup" -r ROOM123
+
+  # Book the organization's "standup" time slot tomorrow
+  miles book --slot standup --date tomorrow -r ROOM123 -t "Team standup"
+
+  # Every Monday until the end of the year, or the next 10 weekdays
+  miles book -r ROOM123 -s "2025-10-20 09:00" -e "2025-10-20 09:15" -t "Standup" --repeat weekly --until 2025-12-31
+  miles book -r ROOM123 -s "2025-10-20 09:00" -e "2025-10-20 09:15" -t "Standup" --repeat weekdays --count 10
+
+  # Book every room on a floor for a company event (admins and managers)
+  miles book --zone Oslo-3F -s "2025-10-24 16:00" -e "2025-10-24 20:00" -t "Friday social"`,
 	RunE: runBook,
 }
 
@@ -46,37 +85,133 @@ var (
 	bookEndTime     string
 	bookTitle       string
 	bookDescription string
+	bookForce       bool
+	bookBusyCal     string
+	bookBuffer      time.Duration
+	bookFromText    string
+	bookLocation    string
+	bookEncrypt     bool
+	bookSetup       string
+	bookSetupNotes  string
+	bookZone        string
+	bookPartial     bool
+	bookSlot        string
+	bookDate        string
+	bookExplain     bool
+	bookRepeat      string
+	bookUntil       string
+	bookCount       int
 )
 
+// maxBookingBuffer is the longest buffer the server will hold
+const maxBookingBuffer = time.Hour
+
 func init() {
 	bookCmd.Flags().StringVarP(&bookRoomID, "room", "r", "", "room ID (optional in interactive mode)")
 	bookCmd.Flags().StringVarP(&bookStartTime, "start", "s", "", `start time (e.g. "2025-10-19 14:00", optional in interactive mode)`)
-	bookCmd.Flags().StringVarP(&bookEndTime, "end", "e", "", `end time (e.g. "2025-10-19 15:00" or "15:00", optional in interactive mode)`)
+	bookCmd.Flags().StringVarP(&bookEndTime, "end", "e", "", `end time or length (e.g. "2025-10-19 15:00", "15:00" or "45m", optional in interactive mode)`)
 	bookCmd.Flags().StringVarP(&bookTitle, "title", "t", "", "meeting title (optional in interactive mode)")
 	bookCmd.Flags().StringVarP(&bookDescription, "description", "d", "", "meeting description (optional)")
-
-	// Register autocomplete for room flag
+	bookCmd.Flags().BoolVar(&bookForce, "force", false, "create the booking even if it overlaps one of your own bookings")
+	bookCmd.Flags().StringVar(&bookBusyCal, "busy-calendar", "", "skip suggested times you're busy in this calendar: gcal or outlook (env: MILES_BUSY_CALENDAR)")
+	viper.BindPFlag("busy_calendar", bookCmd.Flags().Lookup("busy-calendar"))
+	bookCmd.Flags().DurationVar(&bookBuffer, "buffer", 0, "also hold the room this long after the end if it's free, e.g. 10m (env: MILES_BUFFER)")
+	viper.BindPFlag("buffer", bookCmd.Flags().Lookup("buffer"))
+	bookCmd.Flags().StringVar(&bookFromText, "from-text", "", `read the day, time and title from text, e.g. "Tue 14:00-15:00 design review"`)
+	bookCmd.Flags().StringVarP(&bookLocation, "location", "l", "", "location ID or name to pick rooms from, instead of the detected office (env: MILES_LOCATION)")
+	viper.BindPFlag("location", bookCmd.Flags().Lookup("location"))
+	bookCmd.Flags().BoolVar(&bookEncrypt, "encrypt", false, "encrypt the description with description_key from the config (env: MILES_ENCRYPT_DESCRIPTIONS)")
+	viper.BindPFlag("encrypt_descriptions", bookCmd.Flags().Lookup("encrypt"))
+	bookCmd.Flags().StringVar(&bookSetup, "setup", "", "room setup for facilities to prepare: theatre, boardroom or u-shape")
+	bookCmd.Flags().StringVar(&bookSetupNotes, "setup-notes", "", `setup instructions for facilities, e.g. "water for 40, projector on"`)
+	bookCmd.Flags().StringVar(&bookZone, "zone", "", "book every room in this zone (ID or name) as one group, for events")
+	bookCmd.Flags().BoolVar(&bookPartial, "partial", false, "with --zone, book the free rooms even when others are taken")
+	bookCmd.Flags().StringVar(&bookSlot, "slot", "", "book the organization's named time slot instead of -s and -e, e.g. standup")
+	bookCmd.Flags().StringVar(&bookDate, "date", "today", `with --slot, the day to book, e.g. 2025-10-20, "tomorrow" or "next friday"`)
+	bookCmd.Flags().BoolVar(&bookExplain, "explain", false, "show each check the booking goes through and which booking, closure or limit blocks it")
+	bookCmd.Flags().StringVar(&bookRepeat, "repeat", "", "book a series: daily, weekdays, weekly, biweekly or monthly (with --until or --count)")
+	bookCmd.Flags().StringVar(&bookUntil, "until", "", "with --repeat, the last day of the series, YYYY-MM-DD")
+	bookCmd.Flags().IntVar(&bookCount, "count", 0, "with --repeat, how many bookings the series makes")
+	bookCmd.MarkFlagsMutuallyExclusive("until", "count")
+	bookCmd.MarkFlagsMutuallyExclusive("from-text", "start")
+	bookCmd.MarkFlagsMutuallyExclusive("from-text", "end")
+	bookCmd.MarkFlagsMutuallyExclusive("zone", "room")
+	bookCmd.MarkFlagsMutuallyExclusive("zone", "from-text")
+	bookCmd.MarkFlagsMutuallyExclusive("slot", "start")
+	bookCmd.MarkFlagsMutuallyExclusive("slot", "end")
+	bookCmd.MarkFlagsMutuallyExclusive("slot", "from-text")
+
+	// Register autocomplete for room and location flags
 	bookCmd.RegisterFlagCompletionFunc("room", completeRoomIDs)
+	bookCmd.RegisterFlagCompletionFunc("location", completeLocationIDs)
+	bookCmd.RegisterFlagCompletionFunc("start", completeStartTimes)
+	bookCmd.RegisterFlagCompletionFunc("end", completeEndTimes)
+	bookCmd.RegisterFlagCompletionFunc("zone", completeZoneNames)
+	bookCmd.RegisterFlagCompletionFunc("slot", completeSlotNames)
+	bookCmd.RegisterFlagCompletionFunc("repeat", cobra.FixedCompletions([]string{"daily", "weekdays", "weekly", "biweekly", "monthly"}, cobra.ShellCompDirectiveNoFileComp))
+	bookCmd.RegisterFlagCompletionFunc("setup", cobra.FixedCompletions(roomSetupNames, cobra.ShellCompDirectiveNoFileComp))
 
 	// Flags are optional - if missing, interactive mode is triggered
 }
 
 func runBook(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	// Check authentication
 	token := getAuthToken()
 	if token == "" {
 		return fmt.Errorf("not authenticated. Run 'miles login' first")
 	}
 
+	buffer := viper.GetDuration("buffer")
+	if buffer < 0 || buffer > maxBookingBuffer {
+		return fmt.Errorf("buffer must be between 0 and %s", formatDuration(maxBookingBuffer))
+	}
+	if _, err := parseRoomSetup(bookSetup); err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("date") && bookSlot == "" {
+		return fmt.Errorf("--date picks the day for --slot; use -s and -e otherwise")
+	}
+	if err := checkRepeatFlags(); err != nil {
+		return err
+	}
+
 	// Create API client
-	client := config.NewClient(getAPIURL(), token)
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// A named time slot stands in for -s and -e
+	if bookSlot != "" {
+		start, end, err := resolveBookSlot(ctx, client, bookSlot, bookDate)
+		if err != nil {
+			return err
+		}
+		bookStartTime = start.Format("2006-01-02 15:04")
+		bookEndTime = end.Format("2006-01-02 15:04")
+	}
+
+	// A zone books several rooms at once, without the per-room checks
+	if bookZone != "" {
+		return runBookZone(ctx, client)
+	}
+
+	// A pasted meeting line pre-fills an interactive confirmation
+	if bookFromText != "" {
+		return runBookFromText(ctx, client, bookFromText, buffer)
+	}
 
 	// Determine if any flags were provided
 	anyFlagsProvided := bookRoomID != "" || bookStartTime != "" || bookEndTime != "" || bookTitle != ""
 
 	// If no flags provided, enter interactive mode
 	if !anyFlagsProvided {
-		return runInteractiveBook(client)
+		if bookRepeat != "" {
+			return fmt.Errorf("--repeat books one room with -r, -s, -e and -t")
+		}
+		return runInteractiveBook(ctx, client, buffer)
 	}
 
 	// If any flags provided, require all required flags
@@ -99,64 +234,219 @@ func runBook(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid start time: %w", err)
 	}
 
-	endTime, err := parseTime(bookEndTime)
+	endTime, err := parseEnd(bookEndTime, startTime)
 	if err != nil {
 		return fmt.Errorf("invalid end time: %w", err)
 	}
 
-	// Validate times
-	if endTime.Before(startTime) {
-		return fmt.Errorf("end time must be after start time")
+	// A series checks and books each occurrence itself
+	if bookRepeat != "" {
+		return runBookSeries(ctx, client, bookRoomID, startTime, endTime, bookTitle, bookDescription, buffer)
+	}
+
+	buffer, err = checkBooking(ctx, client, bookRoomID, startTime, endTime, bookTitle, buffer)
+	if err != nil {
+		return err
 	}
 
 	// Create booking
-	return createBooking(client, bookRoomID, startTime, endTime, bookTitle, bookDescription)
+	return createBooking(ctx, client, bookRoomID, startTime, endTime, bookTitle, bookDescription, buffer)
 }
 
-func runInteractiveBook(client *config.Client) error {
-	fmt.Println("📅 Interactive Booking\n")
+// checkBooking runs the checks a booking must pass before it is created:
+// valid times, (without --force) not repeating one of the user's bookings,
+// the room's length limits, its location's closed days and availability,
+// the quota and (without --force) overlaps with the user's own bookings. It
+// returns the buffer that can actually be held. With --explain each check
+// says how it went.
+func checkBooking(ctx context.Context, client config.API, roomID string, startTime, endTime time.Time, title string, buffer time.Duration) (time.Duration, error) {
+	// Validate times
+	if endTime.Before(startTime) {
+		return 0, fmt.Errorf("end time must be after start time")
+	}
 
-	// Step 1: Select location
-	location, err := selectLocation(client)
-	if err != nil {
-		return err
+	// Running the same command twice shouldn't quietly book twice
+	if !bookForce {
+		if err := confirmDuplicate(ctx, client, roomID, startTime, endTime, title); err != nil {
+			return 0, err
+		}
 	}
 
-	// Step 2: Select room
-	room, err := selectRoom(client, location)
-	if err != nil {
-		return err
+	why := &explainer{enabled: bookExplain}
+	defer why.done()
+
+	// Enforce the room's access and length limits when we can look them up
+	room, roomErr := findRoom(ctx, client, roomID)
+	if roomErr == nil {
+		if err := checkRoomAccess(room); err != nil {
+			why.fail("Access", err.Error())
+			return 0, err
+		}
+		why.pass("Access", derefString(room.Name)+" is open to you")
+		if err := checkRoomDuration(room, startTime, endTime); err != nil {
+			why.fail("Length", err.Error())
+			return 0, err
+		}
+		why.pass("Length", fmt.Sprintf("%s, %s allowed", formatDuration(endTime.Sub(startTime)), milesapi.DescribeDurationLimits(room.DurationLimits())))
+		if err := checkRoomClosures(ctx, client, room, startTime, endTime); err != nil {
+			why.fail("Closed days", err.Error())
+			return 0, err
+		}
+		why.pass("Closed days", "the location is open "+startTime.Local().Format("Mon Jan 2"))
+	} else {
+		why.warn("Room rules", fmt.Sprintf("could not look up the room (%v); the server checks access, length and closed days", roomErr))
 	}
 
-	// Step 3: Select start time (with availability checking)
-	startTime, err := selectStartTimeWithAvailability(client, room)
-	if err != nil {
-		return err
+	// Check the room is free before trying to book it
+	if conflicts, err := client.CheckRoomAvailability(ctx, roomID, startTime, endTime); err != nil {
+		why.warn("Room free", "could not check; the server decides")
+		fmt.Printf("⚠ Could not check room availability: %v\n", err)
+	} else if len(conflicts) > 0 {
+		for _, conflict := range conflicts {
+			why.fail("Room free", "held by "+milesapi.DescribeBlocker(conflict))
+		}
+		why.done()
+		printConflicts(conflicts)
+		printRoomDay(ctx, client, roomID, startTime, endTime)
+		printAlternatives(findFreeAlternatives(ctx, client, roomID, startTime, endTime, 3), startTime)
+		if roomErr == nil {
+			printNearbyRooms(*room, findNearbyRooms(ctx, client, *room, startTime, endTime, 3))
+		}
+		return 0, fmt.Errorf("room is already booked between %s and %s",
+			startTime.Local().Format("2006-01-02 15:04"), endTime.Local().Format("15:04"))
+	} else {
+		why.pass("Room free", "no other bookings "+describeSlot(startTime, endTime))
+	}
+
+	// Respect the personal booking quota
+	if quota, exceeds, err := checkQuota(ctx, client, startTime, endTime); err != nil {
+		why.warn("Quota", "could not check; the server decides")
+		fmt.Printf("⚠ Could not check your booking quota: %v\n", err)
+	} else if exceeds {
+		if quota.Policy == milesapi.Block {
+			why.fail("Quota", quotaExceededMessage(quota, startTime, endTime))
+			return 0, fmt.Errorf("%s", quotaExceededMessage(quota, startTime, endTime))
+		}
+		why.warn("Quota", quotaExceededMessage(quota, startTime, endTime)+", which is allowed")
+		fmt.Printf("⚠ %s\n", quotaExceededMessage(quota, startTime, endTime))
+	} else if quota.LimitHours > 0 {
+		why.pass("Quota", formatQuota(quota))
+	} else {
+		why.pass("Quota", "no quota")
 	}
 
-	// Step 4: Select end time (relative to start time, checking availability)
-	endTime, err := selectEndTimeWithAvailability(client, room, startTime)
+	// Don't let users double-book themselves unless they ask for it
+	if !bookForce {
+		overlaps, err := findOwnOverlaps(ctx, client, startTime, endTime)
+		if err != nil {
+			why.warn("Your schedule", "could not check")
+			fmt.Printf("⚠ Could not check your schedule for overlaps: %v\n", err)
+		} else if len(overlaps) > 0 {
+			for _, overlap := range overlaps {
+				why.fail("Your schedule", "you have "+milesapi.DescribeBlocker(overlap))
+			}
+			why.done()
+			printOverlaps(overlaps)
+			return 0, fmt.Errorf("new booking overlaps %d of your existing bookings. Use --force to book anyway", len(overlaps))
+		} else {
+			why.pass("Your schedule", "nothing else then")
+		}
+	} else {
+		why.pass("Your schedule", "not checked with --force")
+	}
+
+	// Hold only as much buffer as is free after the meeting
+	if requested := buffer; requested > 0 {
+		buffer = freeBuffer(ctx, client, roomID, endTime, requested)
+		if buffer < requested {
+			fmt.Printf("⚠ Buffer: %s\n", describeBuffer(buffer, endTime))
+		}
+	}
+
+	return buffer, nil
+}
+
+func runInteractiveBook(ctx context.Context, client config.API, buffer time.Duration) error {
+	fmt.Print("📅 Interactive Booking\n\n")
+
+	// Steps 1-4: Select location, room, start and end time
+	s := bookingSession(client)
+	var roomInfo *milesapi.Room
+	busyUntil := time.Now().AddDate(0, 0, 8)
+	err := workflow.Run(ctx, s,
+		workflow.SelectLocation,
+		workflow.SelectRoom,
+		func(ctx context.Context, s *workflow.Session) error {
+			// Room details carry booking length limits; without them the server decides
+			roomInfo, _ = findRoom(ctx, client, s.RoomID)
+			s.MinDuration, s.MaxDuration = roomInfo.DurationLimits()
+
+			// Busy times from the user's external calendar, when one is connected
+			s.Busy = loadCalendarBusy(time.Now(), busyUntil)
+			return nil
+		},
+		workflow.SelectStart,
+		func(ctx context.Context, s *workflow.Session) error {
+			// A custom start time may lie beyond the busy window fetched above
+			if s.Busy != nil && !s.Start.Before(busyUntil) {
+				dayStart := time.Date(s.Start.Year(), s.Start.Month(), s.Start.Day(), 0, 0, 0, 0, s.Start.Location())
+				s.Busy = loadCalendarBusy(dayStart, dayStart.AddDate(0, 0, 1))
+			}
+			if s.MinDuration > 0 || s.MaxDuration > 0 {
+				fmt.Printf("ℹ This room allows bookings of %s\n", milesapi.DescribeDurationLimits(s.MinDuration, s.MaxDuration))
+			}
+			return nil
+		},
+		workflow.SelectEnd,
+	)
 	if err != nil {
 		return err
 	}
+	location, room, startTime, endTime := s.LocationID, s.RoomID, s.Start, s.End
 
 	// Validate times
 	if endTime.Before(startTime) {
 		return fmt.Errorf("end time must be after start time")
 	}
+	if err := checkRoomDuration(roomInfo, startTime, endTime); err != nil {
+		return err
+	}
+	if err := checkRoomClosures(ctx, client, roomInfo, startTime, endTime); err != nil {
+		return err
+	}
+
+	// Check the personal quota before asking for details
+	quota, exceedsQuota, err := checkQuota(ctx, client, startTime, endTime)
+	if err != nil {
+		fmt.Printf("⚠ Could not check your booking quota: %v\n", err)
+	} else if exceedsQuota && quota.Policy == milesapi.Block {
+		return fmt.Errorf("%s", quotaExceededMessage(quota, startTime, endTime))
+	}
 
 	// Step 5: Enter title
-	title, err := promptString("Meeting title", "", true)
+	title, err := workflow.Ask("Meeting title", "", true)
 	if err != nil {
 		return err
 	}
 
 	// Step 6: Enter description (optional)
-	description, err := promptString("Description (optional)", "", false)
+	description, err := workflow.Ask("Description (optional)", "", false)
 	if err != nil {
 		return err
 	}
 
+	// Check for overlaps with the user's own schedule
+	var overlaps []milesapi.Booking
+	if !bookForce {
+		overlaps, err = findOwnOverlaps(ctx, client, startTime, endTime)
+		if err != nil {
+			fmt.Printf("⚠ Could not check your schedule for overlaps: %v\n", err)
+		}
+	}
+
+	requestedBuffer := buffer
+	buffer = freeBuffer(ctx, client, room, endTime, buffer)
+
 	// Step 7: Confirm
 	fmt.Printf("\n📋 Booking Summary:\n")
 	fmt.Printf("  Location:    %s\n", location)
@@ -167,10 +457,26 @@ func runInteractiveBook(client *config.Client) error {
 	if description != "" {
 		fmt.Printf("  Description: %s\n", description)
 	}
+	if quota != nil {
+		fmt.Printf("  Quota:       %s (+%s)\n", formatQuota(quota), formatDuration(endTime.Sub(startTime)))
+	}
+	if requestedBuffer > 0 {
+		fmt.Printf("  Buffer:      %s\n", describeBuffer(buffer, endTime))
+	}
 	fmt.Println()
 
+	label := "Create this booking"
+	if exceedsQuota {
+		fmt.Printf("⚠ %s\n\n", quotaExceededMessage(quota, startTime, endTime))
+		label = "Create this booking anyway"
+	}
+	if len(overlaps) > 0 {
+		printOverlaps(overlaps)
+		label = "Create this booking anyway"
+	}
+
 	prompt := promptui.Prompt{
-		Label:     "Create this booking",
+		Label:     label,
 		IsConfirm: true,
 	}
 	_, err = prompt.Run()
@@ -179,683 +485,617 @@ func runInteractiveBook(client *config.Client) error {
 	}
 
 	// Create booking
-	return createBooking(client, room, startTime, endTime, title, description)
+	return createBooking(ctx, client, room, startTime, endTime, title, description, buffer)
 }
 
-func createBooking(client *config.Client, roomID string, startTime, endTime time.Time, title, description string) error {
-	// Keep local times for display
-	displayStart := startTime
-	displayEnd := endTime
-
-	// Convert times to UTC for API
-	req := generated.BookingInput{
-		RoomId:      roomID,
-		StartTime:   startTime.UTC(),
-		EndTime:     endTime.UTC(),
-		Title:       title,
-		Description: &description,
+// runBookFromText books a meeting read from a line of text. The room comes
+// from --room or is picked interactively, and the title, start and end can
+// be corrected before anything is checked.
+func runBookFromText(ctx context.Context, client config.API, text string, buffer time.Duration) error {
+	parsed, err := snippet.Parse(text, time.Now())
+	if err != nil {
+		return fmt.Errorf("could not read a meeting from the text: %w", err)
 	}
 
-	booking, err := client.CreateBooking(req)
-	if err != nil {
-		return err
+	fmt.Print("📝 Booking from text\n\n")
+	fmt.Printf("  When:  %s - %s\n", parsed.Start.Format("Mon 2006-01-02 15:04"), parsed.End.Format("15:04"))
+	if parsed.Title != "" {
+		fmt.Printf("  Title: %s\n", parsed.Title)
 	}
+	fmt.Println()
 
-	// Output result (using local times for display)
-	fmt.Printf("\n✓ Booking created successfully!\n\n")
-	fmt.Printf("Room:        %s\n", roomID)
-	fmt.Printf("Title:       %s\n", title)
-	fmt.Printf("Start:       %s\n", displayStart.Format("2006-01-02 15:04"))
-	fmt.Printf("End:         %s\n", displayEnd.Format("2006-01-02 15:04"))
+	room := bookRoomID
+	if room == "" {
+		s := bookingSession(client)
+		if err := workflow.Run(ctx, s, workflow.SelectLocation, workflow.SelectRoom); err != nil {
+			return err
+		}
+		room = s.RoomID
+	}
 
-	// Show optional details if returned by API
-	if booking.Id != nil {
-		fmt.Printf("ID:          %s\n", *booking.Id)
+	title := parsed.Title
+	if bookTitle != "" {
+		title = bookTitle
 	}
-	if booking.Status != nil {
-		fmt.Printf("Status:      %s\n", *booking.Status)
+	if title, err = workflow.Edit("Meeting title", title, true); err != nil {
+		return err
 	}
 
-	fmt.Printf("\nView all bookings: miles bookings\n")
-
-	return nil
-}
-
-func parseTime(timeStr string) (time.Time, error) {
-	// Try simple format first - most human-friendly (2025-10-19 14:00)
-	t, err := time.Parse("2006-01-02 15:04", timeStr)
-	if err == nil {
-		// Convert to local timezone for consistency
-		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
+	startInput, err := workflow.Edit("Start", parsed.Start.Format("2006-01-02 15:04"), true)
+	if err != nil {
+		return err
 	}
-
-	// Try time only (15:00) - use today's date
-	t, err = time.Parse("15:04", timeStr)
-	if err == nil {
-		now := time.Now()
-		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
+	startTime, err := parseTime(startInput)
+	if err != nil {
+		return fmt.Errorf("invalid start time: %w", err)
 	}
 
-	// Try RFC3339 format (2025-10-19T14:00:00Z)
-	t, err = time.Parse(time.RFC3339, timeStr)
-	if err == nil {
-		return t, nil
+	// An end given as a time of day is on the start's day, so moving the
+	// start to another day takes the end along
+	endDefault := parsed.End.Format("15:04")
+	if parsed.End.YearDay() != parsed.Start.YearDay() {
+		endDefault = parsed.End.Format("2006-01-02 15:04")
+	}
+	endInput, err := workflow.Edit("End", endDefault, true)
+	if err != nil {
+		return err
+	}
+	endTime, err := parseEnd(endInput, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid end time: %w", err)
 	}
 
-	// Try date only (2025-10-19), default to 9 AM
-	t, err = time.Parse("2006-01-02", timeStr)
-	if err == nil {
-		return time.Date(t.Year(), t.Month(), t.Day(), 9, 0, 0, 0, time.Local), nil
+	actualBuffer, err := checkBooking(ctx, client, room, startTime, endTime, title, buffer)
+	if err != nil {
+		return err
 	}
 
-	// Provide helpful error message
-	return time.Time{}, fmt.Errorf(`unable to parse time: "%s"
+	fmt.Printf("\n📋 Booking Summary:\n")
+	fmt.Printf("  Room:        %s\n", room)
+	fmt.Printf("  Title:       %s\n", title)
+	fmt.Printf("  Start:       %s\n", startTime.Format("2006-01-02 15:04"))
+	fmt.Printf("  End:         %s\n", endTime.Format("2006-01-02 15:04"))
+	if bookDescription != "" {
+		fmt.Printf("  Description: %s\n", bookDescription)
+	}
+	if buffer > 0 {
+		fmt.Printf("  Buffer:      %s\n", describeBuffer(actualBuffer, endTime))
+	}
+	fmt.Println()
 
-Supported formats:
-  "2025-10-19 14:00"    (recommended)
-  "15:00"               (time only, uses today)
-  "2025-10-19T14:00:00Z" (RFC3339)
-  "2025-10-19"          (date only, defaults to 9 AM)
+	prompt := promptui.Prompt{
+		Label:     "Create this booking",
+		IsConfirm: true,
+	}
+	if _, err := prompt.Run(); err != nil {
+		return fmt.Errorf("booking cancelled")
+	}
 
-Example: miles book -r ROOM123 -s "2025-10-19 14:00" -e "15:00" -t "Meeting"`, timeStr)
+	return createBooking(ctx, client, room, startTime, endTime, title, bookDescription, actualBuffer)
 }
 
-// Interactive helper functions
-
-func selectLocation(client *config.Client) (string, error) {
-	locations, err := client.GetLocations()
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch locations: %w", err)
+// parseEnd parses an end time relative to the start: a duration ("45m",
+// "1h30m"), a time of day on the start's day ("15:00") or a full time
+func parseEnd(input string, start time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(input); err == nil {
+		return start.Add(d), nil
+	}
+	if t, err := time.Parse("15:04", input); err == nil {
+		return time.Date(start.Year(), start.Month(), start.Day(), t.Hour(), t.Minute(), 0, 0, start.Location()), nil
 	}
+	return parseTime(input)
+}
 
-	if len(locations) == 0 {
-		return "", fmt.Errorf("no locations available")
+// freeBuffer shortens a requested buffer to the free time after endTime.
+// The server shortens it too; checking here lets the summary show the truth.
+func freeBuffer(ctx context.Context, client config.API, roomID string, endTime time.Time, buffer time.Duration) time.Duration {
+	if buffer <= 0 {
+		return 0
 	}
 
-	// Build list of location names
-	items := make([]string, len(locations))
-	locationMap := make(map[string]string) // name -> ID
-	for i, loc := range locations {
-		name := "Unknown"
-		if loc.Name != nil {
-			name = *loc.Name
+	following, err := client.CheckRoomAvailability(ctx, roomID, endTime, endTime.Add(buffer))
+	if err != nil {
+		return buffer
+	}
+	for _, booking := range following {
+		if booking.StartTime == nil {
+			continue
 		}
-		id := ""
-		if loc.Id != nil {
-			id = *loc.Id
+		if gap := booking.StartTime.Sub(endTime); gap < buffer {
+			buffer = max(0, gap)
 		}
-		items[i] = name
-		locationMap[name] = id
 	}
+	return buffer.Truncate(time.Minute)
+}
 
-	prompt := promptui.Select{
-		Label: "Select location",
-		Items: items,
-		Size:  10,
+// describeBuffer explains the buffer held after a booking
+func describeBuffer(buffer time.Duration, endTime time.Time) string {
+	if buffer <= 0 {
+		return "none (the room is booked right after)"
 	}
+	return fmt.Sprintf("%s until %s (others can claim it)",
+		formatDuration(buffer), endTime.Add(buffer).Local().Format("15:04"))
+}
 
-	_, result, err := prompt.Run()
-	if err != nil {
-		return "", fmt.Errorf("location selection cancelled")
-	}
+// roomSetupNames are the --setup values, as typed
+var roomSetupNames = []string{"theatre", "boardroom", "u-shape"}
+
+// parseRoomSetup reads --setup; empty means no particular layout
+func parseRoomSetup(name string) (*milesapi.RoomSetup, error) {
+	var setup milesapi.RoomSetup
+	switch strings.ToLower(strings.ReplaceAll(name, "_", "-")) {
+	case "":
+		return nil, nil
+	case "theatre", "theater":
+		setup = milesapi.THEATRE
+	case "boardroom":
+		setup = milesapi.BOARDROOM
+	case "u-shape", "ushape", "u":
+		setup = milesapi.USHAPE
+	default:
+		return nil, fmt.Errorf("invalid setup %q (expected %s)", name, strings.Join(roomSetupNames, ", "))
+	}
+	return &setup, nil
+}
 
-	return locationMap[result], nil
+// describeRoomSetup describes a setup request, e.g. "U-shape (water for 40)"
+func describeRoomSetup(setup *milesapi.RoomSetup, notes string) string {
+	layout := "No particular layout"
+	if setup != nil {
+		switch *setup {
+		case milesapi.THEATRE:
+			layout = "Theatre"
+		case milesapi.BOARDROOM:
+			layout = "Boardroom"
+		case milesapi.USHAPE:
+			layout = "U-shape"
+		default:
+			layout = string(*setup)
+		}
+	}
+	if notes != "" {
+		return layout + " (" + notes + ")"
+	}
+	return layout
 }
 
-func selectRoom(client *config.Client, locationID string) (string, error) {
-	rooms, err := client.GetRooms(locationID)
+func createBooking(ctx context.Context, client config.API, roomID string, startTime, endTime time.Time, title, description string, buffer time.Duration) error {
+	// Keep local times for display
+	displayStart := startTime
+	displayEnd := endTime
+
+	// Only those with the team's key can read an encrypted description
+	sealed, err := sealDescription(description)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch rooms: %w", err)
+		return err
 	}
-
-	if len(rooms) == 0 {
-		return "", fmt.Errorf("no rooms available in this location")
+	setup, err := parseRoomSetup(bookSetup)
+	if err != nil {
+		return err
 	}
 
-	// Build list of rooms with details
-	type roomItem struct {
-		Display string
-		ID      string
+	// Convert times to UTC for API
+	req := milesapi.BookingInput{
+		RoomId:    roomID,
+		StartTime: startTime.UTC(),
+		EndTime:   endTime.UTC(),
+		Title:     title,
 	}
-	items := make([]roomItem, len(rooms))
-	for i, room := range rooms {
-		name := "Unknown"
-		if room.Name != nil {
-			name = *room.Name
-		}
-		capacity := 0
-		if room.Capacity != nil {
-			capacity = *room.Capacity
-		}
-		id := ""
-		if room.Id != nil {
-			id = *room.Id
-		}
-		items[i] = roomItem{
-			Display: fmt.Sprintf("%s (capacity: %d)", name, capacity),
-			ID:      id,
-		}
+	if sealed != "" {
+		req.Description = &sealed
 	}
-
-	// Custom searcher for filtering
-	searcher := func(input string, index int) bool {
-		item := items[index]
-		input = strings.ToLower(input)
-		return strings.Contains(strings.ToLower(item.Display), input) ||
-			strings.Contains(strings.ToLower(item.ID), input)
+	if buffer > 0 {
+		minutes := int(buffer / time.Minute)
+		req.BufferMinutes = &minutes
 	}
-
-	prompt := promptui.Select{
-		Label: "Select room (type to search)",
-		Items: items,
-		Size:  10,
-		Templates: &promptui.SelectTemplates{
-			Label:    "{{ . }}",
-			Active:   "▸ {{ .Display }}",
-			Inactive: "  {{ .Display }}",
-			Selected: "✓ {{ .Display }}",
-		},
-		Searcher: searcher,
+	req.Setup = setup
+	if bookSetupNotes != "" {
+		req.SetupNotes = &bookSetupNotes
 	}
 
-	idx, _, err := prompt.Run()
+	booking, err := client.CreateBooking(ctx, req)
 	if err != nil {
-		return "", fmt.Errorf("room selection cancelled")
+		return err
 	}
 
-	return items[idx].ID, nil
-}
+	// Output result (using local times for display)
+	fmt.Printf("\n✓ Booking created successfully!\n\n")
+	fmt.Printf("Room:        %s\n", roomID)
+	fmt.Printf("Title:       %s\n", title)
+	fmt.Printf("Start:       %s\n", displayStart.Format("2006-01-02 15:04"))
+	fmt.Printf("End:         %s\n", displayEnd.Format("2006-01-02 15:04"))
 
-func selectTime(label string, startTime time.Time) (time.Time, error) {
-	now := time.Now()
-
-	// Time suggestions
-	var suggestions []struct {
-		Label string
-		Time  time.Time
-	}
-
-	// If this is for end time (startTime provided), suggest durations relative to start
-	if !startTime.IsZero() {
-		suggestions = []struct {
-			Label string
-			Time  time.Time
-		}{
-			{
-				Label: fmt.Sprintf("30 minutes (%s)", startTime.Add(30*time.Minute).Format("15:04")),
-				Time:  startTime.Add(30 * time.Minute),
-			},
-			{
-				Label: fmt.Sprintf("1 hour (%s)", startTime.Add(1*time.Hour).Format("15:04")),
-				Time:  startTime.Add(1 * time.Hour),
-			},
-			{
-				Label: fmt.Sprintf("2 hours (%s)", startTime.Add(2*time.Hour).Format("15:04")),
-				Time:  startTime.Add(2 * time.Hour),
-			},
-			{
-				Label: "Custom time (enter manually)",
-				Time:  time.Time{}, // Zero value indicates custom
-			},
-		}
-	} else {
-		// Start time suggestions (based on current time)
-		suggestions = []struct {
-			Label string
-			Time  time.Time
-		}{
-			{
-				Label: fmt.Sprintf("Next hour (%s)", now.Add(time.Hour).Truncate(time.Hour).Format("15:04")),
-				Time:  now.Add(time.Hour).Truncate(time.Hour),
-			},
-			{
-				Label: fmt.Sprintf("Tomorrow at 9 AM (%s)", now.AddDate(0, 0, 1).Format("2006-01-02")+" 09:00"),
-				Time:  time.Date(now.Year(), now.Month(), now.Day()+1, 9, 0, 0, 0, time.Local),
-			},
-			{
-				Label: fmt.Sprintf("Next Monday at 9 AM"),
-				Time:  nextWeekday(now, time.Monday, 9, 0),
-			},
-			{
-				Label: "Custom time (enter manually)",
-				Time:  time.Time{}, // Zero value indicates custom
-			},
-		}
-	}
-
-	// Build items
-	items := make([]string, len(suggestions))
-	for i, s := range suggestions {
-		items[i] = s.Label
-	}
-
-	prompt := promptui.Select{
-		Label: fmt.Sprintf("Select %s time", label),
-		Items: items,
-		Size:  len(items),
-	}
-
-	idx, _, err := prompt.Run()
-	if err != nil {
-		return time.Time{}, fmt.Errorf("time selection cancelled")
+	// Show optional details if returned by API
+	if booking.Id != nil {
+		fmt.Printf("ID:          %s\n", *booking.Id)
 	}
-
-	// If custom time selected, prompt for input
-	if suggestions[idx].Time.IsZero() {
-		customTime, err := promptString(
-			fmt.Sprintf("%s time", label),
-			`Format: "2025-10-19 14:00" or "15:00"`,
-			true,
-		)
-		if err != nil {
-			return time.Time{}, err
+	if booking.Status != nil {
+		fmt.Printf("Status:      %s\n", *booking.Status)
+	}
+	if buffer > 0 {
+		// The server has the final say on how much of the buffer was free
+		if booking.BufferMinutes != nil {
+			buffer = time.Duration(*booking.BufferMinutes) * time.Minute
 		}
-		return parseTime(customTime)
+		fmt.Printf("Buffer:      %s\n", describeBuffer(buffer, displayEnd))
+	}
+	if setup != nil || bookSetupNotes != "" {
+		fmt.Printf("Setup:       %s\n", describeRoomSetup(setup, bookSetupNotes))
 	}
 
-	return suggestions[idx].Time, nil
+	fmt.Printf("\nView all bookings: miles bookings\n")
+
+	return nil
 }
 
-func promptString(label, hint string, required bool) (string, error) {
-	validate := func(input string) error {
-		if required && strings.TrimSpace(input) == "" {
-			return fmt.Errorf("this field is required")
+// findRoom looks up a room by ID
+func findRoom(ctx context.Context, client config.API, roomID string) (*milesapi.Room, error) {
+	rooms, err := client.GetRooms(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range rooms {
+		if rooms[i].Id != nil && *rooms[i].Id == roomID {
+			return &rooms[i], nil
 		}
-		return nil
 	}
+	return nil, fmt.Errorf("room %s not found", roomID)
+}
 
-	templates := &promptui.PromptTemplates{
-		Prompt:  "{{ . }} ",
-		Valid:   "{{ . | green }} ",
-		Invalid: "{{ . | red }} ",
-		Success: "{{ . | bold }} ",
-	}
+// checkRoomDuration validates a booking length against the room's limits
+func checkRoomDuration(room *milesapi.Room, start, end time.Time) error {
+	minDuration, maxDuration := room.DurationLimits()
+	duration := end.Sub(start)
 
-	fullLabel := label
-	if hint != "" {
-		fullLabel = fmt.Sprintf("%s (%s)", label, hint)
+	name := "this room"
+	if room != nil && room.Name != nil {
+		name = *room.Name
 	}
 
-	prompt := promptui.Prompt{
-		Label:     fullLabel,
-		Validate:  validate,
-		Templates: templates,
+	if minDuration > 0 && duration < minDuration {
+		return fmt.Errorf("%s must be booked for at least %s (got %s)", name, formatDuration(minDuration), formatDuration(duration))
+	}
+	if maxDuration > 0 && duration > maxDuration {
+		return fmt.Errorf("%s can be booked for at most %s (got %s)", name, formatDuration(maxDuration), formatDuration(duration))
 	}
+	return nil
+}
 
-	result, err := prompt.Run()
+// checkQuota fetches the user's quota for the booking's period and reports
+// whether the booking would exceed it
+func checkQuota(ctx context.Context, client config.API, start, end time.Time) (*milesapi.Quota, bool, error) {
+	quota, err := client.GetQuota(ctx, start)
 	if err != nil {
-		return "", fmt.Errorf("input cancelled")
+		return nil, false, err
 	}
-
-	return strings.TrimSpace(result), nil
+	if quota.LimitHours <= 0 {
+		return quota, false, nil
+	}
+	after := float64(quota.UsedHours) + end.Sub(start).Hours()
+	return quota, after > float64(quota.LimitHours), nil
 }
 
-// nextWeekday returns the next occurrence of the specified weekday at the given time
-func nextWeekday(from time.Time, weekday time.Weekday, hour, minute int) time.Time {
-	daysUntil := int(weekday - from.Weekday())
-	if daysUntil <= 0 {
-		daysUntil += 7
-	}
-	next := from.AddDate(0, 0, daysUntil)
-	return time.Date(next.Year(), next.Month(), next.Day(), hour, minute, 0, 0, time.Local)
+// formatQuota formats quota usage, e.g. "7.5/10h used this week"
+func formatQuota(quota *milesapi.Quota) string {
+	return fmt.Sprintf("%s/%sh used this %s",
+		formatHours(quota.UsedHours), formatHours(quota.LimitHours), quota.Period)
 }
 
-// formatDuration formats a duration in a human-readable way
-func formatDuration(d time.Duration) string {
-	hours := int(d.Hours())
-	minutes := int(d.Minutes()) % 60
+// quotaExceededMessage explains how a booking would exceed the quota
+func quotaExceededMessage(quota *milesapi.Quota, start, end time.Time) string {
+	after := quota.UsedHours + float32(end.Sub(start).Hours())
+	return fmt.Sprintf("this booking would bring you to %sh of your %sh %sly quota (%s)",
+		formatHours(after), formatHours(quota.LimitHours), quota.Period, formatQuota(quota))
+}
 
-	if hours > 0 && minutes > 0 {
-		return fmt.Sprintf("%dh %dm", hours, minutes)
-	} else if hours > 0 {
-		return fmt.Sprintf("%dh", hours)
-	} else {
-		return fmt.Sprintf("%dm", minutes)
-	}
+// formatHours formats hours without trailing zeros, e.g. 7.5 or 10
+func formatHours(hours float32) string {
+	return strconv.FormatFloat(math.Round(float64(hours)*10)/10, 'f', -1, 64)
 }
 
-// selectStartTimeWithAvailability suggests start times with availability checking
-func selectStartTimeWithAvailability(client *config.Client, roomID string) (time.Time, error) {
-	now := time.Now()
-
-	// Generate common start time suggestions
-	suggestions := []struct {
-		Label string
-		Time  time.Time
-	}{
-		{
-			Label: fmt.Sprintf("Next hour (%s)", now.Add(time.Hour).Truncate(time.Hour).Format("15:04")),
-			Time:  now.Add(time.Hour).Truncate(time.Hour),
-		},
-		{
-			Label: fmt.Sprintf("Tomorrow at 9 AM (%s)", now.AddDate(0, 0, 1).Format("2006-01-02")+" 09:00"),
-			Time:  time.Date(now.Year(), now.Month(), now.Day()+1, 9, 0, 0, 0, time.Local),
-		},
-		{
-			Label: fmt.Sprintf("Next Monday at 9 AM"),
-			Time:  nextWeekday(now, time.Monday, 9, 0),
-		},
+// findOwnOverlaps returns the current user's active bookings that overlap [start, end)
+func findOwnOverlaps(ctx context.Context, client config.API, start, end time.Time) ([]milesapi.Booking, error) {
+	me, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Fetch today's bookings to check availability
-	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).UTC()
-	dayEnd := dayStart.Add(24 * time.Hour)
+	bookings, err := client.GetBookings(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	todayBookings, err := client.GetRoomAvailability(roomID, dayStart, dayEnd)
-	var activeBookings []generated.Booking
-	if err == nil {
-		// Filter out cancelled bookings
-		for _, booking := range todayBookings {
-			if booking.Status == nil || *booking.Status != "CANCELLED" {
-				activeBookings = append(activeBookings, booking)
-			}
+	var overlaps []milesapi.Booking
+	for _, booking := range bookings {
+		// Admins and managers see other people's bookings too
+		if me.Id != nil && (booking.UserId == nil || *booking.UserId != *me.Id) {
+			continue
+		}
+		if booking.Status != nil && *booking.Status == milesapi.BookingStatusCANCELLED {
+			continue
+		}
+		if booking.StartTime == nil || booking.EndTime == nil {
+			continue
+		}
+		if booking.StartTime.Before(end) && booking.EndTime.After(start) {
+			overlaps = append(overlaps, booking)
 		}
 	}
 
-	// Check availability for each suggestion and find next available slot
-	var nextAvailableSlot *struct {
-		Start    time.Time
-		Duration time.Duration
+	return overlaps, nil
+}
+
+// printOverlaps lists bookings that clash with a new booking
+// confirmDuplicate asks before creating what looks like a booking the user
+// already has: same room, start, end and title. Without a terminal to ask
+// on it refuses. Failing to look is left to the other checks to report.
+func confirmDuplicate(ctx context.Context, client config.API, roomID string, start, end time.Time, title string) error {
+	overlaps, err := findOwnOverlaps(ctx, client, start, end)
+	if err != nil {
+		return nil
 	}
 
-	for i := range suggestions {
-		if suggestions[i].Time.IsZero() {
+	for _, booking := range overlaps {
+		if derefString(booking.RoomId) != roomID || !booking.StartTime.Equal(start) || !booking.EndTime.Equal(end) ||
+			!strings.EqualFold(strings.TrimSpace(derefString(booking.Title)), strings.TrimSpace(title)) {
 			continue
 		}
 
-		// Check if this start time conflicts
-		hasConflict := false
-		for _, booking := range activeBookings {
-			if booking.StartTime == nil || booking.EndTime == nil {
-				continue
-			}
-
-			bStart := booking.StartTime.Local()
-			bEnd := booking.EndTime.Local()
-
-			// Conflict if the suggested start time overlaps with this booking
-			if !bStart.After(suggestions[i].Time) && !bEnd.Before(suggestions[i].Time) {
-				hasConflict = true
-				break
-			}
+		id := derefString(booking.Id)
+		fmt.Printf("⚠ This looks like a duplicate of your booking %s (same room, time and title)\n", id)
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("looks like a duplicate of booking %s. Use --force to create it anyway", id)
 		}
-
-		// Mark as unavailable if there's a conflict
-		if hasConflict {
-			suggestions[i].Label = suggestions[i].Label + " ⚠ unavailable"
+		prompt := promptui.Prompt{
+			Label:     "Create anyway",
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			return fmt.Errorf("booking cancelled, keeping %s", id)
 		}
+		return nil
 	}
+	return nil
+}
 
-	// Find the next available time slot after now
-	if err == nil {
-		// Find if there's a booking in progress or starting soon
-		var conflictingBooking *generated.Booking
-		for _, booking := range activeBookings {
-			if booking.StartTime == nil || booking.EndTime == nil {
-				continue
-			}
-			bStart := booking.StartTime.Local()
-			bEnd := booking.EndTime.Local()
-
-			// Check if this booking overlaps with "now"
-			if !bStart.After(now) && bEnd.After(now) {
-				// Booking in progress
-				if conflictingBooking == nil || bEnd.After(conflictingBooking.EndTime.Local()) {
-					conflictingBooking = &booking
-				}
-			}
+func printOverlaps(overlaps []milesapi.Booking) {
+	fmt.Printf("⚠ This overlaps with your existing booking(s):\n")
+	for _, booking := range overlaps {
+		title := "Untitled"
+		if booking.Title != nil {
+			title = *booking.Title
 		}
-
-		// If there's a conflict now, find the next available slot
-		if conflictingBooking != nil {
-			nextStart := conflictingBooking.EndTime.Local()
-
-			// Find when the next booking after this starts
-			var nextBookingStart *time.Time
-			for _, booking := range activeBookings {
-				if booking.StartTime == nil {
-					continue
-				}
-				bStart := booking.StartTime.Local()
-				if bStart.After(nextStart) {
-					if nextBookingStart == nil || bStart.Before(*nextBookingStart) {
-						nextBookingStart = &bStart
-					}
-				}
-			}
-
-			// Calculate available duration
-			var duration time.Duration
-			if nextBookingStart != nil {
-				duration = nextBookingStart.Sub(nextStart)
-			} else {
-				// Rest of the day is free (assume until 6 PM or end of day)
-				endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 18, 0, 0, 0, now.Location())
-				if nextStart.Before(endOfDay) {
-					duration = endOfDay.Sub(nextStart)
-				}
-			}
-
-			nextAvailableSlot = &struct {
-				Start    time.Time
-				Duration time.Duration
-			}{
-				Start:    nextStart,
-				Duration: duration,
-			}
+		id := ""
+		if booking.Id != nil {
+			id = *booking.Id
 		}
+		fmt.Printf("  - %s  %s - %s  [%s]\n",
+			title,
+			booking.StartTime.Local().Format("2006-01-02 15:04"),
+			booking.EndTime.Local().Format("15:04"),
+			id,
+		)
 	}
+	fmt.Println()
+}
 
-	// Add next available slot suggestion if found
-	if nextAvailableSlot != nil && nextAvailableSlot.Duration > 0 {
-		durationStr := formatDuration(nextAvailableSlot.Duration)
-		label := fmt.Sprintf("Next available: %s (%s free)",
-			nextAvailableSlot.Start.Format("15:04"),
-			durationStr)
-
-		// Insert as first suggestion
-		suggestions = append([]struct {
-			Label string
-			Time  time.Time
-		}{
-			{Label: label, Time: nextAvailableSlot.Start},
-		}, suggestions...)
+// printConflicts lists the room's bookings that clash with the requested time
+func printConflicts(conflicts []milesapi.Booking) {
+	fmt.Printf("✗ The room is already booked:\n")
+	for _, booking := range conflicts {
+		title := "Untitled"
+		if booking.Title != nil {
+			title = *booking.Title
+		}
+		fmt.Printf("  - %s  %s - %s\n",
+			title,
+			booking.StartTime.Local().Format("2006-01-02 15:04"),
+			booking.EndTime.Local().Format("15:04"),
+		)
 	}
+	fmt.Println()
+}
 
-	// Add custom option
-	suggestions = append(suggestions, struct {
-		Label string
-		Time  time.Time
-	}{
-		Label: "Custom time (enter manually)",
-		Time:  time.Time{},
-	})
+// findFreeAlternatives returns up to limit start times on the same day where
+// the room is free for the requested duration and the user isn't busy in an
+// imported calendar, nearest to the requested start first. Failures return
+// nothing; the alternatives are only a hint.
+func findFreeAlternatives(ctx context.Context, client config.API, roomID string, start, end time.Time, limit int) []time.Time {
+	duration := end.Sub(start)
+	local := start.Local()
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	busy, err := client.CheckRoomAvailability(ctx, roomID, dayStart, dayEnd)
+	if err != nil {
+		return nil
+	}
+	personal, _, _ := calsync.ImportedBusy(dayStart, dayEnd)
 
-	// Build items for prompt
-	items := make([]string, len(suggestions))
-	for i, s := range suggestions {
-		items[i] = s.Label
+	earliest := dayStart
+	if now := config.ServerNow().Truncate(time.Minute).Add(time.Minute); now.After(earliest) {
+		earliest = now
 	}
 
-	prompt := promptui.Select{
-		Label: "Select start time",
-		Items: items,
-		Size:  len(items),
+	// Quarter-hour slots plus exact fits right after or before each booking
+	boundary := bookingBoundary()
+	gap := boundary.Gap()
+	candidates := map[time.Time]bool{}
+	for t := dayStart; !t.Add(duration).After(dayEnd); t = t.Add(15 * time.Minute) {
+		candidates[t] = true
+	}
+	for _, booking := range busy {
+		candidates[booking.EndTime.Local().Add(gap)] = true
+		candidates[booking.StartTime.Local().Add(-duration-gap)] = true
 	}
 
-	idx, _, err := prompt.Run()
-	if err != nil {
-		return time.Time{}, fmt.Errorf("time selection cancelled")
+	var free []time.Time
+	for candidate := range candidates {
+		if candidate.Before(earliest) || candidate.Add(duration).After(dayEnd) ||
+			calsync.Overlaps(personal, candidate, candidate.Add(duration)) {
+			continue
+		}
+		if len(boundary.Conflicting(busy, candidate, candidate.Add(duration))) == 0 {
+			free = append(free, candidate)
+		}
 	}
 
-	// If custom time selected, prompt for input
-	if suggestions[idx].Time.IsZero() {
-		customTime, err := promptString(
-			"start time",
-			`Format: "2025-10-19 14:00" or "15:00"`,
-			true,
-		)
-		if err != nil {
-			return time.Time{}, err
+	distance := func(t time.Time) time.Duration {
+		if d := t.Sub(start); d >= 0 {
+			return d
 		}
-		return parseTime(customTime)
+		return start.Sub(t)
+	}
+	sort.Slice(free, func(i, j int) bool {
+		if di, dj := distance(free[i]), distance(free[j]); di != dj {
+			return di < dj
+		}
+		return free[i].Before(free[j])
+	})
+	if len(free) > limit {
+		free = free[:limit]
 	}
 
-	return suggestions[idx].Time, nil
+	sort.Slice(free, func(i, j int) bool { return free[i].Before(free[j]) })
+	return free
 }
 
-// selectEndTimeWithAvailability suggests end times based on room availability
-func selectEndTimeWithAvailability(client *config.Client, roomID string, startTime time.Time) (time.Time, error) {
-	// Set date range to cover the entire day (start of day to end of day in UTC)
-	dayStart := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, startTime.Location()).UTC()
-	dayEnd := dayStart.Add(24 * time.Hour)
-
-	// Fetch all bookings for this room on this day using availability endpoint
-	roomBookings, err := client.GetRoomAvailability(roomID, dayStart, dayEnd)
-	if err != nil {
-		// If we can't fetch availability, fall back to regular time selection
-		fmt.Println("⚠ Could not check availability, showing all options")
-		return selectTime("end", startTime)
+// printAlternatives suggests free start times relative to the requested one
+func printAlternatives(alternatives []time.Time, start time.Time) {
+	if len(alternatives) == 0 {
+		fmt.Printf("No free slot of that length left in this room on %s.\n\n", start.Local().Format("2006-01-02"))
+		return
 	}
 
-	// Filter out cancelled bookings
-	var activeBookings []generated.Booking
-	for _, booking := range roomBookings {
-		if booking.Status == nil || *booking.Status != "CANCELLED" {
-			activeBookings = append(activeBookings, booking)
+	fmt.Printf("Nearest free times:\n")
+	for _, alternative := range alternatives {
+		offset := formatDuration(alternative.Sub(start)) + " later"
+		if alternative.Before(start) {
+			offset = formatDuration(start.Sub(alternative)) + " earlier"
 		}
+		fmt.Printf("  - %s  (%s)\n", alternative.Format("2006-01-02 15:04"), offset)
 	}
-	roomBookings = activeBookings
-
-	// Find the next booking after our start time
-	var nextAvailableTime *time.Time
+	fmt.Println()
+}
 
-	// Helper function to check if a proposed end time conflicts with any booking
-	hasConflict := func(proposedEnd time.Time) bool {
-		for _, booking := range roomBookings {
-			if booking.StartTime == nil || booking.EndTime == nil {
-				continue
-			}
-			bStart := booking.StartTime.Local()
-			bEnd := booking.EndTime.Local()
-
-			// Check for overlap: our booking overlaps if:
-			// - We start at or before their booking ends AND
-			// - We end at or after their booking starts
-			// Note: This includes touching bookings (end == start) which the API rejects
-			if !startTime.After(bEnd) && !proposedEnd.Before(bStart) {
-				return true
-			}
-		}
-		return false
+func parseTime(timeStr string) (time.Time, error) {
+	// Try simple format first - most human-friendly (2025-10-19 14:00)
+	t, err := time.Parse("2006-01-02 15:04", timeStr)
+	if err == nil {
+		// Convert to local timezone for consistency
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
 	}
 
-	// Find the next booking after our start time
-	for _, booking := range roomBookings {
-		if booking.StartTime == nil || booking.EndTime == nil {
-			continue
-		}
-		bStart := booking.StartTime.Local()
-
-		// Find bookings that start after our start time
-		if bStart.After(startTime) {
-			if nextAvailableTime == nil || bStart.Before(*nextAvailableTime) {
-				nextAvailableTime = &bStart
-			}
-		}
+	// Try time only (15:00) - use today's date
+	t, err = time.Parse("15:04", timeStr)
+	if err == nil {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
 	}
 
-	// Build smart suggestions based on availability
-	var suggestions []struct {
-		Label string
-		Time  time.Time
+	// Try RFC3339 format (2025-10-19T14:00:00Z)
+	t, err = time.Parse(time.RFC3339, timeStr)
+	if err == nil {
+		return t, nil
 	}
 
-	// Common durations to check
-	durations := []struct {
-		minutes int
-		label   string
-	}{
-		{30, "30 minutes"},
-		{60, "1 hour"},
-		{120, "2 hours"},
+	// Try date only (2025-10-19), default to 9 AM
+	t, err = time.Parse("2006-01-02", timeStr)
+	if err == nil {
+		return time.Date(t.Year(), t.Month(), t.Day(), 9, 0, 0, 0, time.Local), nil
 	}
 
-	for _, dur := range durations {
-		endTime := startTime.Add(time.Duration(dur.minutes) * time.Minute)
+	// Provide helpful error message
+	return time.Time{}, fmt.Errorf(`unable to parse time: "%s"
 
-		// Check if this duration would conflict
-		available := !hasConflict(endTime)
+Supported formats:
+  "2025-10-19 14:00"    (recommended)
+  "15:00"               (time only, uses today)
+  "2025-10-19T14:00:00Z" (RFC3339)
+  "2025-10-19"          (date only, defaults to 9 AM)
 
-		// Build label with availability indicator
-		label := fmt.Sprintf("%s (%s)", dur.label, endTime.Format("15:04"))
-		if !available {
-			label = fmt.Sprintf("%s (%s) ⚠ conflicts", dur.label, endTime.Format("15:04"))
-		}
+Example: miles book -r ROOM123 -s "2025-10-19 14:00" -e "15:00" -t "Meeting"`, timeStr)
+}
 
-		suggestions = append(suggestions, struct {
-			Label string
-			Time  time.Time
-		}{
-			Label: label,
-			Time:  endTime,
-		})
+// bookingSession starts an interactive booking with the command line's
+// choices: --location, the detected office, and meeting rooms the user may
+// book
+func bookingSession(client config.API) *workflow.Session {
+	return &workflow.Session{
+		Client:        client,
+		Boundary:      bookingBoundary(),
+		LocationQuery: viper.GetString("location"),
+		MatchLocation: locationIndex,
+		DetectOffice: func(locations []milesapi.Location) (int, string) {
+			i, match := detectOffice(locations)
+			if match == nil {
+				return -1, ""
+			}
+			return i, match.Reason
+		},
+		Rooms:  milesapi.MeetingRooms,
+		Locked: roomLocked,
+		Unlock: func(ctx context.Context, room milesapi.Room) {
+			offerRoomAccess(ctx, client, room)
+		},
+		ParseTime: parseTime,
 	}
+}
 
-	// If there's a next booking, suggest ending 1 minute before it
-	if nextAvailableTime != nil {
-		duration := nextAvailableTime.Sub(startTime)
-		if duration > time.Minute && duration < 3*time.Hour {
-			// End 1 minute before the next booking to avoid conflicts
-			suggestedEnd := nextAvailableTime.Add(-1 * time.Minute)
-			label := fmt.Sprintf("Until next booking (%s) ✓ available", suggestedEnd.Format("15:04"))
-			suggestions = append([]struct {
-				Label string
-				Time  time.Time
-			}{
-				{Label: label, Time: suggestedEnd},
-			}, suggestions...)
+// offerRoomAccess explains why a room is locked and offers to ask its
+// owner for access
+func offerRoomAccess(ctx context.Context, client config.API, room milesapi.Room) {
+	fmt.Println(checkRoomAccess(&room))
+	ask := promptui.Prompt{
+		Label:     "Ask the room's owner for access",
+		IsConfirm: true,
+	}
+	if _, err := ask.Run(); err == nil {
+		if err := requestRoomAccess(ctx, client, derefString(room.Id), ""); err != nil {
+			fmt.Printf("⚠ %v\n", err)
 		}
 	}
+}
 
-	// Add custom option
-	suggestions = append(suggestions, struct {
-		Label string
-		Time  time.Time
-	}{
-		Label: "Custom time (enter manually)",
-		Time:  time.Time{},
-	})
-
-	// Build items for prompt
-	items := make([]string, len(suggestions))
-	for i, s := range suggestions {
-		items[i] = s.Label
-	}
+// formatDuration formats a duration in a human-readable way
+func formatDuration(d time.Duration) string {
+	return workflow.FormatDuration(d)
+}
 
-	prompt := promptui.Select{
-		Label: "Select end time",
-		Items: items,
-		Size:  len(items),
-	}
+// loadCalendarBusy collects busy times from calendars imported with
+// 'miles import ics' and from the calendar named by --busy-calendar (or
+// busy_calendar in the config). It returns nil when neither is available, so
+// suggestions fall back to room availability only. Notes go to stderr so
+// -o json output stays clean.
+func loadCalendarBusy(from, to time.Time) []calsync.Busy {
+	var busy []calsync.Busy
+	checked := false
 
-	idx, _, err := prompt.Run()
+	imported, names, err := calsync.ImportedBusy(from, to)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("time selection cancelled")
-	}
-
-	// If custom time selected, prompt for input
-	if suggestions[idx].Time.IsZero() {
-		customTime, err := promptString(
-			"end time",
-			`Format: "2025-10-19 14:00" or "15:00"`,
-			true,
-		)
-		if err != nil {
-			return time.Time{}, err
+		fmt.Fprintf(os.Stderr, "⚠ Could not read imported calendars: %v\n", err)
+	} else if len(names) > 0 {
+		fmt.Fprintf(os.Stderr, "ℹ Skipping times you're busy in %s\n", strings.Join(names, ", "))
+		busy = append(busy, imported...)
+		checked = true
+	}
+
+	if providerName := viper.GetString("busy_calendar"); providerName != "" {
+		ctx := context.Background()
+		if provider, err := connectCalendar(ctx, providerName, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Could not connect to %s: %v\n", providerName, err)
+		} else if remote, err := provider.BusyTimes(ctx, from, to); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Could not read busy times from %s: %v\n", providerName, err)
+		} else {
+			fmt.Fprintf(os.Stderr, "ℹ Skipping times you're busy in %s\n", providerName)
+			busy = append(busy, remote...)
+			checked = true
 		}
-		return parseTime(customTime)
 	}
 
-	return suggestions[idx].Time, nil
+	if !checked {
+		return nil
+	}
+	// Non-nil even when the calendars are empty, so callers can tell they were checked
+	return append([]calsync.Busy{}, busy...)
 }
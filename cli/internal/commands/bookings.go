@@ -1,14 +1,19 @@
 package commands
 
 import (
-	"encoding/csv"
+	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/miles/booking-cli/internal/config"
-	"github.com/miles/booking-cli/internal/generated"
+	"github.com/miles/booking-cli/internal/mirror"
+	"github.com/miles/booking-cli/internal/query"
+	"github.com/miles/booking-tui/pkg/ical"
+	"github.com/miles/booking-tui/pkg/milesapi"
 	"github.com/spf13/cobra"
 )
 
@@ -20,45 +25,123 @@ var bookingsCmd = &cobra.Command{
 By default, only active (CONFIRMED) bookings are shown.
 Use --all to include cancelled bookings.
 
+Bookings are kept in a local copy (~/.miles-mirror.json) that each run
+brings up to date by fetching only what changed. --search looks through it
+for words in the title, description, room or location: every word must
+match the start of a word in the booking, and -word excludes bookings with
+it. When the server can't be reached, or with --offline, the local copy is
+listed as of its last sync, past bookings included.
+
+Filter with a small expression language over booking fields:
+  id, title, description, status, start, end, duration, created, updated,
+  room.id, room.name, room.capacity,
+  room.location.id, room.location.name, room.location.city, room.location.country
+
+Operators: == != < <= > >= =~ (regex) && || ! + - and parentheses.
+Times compare with now, today, tomorrow or "2025-10-19 14:00" and can be
+shifted by durations like 30m, 2h, 1d or 1w. Upper-case words such as
+CONFIRMED are string constants. A filter that mentions status also sees
+cancelled bookings.
+
 Examples:
   miles bookings                  # List active bookings only
   miles bookings --all            # List all bookings including cancelled
   miles bookings -o json          # Output as JSON
-  miles bookings -o csv > my.csv  # Export to CSV`,
+  miles bookings -o csv > my.csv  # Export to CSV
+  miles bookings -o ics > my.ics  # Export to import into Outlook or Google Calendar
+  miles bookings -S design        # Bookings mentioning "design"
+  miles bookings --offline        # The local copy, without the server
+
+  # Upcoming bookings in Oslo
+  miles bookings --filter 'status==CONFIRMED && room.location.city=="Oslo" && start>now'
+
+  # Long meetings next week with "review" in the title
+  miles bookings -f 'start>=today+7d && start<today+14d && duration>1h && title=~"(?i)review"'`,
 	Aliases: []string{"list"},
 	RunE:    runBookings,
 }
 
-var showAllBookings bool
+var (
+	showAllBookings bool
+	bookingsFilter  string
+	bookingsSearch  string
+	bookingsOffline bool
+)
+
+// bookingFilterFields are the fields a --filter expression can use
+var bookingFilterFields = []string{
+	"id", "title", "description", "status", "start", "end", "duration", "created", "updated",
+	"setup", "setup.notes", "room.id", "room.name", "room.capacity",
+	"room.location.id", "room.location.name", "room.location.city", "room.location.country",
+}
 
 func init() {
 	bookingsCmd.Flags().BoolVarP(&showAllBookings, "all", "a", false, "show all bookings including cancelled")
+	bookingsCmd.Flags().StringVarP(&bookingsFilter, "filter", "f", "", "only show bookings matching an expression, e.g. 'status==CONFIRMED && start>now'")
+	bookingsCmd.Flags().StringVarP(&bookingsSearch, "search", "S", "", `only show bookings containing these words in the title, description, room or location, e.g. "design -retro"`)
+	bookingsCmd.Flags().BoolVar(&bookingsOffline, "offline", false, "list the local copy of your bookings without contacting the server")
 }
 
 func runBookings(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	// Check authentication
 	token := getAuthToken()
 	if token == "" {
 		return fmt.Errorf("not authenticated. Run 'miles login' first")
 	}
 
+	// Parse the filter before making any requests
+	var filter *query.Expr
+	if bookingsFilter != "" {
+		var err error
+		filter, err = parseBookingFilter(bookingsFilter)
+		if err != nil {
+			return err
+		}
+	}
+
+	var tmpl *template.Template
+	if output == "template" {
+		var err error
+		if tmpl, err = parseOutputTemplate(); err != nil {
+			return err
+		}
+	}
+
 	// Create API client
-	client := config.NewClient(getAPIURL(), token)
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
 
-	// Fetch bookings
-	allBookings, err := client.GetBookings()
+	// Bookings come from the local mirror, brought up to date first
+	allBookings, err := mirroredBookings(ctx, client, token)
 	if err != nil {
 		return err
 	}
+	if err := revealDescriptions(allBookings); err != nil {
+		return err
+	}
+
+	// A filter on status decides about cancelled bookings itself
+	includeCancelled := showAllBookings
+	if filter != nil {
+		allBookings, err = filterBookings(ctx, client, filter, allBookings)
+		if err != nil {
+			return err
+		}
+		includeCancelled = includeCancelled || filter.Uses("status")
+	}
 
 	// Separate active and cancelled bookings
-	var activeBookings []generated.Booking
+	var activeBookings []milesapi.Booking
 	var cancelledCount int
 
 	for _, booking := range allBookings {
 		if booking.Status != nil && *booking.Status == "CANCELLED" {
 			cancelledCount++
-			if showAllBookings {
+			if includeCancelled {
 				activeBookings = append(activeBookings, booking)
 			}
 		} else {
@@ -70,6 +153,14 @@ func runBookings(cmd *cobra.Command, args []string) error {
 	bookingsToShow := activeBookings
 
 	if len(bookingsToShow) == 0 {
+		if bookingsSearch != "" && len(allBookings) == 0 {
+			fmt.Printf("No bookings match %q\n", bookingsSearch)
+			return nil
+		}
+		if filter != nil && len(allBookings) == 0 {
+			fmt.Println("No bookings match the filter")
+			return nil
+		}
 		if cancelledCount > 0 {
 			fmt.Printf("No active bookings found (%d cancelled)\n", cancelledCount)
 			fmt.Println("Use 'miles bookings --all' to see cancelled bookings")
@@ -85,18 +176,214 @@ func runBookings(cmd *cobra.Command, args []string) error {
 		return outputJSON(bookingsToShow)
 	case "csv":
 		return outputBookingsCSV(bookingsToShow)
+	case "ics":
+		return outputBookingsICS(bookingsToShow, newTemplateLookup(ctx, client))
+	case "template":
+		lookup := newTemplateLookup(ctx, client)
+		for _, booking := range bookingsToShow {
+			if err := writeTemplate(tmpl, templateBooking{Booking: booking, lookup: lookup}); err != nil {
+				return err
+			}
+		}
+		return nil
 	default:
 		return outputBookingsTable(bookingsToShow, cancelledCount)
 	}
 }
 
-func outputBookingsTable(bookings []generated.Booking, cancelledCount int) error {
-	// Print header - show full IDs
-	fmt.Printf("%-25s %-30s %-16s %-16s %-10s\n",
-		"ID", "Title", "Start", "End", "Status")
-	fmt.Println(strings.Repeat("-", 100))
+// mirroredBookings syncs the local mirror of the user's bookings and returns
+// them, or those matching --search. When the server can't be reached, or
+// with --offline, the last synced copy is used.
+func mirroredBookings(ctx context.Context, client config.API, token string) ([]milesapi.Booking, error) {
+	m := mirror.Open(mirror.DefaultPath(), mirrorOwner(token))
+	// Search sees encrypted descriptions decrypted
+	key, err := descriptionKey()
+	if err != nil {
+		return nil, err
+	}
+	m.DescriptionKey = key
+
+	synced := false
+	if !bookingsOffline {
+		err := m.Sync(ctx, client)
+		if err != nil && (m.Empty() || !config.Unreachable(err)) {
+			return nil, err
+		}
+		if err == nil {
+			synced = true
+			if err := m.Save(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save the local copy of your bookings: %v\n", err)
+			}
+		}
+	}
+	if !synced {
+		if m.Empty() {
+			return nil, fmt.Errorf("no local copy of your bookings yet. Run 'miles bookings' while online first")
+		}
+		// Stderr so the note never ends up in -o json/csv output
+		fmt.Fprintf(os.Stderr, "⚠ Offline: showing bookings as of %s\n\n", humanizeTime(m.SyncedAt, time.Now()))
+	}
+
+	if bookingsSearch != "" {
+		return m.Search(bookingsSearch), nil
+	}
+	return m.List(), nil
+}
+
+// mirrorOwner identifies whose bookings the mirror holds, so logging in as
+// someone else, switching server or impersonating starts a fresh copy
+func mirrorOwner(token string) string {
+	return getAPIURL() + " " + milesapi.TokenUserID(token) + " " + actAs
+}
 
-	// Print bookings
+// parseBookingFilter parses a --filter expression and checks its field names
+func parseBookingFilter(src string) (*query.Expr, error) {
+	filter, err := query.Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	for _, field := range filter.Fields() {
+		if !slices.Contains(bookingFilterFields, field) {
+			return nil, fmt.Errorf("invalid filter: unknown field %q (available: %s)",
+				field, strings.Join(bookingFilterFields, ", "))
+		}
+	}
+	return filter, nil
+}
+
+// filterBookings keeps the bookings matching the filter. Rooms and locations
+// are only fetched when the filter refers to them.
+func filterBookings(ctx context.Context, client config.API, filter *query.Expr, bookings []milesapi.Booking) ([]milesapi.Booking, error) {
+	rooms := map[string]milesapi.Room{}
+	locations := map[string]milesapi.Location{}
+
+	if filter.Uses("room.name") || filter.Uses("room.capacity") || filter.Uses("room.location") {
+		allRooms, err := client.GetRooms(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, room := range allRooms {
+			if room.Id != nil {
+				rooms[*room.Id] = room
+			}
+		}
+	}
+	if filter.Uses("room.location") {
+		allLocations, err := client.GetLocations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, location := range allLocations {
+			if location.Id != nil {
+				locations[*location.Id] = location
+			}
+		}
+	}
+
+	var matched []milesapi.Booking
+	for _, booking := range bookings {
+		ok, err := filter.Match(bookingFilterEnv(booking, rooms, locations))
+		if err != nil {
+			return nil, fmt.Errorf("filter: %w", err)
+		}
+		if ok {
+			matched = append(matched, booking)
+		}
+	}
+	return matched, nil
+}
+
+// bookingFilterEnv exposes a booking's fields to a filter expression.
+// Missing values are null, except text fields which are empty strings.
+func bookingFilterEnv(booking milesapi.Booking, rooms map[string]milesapi.Room, locations map[string]milesapi.Location) query.Env {
+	var room milesapi.Room
+	if booking.RoomId != nil {
+		room = rooms[*booking.RoomId]
+	}
+	var location milesapi.Location
+	if room.LocationId != nil {
+		location = locations[*room.LocationId]
+	}
+
+	text := func(s *string) any {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	timeValue := func(t *time.Time) any {
+		if t == nil {
+			return nil
+		}
+		return *t
+	}
+
+	return func(field string) (any, bool) {
+		switch field {
+		case "id":
+			return text(booking.Id), true
+		case "title":
+			return text(booking.Title), true
+		case "description":
+			return text(booking.Description), true
+		case "status":
+			if booking.Status == nil {
+				return "", true
+			}
+			return string(*booking.Status), true
+		case "setup":
+			if booking.Setup == nil {
+				return "", true
+			}
+			return string(*booking.Setup), true
+		case "setup.notes":
+			return text(booking.SetupNotes), true
+		case "start":
+			return timeValue(booking.StartTime), true
+		case "end":
+			return timeValue(booking.EndTime), true
+		case "duration":
+			if booking.StartTime == nil || booking.EndTime == nil {
+				return nil, true
+			}
+			return booking.EndTime.Sub(*booking.StartTime), true
+		case "created":
+			return timeValue(booking.CreatedAt), true
+		case "updated":
+			return timeValue(booking.UpdatedAt), true
+		case "room.id":
+			return text(booking.RoomId), true
+		case "room.name":
+			return text(room.Name), true
+		case "room.capacity":
+			if room.Capacity == nil {
+				return nil, true
+			}
+			return *room.Capacity, true
+		case "room.location.id":
+			return text(room.LocationId), true
+		case "room.location.name":
+			return text(location.Name), true
+		case "room.location.city":
+			return text(location.City), true
+		case "room.location.country":
+			return text(location.Country), true
+		}
+		return nil, false
+	}
+}
+
+func outputBookingsTable(bookings []milesapi.Booking, cancelledCount int) error {
+	// Full IDs; on narrow terminals the title shrinks, then status and end go
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 5},
+		{header: "Title", width: 30, minWidth: 12, priority: 4},
+		{header: "Start", width: 16, priority: 3},
+		{header: "End", width: 16, priority: 2},
+		{header: "Status", width: 10, priority: 1},
+	}
+
+	var rows [][]string
 	for _, booking := range bookings {
 		id := ""
 		if booking.Id != nil {
@@ -120,15 +407,9 @@ func outputBookingsTable(bookings []generated.Booking, cancelledCount int) error
 			endStr = booking.EndTime.Format("2006-01-02 15:04")
 		}
 
-		// Show full ID, truncate title if needed
-		fmt.Printf("%-25s %-30s %-16s %-16s %-10s\n",
-			id,
-			truncate(title, 30),
-			startStr,
-			endStr,
-			status,
-		)
+		rows = append(rows, []string{id, title, startStr, endStr, status})
 	}
+	printTable(columns, rows)
 
 	// Summary
 	if showAllBookings {
@@ -148,12 +429,22 @@ func outputBookingsTable(bookings []generated.Booking, cancelledCount int) error
 	return nil
 }
 
-func outputBookingsCSV(bookings []generated.Booking) error {
-	w := csv.NewWriter(os.Stdout)
-	defer w.Flush()
+// bookingCSVColumns are the columns of -o csv booking output
+var bookingCSVColumns = []csvColumn{
+	{"id", "ID"},
+	{"title", "Title"},
+	{"description", "Description"},
+	{"room_id", "Room ID"},
+	{"start_time", "Start Time"},
+	{"end_time", "End Time"},
+	{"status", "Status"},
+}
 
-	// Write header
-	w.Write([]string{"ID", "Title", "Description", "Room ID", "Start Time", "End Time", "Status"})
+func outputBookingsCSV(bookings []milesapi.Booking) error {
+	w, err := newCSVWriter(os.Stdout, bookingCSVColumns)
+	if err != nil {
+		return err
+	}
 
 	// Write data
 	for _, booking := range bookings {
@@ -189,5 +480,60 @@ func outputBookingsCSV(bookings []generated.Booking) error {
 		w.Write([]string{id, title, description, roomId, startTime, endTime, status})
 	}
 
-	return nil
+	w.Flush()
+	return w.Error()
+}
+
+// outputBookingsICS writes bookings as an iCalendar file, each in its
+// location's time zone. Pending bookings are tentative events.
+func outputBookingsICS(bookings []milesapi.Booking, lookup *templateLookup) error {
+	events := make([]ical.Event, 0, len(bookings))
+	for _, booking := range bookings {
+		if booking.StartTime == nil || booking.EndTime == nil {
+			continue
+		}
+		event := ical.Event{
+			UID:         derefString(booking.Id) + "@" + ical.UIDDomain,
+			Start:       *booking.StartTime,
+			End:         *booking.EndTime,
+			Summary:     derefString(booking.Title),
+			Description: derefString(booking.Description),
+			Status:      ical.Confirmed,
+		}
+		if booking.UpdatedAt != nil {
+			event.Updated = *booking.UpdatedAt
+		}
+		if booking.Status != nil {
+			switch *booking.Status {
+			case milesapi.BookingStatusPENDING:
+				event.Status = ical.Tentative
+			case milesapi.BookingStatusCANCELLED:
+				event.Status = ical.Cancelled
+			}
+		}
+
+		booking := templateBooking{Booking: booking, lookup: lookup}
+		room, err := booking.Room()
+		if err != nil {
+			return err
+		}
+		location, err := booking.Location()
+		if err != nil {
+			return err
+		}
+		var where []string
+		for _, name := range []string{derefString(room.Name), derefString(location.Name)} {
+			if name != "" {
+				where = append(where, name)
+			}
+		}
+		event.Location = strings.Join(where, ", ")
+		if tz := derefString(location.Timezone); tz != "" {
+			if zone, err := time.LoadLocation(tz); err == nil {
+				event.Zone = zone
+			}
+		}
+		events = append(events, event)
+	}
+	return ical.Write(os.Stdout, events)
 }
@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
 	"github.com/spf13/cobra"
 )
 
@@ -12,21 +16,37 @@ var cancelCmd = &cobra.Command{
 	Short:             "Cancel a booking",
 	Long:              `Cancel an existing booking by its ID.
 
+Whether it asks first is up to confirm in the config file: cancelling
+a group, or someone else's booking as an admin or manager, asks by default.
+--yes answers for you.
+
+Locations can set a late-cancellation window, such as an hour before the
+start. Cancelling your booking inside it warns you first, and the server
+records it as a late cancellation that managers see in
+'miles report utilization'.
+
 Examples:
   miles cancel BOOK123
-  miles cancel --id BOOK123`,
+  miles cancel --id BOOK123
+  miles cancel --group GROUP_ID    # Every room of a zone booking`,
 	Args:              cobra.MaximumNArgs(1),
 	RunE:              runCancel,
 	ValidArgsFunction: completeBookingIDs,
 }
 
-var cancelID string
+var (
+	cancelID    string
+	cancelGroup string
+)
 
 func init() {
 	cancelCmd.Flags().StringVar(&cancelID, "id", "", "booking ID to cancel")
+	cancelCmd.Flags().StringVar(&cancelGroup, "group", "", "cancel every booking in this group, such as a zone booking")
+	cancelCmd.MarkFlagsMutuallyExclusive("id", "group")
 }
 
 func runCancel(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	// Check authentication
 	token := getAuthToken()
 	if token == "" {
@@ -39,20 +59,121 @@ func runCancel(cmd *cobra.Command, args []string) error {
 		bookingID = args[0]
 	}
 
-	if bookingID == "" {
+	if bookingID == "" && cancelGroup == "" {
 		return fmt.Errorf("booking ID is required")
 	}
 
 	// Create API client
-	client := config.NewClient(getAPIURL(), token)
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if cancelGroup != "" {
+		if bookingID != "" {
+			return fmt.Errorf("give a booking ID or --group, not both")
+		}
+		if err := confirmAction(confirmBulkCancel,
+			fmt.Sprintf("Cancel every booking in group %s", cancelGroup),
+			"cancel stopped, the group is kept"); err != nil {
+			return err
+		}
+		result, err := client.CancelBookingGroup(ctx, cancelGroup)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Cancelled %d bookings in group %s\n", result.Cancelled, cancelGroup)
+		printLateCancellation(result)
+		return nil
+	}
+
+	if err := confirmCancelBooking(ctx, client, token, bookingID); err != nil {
+		return err
+	}
 
 	// Cancel booking
-	if err := client.CancelBooking(bookingID); err != nil {
+	result, err := client.CancelBooking(ctx, bookingID)
+	if err != nil {
 		return err
 	}
 
-	fmt.Printf("✓ Booking %s has been cancelled\n\n", bookingID)
+	fmt.Printf("✓ Booking %s has been cancelled\n", bookingID)
+	printLateCancellation(result)
+	fmt.Println()
 	fmt.Println("The booking no longer appears in your active bookings.")
 	fmt.Println("Use 'miles bookings --all' to see all bookings including cancelled ones.")
 	return nil
 }
+
+// confirmCancelBooking asks before cancelling a booking as confirm.cancel
+// says, or as confirm.admin_cancel says when an admin or manager cancels
+// someone else's. Cancelling your own inside its location's late window
+// warns first.
+func confirmCancelBooking(ctx context.Context, client config.API, token, bookingID string) error {
+	action, label := confirmCancel, fmt.Sprintf("Cancel booking %s", bookingID)
+	if booking, err := findBooking(ctx, client, bookingID); err == nil {
+		if derefString(booking.UserId) == milesapi.TokenUserID(token) {
+			warnLateCancellation(ctx, client, booking, config.ServerNow())
+		} else if config.RoleAllows(milesapi.TokenRole(token), milesapi.MANAGER) {
+			action = confirmAdminCancel
+			label = fmt.Sprintf("Cancel someone else's booking %q (%s)", derefString(booking.Title),
+				booking.StartTime.Local().Format("Mon Jan 2 15:04"))
+		}
+	}
+	return confirmAction(action, label, fmt.Sprintf("cancel stopped, keeping %s", bookingID))
+}
+
+// warnLateCancellation says, before cancelling one of your own bookings,
+// when its location will record the cancellation as late. It is only a
+// hint, so failures print nothing.
+func warnLateCancellation(ctx context.Context, client config.API, booking *milesapi.Booking, now time.Time) {
+	if booking.StartTime == nil || booking.EndTime == nil || !booking.EndTime.After(now) {
+		return
+	}
+	room, err := findRoom(ctx, client, derefString(booking.RoomId))
+	if err != nil {
+		return
+	}
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		return
+	}
+	i := locationIndex(locations, derefString(room.LocationId))
+	if i < 0 || locations[i].LateCancelMinutes == nil {
+		return
+	}
+	window := time.Duration(*locations[i].LateCancelMinutes) * time.Minute
+	if booking.StartTime.Sub(now) >= window {
+		return
+	}
+
+	when := "it has already started"
+	if untilStart := booking.StartTime.Sub(now); untilStart > 0 {
+		when = "it starts in " + formatDuration(untilStart.Round(time.Minute))
+	}
+	fmt.Fprintf(os.Stderr, "⚠ Late cancellation: %s. %s records cancellations less than %s before the start as late.\n",
+		when, derefString(locations[i].Name), formatDuration(window))
+}
+
+// printLateCancellation passes on the server's warning when it recorded
+// late cancellations
+func printLateCancellation(result *config.CancelResult) {
+	if result.LateCancellations > 0 && result.Warning != "" {
+		fmt.Printf("⚠ %s\n", result.Warning)
+	}
+}
+
+// findBooking returns the booking with the ID among those the user can see
+func findBooking(ctx context.Context, client config.API, bookingID string) (*milesapi.Booking, error) {
+	bookings, err := client.GetBookings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bookings {
+		if derefString(bookings[i].Id) == bookingID {
+			return &bookings[i], nil
+		}
+	}
+	return nil, fmt.Errorf("booking %s not found", bookingID)
+}
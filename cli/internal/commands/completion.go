@@ -2,12 +2,16 @@ package commands
 
 import (
 	"context"
+	"math"
+	"strings"
 	"time"
 
-	"github.com/miles/booking-cli/internal/config"
 	"github.com/spf13/cobra"
 )
 
+// completionTimeout bounds the requests completions make
+const completionTimeout = time.Second
+
 // completeRoomIDs provides room ID completions for the -r flag
 func completeRoomIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// Get auth token
@@ -17,52 +21,34 @@ func completeRoomIDs(cmd *cobra.Command, args []string, toComplete string) ([]st
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	// Create client with timeout
-	client := config.NewClient(getAPIURL(), token)
-
-	// Use context with timeout to prevent hanging
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	// Give up after a second rather than hang the shell
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
 	defer cancel()
 
-	// Fetch rooms in a goroutine
-	type result struct {
-		ids []string
-		err error
+	// Create client with timeout
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
-	resultCh := make(chan result, 1)
+	defer client.Close()
 
-	go func() {
-		rooms, err := client.GetRooms("")
-		if err != nil {
-			resultCh <- result{nil, err}
-			return
-		}
+	rooms, err := client.GetRooms(ctx, "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
 
-		ids := make([]string, 0, len(rooms))
-		for _, room := range rooms {
-			if room.Id != nil {
-				// Format: room-id:Room Name
-				id := *room.Id
-				if room.Name != nil {
-					id = id + "\t" + *room.Name
-				}
-				ids = append(ids, id)
+	ids := make([]string, 0, len(rooms))
+	for _, room := range rooms {
+		if room.Id != nil {
+			// Format: room-id:Room Name
+			id := *room.Id
+			if room.Name != nil {
+				id = id + "\t" + *room.Name
 			}
+			ids = append(ids, id)
 		}
-		resultCh <- result{ids, nil}
-	}()
-
-	// Wait for result or timeout
-	select {
-	case res := <-resultCh:
-		if res.err != nil {
-			return nil, cobra.ShellCompDirectiveNoFileComp
-		}
-		return res.ids, cobra.ShellCompDirectiveNoFileComp
-	case <-ctx.Done():
-		// Timeout, return empty
-		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
 }
 
 // completeLocationIDs provides location ID completions for the -l flag
@@ -74,52 +60,34 @@ func completeLocationIDs(cmd *cobra.Command, args []string, toComplete string) (
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	// Create client with timeout
-	client := config.NewClient(getAPIURL(), token)
-
-	// Use context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	// Give up after a second rather than hang the shell
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
 	defer cancel()
 
-	// Fetch locations in a goroutine
-	type result struct {
-		ids []string
-		err error
+	// Create client with timeout
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
-	resultCh := make(chan result, 1)
+	defer client.Close()
 
-	go func() {
-		locations, err := client.GetLocations()
-		if err != nil {
-			resultCh <- result{nil, err}
-			return
-		}
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
 
-		ids := make([]string, 0, len(locations))
-		for _, location := range locations {
-			if location.Id != nil {
-				// Format: location-id:Location Name
-				id := *location.Id
-				if location.Name != nil {
-					id = id + "\t" + *location.Name
-				}
-				ids = append(ids, id)
+	ids := make([]string, 0, len(locations))
+	for _, location := range locations {
+		if location.Id != nil {
+			// Format: location-id:Location Name
+			id := *location.Id
+			if location.Name != nil {
+				id = id + "\t" + *location.Name
 			}
+			ids = append(ids, id)
 		}
-		resultCh <- result{ids, nil}
-	}()
-
-	// Wait for result or timeout
-	select {
-	case res := <-resultCh:
-		if res.err != nil {
-			return nil, cobra.ShellCompDirectiveNoFileComp
-		}
-		return res.ids, cobra.ShellCompDirectiveNoFileComp
-	case <-ctx.Done():
-		// Timeout, return empty
-		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
 }
 
 // completeBookingIDs provides booking ID completions for the cancel command
@@ -131,59 +99,131 @@ func completeBookingIDs(cmd *cobra.Command, args []string, toComplete string) ([
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	// Create client with timeout
-	client := config.NewClient(getAPIURL(), token)
-
-	// Use context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	// Give up after a second rather than hang the shell
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
 	defer cancel()
 
-	// Fetch bookings in a goroutine
-	type result struct {
-		ids []string
-		err error
+	// Create client with timeout
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
-	resultCh := make(chan result, 1)
+	defer client.Close()
 
-	go func() {
-		bookings, err := client.GetBookings()
-		if err != nil {
-			resultCh <- result{nil, err}
-			return
-		}
+	bookings, err := client.GetBookings(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
 
-		ids := make([]string, 0, len(bookings))
-		for _, booking := range bookings {
-			// Only suggest active (CONFIRMED) bookings
-			if booking.Status != nil && *booking.Status == "CANCELLED" {
-				continue
-			}
+	ids := make([]string, 0, len(bookings))
+	for _, booking := range bookings {
+		// Only suggest active (CONFIRMED) bookings
+		if booking.Status != nil && *booking.Status == "CANCELLED" {
+			continue
+		}
 
-			if booking.Id != nil {
-				// Format: booking-id:Title (Start - End)
-				id := *booking.Id
-				if booking.Title != nil {
-					desc := *booking.Title
-					if booking.StartTime != nil {
-						desc = desc + " (" + booking.StartTime.Format("Jan 02 15:04") + ")"
-					}
-					id = id + "\t" + desc
+		if booking.Id != nil {
+			// Format: booking-id:Title (Start - End)
+			id := *booking.Id
+			if booking.Title != nil {
+				desc := *booking.Title
+				if booking.StartTime != nil {
+					desc = desc + " (" + booking.StartTime.Format("Jan 02 15:04") + ")"
 				}
-				ids = append(ids, id)
+				id = id + "\t" + desc
 			}
+			ids = append(ids, id)
 		}
-		resultCh <- result{ids, nil}
-	}()
-
-	// Wait for result or timeout
-	select {
-	case res := <-resultCh:
-		if res.err != nil {
-			return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionHours are the hours start times are suggested at
+var completionHours = []int{8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+// completionLengths are the meeting lengths -e suggests
+var completionLengths = []time.Duration{30 * time.Minute, 45 * time.Minute, time.Hour, 90 * time.Minute, 2 * time.Hour, 3 * time.Hour}
+
+// completeStartTimes suggests values for -s without asking the server: the
+// hours left today, then tomorrow and next Monday. Once a date has been
+// typed, the hours on that day are suggested instead.
+func completeStartTimes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	const dateLayout = "2006-01-02"
+	if len(toComplete) >= len(dateLayout) {
+		if day, err := time.ParseInLocation(dateLayout, toComplete[:len(dateLayout)], time.Local); err == nil {
+			return startTimesOn(day, today, "2006-01-02 15:04"), cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+
+	// parseTime puts a bare time on today, so today's hours need no date
+	completions := startTimesOn(today, today, "15:04")
+	tomorrow := today.AddDate(0, 0, 1)
+	completions = append(completions, startTimesOn(tomorrow, today, "2006-01-02 15:04")...)
+	if monday := today.AddDate(0, 0, daysUntil(today, time.Monday)); !monday.Equal(tomorrow) {
+		completions = append(completions, startTimesOn(monday, today, "2006-01-02 15:04")...)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// startTimesOn lists the completion hours on day that are still ahead, in
+// layout and described relative to today
+func startTimesOn(day, today time.Time, layout string) []string {
+	now := time.Now()
+	when := describeDay(day, today)
+
+	var completions []string
+	for _, hour := range completionHours {
+		t := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.Local)
+		if t.After(now) {
+			completions = append(completions, t.Format(layout)+"\t"+when)
+		}
+	}
+	return completions
+}
+
+// daysUntil is how many days after today the next weekday is, 1 to 7
+func daysUntil(today time.Time, weekday time.Weekday) int {
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		return 7
+	}
+	return days
+}
+
+// describeDay names a day relative to today, e.g. "tomorrow", "Friday" or
+// "next Monday"
+func describeDay(day, today time.Time) string {
+	switch days := int(math.Round(day.Sub(today).Hours() / 24)); {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days > 1 && days < 7:
+		return day.Format("Monday")
+	case days == 7:
+		return "next " + day.Format("Monday")
+	}
+	return day.Format("Mon Jan 2")
+}
+
+// completeEndTimes suggests meeting lengths for -e, with the end each gives
+// when -s is already on the command line
+func completeEndTimes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var start time.Time
+	if value, _ := cmd.Flags().GetString("start"); value != "" {
+		start, _ = parseTime(value)
+	}
+
+	completions := make([]string, len(completionLengths))
+	for i, length := range completionLengths {
+		// "1h30m" rather than formatDuration's "1h 30m", which needs quoting
+		completions[i] = strings.ReplaceAll(formatDuration(length), " ", "")
+		if !start.IsZero() {
+			completions[i] += "\tuntil " + start.Add(length).Format("15:04")
 		}
-		return res.ids, cobra.ShellCompDirectiveNoFileComp
-	case <-ctx.Done():
-		// Timeout, return empty
-		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
 }
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// Destructive actions that can ask first, as named under confirm in the
+// config file
+const (
+	confirmCancel      = "cancel"       // Cancelling one of your own bookings
+	confirmBulkCancel  = "bulk_cancel"  // Cancelling a group of bookings at once
+	confirmAdminCancel = "admin_cancel" // Cancelling someone else's booking
+)
+
+// confirmActions are the actions in the order the docs list them
+var confirmActions = []string{confirmCancel, confirmBulkCancel, confirmAdminCancel}
+
+// assumeYes is --yes: answer yes to every confirmation
+var assumeYes bool
+
+// confirmAction asks before a destructive action when confirm.<action> is
+// set in the config, or always with safe_mode. --yes answers for the user.
+// Without a terminal to ask on, scripts go ahead as before unless safe_mode
+// is on; then they must pass --yes. declined is the error when the answer
+// is no.
+func confirmAction(action, label, declined string) error {
+	safeMode := viper.GetBool("safe_mode")
+	if assumeYes || (!safeMode && !viper.GetBool("confirm."+action)) {
+		return nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		if safeMode {
+			return fmt.Errorf("%s: safe_mode needs --yes when there is no terminal to confirm on", label)
+		}
+		return nil
+	}
+
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+	if _, err := prompt.Run(); err != nil {
+		return fmt.Errorf("%s", declined)
+	}
+	return nil
+}
+
+// confirmSetting checks the confirm section of the config file
+func confirmSetting(value any) error {
+	actions, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("must map actions to true or false")
+	}
+	for action, ask := range actions {
+		if !slices.Contains(confirmActions, action) {
+			return fmt.Errorf("unknown action %q: use %s", action, strings.Join(confirmActions, ", "))
+		}
+		if _, ok := ask.(bool); !ok {
+			return fmt.Errorf("%s must be true or false", action)
+		}
+	}
+	return nil
+}
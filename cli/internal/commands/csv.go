@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/viper"
+)
+
+// utf8BOM lets Excel recognise a CSV file as UTF-8
+const utf8BOM = "\ufeff"
+
+// csvColumn is a CSV column: a stable key naming it in header mappings,
+// and its default header
+type csvColumn struct {
+	key    string
+	header string
+}
+
+// newCSVWriter writes -o csv output to out: the optional BOM, then the
+// header row in the configured naming. Records are RFC 4180: CRLF line
+// endings, and fields with delimiters, quotes or line breaks are quoted.
+func newCSVWriter(out io.Writer, columns []csvColumn) (*csv.Writer, error) {
+	delimiter, err := csvDelimiter(viper.GetString("csv_delimiter"))
+	if err != nil {
+		return nil, err
+	}
+	headers, err := csvHeaders(viper.GetString("csv_headers"), columns)
+	if err != nil {
+		return nil, err
+	}
+
+	if viper.GetBool("csv_bom") {
+		if _, err := io.WriteString(out, utf8BOM); err != nil {
+			return nil, err
+		}
+	}
+
+	w := csv.NewWriter(out)
+	w.Comma = delimiter
+	w.UseCRLF = true
+	if err := w.Write(headers); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// csvDelimiter parses --csv-delimiter: a single character, or "tab"
+func csvDelimiter(s string) (rune, error) {
+	switch s {
+	case "":
+		return ',', nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("invalid --csv-delimiter %q: use a single character such as ';', or tab", s)
+	}
+	return r, nil
+}
+
+// csvHeaders returns the header row for columns. naming is "default",
+// "keys" for the column keys, or the name of a mapping from column key to
+// header under "csv_header_sets" in the config file. Columns a mapping leaves
+// out keep their default header.
+func csvHeaders(naming string, columns []csvColumn) ([]string, error) {
+	headers := make([]string, len(columns))
+	switch naming {
+	case "", "default":
+		for i, column := range columns {
+			headers[i] = column.header
+		}
+		return headers, nil
+	case "keys":
+		for i, column := range columns {
+			headers[i] = column.key
+		}
+		return headers, nil
+	}
+
+	mappings := viper.GetStringMap("csv_header_sets")
+	if _, ok := mappings[naming]; !ok {
+		names := []string{"default", "keys"}
+		for name := range mappings {
+			names = append(names, name)
+		}
+		sort.Strings(names[2:])
+		return nil, fmt.Errorf("unknown --csv-headers %q: use %s", naming, strings.Join(names, ", "))
+	}
+
+	mapping := viper.GetStringMapString("csv_header_sets." + naming)
+	for i, column := range columns {
+		headers[i] = column.header
+		if header, ok := mapping[column.key]; ok {
+			headers[i] = header
+		}
+	}
+	return headers, nil
+}
@@ -0,0 +1,294 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-cli/internal/daemon"
+	"github.com/miles/booking-cli/internal/mirror"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/reminder"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run milesd, the background sync daemon",
+	Long: `milesd keeps your bookings, the activity of what you follow and,
+optionally, your external calendar up to date in the background, and
+reminds you of bookings about to start, newly approved or about to end.
+The TUI reads what it fetches and shows its reminders instead of polling
+the server itself, and 'miles bookings' finds its local copy already
+synced.
+
+Configuration (in ~/.miles-cli.yaml):
+  daemon:
+    interval: 30s             # how often bookings and activity are fetched
+    calendar: gcal            # push bookings to gcal or outlook (default: off)
+    calendar_id: ""           # calendar to push into (default: your primary)
+    calendar_interval: 15m    # how often the calendar is synced
+    delete_cancelled: false   # remove events of cancelled bookings
+    notify: notify-send       # run with each reminder's text (default: off)
+
+Sign in to the calendar once with 'miles sync gcal' (or outlook) first; the
+daemon never opens a browser.
+
+Examples:
+  miles daemon start                # Start milesd in the background
+  miles daemon status               # What it is doing
+  miles daemon sync                 # Fetch now instead of at the next poll
+  miles daemon stop
+  miles daemon start --foreground   # Run it under systemd or launchd`,
+}
+
+var daemonStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start milesd in the background",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStart,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop milesd",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		response, err := daemon.Call(daemon.CommandStop)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Stopped milesd (pid %d)\n", response.Status.PID)
+		return nil
+	},
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether milesd is running and how its tasks went",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStatus,
+}
+
+var daemonSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Have milesd fetch everything and sync the calendar now",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := daemon.Call(daemon.CommandSync); err != nil {
+			return err
+		}
+		fmt.Println("✓ milesd is syncing; see 'miles daemon status'")
+		return nil
+	},
+}
+
+var daemonForeground bool
+
+// daemonStartTimeout is how long 'miles daemon start' waits for milesd to
+// answer on its socket
+const daemonStartTimeout = 5 * time.Second
+
+func init() {
+	daemonStartCmd.Flags().BoolVar(&daemonForeground, "foreground", false, "run in this process, logging to stderr, until interrupted")
+
+	daemonCmd.AddCommand(daemonStartCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonStatusCmd)
+	daemonCmd.AddCommand(daemonSyncCmd)
+}
+
+// ExecuteDaemon runs milesd, the daemon binary
+func ExecuteDaemon() error {
+	cmd := &cobra.Command{
+		Use:          "milesd",
+		Short:        "Miles booking sync daemon",
+		Long:         "milesd is started by 'miles daemon start'; see 'miles daemon --help'.",
+		Version:      Version,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemon()
+		},
+	}
+	cmd.Flags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.miles-cli.yaml)")
+	return cmd.Execute()
+}
+
+func runDaemonStart(cmd *cobra.Command, args []string) error {
+	if daemonForeground {
+		return runDaemon()
+	}
+	if getAuthToken() == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+	if response, err := daemon.Call(daemon.CommandStatus); err == nil {
+		fmt.Printf("milesd is already running (pid %d)\n", response.Status.PID)
+		return nil
+	}
+
+	binary, err := findDaemonBinary()
+	if err != nil {
+		return err
+	}
+	logPath, err := daemon.LogPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	var daemonArgs []string
+	if cfgFile != "" {
+		daemonArgs = append(daemonArgs, "--config", cfgFile)
+	}
+	// The daemon inherits the environment, so MILES_TOKEN and API_URL carry over
+	process := exec.Command(binary, daemonArgs...)
+	process.Stdout = logFile
+	process.Stderr = logFile
+	if err := process.Start(); err != nil {
+		return fmt.Errorf("failed to start milesd: %w", err)
+	}
+	pid := process.Process.Pid
+	process.Process.Release()
+
+	deadline := time.Now().Add(daemonStartTimeout)
+	for time.Now().Before(deadline) {
+		if _, err := daemon.Call(daemon.CommandStatus); err == nil {
+			fmt.Printf("✓ Started milesd (pid %d), logging to %s\n", pid, logPath)
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("milesd did not start; see %s", logPath)
+}
+
+// findDaemonBinary finds milesd next to this executable or on the PATH
+func findDaemonBinary() (string, error) {
+	if self, err := os.Executable(); err == nil {
+		path := filepath.Join(filepath.Dir(self), "milesd")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath("milesd")
+	if err != nil {
+		return "", fmt.Errorf("milesd is not installed next to miles or on your PATH (or use 'miles daemon start --foreground')")
+	}
+	return path, nil
+}
+
+// runDaemon runs the daemon in this process until interrupted or stopped
+func runDaemon() error {
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Closing the terminal that started it doesn't stop the daemon
+	signal.Ignore(syscall.SIGHUP)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	calendar := viper.GetString("daemon.calendar")
+	notify := strings.Fields(viper.GetString("daemon.notify"))
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+	return daemon.Run(ctx, daemon.Options{
+		API:              client,
+		Server:           getAPIURL(),
+		UserID:           milesapi.TokenUserID(token),
+		Version:          Version,
+		Mirror:           mirror.Open(mirror.DefaultPath(), mirrorOwner(token)),
+		PollInterval:     viper.GetDuration("daemon.interval"),
+		Calendar:         calendar,
+		CalendarInterval: viper.GetDuration("daemon.calendar_interval"),
+		DeleteCancelled:  viper.GetBool("daemon.delete_cancelled"),
+		Connect: func(ctx context.Context) (calsync.Provider, error) {
+			if !calsync.HasToken(calendar) {
+				return nil, fmt.Errorf("not signed in to %s; run 'miles sync %s' once", calendar, calendar)
+			}
+			return connectCalendar(ctx, calendar, viper.GetString("daemon.calendar_id"))
+		},
+		Notify: func(ctx context.Context, r reminder.Reminder) {
+			if len(notify) == 0 {
+				return
+			}
+			if err := exec.CommandContext(ctx, notify[0], slices.Concat(notify[1:], []string{r.Text()})...).Run(); err != nil {
+				logger.Printf("notify: %v", err)
+			}
+		},
+		Logf: logger.Printf,
+	})
+}
+
+func runDaemonStatus(cmd *cobra.Command, args []string) error {
+	response, err := daemon.Call(daemon.CommandStatus)
+	if errors.Is(err, daemon.ErrNotRunning) {
+		if output == "json" {
+			return outputJSON(map[string]bool{"running": false})
+		}
+		fmt.Println("milesd is not running. Start it with 'miles daemon start'.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	status := response.Status
+
+	if output == "json" {
+		return outputJSON(status)
+	}
+
+	fmt.Printf("milesd %s running (pid %d) since %s\n", status.Version, status.PID, status.Started.Local().Format("Mon Jan 2 15:04"))
+	fmt.Printf("  Server: %s\n\n", status.Server)
+
+	columns := []tableColumn{
+		{header: "TASK", width: 10, priority: 5},
+		{header: "EVERY", width: 6, priority: 2},
+		{header: "LAST RUN", width: 9, priority: 4},
+		{header: "STATUS", width: 50, minWidth: 12, priority: 3},
+	}
+	var rows [][]string
+	for _, task := range status.Tasks {
+		lastRun := "-"
+		if !task.LastRun.IsZero() {
+			lastRun = task.LastRun.Local().Format("15:04:05")
+		}
+		state := "✓ " + task.Detail
+		if task.LastErr != "" {
+			state = "✗ " + task.LastErr
+		} else if task.LastRun.IsZero() {
+			state = "waiting"
+		}
+		every := task.Interval.String()
+		if task.Interval >= time.Minute {
+			every = formatDuration(task.Interval)
+		}
+		rows = append(rows, []string{task.Name, every, lastRun, state})
+	}
+	printTable(columns, rows)
+	return nil
+}
@@ -0,0 +1,312 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/snippet"
+	"github.com/spf13/cobra"
+)
+
+var desksCmd = &cobra.Command{
+	Use:   "desks",
+	Short: "List hot desks and how they're booked for a day",
+	Long: `List hot desks with an hour bar of their day, like miles availability:
+
+  ID              DESK       FLOOR  LOCATION  Mon Oct 20
+  oslo-3-desk-04  Desk 3.04  3      oslo      08 ░░░░████████████░░░░░░░░ 18
+
+Desks are booked like rooms: miles book-desk picks a free one for the day, or
+miles book -r DESK_ID books a desk for any time. --location takes a location
+ID or name; --date a date or a day like "tomorrow".
+
+Examples:
+  miles desks
+  miles desks --location Oslo --floor 3
+  miles desks -l Oslo --date tomorrow -o json`,
+	Args: cobra.NoArgs,
+	RunE: runDesks,
+}
+
+var bookDeskCmd = &cobra.Command{
+	Use:   "book-desk [DESK_ID]",
+	Short: "Book a hot desk for the day",
+	Long: `Book a hot desk, by default for the working day 08:00-16:00. Without
+DESK_ID the first desk free for the whole time is booked, narrowed down with
+--location and --floor. Quotas, approvals and the checks of miles book apply.
+
+Examples:
+  miles book-desk --location Oslo --floor 3
+  miles book-desk --location Oslo --date tomorrow --start 09:00 --end 13:00
+  miles book-desk oslo-3-desk-04 --date "next friday"`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeDeskIDs,
+	RunE:              runBookDesk,
+}
+
+var (
+	desksLocation string
+	desksFloor    string
+	desksDate     string
+	deskStart     string
+	deskEnd       string
+	deskTitle     string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{desksCmd, bookDeskCmd} {
+		cmd.Flags().StringVarP(&desksLocation, "location", "l", "", "only desks at this location (ID or name)")
+		cmd.Flags().StringVar(&desksFloor, "floor", "", `only desks on this floor, e.g. 3`)
+		cmd.Flags().StringVarP(&desksDate, "date", "d", "today", `day, e.g. 2025-10-20, "tomorrow" or "next friday"`)
+		cmd.RegisterFlagCompletionFunc("location", completeLocationIDs)
+	}
+	bookDeskCmd.Flags().StringVar(&deskStart, "start", "08:00", "start time, 24-hour HH:MM")
+	bookDeskCmd.Flags().StringVar(&deskEnd, "end", "16:00", "end time, 24-hour HH:MM")
+	bookDeskCmd.Flags().StringVarP(&deskTitle, "title", "t", "Desk", "booking title")
+	bookDeskCmd.Flags().BoolVar(&bookForce, "force", false, "book even if it overlaps your own bookings or repeats one")
+}
+
+// completeDeskIDs completes the DESK_ID argument
+func completeDeskIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	token := getAuthToken()
+	if token == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer client.Close()
+
+	desks, err := client.GetDesks(ctx, "", "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var ids []string
+	for _, desk := range desks {
+		ids = append(ids, fmt.Sprintf("%s\t%s", derefString(desk.Id), deskLabel(desk)))
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// deskLabel names a desk with its floor, e.g. "Desk 3.04 (floor 3)"
+func deskLabel(desk milesapi.Room) string {
+	if floor := derefString(desk.Floor); floor != "" {
+		return fmt.Sprintf("%s (floor %s)", derefString(desk.Name), floor)
+	}
+	return derefString(desk.Name)
+}
+
+// loadDesks returns the active desks matching --location and --floor,
+// by floor and name
+func loadDesks(ctx context.Context, client config.API) ([]milesapi.Room, error) {
+	if err := requireFeature(ctx, client, featureDesks); err != nil {
+		return nil, err
+	}
+
+	locationID := ""
+	if desksLocation != "" {
+		locations, err := client.GetLocations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		i := locationIndex(locations, desksLocation)
+		if i < 0 {
+			return nil, fmt.Errorf("no location matches %q. Run 'miles rooms' to list locations", desksLocation)
+		}
+		locationID = derefString(locations[i].Id)
+	}
+
+	all, err := client.GetDesks(ctx, locationID, desksFloor)
+	if err != nil {
+		return nil, err
+	}
+	var desks []milesapi.Room
+	for _, desk := range all {
+		if desk.IsActive == nil || *desk.IsActive {
+			desks = append(desks, desk)
+		}
+	}
+	sort.SliceStable(desks, func(i, j int) bool {
+		if fi, fj := derefString(desks[i].Floor), derefString(desks[j].Floor); fi != fj {
+			return fi < fj
+		}
+		return derefString(desks[i].Name) < derefString(desks[j].Name)
+	})
+	return desks, nil
+}
+
+// deskDay is a desk with its bookings on the day shown, the -o json form
+// of miles desks
+type deskDay struct {
+	Desk     milesapi.Room      `json:"desk"`
+	Bookings []milesapi.Booking `json:"bookings"`
+}
+
+func runDesks(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	day, err := snippet.ParseDate(desksDate, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --date: %w", err)
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	desks, err := loadDesks(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	days := []deskDay{}
+	for _, desk := range desks {
+		_, bookings, err := loadRoomDay(ctx, client, derefString(desk.Id), day)
+		if err != nil {
+			return err
+		}
+		days = append(days, deskDay{Desk: desk, Bookings: bookings})
+	}
+
+	if output == "json" {
+		return outputJSON(days)
+	}
+
+	if len(days) == 0 {
+		fmt.Println("No desks found")
+		if desksFloor != "" {
+			fmt.Println("Try without --floor, or another --location.")
+		}
+		return nil
+	}
+
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 4},
+		{header: "DESK", width: 20, minWidth: 10, priority: 5},
+		{header: "FLOOR", width: 6, priority: 3},
+		{header: "LOCATION", width: 12, priority: 1},
+		{header: dayStart.Format("Mon Jan 2"), width: 46, priority: 6},
+	}
+	var rows [][]string
+	free := 0
+	for _, d := range days {
+		if len(d.Bookings) == 0 {
+			free++
+		}
+		bar := hourBar{day: dayStart, bookings: d.Bookings}
+		rows = append(rows, []string{
+			derefString(d.Desk.Id),
+			derefString(d.Desk.Name),
+			derefString(d.Desk.Floor),
+			derefString(d.Desk.LocationId),
+			bar.String(),
+		})
+	}
+	printTable(columns, rows)
+
+	fmt.Printf("\n%s\n", hourBar{day: dayStart}.legend())
+	fmt.Printf("%d of %d desks free all day\n", free, len(days))
+	fmt.Printf("\nBook one: miles book-desk DESK_ID --date %q\n", desksDate)
+	return nil
+}
+
+func runBookDesk(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	if err := checkClockTime("start", deskStart); err != nil {
+		return err
+	}
+	if err := checkClockTime("end", deskEnd); err != nil {
+		return err
+	}
+	day, err := snippet.ParseDate(desksDate, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --date: %w", err)
+	}
+	slot := milesapi.TimeSlot{Name: "--start/--end", StartTime: deskStart, EndTime: deskEnd}
+	start, end, err := slotOn(&slot, day)
+	if err != nil {
+		return err
+	}
+	if !end.After(start) {
+		return fmt.Errorf("--end must be after --start")
+	}
+	if now := config.ServerNow(); start.Before(now) && end.After(now) {
+		// The rest of today: start now rather than in the past
+		start = now.Truncate(time.Minute)
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	deskID := ""
+	if len(args) == 1 {
+		if err := requireFeature(ctx, client, featureDesks); err != nil {
+			return err
+		}
+		deskID = args[0]
+	} else {
+		desk, err := findFreeDesk(ctx, client, start, end)
+		if err != nil {
+			return err
+		}
+		deskID = derefString(desk.Id)
+		fmt.Printf("Free desk: %s\n", deskLabel(*desk))
+	}
+
+	if _, err := checkBooking(ctx, client, deskID, start, end, deskTitle, 0); err != nil {
+		return err
+	}
+	return createBooking(ctx, client, deskID, start, end, deskTitle, "", 0)
+}
+
+// findFreeDesk returns the first desk matching --location and --floor with
+// no booking between start and end
+func findFreeDesk(ctx context.Context, client config.API, start, end time.Time) (*milesapi.Room, error) {
+	desks, err := loadDesks(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if len(desks) == 0 {
+		return nil, fmt.Errorf("no desks found. Check --location and --floor with 'miles desks'")
+	}
+	for i, desk := range desks {
+		bookings, err := client.CheckRoomAvailability(ctx, derefString(desk.Id), start, end)
+		if err != nil {
+			return nil, err
+		}
+		if len(bookings) == 0 {
+			return &desks[i], nil
+		}
+	}
+
+	where := ""
+	if desksFloor != "" {
+		where = " on floor " + desksFloor
+	}
+	return nil, fmt.Errorf("all %d desks%s are taken at some point %s-%s. Run 'miles desks' to see when they free up",
+		len(desks), where, start.Format("Mon Jan 2 15:04"), end.Format("15:04"))
+}
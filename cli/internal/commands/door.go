@@ -0,0 +1,361 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var doorCmd = &cobra.Command{
+	Use:   "door ROOM",
+	Short: "Show a room's status for a display outside its door",
+	Long: `Show whether a room is free, the meeting in it now and the next one.
+
+ROOM matches a room ID or name (case-insensitive).
+
+With --serve, run a small web server with an auto-refreshing page for a
+cheap tablet mounted outside the room. The page needs no login or
+JavaScript; it reloads itself every 30 seconds. Bookings are refreshed
+every --interval and as soon as the live booking feed reports a change to
+the room. The same status is available as JSON at /status.json.
+
+Use --private to show "Booked" instead of meeting titles.
+
+Examples:
+  miles door Fjorden                      # Print the status once
+  miles door Fjorden --serve :8090        # Serve the door page
+  miles door ROOM123 --serve :8090 --private`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRoomIDs,
+	RunE:              runDoor,
+}
+
+var (
+	doorServe    string
+	doorInterval time.Duration
+	doorPrivate  bool
+)
+
+// doorPageRefresh is how often the served page reloads itself
+const doorPageRefresh = 30 * time.Second
+
+func init() {
+	doorCmd.Flags().StringVar(&doorServe, "serve", "", "serve the door page on this address, e.g. :8090")
+	doorCmd.Flags().DurationVar(&doorInterval, "interval", time.Minute, "how often to refresh bookings when serving")
+	doorCmd.Flags().BoolVar(&doorPrivate, "private", false, "hide meeting titles")
+}
+
+// doorMeeting is a booking as shown on the door display
+type doorMeeting struct {
+	Title string    `json:"title"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// doorState is everything the door display shows
+type doorState struct {
+	Room      string       `json:"room"`
+	Location  string       `json:"location"`
+	Status    string       `json:"status"`
+	Detail    string       `json:"detail"`
+	FreeUntil *time.Time   `json:"freeUntil,omitempty"`
+	Current   *doorMeeting `json:"current,omitempty"`
+	Next      *doorMeeting `json:"next,omitempty"`
+	Updated   time.Time    `json:"updated"`
+	Stale     bool         `json:"stale"`
+}
+
+func runDoor(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	room, location, err := findDoorRoom(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	door := &doorServer{client: client, room: room, location: location}
+	if err := door.refresh(ctx); err != nil {
+		return err
+	}
+
+	if doorServe == "" {
+		state := door.state(config.ServerNow())
+		if output == "json" {
+			return outputJSON(state)
+		}
+		printDoorState(state)
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", doorServe)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", doorServe, err)
+	}
+	server := &http.Server{Handler: door}
+	go server.Serve(listener)
+
+	fmt.Printf("Serving door display for %s on http://%s (Ctrl+C to stop)\n", door.roomName(), listener.Addr())
+
+	door.follow(ctx, doorInterval)
+
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdown)
+}
+
+// findDoorRoom returns the room matching an ID or name and its location's name
+func findDoorRoom(ctx context.Context, client config.API, query string) (milesapi.Room, string, error) {
+	rooms, err := client.GetRooms(ctx, "")
+	if err != nil {
+		return milesapi.Room{}, "", err
+	}
+
+	var matched []milesapi.Room
+	for _, room := range rooms {
+		if strings.EqualFold(query, derefString(room.Id)) || strings.EqualFold(query, derefString(room.Name)) {
+			matched = append(matched, room)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return milesapi.Room{}, "", fmt.Errorf("no room matches %q. Run 'miles rooms' to list rooms", query)
+	case 1:
+	default:
+		var ids []string
+		for _, room := range matched {
+			ids = append(ids, derefString(room.Id))
+		}
+		sort.Strings(ids)
+		return milesapi.Room{}, "", fmt.Errorf("%d rooms are named %q; use a room ID instead (%s)", len(matched), query, strings.Join(ids, ", "))
+	}
+
+	room := matched[0]
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		return milesapi.Room{}, "", err
+	}
+	for _, location := range locations {
+		if derefString(location.Id) == derefString(room.LocationId) {
+			return room, derefString(location.Name), nil
+		}
+	}
+	return room, "", nil
+}
+
+// doorServer keeps a room's bookings for today current and serves them
+type doorServer struct {
+	client   config.API
+	room     milesapi.Room
+	location string
+
+	mu         sync.Mutex
+	bookings   []milesapi.Booking
+	updated    time.Time
+	refreshErr error
+}
+
+func (d *doorServer) roomName() string {
+	return derefString(d.room.Name)
+}
+
+// refresh reloads today's bookings, keeping the last ones when it fails
+func (d *doorServer) refresh(ctx context.Context) error {
+	bookings, err := loadTodaysBookings(ctx, d.client, derefString(d.room.Id))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.refreshErr = err
+	if err != nil {
+		return err
+	}
+	d.bookings, d.updated = bookings, time.Now()
+	return nil
+}
+
+// follow refreshes the bookings every interval and whenever the live feed
+// reports a change to the room, until ctx is cancelled. Without a live
+// feed it falls back to polling.
+func (d *doorServer) follow(ctx context.Context, interval time.Duration) {
+	events, err := d.client.WatchBookings(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Live updates unavailable (%v); refreshing every %s\n", err, interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if derefString(event.Booking.RoomId) != derefString(d.room.Id) {
+				continue
+			}
+		}
+		d.refresh(ctx)
+	}
+}
+
+// state works out what the display shows at now
+func (d *doorServer) state(now time.Time) doorState {
+	d.mu.Lock()
+	bookings, updated, stale := d.bookings, d.updated, d.refreshErr != nil
+	d.mu.Unlock()
+
+	status := roomStatus(bookings, now)
+	state := doorState{
+		Room:     d.roomName(),
+		Location: d.location,
+		Status:   status.label,
+		Detail:   status.detail,
+		Updated:  updated,
+		Stale:    stale,
+	}
+
+	for _, booking := range bookings {
+		switch {
+		case booking.StartTime.After(now):
+			if state.Next == nil {
+				state.Next = d.meeting(booking)
+			}
+		case booking.EndTime.After(now):
+			state.Current = d.meeting(booking)
+		}
+	}
+	if state.Current == nil && state.Next != nil {
+		state.FreeUntil = &state.Next.Start
+	}
+	return state
+}
+
+// meeting converts a booking for display, hiding the title when private
+func (d *doorServer) meeting(booking milesapi.Booking) *doorMeeting {
+	title := derefString(booking.Title)
+	if doorPrivate || title == "" {
+		title = "Booked"
+	}
+	return &doorMeeting{Title: title, Start: booking.StartTime.Local(), End: booking.EndTime.Local()}
+}
+
+// ServeHTTP serves the door page at / and its data at /status.json
+func (d *doorServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	state := d.state(config.ServerNow())
+
+	switch r.URL.Path {
+	case "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := doorPage.Execute(w, doorPageData{doorState: state, Refresh: int(doorPageRefresh.Seconds())}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case "/status.json":
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(state)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// printDoorState prints the status once in the terminal
+func printDoorState(state doorState) {
+	color := ansiGreen
+	switch state.Status {
+	case "OCCUPIED":
+		color = ansiRed
+	case "BOOKED SOON":
+		color = ansiYellow
+	}
+
+	name := state.Room
+	if state.Location != "" {
+		name += " (" + state.Location + ")"
+	}
+	fmt.Println(ansiBold + name + ansiReset)
+	fmt.Printf("%s● %s%s  %s\n", ansiBold+color, state.Status, ansiReset, state.Detail)
+	if state.Current != nil {
+		fmt.Printf("Now:  %s  %s–%s\n", state.Current.Title, state.Current.Start.Format("15:04"), state.Current.End.Format("15:04"))
+	}
+	if state.Next != nil {
+		fmt.Printf("Next: %s  %s–%s\n", state.Next.Title, state.Next.Start.Format("15:04"), state.Next.End.Format("15:04"))
+	}
+}
+
+type doorPageData struct {
+	doorState
+	Refresh int
+}
+
+var doorPage = template.Must(template.New("door").Funcs(template.FuncMap{
+	"clock": func(t time.Time) string { return t.Local().Format("15:04") },
+	"class": func(status string) string { return strings.ToLower(strings.ReplaceAll(status, " ", "-")) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<title>{{.Room}} · {{.Status}}</title>
+<style>
+body { margin: 0; font-family: sans-serif; color: #fff; background: #2e7d32; }
+body.occupied { background: #c62828; }
+body.booked-soon { background: #ef6c00; }
+main { padding: 6vmin; }
+h1 { margin: 0; font-size: 8vmin; }
+.location { font-size: 4vmin; opacity: .8; }
+.status { margin: 6vmin 0 1vmin; font-size: 14vmin; font-weight: bold; }
+.detail { font-size: 7vmin; }
+.meeting { margin-top: 5vmin; font-size: 5vmin; }
+.meeting span { display: block; font-size: 3.5vmin; opacity: .8; text-transform: uppercase; }
+footer { position: fixed; bottom: 2vmin; left: 6vmin; font-size: 2.5vmin; opacity: .7; }
+</style>
+</head>
+<body class="{{class .Status}}">
+<main>
+<h1>{{.Room}}</h1>
+{{if .Location}}<div class="location">{{.Location}}</div>{{end}}
+<div class="status">{{.Status}}</div>
+<div class="detail">{{.Detail}}</div>
+{{with .Current}}<div class="meeting"><span>Now</span>{{.Title}} · {{clock .Start}}–{{clock .End}}</div>{{end}}
+{{with .Next}}<div class="meeting"><span>Next</span>{{.Title}} · {{clock .Start}}–{{clock .End}}</div>{{end}}
+</main>
+<footer>{{if .Stale}}⚠ Could not refresh, showing data from {{clock .Updated}}{{else}}Updated {{clock .Updated}}{{end}}</footer>
+</body>
+</html>
+`))
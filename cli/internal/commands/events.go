@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Stream booking events",
+	Long: `Print booking events from the live-update feed.
+
+Without --follow, the current bookings are printed once as booking.snapshot
+events. With --follow, live changes are streamed until interrupted.
+
+Event types:
+  booking.created     A booking was made
+  booking.updated     A booking's time, title or details changed
+  booking.cancelled   A booking was cancelled or removed
+  room.blocked        A room was blocked (gRPC transport only)
+
+With -o json, one JSON object is written per line (NDJSON), ready for jq,
+alerting tools or a logging pipeline.
+
+Examples:
+  miles events --follow                             # Human-readable stream
+  miles events --follow -o json | jq .booking.title # Pipe into jq
+  miles events -f -o json --type booking.cancelled  # Only cancellations`,
+	RunE: runEvents,
+}
+
+var (
+	eventsFollow   bool
+	eventsTypes    []string
+	eventsInterval time.Duration
+)
+
+func init() {
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "stream live events until interrupted")
+	eventsCmd.Flags().StringSliceVar(&eventsTypes, "type", nil, "only emit these event types (comma-separated)")
+	eventsCmd.Flags().DurationVar(&eventsInterval, "interval", config.DefaultWatchInterval, "poll interval when using the REST transport")
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	writer, err := newEventWriter(ctx, output, client)
+	if err != nil {
+		return err
+	}
+	defer writer.Flush()
+
+	if !eventsFollow {
+		bookings, err := client.GetBookings(ctx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, booking := range bookings {
+			event := config.BookingEvent{Type: config.BookingSnapshot, Time: now, Booking: booking}
+			if wantEvent(event) {
+				if err := writer.Write(event); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	// Polling interval only applies to the REST transport
+	if rest, ok := client.(*config.Client); ok {
+		rest.WatchInterval = eventsInterval
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	events, err := client.WatchBookings(ctx)
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		if !wantEvent(event) {
+			continue
+		}
+		if err := writer.Write(event); err != nil {
+			return err
+		}
+		// Flush per event so downstream consumers see it immediately
+		writer.Flush()
+	}
+
+	if ctx.Err() == nil {
+		return fmt.Errorf("event stream closed by server")
+	}
+	return nil
+}
+
+// wantEvent applies the --type filter
+func wantEvent(event config.BookingEvent) bool {
+	if len(eventsTypes) == 0 {
+		return true
+	}
+	for _, t := range eventsTypes {
+		if strings.EqualFold(strings.TrimSpace(t), string(event.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventWriter writes events in the selected output format
+type eventWriter interface {
+	Write(event config.BookingEvent) error
+	Flush()
+}
+
+func newEventWriter(ctx context.Context, format string, client config.API) (eventWriter, error) {
+	switch format {
+	case "json":
+		return &jsonEventWriter{encoder: json.NewEncoder(os.Stdout)}, nil
+	case "csv":
+		w, err := newCSVWriter(os.Stdout, eventCSVColumns)
+		if err != nil {
+			return nil, err
+		}
+		return &csvEventWriter{w: w}, nil
+	case "template":
+		tmpl, err := parseOutputTemplate()
+		if err != nil {
+			return nil, err
+		}
+		return &templateEventWriter{tmpl: tmpl, lookup: newTemplateLookup(ctx, client)}, nil
+	case "table", "":
+		return &textEventWriter{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format for events: %s", format)
+	}
+}
+
+// jsonEventWriter emits newline-delimited JSON
+type jsonEventWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonEventWriter) Write(event config.BookingEvent) error {
+	return w.encoder.Encode(event)
+}
+
+func (w *jsonEventWriter) Flush() {}
+
+// eventCSVColumns are the columns of -o csv event output
+var eventCSVColumns = []csvColumn{
+	{"time", "Time"},
+	{"type", "Type"},
+	{"booking_id", "Booking ID"},
+	{"room_id", "Room ID"},
+	{"title", "Title"},
+	{"start_time", "Start Time"},
+	{"end_time", "End Time"},
+	{"status", "Status"},
+}
+
+type csvEventWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvEventWriter) Write(event config.BookingEvent) error {
+	f := eventFields(event)
+	return w.w.Write([]string{
+		event.Time.Format(time.RFC3339), string(event.Type),
+		f.id, f.roomID, f.title, f.start, f.end, f.status,
+	})
+}
+
+func (w *csvEventWriter) Flush() {
+	w.w.Flush()
+}
+
+// templateEventWriter renders each event with the --template template
+type templateEventWriter struct {
+	tmpl   *template.Template
+	lookup *templateLookup
+}
+
+func (w *templateEventWriter) Write(event config.BookingEvent) error {
+	return writeTemplate(w.tmpl, templateEvent{
+		Type:    event.Type,
+		Time:    event.Time,
+		Booking: templateBooking{Booking: event.Booking, lookup: w.lookup},
+	})
+}
+
+func (w *templateEventWriter) Flush() {}
+
+// textEventWriter prints one human-readable line per event
+type textEventWriter struct{}
+
+func (w *textEventWriter) Write(event config.BookingEvent) error {
+	f := eventFields(event)
+	_, err := fmt.Printf("%s  %-18s %-25s %-30s %s\n",
+		event.Time.Local().Format("15:04:05"),
+		event.Type,
+		f.id,
+		truncate(f.title, 30),
+		f.window,
+	)
+	return err
+}
+
+func (w *textEventWriter) Flush() {}
+
+type eventFieldValues struct {
+	id, roomID, title, status string
+	start, end, window        string
+}
+
+func eventFields(event config.BookingEvent) eventFieldValues {
+	var f eventFieldValues
+	booking := event.Booking
+	if booking.Id != nil {
+		f.id = *booking.Id
+	}
+	if booking.RoomId != nil {
+		f.roomID = *booking.RoomId
+	}
+	if booking.Title != nil {
+		f.title = *booking.Title
+	}
+	if booking.Status != nil {
+		f.status = string(*booking.Status)
+	}
+	if booking.StartTime != nil {
+		f.start = booking.StartTime.Format(time.RFC3339)
+		f.window = booking.StartTime.Local().Format("2006-01-02 15:04")
+	}
+	if booking.EndTime != nil {
+		f.end = booking.EndTime.Format(time.RFC3339)
+		f.window += " - " + booking.EndTime.Local().Format("15:04")
+	}
+	return f
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// explainer prints, under --explain, each check a booking goes through and
+// what decided it, for "why can't I book this?" questions
+type explainer struct {
+	enabled bool
+	started bool
+}
+
+// pass prints a check the booking passed, e.g. "✓ Length: 1h, 15m to 4h allowed"
+func (e *explainer) pass(check, detail string) {
+	e.print("✓", check, detail)
+}
+
+// fail prints a check that blocks the booking
+func (e *explainer) fail(check, detail string) {
+	e.print("✗", check, detail)
+}
+
+// warn prints a check that passed with a caveat, or couldn't be made and
+// is left to the server
+func (e *explainer) warn(check, detail string) {
+	e.print("⚠", check, detail)
+}
+
+func (e *explainer) print(mark, check, detail string) {
+	if !e.enabled {
+		return
+	}
+	if !e.started {
+		fmt.Println("Why:")
+		e.started = true
+	}
+	fmt.Printf("  %s %-14s %s\n", mark, check+":", detail)
+}
+
+// done ends the explanation with a blank line, if there was one, so what
+// follows stands apart from it
+func (e *explainer) done() {
+	if e.started {
+		fmt.Println()
+		e.started = false
+	}
+}
+
+// namedBusy is a busy period with whose it is, for explaining find-common
+type namedBusy struct {
+	calsync.Busy
+	Who string
+}
+
+// roomVerdict says whether find-common considered a room, and why not
+type roomVerdict struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// skippedStarts are consecutive start times find-common passed over for
+// the same reason
+type skippedStarts struct {
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Reason string    `json:"reason"`
+}
+
+// commonExplanation is what --explain adds to find-common: which rooms
+// were considered, and why the start times before the last proposal were
+// passed over
+type commonExplanation struct {
+	Rooms        []roomVerdict   `json:"rooms"`
+	Skipped      []skippedStarts `json:"skipped"`
+	Checked      int             `json:"checked"`
+	OutsideHours int             `json:"outsideHours"`
+	Busy         int             `json:"busy"`
+	NoRoom       int             `json:"noRoom"`
+}
+
+// maxExplainedRuns caps the skipped times printed; JSON lists them all
+const maxExplainedRuns = 20
+
+// commonRoomExcluded returns why find-common leaves a room out, or "" when
+// it is considered
+func commonRoomExcluded(room milesapi.Room, capacity int, d time.Duration) string {
+	switch {
+	case room.IsActive != nil && !*room.IsActive:
+		return "inactive"
+	case room.Capacity == nil:
+		return "seats unknown"
+	case *room.Capacity < capacity:
+		return fmt.Sprintf("seats %d, need %d", *room.Capacity, capacity)
+	case !room.AllowsDuration(d):
+		return "bookable for " + milesapi.DescribeDurationLimits(room.DurationLimits())
+	}
+	return ""
+}
+
+// explainCommonSlots walks the same start times as findCommonSlots, up to
+// the last slot proposed (or the end of the window when fewer were found),
+// and says why each one that wasn't proposed was passed over
+func explainCommonSlots(from, to time.Time, d time.Duration, dayFrom, dayTo int, busy []namedBusy, rooms []milesapi.Room, roomBookings map[string][]milesapi.Booking, boundary config.Boundary, slots []commonSlot, limit int) commonExplanation {
+	var explanation commonExplanation
+	last := to.Add(-d)
+	if len(slots) >= limit {
+		last = slots[len(slots)-1].Start
+	}
+	proposed := make(map[int64]bool)
+	for _, slot := range slots {
+		proposed[slot.Start.Unix()] = true
+	}
+
+	start := from.Truncate(15 * time.Minute)
+	if start.Before(from) {
+		start = start.Add(15 * time.Minute)
+	}
+	for ; !start.After(last); start = start.Add(15 * time.Minute) {
+		explanation.Checked++
+		end := start.Add(d)
+		minutes := start.Hour()*60 + start.Minute()
+		if start.Weekday() == time.Saturday || start.Weekday() == time.Sunday ||
+			minutes < dayFrom || minutes+int(d/time.Minute) > dayTo || end.Day() != start.Day() {
+			explanation.OutsideHours++
+			continue
+		}
+		if proposed[start.Unix()] {
+			continue
+		}
+
+		reason := busyReason(busy, start, end)
+		if reason != "" {
+			explanation.Busy++
+		} else {
+			reason = noRoomReason(rooms, roomBookings, boundary, start, end)
+			explanation.NoRoom++
+		}
+
+		// Runs of quarter hours passed over for the same reason are one line
+		if n := len(explanation.Skipped); n > 0 {
+			run := &explanation.Skipped[n-1]
+			if run.Reason == reason && run.To.Add(15*time.Minute).Equal(start) {
+				run.To = start
+				continue
+			}
+		}
+		explanation.Skipped = append(explanation.Skipped, skippedStarts{From: start, To: start, Reason: reason})
+	}
+	return explanation
+}
+
+// busyReason says who is busy during [start, end), e.g.
+// "kari@miles.no busy 08:30-10:00", or returns ""
+func busyReason(busy []namedBusy, start, end time.Time) string {
+	var who []string
+	for _, b := range busy {
+		if b.Start.Before(end) && b.End.After(start) {
+			who = append(who, fmt.Sprintf("%s busy %s-%s", b.Who, b.Start.Local().Format("15:04"), b.End.Local().Format("15:04")))
+		}
+	}
+	return joinCapped(who, 2)
+}
+
+// noRoomReason says what holds each room during [start, end), e.g.
+// "no room free: Teamrommet 09:00-10:00, Fjord 09:30-11:00"
+func noRoomReason(rooms []milesapi.Room, roomBookings map[string][]milesapi.Booking, boundary config.Boundary, start, end time.Time) string {
+	var held []string
+	for _, room := range rooms {
+		for _, booking := range boundary.Conflicting(roomBookings[derefString(room.Id)], start, end) {
+			held = append(held, fmt.Sprintf("%s %s-%s", derefString(room.Name),
+				booking.StartTime.Local().Format("15:04"), booking.EndTime.Local().Format("15:04")))
+			break
+		}
+	}
+	return "no room free: " + joinCapped(held, 3)
+}
+
+// joinCapped joins up to n items, e.g. "a, b and 3 more"
+func joinCapped(items []string, n int) string {
+	if len(items) <= n {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:n], ", "), len(items)-n)
+}
+
+// printCommonExplanation prints what --explain found for find-common
+func printCommonExplanation(explanation commonExplanation) {
+	fmt.Println("\nWhy:")
+	var considered []string
+	for _, room := range explanation.Rooms {
+		if room.Reason == "" {
+			considered = append(considered, room.Name)
+		}
+	}
+	if len(considered) > 0 {
+		fmt.Printf("  ✓ Rooms considered: %s\n", strings.Join(considered, ", "))
+	}
+	for _, room := range explanation.Rooms {
+		if room.Reason != "" {
+			fmt.Printf("  ✗ %s: %s\n", room.Name, room.Reason)
+		}
+	}
+
+	if len(explanation.Skipped) > 0 {
+		fmt.Println("  Start times passed over:")
+	}
+	for i, run := range explanation.Skipped {
+		if i == maxExplainedRuns {
+			fmt.Printf("  … %d more; -o json lists them all\n", len(explanation.Skipped)-i)
+			break
+		}
+		when := run.From.Format("Mon Jan 2 15:04")
+		if !run.To.Equal(run.From) {
+			when += "-" + run.To.Format("15:04")
+		}
+		fmt.Printf("  ✗ %-22s  %s\n", when, run.Reason)
+	}
+	if explanation.Checked == 0 {
+		return
+	}
+	fmt.Printf("\nChecked %d start times: %d outside --hours or on weekends, %d with someone busy, %d with no room free.\n",
+		explanation.Checked, explanation.OutsideHours, explanation.Busy, explanation.NoRoom)
+}
@@ -0,0 +1,328 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export your bookings for other tools",
+}
+
+var exportTimesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Export a month of meetings as time entries",
+	Long: `Turn the confirmed bookings you held in a month into time entries for a
+time-tracking system. Cancelled bookings and bookings still to come are
+left out.
+
+Each entry's project comes from the first rule under timesheet_projects
+in the config file that matches the booking's title. A rule matches a
+#tag in the title, or the whole title as a template where * stands for
+any text. Case doesn't matter. Tags in titles are exported too.
+
+  timesheet_projects:
+    - match: "#acme"
+      project: Acme rebuild
+      client: Acme
+    - match: "Sprint * review"
+      project: Internal
+  timesheet_default_project: Meetings
+
+Formats:
+  csv    date, start, end, hours, project, client, description, tags and
+         room; follows --csv-delimiter, --csv-bom and --csv-headers
+  toggl  the columns Toggl Track's CSV import expects
+
+Examples:
+  miles export timesheet --month 2025-11
+  miles export timesheet --month 2025-11 --format toggl --out november.csv`,
+	Args: cobra.NoArgs,
+	RunE: runExportTimesheet,
+}
+
+var (
+	timesheetMonth  string
+	timesheetFormat string
+	timesheetOut    string
+)
+
+// timesheetCSVColumns are the columns of --format csv
+var timesheetCSVColumns = []csvColumn{
+	{key: "date", header: "Date"},
+	{key: "start", header: "Start"},
+	{key: "end", header: "End"},
+	{key: "hours", header: "Hours"},
+	{key: "project", header: "Project"},
+	{key: "client", header: "Client"},
+	{key: "description", header: "Description"},
+	{key: "tags", header: "Tags"},
+	{key: "room", header: "Room"},
+}
+
+// togglHeaders are the columns Toggl Track imports time entries from
+var togglHeaders = []string{"Email", "Project", "Client", "Description", "Start date", "Start time", "Duration", "Tags"}
+
+// titleTagPattern matches #tags in booking titles
+var titleTagPattern = regexp.MustCompile(`#[\p{L}\p{N}_-]+`)
+
+// timesheetProject maps bookings whose title matches to a project
+type timesheetProject struct {
+	Match   string `mapstructure:"match" yaml:"match"`
+	Project string `mapstructure:"project" yaml:"project"`
+	Client  string `mapstructure:"client" yaml:"client"`
+}
+
+// timeEntry is one booking as a time entry
+type timeEntry struct {
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Project     string    `json:"project"`
+	Client      string    `json:"client,omitempty"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags"`
+	Room        string    `json:"room"`
+	BookingID   string    `json:"bookingId"`
+}
+
+func init() {
+	exportTimesheetCmd.Flags().StringVar(&timesheetMonth, "month", "", "month to export, e.g. 2025-11 (default: this month)")
+	exportTimesheetCmd.Flags().StringVar(&timesheetFormat, "format", "csv", "csv or toggl")
+	exportTimesheetCmd.Flags().StringVar(&timesheetOut, "out", "", "write to this file instead of stdout")
+	exportTimesheetCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"csv", "toggl"}, cobra.ShellCompDirectiveNoFileComp))
+
+	exportCmd.AddCommand(exportTimesheetCmd)
+}
+
+func runExportTimesheet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if timesheetFormat != "csv" && timesheetFormat != "toggl" {
+		return fmt.Errorf("--format must be csv or toggl, got %q", timesheetFormat)
+	}
+
+	now := config.ServerNow()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	if timesheetMonth != "" {
+		month, err := time.ParseInLocation("2006-01", timesheetMonth, time.Local)
+		if err != nil {
+			return fmt.Errorf("--month must look like 2025-11")
+		}
+		from = month
+	}
+	to := from.AddDate(0, 1, 0)
+
+	var projects []timesheetProject
+	if err := viper.UnmarshalKey("timesheet_projects", &projects); err != nil {
+		return fmt.Errorf("invalid timesheet_projects in config: %w", err)
+	}
+	defaultProject := viper.GetString("timesheet_default_project")
+
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	bookings, err := client.GetBookings(ctx)
+	if err != nil {
+		return err
+	}
+	rooms, err := client.GetRooms(ctx, "")
+	if err != nil {
+		return err
+	}
+	roomNames := make(map[string]string)
+	for _, room := range rooms {
+		roomNames[derefString(room.Id)] = derefString(room.Name)
+	}
+
+	var entries []timeEntry
+	for _, booking := range bookings {
+		if booking.Status == nil || *booking.Status != milesapi.BookingStatusCONFIRMED ||
+			booking.StartTime == nil || booking.EndTime == nil {
+			continue
+		}
+		start, end := booking.StartTime.Local(), booking.EndTime.Local()
+		// Only meetings that have happened, started within the month
+		if start.Before(from) || !start.Before(to) || end.After(now) {
+			continue
+		}
+
+		title := derefString(booking.Title)
+		entry := timeEntry{
+			Start:       start,
+			End:         end,
+			Project:     defaultProject,
+			Description: title,
+			Tags:        titleTags(title),
+			Room:        roomNames[derefString(booking.RoomId)],
+			BookingID:   derefString(booking.Id),
+		}
+		if project, ok := matchTimesheetProject(projects, title); ok {
+			entry.Project, entry.Client = project.Project, project.Client
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
+
+	out := io.Writer(os.Stdout)
+	if timesheetOut != "" {
+		file, err := os.Create(timesheetOut)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch {
+	case output == "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(entries)
+	case timesheetFormat == "toggl":
+		// Toggl files each entry under the user with this email
+		var user *milesapi.User
+		if user, err = client.GetCurrentUser(ctx); err != nil {
+			return err
+		}
+		email := ""
+		if user.Email != nil {
+			email = string(*user.Email)
+		}
+		err = writeTogglEntries(out, email, entries)
+	default:
+		err = writeTimesheetCSV(out, entries)
+	}
+	if err != nil {
+		return err
+	}
+
+	if timesheetOut != "" {
+		printTimesheetSummary(entries, from)
+	}
+	return nil
+}
+
+// matchTimesheetProject returns the first project whose rule matches title
+func matchTimesheetProject(projects []timesheetProject, title string) (timesheetProject, bool) {
+	tags := titleTags(title)
+	for _, project := range projects {
+		match := strings.TrimSpace(project.Match)
+		if match == "" {
+			continue
+		}
+		if strings.HasPrefix(match, "#") {
+			for _, tag := range tags {
+				if strings.EqualFold(tag, strings.TrimPrefix(match, "#")) {
+					return project, true
+				}
+			}
+			continue
+		}
+		if titleTemplate(match).MatchString(strings.TrimSpace(title)) {
+			return project, true
+		}
+	}
+	return timesheetProject{}, false
+}
+
+// titleTemplate compiles a title template, where * stands for any text,
+// into a case-insensitive pattern for the whole title
+func titleTemplate(template string) *regexp.Regexp {
+	parts := strings.Split(template, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile(`(?i)^` + strings.Join(parts, ".*") + `$`)
+}
+
+// titleTags returns the #tags in a title, without the #
+func titleTags(title string) []string {
+	tags := []string{}
+	for _, tag := range titleTagPattern.FindAllString(title, -1) {
+		tags = append(tags, strings.TrimPrefix(tag, "#"))
+	}
+	return tags
+}
+
+// writeTimesheetCSV writes entries as --format csv
+func writeTimesheetCSV(out io.Writer, entries []timeEntry) error {
+	w, err := newCSVWriter(out, timesheetCSVColumns)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		w.Write([]string{
+			entry.Start.Format("2006-01-02"),
+			entry.Start.Format("15:04"),
+			entry.End.Format("15:04"),
+			fmt.Sprintf("%.2f", entry.End.Sub(entry.Start).Hours()),
+			entry.Project,
+			entry.Client,
+			entry.Description,
+			strings.Join(entry.Tags, ", "),
+			entry.Room,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// writeTogglEntries writes entries in Toggl Track's CSV import format
+func writeTogglEntries(out io.Writer, email string, entries []timeEntry) error {
+	w := csv.NewWriter(out)
+	w.Write(togglHeaders)
+	for _, entry := range entries {
+		d := entry.End.Sub(entry.Start).Round(time.Second)
+		w.Write([]string{
+			email,
+			entry.Project,
+			entry.Client,
+			entry.Description,
+			entry.Start.Format("2006-01-02"),
+			entry.Start.Format("15:04:05"),
+			fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60),
+			strings.Join(entry.Tags, ", "),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// printTimesheetSummary reports what was written to --out
+func printTimesheetSummary(entries []timeEntry, month time.Time) {
+	var total time.Duration
+	unassigned := 0
+	for _, entry := range entries {
+		total += entry.End.Sub(entry.Start)
+		if entry.Project == "" {
+			unassigned++
+		}
+	}
+	fmt.Printf("✓ Wrote %d time entries for %s (%s) to %s\n",
+		len(entries), month.Format("January 2006"), formatDuration(total), timesheetOut)
+	if unassigned > 0 {
+		fmt.Printf("%d entries have no project; add rules under timesheet_projects in the config\n", unassigned)
+	}
+}
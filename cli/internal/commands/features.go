@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+// Optional features a deployment can turn off
+const (
+	featureApprovals = "approvals"
+	featureDesks     = "desks"
+)
+
+// featureNouns name features in errors, e.g. "hot desks are turned off"
+var featureNouns = map[string]string{
+	featureApprovals: "approvals",
+	featureDesks:     "hot desks",
+}
+
+var featuresCmd = &cobra.Command{
+	Use:   "features",
+	Short: "Show which optional features the server has on",
+	Long: `Show which optional features this server has on. Deployments turn off
+what they don't use with DISABLED_FEATURES on the server, and commands for
+features that are off stop before doing anything:
+
+  approvals  approval rules (miles admin rules)
+  desks      hot desks (miles desks, miles book-desk)
+
+Servers from before feature flags have everything on. No login needed.
+
+Examples:
+  miles features
+  miles features -o json`,
+	Args: cobra.NoArgs,
+	RunE: runFeatures,
+}
+
+func runFeatures(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := newAPIClient(ctx, getAuthToken())
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	features, err := client.GetFeatures(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		if features == nil {
+			features = []milesapi.Feature{}
+		}
+		return outputJSON(features)
+	}
+
+	if len(features) == 0 {
+		fmt.Println("This server doesn't report features; everything is on.")
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "FEATURE", width: 12, priority: 3},
+		{header: "STATUS", width: 6, priority: 2},
+		{header: "DESCRIPTION", width: 60, minWidth: 10, priority: 1},
+	}
+	var rows [][]string
+	for _, feature := range features {
+		status := "off"
+		if feature.Enabled {
+			status = "on"
+		}
+		rows = append(rows, []string{feature.Name, status, derefString(feature.Description)})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+// serverFeatures caches what the server reports for the rest of the run
+var serverFeatures map[string]bool
+
+// requireFeature fails when the server has turned a feature off. Features
+// the server doesn't list, and failures to ask, count as on; the server
+// still has the last word on each request.
+func requireFeature(ctx context.Context, client config.API, name string) error {
+	if serverFeatures == nil {
+		serverFeatures = map[string]bool{}
+		features, _ := client.GetFeatures(ctx)
+		for _, feature := range features {
+			serverFeatures[feature.Name] = feature.Enabled
+		}
+	}
+	if enabled, ok := serverFeatures[name]; ok && !enabled {
+		return fmt.Errorf("%s are turned off on this server. Run 'miles features' to see what's on", featureNouns[name])
+	}
+	return nil
+}
@@ -0,0 +1,427 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var findCmd = &cobra.Command{
+	Use:   "find",
+	Short: "Find the best room for a meeting",
+	Long: `Find meeting rooms that seat --attendees, have every --amenity and are
+free for --duration some time --within, on weekdays within --hours. Each
+room is shown with the first time it's free, best fit first.
+
+Fit is about the room, not the time: a room with fewer seats to spare
+beats a bigger one, and a room near you beats one further away. Near
+means the floor and wing of the room given with --near, or else the
+office you seem to be at (see 'offices_file'); rooms at other locations
+come last. Between rooms that fit equally well, the one free first wins.
+
+--within is today, tomorrow, this week, next week, a number of days (3d),
+a date (2025-10-20) or a date range (2025-10-20..2025-10-24), as for
+'miles find-common'.
+
+--book books the best room at its first free time, with the usual checks
+of 'miles book'.
+
+Examples:
+  miles find --attendees 6
+  miles find -a 4 --amenity projector --duration 30m --within tomorrow
+  miles find -a 8 --amenity screen --amenity whiteboard --location oslo
+  miles find -a 3 --near stavanger-teamrommet
+  miles find -a 5 --within today --book -t "Sprint planning"
+  miles find -a 4 -o json`,
+	Args: cobra.NoArgs,
+	RunE: runFind,
+}
+
+var (
+	findAttendees  int
+	findAmenities  []string
+	findDuration   time.Duration
+	findWithin     string
+	findHours      string
+	findLocationID string
+	findNear       string
+	findLimit      int
+	findBook       bool
+	findTitle      string
+)
+
+func init() {
+	findCmd.Flags().IntVarP(&findAttendees, "attendees", "a", 1, "seats needed, including you")
+	findCmd.Flags().StringArrayVar(&findAmenities, "amenity", nil, "amenity the room must have, e.g. projector (repeatable)")
+	findCmd.Flags().DurationVarP(&findDuration, "duration", "d", time.Hour, "meeting length")
+	findCmd.Flags().StringVarP(&findWithin, "within", "w", "today", "when to look")
+	findCmd.Flags().StringVar(&findHours, "hours", "08:00-17:00", "working hours the meeting must fit in")
+	findCmd.Flags().StringVarP(&findLocationID, "location", "l", "", "only rooms at this location ID")
+	findCmd.Flags().StringVar(&findNear, "near", "", "rank rooms by how near they are to this room ID")
+	findCmd.Flags().IntVarP(&findLimit, "limit", "n", 5, "how many rooms to show")
+	findCmd.Flags().BoolVar(&findBook, "book", false, "book the best room at its first free time")
+	findCmd.Flags().StringVarP(&findTitle, "title", "t", "Meeting", "title for --book")
+	findCmd.RegisterFlagCompletionFunc("location", completeLocationIDs)
+	findCmd.RegisterFlagCompletionFunc("near", completeRoomIDs)
+}
+
+// Fit penalties, lower is better. A spare seat costs little, a floor away
+// a bit more, and another location more than most spare seats.
+const (
+	spareSeatPenalty     = 2
+	distancePenalty      = 3
+	otherLocationPenalty = 30
+)
+
+var findCSVColumns = []csvColumn{
+	{"rank", "Rank"},
+	{"room_id", "Room ID"},
+	{"room", "Room"},
+	{"location", "Location"},
+	{"seats", "Seats"},
+	{"spare_seats", "Spare Seats"},
+	{"start", "Start"},
+	{"end", "End"},
+	{"nearness", "Nearness"},
+}
+
+// findCandidate is a room that suits the meeting and the first time it is
+// free
+type findCandidate struct {
+	Room       milesapi.Room `json:"room"`
+	Location   string        `json:"location"`
+	Start      time.Time     `json:"start"`
+	End        time.Time     `json:"end"`
+	SpareSeats int           `json:"spareSeats"`
+
+	// Where the room is relative to the reference, e.g. "same floor", or
+	// "" when it can't tell
+	Nearness string `json:"nearness,omitempty"`
+
+	// Penalty ranks candidates: spare seats and distance, lower is better
+	Penalty int `json:"penalty"`
+}
+
+func runFind(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if findAttendees < 1 {
+		return fmt.Errorf("--attendees must be at least 1")
+	}
+	if findDuration < 15*time.Minute {
+		return fmt.Errorf("--duration must be at least 15m")
+	}
+	if findLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	if findBook && strings.TrimSpace(findTitle) == "" {
+		return fmt.Errorf("--book needs a --title")
+	}
+	dayFrom, dayTo, err := parseWorkingHours(findHours)
+	if err != nil {
+		return err
+	}
+	// A bad --within fails before going to the server
+	if _, _, err := parseWithin(findWithin, time.Now()); err != nil {
+		return err
+	}
+
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The window follows the server's clock, known once a response is in
+	now := config.ServerNow()
+	from, to, _ := parseWithin(findWithin, now)
+	if from.Before(now) {
+		from = now
+	}
+	if !to.After(from) {
+		return fmt.Errorf("--within %q has already passed", findWithin)
+	}
+
+	locationNames := map[string]string{}
+	for _, location := range locations {
+		locationNames[derefString(location.Id)] = derefString(location.Name)
+	}
+
+	// Rooms are near the --near room, or else the office you're at
+	var near *milesapi.Room
+	home := findLocationID
+	if findNear != "" {
+		if near, err = findRoom(ctx, client, findNear); err != nil {
+			return err
+		}
+		home = derefString(near.LocationId)
+	} else if home == "" {
+		if i, _ := detectOffice(locations); i >= 0 {
+			home = derefString(locations[i].Id)
+		}
+	}
+
+	rooms, err := client.GetRooms(ctx, findLocationID)
+	if err != nil {
+		return err
+	}
+	var suitable []milesapi.Room
+	excluded := map[string]int{}
+	for _, room := range milesapi.MeetingRooms(rooms) {
+		if reason := findRoomExcluded(room, findAttendees, findDuration, findAmenities); reason != "" {
+			excluded[reason]++
+			continue
+		}
+		suitable = append(suitable, room)
+	}
+	if len(suitable) == 0 {
+		if len(excluded) == 0 {
+			return fmt.Errorf("no meeting rooms found. Try another --location")
+		}
+		return fmt.Errorf("no room suits the meeting (%s). Try fewer --amenity, fewer --attendees or another --location", describeExclusions(excluded))
+	}
+
+	candidates, err := findCandidates(ctx, client, suitable, near, home, from, to, dayFrom, dayTo)
+	if err != nil {
+		return err
+	}
+	for i := range candidates {
+		candidates[i].Location = locationNames[derefString(candidates[i].Room.LocationId)]
+	}
+	if len(candidates) > findLimit {
+		candidates = candidates[:findLimit]
+	}
+
+	if findBook {
+		if len(candidates) == 0 {
+			return fmt.Errorf("no room seating %d is free for %s %s", findAttendees, formatDuration(findDuration), findWithin)
+		}
+		return bookFindCandidate(ctx, client, candidates[0])
+	}
+
+	switch output {
+	case "json":
+		return outputJSON(candidates)
+	case "csv":
+		return printFindCSV(candidates)
+	}
+
+	if len(candidates) == 0 {
+		fmt.Printf("No room seating %d is free for %s %s.\n", findAttendees, formatDuration(findDuration), findWithin)
+		fmt.Println("Try a shorter --duration, a later --within, or wider --hours.")
+		return nil
+	}
+	printFindTable(candidates)
+	best := candidates[0]
+	fmt.Printf("\nBook the best with: miles book -r %s -s %q -e %s -t TITLE\n",
+		derefString(best.Room.Id), best.Start.Format("2006-01-02 15:04"), best.End.Format("15:04"))
+	fmt.Println("Or run this again with --book -t TITLE.")
+	return nil
+}
+
+// findRoomExcluded returns why a room doesn't suit the meeting, e.g. "too
+// small" or "no projector", or "" when it does
+func findRoomExcluded(room milesapi.Room, attendees int, d time.Duration, amenities []string) string {
+	switch {
+	case room.IsActive != nil && !*room.IsActive:
+		return "inactive"
+	case roomLocked(room):
+		return "restricted"
+	case room.Capacity == nil || *room.Capacity < attendees:
+		return "too small"
+	case !room.AllowsDuration(d):
+		return "not bookable for " + formatDuration(d)
+	}
+	for _, amenity := range amenities {
+		if !roomHasAmenity(room, amenity) {
+			return "no " + amenity
+		}
+	}
+	return ""
+}
+
+// roomHasAmenity reports whether one of the room's amenities contains
+// want, ignoring case, so "screen" matches "Screen (65\")"
+func roomHasAmenity(room milesapi.Room, want string) bool {
+	want = strings.ToLower(strings.TrimSpace(want))
+	if want == "" {
+		return true
+	}
+	if room.Amenities == nil {
+		return false
+	}
+	for _, amenity := range *room.Amenities {
+		if strings.Contains(strings.ToLower(amenity), want) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeExclusions counts the rooms left out by reason, e.g. "5 too
+// small, 2 no projector"
+func describeExclusions(excluded map[string]int) string {
+	reasons := make([]string, 0, len(excluded))
+	for reason, count := range excluded {
+		reasons = append(reasons, fmt.Sprintf("%d %s", count, reason))
+	}
+	sort.Strings(reasons)
+	return strings.Join(reasons, ", ")
+}
+
+// findCandidates looks up when each room is first free for the meeting and
+// ranks those that are by fit, then by time. Rooms near the --near room or
+// at the home location count as near; with neither, distance is ignored.
+func findCandidates(ctx context.Context, client config.API, rooms []milesapi.Room, near *milesapi.Room, home string, from, to time.Time, dayFrom, dayTo int) ([]findCandidate, error) {
+	boundary := bookingBoundary()
+	candidates := []findCandidate{}
+	for _, room := range rooms {
+		id := derefString(room.Id)
+		bookings, err := client.GetRoomAvailability(ctx, id, from, to)
+		if err != nil {
+			return nil, err
+		}
+		slots := findCommonSlots(from, to, findDuration, dayFrom, dayTo, nil,
+			[]milesapi.Room{room}, map[string][]milesapi.Booking{id: bookings}, boundary, 1)
+		if len(slots) == 0 {
+			continue
+		}
+
+		candidate := findCandidate{
+			Room:       room,
+			Start:      slots[0].Start,
+			End:        slots[0].End,
+			SpareSeats: *room.Capacity - findAttendees,
+		}
+		candidate.Penalty = candidate.SpareSeats * spareSeatPenalty
+		switch {
+		case near != nil:
+			if distance, ok := milesapi.RoomDistance(*near, room); ok {
+				candidate.Penalty += distance * distancePenalty
+				candidate.Nearness = milesapi.DescribeNearness(*near, room)
+				if id == derefString(near.Id) {
+					candidate.Nearness = "the room you asked for"
+				}
+			} else {
+				candidate.Penalty += otherLocationPenalty
+			}
+		case home != "" && derefString(room.LocationId) != home:
+			candidate.Penalty += otherLocationPenalty
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Penalty != b.Penalty {
+			return a.Penalty < b.Penalty
+		}
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		return derefString(a.Room.Name) < derefString(b.Room.Name)
+	})
+	return candidates, nil
+}
+
+// describeFit explains a candidate's place in the ranking
+func describeFit(c findCandidate) string {
+	parts := []string{}
+	switch c.SpareSeats {
+	case 0:
+		parts = append(parts, "exact fit")
+	case 1:
+		parts = append(parts, "1 seat spare")
+	default:
+		parts = append(parts, fmt.Sprintf("%d seats spare", c.SpareSeats))
+	}
+	if c.Nearness != "" {
+		parts = append(parts, c.Nearness)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func printFindTable(candidates []findCandidate) {
+	amenities := ""
+	if len(findAmenities) > 0 {
+		amenities = " with " + strings.Join(findAmenities, ", ")
+	}
+	fmt.Printf("Rooms for %d%s, %s %s:\n\n", findAttendees, amenities, formatDuration(findDuration), findWithin)
+
+	columns := []tableColumn{
+		{header: "#", width: 3, priority: 5},
+		{header: "ROOM", width: 25, minWidth: 10, priority: 4},
+		{header: "LOCATION", width: 15, minWidth: 8, priority: 1},
+		{header: "SEATS", width: 6, priority: 2},
+		{header: "FREE", width: 22, priority: 5},
+		{header: "FIT", width: 30, minWidth: 12, priority: 3},
+	}
+	var rows [][]string
+	for i, c := range candidates {
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			derefString(c.Room.Name),
+			c.Location,
+			strconv.Itoa(*c.Room.Capacity),
+			c.Start.Format("Mon Jan 2 15:04") + "-" + c.End.Format("15:04"),
+			describeFit(c),
+		})
+	}
+	printTable(columns, rows)
+}
+
+func printFindCSV(candidates []findCandidate) error {
+	w, err := newCSVWriter(os.Stdout, findCSVColumns)
+	if err != nil {
+		return err
+	}
+	for i, c := range candidates {
+		w.Write([]string{
+			strconv.Itoa(i + 1),
+			derefString(c.Room.Id),
+			derefString(c.Room.Name),
+			c.Location,
+			strconv.Itoa(*c.Room.Capacity),
+			strconv.Itoa(c.SpareSeats),
+			c.Start.Format("2006-01-02 15:04"),
+			c.End.Format("2006-01-02 15:04"),
+			c.Nearness,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// bookFindCandidate books the best room at its first free time, through
+// the same checks as 'miles book'
+func bookFindCandidate(ctx context.Context, client config.API, best findCandidate) error {
+	roomID := derefString(best.Room.Id)
+	fmt.Printf("Best fit: %s (%s), %s-%s, %s\n", derefString(best.Room.Name), best.Location,
+		best.Start.Format("Mon Jan 2 15:04"), best.End.Format("15:04"), describeFit(best))
+
+	buffer, err := checkBooking(ctx, client, roomID, best.Start, best.End, findTitle, viper.GetDuration("buffer"))
+	if err != nil {
+		return err
+	}
+	return createBooking(ctx, client, roomID, best.Start, best.End, findTitle, "", buffer)
+}
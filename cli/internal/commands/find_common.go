@@ -0,0 +1,362 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var findCommonCmd = &cobra.Command{
+	Use:   "find-common",
+	Short: "Find a time and room that suit several people",
+	Long: `Find times when you and everyone in --people are free, together with a
+room that is free then and seats you all. Slots are on weekdays within
+--hours, earliest first, each with the smallest room that fits. With
+--near, the room you'd prefer comes first when it's free, then the rooms
+nearest to it by floor and wing.
+
+Everyone's bookings in the booking system count as busy; titles are never
+shown. Your own imported and connected calendars (see 'miles import' and
+--busy-calendar on 'miles book') count too.
+
+--within is today, tomorrow, this week, next week, a number of days (3d),
+a date (2025-10-20) or a date range (2025-10-20..2025-10-24).
+
+--explain adds why: which rooms were left out and for what, and who was
+busy or which bookings held the rooms at each time passed over.
+
+Examples:
+  miles find-common --people kari@miles.no,ola@miles.no --duration 1h
+  miles find-common --people kari@miles.no --duration 30m --within tomorrow
+  miles find-common --people kari@miles.no --within "next week" --location oslo
+  miles find-common --people kari@miles.no --near stavanger-teamrommet
+  miles find-common --people kari@miles.no --within tomorrow --explain
+  miles find-common --people kari@miles.no -o json`,
+	Args: cobra.NoArgs,
+	RunE: runFindCommon,
+}
+
+var (
+	commonPeople     []string
+	commonDuration   time.Duration
+	commonWithin     string
+	commonHours      string
+	commonLocationID string
+	commonCapacity   int
+	commonLimit      int
+	commonNear       string
+	commonExplain    bool
+)
+
+func init() {
+	findCommonCmd.Flags().StringSliceVarP(&commonPeople, "people", "p", nil, "emails of the other participants (required)")
+	findCommonCmd.Flags().DurationVarP(&commonDuration, "duration", "d", time.Hour, "meeting length")
+	findCommonCmd.Flags().StringVarP(&commonWithin, "within", "w", "this week", "when to look")
+	findCommonCmd.Flags().StringVar(&commonHours, "hours", "08:00-17:00", "working hours the meeting must fit in")
+	findCommonCmd.Flags().StringVarP(&commonLocationID, "location", "l", "", "only rooms at this location ID")
+	findCommonCmd.Flags().IntVar(&commonCapacity, "capacity", 0, "seats needed (default: everyone, including you)")
+	findCommonCmd.Flags().IntVarP(&commonLimit, "limit", "n", 10, "how many slots to propose")
+	findCommonCmd.Flags().StringVar(&commonNear, "near", "", "prefer this room ID, then the rooms nearest to it")
+	findCommonCmd.Flags().BoolVar(&commonExplain, "explain", false, "also show why rooms and times were passed over")
+	findCommonCmd.MarkFlagRequired("people")
+	findCommonCmd.RegisterFlagCompletionFunc("location", completeLocationIDs)
+	findCommonCmd.RegisterFlagCompletionFunc("near", completeRoomIDs)
+}
+
+// commonSlot is a time everyone is free, with the rooms free then, best
+// fitting first
+type commonSlot struct {
+	Start time.Time       `json:"start"`
+	End   time.Time       `json:"end"`
+	Rooms []milesapi.Room `json:"rooms"`
+}
+
+// maxCommonWindow matches the longest window the busy times API covers
+const maxCommonWindow = 31 * 24 * time.Hour
+
+// dateRangePattern matches --within date ranges such as 2025-10-20..2025-10-24
+var dateRangePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\.\.(\d{4}-\d{2}-\d{2})$`)
+
+func runFindCommon(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if commonDuration < 15*time.Minute {
+		return fmt.Errorf("--duration must be at least 15m")
+	}
+	if commonLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	dayFrom, dayTo, err := parseWorkingHours(commonHours)
+	if err != nil {
+		return err
+	}
+	now := config.ServerNow()
+	from, to, err := parseWithin(commonWithin, now)
+	if err != nil {
+		return err
+	}
+	if from.Before(now) {
+		from = now
+	}
+	if !to.After(from) {
+		return fmt.Errorf("--within %q has already passed", commonWithin)
+	}
+
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// Everyone in --people, and you
+	me, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	people := commonPeople
+	if me.Email != nil {
+		people = append(people, string(*me.Email))
+	}
+	emails := []string{}
+	seen := map[string]bool{}
+	for _, email := range people {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email != "" && !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	capacity := commonCapacity
+	if capacity == 0 {
+		capacity = len(emails)
+	}
+
+	busyTimes, err := client.GetBusyTimes(ctx, emails, from, to)
+	if err != nil {
+		return err
+	}
+	busy := make([]calsync.Busy, 0, len(busyTimes))
+	named := make([]namedBusy, 0, len(busyTimes))
+	for _, b := range busyTimes {
+		period := calsync.Busy{Start: b.StartTime, End: b.EndTime}
+		busy = append(busy, period)
+		named = append(named, namedBusy{Busy: period, Who: string(b.Email)})
+	}
+	for _, period := range loadCalendarBusy(from, to) {
+		busy = append(busy, period)
+		named = append(named, namedBusy{Busy: period, Who: "your calendar"})
+	}
+
+	// --near keeps to the preferred room's location
+	locationID := commonLocationID
+	var near *milesapi.Room
+	if commonNear != "" {
+		if near, err = findRoom(ctx, client, commonNear); err != nil {
+			return err
+		}
+		if locationID != "" && locationID != derefString(near.LocationId) {
+			return fmt.Errorf("room %s is not at location %s", commonNear, locationID)
+		}
+		locationID = derefString(near.LocationId)
+	}
+
+	// Rooms that seat everyone, with their bookings in the window
+	allRooms, err := client.GetRooms(ctx, locationID)
+	if err != nil {
+		return err
+	}
+	var rooms []milesapi.Room
+	var verdicts []roomVerdict
+	roomBookings := map[string][]milesapi.Booking{}
+	for _, room := range allRooms {
+		reason := commonRoomExcluded(room, capacity, commonDuration)
+		verdicts = append(verdicts, roomVerdict{ID: derefString(room.Id), Name: derefString(room.Name), Reason: reason})
+		if reason != "" {
+			continue
+		}
+		bookings, err := client.GetRoomAvailability(ctx, derefString(room.Id), from, to)
+		if err != nil {
+			return err
+		}
+		rooms = append(rooms, room)
+		roomBookings[derefString(room.Id)] = bookings
+	}
+	if len(rooms) == 0 {
+		if commonExplain && output != "json" {
+			printCommonExplanation(commonExplanation{Rooms: verdicts})
+		}
+		return fmt.Errorf("no room seats %d for %s. Try another --location, --near or --capacity", capacity, formatDuration(commonDuration))
+	}
+	// Smallest room that fits first, or with --near the preferred room and
+	// then the nearest
+	sort.SliceStable(rooms, func(i, j int) bool { return *rooms[i].Capacity < *rooms[j].Capacity })
+	if near != nil {
+		rank := func(room milesapi.Room) int {
+			if derefString(room.Id) == derefString(near.Id) {
+				return -1
+			}
+			distance, _ := milesapi.RoomDistance(*near, room)
+			return distance
+		}
+		sort.SliceStable(rooms, func(i, j int) bool { return rank(rooms[i]) < rank(rooms[j]) })
+	}
+
+	slots := findCommonSlots(from, to, commonDuration, dayFrom, dayTo, busy, rooms, roomBookings, bookingBoundary(), commonLimit)
+
+	var explanation commonExplanation
+	if commonExplain {
+		explanation = explainCommonSlots(from, to, commonDuration, dayFrom, dayTo, named, rooms, roomBookings, bookingBoundary(), slots, commonLimit)
+		explanation.Rooms = verdicts
+	}
+
+	if output == "json" {
+		if commonExplain {
+			return outputJSON(map[string]any{"slots": slots, "explanation": explanation})
+		}
+		return outputJSON(slots)
+	}
+
+	if len(slots) == 0 {
+		fmt.Printf("No %s slot %s when all %d of you and a room are free.\n", formatDuration(commonDuration), commonWithin, len(emails))
+		fmt.Println("Try a shorter --duration, a later --within, or wider --hours.")
+		if commonExplain {
+			printCommonExplanation(explanation)
+		}
+		return nil
+	}
+
+	fmt.Printf("%s slots %s for %s:\n\n", formatDuration(commonDuration), commonWithin, strings.Join(emails, ", "))
+	columns := []tableColumn{
+		{header: "WHEN", width: 22, priority: 4},
+		{header: "ROOM", width: 25, minWidth: 10, priority: 3},
+		{header: "SEATS", width: 8, priority: 2},
+		{header: "ALSO FREE", width: 9, priority: 1},
+	}
+	var rows [][]string
+	for _, slot := range slots {
+		room := slot.Rooms[0]
+		rows = append(rows, []string{
+			slot.Start.Format("Mon Jan 2 15:04") + "-" + slot.End.Format("15:04"),
+			derefString(room.Name),
+			strconv.Itoa(*room.Capacity),
+			strconv.Itoa(len(slot.Rooms) - 1),
+		})
+	}
+	printTable(columns, rows)
+	first := slots[0]
+	fmt.Printf("\nBook the first with: miles book -r %s -s %q -e %s -t TITLE\n",
+		derefString(first.Rooms[0].Id), first.Start.Format("2006-01-02 15:04"), first.End.Format("15:04"))
+	if commonExplain {
+		printCommonExplanation(explanation)
+	}
+	return nil
+}
+
+// findCommonSlots walks [from, to) in quarter hours on weekdays within
+// working hours (minutes after midnight) and returns up to limit slots of
+// length d where nobody is busy and at least one room is free
+func findCommonSlots(from, to time.Time, d time.Duration, dayFrom, dayTo int, busy []calsync.Busy, rooms []milesapi.Room, roomBookings map[string][]milesapi.Booking, boundary config.Boundary, limit int) []commonSlot {
+	var slots []commonSlot
+	start := from.Truncate(15 * time.Minute)
+	if start.Before(from) {
+		start = start.Add(15 * time.Minute)
+	}
+
+	for ; !start.Add(d).After(to) && len(slots) < limit; start = start.Add(15 * time.Minute) {
+		end := start.Add(d)
+		if start.Weekday() == time.Saturday || start.Weekday() == time.Sunday {
+			continue
+		}
+		minutes := start.Hour()*60 + start.Minute()
+		if minutes < dayFrom || minutes+int(d/time.Minute) > dayTo || end.Day() != start.Day() {
+			continue
+		}
+		if calsync.Overlaps(busy, start, end) {
+			continue
+		}
+
+		slot := commonSlot{Start: start, End: end}
+		for _, room := range rooms {
+			if len(boundary.Conflicting(roomBookings[derefString(room.Id)], start, end)) == 0 {
+				slot.Rooms = append(slot.Rooms, room)
+			}
+		}
+		if len(slot.Rooms) > 0 {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// parseWorkingHours parses --hours such as 08:00-17:00 into minutes after
+// midnight
+func parseWorkingHours(s string) (from, to int, err error) {
+	if !coreHoursPattern.MatchString(s) {
+		return 0, 0, fmt.Errorf("invalid --hours %q: use HH:MM-HH:MM, e.g. 08:00-17:00", s)
+	}
+	minutes := func(hhmm string) int {
+		hour, _ := strconv.Atoi(hhmm[:2])
+		minute, _ := strconv.Atoi(hhmm[3:])
+		return hour*60 + minute
+	}
+	from, to = minutes(s[:5]), minutes(s[6:])
+	if to <= from {
+		return 0, 0, fmt.Errorf("invalid --hours %q: the end must be after the start", s)
+	}
+	return from, to, nil
+}
+
+// parseWithin parses --within into a window in local time
+func parseWithin(s string, now time.Time) (from, to time.Time, err error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	// Days since Monday
+	weekday := (int(today.Weekday()) + 6) % 7
+
+	switch within := strings.ToLower(strings.TrimSpace(s)); within {
+	case "today":
+		from, to = today, today.AddDate(0, 0, 1)
+	case "tomorrow":
+		from, to = today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+	case "this week":
+		from, to = today, today.AddDate(0, 0, 7-weekday)
+	case "next week":
+		from = today.AddDate(0, 0, 7-weekday)
+		to = from.AddDate(0, 0, 7)
+	default:
+		days := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(within, "days"), "day"), "d")
+		if n, err := strconv.Atoi(strings.TrimSpace(days)); err == nil && n > 0 {
+			from, to = now, now.AddDate(0, 0, n)
+			break
+		}
+
+		first, last := within, within
+		if m := dateRangePattern.FindStringSubmatch(within); m != nil {
+			first, last = m[1], m[2]
+		}
+		start, err1 := time.ParseInLocation("2006-01-02", first, time.Local)
+		end, err2 := time.ParseInLocation("2006-01-02", last, time.Local)
+		if err1 != nil || err2 != nil || end.Before(start) {
+			return time.Time{}, time.Time{}, fmt.Errorf(`invalid --within %q: use today, tomorrow, "this week", "next week", 3d, 2025-10-20 or 2025-10-20..2025-10-24`, s)
+		}
+		from, to = start, end.AddDate(0, 0, 1)
+	}
+
+	if to.Sub(from) > maxCommonWindow {
+		return time.Time{}, time.Time{}, fmt.Errorf("--within can cover at most 31 days")
+	}
+	return from, to, nil
+}
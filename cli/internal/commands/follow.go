@@ -0,0 +1,317 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/spf13/cobra"
+)
+
+var followCmd = &cobra.Command{
+	Use:   "follow",
+	Short: "Follow rooms and colleagues",
+	Long: `Follow rooms and colleagues to hear when bookings are made or cancelled
+in those rooms or by those colleagues. Without a subcommand, lists what you
+follow. The TUI shows the same list, with toasts and an Activity view.
+
+Examples:
+  miles follow room ROOM123            # Follow a room
+  miles follow user kari@miles.no      # Follow a colleague
+  miles follow                         # What you follow
+  miles follow activity                # The last week's activity
+  miles follow activity --watch        # Keep printing new activity
+  miles follow rm ROOM123              # Stop following`,
+	Args: cobra.NoArgs,
+	RunE: runFollowList,
+}
+
+var followRoomCmd = &cobra.Command{
+	Use:               "room ROOM_ID",
+	Short:             "Follow a room",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRoomIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFollow(cmd.Context(), milesapi.SubscriptionInput{RoomId: &args[0]})
+	},
+}
+
+var followUserCmd = &cobra.Command{
+	Use:   "user EMAIL",
+	Short: "Follow a colleague",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		email := openapi_types.Email(args[0])
+		return runFollow(cmd.Context(), milesapi.SubscriptionInput{Email: &email})
+	},
+}
+
+var followRemoveCmd = &cobra.Command{
+	Use:     "rm ROOM_ID|EMAIL",
+	Aliases: []string{"remove", "unfollow"},
+	Short:   "Stop following a room or colleague",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runUnfollow,
+}
+
+var followActivityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Show new and cancelled bookings for what you follow",
+	Args:  cobra.NoArgs,
+	RunE:  runFollowActivity,
+}
+
+var (
+	activityWatch    bool
+	activityInterval time.Duration
+)
+
+func init() {
+	followActivityCmd.Flags().BoolVarP(&activityWatch, "watch", "w", false, "keep printing new activity until interrupted")
+	followActivityCmd.Flags().DurationVar(&activityInterval, "interval", 30*time.Second, "how often --watch checks for activity")
+
+	followCmd.AddCommand(followRoomCmd)
+	followCmd.AddCommand(followUserCmd)
+	followCmd.AddCommand(followRemoveCmd)
+	followCmd.AddCommand(followActivityCmd)
+}
+
+// followClient creates an API client for the logged-in user
+func followClient(ctx context.Context) (config.API, error) {
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return nil, fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	return newAPIClient(ctx, token)
+}
+
+func runFollowList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := followClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	subscriptions, err := client.GetSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(subscriptions)
+	}
+
+	if len(subscriptions) == 0 {
+		fmt.Println("You don't follow any rooms or colleagues.")
+		fmt.Println("Follow one with: miles follow room ROOM_ID, or miles follow user EMAIL")
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "TYPE", width: 6, priority: 1},
+		{header: "FOLLOWING", width: 30, minWidth: 12, priority: 2},
+		{header: "ID", width: 25, priority: 3},
+	}
+	var rows [][]string
+	for _, subscription := range subscriptions {
+		kind, name, id := describeSubscription(subscription)
+		rows = append(rows, []string{kind, name, id})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runFollow(ctx context.Context, input milesapi.SubscriptionInput) error {
+	client, err := followClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	subscription, err := client.Follow(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(subscription)
+	}
+	_, name, _ := describeSubscription(*subscription)
+	fmt.Printf("✓ Following %s\n", name)
+	return nil
+}
+
+func runUnfollow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := followClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	subscriptions, err := client.GetSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Match the room ID or email shown by 'miles follow'
+	for _, subscription := range subscriptions {
+		_, name, id := describeSubscription(subscription)
+		if !strings.EqualFold(id, args[0]) && subscription.Id != args[0] {
+			continue
+		}
+		if err := client.Unfollow(ctx, subscription.Id); err != nil {
+			return err
+		}
+		if output == "json" {
+			return outputJSON(map[string]string{"unfollowed": subscription.Id})
+		}
+		fmt.Printf("✓ No longer following %s\n", name)
+		return nil
+	}
+	return fmt.Errorf("you don't follow %s. Run 'miles follow' to list what you follow", args[0])
+}
+
+func runFollowActivity(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := followClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	activity, cursor, err := client.GetActivity(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	if !activityWatch {
+		if output == "json" {
+			return outputJSON(activity)
+		}
+		if len(activity) == 0 {
+			fmt.Println("No activity in the last week for what you follow.")
+			return nil
+		}
+		for _, item := range activity {
+			printActivity(item)
+		}
+		return nil
+	}
+
+	// Oldest first when streaming, so the newest ends up at the bottom
+	for i := len(activity) - 1; i >= 0; i-- {
+		if err := writeActivity(activity[i]); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(activityInterval)
+	defer ticker.Stop()
+	seen := map[string]bool{}
+	for _, item := range activity {
+		seen[activityKey(item)] = true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		items, next, err := client.GetActivity(ctx, cursor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+		cursor = next
+		for i := len(items) - 1; i >= 0; i-- {
+			// The cursor is inclusive, so the newest item can come again
+			if seen[activityKey(items[i])] {
+				continue
+			}
+			seen[activityKey(items[i])] = true
+			if err := writeActivity(items[i]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeActivity prints one item while watching; -o json writes one JSON
+// object per line, as 'miles events' does
+func writeActivity(item milesapi.ActivityItem) error {
+	if output == "json" {
+		return json.NewEncoder(os.Stdout).Encode(item)
+	}
+	printActivity(item)
+	return nil
+}
+
+// printActivity prints an activity item on one line
+func printActivity(item milesapi.ActivityItem) {
+	verb := "booked"
+	if item.Type == milesapi.ActivityItemTypeBookingCancelled {
+		verb = "cancelled"
+	}
+	booking := item.Booking
+	fmt.Printf("%-8s %s %s %q in %s, %s %s-%s\n",
+		humanizeTime(item.Time, config.ServerNow()),
+		activityUserName(booking.User),
+		verb,
+		booking.Title,
+		booking.Room.Name,
+		booking.StartTime.Local().Format("Mon Jan 2"),
+		booking.StartTime.Local().Format("15:04"),
+		booking.EndTime.Local().Format("15:04"),
+	)
+}
+
+// activityKey identifies an activity item across polls
+func activityKey(item milesapi.ActivityItem) string {
+	return string(item.Type) + "/" + item.Booking.Id
+}
+
+// activityUserName returns a colleague's name, falling back to their email
+func activityUserName(user milesapi.User) string {
+	name := strings.TrimSpace(derefString(user.FirstName) + " " + derefString(user.LastName))
+	if name == "" && user.Email != nil {
+		name = string(*user.Email)
+	}
+	return name
+}
+
+// describeSubscription returns a subscription's kind, a readable name, and
+// the room ID or email that identifies it
+func describeSubscription(subscription milesapi.Subscription) (kind, name, id string) {
+	if subscription.Room != nil {
+		return "room", subscription.Room.Name + " (" + subscription.Room.Location.Name + ")", subscription.Room.Id
+	}
+	if subscription.RoomId != nil {
+		return "room", *subscription.RoomId, *subscription.RoomId
+	}
+	if user := subscription.FollowedUser; user != nil {
+		email := ""
+		if user.Email != nil {
+			email = string(*user.Email)
+		}
+		return "user", activityUserName(*user), email
+	}
+	return "user", derefString(subscription.FollowedUserId), derefString(subscription.FollowedUserId)
+}
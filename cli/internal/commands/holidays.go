@@ -0,0 +1,340 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var adminHolidaysCmd = &cobra.Command{
+	Use:   "holidays",
+	Short: "Manage the days a location is closed (admins and its managers)",
+	Long: `A location is closed on its holidays and on the weekdays it closes every
+week. Nothing can be booked in its rooms on those days: miles book and the
+TUI's booking form say "Office closed Dec 25 (Christmas Day)", and the TUI
+calendar greys the days out. Days are in the location's time zone.
+
+LOCATION is a location ID or name; HOLIDAY is a holiday ID or its date.
+
+Examples:
+  miles admin holidays add Oslo 2025-12-25 "Christmas Day"
+  miles admin holidays list Oslo
+  miles admin holidays list Oslo --from 2025-01-01 --to 2025-12-31
+  miles admin holidays rm Oslo 2025-12-25
+  miles admin holidays closed Oslo sat,sun
+  miles admin holidays closed Oslo none`,
+}
+
+var adminHolidaysListCmd = &cobra.Command{
+	Use:               "list LOCATION",
+	Short:             "List a location's holidays and closed weekdays",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminHolidaysList,
+}
+
+var adminHolidaysAddCmd = &cobra.Command{
+	Use:               "add LOCATION DATE NAME",
+	Short:             "Close a location for a day",
+	Args:              cobra.ExactArgs(3),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminHolidaysAdd,
+}
+
+var adminHolidaysRemoveCmd = &cobra.Command{
+	Use:     "rm LOCATION HOLIDAY",
+	Aliases: []string{"remove", "delete"},
+	Short:   "Open a location again on a holiday",
+	Args:    cobra.ExactArgs(2),
+	RunE:    runAdminHolidaysRemove,
+}
+
+var adminHolidaysClosedCmd = &cobra.Command{
+	Use:   "closed LOCATION WEEKDAYS",
+	Short: `Set the weekdays a location is closed every week, e.g. "sat,sun" or "none"`,
+	Args:  cobra.ExactArgs(2),
+	RunE:  runAdminHolidaysClosed,
+}
+
+var (
+	holidaysFrom string
+	holidaysTo   string
+)
+
+// upcomingHolidays is how far ahead holidays are listed by default
+const upcomingHolidays = 365 * 24 * time.Hour
+
+func init() {
+	adminHolidaysListCmd.Flags().StringVar(&holidaysFrom, "from", "", "first day to list, YYYY-MM-DD (default today)")
+	adminHolidaysListCmd.Flags().StringVar(&holidaysTo, "to", "", "last day to list, YYYY-MM-DD (default a year from the first)")
+
+	adminHolidaysCmd.AddCommand(adminHolidaysListCmd)
+	adminHolidaysCmd.AddCommand(adminHolidaysAddCmd)
+	adminHolidaysCmd.AddCommand(adminHolidaysRemoveCmd)
+	adminHolidaysCmd.AddCommand(adminHolidaysClosedCmd)
+	adminCmd.AddCommand(adminHolidaysCmd)
+}
+
+// closures are the days a location is closed, as fetched for one range
+type closures struct {
+	location *time.Location
+	holidays map[string]string // Date to name
+	weekdays []int
+}
+
+// loadClosures fetches a location's closed days between two times
+func loadClosures(ctx context.Context, client config.API, locationID string, from, to time.Time) (*closures, error) {
+	// The days are the location's; a day either side covers every zone
+	result, err := client.GetLocationHolidays(ctx, locationID,
+		from.AddDate(0, 0, -1).Format(time.DateOnly), to.AddDate(0, 0, 1).Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	zone, err := time.LoadLocation(result.Timezone)
+	if err != nil {
+		zone = time.UTC
+	}
+	c := &closures{location: zone, holidays: make(map[string]string), weekdays: result.ClosedWeekdays}
+	for _, holiday := range result.Holidays {
+		c.holidays[holiday.Date] = holiday.Name
+	}
+	return c, nil
+}
+
+// closedOn returns why the location is closed on the day at falls on
+// there, e.g. "Christmas Day" or "closed on Saturdays", or "" when it's open
+func (c *closures) closedOn(at time.Time) string {
+	local := at.In(c.location)
+	if name, ok := c.holidays[local.Format(time.DateOnly)]; ok {
+		return name
+	}
+	if slices.Contains(c.weekdays, int(local.Weekday())) {
+		return "closed on " + local.Weekday().String() + "s"
+	}
+	return ""
+}
+
+// check returns an error like the server's when [start, end) touches a
+// closed day, e.g. "office closed Dec 25, 2025 (Christmas Day)"
+func (c *closures) check(start, end time.Time) error {
+	last := end.Add(-time.Nanosecond)
+	if last.Before(start) {
+		last = start
+	}
+	lastDay := last.In(c.location).Format(time.DateOnly)
+	for day := start.In(c.location); ; day = day.AddDate(0, 0, 1) {
+		if reason := c.closedOn(day); reason != "" {
+			return fmt.Errorf("office closed %s (%s)", day.Format("Jan 2, 2006"), reason)
+		}
+		if day.Format(time.DateOnly) >= lastDay {
+			return nil
+		}
+	}
+}
+
+// checkOrNil is check for closures that may not have loaded: without
+// them, every day passes
+func (c *closures) checkOrNil(start, end time.Time) error {
+	if c == nil {
+		return nil
+	}
+	return c.check(start, end)
+}
+
+// checkRoomClosures fails when the room's location is closed on a day the
+// booking touches. The server has the final say, so failing to look passes.
+func checkRoomClosures(ctx context.Context, client config.API, room *milesapi.Room, start, end time.Time) error {
+	if room == nil || derefString(room.LocationId) == "" {
+		return nil
+	}
+	c, err := loadClosures(ctx, client, *room.LocationId, start, end)
+	if err != nil {
+		return nil
+	}
+	return c.check(start, end)
+}
+
+// parseWeekdays parses "sat,sun" or "none" into weekday numbers, 0 = Sunday
+func parseWeekdays(input string) ([]int, error) {
+	weekdays := []int{}
+	if strings.EqualFold(strings.TrimSpace(input), "none") {
+		return weekdays, nil
+	}
+	for _, name := range strings.Split(input, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			full := strings.ToLower(day.String())
+			if len(name) >= 2 && strings.HasPrefix(full, name) {
+				if !slices.Contains(weekdays, int(day)) {
+					weekdays = append(weekdays, int(day))
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown weekday %q: use names like mon or saturday, or none", name)
+		}
+	}
+	slices.Sort(weekdays)
+	return weekdays, nil
+}
+
+// describeWeekdays formats weekday numbers, e.g. "Saturday, Sunday"
+func describeWeekdays(weekdays []int) string {
+	if len(weekdays) == 0 {
+		return "none"
+	}
+	var names []string
+	for _, day := range weekdays {
+		names = append(names, time.Weekday(day).String())
+	}
+	return strings.Join(names, ", ")
+}
+
+func runAdminHolidaysList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	from := holidaysFrom
+	if from == "" {
+		from = time.Now().Format(time.DateOnly)
+	}
+	to := holidaysTo
+	if to == "" {
+		start, err := time.Parse(time.DateOnly, from)
+		if err != nil {
+			return fmt.Errorf("invalid --from: %w", err)
+		}
+		to = start.Add(upcomingHolidays).Format(time.DateOnly)
+	}
+
+	result, err := client.GetLocationHolidays(ctx, derefString(location.Id), from, to)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(result)
+	}
+
+	fmt.Printf("%s (%s)\n", derefString(location.Name), result.Timezone)
+	fmt.Printf("Closed every: %s\n\n", describeWeekdays(result.ClosedWeekdays))
+	if len(result.Holidays) == 0 {
+		fmt.Printf("No holidays between %s and %s.\n", from, to)
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 2},
+		{header: "DATE", width: 15, priority: 5},
+		{header: "HOLIDAY", width: 30, minWidth: 12, priority: 4},
+	}
+	var rows [][]string
+	for _, holiday := range result.Holidays {
+		date := holiday.Date
+		if day, err := time.Parse(time.DateOnly, holiday.Date); err == nil {
+			date = day.Format("Mon 2006-01-02")
+		}
+		rows = append(rows, []string{holiday.Id, date, holiday.Name})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminHolidaysAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if _, err := time.Parse(time.DateOnly, args[1]); err != nil {
+		return fmt.Errorf("invalid date %q: use YYYY-MM-DD", args[1])
+	}
+
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	holiday, err := client.CreateLocationHoliday(ctx, derefString(location.Id), milesapi.LocationHolidayInput{
+		Date: args[1],
+		Name: args[2],
+	})
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(holiday)
+	}
+	fmt.Printf("✓ %s is closed on %s (%s)\n", derefString(location.Name), holiday.Date, holiday.Name)
+	fmt.Println("Bookings already made for that day are kept; cancel them with 'miles cancel'.")
+	return nil
+}
+
+func runAdminHolidaysRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.GetLocationHolidays(ctx, derefString(location.Id), "", "")
+	if err != nil {
+		return err
+	}
+	var holiday *milesapi.LocationHoliday
+	for i := range result.Holidays {
+		if result.Holidays[i].Id == args[1] || result.Holidays[i].Date == args[1] {
+			holiday = &result.Holidays[i]
+			break
+		}
+	}
+	if holiday == nil {
+		return fmt.Errorf("no holiday %q at %s. Run 'miles admin holidays list %q'", args[1], derefString(location.Name), derefString(location.Name))
+	}
+
+	if err := client.DeleteLocationHoliday(ctx, derefString(location.Id), holiday.Id); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]string{"deleted": holiday.Id})
+	}
+	fmt.Printf("✓ %s is open again on %s\n", derefString(location.Name), holiday.Date)
+	return nil
+}
+
+func runAdminHolidaysClosed(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	weekdays, err := parseWeekdays(args[1])
+	if err != nil {
+		return err
+	}
+
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.SetClosedWeekdays(ctx, derefString(location.Id), weekdays); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string][]int{"closedWeekdays": weekdays})
+	}
+	fmt.Printf("✓ %s is closed every: %s\n", derefString(location.Name), describeWeekdays(weekdays))
+	return nil
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"golang.org/x/term"
+)
+
+// hourBarSlot is how much of the day one block of an hour bar covers
+const hourBarSlot = 15 * time.Minute
+
+// The hours an hour bar shows unless bookings fall outside them
+const (
+	hourBarFrom = 8
+	hourBarTo   = 18
+)
+
+// Blocks of an hour bar
+const (
+	barBooked    = "█"
+	barFree      = "░"
+	barRequested = "▒"
+	barClash     = "▓"
+)
+
+// hourBar draws a room's day on one line, e.g. "08 ░░░░████░░▒▒░░ 18",
+// with a block per quarter hour
+type hourBar struct {
+	day      time.Time // Midnight, local time
+	bookings []milesapi.Booking
+
+	// The slot being asked for, if any; zero times for none
+	start, end time.Time
+}
+
+// hours returns the span the bar covers: working hours, widened to whole
+// hours around any booking or requested slot outside them
+func (b hourBar) hours() (from, to int) {
+	from, to = hourBarFrom, hourBarTo
+	widen := func(start, end time.Time) {
+		dayEnd := b.day.AddDate(0, 0, 1)
+		if !start.Before(dayEnd) || !end.After(b.day) {
+			return
+		}
+		if start.Before(b.day) {
+			start = b.day
+		}
+		if end.After(dayEnd) {
+			end = dayEnd
+		}
+		from = min(from, int(start.Sub(b.day)/time.Hour))
+		to = max(to, int((end.Sub(b.day)+time.Hour-1)/time.Hour))
+	}
+	for _, booking := range b.bookings {
+		widen(booking.StartTime.Local(), booking.EndTime.Local())
+	}
+	if !b.start.IsZero() {
+		widen(b.start.Local(), b.end.Local())
+	}
+	return from, to
+}
+
+// booked reports whether any booking overlaps [start, end)
+func (b hourBar) booked(start, end time.Time) bool {
+	for _, booking := range b.bookings {
+		if booking.StartTime.Before(end) && booking.EndTime.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the bar. Colour is used only on a terminal, and never with
+// NO_COLOR set.
+func (b hourBar) String() string {
+	color := useColor()
+	paint := func(block, ansi string) string {
+		if !color || ansi == "" {
+			return block
+		}
+		return ansi + block + ansiReset
+	}
+
+	from, to := b.hours()
+	var out strings.Builder
+	fmt.Fprintf(&out, "%02d ", from)
+	for slot := b.day.Add(time.Duration(from) * time.Hour); slot.Before(b.day.Add(time.Duration(to) * time.Hour)); slot = slot.Add(hourBarSlot) {
+		slotEnd := slot.Add(hourBarSlot)
+		requested := !b.start.IsZero() && b.start.Before(slotEnd) && b.end.After(slot)
+		booked := b.booked(slot, slotEnd)
+		switch {
+		case requested && booked:
+			out.WriteString(paint(barClash, ansiRed))
+		case requested:
+			out.WriteString(paint(barRequested, ansiGreen))
+		case booked:
+			out.WriteString(paint(barBooked, ansiYellow))
+		default:
+			out.WriteString(paint(barFree, ansiDim))
+		}
+	}
+	fmt.Fprintf(&out, " %02d", to)
+	return out.String()
+}
+
+// legend explains the blocks the bar can show
+func (b hourBar) legend() string {
+	legend := barBooked + " booked  " + barFree + " free"
+	if !b.start.IsZero() {
+		legend += "  " + barRequested + " your time  " + barClash + " clash"
+	}
+	return legend + "  (one block = 15 min)"
+}
+
+// useColor reports whether output goes to a terminal that wants colour
+func useColor() bool {
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return !noColor && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// loadRoomDay returns the room's active bookings on day's date
+func loadRoomDay(ctx context.Context, client config.API, roomID string, day time.Time) (time.Time, []milesapi.Booking, error) {
+	local := day.Local()
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	bookings, err := client.CheckRoomAvailability(ctx, roomID, dayStart, dayStart.AddDate(0, 0, 1))
+	return dayStart, bookings, err
+}
+
+// printRoomDay prints the hour bar for the day of [start, end) in the room,
+// with that slot marked. It is only a hint, so failures print nothing.
+func printRoomDay(ctx context.Context, client config.API, roomID string, start, end time.Time) {
+	day, bookings, err := loadRoomDay(ctx, client, roomID, start)
+	if err != nil {
+		return
+	}
+	bar := hourBar{day: day, bookings: bookings, start: start, end: end}
+	fmt.Printf("  %s\n  %s\n\n", bar, bar.legend())
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import data from other tools",
+}
+
+var importICSCmd = &cobra.Command{
+	Use:   "ics [FILE]",
+	Short: "Import an .ics calendar of your external commitments",
+	Long: `Import an iCalendar (.ics) file so its events count as times you're busy.
+
+A copy of the file is kept in ~/.miles-cli/calendars under the file's name;
+importing a file with the same name again replaces it. Interactive booking
+and the alternatives suggested when a room is taken skip these times.
+
+Events marked free or cancelled are ignored. Daily and weekly recurring
+events are expanded; other recurrences count only their first occurrence.
+
+Examples:
+  miles import ics my-calendar.ics        # Import or refresh a calendar
+  miles import ics --list                 # Show imported calendars
+  miles import ics --remove my-calendar   # Forget an imported calendar`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if importList || importRemove != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runImportICS,
+}
+
+var (
+	importList   bool
+	importRemove string
+)
+
+func init() {
+	importICSCmd.Flags().BoolVar(&importList, "list", false, "list imported calendars")
+	importICSCmd.Flags().StringVar(&importRemove, "remove", "", "remove the imported calendar with this name")
+
+	importCmd.AddCommand(importICSCmd)
+}
+
+func runImportICS(cmd *cobra.Command, args []string) error {
+	if importRemove != "" {
+		if err := calsync.RemoveImported(importRemove); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Removed imported calendar %s\n", importRemove)
+		return nil
+	}
+
+	if importList {
+		names, err := calsync.ImportedCalendars()
+		if err != nil {
+			return err
+		}
+		if output == "json" {
+			return outputJSON(names)
+		}
+		if len(names) == 0 {
+			fmt.Println("No imported calendars. Run 'miles import ics FILE' to add one.")
+			return nil
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	name, cal, err := calsync.ImportICS(args[0])
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	upcoming := cal.BusyTimes(now, now.AddDate(0, 0, 30))
+
+	if output == "json" {
+		return outputJSON(map[string]interface{}{
+			"name":     name,
+			"events":   cal.Events(),
+			"upcoming": upcoming,
+		})
+	}
+
+	fmt.Printf("✓ Imported %d event(s) as %s\n", cal.Events(), name)
+	fmt.Printf("  %d busy period(s) in the next 30 days\n", len(upcoming))
+	if cal.Unsupported > 0 {
+		fmt.Printf("⚠ %d recurring event(s) use rules that aren't supported; only their first occurrence counts\n", cal.Unsupported)
+	}
+	return nil
+}
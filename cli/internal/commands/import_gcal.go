@@ -0,0 +1,393 @@
+package commands
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var importGcalCmd = &cobra.Command{
+	Use:   "gcal",
+	Short: "Turn Google Calendar events held in rooms into bookings",
+	Long: `Turn the events in a Google Calendar that were held in meeting rooms into
+Miles bookings, for moving from rooms managed as calendar resources.
+
+An event's rooms are the room resources that accepted it, or else its
+location. The room mapping file says which Miles room each calendar room
+name is, by Miles room ID or name:
+
+  # map.yaml
+  "Oslo-3-Fjorden (12)": oslo-fjorden
+  "Stavanger Skagen": Skagen
+
+Events in unmapped rooms, all-day events and events without a room are
+skipped. Events that already happened are backfilled as history, which
+only admins can do; upcoming ones are booked like any booking, in your
+name. Running the import again skips events that are already booked.
+
+Use --dry-run first: it books nothing and reports what would happen, with
+the calendar rooms missing from the mapping and mapping entries that name
+no Miles room. Uses the gcal_client_id and gcal_client_secret settings and
+sign-in of 'miles sync gcal'.
+
+Examples:
+  miles import gcal --calendar work --since 2025-01-01 --room-mapping map.yaml --dry-run
+  miles import gcal --calendar work --since 2025-01-01 --room-mapping map.yaml
+  miles import gcal --since 2025-06-01 --until 2025-12-31 --room-mapping map.yaml -o json`,
+	Args: cobra.NoArgs,
+	RunE: runImportGcal,
+}
+
+var (
+	importGcalCalendar string
+	importGcalSince    string
+	importGcalUntil    string
+	importGcalMapping  string
+	importGcalDryRun   bool
+	importGcalReauth   bool
+)
+
+func init() {
+	importGcalCmd.Flags().StringVar(&importGcalCalendar, "calendar", "primary", "calendar to import, by name or ID")
+	importGcalCmd.Flags().StringVar(&importGcalSince, "since", "", "import events starting on or after this date (YYYY-MM-DD)")
+	importGcalCmd.Flags().StringVar(&importGcalUntil, "until", "", "import events starting before this date (default: 90 days from now)")
+	importGcalCmd.Flags().StringVar(&importGcalMapping, "room-mapping", "", "YAML file mapping calendar room names to Miles rooms")
+	importGcalCmd.Flags().BoolVar(&importGcalDryRun, "dry-run", false, "report what would be booked without booking anything")
+	importGcalCmd.Flags().BoolVar(&importGcalReauth, "reauth", false, "discard the cached calendar token and sign in again")
+	importGcalCmd.MarkFlagRequired("since")
+	importGcalCmd.MarkFlagRequired("room-mapping")
+
+	importCmd.AddCommand(importGcalCmd)
+}
+
+// What happened to an imported event
+const (
+	importBooked     = "booked"
+	importBackfilled = "backfilled"
+	importWouldBook  = "would book"
+	importWouldFill  = "would backfill"
+	importExisting   = "already booked"
+	importConflict   = "conflict"
+	importUnmapped   = "unmapped room"
+	importNeedsAdmin = "past, admins only"
+	importFailed     = "failed"
+)
+
+// gcalImport is one event and room the import looked at
+type gcalImport struct {
+	EventID      string    `json:"eventId"`
+	Title        string    `json:"title"`
+	StartTime    time.Time `json:"startTime"`
+	EndTime      time.Time `json:"endTime"`
+	CalendarRoom string    `json:"calendarRoom"`
+	RoomID       string    `json:"roomId,omitempty"`
+	RoomName     string    `json:"roomName,omitempty"`
+	Result       string    `json:"result"`
+	BookingID    string    `json:"bookingId,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// gcalImportReport is the -o json form of miles import gcal
+type gcalImportReport struct {
+	DryRun  bool         `json:"dryRun"`
+	Imports []gcalImport `json:"imports"`
+	// Calendar room names without a mapping, with how many events used them
+	UnmappedRooms map[string]int `json:"unmappedRooms"`
+	// Mapping entries whose Miles room doesn't exist
+	UnknownRooms map[string]string `json:"unknownRooms"`
+	Skipped      struct {
+		AllDay int `json:"allDay"`
+		NoRoom int `json:"noRoom"`
+	} `json:"skipped"`
+}
+
+func runImportGcal(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	since, err := time.ParseInLocation("2006-01-02", importGcalSince, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid --since %q: use YYYY-MM-DD", importGcalSince)
+	}
+	until := time.Now().AddDate(0, 0, 90)
+	if importGcalUntil != "" {
+		if until, err = time.ParseInLocation("2006-01-02", importGcalUntil, time.Local); err != nil {
+			return fmt.Errorf("invalid --until %q: use YYYY-MM-DD", importGcalUntil)
+		}
+	}
+	if !since.Before(until) {
+		return fmt.Errorf("--since must be before --until")
+	}
+
+	mapping, err := loadRoomMapping(importGcalMapping)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rooms, err := client.GetRooms(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	report := gcalImportReport{
+		DryRun:        importGcalDryRun,
+		UnmappedRooms: map[string]int{},
+		UnknownRooms:  map[string]string{},
+	}
+	resolved := map[string]milesapi.Room{}
+	for calendarRoom, target := range mapping {
+		if room, ok := matchRoom(rooms, target); ok {
+			resolved[strings.ToLower(calendarRoom)] = room
+		} else {
+			report.UnknownRooms[calendarRoom] = target
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if importGcalReauth {
+		if err := calsync.ForgetToken("gcal"); err != nil {
+			return err
+		}
+	}
+	google, err := calsync.NewGoogle(ctx, calsync.GoogleConfig{
+		ClientID:     viper.GetString("gcal_client_id"),
+		ClientSecret: viper.GetString("gcal_client_secret"),
+	})
+	if err != nil {
+		return err
+	}
+	if err := google.SelectCalendar(ctx, importGcalCalendar); err != nil {
+		return err
+	}
+	events, err := google.Events(ctx, since, until)
+	if err != nil {
+		return err
+	}
+
+	isAdmin := milesapi.TokenRole(token) == milesapi.ADMIN
+	now := time.Now()
+	for _, event := range events {
+		switch {
+		case event.AllDay:
+			report.Skipped.AllDay++
+			continue
+		case len(event.Rooms) == 0:
+			report.Skipped.NoRoom++
+			continue
+		}
+
+		for _, calendarRoom := range event.Rooms {
+			item := gcalImport{
+				EventID:      event.ID,
+				Title:        cmp.Or(strings.TrimSpace(event.Title), "Imported event"),
+				StartTime:    event.Start,
+				EndTime:      event.End,
+				CalendarRoom: calendarRoom,
+			}
+			room, ok := resolved[strings.ToLower(calendarRoom)]
+			if !ok {
+				if _, unknown := mapping[calendarRoom]; !unknown {
+					report.UnmappedRooms[calendarRoom]++
+				}
+				item.Result = importUnmapped
+				report.Imports = append(report.Imports, item)
+				continue
+			}
+			item.RoomID, item.RoomName = derefString(room.Id), derefString(room.Name)
+			importEvent(ctx, client, &item, event, now, isAdmin)
+			report.Imports = append(report.Imports, item)
+		}
+	}
+
+	if output == "json" {
+		if report.Imports == nil {
+			report.Imports = []gcalImport{}
+		}
+		return outputJSON(report)
+	}
+
+	printGcalImport(report)
+	if failed := countImports(report.Imports, importFailed); failed > 0 {
+		return fmt.Errorf("%d events failed to import", failed)
+	}
+	return nil
+}
+
+// importEvent books one event in its mapped room, or on a dry run says
+// what booking it would do. Slots already holding a booking at exactly the
+// event's times count as imported before.
+func importEvent(ctx context.Context, client config.API, item *gcalImport, event calsync.CalendarEvent, now time.Time, isAdmin bool) {
+	past := event.Start.Before(now)
+	if past && !isAdmin {
+		item.Result = importNeedsAdmin
+		return
+	}
+
+	busy, err := client.CheckRoomAvailability(ctx, item.RoomID, event.Start, event.End)
+	if err != nil {
+		item.Result, item.Error = importFailed, err.Error()
+		return
+	}
+	for _, booking := range busy {
+		if booking.StartTime != nil && booking.EndTime != nil &&
+			booking.StartTime.Equal(event.Start) && booking.EndTime.Equal(event.End) {
+			item.Result, item.BookingID = importExisting, derefString(booking.Id)
+			return
+		}
+	}
+	if len(busy) > 0 {
+		item.Result = importConflict
+		return
+	}
+
+	if importGcalDryRun {
+		item.Result = importWouldBook
+		if past {
+			item.Result = importWouldFill
+		}
+		return
+	}
+
+	req := milesapi.BookingInput{
+		RoomId:    item.RoomID,
+		StartTime: event.Start,
+		EndTime:   event.End,
+		Title:     item.Title,
+	}
+	if description := strings.TrimSpace(event.Description); description != "" {
+		req.Description = &description
+	}
+	if past {
+		req.Backfill = &past
+	}
+	booking, err := client.CreateBooking(ctx, req)
+	var conflict *config.ConflictError
+	switch {
+	case errors.As(err, &conflict):
+		item.Result = importConflict
+	case err != nil:
+		item.Result, item.Error = importFailed, err.Error()
+	default:
+		item.Result, item.BookingID = importBooked, derefString(booking.Id)
+		if past {
+			item.Result = importBackfilled
+		}
+	}
+}
+
+// loadRoomMapping reads a YAML map of calendar room names to Miles rooms
+func loadRoomMapping(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read room mapping: %w", err)
+	}
+	var mapping map[string]string
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("invalid room mapping %s: %w", path, err)
+	}
+	if len(mapping) == 0 {
+		return nil, fmt.Errorf("room mapping %s maps no rooms", path)
+	}
+	return mapping, nil
+}
+
+func countImports(imports []gcalImport, result string) int {
+	count := 0
+	for _, item := range imports {
+		if item.Result == result {
+			count++
+		}
+	}
+	return count
+}
+
+// printGcalImport shows each event's outcome, then the mapping mismatches
+func printGcalImport(report gcalImportReport) {
+	if len(report.Imports) > 0 {
+		columns := []tableColumn{
+			{header: "WHEN", width: 22, priority: 5},
+			{header: "TITLE", width: 30, minWidth: 10, priority: 3},
+			{header: "CALENDAR ROOM", width: 24, minWidth: 10, priority: 2},
+			{header: "MILES ROOM", width: 20, minWidth: 8, priority: 1},
+			{header: "RESULT", width: 17, priority: 4},
+		}
+		var rows [][]string
+		for _, item := range report.Imports {
+			start, end := item.StartTime.Local(), item.EndTime.Local()
+			result := item.Result
+			if item.Error != "" {
+				result += ": " + item.Error
+			}
+			rows = append(rows, []string{
+				start.Format("2006-01-02 15:04") + "-" + end.Format("15:04"),
+				item.Title,
+				item.CalendarRoom,
+				item.RoomName,
+				result,
+			})
+		}
+		printTable(columns, rows)
+		fmt.Println()
+	}
+
+	if report.DryRun {
+		fmt.Printf("Dry run: %d to book, %d to backfill", countImports(report.Imports, importWouldBook), countImports(report.Imports, importWouldFill))
+	} else {
+		fmt.Printf("✓ %d booked, %d backfilled", countImports(report.Imports, importBooked), countImports(report.Imports, importBackfilled))
+	}
+	fmt.Printf(", %d already booked, %d conflicts\n", countImports(report.Imports, importExisting), countImports(report.Imports, importConflict))
+	if skipped := report.Skipped.AllDay + report.Skipped.NoRoom; skipped > 0 {
+		fmt.Printf("  Skipped %d all-day events and %d events without a room\n", report.Skipped.AllDay, report.Skipped.NoRoom)
+	}
+	if admin := countImports(report.Imports, importNeedsAdmin); admin > 0 {
+		fmt.Printf("⚠ %d past events need an admin to backfill them\n", admin)
+	}
+
+	if len(report.UnmappedRooms) > 0 {
+		names := make([]string, 0, len(report.UnmappedRooms))
+		for name := range report.UnmappedRooms {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Println("\nCalendar rooms missing from the room mapping:")
+		for _, name := range names {
+			fmt.Printf("  %q  (%d events)\n", name, report.UnmappedRooms[name])
+		}
+	}
+	if len(report.UnknownRooms) > 0 {
+		names := make([]string, 0, len(report.UnknownRooms))
+		for name := range report.UnknownRooms {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Println("\nMapping entries that name no Miles room:")
+		for _, name := range names {
+			fmt.Printf("  %q: %s\n", name, report.UnknownRooms[name])
+		}
+	}
+}
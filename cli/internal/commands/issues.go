@@ -0,0 +1,393 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var reportIssueCmd = &cobra.Command{
+	Use:   "issue ROOM",
+	Short: "Report something wrong with a room",
+	Long: `Report something wrong with a room, such as a dead projector or a broken
+chair. The location's managers get an email and the issue joins their
+queue ('miles admin issues'); follow it with 'miles report issues'.
+
+--severity is low, medium (the default) or high. --attach describes a photo
+or other file: its name, type and size go with the report, but the file
+itself isn't uploaded, so keep it to hand for whoever fixes the issue.
+
+Examples:
+  miles report issue ROOM123 --desc "projector dead" --severity high
+  miles report issue ROOM123 -d "wobbly table" --attach table.jpg`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRoomIDs,
+	RunE:              runReportIssue,
+}
+
+var reportIssuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "List the room issues you reported and where they are",
+	Long: `List the room issues you reported, newest first, with their status:
+open, in progress, resolved or dismissed. Managers note what was done when
+they move an issue along; it's shown as the note.
+
+Examples:
+  miles report issues
+  miles report issues --status open`,
+	Args: cobra.NoArgs,
+	RunE: runReportIssues,
+}
+
+var adminIssuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "Work the queue of room issues (admins and managers)",
+	Long: `Issues reported in a location's rooms, most severe first and then oldest
+first. The queue holds the issues still open or in progress; --status lists
+those with another status. Moving an issue along needs a note saying what
+was done, and the reporter is emailed.
+
+LOCATION is a location ID or name. STATUS is open, in-progress, resolved or
+dismissed.
+
+Examples:
+  miles admin issues list Oslo
+  miles admin issues list Oslo --status resolved
+  miles admin issues update ISSUE123 in-progress -m "Facilities are on it"
+  miles admin issues update ISSUE123 resolved -m "Replaced the projector lamp"`,
+}
+
+var adminIssuesListCmd = &cobra.Command{
+	Use:               "list LOCATION",
+	Short:             "List a location's room issues",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminIssuesList,
+}
+
+var adminIssuesUpdateCmd = &cobra.Command{
+	Use:   "update ISSUE STATUS",
+	Short: "Move a room issue along, saying what was done",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runAdminIssuesUpdate,
+}
+
+var (
+	issueDescription string
+	issueSeverity    string
+	issueAttachments []string
+	issueStatus      string
+	issueComment     string
+)
+
+func init() {
+	reportIssueCmd.Flags().StringVarP(&issueDescription, "desc", "d", "", "what's wrong (required)")
+	reportIssueCmd.Flags().StringVarP(&issueSeverity, "severity", "s", "medium", "low, medium or high")
+	reportIssueCmd.Flags().StringArrayVarP(&issueAttachments, "attach", "a", nil, "photo or file describing the issue; only its name, type and size are sent (repeatable)")
+	reportIssueCmd.MarkFlagRequired("desc")
+	reportIssueCmd.RegisterFlagCompletionFunc("severity", cobra.FixedCompletions([]string{"low", "medium", "high"}, cobra.ShellCompDirectiveNoFileComp))
+
+	reportIssuesCmd.Flags().StringVar(&issueStatus, "status", "", "only issues with this status: open, in-progress, resolved or dismissed")
+	adminIssuesListCmd.Flags().StringVar(&issueStatus, "status", "", "issues with this status instead of those open or in progress")
+	adminIssuesUpdateCmd.Flags().StringVarP(&issueComment, "message", "m", "", "what was done, emailed to the reporter (required)")
+	adminIssuesUpdateCmd.MarkFlagRequired("message")
+
+	reportCmd.AddCommand(reportIssueCmd)
+	reportCmd.AddCommand(reportIssuesCmd)
+	adminIssuesCmd.AddCommand(adminIssuesListCmd)
+	adminIssuesCmd.AddCommand(adminIssuesUpdateCmd)
+	adminCmd.AddCommand(adminIssuesCmd)
+}
+
+// parseIssueSeverity parses low, medium or high
+func parseIssueSeverity(input string) (milesapi.FeedbackSeverity, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "low":
+		return milesapi.LOW, nil
+	case "medium", "":
+		return milesapi.MEDIUM, nil
+	case "high":
+		return milesapi.HIGH, nil
+	}
+	return "", fmt.Errorf("unknown severity %q: use low, medium or high", input)
+}
+
+// parseIssueStatus parses open, in-progress, resolved or dismissed. An
+// empty input stays empty, for the server's default.
+func parseIssueStatus(input string) (milesapi.FeedbackStatus, error) {
+	switch strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(input)) {
+	case "":
+		return "", nil
+	case "open":
+		return milesapi.OPEN, nil
+	case "inprogress":
+		return milesapi.INPROGRESS, nil
+	case "resolved":
+		return milesapi.RESOLVED, nil
+	case "dismissed":
+		return milesapi.DISMISSED, nil
+	}
+	return "", fmt.Errorf("unknown status %q: use open, in-progress, resolved or dismissed", input)
+}
+
+// describeIssueStatus formats a status for people, e.g. "in progress"
+func describeIssueStatus(status milesapi.FeedbackStatus) string {
+	return strings.ReplaceAll(strings.ToLower(string(status)), "_", " ")
+}
+
+// describeAttachment reads a file's name, type and size. The type comes
+// from the extension, or the file's first bytes when that's unknown.
+func describeAttachment(path string) (milesapi.FeedbackAttachmentInput, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return milesapi.FeedbackAttachmentInput{}, fmt.Errorf("attachment: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return milesapi.FeedbackAttachmentInput{}, fmt.Errorf("attachment: %w", err)
+	}
+	if info.IsDir() {
+		return milesapi.FeedbackAttachmentInput{}, fmt.Errorf("attachment %s is a directory", path)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		head := make([]byte, 512)
+		n, _ := io.ReadFull(file, head)
+		contentType = http.DetectContentType(head[:n])
+	}
+
+	return milesapi.FeedbackAttachmentInput{
+		FileName:    filepath.Base(path),
+		ContentType: contentType,
+		Size:        int(info.Size()),
+	}, nil
+}
+
+// describeSize formats a size in bytes, e.g. "471 KB"
+func describeSize(size int) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%d KB", size>>10)
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+func runReportIssue(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	description := strings.TrimSpace(issueDescription)
+	if description == "" {
+		return fmt.Errorf("--desc is required: say what's wrong with the room")
+	}
+	severity, err := parseIssueSeverity(issueSeverity)
+	if err != nil {
+		return err
+	}
+	input := milesapi.FeedbackInput{RoomId: args[0], Message: description, Severity: &severity}
+	if len(issueAttachments) > 0 {
+		attachments := make([]milesapi.FeedbackAttachmentInput, 0, len(issueAttachments))
+		for _, path := range issueAttachments {
+			attachment, err := describeAttachment(path)
+			if err != nil {
+				return err
+			}
+			attachments = append(attachments, attachment)
+		}
+		input.Attachments = &attachments
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	issue, err := client.ReportIssue(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(issue)
+	}
+
+	fmt.Printf("✓ Reported %s issue in %s (%s)\n", strings.ToLower(string(issue.Severity)), issue.Room.Name, issue.Id)
+	for _, attachment := range issue.Attachments {
+		fmt.Printf("  📎 %s (%s, %s)\n", attachment.FileName, attachment.ContentType, describeSize(attachment.Size))
+	}
+	fmt.Println("The location's managers have been told. Follow it with 'miles report issues'.")
+	return nil
+}
+
+func runReportIssues(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	status, err := parseIssueStatus(issueStatus)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	issues, err := client.GetMyIssues(ctx, status)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(issues)
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("You haven't reported any issues. Report one with 'miles report issue ROOM --desc ...'.")
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 1},
+		{header: "ROOM", width: 20, minWidth: 8, priority: 5},
+		{header: "REPORTED", width: 16, priority: 3},
+		{header: "SEVERITY", width: 8, priority: 2},
+		{header: "STATUS", width: 11, priority: 6},
+		{header: "ISSUE", width: 30, minWidth: 10, priority: 7},
+		{header: "NOTE", width: 30, minWidth: 10, priority: 4},
+	}
+	var rows [][]string
+	for _, issue := range issues {
+		rows = append(rows, []string{
+			issue.Id,
+			issue.Room.Name,
+			issue.CreatedAt.Local().Format("Mon Jan 2 15:04"),
+			strings.ToLower(string(issue.Severity)),
+			describeIssueStatus(issue.Status),
+			issue.Message,
+			derefString(issue.ResolutionComment),
+		})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminIssuesList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	status, err := parseIssueStatus(issueStatus)
+	if err != nil {
+		return err
+	}
+
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	issues, err := client.GetLocationIssues(ctx, derefString(location.Id), status)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(issues)
+	}
+
+	if len(issues) == 0 {
+		if status == "" {
+			fmt.Printf("No open issues at %s.\n", derefString(location.Name))
+		} else {
+			fmt.Printf("No %s issues at %s.\n", describeIssueStatus(status), derefString(location.Name))
+		}
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 7},
+		{header: "SEVERITY", width: 8, priority: 6},
+		{header: "STATUS", width: 11, priority: 5},
+		{header: "ROOM", width: 20, minWidth: 8, priority: 8},
+		{header: "REPORTED", width: 16, priority: 3},
+		{header: "BY", width: 20, minWidth: 8, priority: 2},
+		{header: "FILES", width: 5, priority: 1},
+		{header: "ISSUE", width: 36, minWidth: 10, priority: 9},
+	}
+	var rows [][]string
+	for _, issue := range issues {
+		rows = append(rows, []string{
+			issue.Id,
+			strings.ToLower(string(issue.Severity)),
+			describeIssueStatus(issue.Status),
+			issue.Room.Name,
+			issue.CreatedAt.Local().Format("Mon Jan 2 15:04"),
+			strings.TrimSpace(derefString(issue.User.FirstName) + " " + derefString(issue.User.LastName)),
+			strconv.Itoa(len(issue.Attachments)),
+			issue.Message,
+		})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminIssuesUpdate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+	if err := requireRole(token, milesapi.MANAGER); err != nil {
+		return err
+	}
+
+	status, err := parseIssueStatus(args[1])
+	if err != nil {
+		return err
+	}
+	if status == "" {
+		return fmt.Errorf("STATUS is required: open, in-progress, resolved or dismissed")
+	}
+	comment := strings.TrimSpace(issueComment)
+	if comment == "" {
+		return fmt.Errorf("--message is required: say what was done")
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	issue, err := client.UpdateIssueStatus(ctx, args[0], milesapi.FeedbackStatusUpdate{Status: status, Comment: comment})
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(issue)
+	}
+	fmt.Printf("✓ %s issue in %s is %s\n", strings.ToLower(string(issue.Severity)), issue.Room.Name, describeIssueStatus(issue.Status))
+	fmt.Println("The reporter has been emailed.")
+	return nil
+}
@@ -0,0 +1,376 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var kioskCmd = &cobra.Command{
+	Use:   "kiosk",
+	Short: "Show live room status for a wall-mounted screen",
+	Long: `Fill the terminal with a live overview of every room at a location:
+whether it is free or occupied and when that changes next.
+
+The kiosk is read-only. It shows no meeting titles or names and takes no
+keyboard input apart from Ctrl+C, so it is safe to leave running on a shared
+screen logged in as a kiosk account.
+
+--location matches a location ID, name or city (case-insensitive).
+
+Examples:
+  miles kiosk --location OSLO
+  miles kiosk -l stavanger --interval 1m`,
+	RunE: runKiosk,
+}
+
+var (
+	kioskLocation string
+	kioskInterval time.Duration
+)
+
+// kioskSoon is how close the next booking must be for a free room to be
+// shown as about to be taken
+const kioskSoon = 15 * time.Minute
+
+func init() {
+	kioskCmd.Flags().StringVarP(&kioskLocation, "location", "l", "", "location ID, name or city to show (required)")
+	kioskCmd.Flags().DurationVar(&kioskInterval, "interval", 30*time.Second, "how often to refresh bookings")
+	kioskCmd.MarkFlagRequired("location")
+
+	kioskCmd.RegisterFlagCompletionFunc("location", completeLocationIDs)
+}
+
+// kioskRoom is a room and today's bookings for it
+type kioskRoom struct {
+	name     string
+	location string
+	bookings []milesapi.Booking
+}
+
+func runKiosk(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	locations, err := findKioskLocations(ctx, client, kioskLocation)
+	if err != nil {
+		return err
+	}
+
+	rooms, err := loadKioskRooms(ctx, client, locations)
+	if err != nil {
+		return err
+	}
+	updated := time.Now()
+	var refreshErr error
+
+	// Take over the screen until interrupted
+	fmt.Print("\033[?1049h\033[?25l")
+	defer fmt.Print("\033[?25h\033[?1049l")
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	clock := time.NewTicker(time.Second)
+	defer clock.Stop()
+	refresh := time.NewTicker(kioskInterval)
+	defer refresh.Stop()
+
+	for {
+		// Redraw in place, clearing leftovers, so the screen doesn't flicker
+		frame := renderKiosk(locations, rooms, config.ServerNow(), updated, refreshErr)
+		fmt.Print("\033[H" + strings.ReplaceAll(frame, "\n", "\033[K\n") + "\033[K\033[J")
+
+		select {
+		case <-stop:
+			return nil
+		case <-clock.C:
+		case <-refresh.C:
+			// Keep showing the last data when a refresh fails
+			if fresh, err := loadKioskRooms(ctx, client, locations); err != nil {
+				refreshErr = err
+			} else {
+				rooms, updated, refreshErr = fresh, time.Now(), nil
+			}
+		}
+	}
+}
+
+// findKioskLocations returns the locations matching an ID, name or city
+func findKioskLocations(ctx context.Context, client config.API, query string) ([]milesapi.Location, error) {
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	var matched []milesapi.Location
+	for _, location := range locations {
+		id, name, city := derefString(location.Id), derefString(location.Name), derefString(location.City)
+		names = append(names, name)
+		if strings.EqualFold(query, id) || strings.EqualFold(query, name) || strings.EqualFold(query, city) {
+			matched = append(matched, location)
+		}
+	}
+
+	if len(matched) == 0 {
+		sort.Strings(names)
+		return nil, fmt.Errorf("no location matches %q (available: %s)", query, strings.Join(names, ", "))
+	}
+	return matched, nil
+}
+
+// loadKioskRooms fetches the rooms at the locations and today's bookings for each
+func loadKioskRooms(ctx context.Context, client config.API, locations []milesapi.Location) ([]kioskRoom, error) {
+	var rooms []kioskRoom
+	for _, location := range locations {
+		locationRooms, err := client.GetRooms(ctx, derefString(location.Id))
+		if err != nil {
+			return nil, err
+		}
+		for _, room := range locationRooms {
+			if room.Id == nil || room.IsActive != nil && !*room.IsActive {
+				continue
+			}
+			bookings, err := loadTodaysBookings(ctx, client, *room.Id)
+			if err != nil {
+				return nil, err
+			}
+			rooms = append(rooms, kioskRoom{
+				name:     derefString(room.Name),
+				location: derefString(location.Name),
+				bookings: bookings,
+			})
+		}
+	}
+
+	sort.SliceStable(rooms, func(i, j int) bool {
+		if rooms[i].location != rooms[j].location {
+			return rooms[i].location < rooms[j].location
+		}
+		return rooms[i].name < rooms[j].name
+	})
+	return rooms, nil
+}
+
+// loadTodaysBookings returns a room's active bookings for today in start order
+func loadTodaysBookings(ctx context.Context, client config.API, roomID string) ([]milesapi.Booking, error) {
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	bookings, err := client.CheckRoomAvailability(ctx, roomID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(bookings, func(i, j int) bool {
+		return bookings[i].StartTime.Before(*bookings[j].StartTime)
+	})
+	return bookings, nil
+}
+
+// kioskStatus describes what a room is doing now and when that changes
+type kioskStatus struct {
+	label  string
+	detail string
+	color  string
+}
+
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiDim    = "\033[2m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+)
+
+// roomStatus works out whether a room is free or occupied at now. Back-to-back
+// bookings count as one occupied stretch.
+func roomStatus(bookings []milesapi.Booking, now time.Time) kioskStatus {
+	for i, booking := range bookings {
+		if booking.StartTime.After(now) {
+			status := kioskStatus{label: "FREE", color: ansiGreen,
+				detail: "until " + booking.StartTime.Local().Format("15:04")}
+			if booking.StartTime.Sub(now) <= kioskSoon {
+				status.label, status.color = "BOOKED SOON", ansiYellow
+				status.detail = "from " + booking.StartTime.Local().Format("15:04")
+			}
+			return status
+		}
+		if booking.EndTime.After(now) {
+			freeAt := *booking.EndTime
+			for _, next := range bookings[i+1:] {
+				if next.StartTime.After(freeAt) {
+					break
+				}
+				if next.EndTime.After(freeAt) {
+					freeAt = *next.EndTime
+				}
+			}
+			return kioskStatus{label: "OCCUPIED", color: ansiRed,
+				detail: "free at " + freeAt.Local().Format("15:04")}
+		}
+	}
+	return kioskStatus{label: "FREE", color: ansiGreen, detail: "rest of the day"}
+}
+
+// renderKiosk draws the whole screen
+func renderKiosk(locations []milesapi.Location, rooms []kioskRoom, now, updated time.Time, refreshErr error) string {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		width, height = 80, 24
+	}
+
+	var b strings.Builder
+
+	names := make([]string, len(locations))
+	for i, location := range locations {
+		names[i] = derefString(location.Name)
+	}
+	title := strings.ToUpper(strings.Join(names, " · "))
+
+	// Big clock on the right when there's room for it
+	clock := bigText(now.Format("15:04"))
+	clockWidth := utf8.RuneCountInString(clock[0])
+	if width >= utf8.RuneCountInString(title)+clockWidth+4 && height >= 12 {
+		for i, line := range clock {
+			left, visible := "", 0
+			if i == 1 {
+				left, visible = ansiBold+title+ansiReset, utf8.RuneCountInString(title)
+			}
+			b.WriteString(" " + padVisible(left, visible, width-clockWidth-2) + line + "\n")
+		}
+	} else {
+		b.WriteString(" " + ansiBold + title + ansiReset + "  " + now.Format("15:04") + "\n")
+	}
+	b.WriteString("\n")
+
+	if len(rooms) == 0 {
+		b.WriteString(" No rooms at this location\n")
+	}
+
+	// Room tiles, as many per row as fit
+	const tileWidth = 28
+	perRow := max(1, (width-1)/(tileWidth+1))
+	for start := 0; start < len(rooms); start += perRow {
+		row := rooms[start:min(start+perRow, len(rooms))]
+		tiles := make([][]string, len(row))
+		for i, room := range row {
+			tiles[i] = renderKioskTile(room, roomStatus(room.bookings, now), len(locations) > 1, tileWidth)
+		}
+		for line := range tiles[0] {
+			b.WriteString(" ")
+			for _, tile := range tiles {
+				b.WriteString(tile[line] + " ")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	footer := " Updated " + updated.Format("15:04:05")
+	if refreshErr != nil {
+		footer = ansiYellow + fmt.Sprintf(" ⚠ Could not refresh (showing data from %s)", updated.Format("15:04")) + ansiReset
+	}
+	b.WriteString(ansiDim + footer + ansiReset)
+
+	return b.String()
+}
+
+// renderKioskTile draws one room as a bordered box coloured by its status
+func renderKioskTile(room kioskRoom, status kioskStatus, showLocation bool, width int) []string {
+	inner := width - 4
+	line := func(text string, style string) string {
+		// Count runes, not bytes, so names like "Ærlig" line up
+		if runes := []rune(text); len(runes) > inner {
+			text = string(runes[:inner-1]) + "…"
+		}
+		return status.color + "│ " + ansiReset + style + text + ansiReset +
+			strings.Repeat(" ", inner-utf8.RuneCountInString(text)) + status.color + " │" + ansiReset
+	}
+
+	tile := []string{
+		status.color + "┌" + strings.Repeat("─", width-2) + "┐" + ansiReset,
+		line(strings.ToUpper(room.name), ansiBold),
+		line("● "+status.label, ansiBold+status.color),
+		line(status.detail, ""),
+		status.color + "└" + strings.Repeat("─", width-2) + "┘" + ansiReset,
+	}
+	if showLocation {
+		// Tell rooms with the same name at different locations apart
+		tile = append(tile[:4], line(room.location, ansiDim), tile[4])
+	}
+	return tile
+}
+
+// padVisible pads s, whose visible width is visible, to width columns
+func padVisible(s string, visible, width int) string {
+	if visible >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-visible)
+}
+
+// bigDigits is a three-line block font for the clock
+var bigDigits = map[rune][3]string{
+	'0': {"█▀█", "█ █", "█▄█"},
+	'1': {" ▀█", "  █", "  █"},
+	'2': {"▀▀█", "█▀▀", "█▄▄"},
+	'3': {"▀▀█", " ▀█", "▄▄█"},
+	'4': {"█ █", "▀▀█", "  █"},
+	'5': {"█▀▀", "▀▀█", "▄▄█"},
+	'6': {"█▀▀", "█▀█", "█▄█"},
+	'7': {"▀▀█", "  █", "  █"},
+	'8': {"█▀█", "█▀█", "█▄█"},
+	'9': {"█▀█", "▀▀█", "▄▄█"},
+	':': {" ", "▀", "▀"},
+}
+
+// bigText renders digits and colons in the block font
+func bigText(s string) []string {
+	lines := make([]string, 3)
+	for i, r := range s {
+		glyph, ok := bigDigits[r]
+		if !ok {
+			continue
+		}
+		for row := range lines {
+			if i > 0 {
+				lines[row] += " "
+			}
+			lines[row] += glyph[row]
+		}
+	}
+	return lines
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
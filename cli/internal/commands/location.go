@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var locationCmd = &cobra.Command{
+	Use:     "location",
+	Aliases: []string{"locations"},
+	Short:   "Show office locations and the services they offer",
+}
+
+var locationShowCmd = &cobra.Command{
+	Use:   "show LOCATION",
+	Short: "Show a location, its rooms and its services directory",
+	Long: `Show a location's address and settings, how many rooms it has, the days
+it is closed in the next three months, and its services directory: parking, lockers, bike room and the like, with how many
+there are and who to contact.
+
+Reservable services are booked like rooms, through the room they name; the
+BOOK column shows the command. LOCATION is a location ID or name.
+
+Examples:
+  miles location show Oslo
+  miles location show Oslo -o json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runLocationShow,
+}
+
+func init() {
+	locationCmd.AddCommand(locationShowCmd)
+}
+
+// locationDetails is the -o json form of miles location show
+type locationDetails struct {
+	Location milesapi.Location          `json:"location"`
+	Rooms    int                        `json:"rooms"`
+	Holidays []milesapi.LocationHoliday `json:"holidays"`
+	Services []milesapi.LocationService `json:"services"`
+}
+
+// showHolidaysAhead is how far ahead miles location show lists holidays
+const showHolidaysAhead = 90 * 24 * time.Hour
+
+func runLocationShow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		return err
+	}
+	i := locationIndex(locations, args[0])
+	if i < 0 {
+		return fmt.Errorf("no location matches %q. Run 'miles rooms' to list locations", args[0])
+	}
+	location := locations[i]
+	locationID := derefString(location.Id)
+
+	rooms, err := client.GetRooms(ctx, locationID)
+	if err != nil {
+		return err
+	}
+	services, err := client.GetLocationServices(ctx, locationID)
+	if err != nil {
+		return err
+	}
+	// Servers without holidays just show none
+	var holidays []milesapi.LocationHoliday
+	now := time.Now()
+	if result, err := client.GetLocationHolidays(ctx, locationID, now.Format(time.DateOnly), now.Add(showHolidaysAhead).Format(time.DateOnly)); err == nil {
+		holidays = result.Holidays
+	}
+
+	if output == "json" {
+		return outputJSON(locationDetails{Location: location, Rooms: len(rooms), Holidays: holidays, Services: services})
+	}
+
+	fmt.Println(derefString(location.Name))
+	if address := derefString(location.Address); address != "" {
+		fmt.Printf("  Address:   %s, %s, %s\n", address, derefString(location.City), derefString(location.Country))
+	}
+	if timezone := derefString(location.Timezone); timezone != "" {
+		fmt.Printf("  Time zone: %s\n", timezone)
+	}
+	fmt.Printf("  Rooms:     %d\n", len(rooms))
+	if location.RequiresApproval != nil && *location.RequiresApproval {
+		fmt.Println("  Approval:  bookings wait for a manager unless a rule covers them")
+	}
+	if location.LateCancelMinutes != nil {
+		fmt.Printf("  Late:      cancelling less than %s before the start counts as late\n",
+			formatDuration(time.Duration(*location.LateCancelMinutes)*time.Minute))
+	}
+	if location.ClosedWeekdays != nil && len(*location.ClosedWeekdays) > 0 {
+		fmt.Printf("  Closed:    every %s\n", describeWeekdays(*location.ClosedWeekdays))
+	}
+	for i, holiday := range holidays {
+		label := ""
+		if i == 0 {
+			label = "Holidays:"
+		}
+		date := holiday.Date
+		if day, err := time.Parse(time.DateOnly, holiday.Date); err == nil {
+			date = day.Format("Mon Jan 2")
+		}
+		fmt.Printf("  %-10s %s  %s\n", label, date, holiday.Name)
+	}
+	if description := derefString(location.Description); description != "" {
+		fmt.Printf("\n  %s\n", description)
+	}
+	fmt.Println()
+
+	if len(services) == 0 {
+		fmt.Println("No services listed.")
+		fmt.Printf("Managers list them with: miles admin services add %q NAME --category parking\n", derefString(location.Name))
+		return nil
+	}
+
+	roomNames := make(map[string]string)
+	for _, room := range rooms {
+		roomNames[derefString(room.Id)] = derefString(room.Name)
+	}
+
+	columns := []tableColumn{
+		{header: "SERVICE", width: 24, minWidth: 12, priority: 6},
+		{header: "CATEGORY", width: 10, priority: 4},
+		{header: "QTY", width: 4, priority: 3},
+		{header: "CONTACT", width: 24, minWidth: 10, priority: 2},
+		{header: "BOOK", width: 36, minWidth: 16, priority: 5},
+		{header: "DESCRIPTION", width: 30, minWidth: 10, priority: 1},
+	}
+	var rows [][]string
+	for _, service := range services {
+		rows = append(rows, []string{
+			service.Name,
+			string(service.Category),
+			serviceQuantity(service),
+			derefString(service.Contact),
+			serviceBooking(service, roomNames),
+			derefString(service.Description),
+		})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+// serviceQuantity shows how many of a service there are, or nothing when
+// it isn't counted
+func serviceQuantity(service milesapi.LocationService) string {
+	if service.Quantity == nil {
+		return ""
+	}
+	return strconv.Itoa(*service.Quantity)
+}
+
+// serviceBooking shows how to reserve a service: the room to book, or
+// nothing when it isn't reservable
+func serviceBooking(service milesapi.LocationService, roomNames map[string]string) string {
+	roomID := derefString(service.RoomId)
+	if roomID == "" {
+		return ""
+	}
+	if name, ok := roomNames[roomID]; ok {
+		return fmt.Sprintf("miles book -r %s (%s)", roomID, name)
+	}
+	return "miles book -r " + roomID
+}
@@ -5,7 +5,7 @@ import (
 	"os"
 	"syscall"
 
-	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-cli/internal/keychain"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/term"
@@ -15,7 +15,14 @@ var loginCmd = &cobra.Command{
 	Use:   "login [email]",
 	Short: "Authenticate with the Miles booking system",
 	Long: `Login to the Miles booking system and save your authentication token.
-The token will be stored in your config file (~/.miles-cli.yaml) for future use.
+The token is stored in the system keychain (macOS Keychain, Windows
+Credential Manager or the Secret Service on Linux), one per server, and
+any plaintext copy in the config file is removed.
+
+Where there is no keychain, as on most CI runners, or with --no-keyring
+(env: MILES_NO_KEYRING=1), the token goes in the config file
+(~/.miles-cli.yaml) instead, readable only by you. --token and
+MILES_TOKEN always win over a stored token.
 
 Examples:
   miles login user@example.com
@@ -31,6 +38,7 @@ func init() {
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	// Get email from args or flag
 	email := loginEmail
 	if len(args) > 0 {
@@ -53,33 +61,25 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	password := string(passwordBytes)
 
 	// Create API client
-	client := config.NewClient(getAPIURL(), "")
+	client, err := newAPIClient(ctx, "")
+	if err != nil {
+		return err
+	}
+	defer client.Close()
 
 	// Attempt login
-	result, err := client.Login(email, password)
+	result, err := client.Login(ctx, email, password)
 	if err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
 
-	// Save token to config
-	viper.Set("token", result.Token)
-
-	// Get or create config file
-	configFile := viper.ConfigFileUsed()
-	if configFile == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
-		configFile = home + "/.miles-cli.yaml"
-	}
-
-	if err := viper.WriteConfigAs(configFile); err != nil {
+	where, err := saveToken(result.Token)
+	if err != nil {
 		return fmt.Errorf("failed to save token: %w", err)
 	}
 
 	fmt.Printf("✓ Login successful!\n")
-	fmt.Printf("✓ Token saved to %s\n", configFile)
+	fmt.Printf("✓ Token saved to %s\n", where)
 	if result.User != nil {
 		name := ""
 		if result.User.FirstName != nil {
@@ -92,3 +92,59 @@ func runLogin(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// The keychain is asked once per run: it can be a round trip to the
+// credential store, or a prompt to unlock it
+var (
+	keychainAsked  bool
+	keychainCached string
+)
+
+// keychainToken returns the token stored in the keychain for this server,
+// or "" when there is none or the keychain is off or unavailable
+func keychainToken() string {
+	if viper.GetBool("no_keyring") {
+		return ""
+	}
+	if !keychainAsked {
+		keychainAsked = true
+		keychainCached, _ = keychain.Token(serverTarget())
+	}
+	return keychainCached
+}
+
+// saveToken stores the token in the keychain and removes any plaintext
+// copy from the config file. Without a keychain, or with --no-keyring, the
+// token goes in the config file instead. It returns where the token went.
+func saveToken(token string) (string, error) {
+	path := configFilePath()
+	local, err := readConfigFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	server := serverTarget()
+	if !viper.GetBool("no_keyring") {
+		err := keychain.SetToken(server, token)
+		if err == nil {
+			keychainAsked, keychainCached = true, token
+			if _, ok := local["token"]; ok {
+				delete(local, "token")
+				if err := writeConfigFile(path, local); err != nil {
+					return "", err
+				}
+				fmt.Printf("✓ Removed the plaintext token from %s\n", path)
+			}
+			return "the system keychain", nil
+		}
+		fmt.Fprintf(os.Stderr, "⚠ %v; using the config file instead\n", err)
+	}
+
+	// A token left in the keychain would win over this one
+	keychain.DeleteToken(server)
+	local["token"] = token
+	if err := writeConfigFile(path, local); err != nil {
+		return "", err
+	}
+	return path, nil
+}
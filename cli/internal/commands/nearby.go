@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// findNearbyRooms returns up to limit free rooms near the given one, as
+// milesapi.NearbyRooms ranks them. Failures return nothing; the
+// suggestions are only a hint.
+func findNearbyRooms(ctx context.Context, client config.API, room milesapi.Room, start, end time.Time, limit int) []milesapi.Room {
+	rooms, err := client.GetRooms(ctx, derefString(room.LocationId))
+	if err != nil {
+		return nil
+	}
+
+	boundary := bookingBoundary()
+	return milesapi.NearbyRooms(room, rooms, start, end, limit, func(other milesapi.Room) bool {
+		busy, err := client.CheckRoomAvailability(ctx, derefString(other.Id), start, end)
+		return err == nil && len(boundary.Conflicting(busy, start, end)) == 0
+	})
+}
+
+// printNearbyRooms suggests free rooms near a busy one
+func printNearbyRooms(room milesapi.Room, nearby []milesapi.Room) {
+	if len(nearby) == 0 {
+		return
+	}
+
+	fmt.Printf("Free rooms nearby:\n")
+	for _, other := range nearby {
+		details := []string{}
+		if where := milesapi.DescribeNearness(room, other); where != "" {
+			details = append(details, where)
+		}
+		if other.Capacity != nil {
+			details = append(details, fmt.Sprintf("%d seats", *other.Capacity))
+		}
+		fmt.Printf("  - %s [%s]  (%s)\n", derefString(other.Name), derefString(other.Id), strings.Join(details, ", "))
+	}
+	fmt.Println()
+}
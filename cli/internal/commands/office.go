@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/office"
+	"github.com/spf13/viper"
+)
+
+// officeDetectTimeout bounds office detection before the location prompt
+const officeDetectTimeout = 3 * time.Second
+
+// locationIndex returns the index of the location with this ID or name,
+// or -1
+func locationIndex(locations []milesapi.Location, query string) int {
+	for i, location := range locations {
+		if strings.EqualFold(query, derefString(location.Id)) || strings.EqualFold(query, derefString(location.Name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// detectOffice returns the index of the location the user appears to be
+// at according to the offices file, and why, or -1 when it can't tell
+func detectOffice(locations []milesapi.Location) (int, *office.Match) {
+	cfg, err := office.Load(viper.GetString("offices_file"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Office detection disabled: %v\n", err)
+		return -1, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), officeDetectTimeout)
+	defer cancel()
+
+	match := cfg.Detect(ctx)
+	if match == nil {
+		return -1, nil
+	}
+	i := locationIndex(locations, match.Location)
+	if i < 0 {
+		fmt.Fprintf(os.Stderr, "⚠ Detected office %q (%s) is not a known location\n", match.Location, match.Reason)
+	}
+	return i, match
+}
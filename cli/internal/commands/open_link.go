@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/miles/booking-tui/pkg/deeplink"
+	"github.com/spf13/cobra"
+)
+
+var openLinkCmd = &cobra.Command{
+	Use:   "open-link LINK",
+	Short: "Open a booking link in the TUI",
+	Long: `Open the booking a link points at in the miles-booking TUI, on its details.
+LINK is a miles://booking/<id> link from 'miles share' or the TUI, or a web
+link to the booking.
+
+--register makes this command the handler for miles:// links, so clicking
+one opens the TUI in a terminal. It writes a desktop entry on Linux and a
+per-user registry key on Windows. macOS only hands URL schemes to app
+bundles, so there the link has to be passed to 'miles open-link'.
+
+Examples:
+  miles open-link miles://booking/BOOK123
+  miles open-link --register`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if openLinkRegister {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runOpenLink,
+}
+
+var openLinkRegister bool
+
+// tuiBinary is the TUI's executable name
+const tuiBinary = "miles-booking"
+
+// linkDesktopEntry is the desktop entry --register writes on Linux
+const linkDesktopEntry = "miles-open-link.desktop"
+
+func init() {
+	openLinkCmd.Flags().BoolVar(&openLinkRegister, "register", false, "register this command as the handler for miles:// links")
+}
+
+func runOpenLink(cmd *cobra.Command, args []string) error {
+	if openLinkRegister {
+		return registerLinkHandler()
+	}
+
+	id, err := deeplink.ParseBooking(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	path, err := findTUI()
+	if err != nil {
+		return err
+	}
+
+	tui := exec.Command(path, "--booking", id)
+	tui.Stdin, tui.Stdout, tui.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return tui.Run()
+}
+
+// findTUI returns the path of miles-booking: on the PATH, or else next to
+// this executable as the install script puts them
+func findTUI() (string, error) {
+	if path, err := exec.LookPath(tuiBinary); err == nil {
+		return path, nil
+	}
+	if self, err := os.Executable(); err == nil {
+		name := tuiBinary
+		if runtime.GOOS == "windows" {
+			name += ".exe"
+		}
+		path := filepath.Join(filepath.Dir(self), name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not on your PATH", tuiBinary)
+}
+
+// registerLinkHandler makes `miles open-link` open miles:// links for the
+// current user
+func registerLinkHandler() error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot find this executable: %w", err)
+	}
+	if self, err = filepath.EvalSymlinks(self); err != nil {
+		return err
+	}
+
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		err = registerDesktopEntry(self)
+	case "windows":
+		err = registerURLProtocol(self)
+	case "darwin":
+		return fmt.Errorf("macOS only hands %s:// links to app bundles; open them with 'miles open-link LINK'", deeplink.Scheme)
+	default:
+		return fmt.Errorf("registering a link handler is not supported on %s", runtime.GOOS)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s:// links now open in %s\n", deeplink.Scheme, tuiBinary)
+	return nil
+}
+
+// registerDesktopEntry writes a desktop entry handling miles:// links in a
+// terminal and makes it the default handler with xdg-mime
+func registerDesktopEntry(self string) error {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	dir := filepath.Join(dataHome, "applications")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	mimeType := "x-scheme-handler/" + deeplink.Scheme
+	entry := fmt.Sprintf(`[Desktop Entry]
+Type=Application
+Name=Miles Booking
+Comment=Open booking links in the Miles TUI
+Exec=%q open-link %%u
+Terminal=true
+NoDisplay=true
+MimeType=%s;
+`, self, mimeType)
+	if err := os.WriteFile(filepath.Join(dir, linkDesktopEntry), []byte(entry), 0o644); err != nil {
+		return err
+	}
+
+	if out, err := exec.Command("xdg-mime", "default", linkDesktopEntry, mimeType).CombinedOutput(); err != nil {
+		return fmt.Errorf("wrote %s, but xdg-mime failed: %v %s", filepath.Join(dir, linkDesktopEntry), err, out)
+	}
+	return nil
+}
+
+// registerURLProtocol registers miles:// under HKEY_CURRENT_USER, which
+// needs no administrator rights
+func registerURLProtocol(self string) error {
+	key := `HKCU\Software\Classes\` + deeplink.Scheme
+	commands := [][]string{
+		{"add", key, "/ve", "/d", "URL:Miles Booking", "/f"},
+		{"add", key, "/v", "URL Protocol", "/d", "", "/f"},
+		{"add", key + `\shell\open\command`, "/ve", "/d", fmt.Sprintf(`"%s" open-link "%%1"`, self), "/f"},
+	}
+	for _, args := range commands {
+		if out, err := exec.Command("reg", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("reg %s: %v %s", args[1], err, out)
+		}
+	}
+	return nil
+}
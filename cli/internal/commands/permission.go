@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+// printPermissionHints follows a refused request with the role it needs,
+// the location's managers and a request ready to send an admin, so people
+// don't have to go back and forth with IT to find out. Lookups that fail
+// leave their part out.
+func printPermissionHints(cmd *cobra.Command, err error) {
+	ctx := cmd.Context()
+	var denied *config.PermissionError
+	if !errors.As(err, &denied) || len(denied.RequiredRoles) == 0 {
+		return
+	}
+
+	token := getAuthToken()
+	var client config.API
+	if token != "" {
+		client, _ = newAPIClient(ctx, token)
+	}
+	if client != nil {
+		defer client.Close()
+	}
+
+	// A location-scoped permission comes with managing that location
+	scoped := denied.LocationID != "" && slices.Contains(denied.RequiredRoles, string(milesapi.MANAGER))
+	place := denied.LocationID
+	var managers []milesapi.User
+	if scoped && client != nil {
+		place = locationName(ctx, client, denied.LocationID)
+		managers, _ = client.GetLocationManagers(ctx, denied.LocationID)
+	}
+
+	fmt.Fprintln(os.Stderr)
+	if scoped {
+		fmt.Fprintf(os.Stderr, "Hint: this needs the MANAGER role at %s (admins can do it anywhere).\n", place)
+	} else {
+		fmt.Fprintf(os.Stderr, "Hint: this needs the %s role.\n", strings.Join(denied.RequiredRoles, " or "))
+	}
+	if role := milesapi.TokenRole(token); role != "" {
+		fmt.Fprintf(os.Stderr, "You are signed in as %s.\n", role)
+	}
+
+	if len(managers) > 0 {
+		fmt.Fprintf(os.Stderr, "Managers of %s, who can do it for you:\n", place)
+		for _, manager := range managers {
+			fmt.Fprintf(os.Stderr, "  %s\n", managerContact(manager))
+		}
+	}
+
+	me := ""
+	if client != nil {
+		if user, err := client.GetCurrentUser(ctx); err == nil && user.Email != nil {
+			me = " (" + string(*user.Email) + ")"
+		}
+	}
+	grant := fmt.Sprintf("give me%s the %s role", me, strings.Join(denied.RequiredRoles, " or "))
+	if scoped {
+		grant = fmt.Sprintf("make me%s a manager of %s", me, place)
+	}
+	// Only admins assign roles and managers
+	fmt.Fprintln(os.Stderr, "To get access yourself, ask an admin:")
+	fmt.Fprintf(os.Stderr, "  Hi! Could you %s in Miles booking? I need it to run `%s`.\n", grant, cmd.CommandPath())
+}
+
+// locationName looks up a location's name, falling back to its ID
+func locationName(ctx context.Context, client config.API, locationID string) string {
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		return locationID
+	}
+	for _, location := range locations {
+		if location.Id != nil && *location.Id == locationID && location.Name != nil {
+			return *location.Name
+		}
+	}
+	return locationID
+}
+
+// managerContact formats a manager as "Kari Nordmann <kari@miles.no>"
+func managerContact(user milesapi.User) string {
+	name := activityUserName(user)
+	if user.Email == nil || name == string(*user.Email) {
+		return name
+	}
+	return name + " <" + string(*user.Email) + ">"
+}
@@ -0,0 +1,431 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/spf13/cobra"
+)
+
+var adminPriorityCmd = &cobra.Command{
+	Use:   "priority",
+	Short: "Manage who may bump bookings at a location (admins and its managers)",
+	Long: `A priority rule lets a user, such as an executive assistant, move other
+people's bookings at a location out of the way with 'miles bump'. The owner
+of a bumped booking is emailed, and every bump is kept in the location's
+displacement log.
+
+A rule only reaches bookings that start at least --notice away (default
+24h), and bookings of users who have a rule themselves can't be bumped.
+Admins and the location's managers may always bump. LOCATION is a location
+ID or name.
+
+Examples:
+  miles admin priority add Oslo ea@example.com --name "CEO office" --notice 48h
+  miles admin priority list Oslo
+  miles admin priority edit Oslo RULE_ID --notice 12h
+  miles admin priority disable Oslo RULE_ID
+  miles admin priority rm Oslo RULE_ID
+  miles admin priority log Oslo`,
+}
+
+var adminPriorityListCmd = &cobra.Command{
+	Use:               "list LOCATION",
+	Short:             "List a location's priority rules",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminPriorityList,
+}
+
+var adminPriorityAddCmd = &cobra.Command{
+	Use:               "add LOCATION EMAIL",
+	Short:             "Let a user bump bookings at a location",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminPriorityAdd,
+}
+
+var adminPriorityEditCmd = &cobra.Command{
+	Use:   "edit LOCATION RULE_ID",
+	Short: "Change a priority rule",
+	Long:  `Change a priority rule's name or notice. Only the flags given change.`,
+	Args:  cobra.ExactArgs(2),
+	RunE:  runAdminPriorityEdit,
+}
+
+var adminPriorityEnableCmd = &cobra.Command{
+	Use:   "enable LOCATION RULE_ID",
+	Short: "Enable a priority rule",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdminPriorityToggle(cmd.Context(), args, true)
+	},
+}
+
+var adminPriorityDisableCmd = &cobra.Command{
+	Use:   "disable LOCATION RULE_ID",
+	Short: "Disable a priority rule without deleting it",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdminPriorityToggle(cmd.Context(), args, false)
+	},
+}
+
+var adminPriorityRemoveCmd = &cobra.Command{
+	Use:     "rm LOCATION RULE_ID",
+	Aliases: []string{"remove", "delete"},
+	Short:   "Delete a priority rule",
+	Args:    cobra.ExactArgs(2),
+	RunE:    runAdminPriorityRemove,
+}
+
+var adminPriorityLogCmd = &cobra.Command{
+	Use:               "log LOCATION",
+	Short:             "Show the bookings bumped at a location, newest first",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminPriorityLog,
+}
+
+var bumpCmd = &cobra.Command{
+	Use:   "bump BOOKING_ID",
+	Short: "Move someone else's booking to make way for yours",
+	Long: `Move another person's booking to a new time and, optionally, another
+room, freeing its slot for a priority booking. Admins, the location's
+managers and users with a priority rule there may bump; the owner is
+emailed with the reason and the bump is logged.
+
+--end may be a time of day on the start's day or a duration.
+
+Examples:
+  miles bump BOOK123 --start "2025-10-20 15:00" --end 16:00 --reason "Board meeting"
+  miles bump BOOK123 --room ROOM456 --start "2025-10-20 09:00" --end 1h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBump,
+}
+
+var (
+	priorityName     string
+	priorityNotice   time.Duration
+	priorityDisabled bool
+
+	bumpRoomID string
+	bumpStart  string
+	bumpEnd    string
+	bumpReason string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{adminPriorityAddCmd, adminPriorityEditCmd} {
+		cmd.Flags().StringVar(&priorityName, "name", "", "describes the rule, e.g. \"CEO office\"")
+		cmd.Flags().DurationVar(&priorityNotice, "notice", 24*time.Hour, "only bump bookings starting at least this far ahead, in whole hours")
+	}
+	adminPriorityAddCmd.Flags().BoolVar(&priorityDisabled, "disabled", false, "add the rule switched off")
+	adminPriorityAddCmd.MarkFlagRequired("name")
+
+	adminPriorityCmd.AddCommand(adminPriorityListCmd)
+	adminPriorityCmd.AddCommand(adminPriorityAddCmd)
+	adminPriorityCmd.AddCommand(adminPriorityEditCmd)
+	adminPriorityCmd.AddCommand(adminPriorityEnableCmd)
+	adminPriorityCmd.AddCommand(adminPriorityDisableCmd)
+	adminPriorityCmd.AddCommand(adminPriorityRemoveCmd)
+	adminPriorityCmd.AddCommand(adminPriorityLogCmd)
+	adminCmd.AddCommand(adminPriorityCmd)
+
+	bumpCmd.Flags().StringVarP(&bumpRoomID, "room", "r", "", "room ID to move the booking to (default: the room it's in)")
+	bumpCmd.Flags().StringVarP(&bumpStart, "start", "s", "", `new start time (e.g. "2025-10-19 14:00")`)
+	bumpCmd.Flags().StringVarP(&bumpEnd, "end", "e", "", `new end time (e.g. "2025-10-19 15:00", "15:00" or "1h")`)
+	bumpCmd.Flags().StringVar(&bumpReason, "reason", "", "why, for the owner's email and the log")
+	bumpCmd.MarkFlagRequired("start")
+	bumpCmd.MarkFlagRequired("end")
+	bumpCmd.RegisterFlagCompletionFunc("start", completeStartTimes)
+	bumpCmd.RegisterFlagCompletionFunc("end", completeEndTimes)
+}
+
+func runAdminPriorityList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rules, err := client.GetPriorityRules(ctx, derefString(location.Id))
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(rules)
+	}
+
+	if len(rules) == 0 {
+		fmt.Printf("No priority rules at %s. Only admins and its managers may bump.\n", derefString(location.Name))
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 4},
+		{header: "NAME", width: 24, minWidth: 12, priority: 3},
+		{header: "USER", width: 30, minWidth: 16, priority: 1},
+		{header: "NOTICE", width: 7, priority: 2},
+		{header: "ENABLED", width: 7, priority: 1},
+	}
+	var rows [][]string
+	for _, rule := range rules {
+		enabled := "yes"
+		if !rule.Enabled {
+			enabled = "no"
+		}
+		rows = append(rows, []string{rule.Id, rule.Name, activityUserName(rule.User), fmt.Sprintf("%dh", rule.MinNoticeHours), enabled})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminPriorityAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	input := milesapi.PriorityRuleInput{
+		Email: openapi_types.Email(args[1]),
+		Name:  priorityName,
+	}
+	if err := applyPriorityFlags(cmd, &input.MinNoticeHours); err != nil {
+		return err
+	}
+	if priorityDisabled {
+		enabled := false
+		input.Enabled = &enabled
+	}
+
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rule, err := client.CreatePriorityRule(ctx, derefString(location.Id), input)
+	if err != nil {
+		return err
+	}
+	return printPriorityRule("Added", location, rule)
+}
+
+func runAdminPriorityEdit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	var update milesapi.PriorityRuleUpdate
+	if cmd.Flags().Changed("name") {
+		update.Name = &priorityName
+	}
+	if err := applyPriorityFlags(cmd, &update.MinNoticeHours); err != nil {
+		return err
+	}
+	if update.Name == nil && update.MinNoticeHours == nil {
+		return fmt.Errorf("nothing to change: give --name or --notice")
+	}
+
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rule, err := client.UpdatePriorityRule(ctx, derefString(location.Id), args[1], update)
+	if err != nil {
+		return err
+	}
+	return printPriorityRule("Updated", location, rule)
+}
+
+func runAdminPriorityToggle(ctx context.Context, args []string, enabled bool) error {
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rule, err := client.UpdatePriorityRule(ctx, derefString(location.Id), args[1], milesapi.PriorityRuleUpdate{Enabled: &enabled})
+	if err != nil {
+		return err
+	}
+
+	verb := "Enabled"
+	if !enabled {
+		verb = "Disabled"
+	}
+	return printPriorityRule(verb, location, rule)
+}
+
+func runAdminPriorityRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.DeletePriorityRule(ctx, derefString(location.Id), args[1]); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]string{"deleted": args[1]})
+	}
+	fmt.Printf("✓ Deleted priority rule %s\n", args[1])
+	return nil
+}
+
+func runAdminPriorityLog(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	displacements, err := client.GetDisplacements(ctx, derefString(location.Id))
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(displacements)
+	}
+
+	if len(displacements) == 0 {
+		fmt.Printf("No bookings have been bumped at %s.\n", derefString(location.Name))
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "WHEN", width: 16, priority: 2},
+		{header: "BOOKING", width: 24, minWidth: 12, priority: 3},
+		{header: "OWNER", width: 20, minWidth: 10, priority: 2},
+		{header: "BY", width: 20, minWidth: 10, priority: 1},
+		{header: "FROM", width: 22, priority: 1},
+		{header: "TO", width: 22, priority: 1},
+		{header: "REASON", width: 30, minWidth: 10, priority: 4},
+	}
+	var rows [][]string
+	for _, d := range displacements {
+		title, owner, by := d.BookingId, "", ""
+		if d.Booking != nil {
+			if d.Booking.Title != nil {
+				title = *d.Booking.Title
+			}
+			if d.Booking.User != nil {
+				owner = activityUserName(*d.Booking.User)
+			}
+		}
+		if d.BumpedBy != nil {
+			by = activityUserName(*d.BumpedBy)
+		}
+		rows = append(rows, []string{
+			d.CreatedAt.Local().Format("2006-01-02 15:04"),
+			title,
+			owner,
+			by,
+			describeSlot(d.FromStartTime, d.FromEndTime),
+			describeSlot(d.ToStartTime, d.ToEndTime),
+			derefString(d.Reason),
+		})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runBump(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	startTime, err := parseTime(bumpStart)
+	if err != nil {
+		return fmt.Errorf("invalid start time: %w", err)
+	}
+	endTime, err := parseEnd(bumpEnd, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid end time: %w", err)
+	}
+	if !endTime.After(startTime) {
+		return fmt.Errorf("end time must be after start time")
+	}
+
+	input := milesapi.BumpInput{StartTime: startTime, EndTime: endTime}
+	if bumpRoomID != "" {
+		input.RoomId = &bumpRoomID
+	}
+	if reason := strings.TrimSpace(bumpReason); reason != "" {
+		input.Reason = &reason
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	booking, err := client.BumpBooking(ctx, args[0], input)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(booking)
+	}
+
+	fmt.Printf("✓ Moved booking %s to %s", args[0], describeSlot(startTime, endTime))
+	if booking.RoomId != nil {
+		fmt.Printf(" in room %s", *booking.RoomId)
+	}
+	fmt.Println()
+	fmt.Println("Its owner has been emailed.")
+	return nil
+}
+
+// applyPriorityFlags sets the notice from --notice if it was given
+func applyPriorityFlags(cmd *cobra.Command, minNoticeHours **int) error {
+	if !cmd.Flags().Changed("notice") {
+		return nil
+	}
+	if priorityNotice < 0 || priorityNotice%time.Hour != 0 {
+		return fmt.Errorf("--notice must be whole hours, e.g. 24h")
+	}
+	hours := int(priorityNotice / time.Hour)
+	*minNoticeHours = &hours
+	return nil
+}
+
+// describeSlot formats a booking's time, leaving out the end's date when
+// it's the same day
+func describeSlot(start, end time.Time) string {
+	start, end = start.Local(), end.Local()
+	if start.Format("2006-01-02") == end.Format("2006-01-02") {
+		return start.Format("2006-01-02 15:04") + "-" + end.Format("15:04")
+	}
+	return start.Format("2006-01-02 15:04") + " - " + end.Format("2006-01-02 15:04")
+}
+
+// printPriorityRule reports a created or changed rule
+func printPriorityRule(verb string, location milesapi.Location, rule *milesapi.PriorityRule) error {
+	if output == "json" {
+		return outputJSON(rule)
+	}
+	if rule == nil {
+		return nil
+	}
+	state := ""
+	if !rule.Enabled {
+		state = " (disabled)"
+	}
+	fmt.Printf("✓ %s priority rule %q at %s: %s may bump bookings %dh or more ahead%s\n",
+		verb, rule.Name, derefString(location.Name), activityUserName(rule.User), rule.MinNoticeHours, state)
+	return nil
+}
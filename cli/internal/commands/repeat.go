@@ -0,0 +1,252 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/recurrence"
+	"golang.org/x/term"
+)
+
+// seriesOccurrence is one booking of a repeating series: whether it can be
+// booked, and once the series is booked how that went
+type seriesOccurrence struct {
+	Start   time.Time         `json:"start"`
+	End     time.Time         `json:"end"`
+	Status  string            `json:"status"` // free, skipped, booked or failed
+	Reason  string            `json:"reason,omitempty"`
+	Booking *milesapi.Booking `json:"booking,omitempty"`
+}
+
+// Occurrence statuses
+const (
+	occurrenceFree    = "free"
+	occurrenceSkipped = "skipped"
+	occurrenceBooked  = "booked"
+	occurrenceFailed  = "failed"
+)
+
+// checkRepeatFlags checks --repeat, --until and --count belong together
+// and fit the way the booking is made
+func checkRepeatFlags() error {
+	if bookRepeat == "" {
+		if bookUntil != "" || bookCount != 0 {
+			return fmt.Errorf("--until and --count end a series; add --repeat")
+		}
+		return nil
+	}
+	if _, err := recurrence.ParseFrequency(bookRepeat); err != nil {
+		return err
+	}
+	if (bookUntil == "") == (bookCount == 0) {
+		return fmt.Errorf("--repeat needs an end: --until DATE or --count N")
+	}
+	if bookZone != "" || bookFromText != "" {
+		return fmt.Errorf("--repeat books one room with -r, -s, -e and -t")
+	}
+	return nil
+}
+
+// runBookSeries books a repeating series: it expands the series, checks
+// each occurrence, asks once and books every free one. Occurrences on days
+// the location is closed or with the room taken are skipped; bookings the
+// server refuses are reported at the end.
+func runBookSeries(ctx context.Context, client config.API, roomID string, startTime, endTime time.Time, title, description string, buffer time.Duration) error {
+	frequency, _ := recurrence.ParseFrequency(bookRepeat)
+	var until time.Time
+	if bookUntil != "" {
+		var err error
+		if until, err = time.ParseInLocation(time.DateOnly, bookUntil, time.Local); err != nil {
+			return fmt.Errorf("invalid --until %q: use YYYY-MM-DD", bookUntil)
+		}
+	}
+	if !endTime.After(startTime) {
+		return fmt.Errorf("end time must be after start time")
+	}
+	expanded, err := recurrence.Expand(frequency, startTime, endTime, bookCount, until)
+	if err != nil {
+		return err
+	}
+
+	// Access and length are the same for every occurrence
+	room, err := findRoom(ctx, client, roomID)
+	if err != nil {
+		return err
+	}
+	if err := checkRoomAccess(room); err != nil {
+		return err
+	}
+	if err := checkRoomDuration(room, startTime, endTime); err != nil {
+		return err
+	}
+
+	series := checkSeries(ctx, client, room, expanded)
+	free := 0
+	for _, occurrence := range series {
+		if occurrence.Status == occurrenceFree {
+			free++
+		}
+	}
+
+	if output != "json" {
+		fmt.Printf("%s in %s, %s-%s %s:\n\n", title, derefString(room.Name),
+			startTime.Local().Format("15:04"), endTime.Local().Format("15:04"), frequency.Describe(startTime.Local()))
+		printSeries(series)
+		fmt.Printf("\n%d of %d can be booked.\n", free, len(series))
+	}
+	if free == 0 {
+		return fmt.Errorf("nothing to book: no occurrence of the series is free")
+	}
+	if err := confirmSeries(free); err != nil {
+		return err
+	}
+
+	// Only those with the team's key can read an encrypted description
+	sealed, err := sealDescription(description)
+	if err != nil {
+		return err
+	}
+	setup, err := parseRoomSetup(bookSetup)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for i := range series {
+		occurrence := &series[i]
+		if occurrence.Status != occurrenceFree {
+			continue
+		}
+		req := milesapi.BookingInput{
+			RoomId:    roomID,
+			StartTime: occurrence.Start.UTC(),
+			EndTime:   occurrence.End.UTC(),
+			Title:     title,
+			Setup:     setup,
+		}
+		if sealed != "" {
+			req.Description = &sealed
+		}
+		if buffer > 0 {
+			minutes := int(buffer / time.Minute)
+			req.BufferMinutes = &minutes
+		}
+		if bookSetupNotes != "" {
+			req.SetupNotes = &bookSetupNotes
+		}
+		booking, err := client.CreateBooking(ctx, req)
+		if err != nil {
+			occurrence.Status, occurrence.Reason = occurrenceFailed, err.Error()
+			failed++
+			continue
+		}
+		occurrence.Status, occurrence.Booking = occurrenceBooked, booking
+	}
+
+	if output == "json" {
+		if err := outputJSON(series); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		printSeries(series)
+		fmt.Printf("\n✓ Booked %d of %d occurrences\n", free-failed, len(series))
+		fmt.Printf("\nView all bookings: miles bookings\n")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d occurrences could not be booked", failed, free)
+	}
+	return nil
+}
+
+// checkSeries marks each occurrence free, or skipped with why: in the past,
+// on a day the room's location is closed, the room taken or (without
+// --force) overlapping one of the user's bookings
+func checkSeries(ctx context.Context, client config.API, room *milesapi.Room, expanded []recurrence.Occurrence) []seriesOccurrence {
+	first, last := expanded[0], expanded[len(expanded)-1]
+
+	var closed *closures
+	if locationID := derefString(room.LocationId); locationID != "" {
+		closed, _ = loadClosures(ctx, client, locationID, first.Start, last.End)
+	}
+	var own []milesapi.Booking
+	if !bookForce {
+		own, _ = findOwnOverlaps(ctx, client, first.Start, last.End)
+	}
+
+	now := config.ServerNow()
+	series := make([]seriesOccurrence, 0, len(expanded))
+	for _, o := range expanded {
+		occurrence := seriesOccurrence{Start: o.Start, End: o.End, Status: occurrenceSkipped}
+		if o.Start.Before(now) {
+			occurrence.Reason = "in the past"
+		} else if err := closed.checkOrNil(o.Start, o.End); err != nil {
+			occurrence.Reason = err.Error()
+		} else {
+			occurrence.Reason = seriesConflict(ctx, client, derefString(room.Id), o, own)
+		}
+		if occurrence.Reason == "" {
+			occurrence.Status = occurrenceFree
+		}
+		series = append(series, occurrence)
+	}
+	return series
+}
+
+// seriesConflict says what holds an occurrence's slot: a booking of the
+// room or one of the user's own, or "" when it is free. A failed check
+// leaves it to the server.
+func seriesConflict(ctx context.Context, client config.API, roomID string, o recurrence.Occurrence, own []milesapi.Booking) string {
+	conflicts, err := client.CheckRoomAvailability(ctx, roomID, o.Start, o.End)
+	if err == nil && len(conflicts) > 0 {
+		return "room taken by " + milesapi.DescribeBlocker(conflicts[0])
+	}
+	for _, booking := range own {
+		if booking.StartTime.Before(o.End) && booking.EndTime.After(o.Start) {
+			return "you have " + milesapi.DescribeBlocker(booking) + "; --force books anyway"
+		}
+	}
+	return ""
+}
+
+// printSeries prints one line per occurrence
+func printSeries(series []seriesOccurrence) {
+	for _, occurrence := range series {
+		when := occurrence.Start.Local().Format("Mon 2006-01-02 15:04") + "-" + occurrence.End.Local().Format("15:04")
+		switch occurrence.Status {
+		case occurrenceFree:
+			fmt.Printf("  · %s  free\n", when)
+		case occurrenceBooked:
+			status := "booked " + derefString(occurrence.Booking.Id)
+			if occurrence.Booking.Status != nil && *occurrence.Booking.Status == milesapi.BookingStatusPENDING {
+				status += ", waiting for approval"
+			}
+			fmt.Printf("  ✓ %s  %s\n", when, status)
+		case occurrenceFailed:
+			fmt.Printf("  ✗ %s  failed: %s\n", when, occurrence.Reason)
+		default:
+			fmt.Printf("  - %s  skipped: %s\n", when, occurrence.Reason)
+		}
+	}
+}
+
+// confirmSeries asks once before booking a series, unless --yes is given
+// or there is no terminal to ask on
+func confirmSeries(free int) error {
+	if assumeYes || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Book %d occurrences", free),
+		IsConfirm: true,
+	}
+	if _, err := prompt.Run(); err != nil {
+		return fmt.Errorf("series not booked")
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/snippet"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report room issues, and reports on room use (admins and managers)",
+}
+
+var reportUtilizationCmd = &cobra.Command{
+	Use:   "utilization",
+	Short: "Show how rooms were used and who cancelled late",
+	Long: `Show how much each room was booked over a period, and the bookings,
+cancellations and late cancellations of each team (department) and user.
+
+Utilization is booked time over 8-hour weekdays. A late cancellation is
+one the booker made inside the location's late-cancellation window.
+Managers see the locations they manage.
+
+--since and --until take dates or days like "last monday"; the period
+defaults to the last 30 days.
+
+Examples:
+  miles report utilization
+  miles report utilization --location Stavanger --since 2025-09-01 --until 2025-10-01
+  miles report utilization -o json`,
+	Args: cobra.NoArgs,
+	RunE: runReportUtilization,
+}
+
+var (
+	reportSince    string
+	reportUntil    string
+	reportLocation string
+)
+
+func init() {
+	reportUtilizationCmd.Flags().StringVar(&reportSince, "since", "", "start of the period (default: 30 days before --until)")
+	reportUtilizationCmd.Flags().StringVar(&reportUntil, "until", "", "end of the period, exclusive (default: now)")
+	reportUtilizationCmd.Flags().StringVarP(&reportLocation, "location", "l", "", "location ID or name (default: every location you may report on)")
+	reportUtilizationCmd.RegisterFlagCompletionFunc("location", completeLocationIDs)
+
+	reportCmd.AddCommand(reportUtilizationCmd)
+}
+
+func runReportUtilization(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	var start, end time.Time
+	var err error
+	if reportSince != "" {
+		if start, err = snippet.ParseDate(reportSince, time.Now()); err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if reportUntil != "" {
+		if end, err = snippet.ParseDate(reportUntil, time.Now()); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var locationID string
+	if reportLocation != "" {
+		locations, err := client.GetLocations(ctx)
+		if err != nil {
+			return err
+		}
+		i := locationIndex(locations, reportLocation)
+		if i < 0 {
+			return fmt.Errorf("no location matches %q. Run 'miles rooms' to list locations", reportLocation)
+		}
+		locationID = derefString(locations[i].Id)
+	}
+
+	report, err := client.GetUtilizationReport(ctx, start, end, locationID)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(report)
+	}
+
+	printUtilizationReport(report)
+	return nil
+}
+
+// printUtilizationReport prints the rooms by use, then late cancellations
+// by team and by user
+func printUtilizationReport(report *milesapi.UtilizationReport) {
+	totals := report.Totals
+	fmt.Printf("%s to %s\n", report.StartDate.Local().Format("Mon Jan 2 2006"), report.EndDate.Local().Format("Mon Jan 2 2006"))
+	fmt.Printf("  %d bookings, %.1f hours booked, %d cancelled, %d late\n\n",
+		totals.Bookings, totals.BookedHours, totals.Cancelled, totals.LateCancelled)
+
+	if len(report.Rooms) == 0 {
+		fmt.Println("No rooms were booked.")
+	} else {
+		columns := []tableColumn{
+			{header: "ROOM", width: 24, minWidth: 10, priority: 5},
+			{header: "LOCATION", width: 16, minWidth: 8, priority: 2},
+			{header: "BOOKINGS", width: 8, priority: 3},
+			{header: "HOURS", width: 7, priority: 4},
+			{header: "USE", width: 5, priority: 6},
+		}
+		var rows [][]string
+		for _, room := range report.Rooms {
+			rows = append(rows, []string{
+				room.Name,
+				room.Location,
+				strconv.Itoa(room.Bookings),
+				fmt.Sprintf("%.1f", room.BookedHours),
+				fmt.Sprintf("%.0f%%", room.Utilization*100),
+			})
+		}
+		printTable(columns, rows)
+	}
+
+	if totals.LateCancelled == 0 {
+		fmt.Println("\nNo late cancellations.")
+		return
+	}
+
+	fmt.Println("\nBy team")
+	cancelColumns := func(first string) []tableColumn {
+		return []tableColumn{
+			{header: first, width: 28, minWidth: 10, priority: 5},
+			{header: "BOOKINGS", width: 8, priority: 2},
+			{header: "CANCELLED", width: 9, priority: 3},
+			{header: "LATE", width: 5, priority: 4},
+		}
+	}
+	var rows [][]string
+	for _, team := range report.Teams {
+		rows = append(rows, []string{
+			cmp.Or(derefString(team.Department), "-"),
+			strconv.Itoa(team.Bookings),
+			strconv.Itoa(team.Cancelled),
+			strconv.Itoa(team.LateCancelled),
+		})
+	}
+	printTable(cancelColumns("TEAM"), rows)
+
+	// Users without late cancellations aren't the point of this list
+	fmt.Println("\nLate cancellations by user")
+	rows = nil
+	for _, user := range report.Users {
+		if user.LateCancelled == 0 {
+			continue
+		}
+		rows = append(rows, []string{
+			user.Name + " <" + user.Email + ">",
+			strconv.Itoa(user.Bookings),
+			strconv.Itoa(user.Cancelled),
+			strconv.Itoa(user.LateCancelled),
+		})
+	}
+	printTable(cancelColumns("USER"), rows)
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var roomsShowCmd = &cobra.Command{
+	Use:   "show ROOM",
+	Short: "Show a room's details and today's bookings",
+	Long: `Show everything about one room: its location, floor, capacity, amenities
+and description, what limits bookings in it (length, departments, approval),
+and its bookings today as an hour bar. ROOM is a room ID or name.
+
+Examples:
+  miles room show ROOM123
+  miles room show Skagen
+  miles room show Skagen -o json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRoomIDs,
+	RunE:              runRoomsShow,
+}
+
+func init() {
+	roomsCmd.AddCommand(roomsShowCmd)
+}
+
+// roomDetails is the -o json form of miles room show
+type roomDetails struct {
+	Room     milesapi.Room      `json:"room"`
+	Location *milesapi.Location `json:"location,omitempty"`
+	Today    []milesapi.Booking `json:"today"`
+}
+
+func runRoomsShow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rooms, err := client.GetRooms(ctx, "")
+	if err != nil {
+		return err
+	}
+	room, ok := matchRoom(rooms, args[0])
+	if !ok {
+		return fmt.Errorf("no room matches %q. Run 'miles rooms' to list rooms", args[0])
+	}
+	roomID := derefString(room.Id)
+
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		return err
+	}
+	var location *milesapi.Location
+	if i := locationIndex(locations, derefString(room.LocationId)); i >= 0 {
+		location = &locations[i]
+	}
+
+	day, bookings, err := loadRoomDay(ctx, client, roomID, time.Now())
+	if err != nil {
+		return err
+	}
+	sort.Slice(bookings, func(i, j int) bool {
+		return bookings[i].StartTime.Before(*bookings[j].StartTime)
+	})
+
+	if output == "json" {
+		if bookings == nil {
+			bookings = []milesapi.Booking{}
+		}
+		return outputJSON(roomDetails{Room: room, Location: location, Today: bookings})
+	}
+
+	printRoomDetails(room, location)
+
+	bar := hourBar{day: day, bookings: bookings}
+	fmt.Printf("\nToday, %s\n", day.Format("Monday, January 2"))
+	fmt.Printf("  %s\n  %s\n\n", bar, bar.legend())
+	if len(bookings) == 0 {
+		fmt.Println("  Free all day.")
+		return nil
+	}
+	for _, booking := range bookings {
+		fmt.Printf("  %s-%s  %s\n",
+			booking.StartTime.Local().Format("15:04"),
+			booking.EndTime.Local().Format("15:04"),
+			availabilityTitle(booking),
+		)
+	}
+	return nil
+}
+
+// printRoomDetails prints a room's metadata and booking constraints.
+// Fields the room doesn't have are left out.
+func printRoomDetails(room milesapi.Room, location *milesapi.Location) {
+	name := derefString(room.Name)
+	if roomLocked(room) {
+		name += " (locked)"
+	}
+	fmt.Println(name)
+	fmt.Printf("  ID:          %s\n", derefString(room.Id))
+	if location != nil {
+		fmt.Printf("  Location:    %s\n", derefString(location.Name))
+	} else {
+		fmt.Printf("  Location:    %s\n", derefString(room.LocationId))
+	}
+
+	var place []string
+	if floor := derefString(room.Floor); floor != "" {
+		place = append(place, "floor "+floor)
+	}
+	if wing := derefString(room.Wing); wing != "" {
+		place = append(place, wing+" wing")
+	}
+	if len(place) > 0 {
+		fmt.Printf("  Where:       %s\n", strings.Join(place, ", "))
+	}
+	if room.Type != nil && *room.Type == milesapi.DESK {
+		fmt.Println("  Type:        hot desk")
+	}
+	if room.Capacity != nil {
+		fmt.Printf("  Capacity:    %d people\n", *room.Capacity)
+	}
+	if room.Amenities != nil && len(*room.Amenities) > 0 {
+		fmt.Printf("  Amenities:   %s\n", strings.Join(*room.Amenities, ", "))
+	}
+	if room.IsActive != nil && !*room.IsActive {
+		fmt.Println("  Status:      inactive, can't be booked")
+	}
+
+	fmt.Println()
+	fmt.Println("Booking")
+	fmt.Printf("  Length:      %s\n", milesapi.DescribeDurationLimits(room.DurationLimits()))
+	if room.Departments != nil && len(*room.Departments) > 0 {
+		access := "you can book it"
+		if roomLocked(room) {
+			access = "ask for access with 'miles rooms request-access " + derefString(room.Id) + "'"
+		}
+		fmt.Printf("  Restricted:  %s; %s\n", strings.Join(*room.Departments, ", "), access)
+	}
+	if location != nil && location.RequiresApproval != nil && *location.RequiresApproval {
+		fmt.Println("  Approval:    bookings wait for a manager unless a rule covers them")
+	}
+
+	if description := derefString(room.Description); description != "" {
+		fmt.Printf("\n  %s\n", description)
+	}
+}
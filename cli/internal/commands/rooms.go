@@ -1,28 +1,30 @@
 package commands
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
-	"github.com/miles/booking-cli/internal/config"
-	"github.com/miles/booking-cli/internal/generated"
+	"github.com/miles/booking-tui/pkg/milesapi"
 	"github.com/spf13/cobra"
 )
 
 var roomsCmd = &cobra.Command{
-	Use:   "rooms",
-	Short: "List and search meeting rooms",
+	Use:     "rooms",
+	Aliases: []string{"room"},
+	Short:   "List and search meeting rooms",
 	Long: `List all available meeting rooms or filter by location.
 
 Examples:
   miles rooms                           # List all rooms
   miles rooms --location LOC123         # Filter by location ID
   miles rooms -o json                   # Output as JSON
-  miles rooms -o csv > rooms.csv        # Export to CSV`,
+  miles rooms -o csv > rooms.csv        # Export to CSV
+  miles room show ROOM123               # One room's details`,
 	RunE: runRooms,
 }
 
@@ -36,20 +38,34 @@ func init() {
 }
 
 func runRooms(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	// Check authentication
 	token := getAuthToken()
 	if token == "" {
 		return fmt.Errorf("not authenticated. Run 'miles login' first")
 	}
 
+	var tmpl *template.Template
+	if output == "template" {
+		var err error
+		if tmpl, err = parseOutputTemplate(); err != nil {
+			return err
+		}
+	}
+
 	// Create API client
-	client := config.NewClient(getAPIURL(), token)
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
 
-	// Fetch rooms
-	rooms, err := client.GetRooms(roomsLocationID)
+	// Fetch rooms; desks have their own command
+	rooms, err := client.GetRooms(ctx, roomsLocationID)
 	if err != nil {
 		return err
 	}
+	rooms = milesapi.MeetingRooms(rooms)
 
 	if len(rooms) == 0 {
 		fmt.Println("No rooms found")
@@ -62,17 +78,32 @@ func runRooms(cmd *cobra.Command, args []string) error {
 		return outputJSON(rooms)
 	case "csv":
 		return outputRoomsCSV(rooms)
+	case "template":
+		lookup := newTemplateLookup(ctx, client)
+		for _, room := range rooms {
+			if err := writeTemplate(tmpl, templateRoom{Room: room, lookup: lookup}); err != nil {
+				return err
+			}
+		}
+		return nil
 	default:
 		return outputRoomsTable(rooms)
 	}
 }
 
-func outputRoomsTable(rooms []generated.Room) error {
-	// Print header - wider ID column to show full IDs
-	fmt.Printf("%-25s %-30s %-12s %-8s\n", "ID", "Name", "Location", "Capacity")
-	fmt.Println(strings.Repeat("-", 80))
+func outputRoomsTable(rooms []milesapi.Room) error {
+	// Full IDs; on narrow terminals the name shrinks, then length and
+	// location go
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 5},
+		{header: "Name", width: 30, minWidth: 12, priority: 4},
+		{header: "Location", width: 12, priority: 2},
+		{header: "Capacity", width: 8, priority: 3},
+		{header: "Length", width: 12, priority: 1},
+	}
 
-	// Print rooms
+	var rows [][]string
+	locked := 0
 	for _, room := range rooms {
 		id := ""
 		if room.Id != nil {
@@ -91,16 +122,26 @@ func outputRoomsTable(rooms []generated.Room) error {
 			locationId = *room.LocationId
 		}
 
-		// Show full ID, truncate name if needed
-		fmt.Printf("%-25s %-30s %-12s %-8d\n",
-			id,
-			truncate(name, 30),
-			locationId,
-			capacity,
-		)
+		length := "-"
+		if minDuration, maxDuration := room.DurationLimits(); minDuration > 0 || maxDuration > 0 {
+			length = shortDurationLimits(minDuration, maxDuration)
+		}
+
+		// Restricted rooms the user can't book
+		if roomLocked(room) {
+			name += " (locked)"
+			locked++
+		}
+
+		rows = append(rows, []string{id, name, locationId, strconv.Itoa(capacity), length})
 	}
+	printTable(columns, rows)
 
 	fmt.Printf("\nTotal: %d rooms\n", len(rooms))
+	if locked > 0 {
+		fmt.Printf("\n%d locked rooms are restricted to other departments. Ask for access with:\n", locked)
+		fmt.Printf("     miles rooms request-access ROOM_ID\n")
+	}
 	fmt.Printf("\nTip: Use -o json to see all details, or copy an ID for booking:\n")
 	fmt.Printf("     miles book -r %s -s \"2025-10-19 14:00\" -e \"15:00\" -t \"Meeting\"\n",
 		func() string {
@@ -112,12 +153,23 @@ func outputRoomsTable(rooms []generated.Room) error {
 	return nil
 }
 
-func outputRoomsCSV(rooms []generated.Room) error {
-	w := csv.NewWriter(os.Stdout)
-	defer w.Flush()
+// roomCSVColumns are the columns of -o csv room output
+var roomCSVColumns = []csvColumn{
+	{"id", "ID"},
+	{"name", "Name"},
+	{"location_id", "LocationID"},
+	{"capacity", "Capacity"},
+	{"min_duration_minutes", "MinDurationMinutes"},
+	{"max_duration_minutes", "MaxDurationMinutes"},
+	{"departments", "Departments"},
+	{"can_book", "CanBook"},
+}
 
-	// Write header
-	w.Write([]string{"ID", "Name", "LocationID", "Capacity"})
+func outputRoomsCSV(rooms []milesapi.Room) error {
+	w, err := newCSVWriter(os.Stdout, roomCSVColumns)
+	if err != nil {
+		return err
+	}
 
 	// Write data
 	for _, room := range rooms {
@@ -138,10 +190,37 @@ func outputRoomsCSV(rooms []generated.Room) error {
 			capacity = strconv.Itoa(*room.Capacity)
 		}
 
-		w.Write([]string{id, name, locationId, capacity})
+		minDuration := ""
+		if room.MinDurationMinutes != nil {
+			minDuration = strconv.Itoa(*room.MinDurationMinutes)
+		}
+		maxDuration := ""
+		if room.MaxDurationMinutes != nil {
+			maxDuration = strconv.Itoa(*room.MaxDurationMinutes)
+		}
+
+		departments := ""
+		if room.Departments != nil {
+			departments = strings.Join(*room.Departments, ";")
+		}
+		canBook := strconv.FormatBool(!roomLocked(room))
+
+		w.Write([]string{id, name, locationId, capacity, minDuration, maxDuration, departments, canBook})
 	}
 
-	return nil
+	w.Flush()
+	return w.Error()
+}
+
+// shortDurationLimits formats booking length limits for table cells, e.g. "1h-4h" or "≤1h"
+func shortDurationLimits(minDuration, maxDuration time.Duration) string {
+	switch {
+	case minDuration > 0 && maxDuration > 0:
+		return formatDuration(minDuration) + "-" + formatDuration(maxDuration)
+	case minDuration > 0:
+		return "≥" + formatDuration(minDuration)
+	}
+	return "≤" + formatDuration(maxDuration)
 }
 
 func truncate(s string, maxLen int) string {
@@ -156,3 +235,19 @@ func outputJSON(data interface{}) error {
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
+
+// matchRoom finds a room by ID, or else by name ignoring case
+func matchRoom(rooms []milesapi.Room, target string) (milesapi.Room, bool) {
+	target = strings.TrimSpace(target)
+	for _, room := range rooms {
+		if derefString(room.Id) == target {
+			return room, true
+		}
+	}
+	for _, room := range rooms {
+		if strings.EqualFold(derefString(room.Name), target) {
+			return room, true
+		}
+	}
+	return milesapi.Room{}, false
+}
@@ -1,20 +1,40 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-cli/internal/daemon"
+	"github.com/miles/booking-tui/pkg/deeplink"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/office"
+	"github.com/miles/booking-tui/pkg/update"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile string
-	apiURL  string
-	token   string
-	output  string
+	cfgFile   string
+	apiURL    string
+	token     string
+	output    string
+	transport string
+	grpcAddr  string
+	actAs     string
+	record    string
+	replay    string
+
+	// configLoaded is set once initConfig has run
+	configLoaded bool
 )
 
+// Version is the CLI's version, set at build time with
+// -ldflags "-X github.com/miles/booking-cli/internal/commands.Version=1.2.0"
+var Version = "1.0.0"
+
 var rootCmd = &cobra.Command{
 	Use:   "miles",
 	Short: "Miles Booking CLI - Manage meeting room bookings from the terminal",
@@ -25,38 +45,115 @@ Features:
   - List and search meeting rooms
   - Create and cancel bookings
   - View booking calendars
-  - Export data in multiple formats (table, JSON, CSV)
+  - Export data in multiple formats (table, JSON, CSV, Go templates)
   - Scriptable for automation`,
-	Version: "1.0.0",
+	Version: Version,
 }
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	args, err := expandAliases(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return err
+	}
+	rootCmd.SetArgs(args)
+
+	// Ctrl-C cancels the requests in flight, so commands stop cleanly; a
+	// second one exits at once
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if err != nil {
+		printPermissionHints(cmd, err)
+		printRoomAccessHint(err)
+	}
+	return err
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	// 'miles --version' mentions a newer release, if there is one
+	cobra.AddTemplateFunc("updateHint", updateHint)
+	rootCmd.SetVersionTemplate(`{{with .Name}}{{printf "%s " .}}{{end}}{{printf "version %s" .Version}}
+{{updateHint .Version}}`)
+
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.miles-cli.yaml)")
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "API base URL (env: API_URL)")
 	rootCmd.PersistentFlags().StringVar(&token, "token", "", "authentication token (env: MILES_TOKEN)")
-	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "output format: table, json, csv")
+	rootCmd.PersistentFlags().Bool("no-keyring", false, "keep the login token in the config file instead of the OS keychain, e.g. in CI (env: MILES_NO_KEYRING)")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "output format: table, json, csv, template, or ics for miles bookings")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "answer yes to confirmation prompts, also under safe_mode")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go template for -o template, or the name of one under 'templates' in config")
+	rootCmd.PersistentFlags().String("csv-delimiter", ",", "field delimiter for -o csv, e.g. ';' for Excel in semicolon locales, or tab")
+	rootCmd.PersistentFlags().Bool("csv-bom", false, "start -o csv output with a UTF-8 byte order mark so Excel detects the encoding")
+	rootCmd.PersistentFlags().String("csv-headers", "default", "-o csv header names: default, keys, or a set under 'csv_header_sets' in config")
+	rootCmd.PersistentFlags().StringVar(&transport, "transport", "", "API transport: rest or grpc (env: MILES_TRANSPORT)")
+	rootCmd.PersistentFlags().StringVar(&grpcAddr, "grpc-addr", "", "gRPC server address, e.g. localhost:50051 (env: MILES_GRPC_ADDR)")
+	rootCmd.PersistentFlags().StringVar(&actAs, "as", "", "impersonate a user by email (admins only)")
+	rootCmd.PersistentFlags().StringVar(&record, "record", "", "record every API request and response to this cassette file (REST only)")
+	rootCmd.PersistentFlags().StringVar(&replay, "replay", "", "answer API requests from a cassette made with --record instead of the server")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
+	rootCmd.PersistentFlags().BoolVar(&skipVersionCheck, "skip-version-check", false, "talk to the server even if it speaks an API version this client doesn't support")
+	rootCmd.PersistentFlags().BoolVar(&wideOutput, "wide", false, "print tables at full width, even when the terminal is narrower")
 
 	// Bind flags to viper
 	viper.BindPFlag("api_url", rootCmd.PersistentFlags().Lookup("api-url"))
 	viper.BindPFlag("token", rootCmd.PersistentFlags().Lookup("token"))
+	viper.BindPFlag("no_keyring", rootCmd.PersistentFlags().Lookup("no-keyring"))
+	viper.BindPFlag("transport", rootCmd.PersistentFlags().Lookup("transport"))
+	viper.BindPFlag("grpc_addr", rootCmd.PersistentFlags().Lookup("grpc-addr"))
+	viper.BindPFlag("csv_delimiter", rootCmd.PersistentFlags().Lookup("csv-delimiter"))
+	viper.BindPFlag("csv_bom", rootCmd.PersistentFlags().Lookup("csv-bom"))
+	viper.BindPFlag("csv_headers", rootCmd.PersistentFlags().Lookup("csv-headers"))
 
 	// Add subcommands
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(roomsCmd)
+	rootCmd.AddCommand(locationCmd)
+	rootCmd.AddCommand(availabilityCmd)
 	rootCmd.AddCommand(bookCmd)
+	rootCmd.AddCommand(desksCmd)
+	rootCmd.AddCommand(bookDeskCmd)
 	rootCmd.AddCommand(bookingsCmd)
 	rootCmd.AddCommand(cancelCmd)
+	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(bumpCmd)
+	rootCmd.AddCommand(eventsCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(daemonCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(kioskCmd)
+	rootCmd.AddCommand(doorCmd)
+	rootCmd.AddCommand(adminCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(followCmd)
+	rootCmd.AddCommand(findCmd)
+	rootCmd.AddCommand(findCommonCmd)
+	rootCmd.AddCommand(summaryCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(featuresCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(shareCmd)
+	rootCmd.AddCommand(openLinkCmd)
 }
 
 func initConfig() {
+	if configLoaded {
+		return
+	}
+	configLoaded = true
+
 	if cfgFile != "" {
 		// Use config file from the flag
 		viper.SetConfigFile(cfgFile)
@@ -85,6 +182,16 @@ func initConfig() {
 
 	// Set defaults
 	viper.SetDefault("api_url", "http://localhost:3000")
+	viper.SetDefault("transport", string(config.TransportREST))
+	viper.SetDefault("outlook_tenant", "common")
+	viper.SetDefault("offices_file", office.DefaultPath())
+	viper.SetDefault("booking_boundary", string(config.BoundaryTouch))
+	viper.SetDefault("update_url", update.DefaultURL)
+	viper.SetDefault("web_url", deeplink.DefaultWebURL)
+	viper.SetDefault("confirm."+confirmBulkCancel, true)
+	viper.SetDefault("confirm."+confirmAdminCancel, true)
+	viper.SetDefault("daemon.interval", daemon.DefaultPollInterval)
+	viper.SetDefault("daemon.calendar_interval", daemon.DefaultCalendarInterval)
 }
 
 // Helper function to get API URL
@@ -96,7 +203,86 @@ func getAPIURL() string {
 	return url
 }
 
-// Helper function to get auth token
+// serverTarget describes the server the configured transport talks to
+func serverTarget() string {
+	if config.Transport(viper.GetString("transport")) == config.TransportGRPC {
+		return "grpc://" + viper.GetString("grpc_addr")
+	}
+	return getAPIURL()
+}
+
+// getAuthToken returns the login token: from --token or MILES_TOKEN, else
+// the one 'miles login' stored in the keychain for this server, else the
+// config file's
 func getAuthToken() string {
+	if rootCmd.PersistentFlags().Changed("token") || os.Getenv("MILES_TOKEN") != "" {
+		return viper.GetString("token")
+	}
+	if token := keychainToken(); token != "" {
+		return token
+	}
 	return viper.GetString("token")
 }
+
+// newAPIClient creates an API client for the configured transport, after
+// checking once per run that the server speaks an API version this client
+// supports
+func newAPIClient(ctx context.Context, token string) (config.API, error) {
+	client, err := dialAPI(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkServerVersion(ctx, client); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// dialAPI creates an API client for the configured transport without the
+// version check, for 'miles status' to report on any server
+func dialAPI(token string) (config.API, error) {
+	if actAs != "" {
+		if err := requireRole(token, milesapi.ADMIN); err != nil {
+			return nil, fmt.Errorf("--as %w", err)
+		}
+		// Stderr so the warning never ends up in -o json/csv output
+		fmt.Fprintf(os.Stderr, "⚠ IMPERSONATING %s - all requests run as this user and are audited\n\n", actAs)
+	}
+
+	boundary, err := config.ParseBoundary(viper.GetString("booking_boundary"))
+	if err != nil {
+		return nil, err
+	}
+
+	return config.New(config.Options{
+		Transport:   config.Transport(viper.GetString("transport")),
+		BaseURL:     getAPIURL(),
+		GRPCAddr:    viper.GetString("grpc_addr"),
+		Insecure:    viper.GetBool("grpc_insecure"),
+		Token:       token,
+		Impersonate: actAs,
+		Boundary:    boundary,
+		Record:      record,
+		Replay:      replay,
+	})
+}
+
+// bookingBoundary returns the configured booking_boundary: whether a booking
+// may start the moment another ends. newAPIClient rejects invalid values
+// before any command gets this far.
+func bookingBoundary() config.Boundary {
+	boundary, _ := config.ParseBoundary(viper.GetString("booking_boundary"))
+	return boundary
+}
+
+// requireRole fails before any request is made when the token's role can't
+// do what the command needs. Tokens that can't be decoded are left to the
+// server to judge.
+func requireRole(token string, required milesapi.UserRole) error {
+	role := milesapi.TokenRole(token)
+	if role == "" || config.RoleAllows(role, required) {
+		return nil
+	}
+	return fmt.Errorf("requires %s role (you are %s)", required, role)
+}
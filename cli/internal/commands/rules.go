@@ -0,0 +1,419 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var adminRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage auto-approval rules for a location (admins and its managers)",
+	Long: `At locations that require approval, new bookings wait as PENDING until a
+manager confirms them. Auto-approval rules confirm routine bookings straight
+away so the approval queue only holds what needs a person.
+
+A booking is confirmed when it meets every condition of an enabled rule:
+  --max 2h               at most this long
+  --outside-core-hours   entirely outside core hours on weekdays, in the
+                         location's time zone (--core, default 09:00-16:00)
+
+Adding, editing or enabling a rule also confirms the upcoming pending
+bookings it covers. LOCATION is a location ID or name.
+
+Examples:
+  miles admin rules require Oslo on
+  miles admin rules add Oslo --name "Short after hours" --max 2h --outside-core-hours
+  miles admin rules list Oslo
+  miles admin rules edit Oslo RULE_ID --max 90m
+  miles admin rules disable Oslo RULE_ID
+  miles admin rules rm Oslo RULE_ID`,
+}
+
+var adminRulesListCmd = &cobra.Command{
+	Use:               "list LOCATION",
+	Short:             "List a location's approval rules",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminRulesList,
+}
+
+var adminRulesAddCmd = &cobra.Command{
+	Use:               "add LOCATION",
+	Short:             "Add an approval rule",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminRulesAdd,
+}
+
+var adminRulesEditCmd = &cobra.Command{
+	Use:   "edit LOCATION RULE_ID",
+	Short: "Change an approval rule",
+	Long: `Change the conditions of an approval rule. Only the flags given change;
+use --max 0 to allow any length and --outside-core-hours=false to drop the
+core hours condition.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAdminRulesEdit,
+}
+
+var adminRulesEnableCmd = &cobra.Command{
+	Use:   "enable LOCATION RULE_ID",
+	Short: "Enable an approval rule",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdminRulesToggle(cmd.Context(), args, true)
+	},
+}
+
+var adminRulesDisableCmd = &cobra.Command{
+	Use:   "disable LOCATION RULE_ID",
+	Short: "Disable an approval rule without deleting it",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdminRulesToggle(cmd.Context(), args, false)
+	},
+}
+
+var adminRulesRemoveCmd = &cobra.Command{
+	Use:     "rm LOCATION RULE_ID",
+	Aliases: []string{"remove", "delete"},
+	Short:   "Delete an approval rule",
+	Args:    cobra.ExactArgs(2),
+	RunE:    runAdminRulesRemove,
+}
+
+var adminRulesRequireCmd = &cobra.Command{
+	Use:       "require LOCATION on|off",
+	Short:     "Turn approval of new bookings at a location on or off",
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: []string{"on", "off"},
+	RunE:      runAdminRulesRequire,
+}
+
+var (
+	ruleName             string
+	ruleMax              time.Duration
+	ruleOutsideCoreHours bool
+	ruleCore             string
+	ruleDisabled         bool
+)
+
+// coreHoursPattern matches core hours such as 09:00-16:00
+var coreHoursPattern = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d-([01]\d|2[0-3]):[0-5]\d$`)
+
+func init() {
+	for _, cmd := range []*cobra.Command{adminRulesAddCmd, adminRulesEditCmd} {
+		cmd.Flags().StringVar(&ruleName, "name", "", "describes the rule, e.g. \"Short after hours\"")
+		cmd.Flags().DurationVar(&ruleMax, "max", 0, "only bookings at most this long, e.g. 2h")
+		cmd.Flags().BoolVar(&ruleOutsideCoreHours, "outside-core-hours", false, "only bookings entirely outside core hours")
+		cmd.Flags().StringVar(&ruleCore, "core", "", "core hours in the location's time zone (default 09:00-16:00)")
+	}
+	adminRulesAddCmd.Flags().BoolVar(&ruleDisabled, "disabled", false, "add the rule switched off")
+	adminRulesAddCmd.MarkFlagRequired("name")
+
+	adminRulesCmd.AddCommand(adminRulesListCmd)
+	adminRulesCmd.AddCommand(adminRulesAddCmd)
+	adminRulesCmd.AddCommand(adminRulesEditCmd)
+	adminRulesCmd.AddCommand(adminRulesEnableCmd)
+	adminRulesCmd.AddCommand(adminRulesDisableCmd)
+	adminRulesCmd.AddCommand(adminRulesRemoveCmd)
+	adminRulesCmd.AddCommand(adminRulesRequireCmd)
+	adminCmd.AddCommand(adminRulesCmd)
+}
+
+// completeRuleLocation completes the LOCATION argument
+func completeRuleLocation(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeLocationIDs(cmd, args, toComplete)
+}
+
+// rulesClient checks the caller may manage a location and resolves LOCATION.
+// Approval rules and the services directory both use it.
+func rulesClient(ctx context.Context, query string) (config.API, milesapi.Location, error) {
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return nil, milesapi.Location{}, fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	if err := requireRole(token, milesapi.MANAGER); err != nil {
+		return nil, milesapi.Location{}, err
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return nil, milesapi.Location{}, err
+	}
+
+	locations, err := client.GetLocations(ctx)
+	if err != nil {
+		client.Close()
+		return nil, milesapi.Location{}, err
+	}
+	i := locationIndex(locations, query)
+	if i < 0 {
+		client.Close()
+		return nil, milesapi.Location{}, fmt.Errorf("no location matches %q. Run 'miles rooms' to list locations", query)
+	}
+	return client, locations[i], nil
+}
+
+// approvalRulesClient is rulesClient for the approval rules, which need
+// approvals to be on
+func approvalRulesClient(ctx context.Context, query string) (config.API, milesapi.Location, error) {
+	client, location, err := rulesClient(ctx, query)
+	if err != nil {
+		return nil, location, err
+	}
+	if err := requireFeature(ctx, client, featureApprovals); err != nil {
+		client.Close()
+		return nil, location, err
+	}
+	return client, location, nil
+}
+
+func runAdminRulesList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := approvalRulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rules, err := client.GetApprovalRules(ctx, derefString(location.Id))
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(rules)
+	}
+
+	if rules.RequiresApproval {
+		fmt.Printf("%s requires approval: bookings no enabled rule covers wait for a manager.\n\n", derefString(location.Name))
+	} else {
+		fmt.Printf("%s does not require approval: every booking is confirmed.\n", derefString(location.Name))
+		fmt.Printf("Rules take effect after: miles admin rules require %q on\n\n", derefString(location.Name))
+	}
+
+	if len(rules.Rules) == 0 {
+		fmt.Println("No approval rules.")
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 4},
+		{header: "NAME", width: 28, minWidth: 12, priority: 3},
+		{header: "AUTO-APPROVES", width: 32, minWidth: 16, priority: 2},
+		{header: "ENABLED", width: 7, priority: 1},
+	}
+	var rows [][]string
+	for _, rule := range rules.Rules {
+		enabled := "yes"
+		if !rule.Enabled {
+			enabled = "no"
+		}
+		rows = append(rows, []string{rule.Id, rule.Name, milesapi.DescribeRule(rule), enabled})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminRulesAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	input := milesapi.ApprovalRuleInput{Name: ruleName}
+	if err := applyRuleFlags(cmd, &input); err != nil {
+		return err
+	}
+	if ruleDisabled {
+		enabled := false
+		input.Enabled = &enabled
+	}
+
+	client, location, err := approvalRulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.CreateApprovalRule(ctx, derefString(location.Id), input)
+	if err != nil {
+		return err
+	}
+	return printRuleResult("Added", location, result)
+}
+
+func runAdminRulesEdit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := approvalRulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rule, err := findRule(ctx, client, location, args[1])
+	if err != nil {
+		return err
+	}
+
+	input := rule.Input()
+	if cmd.Flags().Changed("name") {
+		input.Name = ruleName
+	}
+	if err := applyRuleFlags(cmd, &input); err != nil {
+		return err
+	}
+
+	result, err := client.UpdateApprovalRule(ctx, derefString(location.Id), rule.Id, input)
+	if err != nil {
+		return err
+	}
+	return printRuleResult("Updated", location, result)
+}
+
+func runAdminRulesToggle(ctx context.Context, args []string, enabled bool) error {
+	client, location, err := approvalRulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rule, err := findRule(ctx, client, location, args[1])
+	if err != nil {
+		return err
+	}
+
+	input := rule.Input()
+	input.Enabled = &enabled
+	result, err := client.UpdateApprovalRule(ctx, derefString(location.Id), rule.Id, input)
+	if err != nil {
+		return err
+	}
+
+	verb := "Enabled"
+	if !enabled {
+		verb = "Disabled"
+	}
+	return printRuleResult(verb, location, result)
+}
+
+func runAdminRulesRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := approvalRulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.DeleteApprovalRule(ctx, derefString(location.Id), args[1]); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]string{"deleted": args[1]})
+	}
+	fmt.Printf("✓ Deleted approval rule %s\n", args[1])
+	return nil
+}
+
+func runAdminRulesRequire(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	var required bool
+	switch strings.ToLower(args[1]) {
+	case "on":
+		required = true
+	case "off":
+	default:
+		return fmt.Errorf("expected on or off, got %q", args[1])
+	}
+
+	client, location, err := approvalRulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.SetRequiresApproval(ctx, derefString(location.Id), required); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]any{"locationId": derefString(location.Id), "requiresApproval": required})
+	}
+	if required {
+		fmt.Printf("✓ New bookings at %s now need approval unless a rule covers them\n", derefString(location.Name))
+	} else {
+		fmt.Printf("✓ New bookings at %s are confirmed straight away\n", derefString(location.Name))
+	}
+	return nil
+}
+
+// applyRuleFlags copies the condition flags that were given onto input
+func applyRuleFlags(cmd *cobra.Command, input *milesapi.ApprovalRuleInput) error {
+	flags := cmd.Flags()
+	if flags.Changed("max") {
+		switch {
+		case ruleMax < 0:
+			return fmt.Errorf("--max must not be negative")
+		case ruleMax == 0:
+			input.MaxDurationMinutes = nil
+		default:
+			minutes := int(ruleMax.Round(time.Minute).Minutes())
+			if minutes < 1 {
+				return fmt.Errorf("--max must be at least 1m")
+			}
+			input.MaxDurationMinutes = &minutes
+		}
+	}
+	if flags.Changed("outside-core-hours") {
+		input.OutsideCoreHours = &ruleOutsideCoreHours
+	}
+	if flags.Changed("core") {
+		if !coreHoursPattern.MatchString(ruleCore) {
+			return fmt.Errorf("--core must look like 09:00-16:00")
+		}
+		start, end, _ := strings.Cut(ruleCore, "-")
+		if start >= end {
+			return fmt.Errorf("--core must end after it starts")
+		}
+		input.CoreStart, input.CoreEnd = &start, &end
+	}
+	return nil
+}
+
+// findRule returns a location's rule by ID
+func findRule(ctx context.Context, client config.API, location milesapi.Location, ruleID string) (milesapi.ApprovalRule, error) {
+	rules, err := client.GetApprovalRules(ctx, derefString(location.Id))
+	if err != nil {
+		return milesapi.ApprovalRule{}, err
+	}
+	for _, rule := range rules.Rules {
+		if rule.Id == ruleID {
+			return rule, nil
+		}
+	}
+	return milesapi.ApprovalRule{}, fmt.Errorf("no approval rule %s at %s. Run 'miles admin rules list %s'", ruleID, derefString(location.Name), derefString(location.Id))
+}
+
+// printRuleResult reports a created or changed rule
+func printRuleResult(verb string, location milesapi.Location, result *milesapi.ApprovalRuleResult) error {
+	if output == "json" {
+		return outputJSON(result)
+	}
+	if result.Rule != nil {
+		fmt.Printf("✓ %s rule %q at %s: auto-approves %s\n", verb, result.Rule.Name, derefString(location.Name), milesapi.DescribeRule(*result.Rule))
+	}
+	if result.Approved != nil && *result.Approved > 0 {
+		fmt.Printf("✓ Confirmed %d pending booking(s) the rules now cover\n", *result.Approved)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/secret"
+	"github.com/spf13/viper"
+)
+
+// descriptionKey returns the team's key for encrypted descriptions from
+// description_key in the config, or nil when there is none
+func descriptionKey() ([]byte, error) {
+	key, err := secret.ParseKey(viper.GetString("description_key"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid description_key: %w", err)
+	}
+	return key, nil
+}
+
+// sealDescription encrypts a new booking's description when asked to with
+// --encrypt (or encrypt_descriptions in the config)
+func sealDescription(description string) (string, error) {
+	if description == "" || !viper.GetBool("encrypt_descriptions") {
+		return description, nil
+	}
+	key, err := descriptionKey()
+	if err != nil {
+		return "", err
+	}
+	if key == nil {
+		return "", fmt.Errorf("encrypting descriptions needs description_key in the config (generate one with 'openssl rand -base64 32')")
+	}
+	return secret.Encrypt(key, description)
+}
+
+// revealDescriptions decrypts encrypted descriptions in place. Without the
+// right key they read "[encrypted]".
+func revealDescriptions(bookings []milesapi.Booking) error {
+	key, err := descriptionKey()
+	if err != nil {
+		return err
+	}
+	for i, booking := range bookings {
+		if booking.Description != nil && secret.IsEncrypted(*booking.Description) {
+			description := secret.Reveal(key, *booking.Description)
+			bookings[i].Description = &description
+		}
+	}
+	return nil
+}
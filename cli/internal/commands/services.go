@@ -0,0 +1,286 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var adminServicesCmd = &cobra.Command{
+	Use:   "services",
+	Short: "Manage a location's services directory (admins and its managers)",
+	Long: `The services directory lists what a location offers besides meeting rooms:
+parking, lockers, bike room and the like. Everyone sees it with miles location
+show and in the TUI's location details.
+
+A service becomes reservable by naming a room with --room: booking that room
+reserves it, so model a bookable parking spot or locker as a room at the
+location. LOCATION is a location ID or name; SERVICE is a service ID or name.
+
+Examples:
+  miles admin services add Oslo "Garage parking" --category parking --quantity 12 --contact reception@miles.no
+  miles admin services add Oslo "Visitor spot" --category parking --room oslo-visitor-spot
+  miles admin services list Oslo
+  miles admin services edit Oslo "Garage parking" --quantity 10
+  miles admin services rm Oslo "Garage parking"`,
+}
+
+var adminServicesListCmd = &cobra.Command{
+	Use:               "list LOCATION",
+	Short:             "List a location's services",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminServicesList,
+}
+
+var adminServicesAddCmd = &cobra.Command{
+	Use:               "add LOCATION NAME",
+	Short:             "List a service at a location",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeRuleLocation,
+	RunE:              runAdminServicesAdd,
+}
+
+var adminServicesEditCmd = &cobra.Command{
+	Use:   "edit LOCATION SERVICE",
+	Short: "Change a listed service",
+	Long: `Change a listed service. Only the flags given change; an empty value, e.g.
+--room "", clears --description, --contact or --room.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAdminServicesEdit,
+}
+
+var adminServicesRemoveCmd = &cobra.Command{
+	Use:     "rm LOCATION SERVICE",
+	Aliases: []string{"remove", "delete"},
+	Short:   "Remove a service from the directory",
+	Args:    cobra.ExactArgs(2),
+	RunE:    runAdminServicesRemove,
+}
+
+// serviceCategories are the categories the API accepts
+var serviceCategories = []string{"parking", "lockers", "bike-room", "showers", "reception", "other"}
+
+var (
+	serviceName        string
+	serviceCategory    string
+	serviceDescription string
+	serviceQuantityArg int
+	serviceContact     string
+	serviceRoom        string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{adminServicesAddCmd, adminServicesEditCmd} {
+		cmd.Flags().StringVar(&serviceCategory, "category", "", "one of "+strings.Join(serviceCategories, ", ")+" (default other)")
+		cmd.Flags().StringVar(&serviceDescription, "description", "", `where to find it, e.g. "Level -1, entrance from Storgata"`)
+		cmd.Flags().IntVar(&serviceQuantityArg, "quantity", 0, "how many there are, e.g. 12 parking spots")
+		cmd.Flags().StringVar(&serviceContact, "contact", "", "who to ask or where to go to use it")
+		cmd.Flags().StringVar(&serviceRoom, "room", "", "room ID or name to book to reserve it")
+		cmd.RegisterFlagCompletionFunc("category", cobra.FixedCompletions(serviceCategories, cobra.ShellCompDirectiveNoFileComp))
+	}
+	adminServicesEditCmd.Flags().StringVar(&serviceName, "name", "", "new name for the service")
+
+	adminServicesCmd.AddCommand(adminServicesListCmd)
+	adminServicesCmd.AddCommand(adminServicesAddCmd)
+	adminServicesCmd.AddCommand(adminServicesEditCmd)
+	adminServicesCmd.AddCommand(adminServicesRemoveCmd)
+	adminCmd.AddCommand(adminServicesCmd)
+}
+
+// findLocationService returns the location's service with the ID or name,
+// ignoring case
+func findLocationService(ctx context.Context, client config.API, location milesapi.Location, query string) (*milesapi.LocationService, error) {
+	services, err := client.GetLocationServices(ctx, derefString(location.Id))
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		if strings.EqualFold(query, service.Id) || strings.EqualFold(query, service.Name) {
+			return &service, nil
+		}
+	}
+	return nil, fmt.Errorf("no service %q at %s. Run 'miles admin services list %q'", query, derefString(location.Name), derefString(location.Name))
+}
+
+// applyServiceFlags copies the flags given into the input, resolving --room
+// to one of the location's rooms
+func applyServiceFlags(cmd *cobra.Command, client config.API, location milesapi.Location, input *milesapi.LocationServiceInput) error {
+	ctx := cmd.Context()
+	flags := cmd.Flags()
+	if flags.Changed("category") {
+		if !slices.Contains(serviceCategories, serviceCategory) {
+			return fmt.Errorf("--category must be one of %s, got %q", strings.Join(serviceCategories, ", "), serviceCategory)
+		}
+		category := milesapi.LocationServiceInputCategory(serviceCategory)
+		input.Category = &category
+	}
+	if flags.Changed("description") {
+		input.Description = &serviceDescription
+	}
+	if flags.Changed("quantity") {
+		if serviceQuantityArg < 0 {
+			return fmt.Errorf("--quantity can't be negative")
+		}
+		input.Quantity = &serviceQuantityArg
+	}
+	if flags.Changed("contact") {
+		input.Contact = &serviceContact
+	}
+	if flags.Changed("room") {
+		roomID := ""
+		if serviceRoom != "" {
+			rooms, err := client.GetRooms(ctx, derefString(location.Id))
+			if err != nil {
+				return err
+			}
+			for _, room := range rooms {
+				if strings.EqualFold(serviceRoom, derefString(room.Id)) || strings.EqualFold(serviceRoom, derefString(room.Name)) {
+					roomID = derefString(room.Id)
+					break
+				}
+			}
+			if roomID == "" {
+				return fmt.Errorf("no room %q at %s. Run 'miles rooms -l %s'", serviceRoom, derefString(location.Name), derefString(location.Id))
+			}
+		}
+		input.RoomId = &roomID
+	}
+	return nil
+}
+
+func runAdminServicesList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	services, err := client.GetLocationServices(ctx, derefString(location.Id))
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(services)
+	}
+
+	if len(services) == 0 {
+		fmt.Printf("No services listed at %s.\n", derefString(location.Name))
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 2},
+		{header: "SERVICE", width: 24, minWidth: 12, priority: 6},
+		{header: "CATEGORY", width: 10, priority: 5},
+		{header: "QTY", width: 4, priority: 4},
+		{header: "ROOM", width: 25, priority: 3},
+		{header: "CONTACT", width: 24, minWidth: 10, priority: 1},
+	}
+	var rows [][]string
+	for _, service := range services {
+		rows = append(rows, []string{
+			service.Id,
+			service.Name,
+			string(service.Category),
+			serviceQuantity(service),
+			derefString(service.RoomId),
+			derefString(service.Contact),
+		})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminServicesAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	input := milesapi.LocationServiceInput{Name: &args[1]}
+	if err := applyServiceFlags(cmd, client, location, &input); err != nil {
+		return err
+	}
+
+	service, err := client.CreateLocationService(ctx, derefString(location.Id), input)
+	if err != nil {
+		return err
+	}
+	return printLocationService("Added", location, service)
+}
+
+func runAdminServicesEdit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	existing, err := findLocationService(ctx, client, location, args[1])
+	if err != nil {
+		return err
+	}
+
+	var input milesapi.LocationServiceInput
+	if cmd.Flags().Changed("name") {
+		input.Name = &serviceName
+	}
+	if err := applyServiceFlags(cmd, client, location, &input); err != nil {
+		return err
+	}
+	if input == (milesapi.LocationServiceInput{}) {
+		return fmt.Errorf("nothing to change. Use --name, --category, --description, --quantity, --contact or --room")
+	}
+
+	service, err := client.UpdateLocationService(ctx, derefString(location.Id), existing.Id, input)
+	if err != nil {
+		return err
+	}
+	return printLocationService("Updated", location, service)
+}
+
+func runAdminServicesRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, location, err := rulesClient(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	service, err := findLocationService(ctx, client, location, args[1])
+	if err != nil {
+		return err
+	}
+	if err := client.DeleteLocationService(ctx, derefString(location.Id), service.Id); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]string{"deleted": service.Id})
+	}
+	fmt.Printf("✓ Removed %s from %s\n", service.Name, derefString(location.Name))
+	return nil
+}
+
+// printLocationService reports a service that was added or updated
+func printLocationService(verb string, location milesapi.Location, service *milesapi.LocationService) error {
+	if output == "json" {
+		return outputJSON(service)
+	}
+	fmt.Printf("✓ %s %s (%s) at %s\n", verb, service.Name, service.Category, derefString(location.Name))
+	if roomID := derefString(service.RoomId); roomID != "" {
+		fmt.Printf("\nReserve it with: miles book -r %s\n", roomID)
+	}
+	return nil
+}
@@ -0,0 +1,519 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Share settings, aliases and templates",
+	Long: `Export the settings in ~/.miles-cli.yaml that a team can share, and import
+a file someone else exported, so everyone starts from the same profile.
+
+Exported files hold the shareable settings (API address, transport, CSV and
+booking defaults and the like), aliases, output templates and CSV header
+sets. Personal settings - the login token, the description key, calendar
+client secrets, the summary webhook and the offices file path - are never
+exported or imported.`,
+}
+
+var configExportCmd = &cobra.Command{
+	Use:   "export [FILE]",
+	Short: "Write your shareable settings to a file",
+	Long: `Write your shareable settings, aliases, templates and CSV header sets to
+FILE, or to stdout without one.
+
+Examples:
+  miles config export team.yaml
+  miles config export | pbcopy`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigExport,
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Add settings from an exported file to yours",
+	Long: `Check FILE against the settings schema and add it to ~/.miles-cli.yaml.
+Nothing is written if any part of the file is invalid. FILE may be - for
+stdin.
+
+--strategy decides what happens to settings you already have:
+  merge    the file's values win; settings it doesn't mention are kept (default)
+  keep     your values win; only settings you don't have are added
+  replace  the file replaces your shared settings, aliases, templates and
+           header sets; personal settings such as your login are kept
+
+Examples:
+  miles config import team.yaml --dry-run     # See what would change
+  miles config import team.yaml
+  miles config import team.yaml --strategy keep`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigImport,
+}
+
+var (
+	configImportStrategy string
+	configImportDryRun   bool
+)
+
+// settingsFileVersion is the version of the exported file format
+const settingsFileVersion = 1
+
+// Import strategies
+const (
+	strategyMerge   = "merge"
+	strategyKeep    = "keep"
+	strategyReplace = "replace"
+)
+
+func init() {
+	configImportCmd.Flags().StringVar(&configImportStrategy, "strategy", strategyMerge, "how to treat settings you already have: merge, keep or replace")
+	configImportCmd.Flags().BoolVar(&configImportDryRun, "dry-run", false, "show the changes without saving them")
+	configImportCmd.RegisterFlagCompletionFunc("strategy", cobra.FixedCompletions(
+		[]string{strategyMerge, strategyKeep, strategyReplace}, cobra.ShellCompDirectiveNoFileComp))
+
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+}
+
+// settingsFile is the format of exported settings
+type settingsFile struct {
+	Version       int                          `yaml:"version"`
+	Settings      map[string]any               `yaml:"settings,omitempty"`
+	Aliases       map[string]string            `yaml:"aliases,omitempty"`
+	Templates     map[string]string            `yaml:"templates,omitempty"`
+	CSVHeaderSets map[string]map[string]string `yaml:"csv_header_sets,omitempty"`
+}
+
+// sharedSettings are the config keys a team can share, each with a check
+// of its value
+var sharedSettings = map[string]func(any) error{
+	"api_url":                   urlSetting,
+	"transport":                 oneOfSetting(string(config.TransportREST), string(config.TransportGRPC)),
+	"grpc_addr":                 stringSetting,
+	"grpc_insecure":             boolSetting,
+	"booking_boundary":          boundarySetting,
+	"buffer":                    bufferSetting,
+	"location":                  stringSetting,
+	"busy_calendar":             oneOfSetting("gcal", "outlook"),
+	"csv_delimiter":             stringSetting,
+	"csv_bom":                   boolSetting,
+	"csv_headers":               stringSetting,
+	"outlook_tenant":            stringSetting,
+	"outlook_client_id":         stringSetting,
+	"gcal_client_id":            stringSetting,
+	"encrypt_descriptions":      boolSetting,
+	"update_url":                urlSetting,
+	"web_url":                   urlSetting,
+	"update_public_key":         stringSetting,
+	"timesheet_projects":        timesheetProjectsSetting,
+	"timesheet_default_project": stringSetting,
+	"confirm":                   confirmSetting,
+	"safe_mode":                 boolSetting,
+}
+
+// personalSettings are config keys that belong to one person and are
+// never exported or imported
+var personalSettings = []string{"token", "description_key", "gcal_client_secret", "summary_webhook", "offices_file"}
+
+func runConfigExport(cmd *cobra.Command, args []string) error {
+	local, err := readConfigFile(configFilePath())
+	if err != nil {
+		return err
+	}
+
+	file := settingsFile{
+		Version:       settingsFileVersion,
+		Settings:      map[string]any{},
+		Aliases:       stringMap(local["aliases"]),
+		Templates:     stringMap(local["templates"]),
+		CSVHeaderSets: headerSets(local["csv_header_sets"]),
+	}
+	for key := range sharedSettings {
+		if value, ok := local[key]; ok {
+			file.Settings[key] = value
+		}
+	}
+
+	data, err := marshalSettings(file)
+	if err != nil {
+		return err
+	}
+	data = append([]byte("# Miles CLI settings. Add them to yours with 'miles config import'.\n"), data...)
+
+	if len(args) == 0 || args[0] == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(args[0], data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", args[0], err)
+	}
+	fmt.Printf("✓ Exported %d settings, %d aliases, %d templates and %d CSV header sets to %s\n",
+		len(file.Settings), len(file.Aliases), len(file.Templates), len(file.CSVHeaderSets), args[0])
+	return nil
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	strategy := configImportStrategy
+	if strategy != strategyMerge && strategy != strategyKeep && strategy != strategyReplace {
+		return fmt.Errorf("invalid --strategy %q: use merge, keep or replace", strategy)
+	}
+
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+	file, err := parseSettingsFile(data)
+	if err != nil {
+		return fmt.Errorf("invalid settings in %s: %w", args[0], err)
+	}
+
+	path := configFilePath()
+	local, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	var changes []settingChange
+	localSettings := map[string]any{}
+	for key := range sharedSettings {
+		if value, ok := local[key]; ok {
+			localSettings[key] = value
+		}
+	}
+	settings := mergeSettings(&changes, "", localSettings, file.Settings, strategy, settingValue)
+	aliases := mergeSettings(&changes, "aliases.", stringMap(local["aliases"]), file.Aliases, strategy, settingValue)
+	templates := mergeSettings(&changes, "templates.", stringMap(local["templates"]), file.Templates, strategy, settingValue)
+	sets := mergeSettings(&changes, "csv_header_sets.", headerSets(local["csv_header_sets"]), file.CSVHeaderSets, strategy, headerSetValue)
+
+	fmt.Printf("Importing %s into %s (%s):\n", args[0], path, strategy)
+	if len(changes) == 0 {
+		fmt.Println("  Nothing to change")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Println("  " + change.String())
+	}
+	if configImportDryRun {
+		fmt.Println("\nDry run, nothing saved")
+		return nil
+	}
+
+	for key := range sharedSettings {
+		delete(local, key)
+	}
+	maps.Copy(local, settings)
+	for key, section := range map[string]any{"aliases": aliases, "templates": templates, "csv_header_sets": sets} {
+		if sectionLen(section) == 0 {
+			delete(local, key)
+		} else {
+			local[key] = section
+		}
+	}
+	if err := writeConfigFile(path, local); err != nil {
+		return err
+	}
+	fmt.Printf("\n✓ Saved %d change(s)\n", len(changes))
+	return nil
+}
+
+// parseSettingsFile reads an exported file and checks it against the
+// schema, reporting every problem at once
+func parseSettingsFile(data []byte) (settingsFile, error) {
+	var file settingsFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return file, err
+	}
+
+	var problems []string
+	if file.Version != settingsFileVersion {
+		problems = append(problems, fmt.Sprintf("version: expected %d, got %d", settingsFileVersion, file.Version))
+	}
+	for _, key := range slices.Sorted(maps.Keys(file.Settings)) {
+		check, ok := sharedSettings[key]
+		switch {
+		case slices.Contains(personalSettings, key):
+			problems = append(problems, fmt.Sprintf("settings.%s: personal settings can't be imported", key))
+		case !ok:
+			problems = append(problems, fmt.Sprintf("settings.%s: unknown setting", key))
+		default:
+			if err := check(file.Settings[key]); err != nil {
+				problems = append(problems, fmt.Sprintf("settings.%s: %v", key, err))
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(file.Aliases)) {
+		words, err := splitCommandLine(file.Aliases[name])
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("aliases.%s: %v", name, err))
+		case len(words) == 0:
+			problems = append(problems, fmt.Sprintf("aliases.%s: empty", name))
+		case isBuiltinCommand(name):
+			problems = append(problems, fmt.Sprintf("aliases.%s: %q is a built-in command", name, name))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(file.Templates)) {
+		if _, err := template.New(name).Funcs(templateFuncs).Parse(file.Templates[name]); err != nil {
+			problems = append(problems, fmt.Sprintf("templates.%s: %v", name, err))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(file.CSVHeaderSets)) {
+		if name == "default" || name == "keys" {
+			problems = append(problems, fmt.Sprintf("csv_header_sets.%s: %q is built in", name, name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return file, errors.New("\n  " + strings.Join(problems, "\n  "))
+	}
+	return file, nil
+}
+
+// settingChange is one difference an import makes
+type settingChange struct {
+	op       byte // '+' added, '~' changed, '-' removed
+	key      string
+	from, to string
+}
+
+func (c settingChange) String() string {
+	switch c.op {
+	case '+':
+		return fmt.Sprintf("+ %s = %s", c.key, c.to)
+	case '~':
+		return fmt.Sprintf("~ %s: %s → %s", c.key, c.from, c.to)
+	}
+	return "- " + c.key
+}
+
+// mergeSettings combines local and incoming entries of one section by the
+// strategy, noting each difference in changes
+func mergeSettings[V any](changes *[]settingChange, prefix string, local, incoming map[string]V, strategy string, format func(V) string) map[string]V {
+	result := maps.Clone(local)
+	if result == nil || strategy == strategyReplace {
+		result = map[string]V{}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(incoming)) {
+		value := incoming[key]
+		old, exists := local[key]
+		switch {
+		case !exists:
+			*changes = append(*changes, settingChange{op: '+', key: prefix + key, to: format(value)})
+		case format(old) == format(value):
+		case strategy == strategyKeep:
+			continue
+		default:
+			*changes = append(*changes, settingChange{op: '~', key: prefix + key, from: format(old), to: format(value)})
+		}
+		result[key] = value
+	}
+
+	if strategy == strategyReplace {
+		for _, key := range slices.Sorted(maps.Keys(local)) {
+			if _, ok := incoming[key]; !ok {
+				*changes = append(*changes, settingChange{op: '-', key: prefix + key})
+			}
+		}
+	}
+	return result
+}
+
+// settingValue formats a value for the list of changes
+func settingValue[V any](value V) string {
+	text := fmt.Sprint(value)
+	if strings.ContainsAny(text, "\n\t") {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+// headerSetValue formats a CSV header set as "key=Header, ..."
+func headerSetValue(set map[string]string) string {
+	var pairs []string
+	for _, key := range slices.Sorted(maps.Keys(set)) {
+		pairs = append(pairs, key+"="+set[key])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// configFilePath returns the config file in use, or the default one in the
+// home directory when there is none yet
+func configFilePath() string {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".miles-cli.yaml"
+	}
+	return filepath.Join(home, ".miles-cli.yaml")
+}
+
+// readConfigFile reads the config file as it is on disk, without defaults,
+// flags or environment variables; a missing file is empty
+func readConfigFile(path string) (map[string]any, error) {
+	local := map[string]any{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return local, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &local); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if local == nil {
+		local = map[string]any{}
+	}
+	return local, nil
+}
+
+// writeConfigFile saves the config file, readable only by its owner as it
+// holds the login token
+func writeConfigFile(path string, local map[string]any) error {
+	data, err := marshalSettings(local)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// marshalSettings encodes settings as YAML indented like hand-written config
+func marshalSettings(value any) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), encoder.Close()
+}
+
+// stringMap converts a YAML mapping of strings; anything else is empty
+func stringMap(value any) map[string]string {
+	raw, _ := value.(map[string]any)
+	out := make(map[string]string, len(raw))
+	for key, v := range raw {
+		out[key] = fmt.Sprint(v)
+	}
+	return out
+}
+
+// headerSets converts the csv_header_sets mapping
+func headerSets(value any) map[string]map[string]string {
+	raw, _ := value.(map[string]any)
+	out := make(map[string]map[string]string, len(raw))
+	for name, set := range raw {
+		out[name] = stringMap(set)
+	}
+	return out
+}
+
+// sectionLen returns the number of entries in one of the section maps
+func sectionLen(section any) int {
+	switch s := section.(type) {
+	case map[string]string:
+		return len(s)
+	case map[string]map[string]string:
+		return len(s)
+	}
+	return 0
+}
+
+func stringSetting(value any) error {
+	if _, ok := value.(string); !ok {
+		return fmt.Errorf("must be a string")
+	}
+	return nil
+}
+
+func boolSetting(value any) error {
+	if _, ok := value.(bool); !ok {
+		return fmt.Errorf("must be true or false")
+	}
+	return nil
+}
+
+func urlSetting(value any) error {
+	text, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("must be a URL")
+	}
+	u, err := url.Parse(text)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an http(s) URL, got %q", text)
+	}
+	return nil
+}
+
+func oneOfSetting(choices ...string) func(any) error {
+	return func(value any) error {
+		text, _ := value.(string)
+		if !slices.Contains(choices, text) {
+			return fmt.Errorf("must be %s", strings.Join(choices, " or "))
+		}
+		return nil
+	}
+}
+
+func boundarySetting(value any) error {
+	text, _ := value.(string)
+	_, err := config.ParseBoundary(text)
+	return err
+}
+
+func timesheetProjectsSetting(value any) error {
+	rules, ok := value.([]any)
+	if !ok {
+		return fmt.Errorf("must be a list of rules with match and project")
+	}
+	for i, rule := range rules {
+		fields, _ := rule.(map[string]any)
+		for _, key := range []string{"match", "project"} {
+			if text, _ := fields[key].(string); text == "" {
+				return fmt.Errorf("rule %d: %s must be set", i+1, key)
+			}
+		}
+	}
+	return nil
+}
+
+func bufferSetting(value any) error {
+	text, _ := value.(string)
+	buffer, err := time.ParseDuration(text)
+	if err != nil || buffer < 0 || buffer > maxBookingBuffer {
+		return fmt.Errorf("must be a duration between 0 and %s, e.g. 10m", formatDuration(maxBookingBuffer))
+	}
+	return nil
+}
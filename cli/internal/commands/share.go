@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miles/booking-tui/pkg/deeplink"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var shareCmd = &cobra.Command{
+	Use:   "share BOOKING_ID",
+	Short: "Print links to a booking to send to others",
+	Long: `Print two links to a booking: a miles:// link that opens it in the TUI,
+for those who registered the link handler with 'miles open-link --register',
+and a link to it in the web app.
+
+The web app is at web_url in config (default http://localhost:5173).
+
+Examples:
+  miles share BOOK123
+  miles share BOOK123 -o json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBookingIDs,
+	RunE:              runShare,
+}
+
+// bookingLinks is the -o json form of miles share
+type bookingLinks struct {
+	ID   string `json:"id"`
+	Link string `json:"link"`
+	Web  string `json:"web"`
+}
+
+func runShare(cmd *cobra.Command, args []string) error {
+	links := bookingLinks{
+		ID:   args[0],
+		Link: deeplink.Booking(args[0]),
+		Web:  deeplink.BookingWeb(viper.GetString("web_url"), args[0]),
+	}
+	if output == "json" {
+		return outputJSON(links)
+	}
+	fmt.Printf("TUI: %s\n", links.Link)
+	fmt.Printf("Web: %s\n", links.Web)
+	return nil
+}
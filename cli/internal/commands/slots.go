@@ -0,0 +1,310 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/snippet"
+	"github.com/spf13/cobra"
+)
+
+var adminSlotsCmd = &cobra.Command{
+	Use:   "slots",
+	Short: "Manage the organization's named time slots (admins only)",
+	Long: `Time slots are named times of day shared by everyone, like "standup" at
+09:00-09:15. Book one with miles book --slot NAME; the TUI offers them on its
+time step. Times are wall-clock HH:MM in each booker's time zone. Anyone
+signed in can list slots; only admins can change them. SLOT is a slot ID or
+name.
+
+Examples:
+  miles admin slots add standup --start 09:00 --end 09:15
+  miles admin slots add lunch-and-learn --start 11:30 --end 12:30 --description "Fridays"
+  miles admin slots list
+  miles admin slots edit standup --end 09:20
+  miles admin slots rm standup`,
+}
+
+var adminSlotsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List time slots",
+	Args:  cobra.NoArgs,
+	RunE:  runAdminSlotsList,
+}
+
+var adminSlotsAddCmd = &cobra.Command{
+	Use:   "add NAME",
+	Short: "Define a time slot",
+	Long:  `Define a time slot. NAME is lowercase letters, digits and dashes, like lunch-and-learn.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdminSlotsAdd,
+}
+
+var adminSlotsEditCmd = &cobra.Command{
+	Use:               "edit SLOT",
+	Short:             "Rename a time slot or change its times",
+	Long:              `Change a time slot. Only the flags given change.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSlotNames,
+	RunE:              runAdminSlotsEdit,
+}
+
+var adminSlotsRemoveCmd = &cobra.Command{
+	Use:               "rm SLOT",
+	Aliases:           []string{"remove", "delete"},
+	Short:             "Delete a time slot, keeping bookings made with it",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSlotNames,
+	RunE:              runAdminSlotsRemove,
+}
+
+var (
+	slotName        string
+	slotDescription string
+	slotStart       string
+	slotEnd         string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{adminSlotsAddCmd, adminSlotsEditCmd} {
+		cmd.Flags().StringVar(&slotStart, "start", "", "start time, 24-hour HH:MM")
+		cmd.Flags().StringVar(&slotEnd, "end", "", "end time, 24-hour HH:MM")
+		cmd.Flags().StringVar(&slotDescription, "description", "", `describes the slot, e.g. "Fridays"`)
+	}
+	adminSlotsAddCmd.MarkFlagRequired("start")
+	adminSlotsAddCmd.MarkFlagRequired("end")
+	adminSlotsEditCmd.Flags().StringVar(&slotName, "name", "", "new name for the slot")
+
+	adminSlotsCmd.AddCommand(adminSlotsListCmd)
+	adminSlotsCmd.AddCommand(adminSlotsAddCmd)
+	adminSlotsCmd.AddCommand(adminSlotsEditCmd)
+	adminSlotsCmd.AddCommand(adminSlotsRemoveCmd)
+	adminCmd.AddCommand(adminSlotsCmd)
+}
+
+// completeSlotNames completes a SLOT argument or the --slot flag
+func completeSlotNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := zonesClient(ctx, false)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer client.Close()
+
+	slots, err := client.GetTimeSlots(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, slot := range slots {
+		names = append(names, fmt.Sprintf("%s\t%s-%s", slot.Name, slot.StartTime, slot.EndTime))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// findTimeSlot returns the time slot with the ID or name, ignoring case
+func findTimeSlot(ctx context.Context, client config.API, query string) (*milesapi.TimeSlot, error) {
+	slots, err := client.GetTimeSlots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, slot := range slots {
+		if strings.EqualFold(query, slot.Id) || strings.EqualFold(query, slot.Name) {
+			return &slot, nil
+		}
+	}
+	return nil, fmt.Errorf("no time slot matches %q. Run 'miles admin slots list' to list slots", query)
+}
+
+// slotOn returns when a time slot starts and ends on the given day, in the
+// local time zone
+func slotOn(slot *milesapi.TimeSlot, day time.Time) (start, end time.Time, err error) {
+	from, err := time.Parse("15:04", slot.StartTime)
+	if err != nil {
+		return start, end, fmt.Errorf("time slot %s has an invalid start %q", slot.Name, slot.StartTime)
+	}
+	to, err := time.Parse("15:04", slot.EndTime)
+	if err != nil {
+		return start, end, fmt.Errorf("time slot %s has an invalid end %q", slot.Name, slot.EndTime)
+	}
+	start = time.Date(day.Year(), day.Month(), day.Day(), from.Hour(), from.Minute(), 0, 0, time.Local)
+	end = time.Date(day.Year(), day.Month(), day.Day(), to.Hour(), to.Minute(), 0, 0, time.Local)
+	return start, end, nil
+}
+
+// resolveBookSlot turns --slot and --date into the start and end to book
+func resolveBookSlot(ctx context.Context, client config.API, name, date string) (start, end time.Time, err error) {
+	day, err := snippet.ParseDate(date, time.Now())
+	if err != nil {
+		return start, end, fmt.Errorf("invalid --date: %w", err)
+	}
+	slot, err := findTimeSlot(ctx, client, name)
+	if err != nil {
+		return start, end, err
+	}
+	return slotOn(slot, day)
+}
+
+// checkClockTime validates a --start or --end flag
+func checkClockTime(flag, value string) error {
+	if _, err := time.Parse("15:04", value); err != nil || len(value) != 5 {
+		return fmt.Errorf("--%s must be a 24-hour HH:MM time like 09:15, got %q", flag, value)
+	}
+	return nil
+}
+
+func runAdminSlotsList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := zonesClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	slots, err := client.GetTimeSlots(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(slots)
+	}
+
+	if len(slots) == 0 {
+		fmt.Println("No time slots.")
+		fmt.Println("Admins define them with: miles admin slots add standup --start 09:00 --end 09:15")
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "NAME", width: 20, minWidth: 10, priority: 4},
+		{header: "TIME", width: 11, priority: 5},
+		{header: "DESCRIPTION", width: 30, minWidth: 10, priority: 2},
+		{header: "ID", width: 25, priority: 1},
+	}
+	var rows [][]string
+	for _, slot := range slots {
+		rows = append(rows, []string{
+			slot.Name,
+			slot.StartTime + "-" + slot.EndTime,
+			derefString(slot.Description),
+			slot.Id,
+		})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminSlotsAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if err := checkClockTime("start", slotStart); err != nil {
+		return err
+	}
+	if err := checkClockTime("end", slotEnd); err != nil {
+		return err
+	}
+	if slotEnd <= slotStart {
+		return fmt.Errorf("--end must be after --start")
+	}
+
+	client, err := zonesClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	name := strings.ToLower(args[0])
+	input := milesapi.TimeSlotInput{Name: &name, StartTime: &slotStart, EndTime: &slotEnd}
+	if slotDescription != "" {
+		input.Description = &slotDescription
+	}
+	slot, err := client.CreateTimeSlot(ctx, input)
+	if err != nil {
+		return err
+	}
+	return printTimeSlot("Added", slot)
+}
+
+func runAdminSlotsEdit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := zonesClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	existing, err := findTimeSlot(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	var input milesapi.TimeSlotInput
+	if cmd.Flags().Changed("name") {
+		name := strings.ToLower(slotName)
+		input.Name = &name
+	}
+	if cmd.Flags().Changed("description") {
+		input.Description = &slotDescription
+	}
+	if cmd.Flags().Changed("start") {
+		if err := checkClockTime("start", slotStart); err != nil {
+			return err
+		}
+		input.StartTime = &slotStart
+	}
+	if cmd.Flags().Changed("end") {
+		if err := checkClockTime("end", slotEnd); err != nil {
+			return err
+		}
+		input.EndTime = &slotEnd
+	}
+	if input == (milesapi.TimeSlotInput{}) {
+		return fmt.Errorf("nothing to change. Use --name, --description, --start or --end")
+	}
+
+	slot, err := client.UpdateTimeSlot(ctx, existing.Id, input)
+	if err != nil {
+		return err
+	}
+	return printTimeSlot("Updated", slot)
+}
+
+func runAdminSlotsRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := zonesClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	slot, err := findTimeSlot(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	if err := client.DeleteTimeSlot(ctx, slot.Id); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]string{"deleted": slot.Id})
+	}
+	fmt.Printf("✓ Deleted time slot %s\n", slot.Name)
+	return nil
+}
+
+// printTimeSlot reports a time slot that was added or updated
+func printTimeSlot(verb string, slot *milesapi.TimeSlot) error {
+	if output == "json" {
+		return outputJSON(slot)
+	}
+	fmt.Printf("✓ %s time slot %s: %s-%s\n", verb, slot.Name, slot.StartTime, slot.EndTime)
+	fmt.Printf("\nBook it with: miles book --slot %s -r ROOM -t TITLE\n", slot.Name)
+	return nil
+}
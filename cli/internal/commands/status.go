@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check that the server is up and speaks an API version miles supports",
+	Long: `Show which server miles talks to, the API version it speaks and whether
+this client supports it, and who you're signed in as.
+
+Every command checks the API version before talking to a server, so a
+server upgrade that breaks this client stops commands with a clear message
+rather than odd failures halfway. A server ahead of the client calls for
+'miles upgrade'; one behind it for upgrading the server.
+--skip-version-check goes ahead anyway, with a warning. A server found
+compatible isn't asked again for an hour; this command always asks.
+
+It exits non-zero when the server can't be reached or isn't compatible.
+
+Examples:
+  miles status
+  miles status -o json`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+var skipVersionCheck bool
+
+const (
+	// versionCacheFile remembers servers found compatible, in the home
+	// directory
+	versionCacheFile = ".miles-server.json"
+
+	// versionCheckInterval is how long a server found compatible is trusted
+	// before asking again
+	versionCheckInterval = time.Hour
+
+	// versionCheckTimeout keeps a slow server from holding up every command;
+	// the command itself reports a server that doesn't answer
+	versionCheckTimeout = 3 * time.Second
+)
+
+// versionChecked is set once the check has run in this process
+var versionChecked bool
+
+// serverStatus is 'miles status -o json' output
+type serverStatus struct {
+	Client     string `json:"client"`
+	Supported  string `json:"supported"`
+	Server     string `json:"server"`
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// ok, incompatible, unknown (a server from before versioning) or
+	// unreachable
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	SignedIn bool              `json:"signedIn"`
+	Role     milesapi.UserRole `json:"role,omitempty"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+
+	client, err := dialAPI(token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	status := serverStatus{
+		Client:    Version,
+		Supported: milesapi.SupportedAPIVersions(),
+		Server:    serverTarget(),
+		SignedIn:  token != "",
+		Role:      milesapi.TokenRole(token),
+	}
+
+	// A failure below is the answer, not an error to stop on
+	var failure error
+	version, err := client.GetVersion(ctx)
+	switch {
+	case err != nil:
+		status.Status, failure = "unreachable", err
+	case version == nil:
+		status.Status = "unknown"
+	default:
+		status.APIVersion = version.Version
+		status.Status = "ok"
+		if failure = milesapi.CheckAPIVersion(version.Version); failure != nil {
+			status.Status = "incompatible"
+		} else {
+			rememberServerVersion(serverTarget(), version.Version)
+		}
+	}
+	if failure != nil {
+		status.Error = failure.Error()
+	}
+
+	if output == "json" {
+		if err := outputJSON(status); err != nil {
+			return err
+		}
+	} else {
+		printServerStatus(status, failure)
+	}
+
+	if failure != nil {
+		// The reason is printed already
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		return failure
+	}
+	return nil
+}
+
+func printServerStatus(status serverStatus, failure error) {
+	fmt.Printf("Client:     miles %s, speaks API %s\n", status.Client, status.Supported)
+	fmt.Printf("Server:     %s\n", status.Server)
+
+	switch status.Status {
+	case "ok":
+		fmt.Printf("API:        %s ✓ compatible\n", status.APIVersion)
+	case "incompatible":
+		fmt.Printf("API:        %s ✗ not supported\n", status.APIVersion)
+	case "unknown":
+		fmt.Println("API:        unknown; the server predates versioning, so miles assumes it's compatible")
+	case "unreachable":
+		fmt.Println("API:        ✗ unreachable")
+	}
+
+	switch {
+	case !status.SignedIn:
+		fmt.Println("Signed in:  no. Run 'miles login'")
+	case status.Role != "":
+		fmt.Printf("Signed in:  yes, as %s\n", status.Role)
+	default:
+		fmt.Println("Signed in:  yes")
+	}
+
+	if failure != nil {
+		fmt.Printf("\n%s\n", versionAdvice(failure))
+	}
+}
+
+// checkServerVersion stops commands before they talk to a server speaking
+// an API version this client doesn't support; with --skip-version-check it
+// only warns.
+// Servers that don't say, and failures to ask, pass: the command's own
+// requests report a server that's down. Replays skip the check so cassettes
+// don't depend on it.
+func checkServerVersion(ctx context.Context, client config.API) error {
+	if versionChecked || replay != "" || record != "" {
+		return nil
+	}
+	versionChecked = true
+
+	target := serverTarget()
+	if knownCompatible(target) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, versionCheckTimeout)
+	defer cancel()
+	version, err := client.GetVersion(ctx)
+	if err != nil || version == nil {
+		return nil
+	}
+
+	err = milesapi.CheckAPIVersion(version.Version)
+	if err == nil {
+		rememberServerVersion(target, version.Version)
+		return nil
+	}
+	if skipVersionCheck {
+		fmt.Fprintf(os.Stderr, "⚠ %s; going ahead because of --skip-version-check\n\n", err)
+		return nil
+	}
+	return errors.New(versionAdvice(err))
+}
+
+// versionAdvice follows a failed check with what to do about it
+func versionAdvice(err error) string {
+	var incompatible *milesapi.IncompatibleError
+	switch {
+	case !errors.As(err, &incompatible):
+		return err.Error()
+	case incompatible.Newer:
+		return err.Error() + ". Run 'miles upgrade', or pass --skip-version-check to try anyway"
+	default:
+		return err.Error() + ". Ask your admin to upgrade the server, or pass --skip-version-check to try anyway"
+	}
+}
+
+// versionCache maps servers to the API version they were last found
+// compatible with
+type versionCache map[string]struct {
+	Version   string    `json:"version"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// versionCachePath returns ~/.miles-server.json
+func versionCachePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, versionCacheFile)
+}
+
+func loadVersionCache() versionCache {
+	cache := versionCache{}
+	if data, err := os.ReadFile(versionCachePath()); err == nil {
+		json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+// knownCompatible reports whether target was found compatible within the
+// last versionCheckInterval
+func knownCompatible(target string) bool {
+	entry, ok := loadVersionCache()[target]
+	return ok && time.Since(entry.CheckedAt) < versionCheckInterval &&
+		milesapi.CheckAPIVersion(entry.Version) == nil
+}
+
+// rememberServerVersion records that target speaks a compatible version.
+// Failing to write the cache only means asking again next time.
+func rememberServerVersion(target, version string) {
+	path := versionCachePath()
+	if path == "" {
+		return
+	}
+	cache := loadVersionCache()
+	entry := cache[target]
+	entry.Version, entry.CheckedAt = version, time.Now()
+	cache[target] = entry
+	if data, err := json.Marshal(cache); err == nil {
+		os.WriteFile(path, data, 0o600)
+	}
+}
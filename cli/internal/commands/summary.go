@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "End-of-day digest of your meetings",
+	Long: `Print a digest of the day: the meetings you had today, your schedule for
+tomorrow and, for managers and admins, the bookings waiting for approval.
+
+Made to be run from a shell profile or cron. --webhook also posts the digest
+to a chat webhook (Slack, Mattermost or Teams incoming webhooks) as
+{"text": "..."}; set summary_webhook in ~/.miles-cli.yaml to always do so.
+
+Examples:
+  miles summary                           # Print today's digest
+  miles summary -o json                   # As JSON, for scripts
+  miles summary --webhook https://hooks.slack.com/services/...
+
+  # Every weekday at 17:00
+  0 17 * * 1-5  miles summary --webhook https://hooks.slack.com/services/...`,
+	Args: cobra.NoArgs,
+	RunE: runSummary,
+}
+
+// summaryWebhookTimeout bounds posting the digest to a webhook
+const summaryWebhookTimeout = 10 * time.Second
+
+func init() {
+	summaryCmd.Flags().String("webhook", "", "also post the digest to this chat webhook URL")
+	viper.BindPFlag("summary_webhook", summaryCmd.Flags().Lookup("webhook"))
+}
+
+// summaryDigest is the day's digest, as printed and posted
+type summaryDigest struct {
+	Date      string           `json:"date"`
+	Completed []summaryMeeting `json:"completed"`
+	Tomorrow  []summaryMeeting `json:"tomorrow"`
+
+	// Pending is only filled in for managers and admins
+	Pending []summaryMeeting `json:"pendingApprovals,omitempty"`
+}
+
+// summaryMeeting is one booking in the digest
+type summaryMeeting struct {
+	ID    string    `json:"id"`
+	Title string    `json:"title"`
+	Room  string    `json:"room"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func runSummary(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	me, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	bookings, err := client.GetBookings(ctx)
+	if err != nil {
+		return err
+	}
+	rooms, err := client.GetRooms(ctx, "")
+	if err != nil {
+		return err
+	}
+	roomNames := make(map[string]string, len(rooms))
+	for _, room := range rooms {
+		roomNames[derefString(room.Id)] = derefString(room.Name)
+	}
+
+	manager := me.Role != nil && (*me.Role == milesapi.MANAGER || *me.Role == milesapi.ADMIN)
+	digest := buildSummary(bookings, derefString(me.Id), manager, roomNames, time.Now())
+
+	if output == "json" {
+		if err := outputJSON(digest); err != nil {
+			return err
+		}
+	} else {
+		fmt.Print(renderSummary(digest))
+	}
+
+	if webhook := viper.GetString("summary_webhook"); webhook != "" {
+		if err := postSummary(webhook, renderSummary(digest)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildSummary sorts bookings into the digest for now's day. Completed and
+// tomorrow are the user's own bookings; pending approvals are anyone's
+// upcoming bookings the caller can see, when they are a manager.
+func buildSummary(bookings []milesapi.Booking, userID string, manager bool, roomNames map[string]string, now time.Time) summaryDigest {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tomorrow := today.AddDate(0, 0, 1)
+	dayAfter := today.AddDate(0, 0, 2)
+
+	digest := summaryDigest{
+		Date:      today.Format("2006-01-02"),
+		Completed: []summaryMeeting{},
+		Tomorrow:  []summaryMeeting{},
+	}
+	for _, booking := range bookings {
+		if booking.Status == nil || booking.StartTime == nil || booking.EndTime == nil {
+			continue
+		}
+		start, end := booking.StartTime.Local(), booking.EndTime.Local()
+		meeting := summaryMeeting{
+			ID:    derefString(booking.Id),
+			Title: derefString(booking.Title),
+			Room:  roomNames[derefString(booking.RoomId)],
+			Start: start,
+			End:   end,
+		}
+		if meeting.Room == "" {
+			meeting.Room = derefString(booking.RoomId)
+		}
+
+		switch *booking.Status {
+		case milesapi.BookingStatusCANCELLED:
+			continue
+		case milesapi.BookingStatusPENDING:
+			if manager && start.After(now) {
+				digest.Pending = append(digest.Pending, meeting)
+			}
+		}
+
+		if derefString(booking.UserId) != userID {
+			continue
+		}
+		switch {
+		case !start.Before(today) && !end.After(now):
+			digest.Completed = append(digest.Completed, meeting)
+		case !start.Before(tomorrow) && start.Before(dayAfter):
+			digest.Tomorrow = append(digest.Tomorrow, meeting)
+		}
+	}
+
+	byStart := func(a, b summaryMeeting) int { return a.Start.Compare(b.Start) }
+	slices.SortFunc(digest.Completed, byStart)
+	slices.SortFunc(digest.Tomorrow, byStart)
+	slices.SortFunc(digest.Pending, byStart)
+	return digest
+}
+
+// renderSummary formats the digest as plain text, for the terminal and chat
+func renderSummary(digest summaryDigest) string {
+	var b strings.Builder
+	date, _ := time.ParseInLocation("2006-01-02", digest.Date, time.Local)
+	fmt.Fprintf(&b, "Summary for %s\n", date.Format("Monday, January 2"))
+
+	fmt.Fprintf(&b, "\nDone today (%d)\n", len(digest.Completed))
+	if len(digest.Completed) == 0 {
+		b.WriteString("  No meetings\n")
+	}
+	for _, meeting := range digest.Completed {
+		fmt.Fprintf(&b, "  %s-%s  %s (%s)\n", meeting.Start.Format("15:04"), meeting.End.Format("15:04"), meeting.Title, meeting.Room)
+	}
+
+	fmt.Fprintf(&b, "\nTomorrow (%d)\n", len(digest.Tomorrow))
+	if len(digest.Tomorrow) == 0 {
+		b.WriteString("  Nothing booked\n")
+	}
+	for _, meeting := range digest.Tomorrow {
+		fmt.Fprintf(&b, "  %s-%s  %s (%s)\n", meeting.Start.Format("15:04"), meeting.End.Format("15:04"), meeting.Title, meeting.Room)
+	}
+
+	if len(digest.Pending) > 0 {
+		fmt.Fprintf(&b, "\nWaiting for approval (%d)\n", len(digest.Pending))
+		for _, meeting := range digest.Pending {
+			fmt.Fprintf(&b, "  %s %s-%s  %s (%s)  %s\n", meeting.Start.Format("Mon Jan 2"),
+				meeting.Start.Format("15:04"), meeting.End.Format("15:04"), meeting.Title, meeting.Room, meeting.ID)
+		}
+	}
+	return b.String()
+}
+
+// postSummary sends the digest to an incoming chat webhook
+func postSummary(url, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), summaryWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post summary to webhook failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("post summary to webhook failed: %s", resp.Status)
+	}
+	return nil
+}
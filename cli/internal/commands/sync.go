@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync your bookings to an external calendar",
+	Long: `Push your confirmed room bookings to Google Calendar or Outlook as events.
+
+The first run opens a browser sign-in with the calendar provider; the token
+is cached in ~/.miles-cli/sync. Each booking is linked to the event created
+for it, so running sync again updates changed bookings instead of creating
+duplicates.
+
+Configuration (in ~/.miles-cli.yaml or MILES_* environment variables):
+  gcal_client_id       OAuth client ID for Google Calendar
+  gcal_client_secret   OAuth client secret for Google Calendar
+  outlook_client_id    Azure app registration client ID
+  outlook_tenant       Azure tenant (default: common)
+
+Examples:
+  miles sync gcal                       # Push bookings to your primary Google calendar
+  miles sync outlook --delete-cancelled # Also remove events for cancelled bookings
+  miles sync gcal --dry-run             # Show what would change`,
+}
+
+var syncGcalCmd = &cobra.Command{
+	Use:   "gcal",
+	Short: "Sync your bookings to Google Calendar",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSync(cmd.Context(), "gcal")
+	},
+}
+
+var syncOutlookCmd = &cobra.Command{
+	Use:   "outlook",
+	Short: "Sync your bookings to Outlook / Microsoft 365",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSync(cmd.Context(), "outlook")
+	},
+}
+
+var (
+	syncDeleteCancelled bool
+	syncDryRun          bool
+	syncCalendar        string
+	syncReauth          bool
+)
+
+func init() {
+	syncCmd.PersistentFlags().BoolVar(&syncDeleteCancelled, "delete-cancelled", false, "delete events for cancelled bookings")
+	syncCmd.PersistentFlags().BoolVar(&syncDryRun, "dry-run", false, "show what would change without touching the calendar")
+	syncCmd.PersistentFlags().StringVar(&syncCalendar, "calendar", "", "calendar ID to sync into (default: your primary calendar)")
+	syncCmd.PersistentFlags().BoolVar(&syncReauth, "reauth", false, "discard the cached calendar token and sign in again")
+
+	syncCmd.AddCommand(syncGcalCmd)
+	syncCmd.AddCommand(syncOutlookCmd)
+}
+
+func runSync(ctx context.Context, providerName string) error {
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	me, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	bookings, err := client.GetBookings(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Admins and managers see other people's bookings too
+	var mine []milesapi.Booking
+	for _, booking := range bookings {
+		if me.Id != nil && (booking.UserId == nil || *booking.UserId != *me.Id) {
+			continue
+		}
+		mine = append(mine, booking)
+	}
+
+	rooms := make(map[string]string)
+	if allRooms, err := client.GetRooms(ctx, ""); err == nil {
+		for _, room := range allRooms {
+			if room.Id != nil && room.Name != nil {
+				rooms[*room.Id] = *room.Name
+			}
+		}
+	}
+
+	store, err := calsync.OpenStore(providerName)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Dry runs only compare against the mapping store, so skip sign-in
+	var provider calsync.Provider
+	if !syncDryRun {
+		if syncReauth {
+			if err := calsync.ForgetToken(providerName); err != nil {
+				return err
+			}
+		}
+		provider, err = connectCalendar(ctx, providerName, syncCalendar)
+		if err != nil {
+			return err
+		}
+	}
+
+	result := calsync.Sync(ctx, provider, store, mine, calsync.Options{
+		DeleteCancelled: syncDeleteCancelled,
+		DryRun:          syncDryRun,
+		Rooms:           rooms,
+	})
+
+	if !syncDryRun {
+		if err := store.Save(); err != nil {
+			return fmt.Errorf("failed to save sync state: %w", err)
+		}
+	}
+
+	prefix := "✓ Synced"
+	if syncDryRun {
+		prefix = "Dry run:"
+	}
+	fmt.Printf("%s %d created, %d updated, %d deleted, %d unchanged\n",
+		prefix, result.Created, result.Updated, result.Deleted, result.Unchanged)
+
+	for _, failure := range result.Failed {
+		fmt.Fprintf(os.Stderr, "  ✗ %v\n", failure)
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d bookings failed to sync", len(result.Failed))
+	}
+	return nil
+}
+
+// connectCalendar signs in to an external calendar provider ("gcal" or
+// "outlook") using the OAuth app from the config file
+func connectCalendar(ctx context.Context, providerName, calendarID string) (calsync.Provider, error) {
+	switch providerName {
+	case "gcal":
+		return calsync.NewGoogle(ctx, calsync.GoogleConfig{
+			ClientID:     viper.GetString("gcal_client_id"),
+			ClientSecret: viper.GetString("gcal_client_secret"),
+			CalendarID:   calendarID,
+		})
+	case "outlook":
+		return calsync.NewOutlook(ctx, calsync.OutlookConfig{
+			ClientID:   viper.GetString("outlook_client_id"),
+			Tenant:     viper.GetString("outlook_tenant"),
+			CalendarID: calendarID,
+		})
+	default:
+		return nil, fmt.Errorf("unknown calendar provider %q (use gcal or outlook)", providerName)
+	}
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// wideOutput is the --wide flag: print every table column at full width,
+// however narrow the terminal
+var wideOutput bool
+
+// tableColumn is one column of a table printed with printTable
+type tableColumn struct {
+	header string
+	width  int
+
+	// minWidth is the narrowest the column may be truncated to on a narrow
+	// terminal; 0 keeps it at full width
+	minWidth int
+
+	// priority orders dropping columns that don't fit: the lowest goes first
+	priority int
+}
+
+// printTable prints rows under a header and a rule, fitted to the
+// terminal. When the columns are too wide, truncatable ones shrink first,
+// lowest priority first; if that isn't enough, the lowest-priority column
+// is dropped and the rest are fitted again. Output that isn't a terminal
+// is printed in full, unless COLUMNS says otherwise.
+func printTable(columns []tableColumn, rows [][]string) {
+	shown, widths := fitTable(columns, terminalWidth())
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.header
+	}
+	printTableRow(columns, header, shown, widths)
+
+	total := len(shown) - 1
+	for _, i := range shown {
+		total += widths[i]
+	}
+	fmt.Println(strings.Repeat("-", total))
+
+	for _, row := range rows {
+		printTableRow(columns, row, shown, widths)
+	}
+}
+
+// fitTable picks the columns to show and their widths for a terminal
+// available characters wide; 0 means no limit
+func fitTable(columns []tableColumn, available int) (shown []int, widths []int) {
+	for i := range columns {
+		shown = append(shown, i)
+	}
+	widths = make([]int, len(columns))
+
+	for {
+		total := len(shown) - 1
+		for _, i := range shown {
+			widths[i] = columns[i].width
+			total += widths[i]
+		}
+		if wideOutput || available <= 0 || total <= available {
+			return shown, widths
+		}
+
+		// Shrink what may be truncated, least important first
+		byPriority := sortedByPriority(columns, shown)
+		deficit := total - available
+		for _, i := range byPriority {
+			if columns[i].minWidth == 0 {
+				continue
+			}
+			cut := min(deficit, widths[i]-columns[i].minWidth)
+			widths[i] -= cut
+			deficit -= cut
+		}
+		if deficit <= 0 || len(shown) == 1 {
+			return shown, widths
+		}
+
+		// Drop the least important column and fit the rest from scratch
+		drop := byPriority[0]
+		kept := shown[:0:0]
+		for _, i := range shown {
+			if i != drop {
+				kept = append(kept, i)
+			}
+		}
+		shown = kept
+	}
+}
+
+// sortedByPriority returns the shown column indexes, lowest priority first
+func sortedByPriority(columns []tableColumn, shown []int) []int {
+	sorted := append([]int{}, shown...)
+	slices.SortStableFunc(sorted, func(a, b int) int { return columns[a].priority - columns[b].priority })
+	return sorted
+}
+
+// printTableRow prints the shown cells of a row, padded to their widths
+func printTableRow(columns []tableColumn, cells []string, shown []int, widths []int) {
+	var b strings.Builder
+	for n, i := range shown {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		// Columns that can't shrink overflow rather than lose characters
+		if columns[i].minWidth > 0 {
+			cell = truncateCell(cell, widths[i])
+		}
+		b.WriteString(cell)
+		if n < len(shown)-1 {
+			b.WriteString(strings.Repeat(" ", max(widths[i]-utf8.RuneCountInString(cell), 0)+1))
+		}
+	}
+	fmt.Println(b.String())
+}
+
+// truncateCell shortens s to at most width characters, ending in "..."
+// when cut
+func truncateCell(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// terminalWidth returns the width tables must fit: COLUMNS when set, else
+// the terminal's width, else 0 for no limit
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/viper"
+)
+
+// outputTemplate is the --template flag: inline template text or the name
+// of a template under "templates" in the config file
+var outputTemplate string
+
+// parseOutputTemplate parses the template for -o template. Call it before
+// making requests so mistakes fail fast.
+func parseOutputTemplate() (*template.Template, error) {
+	if outputTemplate == "" {
+		return nil, fmt.Errorf("-o template needs --template, e.g. --template '{{.Title}}'")
+	}
+
+	text := outputTemplate
+	if named := viper.GetStringMapString("templates"); named != nil {
+		if t, ok := named[outputTemplate]; ok {
+			text = t
+		}
+	}
+
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// writeTemplate renders one item followed by a newline
+func writeTemplate(tmpl *template.Template, data interface{}) error {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(os.Stdout, b.String())
+	return err
+}
+
+// templateFuncs are the helpers available in output templates
+var templateFuncs = template.FuncMap{
+	// localtime converts a time to the local time zone
+	"localtime": func(v interface{}) (time.Time, error) {
+		t, err := templateTime(v)
+		return t.Local(), err
+	},
+	// humanize describes a time relative to now ("in 2h", "3d ago") or a
+	// duration ("1h 30m")
+	"humanize": func(v interface{}) (string, error) {
+		if d, ok := v.(time.Duration); ok {
+			return formatDuration(d), nil
+		}
+		t, err := templateTime(v)
+		if err != nil || t.IsZero() {
+			return "", err
+		}
+		return humanizeTime(t, config.ServerNow()), nil
+	},
+}
+
+// templateTime accepts the time values templates see, including the
+// generated types' *time.Time
+func templateTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, nil
+		}
+		return *t, nil
+	}
+	return time.Time{}, fmt.Errorf("expected a time, got %s", reflect.TypeOf(v))
+}
+
+// humanizeTime describes t relative to now, rounded to the largest unit
+func humanizeTime(t, now time.Time) string {
+	d := t.Sub(now)
+	past := d < 0
+	if past {
+		d = -d
+	}
+
+	var s string
+	switch {
+	case d < 30*time.Second:
+		return "now"
+	case d < time.Hour-30*time.Second:
+		s = fmt.Sprintf("%dm", int(d.Round(time.Minute).Minutes()))
+	case d < 24*time.Hour-30*time.Minute:
+		s = fmt.Sprintf("%dh", int(d.Round(time.Hour).Hours()))
+	default:
+		s = fmt.Sprintf("%dd", int(d.Round(24*time.Hour).Hours()/24))
+	}
+
+	if past {
+		return s + " ago"
+	}
+	return "in " + s
+}
+
+// templateLookup fetches rooms and locations the first time a template
+// refers to them, so templates that don't cost no extra requests
+type templateLookup struct {
+	ctx       context.Context
+	client    config.API
+	rooms     map[string]milesapi.Room
+	locations map[string]milesapi.Location
+}
+
+func newTemplateLookup(ctx context.Context, client config.API) *templateLookup {
+	return &templateLookup{ctx: ctx, client: client}
+}
+
+func (l *templateLookup) room(id *string) (milesapi.Room, error) {
+	if l.rooms == nil {
+		rooms, err := l.client.GetRooms(l.ctx, "")
+		if err != nil {
+			return milesapi.Room{}, err
+		}
+		l.rooms = make(map[string]milesapi.Room, len(rooms))
+		for _, room := range rooms {
+			l.rooms[derefString(room.Id)] = room
+		}
+	}
+	return l.rooms[derefString(id)], nil
+}
+
+func (l *templateLookup) location(id *string) (milesapi.Location, error) {
+	if l.locations == nil {
+		locations, err := l.client.GetLocations(l.ctx)
+		if err != nil {
+			return milesapi.Location{}, err
+		}
+		l.locations = make(map[string]milesapi.Location, len(locations))
+		for _, location := range locations {
+			l.locations[derefString(location.Id)] = location
+		}
+	}
+	return l.locations[derefString(id)], nil
+}
+
+// templateBooking is a booking as templates see it: its fields plus
+// .Room and .Location
+type templateBooking struct {
+	milesapi.Booking
+	lookup *templateLookup
+}
+
+// Room returns the booked room
+func (b templateBooking) Room() (milesapi.Room, error) {
+	return b.lookup.room(b.RoomId)
+}
+
+// Location returns the booked room's location
+func (b templateBooking) Location() (milesapi.Location, error) {
+	room, err := b.Room()
+	if err != nil {
+		return milesapi.Location{}, err
+	}
+	return b.lookup.location(room.LocationId)
+}
+
+// Duration returns the booking's length
+func (b templateBooking) Duration() time.Duration {
+	if b.StartTime == nil || b.EndTime == nil {
+		return 0
+	}
+	return b.EndTime.Sub(*b.StartTime)
+}
+
+// templateRoom is a room as templates see it: its fields plus .Location
+type templateRoom struct {
+	milesapi.Room
+	lookup *templateLookup
+}
+
+// Location returns the room's location
+func (r templateRoom) Location() (milesapi.Location, error) {
+	return r.lookup.location(r.LocationId)
+}
+
+// templateEvent is a booking event as templates see it
+type templateEvent struct {
+	Type    config.BookingEventType
+	Time    time.Time
+	Booking templateBooking
+}
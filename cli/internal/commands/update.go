@@ -0,0 +1,297 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-cli/internal/workflow"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update [booking-id]",
+	Short: "Change a booking's time, title or description",
+	Long: `Change an existing booking. Only what you give is changed; the room
+stays the same.
+
+Without flags, it asks for each field in turn, starting from the booking
+as it is. With flags, scripts can reschedule a booking in one line:
+
+  -s alone moves the booking, keeping its length
+  -e alone changes when it ends, on its day: "16:00", or a length like "45m"
+  -d "" removes the description
+
+A new time is checked against the room's other bookings first. At a
+location that needs approval, moving a booking may make it pending again.
+
+Examples:
+  miles update BOOK123                                  # Interactive
+  miles update BOOK123 -s "2025-10-20 14:00"            # Move, same length
+  miles update BOOK123 -s "2025-10-20 14:00" -e 15:30
+  miles update BOOK123 -e 1h30m                         # Longer, same start
+  miles update BOOK123 -t "Design review" -d "Bring the prototypes"`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runUpdate,
+	ValidArgsFunction: completeBookingIDs,
+}
+
+var (
+	updateStartTime   string
+	updateEndTime     string
+	updateTitle       string
+	updateDescription string
+)
+
+func init() {
+	updateCmd.Flags().StringVarP(&updateStartTime, "start", "s", "", `new start time, e.g. "2025-10-19 14:00"; keeps the booking's length without -e`)
+	updateCmd.Flags().StringVarP(&updateEndTime, "end", "e", "", `new end time on the booking's day, e.g. "15:00", or its length, e.g. "45m"`)
+	updateCmd.Flags().StringVarP(&updateTitle, "title", "t", "", "new meeting title")
+	updateCmd.Flags().StringVarP(&updateDescription, "description", "d", "", `new description ("" removes it)`)
+	updateCmd.RegisterFlagCompletionFunc("start", completeStartTimes)
+	updateCmd.RegisterFlagCompletionFunc("end", completeEndTimes)
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+	bookingID := args[0]
+
+	// Create API client
+	client, err := newAPIClient(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	booking, err := findBooking(ctx, client, bookingID)
+	if err != nil {
+		return err
+	}
+	if booking.StartTime == nil || booking.EndTime == nil {
+		return fmt.Errorf("booking %s has no time set", bookingID)
+	}
+	if booking.Status != nil && *booking.Status == milesapi.BookingStatusCANCELLED {
+		return fmt.Errorf("booking %s is cancelled", bookingID)
+	}
+
+	// Descriptions are compared and edited as the user reads them
+	current := []milesapi.Booking{*booking}
+	if err := revealDescriptions(current); err != nil {
+		return err
+	}
+	description := derefString(current[0].Description)
+
+	flags := cmd.Flags()
+	anyFlagsProvided := flags.Changed("start") || flags.Changed("end") || flags.Changed("title") || flags.Changed("description")
+
+	var update milesapi.PatchApiBookingsIdJSONRequestBody
+	if anyFlagsProvided {
+		update, err = updateFromFlags(cmd, booking, description)
+	} else {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("nothing to change. Use -s, -e, -t or -d, or run it in a terminal to be asked")
+		}
+		update, err = promptUpdate(booking, description)
+	}
+	if err != nil {
+		return err
+	}
+
+	if update.StartTime == nil && update.EndTime == nil && update.Title == nil && update.Description == nil {
+		fmt.Println("Nothing changed.")
+		return nil
+	}
+
+	if update.StartTime != nil || update.EndTime != nil {
+		if err := checkUpdatedTime(ctx, client, booking, update); err != nil {
+			return err
+		}
+	}
+
+	// Only those with the team's key can read an encrypted description
+	if update.Description != nil {
+		sealed, err := sealDescription(*update.Description)
+		if err != nil {
+			return err
+		}
+		update.Description = &sealed
+	}
+
+	updated, err := client.UpdateBooking(ctx, bookingID, update)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(updated)
+	}
+	printUpdatedBooking(booking, updated, update)
+	return nil
+}
+
+// updateFromFlags builds the update from -s, -e, -t and -d, leaving out
+// values the booking already has
+func updateFromFlags(cmd *cobra.Command, booking *milesapi.Booking, description string) (milesapi.PatchApiBookingsIdJSONRequestBody, error) {
+	var update milesapi.PatchApiBookingsIdJSONRequestBody
+	start, end := *booking.StartTime, *booking.EndTime
+
+	if updateStartTime != "" {
+		newStart, err := parseTime(updateStartTime)
+		if err != nil {
+			return update, fmt.Errorf("invalid start time: %w", err)
+		}
+		// Moving a booking keeps its length unless -e says otherwise
+		end = newStart.Add(end.Sub(start))
+		start = newStart
+	}
+	if updateEndTime != "" {
+		newEnd, err := parseEnd(updateEndTime, start.Local())
+		if err != nil {
+			return update, fmt.Errorf("invalid end time: %w", err)
+		}
+		end = newEnd
+	}
+	setTimes(&update, booking, start, end)
+
+	if cmd.Flags().Changed("title") {
+		if updateTitle == "" {
+			return update, fmt.Errorf("title can't be empty")
+		}
+		if updateTitle != derefString(booking.Title) {
+			update.Title = &updateTitle
+		}
+	}
+	if cmd.Flags().Changed("description") && updateDescription != description {
+		update.Description = &updateDescription
+	}
+	return update, nil
+}
+
+// promptUpdate asks for each field, starting from the booking as it is
+func promptUpdate(booking *milesapi.Booking, description string) (milesapi.PatchApiBookingsIdJSONRequestBody, error) {
+	var update milesapi.PatchApiBookingsIdJSONRequestBody
+	fmt.Printf("✎ Editing %q, %s\n\n", derefString(booking.Title), describeSlot(*booking.StartTime, *booking.EndTime))
+
+	startInput, err := workflow.Edit("Start", booking.StartTime.Local().Format("2006-01-02 15:04"), true)
+	if err != nil {
+		return update, err
+	}
+	start, err := parseTime(startInput)
+	if err != nil {
+		return update, fmt.Errorf("invalid start time: %w", err)
+	}
+
+	// The end is offered on the new start's day, keeping the length
+	endInput, err := workflow.Edit("End (15:00 or 45m)", start.Add(booking.EndTime.Sub(*booking.StartTime)).Local().Format("15:04"), true)
+	if err != nil {
+		return update, err
+	}
+	end, err := parseEnd(endInput, start.Local())
+	if err != nil {
+		return update, fmt.Errorf("invalid end time: %w", err)
+	}
+	setTimes(&update, booking, start, end)
+
+	title, err := workflow.Edit("Title", derefString(booking.Title), true)
+	if err != nil {
+		return update, err
+	}
+	if title != derefString(booking.Title) {
+		update.Title = &title
+	}
+
+	newDescription, err := workflow.Edit("Description", description, false)
+	if err != nil {
+		return update, err
+	}
+	if newDescription != description {
+		update.Description = &newDescription
+	}
+	return update, nil
+}
+
+// setTimes puts the times that differ from the booking's into update, in UTC
+func setTimes(update *milesapi.PatchApiBookingsIdJSONRequestBody, booking *milesapi.Booking, start, end time.Time) {
+	if !start.Equal(*booking.StartTime) {
+		start = start.UTC()
+		update.StartTime = &start
+	}
+	if !end.Equal(*booking.EndTime) {
+		end = end.UTC()
+		update.EndTime = &end
+	}
+}
+
+// checkUpdatedTime checks a booking's new time against the room's length
+// limits, its location's closed days and its other bookings before it is
+// sent
+func checkUpdatedTime(ctx context.Context, client config.API, booking *milesapi.Booking, update milesapi.PatchApiBookingsIdJSONRequestBody) error {
+	start, end := *booking.StartTime, *booking.EndTime
+	if update.StartTime != nil {
+		start = *update.StartTime
+	}
+	if update.EndTime != nil {
+		end = *update.EndTime
+	}
+	if !end.After(start) {
+		return fmt.Errorf("end time must be after start time")
+	}
+
+	roomID := derefString(booking.RoomId)
+	if room, err := findRoom(ctx, client, roomID); err == nil {
+		if err := checkRoomDuration(room, start, end); err != nil {
+			return err
+		}
+		if err := checkRoomClosures(ctx, client, room, start, end); err != nil {
+			return err
+		}
+	}
+
+	// The booking itself doesn't stand in its own way
+	conflicts, err := client.CheckRoomAvailability(ctx, roomID, start, end)
+	if err != nil {
+		fmt.Printf("⚠ Could not check room availability: %v\n", err)
+		return nil
+	}
+	var others []milesapi.Booking
+	for _, conflict := range conflicts {
+		if derefString(conflict.Id) != derefString(booking.Id) {
+			others = append(others, conflict)
+		}
+	}
+	if len(others) > 0 {
+		printConflicts(others)
+		return fmt.Errorf("room is already booked between %s and %s",
+			start.Local().Format("2006-01-02 15:04"), end.Local().Format("15:04"))
+	}
+	return nil
+}
+
+// printUpdatedBooking reports what changed
+func printUpdatedBooking(before, after *milesapi.Booking, update milesapi.PatchApiBookingsIdJSONRequestBody) {
+	fmt.Printf("✓ Booking %s updated\n\n", derefString(before.Id))
+	if (update.StartTime != nil || update.EndTime != nil) && after.StartTime != nil && after.EndTime != nil {
+		fmt.Printf("Time:        %s (was %s)\n", describeSlot(*after.StartTime, *after.EndTime), describeSlot(*before.StartTime, *before.EndTime))
+	}
+	if update.Title != nil {
+		fmt.Printf("Title:       %s (was %s)\n", *update.Title, derefString(before.Title))
+	}
+	if update.Description != nil {
+		fmt.Printf("Description: changed\n")
+	}
+
+	// Moving a booking at a location that needs approval may hold it again
+	if after.Status != nil && *after.Status == milesapi.BookingStatusPENDING &&
+		(before.Status == nil || *before.Status != milesapi.BookingStatusPENDING) {
+		fmt.Println("\n⚠ The new time needs approval: the booking is pending until a manager approves it.")
+	}
+}
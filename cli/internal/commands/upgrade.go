@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/update"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade miles to the latest release",
+	Long: `Check for a newer release and replace this executable with it. The
+download is verified before anything is replaced: against the release's
+SHA-256 checksums, which must carry a valid Ed25519 signature by the
+release key: update_public_key in config, or the key release builds of
+miles carry.
+
+Releases come from GitHub unless update_url in config points elsewhere.
+--tui upgrades the miles-booking TUI found on your PATH instead.
+
+Examples:
+  miles upgrade                # Install the latest release
+  miles upgrade --check-only   # Only say whether there is one
+  miles upgrade --tui          # Upgrade miles-booking`,
+	Args: cobra.NoArgs,
+	RunE: runUpgrade,
+}
+
+var (
+	upgradeCheckOnly bool
+	upgradeTUI       bool
+)
+
+// upgradeTimeout bounds checking for and downloading a release
+const upgradeTimeout = 5 * time.Minute
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradeCheckOnly, "check-only", false, "report whether a newer release exists without installing it")
+	upgradeCmd.Flags().BoolVar(&upgradeTUI, "tui", false, "upgrade the miles-booking TUI instead of the CLI")
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	binary := "miles"
+	current := Version
+	path, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot find this executable: %w", err)
+	}
+	if upgradeTUI {
+		binary = "miles-booking"
+		if path, err = exec.LookPath(binary); err != nil {
+			return fmt.Errorf("miles-booking is not on your PATH")
+		}
+		current = tuiVersion(path)
+	}
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), upgradeTimeout)
+	defer cancel()
+
+	release, err := update.Latest(ctx, viper.GetString("update_url"))
+	if err != nil {
+		return err
+	}
+	// A TUI too old to report its version is always upgraded
+	newer := current == "" || update.Newer(current, release.Version())
+
+	if upgradeCheckOnly {
+		if output == "json" {
+			return outputJSON(map[string]interface{}{
+				"current":   current,
+				"latest":    release.Version(),
+				"available": newer,
+				"url":       release.URL,
+			})
+		}
+		if !newer {
+			fmt.Printf("%s %s is the latest version.\n", binary, current)
+			return nil
+		}
+		if current == "" {
+			current = "an unknown version"
+		}
+		fmt.Printf("%s %s is available (you have %s).\n", binary, release.Version(), current)
+		if release.URL != "" {
+			fmt.Printf("Release notes: %s\n", release.URL)
+		}
+		if upgradeTUI {
+			fmt.Println("Install it with: miles upgrade --tui")
+		} else {
+			fmt.Println("Install it with: miles upgrade")
+		}
+		return nil
+	}
+
+	if !newer {
+		fmt.Printf("%s %s is the latest version.\n", binary, current)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Downloading %s %s...\n", binary, release.Version())
+	data, err := update.Download(ctx, release, binary, viper.GetString("update_public_key"))
+	if errors.Is(err, update.ErrNoPublicKey) {
+		return fmt.Errorf("%w: this build of miles has none, so set update_public_key in config to the one the release maintainers publish", err)
+	}
+	if err != nil {
+		return err
+	}
+	if err := update.Replace(path, data); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]string{"upgraded": binary, "version": release.Version(), "path": path})
+	}
+	fmt.Printf("✓ Upgraded %s to %s (%s)\n", binary, release.Version(), path)
+	return nil
+}
+
+// tuiVersion asks the miles-booking at path for its version, returning ""
+// when it can't tell. Builds from before --version would start the TUI
+// instead, so they are given a moment and then stopped.
+func tuiVersion(path string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	// "miles-booking version 1.2.0"
+	fields := strings.Fields(string(out))
+	if len(fields) != 3 {
+		return ""
+	}
+	return fields[2]
+}
+
+// updateHint is the line 'miles --version' adds when a newer release exists
+func updateHint(current string) string {
+	// Cobra answers --version before running initializers, so update_url
+	// would otherwise not be loaded yet
+	initConfig()
+	latest, ok := update.Available(current, viper.GetString("update_url"))
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("A new version (%s) is available. Run 'miles upgrade' to install it.\n", latest)
+}
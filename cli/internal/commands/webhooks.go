@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var adminWebhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Manage booking lifecycle webhooks (admins only)",
+	Long: `Webhooks tell other systems when bookings change. The server POSTs a JSON
+payload to each webhook subscribed to the event:
+  booking.created     a booking was made
+  booking.updated     a booking's time, room, title or description changed
+  booking.approved    a pending booking was confirmed
+  booking.cancelled   a booking was cancelled or deleted
+
+Deliveries carry X-Miles-Event, X-Miles-Delivery and X-Miles-Signature
+headers. The signature is "sha256=" and the hex HMAC-SHA256 of the raw body,
+keyed with the webhook's secret; the secret is only shown when the webhook
+is added. Failed deliveries are not retried.
+
+Examples:
+  miles admin webhooks add --url https://hooks.example.com/miles --events booking.created,booking.cancelled
+  miles admin webhooks list
+  miles admin webhooks test WEBHOOK_ID
+  miles admin webhooks rm WEBHOOK_ID`,
+}
+
+var adminWebhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhooks",
+	Args:  cobra.NoArgs,
+	RunE:  runAdminWebhooksList,
+}
+
+var adminWebhooksAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a webhook",
+	Args:  cobra.NoArgs,
+	RunE:  runAdminWebhooksAdd,
+}
+
+var adminWebhooksTestCmd = &cobra.Command{
+	Use:               "test WEBHOOK_ID",
+	Short:             "Send a sample booking.created payload to a webhook",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWebhookIDs,
+	RunE:              runAdminWebhooksTest,
+}
+
+var adminWebhooksRemoveCmd = &cobra.Command{
+	Use:               "rm WEBHOOK_ID",
+	Aliases:           []string{"remove", "delete"},
+	Short:             "Delete a webhook",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWebhookIDs,
+	RunE:              runAdminWebhooksRemove,
+}
+
+var (
+	webhookURL    string
+	webhookEvents []string
+)
+
+// allWebhookEvents lists every event a webhook can subscribe to
+var allWebhookEvents = []milesapi.WebhookEvent{
+	milesapi.WebhookEventBookingCreated,
+	milesapi.WebhookEventBookingUpdated,
+	milesapi.WebhookEventBookingApproved,
+	milesapi.WebhookEventBookingCancelled,
+}
+
+func init() {
+	adminWebhooksAddCmd.Flags().StringVar(&webhookURL, "url", "", "http(s) URL deliveries are POSTed to")
+	adminWebhooksAddCmd.Flags().StringSliceVar(&webhookEvents, "events", nil, "comma-separated events to deliver, or \"all\"")
+	adminWebhooksAddCmd.MarkFlagRequired("url")
+	adminWebhooksAddCmd.MarkFlagRequired("events")
+	adminWebhooksAddCmd.RegisterFlagCompletionFunc("events", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		events := []string{"all"}
+		for _, event := range allWebhookEvents {
+			events = append(events, string(event))
+		}
+		return events, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	adminWebhooksCmd.AddCommand(adminWebhooksListCmd)
+	adminWebhooksCmd.AddCommand(adminWebhooksAddCmd)
+	adminWebhooksCmd.AddCommand(adminWebhooksTestCmd)
+	adminWebhooksCmd.AddCommand(adminWebhooksRemoveCmd)
+	adminCmd.AddCommand(adminWebhooksCmd)
+}
+
+// webhooksClient checks the caller is an admin and returns an API client
+func webhooksClient(ctx context.Context) (config.API, error) {
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return nil, fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	if err := requireRole(token, milesapi.ADMIN); err != nil {
+		return nil, err
+	}
+
+	return newAPIClient(ctx, token)
+}
+
+// completeWebhookIDs completes the WEBHOOK_ID argument
+func completeWebhookIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := webhooksClient(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer client.Close()
+
+	webhooks, err := client.GetWebhooks(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var ids []string
+	for _, webhook := range webhooks {
+		ids = append(ids, webhook.Id+"\t"+webhook.Url)
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// parseWebhookEvents validates the --events flag; "all" means every event
+func parseWebhookEvents(values []string) ([]milesapi.WebhookEvent, error) {
+	var events []milesapi.WebhookEvent
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		if value == "all" {
+			return allWebhookEvents, nil
+		}
+		event := milesapi.WebhookEvent(value)
+		if !slices.Contains(allWebhookEvents, event) {
+			return nil, fmt.Errorf("unknown event %q (use %s or all)", value, joinWebhookEvents(allWebhookEvents))
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("--events needs at least one event")
+	}
+	return events, nil
+}
+
+// joinWebhookEvents lists events separated by commas
+func joinWebhookEvents(events []milesapi.WebhookEvent) string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ",")
+}
+
+// describeDelivery summarises the last delivery to a webhook
+func describeDelivery(webhook milesapi.Webhook) string {
+	if webhook.LastDeliveryAt == nil {
+		return "never"
+	}
+	status := "no answer"
+	if webhook.LastStatus != nil && *webhook.LastStatus > 0 {
+		status = fmt.Sprintf("HTTP %d", *webhook.LastStatus)
+	}
+	return fmt.Sprintf("%s, %s", status, webhook.LastDeliveryAt.Local().Format("Jan 2 15:04"))
+}
+
+func runAdminWebhooksList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := webhooksClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	webhooks, err := client.GetWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(webhooks)
+	}
+
+	if len(webhooks) == 0 {
+		fmt.Println("No webhooks.")
+		fmt.Println("Add one with: miles admin webhooks add --url URL --events booking.created")
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 4},
+		{header: "URL", width: 40, minWidth: 16, priority: 3},
+		{header: "EVENTS", width: 36, minWidth: 16, priority: 2},
+		{header: "LAST DELIVERY", width: 22, priority: 1},
+	}
+	var rows [][]string
+	for _, webhook := range webhooks {
+		rows = append(rows, []string{webhook.Id, webhook.Url, joinWebhookEvents(webhook.Events), describeDelivery(webhook)})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminWebhooksAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if !strings.HasPrefix(webhookURL, "http://") && !strings.HasPrefix(webhookURL, "https://") {
+		return fmt.Errorf("--url must be an http(s) URL, got %q", webhookURL)
+	}
+	events, err := parseWebhookEvents(webhookEvents)
+	if err != nil {
+		return err
+	}
+
+	client, err := webhooksClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	webhook, err := client.CreateWebhook(ctx, milesapi.WebhookInput{Url: webhookURL, Events: events})
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(webhook)
+	}
+
+	fmt.Printf("✓ Added webhook %s\n", webhook.Id)
+	fmt.Printf("  URL:    %s\n", webhook.Url)
+	fmt.Printf("  Events: %s\n", joinWebhookEvents(webhook.Events))
+	if webhook.Secret != nil {
+		fmt.Printf("  Secret: %s\n\n", *webhook.Secret)
+		fmt.Println("Store the secret now; it is not shown again. Receivers verify")
+		fmt.Println("X-Miles-Signature with it.")
+	}
+	fmt.Printf("Send a sample delivery with: miles admin webhooks test %s\n", webhook.Id)
+	return nil
+}
+
+func runAdminWebhooksTest(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := webhooksClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	delivery, err := client.TestWebhook(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(delivery)
+	}
+
+	switch {
+	case delivery.Ok:
+		fmt.Printf("✓ Delivered: HTTP %d in %dms\n", delivery.Status, delivery.DurationMs)
+	case delivery.Status > 0:
+		return fmt.Errorf("delivery failed: the receiver answered HTTP %d after %dms", delivery.Status, delivery.DurationMs)
+	default:
+		return fmt.Errorf("delivery failed: %s", derefString(delivery.Error))
+	}
+	return nil
+}
+
+func runAdminWebhooksRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := webhooksClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.DeleteWebhook(ctx, args[0]); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]string{"deleted": args[0]})
+	}
+	fmt.Printf("✓ Deleted webhook %s\n", args[0])
+	return nil
+}
@@ -0,0 +1,388 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/spf13/cobra"
+)
+
+var adminZonesCmd = &cobra.Command{
+	Use:   "zones",
+	Short: "Manage zones of rooms booked together for events (admins only)",
+	Long: `A zone is a named set of rooms, such as a floor, that can be booked in one
+go for a company event with miles book --zone. Anyone signed in can list
+zones; only admins can change them. ZONE is a zone ID or name.
+
+Examples:
+  miles admin zones add Oslo-3F --rooms ROOM1,ROOM2,ROOM3 --description "Third floor"
+  miles admin zones list
+  miles admin zones edit Oslo-3F --rooms ROOM1,ROOM2,ROOM3,ROOM4
+  miles admin zones rm Oslo-3F`,
+}
+
+var adminZonesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List zones and their rooms",
+	Args:  cobra.NoArgs,
+	RunE:  runAdminZonesList,
+}
+
+var adminZonesAddCmd = &cobra.Command{
+	Use:   "add NAME",
+	Short: "Define a zone",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdminZonesAdd,
+}
+
+var adminZonesEditCmd = &cobra.Command{
+	Use:               "edit ZONE",
+	Short:             "Rename a zone or change its rooms",
+	Long:              `Change a zone. Only the flags given change; --rooms replaces the zone's rooms.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeZoneNames,
+	RunE:              runAdminZonesEdit,
+}
+
+var adminZonesRemoveCmd = &cobra.Command{
+	Use:               "rm ZONE",
+	Aliases:           []string{"remove", "delete"},
+	Short:             "Delete a zone, keeping its rooms and bookings",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeZoneNames,
+	RunE:              runAdminZonesRemove,
+}
+
+var (
+	zoneName        string
+	zoneDescription string
+	zoneRooms       []string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{adminZonesAddCmd, adminZonesEditCmd} {
+		cmd.Flags().StringSliceVar(&zoneRooms, "rooms", nil, "comma-separated IDs of the rooms in the zone")
+		cmd.Flags().StringVar(&zoneDescription, "description", "", `describes the zone, e.g. "Third floor"`)
+		cmd.RegisterFlagCompletionFunc("rooms", completeRoomIDs)
+	}
+	adminZonesAddCmd.MarkFlagRequired("rooms")
+	adminZonesEditCmd.Flags().StringVar(&zoneName, "name", "", "new name for the zone")
+
+	adminZonesCmd.AddCommand(adminZonesListCmd)
+	adminZonesCmd.AddCommand(adminZonesAddCmd)
+	adminZonesCmd.AddCommand(adminZonesEditCmd)
+	adminZonesCmd.AddCommand(adminZonesRemoveCmd)
+	adminCmd.AddCommand(adminZonesCmd)
+}
+
+// zonesClient returns an API client, checking the caller is an admin when
+// admin is set
+func zonesClient(ctx context.Context, admin bool) (config.API, error) {
+	// Check authentication
+	token := getAuthToken()
+	if token == "" {
+		return nil, fmt.Errorf("not authenticated. Run 'miles login' first")
+	}
+
+	if admin {
+		if err := requireRole(token, milesapi.ADMIN); err != nil {
+			return nil, err
+		}
+	}
+
+	return newAPIClient(ctx, token)
+}
+
+// completeZoneNames completes a ZONE argument or the --zone flag
+func completeZoneNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := zonesClient(ctx, false)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer client.Close()
+
+	zones, err := client.GetZones(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, zone := range zones {
+		names = append(names, fmt.Sprintf("%s\t%d rooms", zone.Name, len(zone.Rooms)))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// findZone returns the zone with the ID or name, ignoring case
+func findZone(ctx context.Context, client config.API, query string) (*milesapi.Zone, error) {
+	zones, err := client.GetZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		if strings.EqualFold(query, zone.Id) || strings.EqualFold(query, zone.Name) {
+			return &zone, nil
+		}
+	}
+	return nil, fmt.Errorf("no zone matches %q. Run 'miles admin zones list' to list zones", query)
+}
+
+// describeZoneRooms lists a zone's room names, marking inactive ones
+func describeZoneRooms(zone milesapi.Zone) string {
+	names := make([]string, len(zone.Rooms))
+	for i, room := range zone.Rooms {
+		names[i] = room.Name
+		if !room.IsActive {
+			names[i] += " (inactive)"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// zoneCapacity is how many people the zone's active rooms seat together
+func zoneCapacity(zone milesapi.Zone) int {
+	total := 0
+	for _, room := range zone.Rooms {
+		if room.IsActive {
+			total += room.Capacity
+		}
+	}
+	return total
+}
+
+func runAdminZonesList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := zonesClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	zones, err := client.GetZones(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(zones)
+	}
+
+	if len(zones) == 0 {
+		fmt.Println("No zones.")
+		fmt.Println("Admins define them with: miles admin zones add NAME --rooms ROOM1,ROOM2")
+		return nil
+	}
+
+	columns := []tableColumn{
+		{header: "ID", width: 25, priority: 2},
+		{header: "NAME", width: 20, minWidth: 10, priority: 5},
+		{header: "SEATS", width: 5, priority: 3},
+		{header: "ROOMS", width: 48, minWidth: 16, priority: 4},
+		{header: "DESCRIPTION", width: 24, minWidth: 10, priority: 1},
+	}
+	var rows [][]string
+	for _, zone := range zones {
+		rows = append(rows, []string{
+			zone.Id,
+			zone.Name,
+			fmt.Sprintf("%d", zoneCapacity(zone)),
+			describeZoneRooms(zone),
+			derefString(zone.Description),
+		})
+	}
+	printTable(columns, rows)
+	return nil
+}
+
+func runAdminZonesAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := zonesClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	input := milesapi.ZoneInput{Name: &args[0], RoomIds: &zoneRooms}
+	if zoneDescription != "" {
+		input.Description = &zoneDescription
+	}
+	zone, err := client.CreateZone(ctx, input)
+	if err != nil {
+		return err
+	}
+	return printZone("Added", zone)
+}
+
+func runAdminZonesEdit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := zonesClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	existing, err := findZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	var input milesapi.ZoneInput
+	if cmd.Flags().Changed("name") {
+		input.Name = &zoneName
+	}
+	if cmd.Flags().Changed("description") {
+		input.Description = &zoneDescription
+	}
+	if cmd.Flags().Changed("rooms") {
+		input.RoomIds = &zoneRooms
+	}
+	if input == (milesapi.ZoneInput{}) {
+		return fmt.Errorf("nothing to change. Use --name, --description or --rooms")
+	}
+
+	zone, err := client.UpdateZone(ctx, existing.Id, input)
+	if err != nil {
+		return err
+	}
+	return printZone("Updated", zone)
+}
+
+func runAdminZonesRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	client, err := zonesClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	zone, err := findZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	if err := client.DeleteZone(ctx, zone.Id); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return outputJSON(map[string]string{"deleted": zone.Id})
+	}
+	fmt.Printf("✓ Deleted zone %s\n", zone.Name)
+	return nil
+}
+
+// printZone reports a zone that was added or updated
+func printZone(verb string, zone *milesapi.Zone) error {
+	if output == "json" {
+		return outputJSON(zone)
+	}
+	fmt.Printf("✓ %s zone %s (%s)\n", verb, zone.Name, zone.Id)
+	fmt.Printf("  Rooms: %s\n", describeZoneRooms(*zone))
+	fmt.Printf("  Seats: %d\n", zoneCapacity(*zone))
+	fmt.Printf("\nBook it with: miles book --zone %q -s START -e END -t TITLE\n", zone.Name)
+	return nil
+}
+
+// runBookZone books every room in the --zone as one group and reports how
+// each room went
+func runBookZone(ctx context.Context, client config.API) error {
+	if bookStartTime == "" || bookEndTime == "" || bookTitle == "" {
+		return fmt.Errorf("booking a zone needs -s, -e and -t")
+	}
+	startTime, err := parseTime(bookStartTime)
+	if err != nil {
+		return fmt.Errorf("invalid start time: %w", err)
+	}
+	endTime, err := parseEnd(bookEndTime, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid end time: %w", err)
+	}
+	if !endTime.After(startTime) {
+		return fmt.Errorf("end time must be after start time")
+	}
+
+	zone, err := findZone(ctx, client, bookZone)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := sealDescription(bookDescription)
+	if err != nil {
+		return err
+	}
+	input := milesapi.ZoneBookingInput{
+		StartTime: startTime.UTC(),
+		EndTime:   endTime.UTC(),
+		Title:     bookTitle,
+	}
+	if sealed != "" {
+		input.Description = &sealed
+	}
+	if bookPartial {
+		input.Partial = &bookPartial
+	}
+
+	response, err := client.BookZone(ctx, zone.Id, input)
+	if err != nil {
+		return err
+	}
+
+	groupID := derefString(response.GroupId)
+	if output == "json" {
+		if err := outputJSON(response); err != nil {
+			return err
+		}
+	} else {
+		printZoneResults(zone, response, startTime, endTime)
+	}
+
+	if groupID == "" {
+		if bookPartial {
+			return fmt.Errorf("nothing was booked: no room in %s is free", zone.Name)
+		}
+		return fmt.Errorf("nothing was booked: not every room in %s is free. Use --partial to book the free ones", zone.Name)
+	}
+	return nil
+}
+
+// printZoneResults prints one line per room of a zone booking
+func printZoneResults(zone *milesapi.Zone, response *milesapi.ZoneBookingResponse, start, end time.Time) {
+	var results []milesapi.ZoneBookingResult
+	if response.Results != nil {
+		results = *response.Results
+	}
+
+	fmt.Printf("\n%s, %s-%s\n\n", zone.Name, start.Format("2006-01-02 15:04"), end.Format("15:04"))
+	for _, result := range results {
+		switch result.Status {
+		case milesapi.Booked:
+			status := "booked"
+			if result.Booking != nil && result.Booking.Status != nil && *result.Booking.Status == milesapi.BookingStatusPENDING {
+				status = "booked, waiting for approval"
+			}
+			fmt.Printf("  ✓ %-24s %s\n", result.RoomName, status)
+		case milesapi.Conflict:
+			taken := "taken"
+			if c := result.Conflict; c != nil {
+				taken = fmt.Sprintf("taken by %s %s, %s-%s", c.Owner.FirstName, c.Owner.LastName,
+					c.StartTime.Local().Format("15:04"), c.EndTime.Local().Format("15:04"))
+			}
+			fmt.Printf("  ✗ %-24s %s\n", result.RoomName, taken)
+		default:
+			fmt.Printf("  · %-24s free\n", result.RoomName)
+		}
+	}
+
+	if groupID := derefString(response.GroupId); groupID != "" {
+		fmt.Printf("\n✓ %s\n", derefString(response.Message))
+		fmt.Printf("Group:       %s\n", groupID)
+		fmt.Printf("\nCancel every room at once: miles cancel --group %s\n", groupID)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,327 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// API is the transport-agnostic interface to the Miles booking backend.
+// Commands depend on API rather than a concrete client so the transport
+// (REST or gRPC) can be selected by configuration.
+type API interface {
+	Login(ctx context.Context, email, password string) (*LoginResponse, error)
+	GetCurrentUser(ctx context.Context) (*milesapi.User, error)
+	GetLocations(ctx context.Context) ([]milesapi.Location, error)
+
+	// GetLocationManagers returns who manages a location, for asking them
+	// for access
+	GetLocationManagers(ctx context.Context, locationID string) ([]milesapi.User, error)
+
+	GetRooms(ctx context.Context, locationID string) ([]milesapi.Room, error)
+
+	// RequestRoomAccess asks a restricted room's owner, or its location's
+	// managers when it has none, to let the user book it. It returns who
+	// was asked.
+	RequestRoomAccess(ctx context.Context, roomID, message string) ([]RoomAccessContact, error)
+
+	// GetDesks returns the hot desks, optionally at one location and on one
+	// floor. Desks are rooms of type DESK: GetRooms lists them too, and they
+	// are booked and checked for availability like rooms.
+	GetDesks(ctx context.Context, locationID, floor string) ([]milesapi.Room, error)
+
+	GetBookings(ctx context.Context) ([]milesapi.Booking, error)
+	GetBookingsFiltered(ctx context.Context, roomID, locationID string) ([]milesapi.Booking, error)
+
+	// GetBookingsSince returns the bookings changed since cursor, including
+	// cancelled ones, and the cursor to pass next time. An empty cursor
+	// fetches everything. Results may repeat bookings already seen, so
+	// callers merge them by ID (see BookingStore).
+	GetBookingsSince(ctx context.Context, cursor string) ([]milesapi.Booking, string, error)
+	GetRoomAvailability(ctx context.Context, roomID string, startDate, endDate time.Time) ([]milesapi.Booking, error)
+
+	// GetBookingsBetween returns every booking the user may see that
+	// overlaps start to end, in any status. Admins see everyone's.
+	GetBookingsBetween(ctx context.Context, start, end time.Time) ([]milesapi.Booking, error)
+
+	// CheckRoomAvailability returns the active bookings that overlap
+	// [start, end) in the room. An empty result means the room is free.
+	CheckRoomAvailability(ctx context.Context, roomID string, start, end time.Time) ([]milesapi.Booking, error)
+	CreateBooking(ctx context.Context, req milesapi.BookingInput) (*milesapi.Booking, error)
+
+	// CancelBooking cancels a booking. The result warns when the server
+	// recorded it as a late cancellation.
+	CancelBooking(ctx context.Context, bookingID string) (*CancelResult, error)
+
+	// UpdateBooking changes the fields of a booking that are set in update.
+	// A new time is checked against the room's other bookings, and may need
+	// approval again.
+	UpdateBooking(ctx context.Context, bookingID string, update milesapi.PatchApiBookingsIdJSONRequestBody) (*milesapi.Booking, error)
+
+	// GetQuota returns the user's booking quota for the period containing at
+	GetQuota(ctx context.Context, at time.Time) (*milesapi.Quota, error)
+
+	// GetBusyTimes returns when the users with these emails have active
+	// bookings overlapping [start, end), earliest first. The window can be
+	// at most 31 days.
+	GetBusyTimes(ctx context.Context, emails []string, start, end time.Time) ([]milesapi.BusyTime, error)
+
+	// MergeRoom moves every booking from sourceID into targetID and retires
+	// sourceID (admins only). A dry run reports the impact without changing
+	// anything; a real merge fails if any bookings would overlap.
+	MergeRoom(ctx context.Context, sourceID, targetID string, dryRun bool) (*milesapi.RoomMerge, error)
+
+	// GetApprovalRules returns whether a location requires approval and its
+	// auto-approval rules (admins and the location's managers)
+	GetApprovalRules(ctx context.Context, locationID string) (*ApprovalRulesResponse, error)
+
+	// CreateApprovalRule adds a rule. UpdateApprovalRule replaces one. Both
+	// report how many pending bookings the rules now confirm.
+	CreateApprovalRule(ctx context.Context, locationID string, rule milesapi.ApprovalRuleInput) (*milesapi.ApprovalRuleResult, error)
+	UpdateApprovalRule(ctx context.Context, locationID, ruleID string, rule milesapi.ApprovalRuleInput) (*milesapi.ApprovalRuleResult, error)
+	DeleteApprovalRule(ctx context.Context, locationID, ruleID string) error
+
+	// SetRequiresApproval turns approval of new bookings at a location on or off
+	SetRequiresApproval(ctx context.Context, locationID string, required bool) error
+
+	// GetLocationServices returns a location's services directory: parking,
+	// lockers, bike room and the like
+	GetLocationServices(ctx context.Context, locationID string) ([]milesapi.LocationService, error)
+
+	// CreateLocationService and UpdateLocationService change the directory
+	// (admins and the location's managers). Updates only change the fields set.
+	CreateLocationService(ctx context.Context, locationID string, input milesapi.LocationServiceInput) (*milesapi.LocationService, error)
+	UpdateLocationService(ctx context.Context, locationID, serviceID string, input milesapi.LocationServiceInput) (*milesapi.LocationService, error)
+	DeleteLocationService(ctx context.Context, locationID, serviceID string) error
+
+	// GetLocationHolidays returns the days a location is closed: its
+	// holidays between from and to (YYYY-MM-DD, either may be empty) and
+	// the weekdays it is closed every week
+	GetLocationHolidays(ctx context.Context, locationID, from, to string) (*milesapi.LocationHolidays, error)
+
+	// CreateLocationHoliday, DeleteLocationHoliday and SetClosedWeekdays
+	// change them (admins and the location's managers)
+	CreateLocationHoliday(ctx context.Context, locationID string, input milesapi.LocationHolidayInput) (*milesapi.LocationHoliday, error)
+	DeleteLocationHoliday(ctx context.Context, locationID, holidayID string) error
+	SetClosedWeekdays(ctx context.Context, locationID string, weekdays []int) error
+
+	// GetPriorityRules returns who may bump other people's bookings at a
+	// location and with how much notice (admins and the location's managers)
+	GetPriorityRules(ctx context.Context, locationID string) ([]milesapi.PriorityRule, error)
+
+	// CreatePriorityRule grants a user, by email, priority at a location.
+	// UpdatePriorityRule only changes the fields set.
+	CreatePriorityRule(ctx context.Context, locationID string, input milesapi.PriorityRuleInput) (*milesapi.PriorityRule, error)
+	UpdatePriorityRule(ctx context.Context, locationID, ruleID string, update milesapi.PriorityRuleUpdate) (*milesapi.PriorityRule, error)
+	DeletePriorityRule(ctx context.Context, locationID, ruleID string) error
+
+	// GetDisplacements returns the audit log of bookings bumped at a location
+	GetDisplacements(ctx context.Context, locationID string) ([]milesapi.BookingDisplacement, error)
+
+	// BumpBooking moves someone else's booking to another time or room,
+	// emailing its owner. Admins, the location's managers and users with a
+	// priority rule there may bump.
+	BumpBooking(ctx context.Context, id string, input milesapi.BumpInput) (*milesapi.Booking, error)
+
+	// GetSubscriptions returns the rooms and colleagues the user follows
+	GetSubscriptions(ctx context.Context) ([]milesapi.Subscription, error)
+
+	// Follow starts following a room or, by email, a colleague
+	Follow(ctx context.Context, input milesapi.SubscriptionInput) (*milesapi.Subscription, error)
+	Unfollow(ctx context.Context, subscriptionID string) error
+
+	// GetActivity returns new and cancelled bookings for what the user
+	// follows, newest first, and the cursor to pass next time. An empty
+	// cursor returns the last week.
+	GetActivity(ctx context.Context, cursor string) ([]milesapi.ActivityItem, string, error)
+
+	// GetWebhooks returns every booking webhook, without secrets
+	GetWebhooks(ctx context.Context) ([]milesapi.Webhook, error)
+
+	// CreateWebhook adds a webhook; only the result carries its secret
+	CreateWebhook(ctx context.Context, input milesapi.WebhookInput) (*milesapi.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+
+	// TestWebhook sends a sample delivery and reports how the receiver answered
+	TestWebhook(ctx context.Context, webhookID string) (*milesapi.WebhookDelivery, error)
+
+	// GetZones returns the named sets of rooms that can be booked together
+	GetZones(ctx context.Context) ([]milesapi.Zone, error)
+
+	// CreateZone and UpdateZone define zones (admins only). UpdateZone only
+	// changes the fields set; RoomIds replaces the zone's rooms.
+	CreateZone(ctx context.Context, input milesapi.ZoneInput) (*milesapi.Zone, error)
+	UpdateZone(ctx context.Context, zoneID string, input milesapi.ZoneInput) (*milesapi.Zone, error)
+	DeleteZone(ctx context.Context, zoneID string) error
+
+	// BookZone books every active room in a zone as one group. When rooms
+	// are taken and nothing was booked, the response has no group ID, says
+	// why in Error and lists every room; that is not an error.
+	BookZone(ctx context.Context, zoneID string, input milesapi.ZoneBookingInput) (*milesapi.ZoneBookingResponse, error)
+
+	// CancelBookingGroup cancels every booking in a group and says how many,
+	// and how many of them were late cancellations
+	CancelBookingGroup(ctx context.Context, groupID string) (*CancelResult, error)
+
+	// GetTimeSlots returns the organization's named times of day, like
+	// standup at 09:00-09:15, by start time
+	GetTimeSlots(ctx context.Context) ([]milesapi.TimeSlot, error)
+
+	// CreateTimeSlot and UpdateTimeSlot define time slots (admins only).
+	// UpdateTimeSlot only changes the fields set.
+	CreateTimeSlot(ctx context.Context, input milesapi.TimeSlotInput) (*milesapi.TimeSlot, error)
+	UpdateTimeSlot(ctx context.Context, slotID string, input milesapi.TimeSlotInput) (*milesapi.TimeSlot, error)
+	DeleteTimeSlot(ctx context.Context, slotID string) error
+
+	// GetUtilizationReport returns booked hours per room and cancellations
+	// per user and team over a period (admins and managers). Zero times
+	// leave the server's default of the last 30 days; an empty locationID
+	// covers every location the caller may report on.
+	GetUtilizationReport(ctx context.Context, start, end time.Time, locationID string) (*milesapi.UtilizationReport, error)
+
+	// GetMyStats returns the caller's own booking numbers: this month, the
+	// room they book most and meetings and hours over the last six months
+	GetMyStats(ctx context.Context) (*milesapi.MyStats, error)
+
+	// ReportIssue reports an issue in a room, such as a dead projector, to
+	// the location's managers. Attachments only carry file metadata.
+	ReportIssue(ctx context.Context, input milesapi.FeedbackInput) (*milesapi.Feedback, error)
+
+	// GetMyIssues returns the issues the caller reported, newest first. An
+	// empty status returns them all.
+	GetMyIssues(ctx context.Context, status milesapi.FeedbackStatus) ([]milesapi.Feedback, error)
+
+	// GetLocationIssues returns a location's issue queue, most severe
+	// first, and UpdateIssueStatus moves an issue along (admins and the
+	// location's managers). An empty status lists the issues still open or
+	// in progress.
+	GetLocationIssues(ctx context.Context, locationID string, status milesapi.FeedbackStatus) ([]milesapi.Feedback, error)
+	UpdateIssueStatus(ctx context.Context, id string, update milesapi.FeedbackStatusUpdate) (*milesapi.Feedback, error)
+
+	// GetFeatures returns which optional client features the server has
+	// on. Servers from before feature flags return none, and features
+	// they don't list count as on.
+	GetFeatures(ctx context.Context) ([]milesapi.Feature, error)
+
+	// GetVersion returns the API version the server speaks, or nil for
+	// servers from before versioning
+	GetVersion(ctx context.Context) (*milesapi.ServerVersion, error)
+
+	// WatchBookings streams booking changes until ctx is cancelled.
+	// The returned channel is closed when the stream ends.
+	WatchBookings(ctx context.Context) (<-chan BookingEvent, error)
+
+	// Close releases any resources held by the transport
+	Close() error
+}
+
+// Transport selects how the client talks to the backend
+type Transport string
+
+const (
+	TransportREST Transport = "rest"
+	TransportGRPC Transport = "grpc"
+)
+
+// Options configures a new API client
+type Options struct {
+	Transport Transport
+	BaseURL   string // REST base URL, e.g. http://localhost:3000
+	GRPCAddr  string // gRPC target, e.g. localhost:50051
+	Insecure  bool   // Disable TLS for the gRPC connection
+	Token     string
+
+	// Impersonate is the email of a user to act as (ADMIN only)
+	Impersonate string
+
+	// Boundary decides whether back-to-back bookings conflict in
+	// CheckRoomAvailability (default BoundaryTouch)
+	Boundary Boundary
+
+	// Record saves every REST interaction to this cassette file; Replay
+	// answers requests from one instead of the server (see milesapi.Cassette)
+	Record string
+	Replay string
+}
+
+// The shared client's types, under the names commands use
+type (
+	LoginResponse         = milesapi.LoginResponse
+	CancelResult          = milesapi.CancelResult
+	CancelBookingResponse = milesapi.CancelBookingResponse
+	BookingsResponse      = milesapi.BookingsResponse
+	RoomAccessContact     = milesapi.RoomAccessContact
+	ApprovalRulesResponse = milesapi.ApprovalRulesResponse
+	ConflictError         = milesapi.ConflictError
+	PermissionError       = milesapi.PermissionError
+	RestrictedRoomError   = milesapi.RestrictedRoomError
+	BookingEvent          = milesapi.BookingEvent
+	BookingEventType      = milesapi.BookingEventType
+)
+
+const (
+	ImpersonateHeader    = milesapi.ImpersonateHeader
+	IdempotencyKeyHeader = milesapi.IdempotencyKeyHeader
+)
+
+// The kinds of change WatchBookings delivers
+const (
+	BookingCreated   = milesapi.BookingEventCreated
+	BookingUpdated   = milesapi.BookingEventUpdated
+	BookingCancelled = milesapi.BookingEventCancelled
+	BookingSnapshot  = milesapi.BookingEventSnapshot
+	RoomBlocked      = milesapi.BookingEventBlocked
+)
+
+// New creates an API client for the configured transport
+func New(opts Options) (API, error) {
+	switch opts.Transport {
+	case "", TransportREST:
+		client := NewClient(opts.BaseURL, opts.Token)
+		client.SetImpersonate(opts.Impersonate)
+		client.Boundary = opts.Boundary
+		switch {
+		case opts.Record != "":
+			client.Record(opts.Record)
+		case opts.Replay != "":
+			if err := client.Replay(opts.Replay); err != nil {
+				return nil, err
+			}
+		}
+		// A replayed Date header is from when the cassette was recorded
+		if opts.Replay == "" {
+			client.Resty().OnAfterResponse(measureClockSkew)
+		}
+		return client, nil
+	case TransportGRPC:
+		if opts.Record != "" || opts.Replay != "" {
+			return nil, fmt.Errorf("--record and --replay only work with the rest transport")
+		}
+		if opts.GRPCAddr == "" {
+			return nil, fmt.Errorf("grpc transport requires a gRPC address (--grpc-addr or grpc_addr in config)")
+		}
+		client, err := NewGRPCClient(opts.GRPCAddr, opts.Token, opts.Insecure)
+		if err != nil {
+			return nil, err
+		}
+		client.Impersonate = opts.Impersonate
+		client.Boundary = opts.Boundary
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unknown transport %q (expected rest or grpc)", opts.Transport)
+	}
+}
+
+// ErrUnavailable is wrapped by errors from a gRPC server that can't be reached
+var ErrUnavailable = errors.New("server unavailable")
+
+// Unreachable reports whether err means the server couldn't be reached at
+// all, as opposed to it answering with an error
+func Unreachable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, ErrUnavailable) || errors.As(err, &netErr)
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Boundary decides whether a booking may start the moment another ends
+type Boundary string
+
+const (
+	// BoundaryTouch lets back-to-back bookings share their boundary, as the
+	// server does. "Until next booking" ends exactly when the next one starts.
+	BoundaryTouch Boundary = "touch"
+
+	// BoundaryGap keeps a minute free between bookings, for servers that
+	// reject bookings touching an existing one
+	BoundaryGap Boundary = "gap"
+)
+
+// ParseBoundary parses the booking_boundary setting; empty means touch
+func ParseBoundary(s string) (Boundary, error) {
+	switch Boundary(s) {
+	case "", BoundaryTouch:
+		return BoundaryTouch, nil
+	case BoundaryGap:
+		return BoundaryGap, nil
+	}
+	return "", fmt.Errorf("unknown booking boundary %q (expected touch or gap)", s)
+}
+
+// Gap returns how much free time the boundary keeps between bookings
+func (b Boundary) Gap() time.Duration {
+	if b == BoundaryGap {
+		return time.Minute
+	}
+	return 0
+}
+
+// active reports whether a booking holds its room: not cancelled, with times
+func active(booking milesapi.Booking) bool {
+	if booking.Status != nil && *booking.Status == milesapi.BookingStatusCANCELLED {
+		return false
+	}
+	return booking.StartTime != nil && booking.EndTime != nil
+}
+
+// Overlaps reports whether an active booking conflicts with [start, end)
+func (b Boundary) Overlaps(booking milesapi.Booking, start, end time.Time) bool {
+	if !active(booking) {
+		return false
+	}
+	gap := b.Gap()
+	return booking.StartTime.Before(end.Add(gap)) && booking.EndTime.Add(gap).After(start)
+}
+
+// Conflicting filters bookings down to the ones conflicting with [start, end)
+func (b Boundary) Conflicting(bookings []milesapi.Booking, start, end time.Time) []milesapi.Booking {
+	var conflicts []milesapi.Booking
+	for _, booking := range bookings {
+		if b.Overlaps(booking, start, end) {
+			conflicts = append(conflicts, booking)
+		}
+	}
+	return conflicts
+}
+
+// FreeUntil returns the latest a booking from start can end without
+// conflicting with the next active booking, or false when none follows.
+// It returns start itself when start is already taken.
+func (b Boundary) FreeUntil(bookings []milesapi.Booking, start time.Time) (time.Time, bool) {
+	var until time.Time
+	found := false
+	for _, booking := range bookings {
+		if !active(booking) || !booking.EndTime.Add(b.Gap()).After(start) {
+			continue
+		}
+		next := booking.StartTime.Add(-b.Gap())
+		if next.Before(start) {
+			next = start
+		}
+		if !found || next.Before(until) {
+			until, found = next, true
+		}
+	}
+	return until, found
+}
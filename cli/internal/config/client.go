@@ -1,239 +1,36 @@
 package config
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"strings"
+	"context"
 	"time"
 
-	"github.com/go-resty/resty/v2"
-	"github.com/miles/booking-cli/internal/generated"
+	"github.com/miles/booking-tui/pkg/milesapi"
 )
 
-// Client is the API client for the Miles booking system
+// Client is the REST implementation of API: the shared milesapi client
+// plus the CLI's polling watch and conflict boundary
 type Client struct {
-	BaseURL string
-	Token   string
-	http    *resty.Client
-}
-
-// NewClient creates a new API client
-func NewClient(baseURL, token string) *Client {
-	client := resty.New()
-	client.SetTimeout(10 * time.Second)
-	client.SetBaseURL(baseURL)
-
-	if token != "" {
-		client.SetAuthToken(token)
-	}
-
-	return &Client{
-		BaseURL: baseURL,
-		Token:   token,
-		http:    client,
-	}
-}
-
-// LoginResponse represents the login API response
-type LoginResponse struct {
-	Token string          `json:"token"`
-	User  *generated.User `json:"user,omitempty"`
-}
+	*milesapi.Client
 
-// API response wrappers - the API returns data wrapped in objects
-type LocationsResponse struct {
-	Locations []generated.Location `json:"locations"`
-}
+	// WatchInterval controls polling frequency for WatchBookings
+	WatchInterval time.Duration
 
-type RoomsResponse struct {
-	Rooms []generated.Room `json:"rooms"`
+	// Boundary decides whether back-to-back bookings conflict
+	Boundary Boundary
 }
 
-type BookingsResponse struct {
-	Bookings []generated.Booking `json:"bookings"`
-}
-
-// Login authenticates a user and returns a token
-func (c *Client) Login(email, password string) (*LoginResponse, error) {
-	var result LoginResponse
-
-	resp, err := c.http.R().
-		SetBody(map[string]string{
-			"email":    email,
-			"password": password,
-		}).
-		SetResult(&result).
-		Post("/api/auth/login")
-
-	if err != nil {
-		return nil, fmt.Errorf("login request failed: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("login failed: %s", resp.Status())
-	}
-
-	// Update client token
-	c.Token = result.Token
-	c.http.SetAuthToken(result.Token)
-
-	return &result, nil
-}
-
-// GetLocations retrieves all locations
-func (c *Client) GetLocations() ([]generated.Location, error) {
-	var response LocationsResponse
-	resp, err := c.http.R().
-		SetResult(&response).
-		Get("/api/locations")
-
-	if err != nil {
-		return nil, fmt.Errorf("get locations failed: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("get locations failed: %s", resp.Status())
-	}
-
-	return response.Locations, nil
-}
-
-// GetRooms retrieves rooms, optionally filtered by location
-func (c *Client) GetRooms(locationID string) ([]generated.Room, error) {
-	var response RoomsResponse
-	req := c.http.R().SetResult(&response)
-
-	if locationID != "" {
-		req.SetQueryParam("locationId", locationID)
-	}
-
-	resp, err := req.Get("/api/rooms")
-	if err != nil {
-		return nil, fmt.Errorf("get rooms failed: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("get rooms failed: %s", resp.Status())
-	}
-
-	return response.Rooms, nil
-}
-
-// GetBookings retrieves bookings for the authenticated user
-func (c *Client) GetBookings() ([]generated.Booking, error) {
-	return c.GetBookingsFiltered("", "")
-}
-
-// GetBookingsFiltered retrieves bookings with optional filters
-func (c *Client) GetBookingsFiltered(roomID, locationID string) ([]generated.Booking, error) {
-	var response BookingsResponse
-	req := c.http.R().SetResult(&response)
-
-	if roomID != "" {
-		req.SetQueryParam("roomId", roomID)
-	}
-	if locationID != "" {
-		req.SetQueryParam("locationId", locationID)
-	}
-
-	resp, err := req.Get("/api/bookings")
-
-	if err != nil {
-		return nil, fmt.Errorf("get bookings failed: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("get bookings failed: %s", resp.Status())
-	}
-
-	return response.Bookings, nil
-}
-
-// GetRoomAvailability checks availability for a room within a date range
-func (c *Client) GetRoomAvailability(roomID string, startDate, endDate time.Time) ([]generated.Booking, error) {
-	var response BookingsResponse
-	resp, err := c.http.R().
-		SetQueryParam("startDate", startDate.Format(time.RFC3339)).
-		SetQueryParam("endDate", endDate.Format(time.RFC3339)).
-		SetResult(&response).
-		Get(fmt.Sprintf("/api/rooms/%s/availability", roomID))
-
-	if err != nil {
-		return nil, fmt.Errorf("get room availability failed: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("get room availability failed: %s", resp.Status())
-	}
-
-	return response.Bookings, nil
-}
-
-// CreateBooking creates a new booking
-func (c *Client) CreateBooking(req generated.BookingInput) (*generated.Booking, error) {
-	var result generated.Booking
-	resp, err := c.http.R().
-		SetBody(req).
-		SetResult(&result).
-		Post("/api/bookings")
-
-	if err != nil {
-		return nil, fmt.Errorf("create booking failed: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusCreated {
-		var errResp map[string]interface{}
-		json.Unmarshal(resp.Body(), &errResp)
-
-		// Check if we have an error message
-		if msg, ok := errResp["error"].(string); ok {
-			// Check if we have validation details
-			if details, ok := errResp["details"].([]interface{}); ok && len(details) > 0 {
-				// Build detailed error message from validation errors
-				var messages []string
-				for _, detail := range details {
-					if detailMap, ok := detail.(map[string]interface{}); ok {
-						if message, ok := detailMap["message"].(string); ok {
-							if path, ok := detailMap["path"].([]interface{}); ok && len(path) > 0 {
-								fieldName := fmt.Sprintf("%v", path[0])
-								messages = append(messages, fmt.Sprintf("%s: %s", fieldName, message))
-							} else {
-								messages = append(messages, message)
-							}
-						}
-					}
-				}
-				if len(messages) > 0 {
-					return nil, fmt.Errorf("%s:\n  - %s", msg, strings.Join(messages, "\n  - "))
-				}
-			}
-			// Just return the error message if no details
-			return nil, fmt.Errorf("%s", msg)
-		}
-		return nil, fmt.Errorf("create booking failed: %s", resp.Status())
-	}
-
-	return &result, nil
+// NewClient creates a new API client
+func NewClient(baseURL, token string) *Client {
+	return &Client{Client: milesapi.NewClient(baseURL, token)}
 }
 
-// CancelBooking cancels a booking by ID
-func (c *Client) CancelBooking(bookingID string) error {
-	resp, err := c.http.R().
-		Delete(fmt.Sprintf("/api/bookings/%s", bookingID))
-
+// CheckRoomAvailability returns the bookings that conflict with [start, end)
+func (c *Client) CheckRoomAvailability(ctx context.Context, roomID string, start, end time.Time) ([]milesapi.Booking, error) {
+	// Widen the window so bookings within the boundary's gap are seen too
+	gap := c.Boundary.Gap()
+	bookings, err := c.GetRoomAvailability(ctx, roomID, start.Add(-gap), end.Add(gap))
 	if err != nil {
-		return fmt.Errorf("cancel booking failed: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		var errResp map[string]interface{}
-		json.Unmarshal(resp.Body(), &errResp)
-		if msg, ok := errResp["error"].(string); ok {
-			return fmt.Errorf("cancel booking failed: %s", msg)
-		}
-		return fmt.Errorf("cancel booking failed: %s", resp.Status())
+		return nil, err
 	}
-
-	return nil
+	return c.Boundary.Conflicting(bookings, start, end), nil
 }
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ClockSkewWarning is how far the local clock may be off the server's
+// before commands warn. The Date header only has whole seconds, and the
+// server decides what "in the past" means, so smaller skews don't matter.
+const ClockSkewWarning = time.Minute
+
+// clockSkew is how far the server's clock is ahead of the local one,
+// measured once per process from the first response with a Date header
+var clockSkew struct {
+	once sync.Once
+	skew atomic.Int64
+}
+
+// measureClockSkew records the skew from the first response. The server
+// stamped Date somewhere between sending and receiving, so it is compared
+// with the midpoint.
+func measureClockSkew(_ *resty.Client, resp *resty.Response) error {
+	date, err := http.ParseTime(resp.Header().Get("Date"))
+	if err != nil {
+		return nil
+	}
+	sent, received := resp.Request.Time, resp.ReceivedAt()
+	clockSkew.once.Do(func() {
+		local := sent.Add(received.Sub(sent) / 2)
+		skew := date.Sub(local).Round(time.Second)
+		clockSkew.skew.Store(int64(skew))
+
+		// Stderr so the warning never ends up in -o json/csv output
+		if skew.Abs() >= ClockSkewWarning {
+			fmt.Fprintf(os.Stderr, "⚠ %s; relative times are adjusted, but fix the clock to avoid \"cannot book in the past\" errors\n\n", DescribeClockSkew(skew))
+		}
+	})
+	return nil
+}
+
+// ServerNow is the current time by the server's clock, for "starts in…"
+// and for deciding what is already in the past. It is time.Now() until a
+// response has been seen.
+func ServerNow() time.Time {
+	return time.Now().Add(time.Duration(clockSkew.skew.Load()))
+}
+
+// DescribeClockSkew says which way and how far the local clock is off
+func DescribeClockSkew(skew time.Duration) string {
+	if skew > 0 {
+		return fmt.Sprintf("Your clock is %s behind the server", skew)
+	}
+	return fmt.Sprintf("Your clock is %s ahead of the server", -skew)
+}
@@ -0,0 +1,851 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcTimeout bounds unary calls, matching the REST client timeout
+const grpcTimeout = 10 * time.Second
+
+// GRPCClient is the gRPC implementation of API
+type GRPCClient struct {
+	Addr  string
+	Token string
+
+	// Impersonate is the email of a user to act as (ADMIN only)
+	Impersonate string
+
+	// Boundary decides whether back-to-back bookings conflict
+	Boundary Boundary
+
+	conn *grpc.ClientConn
+}
+
+// NewGRPCClient creates a new gRPC API client
+func NewGRPCClient(addr, token string, useInsecure bool) (*GRPCClient, error) {
+	conn, err := milesapi.DialGRPC(addr, useInsecure)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPCClient{
+		Addr:  addr,
+		Token: token,
+		conn:  conn,
+	}, nil
+}
+
+// Close closes the underlying connection
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// withAuth attaches the bearer token and impersonation target to outgoing metadata
+func (c *GRPCClient) withAuth(ctx context.Context) context.Context {
+	return milesapi.GRPCAuth(ctx, c.Token, c.Impersonate)
+}
+
+// invoke performs a unary RPC, bounded by ctx and the default timeout
+func (c *GRPCClient) invoke(ctx context.Context, method string, req, resp any) error {
+	ctx, cancel := context.WithTimeout(ctx, grpcTimeout)
+	defer cancel()
+
+	return c.conn.Invoke(c.withAuth(ctx), milesapi.GRPCService+method, req, resp)
+}
+
+// grpcError converts a gRPC status into an error message consistent with the REST client
+func grpcError(operation string, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("%s failed: %w", operation, err)
+	}
+
+	switch st.Code() {
+	case codes.Unauthenticated:
+		return fmt.Errorf("%s failed: not authenticated (%s)", operation, st.Message())
+	case codes.PermissionDenied:
+		return fmt.Errorf("%s failed: forbidden (%s)", operation, st.Message())
+	case codes.Unavailable:
+		return fmt.Errorf("%s failed: %w: %s", operation, ErrUnavailable, st.Message())
+	}
+	return fmt.Errorf("%s failed: %s", operation, st.Message())
+}
+
+// Login authenticates a user and returns a token
+func (c *GRPCClient) Login(ctx context.Context, email, password string) (*LoginResponse, error) {
+	var result LoginResponse
+	req := map[string]string{
+		"email":    email,
+		"password": password,
+	}
+	if err := c.invoke(ctx, "Login", req, &result); err != nil {
+		return nil, grpcError("login", err)
+	}
+
+	c.Token = result.Token
+	return &result, nil
+}
+
+// GetCurrentUser retrieves the authenticated user's profile
+func (c *GRPCClient) GetCurrentUser(ctx context.Context) (*milesapi.User, error) {
+	var response milesapi.UserResponse
+	if err := c.invoke(ctx, "GetCurrentUser", struct{}{}, &response); err != nil {
+		return nil, grpcError("get current user", err)
+	}
+	return &response.User, nil
+}
+
+// GetLocations retrieves all locations
+func (c *GRPCClient) GetLocations(ctx context.Context) ([]milesapi.Location, error) {
+	var response milesapi.LocationsResponse
+	if err := c.invoke(ctx, "ListLocations", struct{}{}, &response); err != nil {
+		return nil, grpcError("get locations", err)
+	}
+	return response.Locations, nil
+}
+
+// GetLocationManagers retrieves the managers of a location
+func (c *GRPCClient) GetLocationManagers(ctx context.Context, locationID string) ([]milesapi.User, error) {
+	var response milesapi.ManagersResponse
+	req := map[string]string{"locationId": locationID}
+	if err := c.invoke(ctx, "ListLocationManagers", req, &response); err != nil {
+		return nil, grpcError("get location managers", err)
+	}
+	return response.Managers, nil
+}
+
+// RequestRoomAccess asks who looks after a restricted room for access
+func (c *GRPCClient) RequestRoomAccess(ctx context.Context, roomID, message string) ([]RoomAccessContact, error) {
+	var response milesapi.RoomAccessResponse
+	req := map[string]string{"id": roomID, "message": message}
+	if err := c.invoke(ctx, "RequestRoomAccess", req, &response); err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+			return nil, fmt.Errorf("request room access failed: %s", st.Message())
+		}
+		return nil, grpcError("request room access", err)
+	}
+	return response.Contacted, nil
+}
+
+// GetRooms retrieves rooms, optionally filtered by location
+func (c *GRPCClient) GetRooms(ctx context.Context, locationID string) ([]milesapi.Room, error) {
+	var response milesapi.RoomsResponse
+	req := map[string]string{}
+	if locationID != "" {
+		req["locationId"] = locationID
+	}
+	if err := c.invoke(ctx, "ListRooms", req, &response); err != nil {
+		return nil, grpcError("get rooms", err)
+	}
+	return response.Rooms, nil
+}
+
+// GetDesks retrieves hot desks, optionally filtered by location and floor
+func (c *GRPCClient) GetDesks(ctx context.Context, locationID, floor string) ([]milesapi.Room, error) {
+	var response milesapi.RoomsResponse
+	req := map[string]string{"type": string(milesapi.DESK)}
+	if locationID != "" {
+		req["locationId"] = locationID
+	}
+	if floor != "" {
+		req["floor"] = floor
+	}
+	if err := c.invoke(ctx, "ListRooms", req, &response); err != nil {
+		return nil, grpcError("get desks", err)
+	}
+	return response.Rooms, nil
+}
+
+// GetBookings retrieves bookings for the authenticated user
+func (c *GRPCClient) GetBookings(ctx context.Context) ([]milesapi.Booking, error) {
+	return c.GetBookingsFiltered(ctx, "", "")
+}
+
+// GetBookingsFiltered retrieves bookings with optional filters
+func (c *GRPCClient) GetBookingsFiltered(ctx context.Context, roomID, locationID string) ([]milesapi.Booking, error) {
+	var response BookingsResponse
+	req := map[string]string{}
+	if roomID != "" {
+		req["roomId"] = roomID
+	}
+	if locationID != "" {
+		req["locationId"] = locationID
+	}
+	if err := c.invoke(ctx, "ListBookings", req, &response); err != nil {
+		return nil, grpcError("get bookings", err)
+	}
+	return response.Bookings, nil
+}
+
+// GetBookingsBetween retrieves the bookings overlapping start to end
+func (c *GRPCClient) GetBookingsBetween(ctx context.Context, start, end time.Time) ([]milesapi.Booking, error) {
+	var response BookingsResponse
+	req := map[string]string{
+		"startDate": start.UTC().Format(time.RFC3339),
+		"endDate":   end.UTC().Format(time.RFC3339),
+	}
+	if err := c.invoke(ctx, "ListBookings", req, &response); err != nil {
+		return nil, grpcError("get bookings", err)
+	}
+	return response.Bookings, nil
+}
+
+// GetBookingsSince retrieves bookings changed since cursor
+func (c *GRPCClient) GetBookingsSince(ctx context.Context, cursor string) ([]milesapi.Booking, string, error) {
+	var response BookingsResponse
+	req := map[string]string{}
+	if cursor != "" {
+		req["updatedSince"] = cursor
+	}
+	if err := c.invoke(ctx, "ListBookings", req, &response); err != nil {
+		return nil, "", grpcError("get bookings", err)
+	}
+	return response.Bookings, response.NextCursor(cursor), nil
+}
+
+// GetRoomAvailability checks availability for a room within a date range
+func (c *GRPCClient) GetRoomAvailability(ctx context.Context, roomID string, startDate, endDate time.Time) ([]milesapi.Booking, error) {
+	var response BookingsResponse
+	req := map[string]string{
+		"roomId":    roomID,
+		"startDate": startDate.Format(time.RFC3339),
+		"endDate":   endDate.Format(time.RFC3339),
+	}
+	if err := c.invoke(ctx, "GetRoomAvailability", req, &response); err != nil {
+		return nil, grpcError("get room availability", err)
+	}
+	return response.Bookings, nil
+}
+
+// CheckRoomAvailability returns the bookings that conflict with [start, end)
+func (c *GRPCClient) CheckRoomAvailability(ctx context.Context, roomID string, start, end time.Time) ([]milesapi.Booking, error) {
+	// Widen the window so bookings within the boundary's gap are seen too
+	gap := c.Boundary.Gap()
+	bookings, err := c.GetRoomAvailability(ctx, roomID, start.Add(-gap), end.Add(gap))
+	if err != nil {
+		return nil, err
+	}
+	return c.Boundary.Conflicting(bookings, start, end), nil
+}
+
+// CreateBooking creates a new booking
+func (c *GRPCClient) CreateBooking(ctx context.Context, req milesapi.BookingInput) (*milesapi.Booking, error) {
+	var result milesapi.Booking
+	// Retried once with the same key when no answer arrives, as over REST
+	key := milesapi.NewIdempotencyKey()
+	create := func() error {
+		ctx, cancel := context.WithTimeout(ctx, grpcTimeout)
+		defer cancel()
+		ctx = metadata.AppendToOutgoingContext(c.withAuth(ctx), strings.ToLower(IdempotencyKeyHeader), key)
+		return c.conn.Invoke(ctx, milesapi.GRPCService+"CreateBooking", req, &result)
+	}
+
+	err := create()
+	if code := status.Code(err); code == codes.Unavailable || code == codes.DeadlineExceeded {
+		err = create()
+	}
+	if err != nil {
+		// Conflicts and validation failures carry a user-facing message.
+		// The status has no structured details, so conflicts keep the message.
+		if st, ok := status.FromError(err); ok && st.Code() == codes.AlreadyExists {
+			return nil, &ConflictError{Message: st.Message()}
+		}
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return nil, fmt.Errorf("%s", st.Message())
+		}
+		if st, ok := status.FromError(err); ok && st.Code() == codes.PermissionDenied {
+			return nil, &RestrictedRoomError{Message: st.Message(), RoomID: req.RoomId}
+		}
+		return nil, grpcError("create booking", err)
+	}
+	return &result, nil
+}
+
+// CancelBooking cancels a booking by ID
+func (c *GRPCClient) CancelBooking(ctx context.Context, bookingID string) (*CancelResult, error) {
+	var response CancelBookingResponse
+	req := map[string]string{"id": bookingID}
+	if err := c.invoke(ctx, "CancelBooking", req, &response); err != nil {
+		return nil, grpcError("cancel booking", err)
+	}
+	return response.Result(), nil
+}
+
+// UpdateBooking changes an existing booking
+func (c *GRPCClient) UpdateBooking(ctx context.Context, bookingID string, update milesapi.PatchApiBookingsIdJSONRequestBody) (*milesapi.Booking, error) {
+	var response struct {
+		Booking milesapi.Booking `json:"booking"`
+	}
+	req := map[string]any{
+		"id":          bookingID,
+		"startTime":   update.StartTime,
+		"endTime":     update.EndTime,
+		"title":       update.Title,
+		"description": update.Description,
+	}
+	if err := c.invoke(ctx, "UpdateBooking", req, &response); err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.AlreadyExists {
+			return nil, &ConflictError{Message: st.Message()}
+		}
+		return nil, grpcError("update booking", err)
+	}
+	return &response.Booking, nil
+}
+
+// GetQuota retrieves the user's booking quota for the period containing at
+func (c *GRPCClient) GetQuota(ctx context.Context, at time.Time) (*milesapi.Quota, error) {
+	var response milesapi.QuotaResponse
+	req := map[string]string{"date": at.Format(time.RFC3339)}
+	if err := c.invoke(ctx, "GetQuota", req, &response); err != nil {
+		return nil, grpcError("get quota", err)
+	}
+	return &response.Quota, nil
+}
+
+// GetBusyTimes retrieves when colleagues are booked between start and end
+func (c *GRPCClient) GetBusyTimes(ctx context.Context, emails []string, start, end time.Time) ([]milesapi.BusyTime, error) {
+	var response milesapi.BusyTimesResponse
+	req := map[string]any{
+		"emails":    emails,
+		"startDate": start.UTC().Format(time.RFC3339),
+		"endDate":   end.UTC().Format(time.RFC3339),
+	}
+	if err := c.invoke(ctx, "GetBusyTimes", req, &response); err != nil {
+		return nil, grpcError("get busy times", err)
+	}
+	return response.Busy, nil
+}
+
+// MergeRoom moves a room's bookings into another room and retires it
+func (c *GRPCClient) MergeRoom(ctx context.Context, sourceID, targetID string, dryRun bool) (*milesapi.RoomMerge, error) {
+	var response milesapi.RoomMergeResponse
+	req := map[string]any{"id": sourceID, "targetRoomId": targetID, "dryRun": dryRun}
+	if err := c.invoke(ctx, "MergeRoom", req, &response); err != nil {
+		// Overlapping bookings carry a user-facing message
+		if st, ok := status.FromError(err); ok && st.Code() == codes.AlreadyExists {
+			return nil, fmt.Errorf("merge rooms failed: %s", st.Message())
+		}
+		return nil, grpcError("merge rooms", err)
+	}
+	return &response.Merge, nil
+}
+
+// GetApprovalRules retrieves a location's auto-approval rules
+func (c *GRPCClient) GetApprovalRules(ctx context.Context, locationID string) (*ApprovalRulesResponse, error) {
+	var response ApprovalRulesResponse
+	req := map[string]string{"locationId": locationID}
+	if err := c.invoke(ctx, "ListApprovalRules", req, &response); err != nil {
+		return nil, grpcError("get approval rules", err)
+	}
+	return &response, nil
+}
+
+// CreateApprovalRule adds an auto-approval rule to a location
+func (c *GRPCClient) CreateApprovalRule(ctx context.Context, locationID string, rule milesapi.ApprovalRuleInput) (*milesapi.ApprovalRuleResult, error) {
+	var result milesapi.ApprovalRuleResult
+	req := map[string]any{"locationId": locationID, "rule": rule}
+	if err := c.invoke(ctx, "CreateApprovalRule", req, &result); err != nil {
+		return nil, grpcError("create approval rule", err)
+	}
+	return &result, nil
+}
+
+// UpdateApprovalRule replaces an auto-approval rule
+func (c *GRPCClient) UpdateApprovalRule(ctx context.Context, locationID, ruleID string, rule milesapi.ApprovalRuleInput) (*milesapi.ApprovalRuleResult, error) {
+	var result milesapi.ApprovalRuleResult
+	req := map[string]any{"locationId": locationID, "id": ruleID, "rule": rule}
+	if err := c.invoke(ctx, "UpdateApprovalRule", req, &result); err != nil {
+		return nil, grpcError("update approval rule", err)
+	}
+	return &result, nil
+}
+
+// DeleteApprovalRule removes an auto-approval rule
+func (c *GRPCClient) DeleteApprovalRule(ctx context.Context, locationID, ruleID string) error {
+	var result struct{}
+	req := map[string]string{"locationId": locationID, "id": ruleID}
+	if err := c.invoke(ctx, "DeleteApprovalRule", req, &result); err != nil {
+		return grpcError("delete approval rule", err)
+	}
+	return nil
+}
+
+// SetRequiresApproval turns approval of new bookings at a location on or off
+func (c *GRPCClient) SetRequiresApproval(ctx context.Context, locationID string, required bool) error {
+	var result struct{}
+	req := map[string]any{"locationId": locationID, "requiresApproval": required}
+	if err := c.invoke(ctx, "SetRequiresApproval", req, &result); err != nil {
+		return grpcError("update location", err)
+	}
+	return nil
+}
+
+// GetSubscriptions retrieves the rooms and colleagues the user follows
+func (c *GRPCClient) GetSubscriptions(ctx context.Context) ([]milesapi.Subscription, error) {
+	var response struct {
+		Subscriptions []milesapi.Subscription `json:"subscriptions"`
+	}
+	if err := c.invoke(ctx, "ListSubscriptions", struct{}{}, &response); err != nil {
+		return nil, grpcError("get subscriptions", err)
+	}
+	return response.Subscriptions, nil
+}
+
+// Follow starts following a room or colleague
+func (c *GRPCClient) Follow(ctx context.Context, input milesapi.SubscriptionInput) (*milesapi.Subscription, error) {
+	var subscription milesapi.Subscription
+	if err := c.invoke(ctx, "CreateSubscription", input, &subscription); err != nil {
+		return nil, grpcError("follow", err)
+	}
+	return &subscription, nil
+}
+
+// Unfollow removes a subscription
+func (c *GRPCClient) Unfollow(ctx context.Context, subscriptionID string) error {
+	var result struct{}
+	if err := c.invoke(ctx, "DeleteSubscription", map[string]string{"id": subscriptionID}, &result); err != nil {
+		return grpcError("unfollow", err)
+	}
+	return nil
+}
+
+// GetActivity retrieves new and cancelled bookings for what the user follows
+func (c *GRPCClient) GetActivity(ctx context.Context, cursor string) ([]milesapi.ActivityItem, string, error) {
+	var response struct {
+		Activity  []milesapi.ActivityItem `json:"activity"`
+		SyncToken string                  `json:"syncToken"`
+	}
+	if err := c.invoke(ctx, "ListActivity", map[string]string{"since": cursor}, &response); err != nil {
+		return nil, "", grpcError("get activity", err)
+	}
+	return response.Activity, response.SyncToken, nil
+}
+
+// GetWebhooks retrieves every booking webhook
+func (c *GRPCClient) GetWebhooks(ctx context.Context) ([]milesapi.Webhook, error) {
+	var response struct {
+		Webhooks []milesapi.Webhook `json:"webhooks"`
+	}
+	if err := c.invoke(ctx, "ListWebhooks", struct{}{}, &response); err != nil {
+		return nil, grpcError("get webhooks", err)
+	}
+	return response.Webhooks, nil
+}
+
+// CreateWebhook adds a booking webhook
+func (c *GRPCClient) CreateWebhook(ctx context.Context, input milesapi.WebhookInput) (*milesapi.Webhook, error) {
+	var webhook milesapi.Webhook
+	if err := c.invoke(ctx, "CreateWebhook", input, &webhook); err != nil {
+		return nil, grpcError("create webhook", err)
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook removes a booking webhook
+func (c *GRPCClient) DeleteWebhook(ctx context.Context, webhookID string) error {
+	var result struct{}
+	if err := c.invoke(ctx, "DeleteWebhook", map[string]string{"id": webhookID}, &result); err != nil {
+		return grpcError("delete webhook", err)
+	}
+	return nil
+}
+
+// TestWebhook sends a sample delivery to a webhook
+func (c *GRPCClient) TestWebhook(ctx context.Context, webhookID string) (*milesapi.WebhookDelivery, error) {
+	var delivery milesapi.WebhookDelivery
+	if err := c.invoke(ctx, "TestWebhook", map[string]string{"id": webhookID}, &delivery); err != nil {
+		return nil, grpcError("test webhook", err)
+	}
+	return &delivery, nil
+}
+
+// GetZones retrieves every zone with its rooms
+func (c *GRPCClient) GetZones(ctx context.Context) ([]milesapi.Zone, error) {
+	var response struct {
+		Zones []milesapi.Zone `json:"zones"`
+	}
+	if err := c.invoke(ctx, "ListZones", struct{}{}, &response); err != nil {
+		return nil, grpcError("get zones", err)
+	}
+	return response.Zones, nil
+}
+
+// CreateZone defines a zone
+func (c *GRPCClient) CreateZone(ctx context.Context, input milesapi.ZoneInput) (*milesapi.Zone, error) {
+	var zone milesapi.Zone
+	if err := c.invoke(ctx, "CreateZone", input, &zone); err != nil {
+		return nil, grpcError("create zone", err)
+	}
+	return &zone, nil
+}
+
+// UpdateZone changes a zone
+func (c *GRPCClient) UpdateZone(ctx context.Context, zoneID string, input milesapi.ZoneInput) (*milesapi.Zone, error) {
+	req := struct {
+		Id string `json:"id"`
+		milesapi.ZoneInput
+	}{Id: zoneID, ZoneInput: input}
+	var zone milesapi.Zone
+	if err := c.invoke(ctx, "UpdateZone", req, &zone); err != nil {
+		return nil, grpcError("update zone", err)
+	}
+	return &zone, nil
+}
+
+// DeleteZone removes a zone, keeping its rooms and bookings
+func (c *GRPCClient) DeleteZone(ctx context.Context, zoneID string) error {
+	var result struct{}
+	if err := c.invoke(ctx, "DeleteZone", map[string]string{"id": zoneID}, &result); err != nil {
+		return grpcError("delete zone", err)
+	}
+	return nil
+}
+
+// BookZone books every room in a zone as one group
+func (c *GRPCClient) BookZone(ctx context.Context, zoneID string, input milesapi.ZoneBookingInput) (*milesapi.ZoneBookingResponse, error) {
+	req := struct {
+		Id string `json:"id"`
+		milesapi.ZoneBookingInput
+	}{Id: zoneID, ZoneBookingInput: input}
+	var response milesapi.ZoneBookingResponse
+	if err := c.invoke(ctx, "BookZone", req, &response); err != nil {
+		return nil, grpcError("book zone", err)
+	}
+	return &response, nil
+}
+
+// CancelBookingGroup cancels every booking in a group
+func (c *GRPCClient) CancelBookingGroup(ctx context.Context, groupID string) (*CancelResult, error) {
+	var result CancelResult
+	if err := c.invoke(ctx, "CancelBookingGroup", map[string]string{"groupId": groupID}, &result); err != nil {
+		return nil, grpcError("cancel booking group", err)
+	}
+	return &result, nil
+}
+
+// GetUtilizationReport retrieves room use and cancellations over a period
+func (c *GRPCClient) GetUtilizationReport(ctx context.Context, start, end time.Time, locationID string) (*milesapi.UtilizationReport, error) {
+	var report milesapi.UtilizationReport
+	req := map[string]string{}
+	if !start.IsZero() {
+		req["startDate"] = start.UTC().Format(time.RFC3339)
+	}
+	if !end.IsZero() {
+		req["endDate"] = end.UTC().Format(time.RFC3339)
+	}
+	if locationID != "" {
+		req["locationId"] = locationID
+	}
+	if err := c.invoke(ctx, "GetUtilizationReport", req, &report); err != nil {
+		return nil, grpcError("get utilization report", err)
+	}
+	return &report, nil
+}
+
+// GetMyStats retrieves the user's own booking stats
+func (c *GRPCClient) GetMyStats(ctx context.Context) (*milesapi.MyStats, error) {
+	var stats milesapi.MyStats
+	if err := c.invoke(ctx, "GetMyStats", struct{}{}, &stats); err != nil {
+		return nil, grpcError("get booking stats", err)
+	}
+	return &stats, nil
+}
+
+// ReportIssue reports an issue in a room to its location's managers
+func (c *GRPCClient) ReportIssue(ctx context.Context, input milesapi.FeedbackInput) (*milesapi.Feedback, error) {
+	var feedback milesapi.Feedback
+	if err := c.invoke(ctx, "CreateFeedback", input, &feedback); err != nil {
+		return nil, grpcError("report issue", err)
+	}
+	return &feedback, nil
+}
+
+// GetMyIssues retrieves the issues the user reported
+func (c *GRPCClient) GetMyIssues(ctx context.Context, status milesapi.FeedbackStatus) ([]milesapi.Feedback, error) {
+	var result milesapi.FeedbackList
+	req := map[string]string{}
+	if status != "" {
+		req["status"] = string(status)
+	}
+	if err := c.invoke(ctx, "ListMyFeedback", req, &result); err != nil {
+		return nil, grpcError("get my issues", err)
+	}
+	return result.Feedback, nil
+}
+
+// GetLocationIssues retrieves a location's issue queue
+func (c *GRPCClient) GetLocationIssues(ctx context.Context, locationID string, status milesapi.FeedbackStatus) ([]milesapi.Feedback, error) {
+	var result milesapi.FeedbackList
+	req := map[string]string{"locationId": locationID}
+	if status != "" {
+		req["status"] = string(status)
+	}
+	if err := c.invoke(ctx, "ListLocationFeedback", req, &result); err != nil {
+		return nil, grpcError("get location issues", err)
+	}
+	return result.Feedback, nil
+}
+
+// UpdateIssueStatus moves an issue along
+func (c *GRPCClient) UpdateIssueStatus(ctx context.Context, id string, update milesapi.FeedbackStatusUpdate) (*milesapi.Feedback, error) {
+	var feedback milesapi.Feedback
+	req := map[string]string{"id": id, "status": string(update.Status), "comment": update.Comment}
+	if err := c.invoke(ctx, "UpdateFeedbackStatus", req, &feedback); err != nil {
+		return nil, grpcError("update issue", err)
+	}
+	return &feedback, nil
+}
+
+// GetTimeSlots retrieves the organization's time slots
+func (c *GRPCClient) GetTimeSlots(ctx context.Context) ([]milesapi.TimeSlot, error) {
+	var response struct {
+		Slots []milesapi.TimeSlot `json:"slots"`
+	}
+	if err := c.invoke(ctx, "ListTimeSlots", struct{}{}, &response); err != nil {
+		return nil, grpcError("get time slots", err)
+	}
+	return response.Slots, nil
+}
+
+// GetFeatures retrieves the optional client features the server has on
+func (c *GRPCClient) GetFeatures(ctx context.Context) ([]milesapi.Feature, error) {
+	var response struct {
+		Features []milesapi.Feature `json:"features"`
+	}
+	if err := c.invoke(ctx, "ListFeatures", struct{}{}, &response); err != nil {
+		// Servers from before feature flags have everything on
+		if status.Code(err) == codes.Unimplemented {
+			return nil, nil
+		}
+		return nil, grpcError("get features", err)
+	}
+	return response.Features, nil
+}
+
+// GetVersion retrieves the API version the server speaks
+func (c *GRPCClient) GetVersion(ctx context.Context) (*milesapi.ServerVersion, error) {
+	var version milesapi.ServerVersion
+	if err := c.invoke(ctx, "GetVersion", struct{}{}, &version); err != nil {
+		// Servers from before versioning
+		if status.Code(err) == codes.Unimplemented {
+			return nil, nil
+		}
+		return nil, grpcError("get server version", err)
+	}
+	return &version, nil
+}
+
+// CreateTimeSlot defines a time slot
+func (c *GRPCClient) CreateTimeSlot(ctx context.Context, input milesapi.TimeSlotInput) (*milesapi.TimeSlot, error) {
+	var slot milesapi.TimeSlot
+	if err := c.invoke(ctx, "CreateTimeSlot", input, &slot); err != nil {
+		return nil, grpcError("create time slot", err)
+	}
+	return &slot, nil
+}
+
+// UpdateTimeSlot changes a time slot
+func (c *GRPCClient) UpdateTimeSlot(ctx context.Context, slotID string, input milesapi.TimeSlotInput) (*milesapi.TimeSlot, error) {
+	req := struct {
+		Id string `json:"id"`
+		milesapi.TimeSlotInput
+	}{Id: slotID, TimeSlotInput: input}
+	var slot milesapi.TimeSlot
+	if err := c.invoke(ctx, "UpdateTimeSlot", req, &slot); err != nil {
+		return nil, grpcError("update time slot", err)
+	}
+	return &slot, nil
+}
+
+// DeleteTimeSlot removes a time slot
+func (c *GRPCClient) DeleteTimeSlot(ctx context.Context, slotID string) error {
+	var result struct{}
+	if err := c.invoke(ctx, "DeleteTimeSlot", map[string]string{"id": slotID}, &result); err != nil {
+		return grpcError("delete time slot", err)
+	}
+	return nil
+}
+
+// GetLocationServices retrieves a location's services directory
+func (c *GRPCClient) GetLocationServices(ctx context.Context, locationID string) ([]milesapi.LocationService, error) {
+	var response struct {
+		Services []milesapi.LocationService `json:"services"`
+	}
+	req := map[string]string{"locationId": locationID}
+	if err := c.invoke(ctx, "ListLocationServices", req, &response); err != nil {
+		return nil, grpcError("get location services", err)
+	}
+	return response.Services, nil
+}
+
+// CreateLocationService lists a service at a location
+func (c *GRPCClient) CreateLocationService(ctx context.Context, locationID string, input milesapi.LocationServiceInput) (*milesapi.LocationService, error) {
+	var service milesapi.LocationService
+	req := map[string]any{"locationId": locationID, "service": input}
+	if err := c.invoke(ctx, "CreateLocationService", req, &service); err != nil {
+		return nil, grpcError("create location service", err)
+	}
+	return &service, nil
+}
+
+// UpdateLocationService changes a listed service
+func (c *GRPCClient) UpdateLocationService(ctx context.Context, locationID, serviceID string, input milesapi.LocationServiceInput) (*milesapi.LocationService, error) {
+	var service milesapi.LocationService
+	req := map[string]any{"locationId": locationID, "id": serviceID, "service": input}
+	if err := c.invoke(ctx, "UpdateLocationService", req, &service); err != nil {
+		return nil, grpcError("update location service", err)
+	}
+	return &service, nil
+}
+
+// DeleteLocationService removes a service from a location's directory
+func (c *GRPCClient) DeleteLocationService(ctx context.Context, locationID, serviceID string) error {
+	var result struct{}
+	req := map[string]string{"locationId": locationID, "id": serviceID}
+	if err := c.invoke(ctx, "DeleteLocationService", req, &result); err != nil {
+		return grpcError("delete location service", err)
+	}
+	return nil
+}
+
+// GetLocationHolidays retrieves the days a location is closed
+func (c *GRPCClient) GetLocationHolidays(ctx context.Context, locationID, from, to string) (*milesapi.LocationHolidays, error) {
+	var result milesapi.LocationHolidays
+	req := map[string]string{"locationId": locationID, "from": from, "to": to}
+	if err := c.invoke(ctx, "ListLocationHolidays", req, &result); err != nil {
+		return nil, grpcError("get location holidays", err)
+	}
+	return &result, nil
+}
+
+// CreateLocationHoliday closes a location for a day
+func (c *GRPCClient) CreateLocationHoliday(ctx context.Context, locationID string, input milesapi.LocationHolidayInput) (*milesapi.LocationHoliday, error) {
+	var holiday milesapi.LocationHoliday
+	req := map[string]string{"locationId": locationID, "date": input.Date, "name": input.Name}
+	if err := c.invoke(ctx, "CreateLocationHoliday", req, &holiday); err != nil {
+		return nil, grpcError("create location holiday", err)
+	}
+	return &holiday, nil
+}
+
+// DeleteLocationHoliday opens a location again on a holiday's day
+func (c *GRPCClient) DeleteLocationHoliday(ctx context.Context, locationID, holidayID string) error {
+	var result struct{}
+	req := map[string]string{"locationId": locationID, "id": holidayID}
+	if err := c.invoke(ctx, "DeleteLocationHoliday", req, &result); err != nil {
+		return grpcError("delete location holiday", err)
+	}
+	return nil
+}
+
+// SetClosedWeekdays sets the weekdays a location is closed every week
+func (c *GRPCClient) SetClosedWeekdays(ctx context.Context, locationID string, weekdays []int) error {
+	var result struct{}
+	req := map[string]any{"locationId": locationID, "closedWeekdays": weekdays}
+	if err := c.invoke(ctx, "SetClosedWeekdays", req, &result); err != nil {
+		return grpcError("update location", err)
+	}
+	return nil
+}
+
+// GetPriorityRules retrieves who may bump bookings at a location
+func (c *GRPCClient) GetPriorityRules(ctx context.Context, locationID string) ([]milesapi.PriorityRule, error) {
+	var response struct {
+		Rules []milesapi.PriorityRule `json:"rules"`
+	}
+	req := map[string]string{"locationId": locationID}
+	if err := c.invoke(ctx, "ListPriorityRules", req, &response); err != nil {
+		return nil, grpcError("get priority rules", err)
+	}
+	return response.Rules, nil
+}
+
+// CreatePriorityRule lets a user bump bookings at a location
+func (c *GRPCClient) CreatePriorityRule(ctx context.Context, locationID string, input milesapi.PriorityRuleInput) (*milesapi.PriorityRule, error) {
+	var rule milesapi.PriorityRule
+	req := map[string]any{
+		"locationId":     locationID,
+		"email":          input.Email,
+		"name":           input.Name,
+		"minNoticeHours": input.MinNoticeHours,
+		"enabled":        input.Enabled,
+	}
+	if err := c.invoke(ctx, "CreatePriorityRule", req, &rule); err != nil {
+		return nil, grpcError("create priority rule", err)
+	}
+	return &rule, nil
+}
+
+// UpdatePriorityRule changes a priority rule
+func (c *GRPCClient) UpdatePriorityRule(ctx context.Context, locationID, ruleID string, update milesapi.PriorityRuleUpdate) (*milesapi.PriorityRule, error) {
+	var rule milesapi.PriorityRule
+	req := map[string]any{
+		"locationId":     locationID,
+		"id":             ruleID,
+		"name":           update.Name,
+		"minNoticeHours": update.MinNoticeHours,
+		"enabled":        update.Enabled,
+	}
+	if err := c.invoke(ctx, "UpdatePriorityRule", req, &rule); err != nil {
+		return nil, grpcError("update priority rule", err)
+	}
+	return &rule, nil
+}
+
+// DeletePriorityRule removes a priority rule
+func (c *GRPCClient) DeletePriorityRule(ctx context.Context, locationID, ruleID string) error {
+	var result struct{}
+	req := map[string]string{"locationId": locationID, "id": ruleID}
+	if err := c.invoke(ctx, "DeletePriorityRule", req, &result); err != nil {
+		return grpcError("delete priority rule", err)
+	}
+	return nil
+}
+
+// GetDisplacements retrieves the bookings bumped at a location, newest first
+func (c *GRPCClient) GetDisplacements(ctx context.Context, locationID string) ([]milesapi.BookingDisplacement, error) {
+	var response struct {
+		Displacements []milesapi.BookingDisplacement `json:"displacements"`
+	}
+	req := map[string]string{"locationId": locationID}
+	if err := c.invoke(ctx, "ListDisplacements", req, &response); err != nil {
+		return nil, grpcError("get displacements", err)
+	}
+	return response.Displacements, nil
+}
+
+// BumpBooking moves someone else's booking to make way for a priority booking
+func (c *GRPCClient) BumpBooking(ctx context.Context, id string, input milesapi.BumpInput) (*milesapi.Booking, error) {
+	var response struct {
+		Booking milesapi.Booking `json:"booking"`
+	}
+	req := map[string]any{
+		"id":        id,
+		"roomId":    input.RoomId,
+		"startTime": input.StartTime,
+		"endTime":   input.EndTime,
+		"reason":    input.Reason,
+	}
+	if err := c.invoke(ctx, "BumpBooking", req, &response); err != nil {
+		return nil, grpcError("bump booking", err)
+	}
+	return &response.Booking, nil
+}
+
+// WatchBookings subscribes to the server-streaming WatchBookings RPC
+func (c *GRPCClient) WatchBookings(ctx context.Context) (<-chan BookingEvent, error) {
+	events, err := milesapi.WatchBookings(c.withAuth(ctx), c.conn)
+	if err != nil {
+		return nil, grpcError("watch bookings", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// BookingStore is a local copy of the caller's bookings kept current by
+// merging deltas from GetBookingsSince instead of refetching everything
+type BookingStore struct {
+	cursor   string
+	bookings map[string]milesapi.Booking
+}
+
+// NewBookingStore creates an empty store; the first Sync fetches everything
+func NewBookingStore() *BookingStore {
+	return &BookingStore{bookings: make(map[string]milesapi.Booking)}
+}
+
+// Sync fetches changes since the last sync, merges them and returns the
+// events they represent
+func (s *BookingStore) Sync(ctx context.Context, client API) ([]BookingEvent, error) {
+	delta, cursor, err := client.GetBookingsSince(ctx, s.cursor)
+	if err != nil {
+		return nil, err
+	}
+	events := s.Merge(delta)
+	s.cursor = cursor
+	return events, nil
+}
+
+// Merge applies changed bookings to the store and returns the events they
+// represent. Bookings that haven't changed since they were stored are skipped.
+func (s *BookingStore) Merge(delta []milesapi.Booking) []BookingEvent {
+	now := time.Now()
+	var events []BookingEvent
+
+	for _, booking := range delta {
+		if booking.Id == nil {
+			continue
+		}
+		old, existed := s.bookings[*booking.Id]
+		s.bookings[*booking.Id] = booking
+
+		switch {
+		case !existed:
+			events = append(events, BookingEvent{Type: BookingCreated, Time: now, Booking: booking})
+		case isCancelled(booking) && !isCancelled(old):
+			events = append(events, BookingEvent{Type: BookingCancelled, Time: now, Booking: booking})
+		case changed(old, booking):
+			events = append(events, BookingEvent{Type: BookingUpdated, Time: now, Booking: booking})
+		}
+	}
+
+	return events
+}
+
+// Bookings returns every stored booking, cancelled ones included, by start time
+func (s *BookingStore) Bookings() []milesapi.Booking {
+	bookings := make([]milesapi.Booking, 0, len(s.bookings))
+	for _, booking := range s.bookings {
+		bookings = append(bookings, booking)
+	}
+	sort.Slice(bookings, func(i, j int) bool {
+		a, b := bookings[i].StartTime, bookings[j].StartTime
+		if a == nil || b == nil {
+			return b != nil
+		}
+		return a.Before(*b)
+	})
+	return bookings
+}
@@ -0,0 +1,10 @@
+package config
+
+import (
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// RoleAllows reports whether role has at least the privileges of required
+func RoleAllows(role, required milesapi.UserRole) bool {
+	return role.Allows(required)
+}
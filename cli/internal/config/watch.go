@@ -0,0 +1,88 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// DefaultWatchInterval is how often the REST transport polls for changes
+const DefaultWatchInterval = 15 * time.Second
+
+// WatchBookings emulates the gRPC watch stream over REST by polling for
+// bookings changed since the previous poll and merging them into a
+// BookingStore.
+func (c *Client) WatchBookings(ctx context.Context) (<-chan BookingEvent, error) {
+	// Take the initial snapshot synchronously so auth/network errors surface
+	// to the caller instead of silently closing the stream
+	store := NewBookingStore()
+	if _, err := store.Sync(ctx, c); err != nil {
+		return nil, err
+	}
+
+	interval := c.WatchInterval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	events := make(chan BookingEvent)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			changes, err := store.Sync(ctx, c)
+			if err != nil {
+				// Transient failure - try again on the next tick
+				continue
+			}
+
+			for _, event := range changes {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func isCancelled(booking milesapi.Booking) bool {
+	return booking.Status != nil && *booking.Status == milesapi.BookingStatusCANCELLED
+}
+
+// changed reports whether a booking was modified between snapshots
+func changed(old, current milesapi.Booking) bool {
+	if old.UpdatedAt != nil && current.UpdatedAt != nil {
+		return !old.UpdatedAt.Equal(*current.UpdatedAt)
+	}
+	return !timeEqual(old.StartTime, current.StartTime) ||
+		!timeEqual(old.EndTime, current.EndTime) ||
+		stringValue(old.Title) != stringValue(current.Title)
+}
+
+func timeEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,153 @@
+package daemon
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/reminder"
+)
+
+// cacheVersion changes when the cache layout does; readers ignore other
+// versions
+const cacheVersion = 2
+
+// maxActivity is how many activity items the cache keeps, newest first
+const maxActivity = 200
+
+// Cache is what the daemon last fetched, written after every poll for the
+// CLI and TUI to read instead of polling themselves. The TUI reads it with
+// its own types, so fields only change with cacheVersion.
+type Cache struct {
+	Version int `json:"version"`
+
+	// Server is the API URL and UserID the user the cache was fetched for;
+	// readers signed in elsewhere ignore it
+	Server string `json:"server"`
+	UserID string `json:"userId"`
+
+	// AsOf is when the cache was last brought up to date. Readers treat it
+	// as stale after a few missed polls, e.g. when the daemon has died.
+	AsOf time.Time `json:"asOf"`
+
+	// Bookings are the user's own bookings, cancelled ones included, by
+	// start time
+	Bookings []milesapi.Booking `json:"bookings"`
+
+	// Activity is the feed of followed rooms and colleagues, newest first,
+	// and ActivityCursor where the next poll continues from
+	Activity       []milesapi.ActivityItem `json:"activity"`
+	ActivityCursor string                  `json:"activityCursor"`
+
+	// Reminders are those due for the user's bookings, newest first, kept
+	// until they expire. The TUI shows each one once.
+	Reminders []reminder.Reminder `json:"reminders"`
+}
+
+// Dir returns the directory holding the daemon's socket, cache and log
+// (~/.miles-cli/daemon)
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".miles-cli", "daemon"), nil
+}
+
+// CachePath returns ~/.miles-cli/daemon/cache.json
+func CachePath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache.json"), nil
+}
+
+// LogPath returns ~/.miles-cli/daemon/milesd.log, where 'miles daemon
+// start' sends the daemon's output
+func LogPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "milesd.log"), nil
+}
+
+// ReadCache reads the cache. It returns nil when there is none or it was
+// written by another version.
+func ReadCache() *Cache {
+	path, err := CachePath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cache Cache
+	if json.Unmarshal(data, &cache) != nil || cache.Version != cacheVersion {
+		return nil
+	}
+	return &cache
+}
+
+// save writes the cache atomically, so readers never see half of it. Only
+// the owner can read it, as it holds their meeting titles.
+func (c *Cache) save() error {
+	path, err := CachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cache.json.tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// addActivity puts fresh items, newest first, ahead of those already
+// cached, skipping any already there, and keeps the newest maxActivity
+func (c *Cache) addActivity(items []milesapi.ActivityItem) {
+	seen := make(map[string]bool, len(c.Activity))
+	for _, item := range c.Activity {
+		seen[activityKey(item)] = true
+	}
+	var fresh []milesapi.ActivityItem
+	for _, item := range items {
+		if !seen[activityKey(item)] {
+			fresh = append(fresh, item)
+		}
+	}
+	c.Activity = append(fresh, c.Activity...)
+	if len(c.Activity) > maxActivity {
+		c.Activity = c.Activity[:maxActivity]
+	}
+}
+
+// activityKey identifies an activity item: a booking is made once and
+// cancelled at most once
+func activityKey(item milesapi.ActivityItem) string {
+	return string(item.Type) + " " + item.Booking.Id
+}
@@ -0,0 +1,148 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Commands the control socket understands
+const (
+	CommandStatus = "status"
+	CommandStop   = "stop"
+	CommandSync   = "sync"
+)
+
+// ErrNotRunning is returned by Call when no daemon answers on the socket
+var ErrNotRunning = errors.New("milesd is not running")
+
+// callTimeout bounds a control request, so a hung daemon can't hang the CLI
+const callTimeout = 5 * time.Second
+
+// Request is one line sent to the control socket
+type Request struct {
+	Command string `json:"command"`
+}
+
+// Response is the daemon's one-line answer
+type Response struct {
+	Error  string  `json:"error,omitempty"`
+	Status *Status `json:"status,omitempty"`
+}
+
+// Status describes a running daemon
+type Status struct {
+	PID     int       `json:"pid"`
+	Version string    `json:"version"`
+	Started time.Time `json:"started"`
+	Server  string    `json:"server"`
+	Tasks   []Task    `json:"tasks"`
+}
+
+// Task is one background job and how it last went
+type Task struct {
+	Name     string        `json:"name"`
+	Interval time.Duration `json:"interval"`
+	LastRun  time.Time     `json:"lastRun,omitzero"`
+	LastErr  string        `json:"lastError,omitempty"`
+	Detail   string        `json:"detail,omitempty"`
+}
+
+// SocketPath returns ~/.miles-cli/daemon/milesd.sock
+func SocketPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "milesd.sock"), nil
+}
+
+// Call sends command to the running daemon and returns its answer
+func Call(command string) (*Response, error) {
+	path, err := SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("unix", path, callTimeout)
+	if err != nil {
+		return nil, ErrNotRunning
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(callTimeout))
+
+	if err := json.NewEncoder(conn).Encode(Request{Command: command}); err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return nil, fmt.Errorf("milesd did not answer: %w", err)
+	}
+	if response.Error != "" {
+		return &response, errors.New(response.Error)
+	}
+	return &response, nil
+}
+
+// listen opens the control socket. A socket file left by a daemon that
+// died is removed; one with a daemon answering on it is an error.
+func listen() (net.Listener, error) {
+	path, err := SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if _, err := Call(CommandStatus); err == nil {
+		return nil, errors.New("milesd is already running")
+	}
+	os.Remove(path)
+	return net.Listen("unix", path)
+}
+
+// serve answers control requests until the listener is closed
+func (d *daemon) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go d.handle(conn)
+	}
+}
+
+// handle answers one control request
+func (d *daemon) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(callTimeout))
+
+	var request Request
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err == nil {
+		err = json.Unmarshal(line, &request)
+	}
+	if err != nil {
+		json.NewEncoder(conn).Encode(Response{Error: "invalid request"})
+		return
+	}
+
+	var response Response
+	switch request.Command {
+	case CommandStatus:
+		response.Status = d.status()
+	case CommandStop:
+		response.Status = d.status()
+		d.stop()
+	case CommandSync:
+		d.wake()
+		response.Status = d.status()
+	default:
+		response.Error = fmt.Sprintf("unknown command %q", request.Command)
+	}
+	json.NewEncoder(conn).Encode(response)
+}
@@ -0,0 +1,351 @@
+// Package daemon is milesd, a background process that keeps the user's
+// bookings, activity feed and external calendar up to date and reminds
+// them of their bookings. It writes what it fetches, and the reminders due,
+// to a cache the CLI and TUI read instead of polling, and takes commands on
+// a local control socket (see Call).
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-cli/internal/mirror"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/reminder"
+)
+
+// DefaultPollInterval is how often bookings and activity are fetched
+const DefaultPollInterval = 30 * time.Second
+
+// DefaultCalendarInterval is how often bookings are pushed to the calendar
+const DefaultCalendarInterval = 15 * time.Minute
+
+// Task names, as shown by 'miles daemon status'
+const (
+	taskBookings  = "bookings"
+	taskActivity  = "activity"
+	taskReminders = "reminders"
+	taskCalendar  = "calendar"
+)
+
+// Options configures the daemon
+type Options struct {
+	API     config.API
+	Server  string // API URL, recorded in the cache
+	UserID  string
+	Version string
+
+	// Mirror is the bookings mirror behind 'miles bookings'; the daemon
+	// keeps it synced
+	Mirror *mirror.Mirror
+
+	PollInterval time.Duration
+
+	// Calendar is the provider bookings are pushed to, "gcal" or
+	// "outlook", or empty for none. Connect signs in to it without asking
+	// the user, as nobody is watching.
+	Calendar         string
+	CalendarInterval time.Duration
+	Connect          func(ctx context.Context) (calsync.Provider, error)
+	DeleteCancelled  bool
+
+	// Notify tells the user about a reminder that has come due, e.g. with
+	// a desktop notification. Reminders are cached for the TUI either way.
+	Notify func(ctx context.Context, r reminder.Reminder)
+
+	// Logf logs what the daemon does
+	Logf func(format string, args ...any)
+}
+
+// daemon is a running milesd
+type daemon struct {
+	opts    Options
+	started time.Time
+	cancel  context.CancelFunc
+	wakeup  chan struct{}
+
+	mu    sync.Mutex
+	tasks map[string]*Task
+	cache *Cache
+
+	reminders *reminder.Scheduler
+
+	provider calsync.Provider
+	synced   time.Time // Last calendar sync attempt; wake clears it
+}
+
+// Run runs the daemon until ctx is done or it is told to stop
+func Run(ctx context.Context, opts Options) error {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.CalendarInterval <= 0 {
+		opts.CalendarInterval = DefaultCalendarInterval
+	}
+	if opts.Logf == nil {
+		opts.Logf = func(string, ...any) {}
+	}
+	if opts.Notify == nil {
+		opts.Notify = func(context.Context, reminder.Reminder) {}
+	}
+
+	listener, err := listen()
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	d := &daemon{
+		opts:    opts,
+		started: time.Now(),
+		cancel:  cancel,
+		wakeup:  make(chan struct{}, 1),
+		tasks: map[string]*Task{
+			taskBookings:  {Name: taskBookings, Interval: opts.PollInterval},
+			taskActivity:  {Name: taskActivity, Interval: opts.PollInterval},
+			taskReminders: {Name: taskReminders, Interval: opts.PollInterval},
+		},
+		cache: &Cache{Version: cacheVersion, Server: opts.Server, UserID: opts.UserID},
+	}
+	d.reminders = reminder.NewScheduler(opts.UserID, d.nextBooking)
+	if opts.Calendar != "" {
+		d.tasks[taskCalendar] = &Task{Name: taskCalendar, Interval: opts.CalendarInterval, Detail: opts.Calendar}
+	}
+	// Pick up the activity cursor of an earlier run, so nothing is missed,
+	// and the reminders it gave, so none is given twice
+	if cached := ReadCache(); cached != nil && cached.Server == opts.Server && cached.UserID == opts.UserID {
+		d.cache.Activity, d.cache.ActivityCursor = cached.Activity, cached.ActivityCursor
+		d.cache.Reminders = cached.Reminders
+		d.reminders.Given(cached.Reminders)
+	}
+
+	go d.serve(listener)
+	opts.Logf("milesd %s started (pid %d), polling every %s", opts.Version, os.Getpid(), opts.PollInterval)
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		d.poll(ctx)
+		select {
+		case <-ctx.Done():
+			opts.Logf("milesd stopped")
+			return nil
+		case <-ticker.C:
+		case <-d.wakeup:
+		}
+	}
+}
+
+// poll runs every task that is due and saves the cache
+func (d *daemon) poll(ctx context.Context) {
+	fresh := d.run(taskBookings, func() (string, error) { return d.syncBookings(ctx) })
+	if fresh {
+		d.run(taskReminders, func() (string, error) { return d.remind(ctx), nil })
+	}
+	fresh = d.run(taskActivity, func() (string, error) { return d.syncActivity(ctx) }) && fresh
+	d.mu.Lock()
+	calendarDue := d.opts.Calendar != "" && time.Since(d.synced) >= d.opts.CalendarInterval
+	if calendarDue {
+		d.synced = time.Now()
+	}
+	d.mu.Unlock()
+	if calendarDue {
+		d.run(taskCalendar, func() (string, error) { return d.syncCalendar(ctx) })
+	}
+
+	// A cache that failed to update keeps its old time, so readers fall
+	// back to asking the server once it goes stale
+	if !fresh {
+		return
+	}
+	d.mu.Lock()
+	d.cache.AsOf = time.Now()
+	err := d.cache.save()
+	d.mu.Unlock()
+	if err != nil {
+		d.opts.Logf("saving cache: %v", err)
+	}
+}
+
+// run runs one task and records how it went, reporting whether it worked
+func (d *daemon) run(name string, task func() (string, error)) bool {
+	detail, err := task()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	t := d.tasks[name]
+	t.LastRun = time.Now()
+	t.LastErr = ""
+	if err != nil {
+		t.LastErr = err.Error()
+		d.opts.Logf("%s: %v", name, err)
+		return false
+	}
+	if detail != "" {
+		t.Detail = detail
+	}
+	return true
+}
+
+// syncBookings brings the mirror up to date and caches the user's own
+// bookings from it
+func (d *daemon) syncBookings(ctx context.Context) (string, error) {
+	if err := d.opts.Mirror.Sync(ctx, d.opts.API); err != nil {
+		return "", err
+	}
+	if err := d.opts.Mirror.Save(); err != nil {
+		return "", err
+	}
+
+	// Admins and managers see other people's bookings too
+	var mine []milesapi.Booking
+	for _, booking := range d.opts.Mirror.List() {
+		if booking.UserId != nil && *booking.UserId == d.opts.UserID {
+			mine = append(mine, booking)
+		}
+	}
+
+	d.mu.Lock()
+	d.cache.Bookings = mine
+	d.mu.Unlock()
+	return fmt.Sprintf("%d bookings", len(mine)), nil
+}
+
+// remind caches the reminders my bookings call for now, dropping those
+// that have expired, and notifies the user of the new ones
+func (d *daemon) remind(ctx context.Context) string {
+	d.mu.Lock()
+	bookings := d.cache.Bookings
+	d.mu.Unlock()
+
+	now := config.ServerNow()
+	due := d.reminders.Due(ctx, bookings, now)
+
+	d.mu.Lock()
+	current := due
+	for _, r := range d.cache.Reminders {
+		if !r.Expired(now) {
+			current = append(current, r)
+		}
+	}
+	d.cache.Reminders = current
+	d.mu.Unlock()
+
+	for _, r := range due {
+		d.opts.Logf("reminder: %s", r.Text())
+		d.opts.Notify(ctx, r)
+	}
+	return fmt.Sprintf("%d pending", len(current))
+}
+
+// nextBooking looks up who has booking's room after it, for the handover
+// reminder
+func (d *daemon) nextBooking(ctx context.Context, booking milesapi.Booking) (*milesapi.Booking, error) {
+	end := booking.GetEndTime()
+	next, err := d.opts.API.GetRoomAvailability(ctx, booking.GetRoomId(), end, end.Add(reminder.HandoverWindow))
+	if err != nil {
+		return nil, err
+	}
+	return reminder.Successor(booking, next), nil
+}
+
+// syncActivity fetches activity since the last poll
+func (d *daemon) syncActivity(ctx context.Context) (string, error) {
+	d.mu.Lock()
+	cursor := d.cache.ActivityCursor
+	d.mu.Unlock()
+
+	items, next, err := d.opts.API.GetActivity(ctx, cursor)
+	if err != nil {
+		return "", err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cache.addActivity(items)
+	d.cache.ActivityCursor = next
+	return fmt.Sprintf("%d items", len(d.cache.Activity)), nil
+}
+
+// syncCalendar pushes the user's bookings to the external calendar,
+// signing in on first use
+func (d *daemon) syncCalendar(ctx context.Context) (string, error) {
+	if d.provider == nil {
+		provider, err := d.opts.Connect(ctx)
+		if err != nil {
+			return "", err
+		}
+		d.provider = provider
+	}
+
+	store, err := calsync.OpenStore(d.opts.Calendar)
+	if err != nil {
+		return "", err
+	}
+
+	rooms := make(map[string]string)
+	for _, room := range d.opts.Mirror.Rooms {
+		if room.Id != nil && room.Name != nil {
+			rooms[*room.Id] = *room.Name
+		}
+	}
+
+	d.mu.Lock()
+	bookings := d.cache.Bookings
+	d.mu.Unlock()
+
+	result := calsync.Sync(ctx, d.provider, store, bookings, calsync.Options{
+		DeleteCancelled: d.opts.DeleteCancelled,
+		Rooms:           rooms,
+	})
+	if err := store.Save(); err != nil {
+		return "", fmt.Errorf("failed to save sync state: %w", err)
+	}
+	detail := fmt.Sprintf("%s: %d created, %d updated, %d deleted", d.opts.Calendar, result.Created, result.Updated, result.Deleted)
+	if len(result.Failed) > 0 {
+		return "", fmt.Errorf("%d bookings failed to sync, first: %w", len(result.Failed), result.Failed[0])
+	}
+	return detail, nil
+}
+
+// status reports the daemon and its tasks, in a fixed order
+func (d *daemon) status() *Status {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	status := &Status{
+		PID:     os.Getpid(),
+		Version: d.opts.Version,
+		Started: d.started,
+		Server:  d.opts.Server,
+	}
+	for _, name := range []string{taskBookings, taskReminders, taskActivity, taskCalendar} {
+		if t, ok := d.tasks[name]; ok {
+			status.Tasks = append(status.Tasks, *t)
+		}
+	}
+	return status
+}
+
+// wake runs the tasks now rather than at the next tick
+func (d *daemon) wake() {
+	select {
+	case d.wakeup <- struct{}{}:
+	default:
+	}
+	d.mu.Lock()
+	d.synced = time.Time{}
+	d.mu.Unlock()
+}
+
+// stop ends Run
+func (d *daemon) stop() {
+	d.cancel()
+}
@@ -0,0 +1,49 @@
+// Package keychain keeps the login token in the operating system's
+// credential store: the macOS Keychain, the Windows Credential Manager or
+// the Secret Service (GNOME Keyring, KWallet) on Linux. Tokens are stored
+// per server, so logging in to staging doesn't sign you out of production.
+//
+// Machines without a credential store, such as CI runners and headless
+// servers, return an error from every call; callers fall back to the
+// config file.
+package keychain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Service is the name entries are stored under
+const Service = "miles-cli"
+
+// ErrNotFound is returned when no token is stored for a server
+var ErrNotFound = keyring.ErrNotFound
+
+// Token returns the token stored for server
+func Token(server string) (string, error) {
+	token, err := keyring.Get(Service, server)
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf("keychain unavailable: %w", err)
+	}
+	return token, err
+}
+
+// SetToken stores the token for server, replacing any stored before
+func SetToken(server, token string) error {
+	if err := keyring.Set(Service, server, token); err != nil {
+		return fmt.Errorf("keychain unavailable: %w", err)
+	}
+	return nil
+}
+
+// DeleteToken removes the token stored for server. Nothing stored is not
+// an error.
+func DeleteToken(server string) error {
+	err := keyring.Delete(Service, server)
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("keychain unavailable: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package mirror
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/secret"
+)
+
+// Index is a full-text index of bookings: each word of a booking's title,
+// description, room and location points back at the booking
+type Index struct {
+	// words is sorted so prefixes can be found by binary search
+	words    []string
+	postings map[string]map[string]bool
+}
+
+// NewIndex indexes bookings by their text and the names of their room and
+// location. Encrypted descriptions are indexed decrypted with key.
+func NewIndex(bookings map[string]milesapi.Booking, rooms []milesapi.Room, locations []milesapi.Location, key []byte) *Index {
+	locationText := map[string]string{}
+	for _, location := range locations {
+		if location.Id != nil {
+			locationText[*location.Id] = deref(location.Name) + " " + deref(location.City)
+		}
+	}
+	roomText := map[string]string{}
+	for _, room := range rooms {
+		if room.Id != nil {
+			roomText[*room.Id] = deref(room.Name) + " " + locationText[deref(room.LocationId)]
+		}
+	}
+
+	ix := &Index{postings: map[string]map[string]bool{}}
+	for id, booking := range bookings {
+		description := secret.Reveal(key, deref(booking.Description))
+		text := deref(booking.Title) + " " + description + " " + roomText[deref(booking.RoomId)]
+		for _, word := range words(text) {
+			if ix.postings[word] == nil {
+				ix.postings[word] = map[string]bool{}
+				ix.words = append(ix.words, word)
+			}
+			ix.postings[word][id] = true
+		}
+	}
+	sort.Strings(ix.words)
+	return ix
+}
+
+// Search returns the IDs of bookings containing every word of query. Words
+// match case-insensitively as prefixes, so "des" finds "Design review";
+// a word starting with "-" excludes bookings containing it.
+func (ix *Index) Search(query string) []string {
+	var include, exclude []string
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "-") {
+			exclude = append(exclude, words(field)...)
+		} else {
+			include = append(include, words(field)...)
+		}
+	}
+	if len(include) == 0 {
+		return nil
+	}
+
+	matches := ix.prefixed(include[0])
+	for _, word := range include[1:] {
+		next := ix.prefixed(word)
+		for id := range matches {
+			if !next[id] {
+				delete(matches, id)
+			}
+		}
+	}
+	for _, word := range exclude {
+		for id := range ix.prefixed(word) {
+			delete(matches, id)
+		}
+	}
+
+	ids := make([]string, 0, len(matches))
+	for id := range matches {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// prefixed returns the bookings containing a word starting with prefix
+func (ix *Index) prefixed(prefix string) map[string]bool {
+	ids := map[string]bool{}
+	for i := sort.SearchStrings(ix.words, prefix); i < len(ix.words) && strings.HasPrefix(ix.words[i], prefix); i++ {
+		for id := range ix.postings[ix.words[i]] {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// words splits text into lower-case words of letters and digits
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// deref returns the string a pointer points at, or ""
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,169 @@
+// Package mirror keeps a local copy of the user's bookings, with the rooms
+// and locations they refer to, in ~/.miles-mirror.json. It is brought up to
+// date with delta syncs, so listing is cheap, and it can be read and
+// searched without a connection.
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// FileName is the mirror in the home directory
+const FileName = ".miles-mirror.json"
+
+// version changes when the file layout does, discarding older mirrors
+const version = 1
+
+// placesMaxAge is how long rooms and locations are kept before refetching;
+// they change far less often than bookings
+const placesMaxAge = time.Hour
+
+// DefaultPath returns ~/.miles-mirror.json
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return FileName
+	}
+	return filepath.Join(home, FileName)
+}
+
+// Mirror is the local copy of one user's bookings on one server
+type Mirror struct {
+	Version        int                         `json:"version"`
+	Owner          string                      `json:"owner"`
+	Cursor         string                      `json:"cursor"`
+	SyncedAt       time.Time                   `json:"syncedAt"`
+	PlacesSyncedAt time.Time                   `json:"placesSyncedAt"`
+	Bookings       map[string]milesapi.Booking `json:"bookings"`
+	Rooms          []milesapi.Room             `json:"rooms"`
+	Locations      []milesapi.Location         `json:"locations"`
+
+	// DescriptionKey decrypts encrypted descriptions for Search. The mirror
+	// itself keeps them encrypted.
+	DescriptionKey []byte `json:"-"`
+
+	path  string
+	index *Index
+}
+
+// Open reads the mirror at path. owner identifies the server and user; a
+// mirror written for anyone else, or one that can't be read, is replaced by
+// an empty one that the next Sync fills.
+func Open(path, owner string) *Mirror {
+	m := &Mirror{}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, m)
+	}
+	if m.Version != version || m.Owner != owner || m.Bookings == nil {
+		m = &Mirror{Version: version, Owner: owner, Bookings: map[string]milesapi.Booking{}}
+	}
+	m.path = path
+	return m
+}
+
+// Empty reports whether the mirror has never been synced
+func (m *Mirror) Empty() bool {
+	return m.SyncedAt.IsZero()
+}
+
+// Sync merges the bookings changed since the last sync, refetching rooms
+// and locations when they are over an hour old
+func (m *Mirror) Sync(ctx context.Context, client config.API) error {
+	delta, cursor, err := client.GetBookingsSince(ctx, m.Cursor)
+	if err != nil {
+		return err
+	}
+
+	if time.Since(m.PlacesSyncedAt) > placesMaxAge {
+		rooms, err := client.GetRooms(ctx, "")
+		if err != nil {
+			return err
+		}
+		locations, err := client.GetLocations(ctx)
+		if err != nil {
+			return err
+		}
+		m.Rooms, m.Locations = rooms, locations
+		m.PlacesSyncedAt = time.Now()
+	}
+
+	for _, booking := range delta {
+		if booking.Id != nil {
+			m.Bookings[*booking.Id] = booking
+		}
+	}
+	m.Cursor = cursor
+	m.SyncedAt = time.Now()
+	m.index = nil
+	return nil
+}
+
+// Save writes the mirror back to its file. Only the owner can read it, as
+// it holds their meeting titles and descriptions.
+func (m *Mirror) Save() error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(m.path), "."+filepath.Base(m.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), m.path)
+}
+
+// List returns every booking, cancelled ones included, by start time
+func (m *Mirror) List() []milesapi.Booking {
+	bookings := make([]milesapi.Booking, 0, len(m.Bookings))
+	for _, booking := range m.Bookings {
+		bookings = append(bookings, booking)
+	}
+	sortByStart(bookings)
+	return bookings
+}
+
+// Search returns the bookings matching a full-text query, by start time.
+// See Index.Search for the query syntax.
+func (m *Mirror) Search(query string) []milesapi.Booking {
+	if m.index == nil {
+		m.index = NewIndex(m.Bookings, m.Rooms, m.Locations, m.DescriptionKey)
+	}
+	var bookings []milesapi.Booking
+	for _, id := range m.index.Search(query) {
+		bookings = append(bookings, m.Bookings[id])
+	}
+	sortByStart(bookings)
+	return bookings
+}
+
+// sortByStart orders bookings by start time, those without one first
+func sortByStart(bookings []milesapi.Booking) {
+	sort.Slice(bookings, func(i, j int) bool {
+		a, b := bookings[i].StartTime, bookings[j].StartTime
+		if a == nil || b == nil {
+			return b != nil
+		}
+		return a.Before(*b)
+	})
+}
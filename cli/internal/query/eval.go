@@ -0,0 +1,341 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-cli/internal/config"
+)
+
+type node interface {
+	eval(env Env) (any, error)
+	position() int
+}
+
+type literalNode struct {
+	value any
+	pos   int
+}
+
+type fieldNode struct {
+	name string
+	pos  int
+}
+
+type builtinNode struct {
+	name string
+	pos  int
+}
+
+type unaryNode struct {
+	op      string
+	pos     int
+	operand node
+}
+
+type binaryNode struct {
+	op          string
+	pos         int
+	left, right node
+}
+
+func (n *literalNode) position() int { return n.pos }
+func (n *fieldNode) position() int   { return n.pos }
+func (n *builtinNode) position() int { return n.pos }
+func (n *unaryNode) position() int   { return n.pos }
+func (n *binaryNode) position() int  { return n.pos }
+
+func (n *literalNode) eval(Env) (any, error) {
+	return n.value, nil
+}
+
+func (n *fieldNode) eval(env Env) (any, error) {
+	v, ok := env(n.name)
+	if !ok {
+		return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("unknown field %q", n.name)}
+	}
+	return normalize(v), nil
+}
+
+// eval resolves now, today and tomorrow by the server's clock, so queries
+// agree with the server about which bookings have passed
+func (n *builtinNode) eval(Env) (any, error) {
+	now := config.ServerNow()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	switch n.name {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	default:
+		return now, nil
+	}
+}
+
+func (n *unaryNode) eval(env Env) (any, error) {
+	v, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "!":
+		if b, ok := v.(bool); ok {
+			return !b, nil
+		}
+	case "-":
+		switch v := v.(type) {
+		case float64:
+			return -v, nil
+		case time.Duration:
+			return -v, nil
+		}
+	}
+	return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("cannot apply %s to %s", n.op, describe(v))}
+}
+
+func (n *binaryNode) eval(env Env) (any, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || short-circuit so guards like room.name!=null && ... work
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("%s needs conditions, got %s", n.op, describe(left))}
+		}
+		if n.op == "&&" && !l || n.op == "||" && l {
+			return l, nil
+		}
+		right, err := n.right.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("%s needs conditions, got %s", n.op, describe(right))}
+		}
+		return r, nil
+	}
+
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==", "!=":
+		equal, err := n.equal(left, right)
+		if err != nil {
+			return nil, err
+		}
+		return equal == (n.op == "=="), nil
+	case "<", "<=", ">", ">=":
+		return n.compare(left, right)
+	case "=~":
+		return n.match(left, right)
+	case "+", "-":
+		return n.arithmetic(left, right)
+	}
+	return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("unknown operator %s", n.op)}
+}
+
+func (n *binaryNode) equal(left, right any) (bool, error) {
+	if left == nil || right == nil {
+		return left == nil && right == nil, nil
+	}
+	left, right, err := n.coerce(left, right)
+	if err != nil {
+		return false, err
+	}
+	if l, ok := left.(time.Time); ok {
+		return l.Equal(right.(time.Time)), nil
+	}
+	return left == right, nil
+}
+
+func (n *binaryNode) compare(left, right any) (bool, error) {
+	// Missing values never satisfy an ordering
+	if left == nil || right == nil {
+		return false, nil
+	}
+	left, right, err := n.coerce(left, right)
+	if err != nil {
+		return false, err
+	}
+
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		cmp = compareOrdered(l, right.(float64))
+	case string:
+		cmp = strings.Compare(l, right.(string))
+	case time.Duration:
+		cmp = compareOrdered(l, right.(time.Duration))
+	case time.Time:
+		cmp = l.Compare(right.(time.Time))
+	default:
+		return false, &Error{Pos: n.pos, Msg: fmt.Sprintf("cannot order %s", describe(left))}
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+func (n *binaryNode) match(left, right any) (bool, error) {
+	pattern, ok := right.(string)
+	if !ok {
+		return false, &Error{Pos: n.pos, Msg: "=~ needs a pattern string on the right"}
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, &Error{Pos: n.pos, Msg: fmt.Sprintf("invalid pattern: %v", err)}
+	}
+	if left == nil {
+		return false, nil
+	}
+	s, ok := left.(string)
+	if !ok {
+		return false, &Error{Pos: n.pos, Msg: fmt.Sprintf("=~ needs a string on the left, got %s", describe(left))}
+	}
+	return re.MatchString(s), nil
+}
+
+func (n *binaryNode) arithmetic(left, right any) (any, error) {
+	sign := time.Duration(1)
+	if n.op == "-" {
+		sign = -1
+	}
+
+	switch l := left.(type) {
+	case time.Time:
+		switch r := right.(type) {
+		case time.Duration:
+			return l.Add(sign * r), nil
+		case time.Time:
+			if n.op == "-" {
+				return l.Sub(r), nil
+			}
+		}
+	case time.Duration:
+		if r, ok := right.(time.Duration); ok {
+			return l + sign*r, nil
+		}
+	case float64:
+		if r, ok := right.(float64); ok {
+			return l + float64(sign)*r, nil
+		}
+	}
+	return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("cannot compute %s %s %s", describe(left), n.op, describe(right))}
+}
+
+// coerce converts both sides to the same type. Strings are parsed when
+// compared with times or durations.
+func (n *binaryNode) coerce(left, right any) (any, any, error) {
+	switch l := left.(type) {
+	case time.Time:
+		if s, ok := right.(string); ok {
+			t, err := parseTime(s)
+			if err != nil {
+				return nil, nil, &Error{Pos: n.right.position(), Msg: err.Error()}
+			}
+			right = t
+		}
+	case time.Duration:
+		if s, ok := right.(string); ok {
+			d, err := parseDuration(s)
+			if err != nil {
+				return nil, nil, &Error{Pos: n.right.position(), Msg: err.Error()}
+			}
+			right = d
+		}
+	case string:
+		switch right.(type) {
+		case time.Time:
+			t, err := parseTime(l)
+			if err != nil {
+				return nil, nil, &Error{Pos: n.left.position(), Msg: err.Error()}
+			}
+			left = t
+		case time.Duration:
+			d, err := parseDuration(l)
+			if err != nil {
+				return nil, nil, &Error{Pos: n.left.position(), Msg: err.Error()}
+			}
+			left = d
+		}
+	}
+
+	if fmt.Sprintf("%T", left) != fmt.Sprintf("%T", right) {
+		return nil, nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("cannot compare %s with %s", describe(left), describe(right))}
+	}
+	return left, right, nil
+}
+
+// parseTime accepts the same formats as `miles book`
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date or time (use 2006-01-02 or 2006-01-02 15:04)", s)
+}
+
+// normalize converts the value types an Env may return to the ones used here
+func normalize(v any) any {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return v
+}
+
+func compareOrdered[T ~int64 | ~float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// describe names a value's type for error messages
+func describe(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a condition"
+	case time.Time:
+		return "a time"
+	case time.Duration:
+		return "a duration"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokDuration
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+
+	num float64
+	dur time.Duration
+}
+
+// operators longest first so "==" wins over "="
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "<", ">", "!", "+", "-"}
+
+// lex splits src into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	i := 0
+
+	for i < len(runes) {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(' || r == ')':
+			kind := tokLParen
+			if r == ')' {
+				kind = tokRParen
+			}
+			tokens = append(tokens, token{kind: kind, text: string(r), pos: i})
+			i++
+
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var b strings.Builder
+			closed := false
+			for i < len(runes) {
+				c := runes[i]
+				if c == '\\' && i+1 < len(runes) {
+					b.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				i++
+				if c == r {
+					closed = true
+					break
+				}
+				b.WriteRune(c)
+			}
+			if !closed {
+				return nil, &Error{Pos: start, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokString, text: b.String(), pos: start})
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			if i < len(runes) && unicode.IsLetter(runes[i]) {
+				// A number followed by a unit is a duration, e.g. 30m or 1h30m
+				for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+					i++
+				}
+				text := string(runes[start:i])
+				d, err := parseDuration(text)
+				if err != nil {
+					return nil, &Error{Pos: start, Msg: fmt.Sprintf("invalid duration %q", text)}
+				}
+				tokens = append(tokens, token{kind: tokDuration, text: text, pos: start, dur: d})
+				continue
+			}
+			text := string(runes[start:i])
+			n, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, &Error{Pos: start, Msg: fmt.Sprintf("invalid number %q", text)}
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: text, pos: start, num: n})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: start})
+
+		default:
+			rest := string(runes[i:])
+			matched := ""
+			for _, op := range operators {
+				if strings.HasPrefix(rest, op) {
+					matched = op
+					break
+				}
+			}
+			if matched == "" {
+				msg := fmt.Sprintf("unexpected %q", r)
+				if r == '=' || r == '&' || r == '|' {
+					msg += fmt.Sprintf(" (did you mean %q?)", string(r)+string(r))
+				}
+				return nil, &Error{Pos: i, Msg: msg}
+			}
+			tokens = append(tokens, token{kind: tokOp, text: matched, pos: i})
+			i += len([]rune(matched))
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
+
+// parseDuration parses durations like "30m", "1h30m", "2d" or "1w".
+// Days and weeks are 24h and 7×24h.
+func parseDuration(text string) (time.Duration, error) {
+	var total time.Duration
+	rest := text
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.') {
+			i++
+		}
+		j := i
+		for j < len(rest) && !(rest[j] >= '0' && rest[j] <= '9') {
+			j++
+		}
+		if i == 0 || j == i {
+			return 0, fmt.Errorf("invalid duration %q", text)
+		}
+
+		n, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, err
+		}
+		var unit time.Duration
+		switch rest[i:j] {
+		case "s":
+			unit = time.Second
+		case "m":
+			unit = time.Minute
+		case "h":
+			unit = time.Hour
+		case "d":
+			unit = 24 * time.Hour
+		case "w":
+			unit = 7 * 24 * time.Hour
+		default:
+			return 0, fmt.Errorf("unknown unit %q", rest[i:j])
+		}
+		total += time.Duration(n * float64(unit))
+		rest = rest[j:]
+	}
+	return total, nil
+}
@@ -0,0 +1,294 @@
+// Package query implements the small filter language used by
+// `miles bookings --filter`, e.g.
+//
+//	status==CONFIRMED && room.location.city=="Oslo" && start>now
+//
+// Expressions combine field comparisons with &&, || and !. Values are
+// strings, numbers, booleans, times and durations (30m, 2h, 1d, 1w). Times
+// can be compared with date strings ("2025-10-19", "2025-10-19 14:00") and
+// shifted by durations (start < now+7d). Bare upper-case words such as
+// CONFIRMED are string constants. The built-ins now, today and tomorrow are
+// the current time and the start of today and tomorrow.
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Error describes a syntax or evaluation error and where it happened
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+// Env resolves a field name like "room.location.city" to its value for the
+// item being filtered. Values are string, bool, numbers, time.Time,
+// time.Duration or nil. It reports false for unknown fields.
+type Env func(field string) (any, bool)
+
+// Expr is a parsed filter expression
+type Expr struct {
+	src  string
+	root node
+}
+
+// Parse parses a filter expression
+func Parse(src string) (*Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, &Error{Pos: 0, Msg: "empty expression"}
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// String returns the source of the expression
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Fields returns the field names the expression refers to, sorted
+func (e *Expr) Fields() []string {
+	seen := map[string]bool{}
+	var walk func(n node)
+	walk = func(n node) {
+		switch n := n.(type) {
+		case *fieldNode:
+			seen[n.name] = true
+		case *unaryNode:
+			walk(n.operand)
+		case *binaryNode:
+			walk(n.left)
+			walk(n.right)
+		}
+	}
+	walk(e.root)
+
+	fields := make([]string, 0, len(seen))
+	for name := range seen {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Uses reports whether the expression refers to field or any field below it,
+// e.g. Uses("room") is true for room.location.city
+func (e *Expr) Uses(field string) bool {
+	for _, name := range e.Fields() {
+		if name == field || strings.HasPrefix(name, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Match evaluates the expression against one item
+func (e *Expr) Match(env Env) (bool, error) {
+	v, err := e.root.eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, &Error{Pos: e.root.position(), Msg: fmt.Sprintf("expression is %s, not a condition", describe(v))}
+	}
+	return b, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) acceptOp(ops ...string) (token, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return tok, false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			return p.next(), true
+		}
+	}
+	return tok, false
+}
+
+// parseOr parses a || b || ...
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp("||")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text, pos: op.pos, left: left, right: right}
+	}
+}
+
+// parseAnd parses a && b && ...
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp("&&")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text, pos: op.pos, left: left, right: right}
+	}
+}
+
+// parseNot parses !a, where a is a comparison
+func (p *parser) parseNot() (node, error) {
+	if op, ok := p.acceptOp("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op.text, pos: op.pos, operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses a single a OP b; comparisons don't chain
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.acceptOp("==", "!=", "<", "<=", ">", ">=", "=~")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryNode{op: op.text, pos: op.pos, left: left, right: right}, nil
+}
+
+// parseSum parses a + b - c ...
+func (p *parser) parseSum() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text, pos: op.pos, left: left, right: right}
+	}
+}
+
+// parsePrimary parses literals, fields, negation and parentheses
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return &literalNode{value: tok.text, pos: tok.pos}, nil
+	case tokNumber:
+		return &literalNode{value: tok.num, pos: tok.pos}, nil
+	case tokDuration:
+		return &literalNode{value: tok.dur, pos: tok.pos}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{value: true, pos: tok.pos}, nil
+		case "false":
+			return &literalNode{value: false, pos: tok.pos}, nil
+		case "null", "nil":
+			return &literalNode{value: nil, pos: tok.pos}, nil
+		case "now", "today", "tomorrow":
+			return &builtinNode{name: tok.text, pos: tok.pos}, nil
+		}
+		if isConstant(tok.text) {
+			return &literalNode{value: tok.text, pos: tok.pos}, nil
+		}
+		return &fieldNode{name: tok.text, pos: tok.pos}, nil
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &Error{Pos: closing.pos, Msg: "missing \")\""}
+		}
+		return inner, nil
+	case tokOp:
+		if tok.text == "-" {
+			operand, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			return &unaryNode{op: "-", pos: tok.pos, operand: operand}, nil
+		}
+	case tokEOF:
+		return nil, &Error{Pos: tok.pos, Msg: "unexpected end of expression"}
+	}
+	return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+}
+
+// isConstant reports whether an identifier is an upper-case enum value
+// like CONFIRMED rather than a field name
+func isConstant(name string) bool {
+	hasLetter := false
+	for _, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			hasLetter = true
+		case r == '_' || r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return hasLetter
+}
@@ -0,0 +1,163 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// SelectLocation sets LocationID, from LocationQuery when there is one and
+// otherwise from a prompt starting at the detected office
+func SelectLocation(ctx context.Context, s *Session) error {
+	locations, err := s.Client.GetLocations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch locations: %w", err)
+	}
+
+	if len(locations) == 0 {
+		return fmt.Errorf("no locations available")
+	}
+
+	// An explicit location skips the prompt
+	if s.LocationQuery != "" {
+		i := -1
+		if s.MatchLocation != nil {
+			i = s.MatchLocation(locations, s.LocationQuery)
+		}
+		if i < 0 {
+			return fmt.Errorf("no location matches %q. Run 'miles rooms' to list locations", s.LocationQuery)
+		}
+		fmt.Printf("📍 Location: %s (from --location)\n\n", deref(locations[i].Name))
+		s.LocationID = deref(locations[i].Id)
+		return nil
+	}
+
+	// Otherwise start the prompt at the office the network points to
+	cursor := 0
+	if s.DetectOffice != nil {
+		if i, reason := s.DetectOffice(locations); i >= 0 {
+			cursor = i
+			fmt.Printf("📍 You seem to be at %s (%s). Pick another below or use --location.\n\n", deref(locations[i].Name), reason)
+		}
+	}
+
+	items := make([]string, len(locations))
+	for i, loc := range locations {
+		items[i] = "Unknown"
+		if loc.Name != nil {
+			items[i] = *loc.Name
+		}
+	}
+
+	prompt := promptui.Select{
+		Label: "Select location",
+		Items: items,
+		Size:  10,
+	}
+
+	idx, _, err := prompt.RunCursorAt(cursor, max(0, cursor-prompt.Size+1))
+	if err != nil {
+		return fmt.Errorf("location selection cancelled")
+	}
+
+	s.LocationID = deref(locations[idx].Id)
+	return nil
+}
+
+// roomItem is a room as the room prompt lists it
+type roomItem struct {
+	Display string
+	ID      string
+	Room    milesapi.Room
+}
+
+// SelectRoom sets RoomID from a searchable prompt of the rooms at
+// LocationID. Locked rooms are listed but can't be chosen.
+func SelectRoom(ctx context.Context, s *Session) error {
+	rooms, err := s.Client.GetRooms(ctx, s.LocationID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch rooms: %w", err)
+	}
+	if s.Rooms != nil {
+		rooms = s.Rooms(rooms)
+	}
+
+	if len(rooms) == 0 {
+		return fmt.Errorf("no rooms available in this location")
+	}
+
+	locked := func(room milesapi.Room) bool {
+		return s.Locked != nil && s.Locked(room)
+	}
+
+	items := make([]roomItem, len(rooms))
+	for i, room := range rooms {
+		name := "Unknown"
+		if room.Name != nil {
+			name = *room.Name
+		}
+		capacity := 0
+		if room.Capacity != nil {
+			capacity = *room.Capacity
+		}
+		display := fmt.Sprintf("%s (capacity: %d)", name, capacity)
+		if locked(room) {
+			display = "🔒 " + display + " - restricted"
+		}
+		items[i] = roomItem{
+			Display: display,
+			ID:      deref(room.Id),
+			Room:    room,
+		}
+	}
+
+	// Search by what is shown or by room ID
+	searcher := func(input string, index int) bool {
+		item := items[index]
+		input = strings.ToLower(input)
+		return strings.Contains(strings.ToLower(item.Display), input) ||
+			strings.Contains(strings.ToLower(item.ID), input)
+	}
+
+	prompt := promptui.Select{
+		Label: "Select room (type to search)",
+		Items: items,
+		Size:  10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "▸ {{ .Display }}",
+			Inactive: "  {{ .Display }}",
+			Selected: "✓ {{ .Display }}",
+		},
+		Searcher: searcher,
+	}
+
+	for {
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return fmt.Errorf("room selection cancelled")
+		}
+
+		item := items[idx]
+		if !locked(item.Room) {
+			s.RoomID = item.ID
+			return nil
+		}
+
+		if s.Unlock != nil {
+			s.Unlock(ctx, item.Room)
+		}
+		fmt.Println("Pick a room you can book for now.")
+	}
+}
+
+// deref returns the string a pointer points to, or ""
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,63 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+// Ask prompts for a value, with an optional hint on the format
+func Ask(label, hint string, required bool) (string, error) {
+	templates := &promptui.PromptTemplates{
+		Prompt:  "{{ . }} ",
+		Valid:   "{{ . | green }} ",
+		Invalid: "{{ . | red }} ",
+		Success: "{{ . | bold }} ",
+	}
+
+	fullLabel := label
+	if hint != "" {
+		fullLabel = fmt.Sprintf("%s (%s)", label, hint)
+	}
+
+	prompt := promptui.Prompt{
+		Label:     fullLabel,
+		Validate:  validate(required),
+		Templates: templates,
+	}
+
+	result, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("input cancelled")
+	}
+
+	return strings.TrimSpace(result), nil
+}
+
+// Edit prompts for a value, starting from one the user can edit
+func Edit(label, value string, required bool) (string, error) {
+	prompt := promptui.Prompt{
+		Label:     label,
+		Default:   value,
+		AllowEdit: true,
+		Validate:  validate(required),
+	}
+
+	result, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("input cancelled")
+	}
+
+	return strings.TrimSpace(result), nil
+}
+
+// validate refuses blank input when a value is required
+func validate(required bool) promptui.ValidateFunc {
+	return func(input string) error {
+		if required && strings.TrimSpace(input) == "" {
+			return fmt.Errorf("this field is required")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,289 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// busyCheckSlot is how much free time a suggested start needs in the user's
+// external calendar before it is offered
+const busyCheckSlot = 30 * time.Minute
+
+// untilNextLimit is the longest "until next booking" end that is offered
+const untilNextLimit = 3 * time.Hour
+
+// customTimeHint explains the format of a typed time
+const customTimeHint = `Format: "2025-10-19 14:00" or "15:00"`
+
+// Suggestion is a time offered at a time prompt
+type Suggestion struct {
+	Label string
+	Time  time.Time
+}
+
+// StartSuggestions offers start times after now, given the room's active
+// bookings today: when a booking in progress ends, the next hour, 9 AM
+// tomorrow and 9 AM next Monday. Times the room is taken are marked
+// unavailable, and times the user is busy in their own calendar are left
+// out.
+func StartSuggestions(now time.Time, bookings []milesapi.Booking, busy []calsync.Busy, boundary config.Boundary) []Suggestion {
+	// On the hour by the local clock; Truncate would round in UTC, off by
+	// half an hour in zones such as India's
+	nextHour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour()+1, 0, 0, 0, now.Location())
+	suggestions := []Suggestion{
+		{Label: fmt.Sprintf("Next hour (%s)", nextHour.Format("15:04")), Time: nextHour},
+		{
+			Label: fmt.Sprintf("Tomorrow at 9 AM (%s)", now.AddDate(0, 0, 1).Format("2006-01-02")+" 09:00"),
+			Time:  time.Date(now.Year(), now.Month(), now.Day()+1, 9, 0, 0, 0, time.Local),
+		},
+		{Label: "Next Monday at 9 AM", Time: nextWeekday(now, time.Monday, 9, 0)},
+	}
+
+	// A booking holding the room at the suggested start makes it
+	// unavailable. One ending right then only does under the gap boundary.
+	for i, suggestion := range suggestions {
+		for _, booking := range bookings {
+			if booking.StartTime == nil || booking.EndTime == nil {
+				continue
+			}
+			if boundary.Overlaps(booking, suggestion.Time, suggestion.Time.Add(time.Minute)) {
+				suggestions[i].Label += " ⚠ unavailable"
+				break
+			}
+		}
+	}
+
+	if busy != nil {
+		free := suggestions[:0]
+		for _, suggestion := range suggestions {
+			if !calsync.Overlaps(busy, suggestion.Time, suggestion.Time.Add(busyCheckSlot)) {
+				free = append(free, suggestion)
+			}
+		}
+		suggestions = free
+	}
+
+	// When the room is taken now, lead with when it frees up
+	if start, free, ok := nextFree(now, bookings); ok && free > 0 &&
+		!calsync.Overlaps(busy, start, start.Add(busyCheckSlot)) {
+		label := fmt.Sprintf("Next available: %s (%s free)", start.Format("15:04"), FormatDuration(free))
+		suggestions = append([]Suggestion{{Label: label, Time: start}}, suggestions...)
+	}
+
+	return suggestions
+}
+
+// nextFree returns when the booking holding the room at now ends, and how
+// long the room is free from then: until the next booking, or until 6 PM
+// when there is none. ok is false when the room is free now.
+func nextFree(now time.Time, bookings []milesapi.Booking) (start time.Time, free time.Duration, ok bool) {
+	for _, booking := range bookings {
+		if booking.StartTime == nil || booking.EndTime == nil {
+			continue
+		}
+		bookingEnd := booking.EndTime.Local()
+		if !booking.StartTime.Local().After(now) && bookingEnd.After(now) && bookingEnd.After(start) {
+			start, ok = bookingEnd, true
+		}
+	}
+	if !ok {
+		return time.Time{}, 0, false
+	}
+
+	var next *time.Time
+	for _, booking := range bookings {
+		if booking.StartTime == nil {
+			continue
+		}
+		if bookingStart := booking.StartTime.Local(); bookingStart.After(start) && (next == nil || bookingStart.Before(*next)) {
+			next = &bookingStart
+		}
+	}
+
+	if next != nil {
+		return start, next.Sub(start), true
+	}
+	endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 18, 0, 0, 0, now.Location())
+	if start.Before(endOfDay) {
+		free = endOfDay.Sub(start)
+	}
+	return start, free, true
+}
+
+// EndSuggestions offers end times for a booking from start, given the
+// room's active bookings that day: 30 minutes, an hour, two hours and the
+// room's own limits, led by running up to the next booking when that is
+// under three hours away. Lengths outside the limits (zero for none) or
+// running into the user's busy times are left out, and those running into
+// another booking are marked as conflicting.
+func EndSuggestions(start time.Time, bookings []milesapi.Booking, busy []calsync.Busy, boundary config.Boundary, minDuration, maxDuration time.Duration) []Suggestion {
+	withinLimits := func(d time.Duration) bool {
+		return (minDuration == 0 || d >= minDuration) && (maxDuration == 0 || d <= maxDuration)
+	}
+
+	type length struct {
+		duration time.Duration
+		label    string
+	}
+	lengths := []length{
+		{30 * time.Minute, "30 minutes"},
+		{time.Hour, "1 hour"},
+		{2 * time.Hour, "2 hours"},
+	}
+
+	// Offer the room's own limits when the presets don't already cover them
+	preset := func(d time.Duration) bool {
+		for _, l := range lengths {
+			if l.duration == d {
+				return true
+			}
+		}
+		return false
+	}
+	if minDuration > 0 && !preset(minDuration) {
+		lengths = append(lengths, length{minDuration, "Minimum " + FormatDuration(minDuration)})
+	}
+	if maxDuration > 0 && !preset(maxDuration) {
+		lengths = append(lengths, length{maxDuration, "Maximum " + FormatDuration(maxDuration)})
+	}
+	sort.SliceStable(lengths, func(i, j int) bool {
+		return lengths[i].duration < lengths[j].duration
+	})
+
+	var suggestions []Suggestion
+	for _, l := range lengths {
+		end := start.Add(l.duration)
+		if !withinLimits(l.duration) || calsync.Overlaps(busy, start, end) {
+			continue
+		}
+
+		label := fmt.Sprintf("%s (%s)", l.label, end.Format("15:04"))
+		if len(boundary.Conflicting(bookings, start, end)) > 0 {
+			label += " ⚠ conflicts"
+		}
+		suggestions = append(suggestions, Suggestion{Label: label, Time: end})
+	}
+
+	// With the touch boundary the next booking's start; with gap, the gap
+	// before it
+	if end, ok := boundary.FreeUntil(bookings, start); ok {
+		duration := end.Sub(start)
+		if duration > 0 && duration < untilNextLimit && withinLimits(duration) && !calsync.Overlaps(busy, start, end) {
+			label := fmt.Sprintf("Until next booking (%s) ✓ available", end.Local().Format("15:04"))
+			suggestions = append([]Suggestion{{Label: label, Time: end}}, suggestions...)
+		}
+	}
+
+	return suggestions
+}
+
+// SelectStart sets Start from StartSuggestions for RoomID, or a typed time
+func SelectStart(ctx context.Context, s *Session) error {
+	now := config.ServerNow()
+
+	// Today's bookings tell whether the suggestions are free; without them
+	// the suggestions are still offered
+	bookings, _ := roomBookings(ctx, s, now)
+
+	start, err := chooseTime(s, "start", StartSuggestions(now, bookings, s.Busy, s.Boundary))
+	if err != nil {
+		return err
+	}
+	s.Start = start
+	return nil
+}
+
+// SelectEnd sets End from EndSuggestions for RoomID from Start, or a typed
+// time
+func SelectEnd(ctx context.Context, s *Session) error {
+	bookings, err := roomBookings(ctx, s, s.Start)
+	if err != nil {
+		fmt.Println("⚠ Could not check availability, showing all options")
+	}
+
+	end, err := chooseTime(s, "end", EndSuggestions(s.Start, bookings, s.Busy, s.Boundary, s.MinDuration, s.MaxDuration))
+	if err != nil {
+		return err
+	}
+	s.End = end
+	return nil
+}
+
+// roomBookings fetches RoomID's bookings on day's date, leaving out
+// cancelled ones
+func roomBookings(ctx context.Context, s *Session, day time.Time) ([]milesapi.Booking, error) {
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location()).UTC()
+	bookings, err := s.Client.GetRoomAvailability(ctx, s.RoomID, dayStart, dayStart.Add(24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	var active []milesapi.Booking
+	for _, booking := range bookings {
+		if booking.Status == nil || *booking.Status != "CANCELLED" {
+			active = append(active, booking)
+		}
+	}
+	return active, nil
+}
+
+// chooseTime prompts for one of the suggestions, or a typed time
+func chooseTime(s *Session, label string, suggestions []Suggestion) (time.Time, error) {
+	items := make([]string, len(suggestions)+1)
+	for i, suggestion := range suggestions {
+		items[i] = suggestion.Label
+	}
+	items[len(suggestions)] = "Custom time (enter manually)"
+
+	prompt := promptui.Select{
+		Label: fmt.Sprintf("Select %s time", label),
+		Items: items,
+		Size:  len(items),
+	}
+
+	idx, _, err := prompt.Run()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("time selection cancelled")
+	}
+	if idx < len(suggestions) {
+		return suggestions[idx].Time, nil
+	}
+
+	input, err := Ask(label+" time", customTimeHint, true)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.parseTime(input)
+}
+
+// nextWeekday returns the next occurrence of the weekday after from, at
+// the given time
+func nextWeekday(from time.Time, weekday time.Weekday, hour, minute int) time.Time {
+	daysUntil := int(weekday - from.Weekday())
+	if daysUntil <= 0 {
+		daysUntil += 7
+	}
+	next := from.AddDate(0, 0, daysUntil)
+	return time.Date(next.Year(), next.Month(), next.Day(), hour, minute, 0, 0, time.Local)
+}
+
+// FormatDuration formats a duration in a human-readable way, e.g. "1h 30m"
+func FormatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
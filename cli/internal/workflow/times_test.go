@@ -0,0 +1,306 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// at is 15:04 on Thursday 2026-10-15, local time
+func at(clock string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", "2026-10-15 "+clock, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// booked is an active booking from start to end, both 15:04 on at's day
+func booked(start, end string) milesapi.Booking {
+	s, e := at(start), at(end)
+	return milesapi.Booking{StartTime: &s, EndTime: &e}
+}
+
+// busyAt is a busy period in the user's own calendar
+func busyAt(start, end string) calsync.Busy {
+	return calsync.Busy{Start: at(start), End: at(end)}
+}
+
+func labels(suggestions []Suggestion) []string {
+	var out []string
+	for _, suggestion := range suggestions {
+		out = append(out, suggestion.Label)
+	}
+	return out
+}
+
+func TestStartSuggestions(t *testing.T) {
+	tomorrow := "Tomorrow at 9 AM (2026-10-16 09:00)"
+	monday := "Next Monday at 9 AM"
+
+	tests := []struct {
+		name     string
+		now      string
+		bookings []milesapi.Booking
+		busy     []calsync.Busy
+		boundary config.Boundary
+		want     []string
+	}{
+		{
+			name: "free room",
+			now:  "10:30",
+			want: []string{"Next hour (11:00)", tomorrow, monday},
+		},
+		{
+			name:     "booking ending at the suggestion touches it",
+			now:      "10:30",
+			bookings: []milesapi.Booking{booked("10:00", "11:00")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"Next available: 11:00 (7h free)", "Next hour (11:00)", tomorrow, monday},
+		},
+		{
+			name:     "booking ending at the suggestion needs a gap",
+			now:      "10:30",
+			bookings: []milesapi.Booking{booked("10:00", "11:00")},
+			boundary: config.BoundaryGap,
+			want:     []string{"Next available: 11:00 (7h free)", "Next hour (11:00) ⚠ unavailable", tomorrow, monday},
+		},
+		{
+			name:     "booking holding the suggestion",
+			now:      "10:30",
+			bookings: []milesapi.Booking{booked("10:00", "11:30")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"Next available: 11:30 (6h 30m free)", "Next hour (11:00) ⚠ unavailable", tomorrow, monday},
+		},
+		{
+			name:     "free until the next booking",
+			now:      "10:30",
+			bookings: []milesapi.Booking{booked("10:00", "11:00"), booked("12:30", "13:00")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"Next available: 11:00 (1h 30m free)", "Next hour (11:00)", tomorrow, monday},
+		},
+		{
+			name:     "room free again after working hours",
+			now:      "17:00",
+			bookings: []milesapi.Booking{booked("16:00", "18:30")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"Next hour (18:00) ⚠ unavailable", tomorrow, monday},
+		},
+		{
+			name:     "room free again just before the end of the day",
+			now:      "17:00",
+			bookings: []milesapi.Booking{booked("16:00", "17:45")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"Next available: 17:45 (15m free)", "Next hour (18:00)", tomorrow, monday},
+		},
+		{
+			name:     "user busy at the next hour",
+			now:      "10:30",
+			busy:     []calsync.Busy{busyAt("11:00", "11:15")},
+			boundary: config.BoundaryTouch,
+			want:     []string{tomorrow, monday},
+		},
+		{
+			name:     "user busy when the room frees up",
+			now:      "10:30",
+			bookings: []milesapi.Booking{booked("10:00", "10:45")},
+			busy:     []calsync.Busy{busyAt("11:00", "11:15")},
+			boundary: config.BoundaryTouch,
+			want:     []string{tomorrow, monday},
+		},
+		{
+			name:     "busy elsewhere leaves the suggestions",
+			now:      "10:30",
+			busy:     []calsync.Busy{busyAt("13:00", "14:00")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"Next hour (11:00)", tomorrow, monday},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := labels(StartSuggestions(at(tt.now), tt.bookings, tt.busy, tt.boundary))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StartSuggestions() =\n  %q\nwant\n  %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndSuggestions(t *testing.T) {
+	tests := []struct {
+		name        string
+		bookings    []milesapi.Booking
+		busy        []calsync.Busy
+		boundary    config.Boundary
+		minDuration time.Duration
+		maxDuration time.Duration
+		want        []string
+	}{
+		{
+			name: "free room",
+			want: []string{"30 minutes (14:30)", "1 hour (15:00)", "2 hours (16:00)"},
+		},
+		{
+			name:     "next booking touching the end",
+			bookings: []milesapi.Booking{booked("15:00", "16:00")},
+			boundary: config.BoundaryTouch,
+			want: []string{
+				"Until next booking (15:00) ✓ available",
+				"30 minutes (14:30)", "1 hour (15:00)", "2 hours (16:00) ⚠ conflicts",
+			},
+		},
+		{
+			name:     "next booking needing a gap before it",
+			bookings: []milesapi.Booking{booked("15:00", "16:00")},
+			boundary: config.BoundaryGap,
+			want: []string{
+				"Until next booking (14:59) ✓ available",
+				"30 minutes (14:30)", "1 hour (15:00) ⚠ conflicts", "2 hours (16:00) ⚠ conflicts",
+			},
+		},
+		{
+			name:     "next booking too far off to run up to",
+			bookings: []milesapi.Booking{booked("17:00", "18:00")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"30 minutes (14:30)", "1 hour (15:00)", "2 hours (16:00)"},
+		},
+		{
+			name:     "earlier bookings don't count",
+			bookings: []milesapi.Booking{booked("13:00", "14:00")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"30 minutes (14:30)", "1 hour (15:00)", "2 hours (16:00)"},
+		},
+		{
+			name:        "room limits between the presets",
+			boundary:    config.BoundaryTouch,
+			minDuration: 45 * time.Minute,
+			maxDuration: 90 * time.Minute,
+			want:        []string{"Minimum 45m (14:45)", "1 hour (15:00)", "Maximum 1h 30m (15:30)"},
+		},
+		{
+			name:        "room limits matching presets",
+			boundary:    config.BoundaryTouch,
+			minDuration: time.Hour,
+			maxDuration: 2 * time.Hour,
+			want:        []string{"1 hour (15:00)", "2 hours (16:00)"},
+		},
+		{
+			name:        "until next booking shorter than the minimum",
+			bookings:    []milesapi.Booking{booked("14:20", "15:00")},
+			boundary:    config.BoundaryTouch,
+			minDuration: 30 * time.Minute,
+			want:        []string{"30 minutes (14:30) ⚠ conflicts", "1 hour (15:00) ⚠ conflicts", "2 hours (16:00) ⚠ conflicts"},
+		},
+		{
+			name:        "until next booking longer than the maximum",
+			bookings:    []milesapi.Booking{booked("16:00", "17:00")},
+			boundary:    config.BoundaryTouch,
+			maxDuration: time.Hour,
+			want:        []string{"30 minutes (14:30)", "1 hour (15:00)"},
+		},
+		{
+			name:     "user busy part of the way",
+			busy:     []calsync.Busy{busyAt("14:45", "15:15")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"30 minutes (14:30)"},
+		},
+		{
+			name:     "user busy right after the end",
+			bookings: []milesapi.Booking{booked("15:00", "16:00")},
+			busy:     []calsync.Busy{busyAt("15:00", "15:30")},
+			boundary: config.BoundaryTouch,
+			want:     []string{"Until next booking (15:00) ✓ available", "30 minutes (14:30)", "1 hour (15:00)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := labels(EndSuggestions(at("14:00"), tt.bookings, tt.busy, tt.boundary, tt.minDuration, tt.maxDuration))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EndSuggestions() =\n  %q\nwant\n  %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextFree(t *testing.T) {
+	tests := []struct {
+		name      string
+		now       string
+		bookings  []milesapi.Booking
+		wantStart string
+		wantFree  time.Duration
+		wantOK    bool
+	}{
+		{name: "free now", now: "10:30", bookings: []milesapi.Booking{booked("11:00", "12:00")}},
+		{
+			name:      "until the next booking",
+			now:       "10:30",
+			bookings:  []milesapi.Booking{booked("10:00", "11:00"), booked("13:00", "14:00")},
+			wantStart: "11:00", wantFree: 2 * time.Hour, wantOK: true,
+		},
+		{
+			name:      "overlapping bookings in progress",
+			now:       "10:30",
+			bookings:  []milesapi.Booking{booked("10:00", "11:00"), booked("10:15", "11:45")},
+			wantStart: "11:45", wantFree: 6*time.Hour + 15*time.Minute, wantOK: true,
+		},
+		{
+			name:      "until the end of working hours",
+			now:       "16:30",
+			bookings:  []milesapi.Booking{booked("16:00", "17:00")},
+			wantStart: "17:00", wantFree: time.Hour, wantOK: true,
+		},
+		{
+			name:      "frees up after working hours",
+			now:       "17:30",
+			bookings:  []milesapi.Booking{booked("17:00", "19:00")},
+			wantStart: "19:00", wantOK: true,
+		},
+		{
+			name:      "booking starting now is in progress",
+			now:       "10:00",
+			bookings:  []milesapi.Booking{booked("10:00", "10:30")},
+			wantStart: "10:30", wantFree: 7*time.Hour + 30*time.Minute, wantOK: true,
+		},
+		{
+			name:     "booking ending now is not",
+			now:      "10:30",
+			bookings: []milesapi.Booking{booked("10:00", "10:30")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, free, ok := nextFree(at(tt.now), tt.bookings)
+			if ok != tt.wantOK {
+				t.Fatalf("nextFree() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !start.Equal(at(tt.wantStart)) || free != tt.wantFree {
+				t.Errorf("nextFree() = %s, %s, want %s, %s", start.Format("15:04"), free, tt.wantStart, tt.wantFree)
+			}
+		})
+	}
+}
+
+func TestNextWeekday(t *testing.T) {
+	tests := []struct {
+		from string
+		want string
+	}{
+		{from: "2026-10-15 10:30", want: "2026-10-19 09:00"}, // Thursday
+		{from: "2026-10-18 10:30", want: "2026-10-19 09:00"}, // Sunday
+		{from: "2026-10-19 08:00", want: "2026-10-26 09:00"}, // Monday itself
+	}
+	for _, tt := range tests {
+		from, _ := time.ParseInLocation("2006-01-02 15:04", tt.from, time.Local)
+		if got := nextWeekday(from, time.Monday, 9, 0).Format("2006-01-02 15:04"); got != tt.want {
+			t.Errorf("nextWeekday(%s) = %s, want %s", tt.from, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package workflow holds the interactive steps of booking a room: picking
+// a location, a room, a start and an end. Each step reads and fills in a
+// Session, so commands can run the steps they need in the order they need
+// and add their own in between:
+//
+//	s := &workflow.Session{Client: client, Boundary: boundary}
+//	err := workflow.Run(ctx, s, workflow.SelectLocation, workflow.SelectRoom)
+//
+// What the command layer decides, such as which office the user is in or
+// which rooms they may book, comes in through the Session's hooks. The
+// suggestions behind the time prompts are plain functions, so they can be
+// used without a terminal.
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/miles/booking-cli/internal/calsync"
+	"github.com/miles/booking-cli/internal/config"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Session is what an interactive booking has decided so far, and what the
+// steps need to decide the rest
+type Session struct {
+	Client config.API
+	// Boundary decides whether back-to-back bookings conflict
+	Boundary config.Boundary
+
+	// LocationQuery picks the location without a prompt, as --location does
+	LocationQuery string
+	// MatchLocation returns the index of the location a query names, or -1
+	MatchLocation func(locations []milesapi.Location, query string) int
+	// DetectOffice returns the index of the location the user seems to be
+	// at and why, or -1. The location prompt starts there.
+	DetectOffice func(locations []milesapi.Location) (int, string)
+	// Rooms narrows the rooms offered, e.g. to meeting rooms
+	Rooms func(rooms []milesapi.Room) []milesapi.Room
+	// Locked reports rooms the user can't book. Choosing one calls Unlock,
+	// which may offer to ask for access, and the prompt is shown again.
+	Locked func(room milesapi.Room) bool
+	Unlock func(ctx context.Context, room milesapi.Room)
+	// ParseTime reads a time typed at a custom time prompt
+	ParseTime func(input string) (time.Time, error)
+
+	// LocationID and RoomID are the chosen location and room
+	LocationID string
+	RoomID     string
+	// MinDuration and MaxDuration are the room's booking length limits,
+	// zero for none
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	// Busy holds the user's busy times from external calendars. Nil means
+	// none were checked; suggestions then only consider the room.
+	Busy []calsync.Busy
+
+	Start time.Time
+	End   time.Time
+}
+
+// Step is one part of an interactive booking
+type Step func(ctx context.Context, s *Session) error
+
+// Run runs the steps in order, stopping at the first error
+func Run(ctx context.Context, s *Session, steps ...Step) error {
+	for _, step := range steps {
+		if err := step(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTime reads a custom time, in the simple format when no ParseTime
+// hook is set
+func (s *Session) parseTime(input string) (time.Time, error) {
+	if s.ParseTime != nil {
+		return s.ParseTime(input)
+	}
+	return time.ParseInLocation("2006-01-02 15:04", input, time.Local)
+}
@@ -3,15 +3,36 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/miles/booking-tui/internal/ui"
+	"github.com/miles/booking-tui/pkg/deeplink"
 )
 
 func main() {
+	// 'miles upgrade --tui' asks for the version this way
+	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
+		fmt.Printf("miles-booking version %s\n", ui.Version)
+		return
+	}
+
+	app := ui.NewApp()
+
+	// 'miles open-link' starts on a booking with --booking ID; a
+	// miles://booking link works as well
+	if len(os.Args) > 1 {
+		id, err := linkedBooking(os.Args[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		app.OpenLinkedBooking(id)
+	}
+
 	// Initialize the application
 	p := tea.NewProgram(
-		ui.NewApp(),
+		app,
 		tea.WithAltScreen(),       // Use alternate screen buffer
 		tea.WithMouseCellMotion(), // Enable mouse support
 	)
@@ -22,3 +43,20 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// linkedBooking returns the booking ID given as "--booking ID" or as a link
+func linkedBooking(args []string) (string, error) {
+	switch {
+	case len(args) == 2 && args[0] == "--booking" && args[1] != "":
+		return args[1], nil
+	case len(args) == 1 && strings.HasPrefix(args[0], "--booking="):
+		return strings.TrimPrefix(args[0], "--booking="), nil
+	case len(args) == 1:
+		id, err := deeplink.ParseBooking(args[0])
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", args[0], err)
+		}
+		return id, nil
+	}
+	return "", fmt.Errorf("usage: miles-booking [--booking ID | LINK]")
+}
@@ -1,360 +1,554 @@
 package api
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
 	"time"
 
-	"github.com/go-resty/resty/v2"
-	"github.com/miles/booking-tui/internal/models"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 // Client is the API client for the booking system
 type Client struct {
-	baseURL string
-	http    *resty.Client
-	token   string
+	baseURL     string
+	api         *milesapi.Client
+	transport   *cachingTransport
+	impersonate string
+	bookings    *bookingStore
+	recent      *recentWrites
+
+	// Serving the offline snapshot while the server is down
+	snapshot *offlineSnapshot
+	offline  bool
+
+	// Client-side encryption of booking descriptions
+	descriptionKey      []byte
+	encryptDescriptions bool
 }
 
-// NewClient creates a new API client
-func NewClient(baseURL string) *Client {
+// NewClient creates a new API client for the server at serverURL, e.g.
+// http://localhost:3000. It wraps the shared milesapi client, which holds
+// the token and impersonation and makes the requests; on top of it this
+// client adds offline mode, delta sync and description encryption.
+func NewClient(serverURL string) *Client {
+	transport := newCachingTransport(http.DefaultTransport)
+	shared := milesapi.NewClient(serverURL, "")
+	baseURL := shared.Resty().
+		SetTransport(transport).
+		SetTimeout(30*time.Second).
+		SetHeader("Content-Type", "application/json").
+		BaseURL
 	return &Client{
-		baseURL: baseURL,
-		http: resty.New().
-			SetBaseURL(baseURL).
-			SetTimeout(30 * time.Second).
-			SetHeader("Content-Type", "application/json"),
+		baseURL:   baseURL,
+		api:       shared,
+		transport: transport,
+		bookings:  newBookingStore(),
+		recent:    newRecentWrites(),
+		snapshot:  loadOfflineSnapshot(baseURL),
 	}
 }
 
 // SetToken sets the JWT token for authenticated requests
 func (c *Client) SetToken(token string) {
-	c.token = token
-	c.http.SetAuthToken(token)
+	c.api.SetToken(token)
+	c.loadBookingStore()
 }
 
 // GetToken returns the current JWT token
 func (c *Client) GetToken() string {
-	return c.token
+	return c.api.Token
 }
 
 // ClearToken clears the JWT token
 func (c *Client) ClearToken() {
-	c.token = ""
-	c.http.SetAuthToken("")
+	c.api.SetToken("")
+	c.bookings.reset()
+	c.recent.reset()
+	c.snapshot.update(func(s *offlineSnapshot) { s.Token = "" })
+}
+
+// SetImpersonate makes every request act as the user with the given email
+// (ADMIN only). An empty email turns impersonation off.
+func (c *Client) SetImpersonate(email string) {
+	c.impersonate = email
+	c.loadBookingStore()
+	c.api.SetImpersonate(email)
+}
+
+// Impersonating returns the email being impersonated, or ""
+func (c *Client) Impersonating() string {
+	return c.impersonate
 }
 
 // Auth endpoints
 
 // Login authenticates a user
-func (c *Client) Login(email, password string) (*models.AuthResponse, error) {
-	var response struct {
-		Message string       `json:"message"`
-		User    models.User  `json:"user"`
-		Token   string       `json:"token"`
-	}
-	resp, err := c.http.R().
-		SetBody(map[string]string{
-			"email":    email,
-			"password": password,
-		}).
-		SetResult(&response).
-		Post("/auth/login")
-
+func (c *Client) Login(ctx context.Context, email, password string) (*milesapi.LoginResponse, error) {
+	response, err := c.api.Login(ctx, email, password)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("login failed: %s", resp.Status())
-	}
-
-	return &models.AuthResponse{
-		Token: response.Token,
-		User:  response.User,
-	}, nil
+	c.rememberSession(response.Token, response.User)
+	return response, nil
 }
 
 // Register creates a new user account
-func (c *Client) Register(email, password, name string) (*models.AuthResponse, error) {
-	var response models.AuthResponse
-	resp, err := c.http.R().
-		SetBody(map[string]interface{}{
-			"email":    email,
-			"password": password,
-			"name":     name,
-		}).
-		SetResult(&response).
-		Post("/auth/register")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return nil, fmt.Errorf("registration failed: %s", resp.Status())
-	}
-
-	return &response, nil
+func (c *Client) Register(ctx context.Context, input milesapi.PostApiAuthRegisterJSONRequestBody) (*milesapi.LoginResponse, error) {
+	return c.api.Register(ctx, input)
 }
 
 // GetCurrentUser gets the current authenticated user
-func (c *Client) GetCurrentUser() (*models.User, error) {
-	var response struct {
-		User models.User `json:"user"`
+func (c *Client) GetCurrentUser(ctx context.Context) (*milesapi.User, error) {
+	return c.api.GetCurrentUser(ctx)
+}
+
+// Location endpoints
+
+// GetLocations retrieves all locations
+func (c *Client) GetLocations(ctx context.Context) ([]milesapi.Location, error) {
+	if c.offline {
+		return c.offlineLocations(), nil
 	}
-	resp, err := c.http.R().
-		SetResult(&response).
-		Get("/auth/me")
 
+	locations, err := c.api.GetLocations(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get user: %s", resp.Status())
-	}
+	c.snapshot.update(func(s *offlineSnapshot) { s.Locations = locations })
+	return locations, nil
+}
 
-	return &response.User, nil
+// GetLocation retrieves a location by ID
+func (c *Client) GetLocation(ctx context.Context, id string) (*milesapi.Location, error) {
+	return c.api.GetLocation(ctx, id)
 }
 
-// Location endpoints
+// Room endpoints
 
-// GetLocations retrieves all locations
-func (c *Client) GetLocations() ([]models.Location, error) {
-	var response struct {
-		Locations []models.Location `json:"locations"`
+// GetRooms retrieves the rooms, optionally at one location
+func (c *Client) GetRooms(ctx context.Context, locationID string) ([]milesapi.Room, error) {
+	if c.offline {
+		return c.offlineRooms(locationID), nil
 	}
-	resp, err := c.http.R().
-		SetResult(&response).
-		Get("/locations")
 
+	rooms, err := c.api.GetRooms(ctx, locationID)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get locations: %s", resp.Status())
+	// Only the full list is kept for opening offline
+	if locationID == "" {
+		c.snapshot.update(func(s *offlineSnapshot) { s.Rooms = rooms })
 	}
-
-	return response.Locations, nil
+	return rooms, nil
 }
 
-// GetLocation retrieves a location by ID
-func (c *Client) GetLocation(id string) (*models.Location, error) {
-	var location models.Location
-	resp, err := c.http.R().
-		SetResult(&location).
-		Get(fmt.Sprintf("/locations/%s", id))
+// RequestRoomAccess asks a restricted room's owner, or its location's
+// managers, to let the user book it, and returns who was asked
+func (c *Client) RequestRoomAccess(ctx context.Context, roomID, message string) ([]milesapi.RoomAccessContact, error) {
+	return c.api.RequestRoomAccess(ctx, roomID, message)
+}
 
-	if err != nil {
-		return nil, err
+// GetDesks retrieves the hot desks, optionally at one location and on one
+// floor
+func (c *Client) GetDesks(ctx context.Context, locationID, floor string) ([]milesapi.Room, error) {
+	if c.offline {
+		desks := []milesapi.Room{}
+		for _, room := range c.offlineRooms(locationID) {
+			if room.IsDesk() && (floor == "" || strings.EqualFold(room.GetFloor(), floor)) {
+				desks = append(desks, room)
+			}
+		}
+		return desks, nil
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get location: %s", resp.Status())
-	}
+	return c.api.GetDesks(ctx, locationID, floor)
+}
 
-	return &location, nil
+// GetRoom retrieves a room by ID
+func (c *Client) GetRoom(ctx context.Context, id string) (*milesapi.Room, error) {
+	return c.api.GetRoom(ctx, id)
 }
 
-// Room endpoints
+// CheckRoomAvailability checks if a room is available for a time slot.
+// Back-to-back bookings don't conflict, matching the server: a slot may
+// start the moment another booking ends.
+func (c *Client) CheckRoomAvailability(ctx context.Context, roomID string, startTime, endTime time.Time) (bool, error) {
+	return c.CheckRoomAvailabilityExcept(ctx, roomID, startTime, endTime, "")
+}
 
-// GetRooms retrieves rooms with optional filters
-func (c *Client) GetRooms(locationID *string, minCapacity *int, equipment []string) ([]models.Room, error) {
-	var response struct {
-		Rooms []models.Room `json:"rooms"`
+// CheckRoomAvailabilityExcept is CheckRoomAvailability ignoring the booking
+// with exceptID, e.g. one being moved
+func (c *Client) CheckRoomAvailabilityExcept(ctx context.Context, roomID string, startTime, endTime time.Time, exceptID string) (bool, error) {
+	bookings, err := c.GetRoomAvailability(ctx, roomID, startTime, endTime)
+	if err != nil {
+		return false, err
 	}
-	req := c.http.R().SetResult(&response)
 
-	if locationID != nil {
-		req.SetQueryParam("locationId", *locationID)
-	}
-	if minCapacity != nil {
-		req.SetQueryParam("minCapacity", fmt.Sprintf("%d", *minCapacity))
-	}
-	if len(equipment) > 0 {
-		for _, eq := range equipment {
-			req.SetQueryParam("equipment", eq)
+	for _, booking := range bookings {
+		if booking.GetStatus() == milesapi.BookingStatusCANCELLED || (exceptID != "" && booking.GetId() == exceptID) {
+			continue
+		}
+		if booking.GetStartTime().Before(endTime) && booking.GetEndTime().After(startTime) {
+			return false, nil
 		}
 	}
+	return true, nil
+}
 
-	resp, err := req.Get("/rooms")
-	if err != nil {
-		return nil, err
-	}
+// GetRoomAvailability retrieves the active bookings for a room between two
+// times. It needs no login, so guests can see when rooms are taken.
+func (c *Client) GetRoomAvailability(ctx context.Context, roomID string, startTime, endTime time.Time) ([]milesapi.Booking, error) {
+	return c.api.GetRoomAvailability(ctx, roomID, startTime, endTime)
+}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get rooms: %s", resp.Status())
-	}
+// GuestAccessAllowed reports whether the server lets anonymous users browse
+// locations and rooms. Call it before logging in.
+func (c *Client) GuestAccessAllowed(ctx context.Context) bool {
+	_, err := c.api.GetLocations(ctx)
+	return err == nil
+}
 
-	return response.Rooms, nil
+// MergeRoom moves every booking from sourceID into targetID and retires
+// sourceID (ADMIN only). A dry run only reports what would happen.
+func (c *Client) MergeRoom(ctx context.Context, sourceID, targetID string, dryRun bool) (*milesapi.RoomMerge, error) {
+	return c.api.MergeRoom(ctx, sourceID, targetID, dryRun)
 }
 
-// GetRoom retrieves a room by ID
-func (c *Client) GetRoom(id string) (*models.Room, error) {
-	var room models.Room
-	resp, err := c.http.R().
-		SetResult(&room).
-		Get(fmt.Sprintf("/rooms/%s", id))
+// GetApprovalRules retrieves a location's approval setting and rules
+// (ADMIN or the location's MANAGER)
+func (c *Client) GetApprovalRules(ctx context.Context, locationID string) (*milesapi.ApprovalRulesResponse, error) {
+	return c.api.GetApprovalRules(ctx, locationID)
+}
 
+// SaveApprovalRule creates an approval rule, or replaces ruleID when it is
+// set. It returns how many pending bookings the rules now confirmed.
+func (c *Client) SaveApprovalRule(ctx context.Context, locationID, ruleID string, rule milesapi.ApprovalRuleInput) (int, error) {
+	var result *milesapi.ApprovalRuleResult
+	var err error
+	if ruleID == "" {
+		result, err = c.api.CreateApprovalRule(ctx, locationID, rule)
+	} else {
+		result, err = c.api.UpdateApprovalRule(ctx, locationID, ruleID, rule)
+	}
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get room: %s", resp.Status())
+	if result.Approved == nil {
+		return 0, nil
 	}
+	return *result.Approved, nil
+}
 
-	return &room, nil
+// DeleteApprovalRule removes an approval rule
+func (c *Client) DeleteApprovalRule(ctx context.Context, locationID, ruleID string) error {
+	return c.api.DeleteApprovalRule(ctx, locationID, ruleID)
 }
 
-// CheckRoomAvailability checks if a room is available for a time slot
-func (c *Client) CheckRoomAvailability(roomID string, startTime, endTime time.Time) (bool, error) {
-	var result map[string]bool
-	resp, err := c.http.R().
-		SetQueryParams(map[string]string{
-			"startTime": startTime.Format(time.RFC3339),
-			"endTime":   endTime.Format(time.RFC3339),
-		}).
-		SetResult(&result).
-		Get(fmt.Sprintf("/rooms/%s/availability", roomID))
+// SetRequiresApproval turns approval of new bookings at a location on or off
+func (c *Client) SetRequiresApproval(ctx context.Context, locationID string, required bool) error {
+	return c.api.SetRequiresApproval(ctx, locationID, required)
+}
 
-	if err != nil {
-		return false, err
+// Subscription endpoints
+
+// GetSubscriptions retrieves the rooms and colleagues the user follows
+func (c *Client) GetSubscriptions(ctx context.Context) ([]milesapi.Subscription, error) {
+	return c.api.GetSubscriptions(ctx)
+}
+
+// Follow starts following a room (roomID) or a colleague (email)
+func (c *Client) Follow(ctx context.Context, roomID, email string) (*milesapi.Subscription, error) {
+	input := milesapi.SubscriptionInput{RoomId: &roomID}
+	if email != "" {
+		address := openapi_types.Email(email)
+		input = milesapi.SubscriptionInput{Email: &address}
 	}
 
-	if resp.IsError() {
-		return false, fmt.Errorf("failed to check availability: %s", resp.Status())
+	subscription, err := c.api.Follow(ctx, input)
+	switch status := milesapi.StatusCode(err); {
+	case status == http.StatusConflict:
+		return nil, errors.New("already following")
+	case status == http.StatusNotFound && email != "":
+		return nil, errors.New("no user with email " + email)
 	}
+	return subscription, err
+}
 
-	return result["available"], nil
+// Unfollow removes a subscription
+func (c *Client) Unfollow(ctx context.Context, subscriptionID string) error {
+	return c.api.Unfollow(ctx, subscriptionID)
 }
 
-// Booking endpoints
+// GetActivity retrieves new and cancelled bookings for what the user
+// follows, newest first, and the cursor to pass next time. An empty cursor
+// returns the last week.
+func (c *Client) GetActivity(ctx context.Context, cursor string) ([]milesapi.ActivityItem, string, error) {
+	return c.api.GetActivity(ctx, cursor)
+}
 
-// GetBookings retrieves bookings with optional filters
-func (c *Client) GetBookings(roomID, locationID *string, startDate, endDate *time.Time) ([]models.Booking, error) {
-	var response struct {
-		Bookings []models.Booking `json:"bookings"`
-	}
-	req := c.http.R().SetResult(&response)
+// Booking endpoints
 
-	if roomID != nil {
-		req.SetQueryParam("roomId", *roomID)
-	}
-	if locationID != nil {
-		req.SetQueryParam("locationId", *locationID)
-	}
+// GetBookings retrieves bookings with optional filters. The dates are
+// whole days: bookings on or between them are returned.
+func (c *Client) GetBookings(ctx context.Context, roomID, locationID *string, startDate, endDate *time.Time) ([]milesapi.Booking, error) {
+	params := milesapi.GetApiBookingsParams{RoomId: roomID, LocationId: locationID}
 	if startDate != nil {
-		req.SetQueryParam("startDate", startDate.Format("2006-01-02"))
+		from := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+		params.StartDate = &from
 	}
 	if endDate != nil {
-		req.SetQueryParam("endDate", endDate.Format("2006-01-02"))
+		to := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, endDate.Location())
+		params.EndDate = &to
 	}
 
-	resp, err := req.Get("/bookings")
+	bookings, err := c.api.ListBookings(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get bookings: %s", resp.Status())
+	return c.recent.merge(bookings, func(booking milesapi.Booking) bool {
+		if roomID != nil && booking.GetRoomId() != *roomID {
+			return false
+		}
+		if locationID != nil && booking.GetRoom().GetLocationId() != *locationID {
+			return false
+		}
+		return overlaps(booking, startDate, endDate)
+	}), nil
+}
+
+// BookingFilter narrows the bookings the API returns. Zero fields don't filter.
+type BookingFilter struct {
+	LocationID string
+	RoomID     string
+	User       string // Booker's email or name, matched as a substring
+	From       time.Time
+	To         time.Time
+	Status     milesapi.BookingStatus
+	HasSetup   bool // Only bookings with a setup request
+}
+
+// IsZero reports whether the filter lets every booking through
+func (f BookingFilter) IsZero() bool {
+	return f == BookingFilter{}
+}
+
+// GetBookingsFiltered retrieves the bookings matching filter. Which bookings
+// are visible at all still depends on the user's role.
+func (c *Client) GetBookingsFiltered(ctx context.Context, filter BookingFilter) ([]milesapi.Booking, error) {
+	var params milesapi.GetApiBookingsParams
+	if filter.LocationID != "" {
+		params.LocationId = &filter.LocationID
+	}
+	if filter.RoomID != "" {
+		params.RoomId = &filter.RoomID
+	}
+	if filter.User != "" {
+		params.User = &filter.User
+	}
+	if !filter.From.IsZero() {
+		params.StartDate = &filter.From
+	}
+	if !filter.To.IsZero() {
+		params.EndDate = &filter.To
+	}
+	if filter.Status != "" {
+		status := string(filter.Status)
+		params.Status = &status
+	}
+	if filter.HasSetup {
+		params.HasSetup = &filter.HasSetup
 	}
 
-	return response.Bookings, nil
+	return c.api.ListBookings(ctx, params)
 }
 
 // GetBooking retrieves a booking by ID
-func (c *Client) GetBooking(id string) (*models.Booking, error) {
-	var booking models.Booking
-	resp, err := c.http.R().
-		SetResult(&booking).
-		Get(fmt.Sprintf("/bookings/%s", id))
+func (c *Client) GetBooking(ctx context.Context, id string) (*milesapi.Booking, error) {
+	return c.api.GetBooking(ctx, id)
+}
+
+// GetQuota retrieves the user's booking quota for the period containing at
+func (c *Client) GetQuota(ctx context.Context, at time.Time) (*milesapi.Quota, error) {
+	return c.api.GetQuota(ctx, at)
+}
+
+// CheckServerVersion returns a *milesapi.IncompatibleError when the server
+// speaks an API version the TUI doesn't support
+func (c *Client) CheckServerVersion(ctx context.Context) error {
+	if c.offline {
+		return nil
+	}
+	return c.api.CheckServerVersion(ctx)
+}
 
+// GetFeatures retrieves which optional features the server has on. Servers
+// from before feature flags have everything on.
+func (c *Client) GetFeatures(ctx context.Context) (milesapi.Features, error) {
+	list, err := c.api.GetFeatures(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get booking: %s", resp.Status())
+	features := milesapi.Features{}
+	for _, feature := range list {
+		features[feature.Name] = feature.Enabled
 	}
+	return features, nil
+}
 
-	return &booking, nil
+// GetAnnouncements retrieves the current office-wide announcements
+func (c *Client) GetAnnouncements(ctx context.Context) ([]milesapi.Announcement, error) {
+	return c.api.GetAnnouncements(ctx)
 }
 
-// CreateBooking creates a new booking
-func (c *Client) CreateBooking(req models.CreateBookingRequest) (*models.Booking, error) {
-	var response struct {
-		Booking models.Booking `json:"booking"`
+// GetLocationServices retrieves a location's services directory, by category
+func (c *Client) GetLocationServices(ctx context.Context, locationID string) ([]milesapi.LocationService, error) {
+	if c.offline {
+		return nil, ErrOffline
 	}
-	resp, err := c.http.R().
-		SetBody(req).
-		SetResult(&response).
-		Post("/bookings")
 
-	if err != nil {
-		return nil, err
-	}
+	return c.api.GetLocationServices(ctx, locationID)
+}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to create booking: %s", resp.Status())
+// GetLocationHolidays retrieves the days a location is closed: its holidays
+// from one YYYY-MM-DD day to another and its weekly closed days
+func (c *Client) GetLocationHolidays(ctx context.Context, locationID, from, to string) (*milesapi.LocationHolidays, error) {
+	if c.offline {
+		return nil, ErrOffline
 	}
 
-	return &response.Booking, nil
+	return c.api.GetLocationHolidays(ctx, locationID, from, to)
 }
 
-// UpdateBooking updates an existing booking
-func (c *Client) UpdateBooking(id string, req models.UpdateBookingRequest) (*models.Booking, error) {
-	var booking models.Booking
-	resp, err := c.http.R().
-		SetBody(req).
-		SetResult(&booking).
-		Patch(fmt.Sprintf("/bookings/%s", id))
+// GetTimeSlots retrieves the organization's named time slots, by start time
+func (c *Client) GetTimeSlots(ctx context.Context) ([]milesapi.TimeSlot, error) {
+	return c.api.GetTimeSlots(ctx)
+}
 
+// ConflictError and RestrictedRoomError are the shared client's errors for
+// a taken slot and a room restricted to other departments
+type (
+	ConflictError       = milesapi.ConflictError
+	RestrictedRoomError = milesapi.RestrictedRoomError
+)
+
+// CreateBooking creates a new booking, retried once with the same
+// Idempotency-Key when no answer arrives
+func (c *Client) CreateBooking(ctx context.Context, req milesapi.BookingInput) (*milesapi.Booking, error) {
+	if req.Description != nil {
+		description, err := c.sealDescription(*req.Description)
+		if err != nil {
+			return nil, err
+		}
+		req.Description = &description
+	}
+
+	booking, err := c.api.CreateBooking(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to update booking: %s", resp.Status())
+	c.recent.record(*booking)
+	return booking, nil
+}
+
+// UpdateBooking updates an existing booking
+func (c *Client) UpdateBooking(ctx context.Context, id string, req milesapi.PatchApiBookingsIdJSONRequestBody) (*milesapi.Booking, error) {
+	if req.Description != nil {
+		description, err := c.sealDescription(*req.Description)
+		if err != nil {
+			return nil, err
+		}
+		req.Description = &description
 	}
 
-	return &booking, nil
+	booking, err := c.api.UpdateBooking(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.recent.record(*booking)
+	return booking, nil
 }
 
-// CancelBooking cancels a booking
-func (c *Client) CancelBooking(id string) error {
-	resp, err := c.http.R().
-		Delete(fmt.Sprintf("/bookings/%s", id))
+// BumpBooking moves another user's booking to make way for a priority
+// booking (admins, the location's managers and users with a priority rule).
+// Refusals say why, e.g. how much notice the rule needs.
+func (c *Client) BumpBooking(ctx context.Context, id string, req milesapi.BumpInput) (*milesapi.Booking, error) {
+	return c.api.BumpBooking(ctx, id, req)
+}
 
+// CancelBooking cancels a booking. It returns the server's warning when
+// the cancellation was late under the location's policy.
+func (c *Client) CancelBooking(ctx context.Context, id string) (string, error) {
+	result, err := c.api.CancelBooking(ctx, id)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	if resp.IsError() {
-		return fmt.Errorf("failed to cancel booking: %s", resp.Status())
+	// A cancelled booking must not come back from a recent write
+	c.recent.forget(id)
+	return result.Warning, nil
+}
+
+// GetBookingComments retrieves the latest comments on a booking, oldest
+// first
+func (c *Client) GetBookingComments(ctx context.Context, bookingID string, limit int) ([]milesapi.BookingComment, error) {
+	comments, err := c.api.GetBookingComments(ctx, bookingID, milesapi.GetApiBookingsIdCommentsParams{Limit: &limit})
+	if milesapi.StatusCode(err) == http.StatusForbidden {
+		return nil, errors.New("only people booking this room that day can see its comments")
 	}
+	return comments, err
+}
 
-	return nil
+// AddBookingComment posts a comment to a booking's discussion thread
+func (c *Client) AddBookingComment(ctx context.Context, bookingID, message string) (*milesapi.BookingComment, error) {
+	return c.api.AddBookingComment(ctx, bookingID, milesapi.BookingCommentInput{Message: message})
 }
 
-// GetMyBookings retrieves the current user's bookings
-// Note: The API automatically filters by user role - regular users only see their own bookings
-func (c *Client) GetMyBookings() ([]models.Booking, error) {
-	var response struct {
-		Bookings []models.Booking `json:"bookings"`
-	}
-	resp, err := c.http.R().
-		SetResult(&response).
-		Get("/bookings")
+// ReportIssue files an issue report for a room with its location's managers
+func (c *Client) ReportIssue(ctx context.Context, req milesapi.FeedbackInput) (*milesapi.Feedback, error) {
+	return c.api.ReportIssue(ctx, req)
+}
 
-	if err != nil {
-		return nil, err
-	}
+// GetMyIssues retrieves the issues the current user has reported, newest first
+func (c *Client) GetMyIssues(ctx context.Context) ([]milesapi.Feedback, error) {
+	return c.api.GetMyIssues(ctx, "")
+}
+
+// GetMyStats retrieves the signed-in user's booking stats from the
+// reports API
+func (c *Client) GetMyStats(ctx context.Context) (*milesapi.MyStats, error) {
+	return c.api.GetMyStats(ctx)
+}
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to get bookings: %s", resp.Status())
+// GetLocationIssues retrieves a location's issue queue, most severe first.
+// Without a status it holds the issues still open or in progress.
+func (c *Client) GetLocationIssues(ctx context.Context, locationID string, status milesapi.FeedbackStatus) ([]milesapi.Feedback, error) {
+	issues, err := c.api.GetLocationIssues(ctx, locationID, status)
+	if milesapi.StatusCode(err) == http.StatusForbidden {
+		return nil, errors.New("only managers of this location can see its issues")
 	}
+	return issues, err
+}
 
-	return response.Bookings, nil
+// UpdateIssueStatus moves an issue along the queue. The comment is sent to
+// the reporter.
+func (c *Client) UpdateIssueStatus(ctx context.Context, issueID string, status milesapi.FeedbackStatus, comment string) (*milesapi.Feedback, error) {
+	return c.api.UpdateIssueStatus(ctx, issueID, milesapi.FeedbackStatusUpdate{Status: status, Comment: comment})
+}
+
+// GetMyBookings retrieves the current user's bookings
+// Note: The API automatically filters by user role - regular users only see their own bookings.
+// After the first call only changes since the previous call are fetched and merged.
+func (c *Client) GetMyBookings(ctx context.Context) ([]milesapi.Booking, error) {
+	return c.syncBookings(ctx)
 }
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// serverClock remembers how far the server's clock is ahead of ours,
+// measured from the Date header of the first response that has one
+type serverClock struct {
+	skew     atomic.Int64
+	measured atomic.Bool
+}
+
+// measure records the skew from a response's Date header unless it is
+// already known. The server stamped Date somewhere between sending and
+// receiving, so it is compared with the midpoint.
+func (c *serverClock) measure(header http.Header, sent, received time.Time) {
+	if c.measured.Load() {
+		return
+	}
+	date, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		return
+	}
+	local := sent.Add(received.Sub(sent) / 2)
+	c.skew.Store(int64(date.Sub(local).Round(time.Second)))
+	c.measured.Store(true)
+}
+
+// ClockSkew returns how far the server's clock is ahead of the local one,
+// and false until a response has been seen
+func (c *Client) ClockSkew() (time.Duration, bool) {
+	clock := &c.transport.clock
+	return time.Duration(clock.skew.Load()), clock.measured.Load()
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/reminder"
+)
+
+// daemonCacheVersion is the layout of milesd's cache the TUI understands
+const daemonCacheVersion = 2
+
+// daemonStaleAfter is how old milesd's cache can get before the TUI polls
+// the server itself again, e.g. after the daemon stopped
+const daemonStaleAfter = 2 * time.Minute
+
+// daemonCache is what milesd, the CLI's sync daemon, last fetched. It is
+// kept in ~/.miles-cli/daemon/cache.json.
+type daemonCache struct {
+	Version        int                     `json:"version"`
+	Server         string                  `json:"server"`
+	UserID         string                  `json:"userId"`
+	AsOf           time.Time               `json:"asOf"`
+	Bookings       []milesapi.Booking      `json:"bookings"`
+	Activity       []milesapi.ActivityItem `json:"activity"`
+	ActivityCursor string                  `json:"activityCursor"`
+	Reminders      []reminder.Reminder     `json:"reminders"`
+}
+
+// daemonCachePath returns ~/.miles-cli/daemon/cache.json
+func daemonCachePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".miles-cli", "daemon", "cache.json")
+}
+
+// readDaemonCache returns milesd's cache when it is recent and fetched for
+// this server and user, or nil. The CLI's API URL has no /api suffix.
+func (c *Client) readDaemonCache() *daemonCache {
+	if c.impersonate != "" || c.offline {
+		return nil
+	}
+	data, err := os.ReadFile(daemonCachePath())
+	if err != nil {
+		return nil
+	}
+	var cache daemonCache
+	if json.Unmarshal(data, &cache) != nil || cache.Version != daemonCacheVersion {
+		return nil
+	}
+	server := strings.TrimSuffix(strings.TrimRight(c.baseURL, "/"), "/api")
+	if strings.TrimRight(cache.Server, "/") != server || cache.UserID == "" || cache.UserID != c.claims().UserID {
+		return nil
+	}
+	if time.Since(cache.AsOf) > daemonStaleAfter {
+		return nil
+	}
+	return &cache
+}
+
+// DaemonActivity answers like GetActivity from milesd's cache: the items at
+// or after cursor, newest first, and the cursor to pass next time. ok is
+// false when milesd isn't keeping the feed for this user, and the server
+// has to be asked.
+func (c *Client) DaemonActivity(cursor string) (items []milesapi.ActivityItem, next string, ok bool) {
+	cache := c.readDaemonCache()
+	if cache == nil {
+		return nil, "", false
+	}
+	since, err := time.Parse(time.RFC3339Nano, cursor)
+	for _, item := range cache.Activity {
+		if cursor == "" || err != nil || !item.Time.Before(since) {
+			items = append(items, item)
+		}
+	}
+	return items, cache.ActivityCursor, true
+}
+
+// DaemonReminders returns the reminders milesd has cached for my
+// bookings, newest first. ok is false when milesd isn't keeping them for
+// this user, and the app has to work them out itself.
+func (c *Client) DaemonReminders() ([]reminder.Reminder, bool) {
+	cache := c.readDaemonCache()
+	if cache == nil {
+		return nil, false
+	}
+	return cache.Reminders, true
+}
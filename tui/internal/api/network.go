@@ -0,0 +1,297 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// NetworkMode selects how the client trades freshness for fewer requests
+type NetworkMode string
+
+const (
+	// NetworkAuto switches to low-bandwidth behaviour when requests get slow
+	NetworkAuto NetworkMode = "auto"
+	// NetworkNormal always uses normal behaviour
+	NetworkNormal NetworkMode = "normal"
+	// NetworkLow always uses low-bandwidth behaviour
+	NetworkLow NetworkMode = "low"
+)
+
+const (
+	normalCacheTTL       = 10 * time.Second
+	lowBandwidthCacheTTL = 5 * time.Minute
+
+	// Auto mode enters low-bandwidth above slowLatency and leaves it below
+	// fastLatency, so a single slow request doesn't flip the mode back and forth
+	slowLatency = 1500 * time.Millisecond
+	fastLatency = 600 * time.Millisecond
+
+	// Number of recent responses remembered for DataAsOf
+	servedHistory = 64
+)
+
+// cachingTransport caches GET responses, coalesces identical in-flight GETs
+// and measures request latency to detect slow connections. Any non-GET
+// request expires the cache, since it may have changed what GETs return.
+type cachingTransport struct {
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	mode     NetworkMode
+	slow     bool          // Auto mode detected a slow connection
+	latency  time.Duration // Moving average of network round trips
+	entries  map[string]*cacheEntry
+	inflight map[string]*inflightCall
+	served   []servedResponse
+
+	// Measured from the first response
+	clock serverClock
+}
+
+type cacheEntry struct {
+	status    int
+	header    http.Header
+	body      []byte
+	fetchedAt time.Time
+	expired   bool
+}
+
+type inflightCall struct {
+	done  chan struct{}
+	entry *cacheEntry
+	err   error
+}
+
+// servedResponse records when a response was handed out and how old it was
+type servedResponse struct {
+	servedAt  time.Time
+	fetchedAt time.Time
+}
+
+func newCachingTransport(next http.RoundTripper) *cachingTransport {
+	return &cachingTransport{
+		next:     next,
+		mode:     NetworkAuto,
+		entries:  make(map[string]*cacheEntry),
+		inflight: make(map[string]*inflightCall),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		t.Invalidate()
+		started := time.Now()
+		resp, err := t.next.RoundTrip(req)
+		t.recordLatency(time.Since(started), err)
+		if err == nil {
+			t.clock.measure(resp.Header, started, time.Now())
+		}
+		return resp, err
+	}
+
+	// Responses depend on who is asking
+	key := req.URL.String() + "|" + req.Header.Get("Authorization") + "|" + req.Header.Get(milesapi.ImpersonateHeader)
+
+	t.mu.Lock()
+	if entry, ok := t.entries[key]; ok && !entry.expired && time.Since(entry.fetchedAt) < t.ttlLocked() {
+		t.recordServedLocked(entry)
+		t.mu.Unlock()
+		return entry.response(req), nil
+	}
+	if call, ok := t.inflight[key]; ok {
+		t.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		// The request shared was cancelled by the view that made it, not
+		// this one, so make it again
+		if errors.Is(call.err, context.Canceled) {
+			return t.RoundTrip(req)
+		}
+		return t.finish(req, key, call.entry, call.err)
+	}
+	call := &inflightCall{done: make(chan struct{})}
+	t.inflight[key] = call
+	t.mu.Unlock()
+
+	call.entry, call.err = t.fetch(req)
+
+	t.mu.Lock()
+	delete(t.inflight, key)
+	if call.err == nil && call.entry.status == http.StatusOK {
+		t.entries[key] = call.entry
+	}
+	t.mu.Unlock()
+	close(call.done)
+
+	return t.finish(req, key, call.entry, call.err)
+}
+
+// fetch performs the request and buffers the response so it can be shared
+func (t *cachingTransport) fetch(req *http.Request) (*cacheEntry, error) {
+	started := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.recordLatency(time.Since(started), err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	t.clock.measure(resp.Header, started, time.Now())
+
+	body, err := io.ReadAll(resp.Body)
+	t.recordLatency(time.Since(started), err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cacheEntry{
+		status:    resp.StatusCode,
+		header:    resp.Header.Clone(),
+		body:      body,
+		fetchedAt: time.Now(),
+	}, nil
+}
+
+// finish returns a fetched response, falling back to the last cached copy
+// when the network failed
+func (t *cachingTransport) finish(req *http.Request, key string, entry *cacheEntry, err error) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		if cached, ok := t.entries[key]; ok {
+			t.recordServedLocked(cached)
+			return cached.response(req), nil
+		}
+		return nil, err
+	}
+
+	t.recordServedLocked(entry)
+	return entry.response(req), nil
+}
+
+// response builds a fresh *http.Response from a buffered entry
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// recordLatency updates the moving average and the auto-detected mode.
+// Failed requests count as slow; cancelled ones say nothing about the
+// network and are left out.
+func (t *cachingTransport) recordLatency(d time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil && d < slowLatency {
+		d = slowLatency
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.latency == 0 {
+		t.latency = d
+	} else {
+		t.latency = (3*t.latency + d) / 4
+	}
+
+	switch {
+	case t.latency > slowLatency:
+		t.slow = true
+	case t.latency < fastLatency:
+		t.slow = false
+	}
+}
+
+func (t *cachingTransport) recordServedLocked(entry *cacheEntry) {
+	t.served = append(t.served, servedResponse{servedAt: time.Now(), fetchedAt: entry.fetchedAt})
+	if len(t.served) > servedHistory {
+		t.served = t.served[len(t.served)-servedHistory:]
+	}
+}
+
+func (t *cachingTransport) lowBandwidthLocked() bool {
+	switch t.mode {
+	case NetworkLow:
+		return true
+	case NetworkNormal:
+		return false
+	default:
+		return t.slow
+	}
+}
+
+func (t *cachingTransport) ttlLocked() time.Duration {
+	if t.lowBandwidthLocked() {
+		return lowBandwidthCacheTTL
+	}
+	return normalCacheTTL
+}
+
+// Invalidate expires every cached response. Expired entries are still used
+// as a fallback when the network is unreachable.
+func (t *cachingTransport) Invalidate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, entry := range t.entries {
+		entry.expired = true
+	}
+}
+
+// SetNetworkMode selects normal, low-bandwidth or auto-detected behaviour
+func (c *Client) SetNetworkMode(mode NetworkMode) {
+	if mode != NetworkNormal && mode != NetworkLow {
+		mode = NetworkAuto
+	}
+	c.transport.mu.Lock()
+	c.transport.mode = mode
+	c.transport.mu.Unlock()
+}
+
+// LowBandwidth reports whether the client is in low-bandwidth mode, either
+// because it was configured or because requests have been slow
+func (c *Client) LowBandwidth() bool {
+	c.transport.mu.Lock()
+	defer c.transport.mu.Unlock()
+	return c.transport.lowBandwidthLocked()
+}
+
+// Refresh makes the next requests bypass the cache
+func (c *Client) Refresh() {
+	c.transport.Invalidate()
+}
+
+// DataAsOf returns when the oldest response handed out since the given time
+// was fetched from the server. It returns since if nothing was served.
+func (c *Client) DataAsOf(since time.Time) time.Time {
+	c.transport.mu.Lock()
+	defer c.transport.mu.Unlock()
+
+	asOf := since
+	for _, served := range c.transport.served {
+		if !served.servedAt.Before(since) && served.fetchedAt.Before(asOf) {
+			asOf = served.fetchedAt
+		}
+	}
+	return asOf
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// ErrOffline is returned instead of contacting a server known to be down
+var ErrOffline = errors.New("offline: the server can't be reached")
+
+// pingTimeout bounds a reachability check, so a dead server is noticed fast
+const pingTimeout = 5 * time.Second
+
+// offlineFileName is where the offline snapshot is kept in the home directory
+const offlineFileName = ".miles-tui-offline.json"
+
+// offlineSnapshot is what the app needs to open while the server is down:
+// the last session and the rooms and locations. The session also skips
+// the login screen at launch while the server accepts it. It is kept in
+// ~/.miles-tui-offline.json, readable only by the user since it holds the
+// session token.
+type offlineSnapshot struct {
+	mu sync.Mutex
+
+	BaseURL   string              `json:"baseUrl"`
+	Token     string              `json:"token,omitempty"`
+	User      *milesapi.User      `json:"user,omitempty"`
+	Locations []milesapi.Location `json:"locations,omitempty"`
+	Rooms     []milesapi.Room     `json:"rooms,omitempty"`
+	SavedAt   time.Time           `json:"savedAt"`
+}
+
+// offlinePath returns ~/.miles-tui-offline.json
+func offlinePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, offlineFileName)
+}
+
+// loadOfflineSnapshot reads the snapshot saved for the server, or starts an
+// empty one
+func loadOfflineSnapshot(baseURL string) *offlineSnapshot {
+	s := &offlineSnapshot{BaseURL: baseURL}
+	data, err := os.ReadFile(offlinePath())
+	if err != nil {
+		return s
+	}
+	var saved offlineSnapshot
+	if json.Unmarshal(data, &saved) != nil || saved.BaseURL != baseURL {
+		return s
+	}
+	return &saved
+}
+
+// update changes the snapshot and writes it out. Failing to save is
+// harmless: the app just can't open offline next time.
+func (s *offlineSnapshot) update(change func(s *offlineSnapshot)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(s)
+	s.SavedAt = time.Now()
+
+	path := offlinePath()
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if os.WriteFile(tmp, data, 0o600) == nil {
+		os.Rename(tmp, path)
+	}
+}
+
+// Ping checks the server answers at all, bypassing the response cache. Any
+// HTTP response counts; only a network failure is an error.
+func (c *Client) Ping(ctx context.Context) error {
+	health := strings.TrimSuffix(c.baseURL, "/api") + "/health"
+	client := &http.Client{Timeout: pingTimeout}
+	started := time.Now()
+	resp, err := client.Get(health)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	c.transport.clock.measure(resp.Header, started, time.Now())
+	return nil
+}
+
+// OpenOffline switches the client to the snapshot saved by an earlier run:
+// its session, its bookings as of the last sync, and its rooms and
+// locations. It returns the user of that session, or false when there is
+// nothing to open.
+func (c *Client) OpenOffline() (*milesapi.User, time.Time, bool) {
+	s := c.snapshot
+	s.mu.Lock()
+	user, token, savedAt := s.User, s.Token, s.SavedAt
+	s.mu.Unlock()
+	if user == nil || token == "" {
+		return nil, time.Time{}, false
+	}
+
+	c.offline = true
+	c.SetToken(token)
+	return user, savedAt, true
+}
+
+// Reconnect checks the server is back and, if so, leaves offline mode. The
+// restored session may have expired meanwhile; GetCurrentUser tells.
+func (c *Client) Reconnect(ctx context.Context) error {
+	if err := c.Ping(ctx); err != nil {
+		return err
+	}
+	c.offline = false
+	c.Refresh()
+	return nil
+}
+
+// rememberSession keeps a successful login for the next launch and for
+// opening offline later
+func (c *Client) rememberSession(token string, user *milesapi.User) {
+	c.snapshot.update(func(s *offlineSnapshot) {
+		s.Token = token
+		s.User = user
+	})
+}
+
+// ResumeSession signs back in with the session an earlier run saved,
+// checking with the server that the token is still good. It returns nil
+// when there is none or the server turned it down; a rejected token is
+// forgotten, while one that couldn't be checked is kept for offline use.
+func (c *Client) ResumeSession(ctx context.Context) (*milesapi.User, error) {
+	s := c.snapshot
+	s.mu.Lock()
+	token := s.Token
+	s.mu.Unlock()
+	if token == "" {
+		return nil, nil
+	}
+
+	c.SetToken(token)
+	user, err := c.api.GetCurrentUser(ctx)
+	if milesapi.StatusCode(err) != 0 {
+		c.ClearToken()
+		return nil, nil
+	}
+	if err != nil {
+		c.api.SetToken("")
+		return nil, err
+	}
+
+	c.rememberSession(token, user)
+	return user, nil
+}
+
+// offlineRooms filters the saved rooms the way GetRooms asks the server to
+func (c *Client) offlineRooms(locationID string) []milesapi.Room {
+	s := c.snapshot
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rooms := []milesapi.Room{}
+	for _, room := range s.Rooms {
+		if locationID != "" && room.GetLocationId() != locationID {
+			continue
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// offlineLocations returns the saved locations
+func (c *Client) offlineLocations() []milesapi.Location {
+	s := c.snapshot
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]milesapi.Location{}, s.Locations...)
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// recentWriteWindow is how long a booking this client created or changed is
+// merged into list results the server returns without it. List endpoints
+// can lag a moment behind writes; a refresh right after booking shouldn't
+// show the booking missing.
+const recentWriteWindow = 30 * time.Second
+
+// recentWrites are the bookings this client wrote in the last
+// recentWriteWindow, as the server answered the write
+type recentWrites struct {
+	mu       sync.Mutex
+	bookings map[string]recentWrite
+}
+
+type recentWrite struct {
+	booking milesapi.Booking
+	until   time.Time
+}
+
+func newRecentWrites() *recentWrites {
+	return &recentWrites{bookings: make(map[string]recentWrite)}
+}
+
+// reset forgets every write, e.g. after the token or impersonated user
+// changes
+func (r *recentWrites) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bookings = make(map[string]recentWrite)
+}
+
+// record remembers a booking the server just accepted
+func (r *recentWrites) record(booking milesapi.Booking) {
+	if booking.GetId() == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bookings[booking.GetId()] = recentWrite{booking: booking, until: time.Now().Add(recentWriteWindow)}
+}
+
+// forget drops the write of a booking, e.g. once it is cancelled
+func (r *recentWrites) forget(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.bookings, id)
+}
+
+// merge returns bookings with the recent writes that match added or, where
+// the server's copy is older, swapped in. A write is forgotten once the
+// server returns it as new, or when its window ends.
+func (r *recentWrites) merge(bookings []milesapi.Booking, match func(milesapi.Booking) bool) []milesapi.Booking {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.bookings) == 0 {
+		return bookings
+	}
+
+	now := time.Now()
+	pending := make(map[string]milesapi.Booking)
+	for id, write := range r.bookings {
+		if now.After(write.until) {
+			delete(r.bookings, id)
+			continue
+		}
+		if match(write.booking) {
+			pending[id] = write.booking
+		}
+	}
+	if len(pending) == 0 {
+		return bookings
+	}
+
+	merged := make([]milesapi.Booking, 0, len(bookings)+len(pending))
+	for _, booking := range bookings {
+		if write, ok := pending[booking.GetId()]; ok {
+			delete(pending, booking.GetId())
+			if booking.GetUpdatedAt().Before(write.GetUpdatedAt()) {
+				booking = write
+			} else {
+				delete(r.bookings, booking.GetId())
+			}
+		}
+		merged = append(merged, booking)
+	}
+	if len(pending) == 0 {
+		return merged
+	}
+	for _, booking := range pending {
+		merged = append(merged, booking)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].GetStartTime().Before(merged[j].GetStartTime())
+	})
+	return merged
+}
+
+// overlaps reports whether booking falls on or between the dates GetBookings
+// was asked for. Either date may be nil.
+func overlaps(booking milesapi.Booking, startDate, endDate *time.Time) bool {
+	if startDate != nil {
+		from := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+		if !booking.GetEndTime().After(from) {
+			return false
+		}
+	}
+	if endDate != nil {
+		to := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, endDate.Location())
+		if !booking.GetStartTime().Before(to) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/miles/booking-tui/pkg/secret"
+)
+
+// SetDescriptionKey sets the team's key for encrypted descriptions (base64,
+// from descriptionKey in ~/.miles-tui.json) and whether descriptions of new
+// and edited bookings are encrypted with it. An invalid key is dropped.
+func (c *Client) SetDescriptionKey(key string, encryptNew bool) error {
+	parsed, err := secret.ParseKey(key)
+	c.descriptionKey = parsed
+	c.encryptDescriptions = encryptNew
+	return err
+}
+
+// RevealDescription returns a booking description to show: decrypted when
+// it is encrypted, or "[encrypted]" without the right key
+func (c *Client) RevealDescription(description string) string {
+	return secret.Reveal(c.descriptionKey, description)
+}
+
+// sealDescription encrypts a description before it is sent, when enabled
+func (c *Client) sealDescription(description string) (string, error) {
+	if description == "" || !c.encryptDescriptions {
+		return description, nil
+	}
+	if c.descriptionKey == nil {
+		return "", errors.New("encrypting descriptions needs a valid descriptionKey in ~/.miles-tui.json")
+	}
+	return secret.Encrypt(c.descriptionKey, description)
+}
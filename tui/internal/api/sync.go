@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// bookingStore is the client's merged copy of the user's bookings. After the
+// first full fetch only bookings changed since the last sync are requested.
+// It is kept in ~/.miles-tui-bookings.json between runs, so views can show
+// the bookings straight away while the first sync runs.
+type bookingStore struct {
+	mu       sync.Mutex
+	cursor   string
+	syncedAt time.Time
+	bookings map[string]milesapi.Booking
+
+	// owner identifies the server and user the store belongs to; it is
+	// only saved when set
+	owner string
+}
+
+// storeFileName is where the store is kept in the home directory
+const storeFileName = ".miles-tui-bookings.json"
+
+// storeFile is the store as saved between runs
+type storeFile struct {
+	Owner    string             `json:"owner"`
+	Cursor   string             `json:"cursor"`
+	SyncedAt time.Time          `json:"syncedAt"`
+	Bookings []milesapi.Booking `json:"bookings"`
+}
+
+// storePath returns ~/.miles-tui-bookings.json
+func storePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, storeFileName)
+}
+
+func newBookingStore() *bookingStore {
+	return &bookingStore{bookings: make(map[string]milesapi.Booking)}
+}
+
+// reset drops the merged bookings so the next sync fetches everything,
+// e.g. after the token or impersonated user changes
+func (s *bookingStore) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursor = ""
+	s.syncedAt = time.Time{}
+	s.bookings = make(map[string]milesapi.Booking)
+	s.owner = ""
+}
+
+// load replaces the store with the one saved for owner, if there is one
+func (s *bookingStore) load(owner string) {
+	s.reset()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.owner = owner
+
+	data, err := os.ReadFile(storePath())
+	if err != nil {
+		return
+	}
+	var saved storeFile
+	if json.Unmarshal(data, &saved) != nil || saved.Owner != owner {
+		return
+	}
+	s.cursor = saved.Cursor
+	s.syncedAt = saved.SyncedAt
+	for _, booking := range saved.Bookings {
+		s.bookings[booking.GetId()] = booking
+	}
+}
+
+// saveLocked writes the store out for the next run. Failing to is harmless:
+// the next run just starts with a full fetch.
+func (s *bookingStore) saveLocked() {
+	path := storePath()
+	if s.owner == "" || path == "" {
+		return
+	}
+	data, err := json.Marshal(storeFile{
+		Owner:    s.owner,
+		Cursor:   s.cursor,
+		SyncedAt: s.syncedAt,
+		Bookings: s.sortedLocked(),
+	})
+	if err != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if os.WriteFile(tmp, data, 0o600) == nil {
+		os.Rename(tmp, path)
+	}
+}
+
+// sortedLocked returns every booking in the store, ordered by start time
+func (s *bookingStore) sortedLocked() []milesapi.Booking {
+	bookings := make([]milesapi.Booking, 0, len(s.bookings))
+	for _, booking := range s.bookings {
+		bookings = append(bookings, booking)
+	}
+	sort.Slice(bookings, func(i, j int) bool {
+		return bookings[i].GetStartTime().Before(bookings[j].GetStartTime())
+	})
+	return bookings
+}
+
+// loadBookingStore switches the store to the current token and impersonated
+// user, picking up what an earlier run saved for them
+func (c *Client) loadBookingStore() {
+	c.recent.reset()
+	userID := c.claims().UserID
+	if userID == "" {
+		c.bookings.reset()
+		return
+	}
+	c.bookings.load(c.baseURL + " " + userID + " " + c.impersonate)
+}
+
+// CachedMyBookings returns the user's bookings as of the last sync, possibly
+// from an earlier run, without contacting the server. The time is zero when
+// there are none.
+func (c *Client) CachedMyBookings() ([]milesapi.Booking, time.Time) {
+	s := c.bookings
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.syncedAt.IsZero() {
+		return nil, time.Time{}
+	}
+	return s.sortedLocked(), s.syncedAt
+}
+
+// GetBookingsSince retrieves the bookings changed at or after cursor,
+// cancelled ones included, and the cursor to pass next time. An empty
+// cursor fetches everything.
+func (c *Client) GetBookingsSince(ctx context.Context, cursor string) ([]milesapi.Booking, string, error) {
+	return c.api.GetBookingsSince(ctx, cursor)
+}
+
+// syncBookings merges the changes since the last sync into the store and
+// returns every booking in it, ordered by start time
+func (c *Client) syncBookings(ctx context.Context) ([]milesapi.Booking, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+
+	s := c.bookings
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delta, cursor, err := c.GetBookingsSince(ctx, s.cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, booking := range delta {
+		s.bookings[booking.GetId()] = booking
+	}
+	s.cursor = cursor
+	s.syncedAt = time.Now()
+	s.saveLocked()
+
+	// What this client just wrote is shown even before the delta has it;
+	// the store itself only ever holds what the server returned
+	return c.recent.merge(s.sortedLocked(), func(milesapi.Booking) bool { return true }), nil
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// claims reads the client's JWT claims without verifying them. They are
+// empty when there is no token or it can't be decoded.
+func (c *Client) claims() milesapi.TokenClaims {
+	claims, _ := milesapi.DecodeToken(c.api.Token)
+	return claims
+}
+
+// TokenRole reads the role claim from the client's JWT without verifying it.
+// The server authorizes requests by this claim, so it is what the UI should
+// offer; it may be stale compared to the user record. It returns "" when
+// there is no token or it can't be decoded.
+func (c *Client) TokenRole() milesapi.UserRole {
+	return milesapi.TokenRole(c.api.Token)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// WatchBookings streams changes to the bookings the user can see from the
+// server's gRPC interface at addr, over TLS unless useInsecure is set. Every
+// change also makes the next requests bypass the cache, so views reloading
+// because of it see it. The channel is closed when ctx is done or the stream
+// ends, say because the server restarted.
+//
+// Only this stream uses gRPC; every other request goes over REST.
+func (c *Client) WatchBookings(ctx context.Context, addr string, useInsecure bool) (<-chan milesapi.BookingEvent, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+
+	conn, err := milesapi.DialGRPC(addr, useInsecure)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := milesapi.WatchBookings(milesapi.GRPCAuth(ctx, c.api.Token, c.impersonate), conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("watch bookings failed: %w", err)
+	}
+
+	// The connection only serves this stream, so it goes when the stream does
+	events := make(chan milesapi.BookingEvent)
+	go func() {
+		defer conn.Close()
+		defer close(events)
+		for event := range stream {
+			c.transport.Invalidate()
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
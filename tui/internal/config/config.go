@@ -0,0 +1,185 @@
+// Package config persists TUI preferences in ~/.miles-tui.json. The
+// running TUI watches the file and applies changes made to it by hand.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/deeplink"
+)
+
+// Widget IDs for dashboard panels
+const (
+	WidgetStats         = "stats"
+	WidgetUpcoming      = "upcoming"
+	WidgetFavoriteRoom  = "favorite-room"
+	WidgetAnnouncements = "announcements"
+)
+
+// DefaultDashboardWidgets is the dashboard layout used until the user changes it
+var DefaultDashboardWidgets = []string{WidgetStats, WidgetUpcoming}
+
+// Default intervals, used when the config doesn't set them
+const (
+	DefaultDashboardRefresh = time.Minute
+	DefaultActivityPoll     = 30 * time.Second
+)
+
+// Transports for Config.Transport
+const (
+	TransportREST = "rest"
+	TransportGRPC = "grpc"
+)
+
+// Config holds user preferences
+type Config struct {
+	// DashboardWidgets lists the enabled dashboard panels in display order
+	DashboardWidgets []string `json:"dashboardWidgets"`
+
+	// FavoriteRoomID is the room shown by the favorite room widget
+	FavoriteRoomID string `json:"favoriteRoomId,omitempty"`
+
+	// NetworkMode is "auto" (default), "normal" or "low" for low-bandwidth mode
+	NetworkMode string `json:"networkMode,omitempty"`
+
+	// OfficeLocationID is the location the rooms view starts at. Empty means
+	// detecting the office from the network (see ~/.miles-offices.yaml).
+	OfficeLocationID string `json:"officeLocationId,omitempty"`
+
+	// DescriptionKey is the team's base64 key for encrypted booking
+	// descriptions. Without it they show as "[encrypted]".
+	DescriptionKey string `json:"descriptionKey,omitempty"`
+
+	// EncryptDescriptions encrypts the descriptions of bookings made here
+	// with DescriptionKey
+	EncryptDescriptions bool `json:"encryptDescriptions,omitempty"`
+
+	// Theme overrides colors of the default palette by name, e.g.
+	// {"primary": "#FF5F87", "border": "240"}
+	Theme map[string]string `json:"theme,omitempty"`
+
+	// Keys adds keys for the global shortcuts: each key acts as the
+	// shortcut it maps to, e.g. {"b": "5"} opens bookings with b as well
+	Keys map[string]string `json:"keys,omitempty"`
+
+	// DashboardRefreshSeconds and ActivityPollSeconds set how often the
+	// dashboard refreshes and new activity is checked for; 0 means the
+	// default
+	DashboardRefreshSeconds int `json:"dashboardRefreshSeconds,omitempty"`
+	ActivityPollSeconds     int `json:"activityPollSeconds,omitempty"`
+
+	// WebURL is where the web app runs, for the web links to bookings;
+	// empty means deeplink.DefaultWebURL
+	WebURL string `json:"webUrl,omitempty"`
+
+	// Transport is "rest" (default) or "grpc". With grpc, changes to
+	// bookings are streamed from the server's gRPC interface at GRPCAddr,
+	// e.g. localhost:50051, as they happen; other requests stay on REST.
+	// GRPCInsecure connects without TLS, for local servers.
+	Transport    string `json:"transport,omitempty"`
+	GRPCAddr     string `json:"grpcAddr,omitempty"`
+	GRPCInsecure bool   `json:"grpcInsecure,omitempty"`
+
+	path string
+}
+
+// Default returns the configuration used when no file exists
+func Default() *Config {
+	return &Config{
+		DashboardWidgets: append([]string{}, DefaultDashboardWidgets...),
+	}
+}
+
+// Load reads ~/.miles-tui.json, falling back to defaults when it doesn't exist
+func Load() (*Config, error) {
+	cfg := Default()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return cfg, err
+	}
+	cfg.path = filepath.Join(home, ".miles-tui.json")
+
+	data, err := os.ReadFile(cfg.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		fallback := Default()
+		fallback.path = cfg.path
+		return fallback, fmt.Errorf("failed to parse %s: %w", cfg.path, err)
+	}
+	if cfg.DashboardWidgets == nil {
+		cfg.DashboardWidgets = append([]string{}, DefaultDashboardWidgets...)
+	}
+	return cfg, nil
+}
+
+// DashboardRefresh is how often the dashboard refreshes itself
+func (c *Config) DashboardRefresh() time.Duration {
+	if c.DashboardRefreshSeconds > 0 {
+		return time.Duration(c.DashboardRefreshSeconds) * time.Second
+	}
+	return DefaultDashboardRefresh
+}
+
+// ActivityPoll is how often new activity is checked for
+func (c *Config) ActivityPoll() time.Duration {
+	if c.ActivityPollSeconds > 0 {
+		return time.Duration(c.ActivityPollSeconds) * time.Second
+	}
+	return DefaultActivityPoll
+}
+
+// WatchAddr returns the gRPC address to stream booking changes from, or ""
+// when they aren't streamed
+func (c *Config) WatchAddr() string {
+	if c.Transport != TransportGRPC {
+		return ""
+	}
+	return c.GRPCAddr
+}
+
+// Shortcut returns the global shortcut key stands for: the one it maps to
+// under Keys, or key itself
+func (c *Config) Shortcut(key string) string {
+	if shortcut, ok := c.Keys[key]; ok {
+		return shortcut
+	}
+	return key
+}
+
+// BookingWebLink returns the web app's link to the booking with id
+func (c *Config) BookingWebLink(id string) string {
+	webURL := c.WebURL
+	if webURL == "" {
+		webURL = deeplink.DefaultWebURL
+	}
+	return deeplink.BookingWeb(webURL, id)
+}
+
+// Path returns the file the configuration is read from and saved to
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Save writes the configuration back to disk
+func (c *Config) Save() error {
+	if c.path == "" {
+		return errors.New("config file location unknown")
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o600)
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// settleDelay lets an editor finish writing, often in several steps,
+// before the file is read again
+const settleDelay = 150 * time.Millisecond
+
+// ErrWatcherClosed is returned by Next once the watcher is closed
+var ErrWatcherClosed = errors.New("config watcher closed")
+
+// Watcher reports changes to the config file
+type Watcher struct {
+	path    string
+	watcher *fsnotify.Watcher
+}
+
+// Watch watches the file cfg was loaded from. The directory is watched
+// rather than the file, so editors that save by replacing it are seen too.
+func Watch(cfg *Config) (*Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(filepath.Dir(cfg.path)); err != nil {
+		w.Close()
+		return nil, err
+	}
+	return &Watcher{path: cfg.path, watcher: w}, nil
+}
+
+// Next blocks until the file changes and returns it loaded again, with
+// the error Load gives for a broken file, or ErrWatcherClosed
+func (w *Watcher) Next() (*Config, error) {
+	for {
+		select {
+		case event, open := <-w.watcher.Events:
+			if !open {
+				return nil, ErrWatcherClosed
+			}
+			if filepath.Clean(event.Name) != w.path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+			w.settle()
+			return Load()
+		case _, open := <-w.watcher.Errors:
+			if !open {
+				return nil, ErrWatcherClosed
+			}
+		}
+	}
+}
+
+// settle waits until the file has been quiet for settleDelay
+func (w *Watcher) settle() {
+	timer := time.NewTimer(settleDelay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.watcher.Events:
+			timer.Reset(settleDelay)
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	return w.watcher.Close()
+}
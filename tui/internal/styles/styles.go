@@ -1,6 +1,11 @@
 package styles
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -77,10 +82,11 @@ type Styles struct {
 	BadgeInfo    lipgloss.Style
 
 	// Special
-	Help      lipgloss.Style
-	StatusBar lipgloss.Style
-	Header    lipgloss.Style
-	Footer    lipgloss.Style
+	Help         lipgloss.Style
+	HelpDisabled lipgloss.Style // Key hints for actions that don't apply right now
+	StatusBar    lipgloss.Style
+	Header       lipgloss.Style
+	Footer       lipgloss.Style
 }
 
 // DefaultColors returns the default color palette
@@ -115,10 +121,62 @@ func DefaultColors() *Colors {
 	}
 }
 
+// colorNames are the names a theme gives colors by
+var colorNames = map[string]func(*Colors) *lipgloss.Color{
+	"primary":          func(c *Colors) *lipgloss.Color { return &c.Primary },
+	"secondary":        func(c *Colors) *lipgloss.Color { return &c.Secondary },
+	"accent":           func(c *Colors) *lipgloss.Color { return &c.Accent },
+	"success":          func(c *Colors) *lipgloss.Color { return &c.Success },
+	"warning":          func(c *Colors) *lipgloss.Color { return &c.Warning },
+	"error":            func(c *Colors) *lipgloss.Color { return &c.Error },
+	"info":             func(c *Colors) *lipgloss.Color { return &c.Info },
+	"text":             func(c *Colors) *lipgloss.Color { return &c.Text },
+	"textMuted":        func(c *Colors) *lipgloss.Color { return &c.TextMuted },
+	"textDim":          func(c *Colors) *lipgloss.Color { return &c.TextDim },
+	"textBright":       func(c *Colors) *lipgloss.Color { return &c.TextBright },
+	"background":       func(c *Colors) *lipgloss.Color { return &c.Background },
+	"backgroundAlt":    func(c *Colors) *lipgloss.Color { return &c.BackgroundAlt },
+	"backgroundActive": func(c *Colors) *lipgloss.Color { return &c.BackgroundActive },
+	"border":           func(c *Colors) *lipgloss.Color { return &c.Border },
+	"borderActive":     func(c *Colors) *lipgloss.Color { return &c.BorderActive },
+	"borderFocus":      func(c *Colors) *lipgloss.Color { return &c.BorderFocus },
+}
+
+// colorValue matches the colors a theme can set: #RGB, #RRGGBB or an ANSI
+// color number
+var colorValue = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[0-9]{1,3})$`)
+
+// ThemedColors returns the default palette with a theme's colors, e.g.
+// {"primary": "#FF5F87"}, on top. Unknown names and invalid colors are
+// skipped and reported in the error.
+func ThemedColors(theme map[string]string) (*Colors, error) {
+	colors := DefaultColors()
+	var problems []string
+	for name, value := range theme {
+		field, ok := colorNames[name]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("unknown color %q", name))
+		case !colorValue.MatchString(value):
+			problems = append(problems, fmt.Sprintf("%s: %q is not a color like #0066CC", name, value))
+		default:
+			*field(colors) = lipgloss.Color(value)
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return colors, fmt.Errorf("theme: %s", strings.Join(problems, "; "))
+	}
+	return colors, nil
+}
+
 // DefaultStyles returns the default application styles
 func DefaultStyles() *Styles {
-	colors := DefaultColors()
+	return New(DefaultColors())
+}
 
+// New returns the application styles in colors
+func New(colors *Colors) *Styles {
 	return &Styles{
 		Colors: colors,
 
@@ -272,6 +330,9 @@ func DefaultStyles() *Styles {
 			Foreground(colors.TextDim).
 			Padding(1, 0),
 
+		HelpDisabled: lipgloss.NewStyle().
+			Foreground(colors.Border),
+
 		StatusBar: lipgloss.NewStyle().
 			Foreground(colors.TextMuted).
 			Background(colors.BackgroundAlt).
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// describeRestriction says who can book a restricted room
+func describeRestriction(room milesapi.Room) string {
+	return "Restricted to " + strings.Join(room.GetDepartments(), ", ")
+}
+
+// requestAccessCmd asks who looks after a restricted room to let the user
+// book it, and says who was asked
+func requestAccessCmd(ctx context.Context, client *api.Client, room milesapi.Room) tea.Cmd {
+	return func() tea.Msg {
+		contacted, err := client.RequestRoomAccess(ctx, room.GetId(), "")
+		if err != nil {
+			return ToastMsg{Text: "Could not request access: " + err.Error(), Error: true}
+		}
+		names := make([]string, len(contacted))
+		for i, contact := range contacted {
+			names[i] = contact.Name
+		}
+		return ToastMsg{Text: "✓ Asked " + strings.Join(names, ", ") + " for access to " + room.GetName()}
+	}
+}
@@ -0,0 +1,576 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// ActivityModel shows the rooms and colleagues the user follows and the
+// bookings made or cancelled for them
+type ActivityModel struct {
+	requests
+
+	styles *styles.Styles
+	client *api.Client
+	width  int
+	height int
+
+	// Data
+	subscriptions []milesapi.Subscription
+	items         []milesapi.ActivityItem
+	cursor        int // Over subscriptions
+	loading       bool
+	error         string
+	notice        string
+
+	// Follow a colleague by email; open while adding is set
+	adding     bool
+	emailInput textinput.Model
+
+	// Title and help stay put while the lists scroll
+	layout stickyLayout
+}
+
+// ActivityDataMsg contains the loaded subscriptions and activity
+type ActivityDataMsg struct {
+	Subscriptions []milesapi.Subscription
+	Items         []milesapi.ActivityItem
+}
+
+// ActivityErrorMsg contains error information
+type ActivityErrorMsg struct {
+	Error string
+}
+
+// ActivityItemsMsg carries new activity found by the app's poller
+type ActivityItemsMsg struct {
+	Items []milesapi.ActivityItem
+}
+
+// SubscriptionsChangedMsg is sent after following or unfollowing, with a
+// notice saying what changed
+type SubscriptionsChangedMsg struct {
+	Notice string
+}
+
+// NewActivityModel creates the activity view
+func NewActivityModel(client *api.Client, styles *styles.Styles) *ActivityModel {
+	emailInput := textinput.New()
+	emailInput.Placeholder = "colleague@miles.no"
+	emailInput.CharLimit = 100
+	emailInput.Width = 40
+
+	return &ActivityModel{
+		styles:     styles,
+		client:     client,
+		loading:    true,
+		emailInput: emailInput,
+		layout:     newStickyLayout(),
+	}
+}
+
+// Init loads the subscriptions and the last week's activity
+func (m *ActivityModel) Init() tea.Cmd {
+	return m.loadData()
+}
+
+// isLoading reports whether the feed is still loading
+func (m *ActivityModel) isLoading() bool {
+	return m.loading
+}
+
+// Update handles messages for the activity view
+func (m *ActivityModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.layout.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case ActivityDataMsg:
+		m.subscriptions = msg.Subscriptions
+		m.items = msg.Items
+		m.cursor = min(m.cursor, max(0, len(m.subscriptions)-1))
+		m.loading = false
+		return m, nil
+
+	case ActivityItemsMsg:
+		m.items = mergeActivity(msg.Items, m.items)
+		return m, nil
+
+	case SubscriptionsChangedMsg:
+		m.notice = msg.Notice
+		return m, m.loadData()
+
+	case ActivityErrorMsg:
+		m.error = msg.Error
+		m.loading = false
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+		if m.adding {
+			return m.handleAddKeys(msg)
+		}
+		if m.layout.Scroll(msg) {
+			return m, nil
+		}
+
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Refresh):
+			m.loading = true
+			m.error = ""
+			return m, m.loadData()
+
+		case key.Matches(msg, k.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, k.Down):
+			if m.cursor < len(m.subscriptions)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, k.Follow):
+			m.adding = true
+			m.notice = ""
+			m.emailInput.SetValue("")
+			m.emailInput.Focus()
+			return m, textinput.Blink
+
+		case key.Matches(msg, k.Unfollow):
+			return m, m.unfollow(m.subscriptions[m.cursor])
+		}
+	}
+
+	return m, nil
+}
+
+// handleAddKeys handles keys while typing a colleague's email
+func (m *ActivityModel) handleAddKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap().Add
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.adding = false
+		m.emailInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		email := strings.TrimSpace(m.emailInput.Value())
+		m.adding = false
+		m.emailInput.Blur()
+		return m, followCmd(m.requestCtx(), m.client, "", email)
+	}
+
+	var cmd tea.Cmd
+	m.emailInput, cmd = m.emailInput.Update(msg)
+	return m, cmd
+}
+
+// activityKeyMap lists the activity view's keys, with those of the prompt
+// for a colleague's email
+type activityKeyMap struct {
+	listKeyMap
+	Follow   key.Binding
+	Unfollow key.Binding
+	scrollKeyMap
+	Refresh key.Binding
+	Add     promptKeyMap
+}
+
+// keyMap returns the activity view's keys; d needs a followed colleague
+// under the cursor and the prompt needs an email
+func (m *ActivityModel) keyMap() activityKeyMap {
+	k := activityKeyMap{
+		listKeyMap:   newListKeyMap(),
+		Follow:       newKey("a", "Follow a colleague", "a"),
+		Unfollow:     newKey("d", "Unfollow", "d", "delete"),
+		scrollKeyMap: newScrollKeyMap(),
+		Refresh:      newKey("r", "Refresh", "r", "f5"),
+		Add:          newPromptKeyMap("Follow"),
+	}
+	k.Top.Unbind()
+	k.Bottom.Unbind()
+	k.Unfollow.SetEnabled(m.cursor < len(m.subscriptions))
+	k.Add.Submit.SetEnabled(strings.TrimSpace(m.emailInput.Value()) != "")
+	return k
+}
+
+// CapturingInput reports whether keys should go to the email input rather
+// than the app's global shortcuts
+func (m *ActivityModel) CapturingInput() bool {
+	return m.adding
+}
+
+// View renders the activity view
+func (m *ActivityModel) View() string {
+	if m.loading {
+		return m.styles.Title.Render("Activity") + "\n\n" +
+			m.styles.TextMuted.Render("Loading...")
+	}
+
+	if m.error != "" {
+		return m.styles.Title.Render("Activity") + "\n\n" +
+			m.styles.TextError.Render("Error: "+m.error) + "\n\n" +
+			m.styles.Help.Render("r: Retry")
+	}
+
+	header := m.styles.Title.Render("Activity") + "\n" +
+		m.styles.Subtitle.Render(fmt.Sprintf("Following %d rooms and colleagues", len(m.subscriptions)))
+	if m.notice != "" {
+		header += "\n" + m.styles.TextSuccess.Render(m.notice)
+	}
+	header += "\n"
+
+	// What is followed, then what happened
+	var b strings.Builder
+	b.WriteString(m.styles.Heading.Render("Following"))
+	b.WriteString("\n")
+	top, bottom := -1, -1
+	if len(m.subscriptions) == 0 {
+		b.WriteString(m.styles.TextMuted.Render("  Nothing yet. Press s on a room in Rooms (3), or a to follow a colleague."))
+		b.WriteString("\n")
+	}
+	for i, subscription := range m.subscriptions {
+		kind := "👤"
+		if subscription.Room != nil {
+			kind = "🚪"
+		}
+		line := "  " + m.styles.Text.Render(kind+" "+subscription.Name())
+		if i == m.cursor {
+			top, bottom = i+1, i+1
+			line = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ") +
+				m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render(kind+" "+subscription.Name())
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Heading.Render("Recent"))
+	if len(m.items) == 0 {
+		b.WriteString("\n")
+		b.WriteString(m.styles.TextMuted.Render("  No bookings made or cancelled in the last week."))
+	}
+	now := utils.Now()
+	for _, item := range m.items {
+		b.WriteString("\n")
+		b.WriteString(m.renderItem(item, now))
+	}
+
+	var footer string
+	k := m.keyMap()
+	if m.adding {
+		footer = "\n" + m.styles.Text.Render("Follow colleague: ") + m.emailInput.View() + "\n" +
+			renderFooter(m.styles, m.width, k.Add.Submit, k.Add.Cancel)
+	} else {
+		footer = "\n" + renderFooter(m.styles, m.width, k.Up, k.Follow, k.Unfollow, k.PageUp, k.Refresh)
+	}
+
+	return m.layout.Render(header, b.String(), footer, top, bottom)
+}
+
+// renderItem renders an activity item on one line
+func (m *ActivityModel) renderItem(item milesapi.ActivityItem, now time.Time) string {
+	booking := item.Booking
+	verb, style := "booked", m.styles.Text
+	if item.Type == milesapi.ActivityItemTypeBookingCancelled {
+		verb, style = "cancelled", m.styles.TextMuted
+	}
+	return m.styles.TextMuted.Render(fmt.Sprintf("  %-8s ", humanizeActivityTime(item.Time, now))) +
+		style.Render(fmt.Sprintf("%s %s “%s” in %s, %s–%s",
+			booking.User.FullName(), verb, booking.Title, booking.Room.Name,
+			booking.StartTime.Local().Format("Mon Jan 2 15:04"),
+			booking.EndTime.Local().Format("15:04")))
+}
+
+// loadData loads the subscriptions and the last week's activity
+func (m *ActivityModel) loadData() tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		subscriptions, err := client.GetSubscriptions(ctx)
+		if err != nil {
+			return ActivityErrorMsg{Error: err.Error()}
+		}
+		items, _, err := client.GetActivity(ctx, "")
+		if err != nil {
+			return ActivityErrorMsg{Error: err.Error()}
+		}
+		return ActivityDataMsg{Subscriptions: subscriptions, Items: items}
+	}
+}
+
+// unfollow stops following a subscription
+func (m *ActivityModel) unfollow(subscription milesapi.Subscription) tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		if err := client.Unfollow(ctx, subscription.Id); err != nil {
+			return ToastMsg{Text: "Could not unfollow: " + err.Error(), Error: true}
+		}
+		return SubscriptionsChangedMsg{Notice: "✓ No longer following " + subscription.Name()}
+	}
+}
+
+// followCmd follows a room (roomID) or colleague (email) and reports the
+// result as a toast. Views that list subscriptions reload on success.
+func followCmd(ctx context.Context, client *api.Client, roomID, email string) tea.Cmd {
+	return func() tea.Msg {
+		subscription, err := client.Follow(ctx, roomID, email)
+		if err != nil {
+			return ToastMsg{Text: "Could not follow: " + err.Error(), Error: true}
+		}
+		return SubscriptionsChangedMsg{Notice: "✓ Following " + subscription.Name()}
+	}
+}
+
+// mergeActivity puts newer items in front of older ones, dropping repeats.
+// The poll cursor is inclusive, so the newest item can come back.
+func mergeActivity(newer, older []milesapi.ActivityItem) []milesapi.ActivityItem {
+	seen := make(map[string]bool, len(newer))
+	merged := make([]milesapi.ActivityItem, 0, len(newer)+len(older))
+	for _, items := range [][]milesapi.ActivityItem{newer, older} {
+		for _, item := range items {
+			if seen[item.Key()] {
+				continue
+			}
+			seen[item.Key()] = true
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// humanizeActivityTime describes t relative to now, e.g. "5m ago"
+func humanizeActivityTime(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// describeActivity is the toast text for an activity item
+func describeActivity(item milesapi.ActivityItem) string {
+	booking := item.Booking
+	if item.Type == milesapi.ActivityItemTypeBookingCancelled {
+		return fmt.Sprintf("%s cancelled “%s” in %s (%s)", booking.User.FullName(), booking.Title,
+			booking.Room.Name, booking.StartTime.Local().Format("Mon 15:04"))
+	}
+	return fmt.Sprintf("%s booked %s for “%s” (%s–%s)", booking.User.FullName(), booking.Room.Name,
+		booking.Title, booking.StartTime.Local().Format("Mon 15:04"), booking.EndTime.Local().Format("15:04"))
+}
+
+// activityBooking fills in a booking from what the activity feed has of it
+func activityBooking(item milesapi.ActivityItem) milesapi.Booking {
+	summary := item.Booking
+	status := milesapi.BookingStatus(summary.Status)
+	return milesapi.Booking{
+		Id:        &summary.Id,
+		Title:     &summary.Title,
+		StartTime: &summary.StartTime,
+		EndTime:   &summary.EndTime,
+		Status:    &status,
+		RoomId:    &summary.Room.Id,
+		Room: &milesapi.Room{
+			Id:         &summary.Room.Id,
+			Name:       &summary.Room.Name,
+			LocationId: &summary.Room.Location.Id,
+			Location:   &milesapi.Location{Id: &summary.Room.Location.Id, Name: &summary.Room.Location.Name},
+		},
+		User:   &summary.User,
+		UserId: summary.User.Id,
+	}
+}
+
+// toastDuration is how long a toast stays on screen
+const toastDuration = 6 * time.Second
+
+// maxToasts is how many toasts are shown at once; older ones are dropped
+const maxToasts = 3
+
+// ToastMsg asks the app to show a short-lived notice above the current view
+type ToastMsg struct {
+	Text    string
+	Error   bool
+	Actions []ToastAction // Quick replies, see ToastAction
+}
+
+// toast is a notice on screen
+type toast struct {
+	id      int
+	text    string
+	error   bool
+	actions []ToastAction
+}
+
+// toastExpiredMsg removes a toast once it has been shown long enough
+type toastExpiredMsg struct {
+	id int
+}
+
+// activityTickMsg triggers the next activity poll
+type activityTickMsg struct {
+	gen int
+}
+
+// activityPolledMsg carries the result of an activity poll
+type activityPolledMsg struct {
+	gen    int
+	items  []milesapi.ActivityItem
+	cursor string
+	err    error
+}
+
+// showToast shows a toast and schedules its removal
+func (a *App) showToast(text string, isError bool) tea.Cmd {
+	return a.addToast(toast{text: text, error: isError})
+}
+
+// showActionToast shows a toast offering quick replies
+func (a *App) showActionToast(text string, actions ...ToastAction) tea.Cmd {
+	return a.addToast(toast{text: text, actions: actions})
+}
+
+// addToast shows t and schedules its removal
+func (a *App) addToast(t toast) tea.Cmd {
+	if t.text == "" {
+		return nil
+	}
+	a.toastSeq++
+	id := a.toastSeq
+	t.id = id
+	a.toasts = append(a.toasts, t)
+	if len(a.toasts) > maxToasts {
+		a.toasts = a.toasts[len(a.toasts)-maxToasts:]
+	}
+	return tea.Batch(a.resizeViews(), tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	}))
+}
+
+// renderToasts renders the toasts on screen, one per line, after any
+// handover notice, which stays until its booking ends
+func (a *App) renderToasts() string {
+	var lines []string
+	if a.handover != nil {
+		lines = append(lines, a.styles.TextWarning.Render("⏳ "+a.handover.text))
+	}
+	actionable := a.actionToast()
+	for i, t := range a.toasts {
+		var line string
+		if t.error {
+			line = a.styles.TextError.Render("✗ " + t.text)
+		} else {
+			line = a.styles.TextSuccess.Render("🔔 " + t.text)
+		}
+		if i == actionable {
+			line += "  " + a.styles.Help.Render(renderToastActions(t.actions))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// startActivityPolling (re)starts polling for activity to toast. The first
+// poll only finds where the feed currently ends, so nothing old is toasted.
+func (a *App) startActivityPolling() tea.Cmd {
+	a.activityGen++
+	a.activityCursor = ""
+	a.activitySeen = nil
+	a.approvalsSeen = nil
+	a.pendingApprovals = 0
+	if a.guest {
+		return nil
+	}
+	return tea.Batch(a.pollActivity(), a.pollApprovals())
+}
+
+// pollActivity fetches activity since the cursor, from milesd's cache when
+// the daemon is keeping it
+func (a *App) pollActivity() tea.Cmd {
+	ctx := a.requestCtx()
+	client, gen, cursor := a.client, a.activityGen, a.activityCursor
+	return func() tea.Msg {
+		if items, next, ok := client.DaemonActivity(cursor); ok {
+			return activityPolledMsg{gen: gen, items: items, cursor: next}
+		}
+		items, next, err := client.GetActivity(ctx, cursor)
+		return activityPolledMsg{gen: gen, items: items, cursor: next, err: err}
+	}
+}
+
+// scheduleActivityPoll schedules the next check for new activity to toast,
+// as often as the config says
+func (a *App) scheduleActivityPoll() tea.Cmd {
+	gen := a.activityGen
+	return tea.Tick(a.cfg.ActivityPoll(), func(time.Time) tea.Msg {
+		return activityTickMsg{gen: gen}
+	})
+}
+
+// handleActivity toasts new activity and passes it on to the activity view.
+// Failed polls are retried on the next tick without bothering the user.
+func (a *App) handleActivity(msg activityPolledMsg) tea.Cmd {
+	if msg.err != nil {
+		return nil
+	}
+	first := a.activityCursor == ""
+	a.activityCursor = msg.cursor
+
+	// The cursor is inclusive, so the newest item can come back
+	seen := make(map[string]bool, len(msg.items))
+	var fresh []milesapi.ActivityItem
+	for _, item := range msg.items {
+		seen[item.Key()] = true
+		if !a.activitySeen[item.Key()] {
+			fresh = append(fresh, item)
+		}
+	}
+	a.activitySeen = seen
+	if first || len(fresh) == 0 {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	if a.activity != nil {
+		var cmd tea.Cmd
+		a.activity, cmd = a.activity.Update(ActivityItemsMsg{Items: fresh})
+		cmds = append(cmds, cmd)
+	}
+	// Oldest first, so the newest ends up at the bottom
+	for i := len(fresh) - 1; i >= 0; i-- {
+		item := fresh[i]
+		if item.Type == milesapi.ActivityItemTypeBookingCancelled {
+			// A freed room may be worth grabbing
+			cmds = append(cmds, a.showActionToast(describeActivity(item), detailsAction(activityBooking(item))))
+			continue
+		}
+		cmds = append(cmds, a.showToast(describeActivity(item), false))
+	}
+	return tea.Batch(cmds...)
+}
@@ -3,12 +3,15 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
 	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/pkg/milesapi"
 )
 
 // AdminViewMode represents the current admin view
@@ -19,13 +22,29 @@ const (
 	AdminLocationsMode
 	AdminAllBookingsMode
 	AdminUsersMode
+	AdminMergeRoomsMode
+	AdminApprovalRulesMode
+	AdminIssuesMode
+)
+
+// mergeStep is a step of the room merge wizard
+type mergeStep int
+
+const (
+	mergeStepSource mergeStep = iota
+	mergeStepTarget
+	mergeStepReview
+	mergeStepDone
 )
 
 // AdminModel represents the admin panel
 type AdminModel struct {
+	requests
+
 	styles *styles.Styles
 	client *api.Client
-	user   *models.User
+	user   *milesapi.User
+	role   milesapi.UserRole // What the server will let us do, see App.effectiveRole
 	width  int
 	height int
 
@@ -34,13 +53,50 @@ type AdminModel struct {
 	cursor int
 
 	// Data
-	locations []models.Location
-	bookings  []models.Booking
+	locations []milesapi.Location
+	bookings  []milesapi.Booking
 	loading   bool
 	error     string
 
 	// Menu items (role-dependent)
 	menuItems []adminMenuItem
+
+	// Impersonation target input (user management)
+	impersonateInput textinput.Model
+
+	// All bookings filter; the form is open while it is being edited
+	filter     api.BookingFilter
+	filterForm *bookingFilterForm
+
+	// Bump form for moving the selected booking, and what the last bump did
+	bumpForm       *bumpForm
+	bookingsNotice string
+
+	// Room merge wizard. Rooms and locations also feed the booking filter.
+	rooms       []milesapi.Room
+	mergeStep   mergeStep
+	mergeSource *milesapi.Room
+	mergeTarget *milesapi.Room
+	merge       *milesapi.RoomMerge
+
+	// Approval rules editor: the chosen location, its rules, and the rule
+	// form while one is being added or edited
+	rulesLocation *milesapi.Location
+	rules         *milesapi.ApprovalRulesResponse
+	ruleForm      *approvalRuleForm
+	rulesNotice   string
+	confirmDelete bool
+
+	// Issue queue: the chosen location, its issues as filtered by
+	// issuesView, and the comment prompt while an issue changes status
+	issuesLocation *milesapi.Location
+	issues         []milesapi.Feedback
+	issuesView     int // Index into issueQueueViews
+	issuePrompt    *issueStatusPrompt
+	issuesNotice   string
+
+	// Titles and help stay put while lists scroll
+	layout stickyLayout
 }
 
 type adminMenuItem struct {
@@ -52,12 +108,27 @@ type adminMenuItem struct {
 
 // AdminLocationsDataMsg contains loaded locations data
 type AdminLocationsDataMsg struct {
-	Locations []models.Location
+	Locations []milesapi.Location
 }
 
 // AdminBookingsDataMsg contains loaded bookings data
 type AdminBookingsDataMsg struct {
-	Bookings []models.Booking
+	Bookings []milesapi.Booking
+}
+
+// AdminRoomsDataMsg contains the rooms to choose from when merging
+type AdminRoomsDataMsg struct {
+	Rooms []milesapi.Room
+}
+
+// AdminMergePreviewMsg contains the impact of a room merge before it is made
+type AdminMergePreviewMsg struct {
+	Merge *milesapi.RoomMerge
+}
+
+// AdminMergeDoneMsg is sent once a room merge has been committed
+type AdminMergeDoneMsg struct {
+	Merge *milesapi.RoomMerge
 }
 
 // AdminErrorMsg contains error information
@@ -65,26 +136,40 @@ type AdminErrorMsg struct {
 	Error string
 }
 
-// NewAdminModel creates a new admin panel
-func NewAdminModel(client *api.Client, user *models.User, styles *styles.Styles) *AdminModel {
+// ImpersonateMsg asks the app to start acting as another user
+type ImpersonateMsg struct {
+	Email string
+}
+
+// NewAdminModel creates a new admin panel for a user acting with role
+func NewAdminModel(client *api.Client, user *milesapi.User, role milesapi.UserRole, styles *styles.Styles) *AdminModel {
+	impersonateInput := textinput.New()
+	impersonateInput.Placeholder = "user@example.com"
+	impersonateInput.CharLimit = 100
+	impersonateInput.Width = 40
+
 	m := &AdminModel{
-		styles: styles,
-		client: client,
-		user:   user,
-		mode:   AdminMenuMode,
+		styles:           styles,
+		client:           client,
+		user:             user,
+		role:             role,
+		mode:             AdminMenuMode,
+		impersonateInput: impersonateInput,
+		layout:           newStickyLayout(),
 	}
 
-	// Build menu based on user role
+	// Build menu based on role
 	m.buildMenu()
 
 	return m
 }
 
-// buildMenu builds the admin menu based on user role
+// buildMenu builds the admin menu based on role. Managers see the
+// admin-only items greyed out so they know they exist.
 func (m *AdminModel) buildMenu() {
 	var items []adminMenuItem
 
-	if m.user.Role == models.RoleAdmin {
+	if m.role == milesapi.ADMIN {
 		// Admin gets all features
 		items = []adminMenuItem{
 			{
@@ -99,14 +184,8 @@ func (m *AdminModel) buildMenu() {
 				mode:        AdminAllBookingsMode,
 				adminOnly:   false,
 			},
-			{
-				label:       "User Management",
-				description: "View and manage user accounts and roles",
-				mode:        AdminUsersMode,
-				adminOnly:   true,
-			},
 		}
-	} else if m.user.Role == models.RoleManager {
+	} else if m.role == milesapi.MANAGER {
 		// Manager gets limited features
 		items = []adminMenuItem{
 			{
@@ -124,20 +203,58 @@ func (m *AdminModel) buildMenu() {
 		}
 	}
 
+	items = append(items,
+		adminMenuItem{
+			label:       "Approval Rules",
+			description: "Choose which bookings are confirmed without waiting for approval",
+			mode:        AdminApprovalRulesMode,
+			adminOnly:   false,
+		},
+		adminMenuItem{
+			label:       "Room Issues",
+			description: "Work through the problems people report with rooms, most severe first",
+			mode:        AdminIssuesMode,
+			adminOnly:   false,
+		},
+		adminMenuItem{
+			label:       "User Management",
+			description: "Impersonate a user to see what they see",
+			mode:        AdminUsersMode,
+			adminOnly:   true,
+		},
+		adminMenuItem{
+			label:       "Merge Rooms",
+			description: "Move a duplicate room's bookings into another room and retire it",
+			mode:        AdminMergeRoomsMode,
+			adminOnly:   true,
+		},
+	)
+
 	m.menuItems = items
 }
 
+// available reports whether the role may open a menu item
+func (m *AdminModel) available(item adminMenuItem) bool {
+	return !item.adminOnly || m.role.Allows(milesapi.ADMIN)
+}
+
 // Init initializes the admin panel
 func (m *AdminModel) Init() tea.Cmd {
 	return nil
 }
 
+// isLoading reports whether the current admin screen is still loading
+func (m *AdminModel) isLoading() bool {
+	return m.loading
+}
+
 // Update handles messages for the admin panel
 func (m *AdminModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.layout.SetSize(msg.Width, msg.Height)
 		return m, nil
 
 	case AdminLocationsDataMsg:
@@ -150,6 +267,63 @@ func (m *AdminModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		return m, nil
 
+	case AdminRoomsDataMsg:
+		m.rooms = msg.Rooms
+		m.loading = false
+		return m, nil
+
+	case AdminFilterOptionsMsg:
+		m.locations = msg.Locations
+		m.rooms = msg.Rooms
+		return m, nil
+
+	case AdminMergePreviewMsg:
+		m.merge = msg.Merge
+		m.mergeStep = mergeStepReview
+		m.loading = false
+		m.layout.GotoTop()
+		return m, nil
+
+	case AdminMergeDoneMsg:
+		m.merge = msg.Merge
+		m.mergeStep = mergeStepDone
+		m.loading = false
+		return m, nil
+
+	case AdminApprovalRulesMsg:
+		m.rules = msg.Rules
+		m.cursor = min(m.cursor, max(0, len(msg.Rules.Rules)-1))
+		m.loading = false
+		return m, nil
+
+	case AdminApprovalRulesChangedMsg:
+		m.rulesNotice = msg.Notice
+		return m, m.loadRules()
+
+	case AdminIssuesMsg:
+		m.issues = msg.Issues
+		m.cursor = min(m.cursor, max(0, len(msg.Issues)-1))
+		m.loading = false
+		return m, nil
+
+	case AdminIssueUpdatedMsg:
+		m.issuesNotice = msg.Notice
+		return m, m.loadIssues()
+
+	case AdminBookingBumpedMsg:
+		m.bumpForm = nil
+		m.bookingsNotice = msg.Notice
+		return m, m.loadAllBookings()
+
+	case AdminBumpErrorMsg:
+		m.loading = false
+		if m.bumpForm != nil {
+			m.bumpForm.error = msg.Error
+			return m, nil
+		}
+		m.error = msg.Error
+		return m, nil
+
 	case AdminErrorMsg:
 		m.error = msg.Error
 		m.loading = false
@@ -170,6 +344,12 @@ func (m *AdminModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleBookingsKeys(msg)
 		case AdminUsersMode:
 			return m.handleUsersKeys(msg)
+		case AdminMergeRoomsMode:
+			return m.handleMergeKeys(msg)
+		case AdminApprovalRulesMode:
+			return m.handleRulesKeys(msg)
+		case AdminIssuesMode:
+			return m.handleIssuesKeys(msg)
 		}
 	}
 
@@ -178,47 +358,55 @@ func (m *AdminModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleMenuKeys handles keys in menu mode
 func (m *AdminModel) handleMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "up", "k":
+	k := m.menuKeyMap()
+	switch {
+	case key.Matches(msg, k.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		}
 		return m, nil
 
-	case "down", "j":
+	case key.Matches(msg, k.Down):
 		if m.cursor < len(m.menuItems)-1 {
 			m.cursor++
 		}
 		return m, nil
 
-	case "g":
+	case key.Matches(msg, k.Top):
 		m.cursor = 0
 		return m, nil
 
-	case "G":
+	case key.Matches(msg, k.Bottom):
 		m.cursor = len(m.menuItems) - 1
 		return m, nil
 
-	case "enter":
-		if m.cursor < len(m.menuItems) {
-			selectedItem := m.menuItems[m.cursor]
-			m.mode = selectedItem.mode
-			m.cursor = 0
-			m.error = ""
+	case key.Matches(msg, k.Select):
+		selectedItem := m.menuItems[m.cursor]
+		m.mode = selectedItem.mode
+		m.cursor = 0
+		m.error = ""
 
-			// Load data for the selected view
-			switch selectedItem.mode {
-			case AdminLocationsMode:
-				m.loading = true
-				return m, m.loadLocations()
-			case AdminAllBookingsMode:
-				m.loading = true
-				return m, m.loadAllBookings()
-			case AdminUsersMode:
-				// User management not implemented yet
-				m.error = "User management coming soon"
-				return m, nil
-			}
+		// Load data for the selected view
+		switch selectedItem.mode {
+		case AdminLocationsMode:
+			m.loading = true
+			return m, m.loadLocations()
+		case AdminAllBookingsMode:
+			m.loading = true
+			m.filterForm = nil
+			m.bumpForm = nil
+			m.bookingsNotice = ""
+			return m, tea.Batch(m.loadAllBookings(), m.loadFilterOptions())
+		case AdminUsersMode:
+			m.impersonateInput.SetValue("")
+			m.impersonateInput.Focus()
+			return m, textinput.Blink
+		case AdminMergeRoomsMode:
+			return m, m.startMerge()
+		case AdminApprovalRulesMode:
+			return m, m.openRules()
+		case AdminIssuesMode:
+			return m, m.openIssues()
 		}
 		return m, nil
 	}
@@ -228,35 +416,36 @@ func (m *AdminModel) handleMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleLocationsKeys handles keys in locations mode
 func (m *AdminModel) handleLocationsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
+	k := m.locationsKeyMap()
+	switch {
+	case key.Matches(msg, k.Back):
 		m.mode = AdminMenuMode
 		m.cursor = 0
 		m.error = ""
 		return m, nil
 
-	case "r", "f5":
+	case key.Matches(msg, k.Refresh):
 		m.loading = true
 		m.error = ""
 		return m, m.loadLocations()
 
-	case "up", "k":
+	case key.Matches(msg, k.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		}
 		return m, nil
 
-	case "down", "j":
+	case key.Matches(msg, k.Down):
 		if m.cursor < len(m.locations)-1 {
 			m.cursor++
 		}
 		return m, nil
 
-	case "g":
+	case key.Matches(msg, k.Top):
 		m.cursor = 0
 		return m, nil
 
-	case "G":
+	case key.Matches(msg, k.Bottom):
 		m.cursor = len(m.locations) - 1
 		return m, nil
 	}
@@ -266,35 +455,66 @@ func (m *AdminModel) handleLocationsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleBookingsKeys handles keys in all bookings mode
 func (m *AdminModel) handleBookingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
+	if m.filterForm != nil {
+		return m.handleFilterFormKeys(msg)
+	}
+	if m.bumpForm != nil {
+		return m.handleBumpFormKeys(msg)
+	}
+
+	k := m.bookingsKeyMap()
+	switch {
+	case key.Matches(msg, k.Back):
 		m.mode = AdminMenuMode
 		m.cursor = 0
 		m.error = ""
+		m.bookingsNotice = ""
 		return m, nil
 
-	case "r", "f5":
+	case key.Matches(msg, k.Refresh):
 		m.loading = true
 		m.error = ""
+		m.bookingsNotice = ""
 		return m, m.loadAllBookings()
 
-	case "up", "k":
+	case key.Matches(msg, k.Filter):
+		return m, m.openFilterForm()
+
+	case key.Matches(msg, k.Bump):
+		return m, m.openBumpForm()
+
+	case key.Matches(msg, k.Today):
+		return m, m.applyPreset("today")
+
+	case key.Matches(msg, k.Week):
+		return m, m.applyPreset("week")
+
+	case key.Matches(msg, k.Pending):
+		return m, m.applyPreset("pending")
+
+	case key.Matches(msg, k.Setup):
+		return m, m.applyPreset("setup")
+
+	case key.Matches(msg, k.ClearFilter):
+		return m, m.applyFilter(api.BookingFilter{})
+
+	case key.Matches(msg, k.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		}
 		return m, nil
 
-	case "down", "j":
+	case key.Matches(msg, k.Down):
 		if m.cursor < len(m.bookings)-1 {
 			m.cursor++
 		}
 		return m, nil
 
-	case "g":
+	case key.Matches(msg, k.Top):
 		m.cursor = 0
 		return m, nil
 
-	case "G":
+	case key.Matches(msg, k.Bottom):
 		m.cursor = len(m.bookings) - 1
 		return m, nil
 	}
@@ -304,17 +524,262 @@ func (m *AdminModel) handleBookingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleUsersKeys handles keys in user management mode
 func (m *AdminModel) handleUsersKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
+	k := m.usersKeyMap()
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.impersonateInput.Blur()
 		m.mode = AdminMenuMode
 		m.cursor = 0
 		m.error = ""
 		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		email := strings.TrimSpace(m.impersonateInput.Value())
+		m.impersonateInput.Blur()
+		m.mode = AdminMenuMode
+		return m, func() tea.Msg {
+			return ImpersonateMsg{Email: email}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.impersonateInput, cmd = m.impersonateInput.Update(msg)
+	return m, cmd
+}
+
+// handleMergeKeys handles keys in the room merge wizard
+func (m *AdminModel) handleMergeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.mergeKeyMap()
+	if m.error != "" {
+		switch {
+		case key.Matches(msg, k.Back):
+			m.mode = AdminMenuMode
+			m.cursor = 0
+			m.error = ""
+		case key.Matches(msg, k.Retry):
+			return m, m.startMerge()
+		}
+		return m, nil
+	}
+
+	switch m.mergeStep {
+	case mergeStepReview:
+		if m.layout.Scroll(msg) {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, k.Confirm.No):
+			m.mergeStep = mergeStepTarget
+			m.merge = nil
+		case key.Matches(msg, k.Confirm.Yes):
+			m.loading = true
+			return m, m.commitMerge()
+		}
+		return m, nil
+
+	case mergeStepDone:
+		if key.Matches(msg, k.Done) {
+			m.mode = AdminMenuMode
+			m.cursor = 0
+		}
+		return m, nil
+	}
+
+	candidates := m.mergeCandidates()
+	switch {
+	case key.Matches(msg, k.Back):
+		if m.mergeStep == mergeStepTarget {
+			m.mergeStep = mergeStepSource
+			m.mergeSource = nil
+		} else {
+			m.mode = AdminMenuMode
+		}
+		m.cursor = 0
+		return m, nil
+
+	case key.Matches(msg, k.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Down):
+		if m.cursor < len(candidates)-1 {
+			m.cursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Top):
+		m.cursor = 0
+		return m, nil
+
+	case key.Matches(msg, k.Bottom):
+		m.cursor = len(candidates) - 1
+		return m, nil
+
+	case key.Matches(msg, k.Select):
+		room := candidates[m.cursor]
+		m.cursor = 0
+		if m.mergeStep == mergeStepSource {
+			m.mergeSource = &room
+			m.mergeStep = mergeStepTarget
+			return m, nil
+		}
+		m.mergeTarget = &room
+		m.loading = true
+		return m, m.previewMerge()
 	}
 
 	return m, nil
 }
 
+// adminMenuKeyMap lists the admin menu's keys. Back is the app's; it's here
+// for the footer.
+type adminMenuKeyMap struct {
+	listKeyMap
+	Select key.Binding
+	Back   key.Binding
+}
+
+// menuKeyMap returns the menu's keys; items the role can't use can't be
+// selected
+func (m *AdminModel) menuKeyMap() adminMenuKeyMap {
+	k := adminMenuKeyMap{
+		listKeyMap: newListKeyMap(),
+		Select:     newKey("Enter", "Select", "enter"),
+		Back:       newKey("1", "Back to Dashboard", "1"),
+	}
+	k.Select.SetEnabled(m.cursor < len(m.menuItems) && m.available(m.menuItems[m.cursor]))
+	return k
+}
+
+// adminLocationsKeyMap lists the keys of the locations list
+type adminLocationsKeyMap struct {
+	listKeyMap
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (m *AdminModel) locationsKeyMap() adminLocationsKeyMap {
+	return adminLocationsKeyMap{
+		listKeyMap: newListKeyMap(),
+		Refresh:    newKey("r", "Refresh", "r", "f5"),
+		Back:       newKey("Esc", "Back to menu", "esc", "q"),
+	}
+}
+
+// adminBookingsKeyMap lists the keys of the bookings list. Today carries
+// the hint for all four presets.
+type adminBookingsKeyMap struct {
+	listKeyMap
+	Filter      key.Binding
+	Bump        key.Binding
+	Today       key.Binding
+	Week        key.Binding
+	Pending     key.Binding
+	Setup       key.Binding
+	ClearFilter key.Binding
+	Refresh     key.Binding
+	Back        key.Binding
+}
+
+// bookingsKeyMap returns the bookings list's keys. Only bookings still to
+// come can be bumped, and there is only a filter to clear when one is set.
+func (m *AdminModel) bookingsKeyMap() adminBookingsKeyMap {
+	k := adminBookingsKeyMap{
+		listKeyMap:  newListKeyMap(),
+		Filter:      newKey("f", "Filter", "f"),
+		Bump:        newKey("b", "Bump", "b"),
+		Today:       newKey("t/w/p/s", "Today/This week/Pending approval/Setup requests", "t"),
+		Week:        hiddenKey("w"),
+		Pending:     hiddenKey("p"),
+		Setup:       hiddenKey("s"),
+		ClearFilter: newKey("x", "Clear filter", "x"),
+		Refresh:     newKey("r", "Refresh", "r", "f5"),
+		Back:        newKey("Esc", "Back to menu", "esc", "q"),
+	}
+	bumpable := false
+	if m.cursor < len(m.bookings) {
+		booking := m.bookings[m.cursor]
+		bumpable = booking.GetStatus() != milesapi.BookingStatusCANCELLED && booking.GetEndTime().After(time.Now())
+	}
+	k.Bump.SetEnabled(bumpable)
+	k.ClearFilter.SetEnabled(!m.filter.IsZero())
+	return k
+}
+
+// usersKeyMap returns the keys of the impersonation prompt
+func (m *AdminModel) usersKeyMap() promptKeyMap {
+	k := newPromptKeyMap("Start impersonating")
+	k.Cancel.SetHelp("Esc", "Back to menu")
+	k.Submit.SetEnabled(strings.TrimSpace(m.impersonateInput.Value()) != "")
+	return k
+}
+
+// adminMergeKeyMap lists the keys of each step of the room merge wizard
+type adminMergeKeyMap struct {
+	listKeyMap
+	Select key.Binding
+	Back   key.Binding
+	Retry  key.Binding
+	scrollKeyMap
+	Blocked key.Binding
+	Confirm confirmKeyMap
+	Done    key.Binding
+}
+
+// mergeKeyMap returns the merge wizard's keys. A merge with overlapping
+// bookings can't be committed.
+func (m *AdminModel) mergeKeyMap() adminMergeKeyMap {
+	k := adminMergeKeyMap{
+		listKeyMap:   newListKeyMap(),
+		Select:       newKey("Enter", "Select", "enter"),
+		Back:         newKey("Esc", "Back", "esc"),
+		Retry:        newKey("r", "Retry", "r"),
+		scrollKeyMap: newScrollKeyMap(),
+		Blocked:      hintKey("Move or cancel the overlapping bookings first"),
+		Confirm:      newConfirmKeyMap("Merge and retire", "Back"),
+		Done:         newKey("Enter/Esc", "Back to menu", "enter", "esc"),
+	}
+	if m.mergeStep == mergeStepSource || m.error != "" {
+		k.Back.SetHelp("Esc", "Back to menu")
+	}
+	k.Select.SetEnabled(m.cursor < len(m.mergeCandidates()))
+	if m.mergeSource != nil {
+		k.Confirm.Yes.SetHelp("y", "Merge and retire "+m.mergeSource.GetName())
+	}
+	k.Confirm.No.SetHelp("Esc", "Back")
+	if m.merge != nil && len(m.merge.Conflicts) > 0 {
+		k.Confirm.Yes.SetEnabled(false)
+	} else {
+		k.Blocked.Unbind()
+	}
+	return k
+}
+
+// mergeCandidates returns the rooms that can be picked in the current step.
+// Retired rooms are left out, as is the source when picking the target.
+func (m *AdminModel) mergeCandidates() []milesapi.Room {
+	var rooms []milesapi.Room
+	for _, room := range m.rooms {
+		if !room.GetIsActive() {
+			continue
+		}
+		if m.mergeStep == mergeStepTarget && m.mergeSource != nil && room.GetId() == m.mergeSource.GetId() {
+			continue
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// CapturingInput reports whether keys should go to a text input rather
+// than the app's global shortcuts
+func (m *AdminModel) CapturingInput() bool {
+	return m.mode == AdminUsersMode || m.mode == AdminAllBookingsMode && (m.filterForm != nil || m.bumpForm != nil) ||
+		m.mode == AdminApprovalRulesMode && m.ruleForm != nil || m.mode == AdminIssuesMode && m.issuePrompt != nil
+}
+
 // View renders the admin panel
 func (m *AdminModel) View() string {
 	if m.loading {
@@ -334,6 +799,12 @@ func (m *AdminModel) View() string {
 		return m.renderAllBookings()
 	case AdminUsersMode:
 		return m.renderUsers()
+	case AdminMergeRoomsMode:
+		return m.renderMerge()
+	case AdminApprovalRulesMode:
+		return m.renderRules()
+	case AdminIssuesMode:
+		return m.renderIssues()
 	default:
 		return "Unknown mode"
 	}
@@ -341,93 +812,78 @@ func (m *AdminModel) View() string {
 
 // renderMenu renders the admin menu
 func (m *AdminModel) renderMenu() string {
-	var b strings.Builder
-
 	// Header
-	roleLabel := string(m.user.Role)
-	if m.user.Role == models.RoleAdmin {
-		b.WriteString(m.styles.Title.Render("Admin Panel"))
-	} else {
-		b.WriteString(m.styles.Title.Render("Manager Panel"))
+	roleLabel := string(m.role)
+	title := "Manager Panel"
+	if m.role == milesapi.ADMIN {
+		title = "Admin Panel"
 	}
-	b.WriteString("\n")
-	b.WriteString(m.styles.Subtitle.Render(fmt.Sprintf("Logged in as %s (%s)", m.user.FullName(), roleLabel)))
-	b.WriteString("\n\n")
+	header := m.styles.Title.Render(title) + "\n" +
+		m.styles.Subtitle.Render(fmt.Sprintf("Logged in as %s (%s)", m.user.FullName(), roleLabel)) + "\n"
 
 	// Menu items
+	items := make([]string, len(m.menuItems))
 	for i, item := range m.menuItems {
 		cursor := "  "
 		nameStyle := m.styles.Text
 		descStyle := m.styles.TextMuted
+		description := item.description
 
-		if i == m.cursor {
+		if !m.available(item) {
+			nameStyle = m.styles.TextMuted
+			description = "Requires ADMIN role"
+			if i == m.cursor {
+				cursor = m.styles.TextMuted.Render("> ")
+			}
+		} else if i == m.cursor {
 			cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
 			nameStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
 			descStyle = m.styles.TextMuted.Foreground(m.styles.Colors.Primary)
 		}
 
-		b.WriteString(cursor)
-		b.WriteString(nameStyle.Render(item.label))
-		b.WriteString("\n")
-		b.WriteString("  ")
-		b.WriteString(descStyle.Render(item.description))
-		b.WriteString("\n")
-
-		if i < len(m.menuItems)-1 {
-			b.WriteString("\n")
-		}
+		items[i] = cursor + nameStyle.Render(item.label) + "\n" + "  " + descStyle.Render(description)
 	}
-
-	b.WriteString("\n\n")
+	body, top, bottom := joinItems(items, "\n\n", m.cursor)
 
 	// Help
-	b.WriteString(m.styles.Help.Render("j/k or ↑↓: Navigate • Enter: Select • 1: Back to Dashboard"))
+	k := m.menuKeyMap()
+	footer := "\n" + renderFooter(m.styles, m.width, k.Up, k.Select, k.Back)
 
-	return b.String()
+	return m.layout.Render(header, body, footer, top, bottom)
 }
 
 // renderLocations renders the locations management view
 func (m *AdminModel) renderLocations() string {
-	var b strings.Builder
-
 	// Header
-	if m.user.Role == models.RoleAdmin {
-		b.WriteString(m.styles.Title.Render("Location Management"))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Subtitle.Render(fmt.Sprintf("%d locations", len(m.locations))))
+	var header string
+	if m.role == milesapi.ADMIN {
+		header = m.styles.Title.Render("Location Management") + "\n" +
+			m.styles.Subtitle.Render(fmt.Sprintf("%d locations", len(m.locations)))
 	} else {
-		b.WriteString(m.styles.Title.Render("Managed Locations"))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Subtitle.Render(fmt.Sprintf("%d managed locations", len(m.locations))))
+		header = m.styles.Title.Render("Managed Locations") + "\n" +
+			m.styles.Subtitle.Render(fmt.Sprintf("%d managed locations", len(m.locations)))
 	}
-	b.WriteString("\n\n")
+	header += "\n"
 
 	// Locations list
-	if len(m.locations) == 0 {
-		b.WriteString(m.styles.TextMuted.Render("No locations found."))
-	} else {
+	body, top, bottom := m.styles.TextMuted.Render("No locations found."), -1, -1
+	if len(m.locations) > 0 {
+		items := make([]string, len(m.locations))
 		for i, location := range m.locations {
-			b.WriteString(m.renderLocationItem(location, i == m.cursor))
-			if i < len(m.locations)-1 {
-				b.WriteString("\n\n")
-			}
+			items[i] = m.renderLocationItem(location, i == m.cursor)
 		}
+		body, top, bottom = joinItems(items, "\n\n", m.cursor)
 	}
 
-	b.WriteString("\n\n")
-
 	// Help
-	if m.user.Role == models.RoleAdmin {
-		b.WriteString(m.styles.Help.Render("j/k or ↑↓: Navigate • r: Refresh • Esc: Back to menu"))
-	} else {
-		b.WriteString(m.styles.Help.Render("j/k or ↑↓: Navigate • r: Refresh • Esc: Back to menu"))
-	}
+	k := m.locationsKeyMap()
+	footer := "\n" + renderFooter(m.styles, m.width, k.Up, k.Refresh, k.Back)
 
-	return b.String()
+	return m.layout.Render(header, body, footer, top, bottom)
 }
 
 // renderLocationItem renders a single location item
-func (m *AdminModel) renderLocationItem(location models.Location, isSelected bool) string {
+func (m *AdminModel) renderLocationItem(location milesapi.Location, isSelected bool) string {
 	cursor := "  "
 	nameStyle := m.styles.TextBold
 	textStyle := m.styles.Text
@@ -443,21 +899,21 @@ func (m *AdminModel) renderLocationItem(location models.Location, isSelected boo
 	// Build location card
 	line1 := lipgloss.JoinHorizontal(lipgloss.Left,
 		cursor,
-		nameStyle.Render(location.Name),
+		nameStyle.Render(location.GetName()),
 		" • ",
-		textStyle.Render(location.City+", "+location.Country),
+		textStyle.Render(location.GetCity()+", "+location.GetCountry()),
 	)
 
 	line2 := lipgloss.JoinHorizontal(lipgloss.Left,
 		"  ",
-		mutedStyle.Render(location.Address),
+		mutedStyle.Render(location.GetAddress()),
 	)
 
 	line3 := ""
-	if location.Description != "" {
+	if location.GetDescription() != "" {
 		line3 = lipgloss.JoinHorizontal(lipgloss.Left,
 			"  ",
-			mutedStyle.Render(location.Description),
+			mutedStyle.Render(location.GetDescription()),
 		)
 	}
 
@@ -469,42 +925,56 @@ func (m *AdminModel) renderLocationItem(location models.Location, isSelected boo
 
 // renderAllBookings renders all bookings view
 func (m *AdminModel) renderAllBookings() string {
-	var b strings.Builder
+	if m.filterForm != nil {
+		return m.renderFilterForm()
+	}
+	if m.bumpForm != nil {
+		return m.renderBumpForm()
+	}
 
 	// Header
-	if m.user.Role == models.RoleAdmin {
-		b.WriteString(m.styles.Title.Render("All Bookings"))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Subtitle.Render(fmt.Sprintf("%d bookings across all locations", len(m.bookings))))
-	} else {
-		b.WriteString(m.styles.Title.Render("Location Bookings"))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Subtitle.Render(fmt.Sprintf("%d bookings in managed locations", len(m.bookings))))
+	var header string
+	switch {
+	case !m.filter.IsZero():
+		title := "All Bookings"
+		if m.role != milesapi.ADMIN {
+			title = "Location Bookings"
+		}
+		header = m.styles.Title.Render(title) + "\n" +
+			m.styles.Subtitle.Render(fmt.Sprintf("%d matching bookings", len(m.bookings))) + "\n" +
+			m.styles.Text.Render("Filter: "+m.describeFilter())
+	case m.role == milesapi.ADMIN:
+		header = m.styles.Title.Render("All Bookings") + "\n" +
+			m.styles.Subtitle.Render(fmt.Sprintf("%d bookings across all locations", len(m.bookings)))
+	default:
+		header = m.styles.Title.Render("Location Bookings") + "\n" +
+			m.styles.Subtitle.Render(fmt.Sprintf("%d bookings in managed locations", len(m.bookings)))
 	}
-	b.WriteString("\n\n")
+	if m.bookingsNotice != "" {
+		header += "\n" + m.styles.TextSuccess.Render(m.bookingsNotice)
+	}
+	header += "\n"
 
 	// Bookings list
-	if len(m.bookings) == 0 {
-		b.WriteString(m.styles.TextMuted.Render("No bookings found."))
-	} else {
+	body, top, bottom := m.styles.TextMuted.Render("No bookings found."), -1, -1
+	if len(m.bookings) > 0 {
+		items := make([]string, len(m.bookings))
 		for i, booking := range m.bookings {
-			b.WriteString(m.renderBookingItem(booking, i == m.cursor))
-			if i < len(m.bookings)-1 {
-				b.WriteString("\n\n")
-			}
+			items[i] = m.renderBookingItem(booking, i == m.cursor)
 		}
+		body, top, bottom = joinItems(items, "\n\n", m.cursor)
 	}
 
-	b.WriteString("\n\n")
-
 	// Help
-	b.WriteString(m.styles.Help.Render("j/k or ↑↓: Navigate • r: Refresh • Esc: Back to menu"))
+	k := m.bookingsKeyMap()
+	footer := "\n" + renderFooter(m.styles, m.width, k.Up, k.Filter, k.Bump, k.Today, k.ClearFilter,
+		k.Refresh, k.Back)
 
-	return b.String()
+	return m.layout.Render(header, body, footer, top, bottom)
 }
 
 // renderBookingItem renders a single booking item
-func (m *AdminModel) renderBookingItem(booking models.Booking, isSelected bool) string {
+func (m *AdminModel) renderBookingItem(booking milesapi.Booking, isSelected bool) string {
 	cursor := "  "
 	nameStyle := m.styles.TextBold
 	textStyle := m.styles.Text
@@ -519,36 +989,44 @@ func (m *AdminModel) renderBookingItem(booking models.Booking, isSelected bool)
 
 	// Status badge
 	var statusBadge string
-	switch booking.Status {
-	case models.BookingStatusConfirmed:
+	switch booking.GetStatus() {
+	case milesapi.BookingStatusCONFIRMED:
 		statusBadge = m.styles.BadgeSuccess.Render("CONFIRMED")
-	case models.BookingStatusPending:
+	case milesapi.BookingStatusPENDING:
 		statusBadge = m.styles.BadgeWarning.Render("PENDING")
-	case models.BookingStatusCancelled:
+	case milesapi.BookingStatusCANCELLED:
 		statusBadge = m.styles.BadgeError.Render("CANCELLED")
 	}
 
 	// Build booking card
 	line1 := lipgloss.JoinHorizontal(lipgloss.Left,
 		cursor,
-		nameStyle.Render(booking.Title),
+		nameStyle.Render(booking.GetTitle()),
 		" • ",
-		textStyle.Render(booking.User.FullName()),
+		textStyle.Render(booking.GetUser().FullName()),
 		"  ",
 		statusBadge,
 	)
 
 	line2 := lipgloss.JoinHorizontal(lipgloss.Left,
 		"  ",
-		mutedStyle.Render(booking.Room.Name+" • "+booking.Room.Location.Name),
+		mutedStyle.Render(booking.GetRoom().GetName()+" • "+booking.GetRoom().GetLocation().GetName()),
 	)
 
 	line3 := lipgloss.JoinHorizontal(lipgloss.Left,
 		"  ",
-		textStyle.Render(booking.StartTime.Format("Jan 2, 2006 3:04 PM")+" - "+booking.EndTime.Format("3:04 PM")),
+		textStyle.Render(booking.GetStartTime().Format("Jan 2, 2006 3:04 PM")+" - "+booking.GetEndTime().Format("3:04 PM")),
 	)
 
-	return line1 + "\n" + line2 + "\n" + line3
+	card := line1 + "\n" + line2 + "\n" + line3
+	if booking.HasSetupRequest() {
+		setup := "Setup: " + booking.GetSetup().Label()
+		if booking.GetSetupNotes() != "" {
+			setup += " • " + booking.GetSetupNotes()
+		}
+		card += "\n  " + mutedStyle.Render(setup)
+	}
+	return card
 }
 
 // renderUsers renders the user management view
@@ -558,18 +1036,130 @@ func (m *AdminModel) renderUsers() string {
 	b.WriteString(m.styles.Title.Render("User Management"))
 	b.WriteString("\n\n")
 
-	b.WriteString(m.styles.TextMuted.Render("Coming soon..."))
+	b.WriteString(m.styles.Heading.Render("Impersonate User"))
+	b.WriteString("\n")
+	b.WriteString(m.styles.TextMuted.Render("See the app exactly as a user does. Every request is audited with both identities."))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.Text.Render("Email:"))
+	b.WriteString("\n")
+	b.WriteString(m.impersonateInput.View())
 	b.WriteString("\n\n")
 
-	b.WriteString(m.styles.Help.Render("Esc: Back to menu"))
+	k := m.usersKeyMap()
+	b.WriteString(renderFooter(m.styles, m.width, k.Submit, k.Cancel))
 
 	return b.String()
 }
 
+// renderMerge renders the current step of the room merge wizard
+func (m *AdminModel) renderMerge() string {
+	header := m.styles.Title.Render("Merge Rooms") + "\n"
+
+	switch m.mergeStep {
+	case mergeStepSource:
+		header += m.styles.Subtitle.Render("Step 1 of 3: Pick the duplicate room to retire")
+	case mergeStepTarget:
+		header += m.styles.Subtitle.Render("Step 2 of 3: Pick the room to move " + m.mergeSource.GetName() + "'s bookings into")
+	case mergeStepReview:
+		header += m.styles.Subtitle.Render("Step 3 of 3: Review") + "\n"
+		body, footer := m.renderMergeImpact()
+		return m.layout.Render(header, body, footer, -1, -1)
+	case mergeStepDone:
+		return header + "\n" +
+			m.styles.TextSuccess.Render(fmt.Sprintf("✓ Moved %d booking(s) from %s to %s",
+				len(m.merge.Bookings), m.mergeSource.GetName(), m.mergeTarget.GetName())) + "\n" +
+			m.styles.TextSuccess.Render("✓ "+m.mergeSource.GetName()+" has been retired") + "\n\n" +
+			renderFooter(m.styles, m.width, m.mergeKeyMap().Done)
+	}
+	header += "\n"
+
+	candidates := m.mergeCandidates()
+	body, top, bottom := m.styles.TextMuted.Render("No rooms to choose from."), -1, -1
+	if len(candidates) > 0 {
+		items := make([]string, len(candidates))
+		for i, room := range candidates {
+			cursor := "  "
+			nameStyle := m.styles.TextBold
+			mutedStyle := m.styles.TextMuted
+			if i == m.cursor {
+				cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+				nameStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
+				mutedStyle = m.styles.TextMuted.Foreground(m.styles.Colors.Primary)
+			}
+			items[i] = lipgloss.JoinHorizontal(lipgloss.Left,
+				cursor,
+				nameStyle.Render(room.GetName()),
+				" • ",
+				mutedStyle.Render(room.GetLocation().GetName()+" • "+room.GetId()),
+			)
+		}
+		body, top, bottom = joinItems(items, "\n", m.cursor)
+	}
+
+	k := m.mergeKeyMap()
+	footer := "\n" + renderFooter(m.styles, m.width, k.Up, k.Select, k.Back)
+
+	return m.layout.Render(header, body, footer, top, bottom)
+}
+
+// mergeListLimit caps how many bookings the review step lists
+const mergeListLimit = 10
+
+// renderMergeImpact renders what a merge will do before it is committed,
+// returning the impact and the help shown below it
+func (m *AdminModel) renderMergeImpact() (string, string) {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Text.Render("Retire: " + m.mergeSource.GetName() + " (" + m.mergeSource.GetId() + ")"))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Text.Render("Into:   " + m.mergeTarget.GetName() + " (" + m.mergeTarget.GetId() + ")"))
+	b.WriteString("\n\n")
+
+	if len(m.merge.Bookings) == 0 {
+		b.WriteString(m.styles.TextMuted.Render("No bookings to move."))
+	} else {
+		b.WriteString(m.styles.Heading.Render(fmt.Sprintf("%d booking(s) will move", len(m.merge.Bookings))))
+		for i, booking := range m.merge.Bookings {
+			b.WriteString("\n")
+			if i == mergeListLimit {
+				b.WriteString(m.styles.TextMuted.Render(fmt.Sprintf("  …and %d more", len(m.merge.Bookings)-mergeListLimit)))
+				break
+			}
+			b.WriteString(m.styles.Text.Render("  " + m.describeMergeBooking(booking)))
+		}
+	}
+
+	if len(m.merge.Conflicts) > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(m.styles.TextError.Render(fmt.Sprintf("✗ %d booking(s) overlap bookings in %s", len(m.merge.Conflicts), m.mergeTarget.GetName())))
+		for _, conflict := range m.merge.Conflicts {
+			b.WriteString("\n")
+			b.WriteString(m.styles.Text.Render("  " + m.describeMergeBooking(conflict.Booking)))
+			b.WriteString("\n")
+			b.WriteString(m.styles.TextMuted.Render("    clashes with " + m.describeMergeBooking(conflict.ConflictsWith)))
+		}
+	}
+
+	k := m.mergeKeyMap()
+	return b.String(), "\n" + renderFooter(m.styles, m.width, k.Blocked, k.Confirm.Yes, k.PageUp, k.Confirm.No)
+}
+
+// describeMergeBooking formats a booking as a single line for the review step
+func (m *AdminModel) describeMergeBooking(booking milesapi.Booking) string {
+	line := booking.GetTitle() + " • " + booking.GetStartTime().Local().Format("Jan 2, 2006 15:04") + "-" + booking.GetEndTime().Local().Format("15:04")
+	if name := booking.GetUser().FullName(); strings.TrimSpace(name) != "" {
+		line += " • " + name
+	}
+	if booking.GetStatus() == milesapi.BookingStatusCANCELLED {
+		line += " (cancelled)"
+	}
+	return line
+}
+
 // renderLoading renders the loading state
 func (m *AdminModel) renderLoading() string {
 	title := "Admin Panel"
-	if m.user.Role == models.RoleManager {
+	if m.role == milesapi.MANAGER {
 		title = "Manager Panel"
 	}
 
@@ -580,7 +1170,7 @@ func (m *AdminModel) renderLoading() string {
 // renderError renders the error state
 func (m *AdminModel) renderError() string {
 	title := "Admin Panel"
-	if m.user.Role == models.RoleManager {
+	if m.role == milesapi.MANAGER {
 		title = "Manager Panel"
 	}
 
@@ -591,8 +1181,9 @@ func (m *AdminModel) renderError() string {
 
 // loadLocations loads all locations
 func (m *AdminModel) loadLocations() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
-		locations, err := m.client.GetLocations()
+		locations, err := m.client.GetLocations(ctx)
 		if err != nil {
 			return AdminErrorMsg{Error: err.Error()}
 		}
@@ -601,12 +1192,14 @@ func (m *AdminModel) loadLocations() tea.Cmd {
 	}
 }
 
-// loadAllBookings loads all bookings
+// loadAllBookings loads the bookings matching the filter
 func (m *AdminModel) loadAllBookings() tea.Cmd {
+	ctx := m.requestCtx()
+	filter := m.filter
 	return func() tea.Msg {
-		// GetBookings without filters returns all bookings
+		// Without a filter this returns all bookings
 		// The API automatically filters based on user role
-		bookings, err := m.client.GetBookings(nil, nil, nil, nil)
+		bookings, err := m.client.GetBookingsFiltered(ctx, filter)
 		if err != nil {
 			return AdminErrorMsg{Error: err.Error()}
 		}
@@ -614,3 +1207,52 @@ func (m *AdminModel) loadAllBookings() tea.Cmd {
 		return AdminBookingsDataMsg{Bookings: bookings}
 	}
 }
+
+// startMerge resets the merge wizard and loads the rooms to choose from
+func (m *AdminModel) startMerge() tea.Cmd {
+	ctx := m.requestCtx()
+	m.mergeStep = mergeStepSource
+	m.mergeSource = nil
+	m.mergeTarget = nil
+	m.merge = nil
+	m.cursor = 0
+	m.error = ""
+	m.loading = true
+
+	return func() tea.Msg {
+		rooms, err := m.client.GetRooms(ctx, "")
+		if err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		return AdminRoomsDataMsg{Rooms: rooms}
+	}
+}
+
+// previewMerge asks the server what merging the chosen rooms would do
+func (m *AdminModel) previewMerge() tea.Cmd {
+	ctx := m.requestCtx()
+	sourceID, targetID := m.mergeSource.GetId(), m.mergeTarget.GetId()
+	return func() tea.Msg {
+		merge, err := m.client.MergeRoom(ctx, sourceID, targetID, true)
+		if err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		return AdminMergePreviewMsg{Merge: merge}
+	}
+}
+
+// commitMerge merges the chosen rooms
+func (m *AdminModel) commitMerge() tea.Cmd {
+	ctx := m.requestCtx()
+	sourceID, targetID := m.mergeSource.GetId(), m.mergeTarget.GetId()
+	return func() tea.Msg {
+		merge, err := m.client.MergeRoom(ctx, sourceID, targetID, false)
+		if err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		return AdminMergeDoneMsg{Merge: merge}
+	}
+}
@@ -0,0 +1,279 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Fields of the bump form, top to bottom
+const (
+	bumpFieldRoom = iota
+	bumpFieldDate
+	bumpFieldStart
+	bumpFieldEnd
+	bumpFieldReason
+	bumpFieldCount
+)
+
+// bumpForm moves the selected booking to another time or room to make way
+// for a priority booking
+type bumpForm struct {
+	booking milesapi.Booking
+	field   int
+	room    string // Room ID
+	date    textinput.Model
+	start   textinput.Model
+	end     textinput.Model
+	reason  textinput.Model
+	error   string
+}
+
+// AdminBookingBumpedMsg is sent once a booking has been moved
+type AdminBookingBumpedMsg struct {
+	Notice string
+}
+
+// AdminBumpErrorMsg reports a refused bump, keeping the form open
+type AdminBumpErrorMsg struct {
+	Error string
+}
+
+// openBumpForm starts moving the selected booking, prefilled with its
+// current room and time
+func (m *AdminModel) openBumpForm() tea.Cmd {
+	if m.cursor >= len(m.bookings) {
+		return nil
+	}
+	booking := m.bookings[m.cursor]
+	// Only bookings still to come can be moved
+	if booking.GetStatus() == milesapi.BookingStatusCANCELLED || !booking.GetEndTime().After(time.Now()) {
+		return nil
+	}
+
+	newInput := func(placeholder, value string, limit int) textinput.Model {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholder
+		input.CharLimit = limit
+		input.Width = 30
+		input.SetValue(value)
+		return input
+	}
+
+	start, end := booking.GetStartTime().Local(), booking.GetEndTime().Local()
+	m.bumpForm = &bumpForm{
+		booking: booking,
+		room:    booking.GetRoomId(),
+		date:    newInput(filterDateFormat, start.Format(filterDateFormat), 10),
+		start:   newInput("15:04", start.Format("15:04"), 5),
+		end:     newInput("15:04", end.Format("15:04"), 5),
+		reason:  newInput("shown to the owner", "", 500),
+	}
+	m.bookingsNotice = ""
+	m.focusBumpField()
+	return textinput.Blink
+}
+
+// handleBumpFormKeys handles keys while the bump form is open
+func (m *AdminModel) handleBumpFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := m.bumpForm
+
+	k := m.bumpKeyMap()
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.bumpForm = nil
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		req, err := m.bumpRequestFromForm()
+		if err != nil {
+			form.error = err.Error()
+			return m, nil
+		}
+		m.loading = true
+		return m, m.bumpBooking(form.booking, req)
+
+	case key.Matches(msg, k.Next):
+		form.field = (form.field + 1) % bumpFieldCount
+		m.focusBumpField()
+		return m, nil
+
+	case key.Matches(msg, k.Prev):
+		form.field = (form.field + bumpFieldCount - 1) % bumpFieldCount
+		m.focusBumpField()
+		return m, nil
+
+	case key.Matches(msg, k.Decrease, k.Increase):
+		step := 1
+		if key.Matches(msg, k.Decrease) {
+			step = -1
+		}
+		form.room = cycleChoice(m.bumpRoomIDs(form.booking), form.room, step)
+		form.error = ""
+		return m, nil
+	}
+
+	// Everything else is typing into the focused text field
+	var cmd tea.Cmd
+	switch form.field {
+	case bumpFieldDate:
+		form.date, cmd = form.date.Update(msg)
+	case bumpFieldStart:
+		form.start, cmd = form.start.Update(msg)
+	case bumpFieldEnd:
+		form.end, cmd = form.end.Update(msg)
+	case bumpFieldReason:
+		form.reason, cmd = form.reason.Update(msg)
+	}
+	form.error = ""
+	return m, cmd
+}
+
+// bumpKeyMap returns the bump form's keys. ←→ only change the room; in the
+// text fields they move the text cursor.
+func (m *AdminModel) bumpKeyMap() formKeyMap {
+	k := newFormKeyMap("Bump")
+	k.Decrease.SetHelp("←→", "Room")
+	onRoom := m.bumpForm.field == bumpFieldRoom
+	k.Decrease.SetEnabled(onRoom)
+	k.Increase.SetEnabled(onRoom)
+	return k
+}
+
+// focusBumpField moves the text cursor to the focused field, if it takes text
+func (m *AdminModel) focusBumpField() {
+	form := m.bumpForm
+	for field, input := range map[int]*textinput.Model{
+		bumpFieldDate:   &form.date,
+		bumpFieldStart:  &form.start,
+		bumpFieldEnd:    &form.end,
+		bumpFieldReason: &form.reason,
+	} {
+		if field == form.field {
+			input.Focus()
+		} else {
+			input.Blur()
+		}
+	}
+}
+
+// bumpRoomIDs returns the rooms a booking can be moved to: the active
+// rooms at its location
+func (m *AdminModel) bumpRoomIDs(booking milesapi.Booking) []string {
+	ids := []string{booking.GetRoomId()}
+	for _, room := range m.rooms {
+		if room.GetId() != booking.GetRoomId() && room.GetIsActive() && room.GetLocationId() == booking.GetRoom().GetLocationId() {
+			ids = append(ids, room.GetId())
+		}
+	}
+	return ids
+}
+
+// bumpRequestFromForm builds the move the form describes
+func (m *AdminModel) bumpRequestFromForm() (milesapi.BumpInput, error) {
+	form := m.bumpForm
+	var req milesapi.BumpInput
+
+	date, err := parseFilterDate(form.date.Value())
+	if err != nil {
+		return req, fmt.Errorf("date: %w", err)
+	}
+	if date.IsZero() {
+		return req, fmt.Errorf("date: required")
+	}
+	clock := func(label, value string) (time.Time, error) {
+		t, err := time.Parse("15:04", strings.TrimSpace(value))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%s: use HH:MM", label)
+		}
+		return date.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute), nil
+	}
+	if req.StartTime, err = clock("start", form.start.Value()); err != nil {
+		return req, err
+	}
+	if req.EndTime, err = clock("end", form.end.Value()); err != nil {
+		return req, err
+	}
+	if !req.EndTime.After(req.StartTime) {
+		return req, fmt.Errorf("end must be after start")
+	}
+
+	booking := form.booking
+	if form.room == booking.GetRoomId() && req.StartTime.Equal(booking.GetStartTime()) && req.EndTime.Equal(booking.GetEndTime()) {
+		return req, fmt.Errorf("choose another time or room")
+	}
+	if room := form.room; room != booking.GetRoomId() {
+		req.RoomId = &room
+	}
+	if reason := strings.TrimSpace(form.reason.Value()); reason != "" {
+		req.Reason = &reason
+	}
+	return req, nil
+}
+
+// bumpBooking moves the booking and reloads the list
+func (m *AdminModel) bumpBooking(booking milesapi.Booking, req milesapi.BumpInput) tea.Cmd {
+	ctx := m.requestCtx()
+	return func() tea.Msg {
+		moved, err := m.client.BumpBooking(ctx, booking.GetId(), req)
+		if err != nil {
+			return AdminBumpErrorMsg{Error: err.Error()}
+		}
+
+		notice := fmt.Sprintf("Moved %q to %s %s-%s; %s has been emailed",
+			booking.GetTitle(), moved.GetStartTime().Local().Format("Mon Jan 2"),
+			moved.GetStartTime().Local().Format("15:04"), moved.GetEndTime().Local().Format("15:04"),
+			booking.GetUser().FullName())
+		return AdminBookingBumpedMsg{Notice: notice}
+	}
+}
+
+// renderBumpForm renders the bump form
+func (m *AdminModel) renderBumpForm() string {
+	form := m.bumpForm
+	booking := form.booking
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Bump Booking"))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Subtitle.Render(fmt.Sprintf("%s • %s • %s, %s",
+		booking.GetTitle(), booking.GetUser().FullName(), booking.GetRoom().GetName(),
+		booking.GetStartTime().Local().Format("Jan 2 15:04")+"-"+booking.GetEndTime().Local().Format("15:04"))))
+	b.WriteString("\n\n")
+
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"Room", "‹ " + m.roomName(form.room) + " ›"},
+		{"Date", form.date.View()},
+		{"Start", form.start.View()},
+		{"End", form.end.View()},
+		{"Reason", form.reason.View()},
+	}
+	for i, row := range rows {
+		cursor, labelStyle := "  ", m.styles.TextMuted
+		if i == form.field {
+			cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+			labelStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
+		}
+		b.WriteString(cursor + labelStyle.Render(fmt.Sprintf("%-9s", row.label)) + " " + row.value + "\n")
+	}
+
+	if form.error != "" {
+		b.WriteString("\n" + m.styles.TextError.Render(form.error) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.TextMuted.Render("The owner is emailed and the move is logged."))
+	b.WriteString("\n\n")
+	k := m.bumpKeyMap()
+	b.WriteString(renderFooter(m.styles, m.width, k.Next, k.Decrease, k.Submit, k.Cancel))
+	return b.String()
+}
@@ -0,0 +1,410 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Fields of the booking filter form, top to bottom
+const (
+	filterFieldLocation = iota
+	filterFieldRoom
+	filterFieldUser
+	filterFieldFrom
+	filterFieldTo
+	filterFieldStatus
+	filterFieldCount
+)
+
+// filterDateFormat is how dates are typed into the filter form
+const filterDateFormat = "2006-01-02"
+
+// filterStatuses are the status filter choices; "" lets every status through
+var filterStatuses = []milesapi.BookingStatus{
+	"",
+	milesapi.BookingStatusPENDING,
+	milesapi.BookingStatusCONFIRMED,
+	milesapi.BookingStatusCANCELLED,
+}
+
+// bookingFilterForm edits the all-bookings filter before it is applied
+type bookingFilterForm struct {
+	field    int
+	location string // Location ID, "" for any
+	room     string // Room ID, "" for any
+	status   milesapi.BookingStatus
+	user     textinput.Model
+	from     textinput.Model
+	to       textinput.Model
+	error    string
+}
+
+// AdminFilterOptionsMsg contains the locations and rooms to filter bookings by
+type AdminFilterOptionsMsg struct {
+	Locations []milesapi.Location
+	Rooms     []milesapi.Room
+}
+
+// openFilterForm starts editing the current filter
+func (m *AdminModel) openFilterForm() tea.Cmd {
+	newInput := func(placeholder string, value string) textinput.Model {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholder
+		input.CharLimit = 100
+		input.Width = 30
+		input.SetValue(value)
+		return input
+	}
+
+	form := &bookingFilterForm{
+		location: m.filter.LocationID,
+		room:     m.filter.RoomID,
+		status:   m.filter.Status,
+		user:     newInput("email or name", m.filter.User),
+		from:     newInput(filterDateFormat, formatFilterDate(m.filter.From)),
+		to:       newInput(filterDateFormat, formatFilterDate(m.filter.To)),
+	}
+	m.filterForm = form
+	m.focusFilterField()
+	return textinput.Blink
+}
+
+// handleFilterFormKeys handles keys while the filter form is open
+func (m *AdminModel) handleFilterFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := m.filterForm
+
+	k := m.filterFormKeyMap()
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.filterForm = nil
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		filter, err := m.filterFromForm()
+		if err != nil {
+			form.error = err.Error()
+			return m, nil
+		}
+		m.filterForm = nil
+		return m, m.applyFilter(filter)
+
+	case key.Matches(msg, k.Next):
+		form.field = (form.field + 1) % filterFieldCount
+		m.focusFilterField()
+		return m, nil
+
+	case key.Matches(msg, k.Prev):
+		form.field = (form.field + filterFieldCount - 1) % filterFieldCount
+		m.focusFilterField()
+		return m, nil
+
+	case key.Matches(msg, k.Decrease, k.Increase):
+		step := 1
+		if key.Matches(msg, k.Decrease) {
+			step = -1
+		}
+		switch form.field {
+		case filterFieldLocation:
+			form.location = cycleChoice(m.filterLocationIDs(), form.location, step)
+			// The room must be at the chosen location
+			form.room = ""
+			return m, nil
+		case filterFieldRoom:
+			form.room = cycleChoice(m.filterRoomIDs(form.location), form.room, step)
+			return m, nil
+		case filterFieldStatus:
+			statuses := make([]string, len(filterStatuses))
+			for i, status := range filterStatuses {
+				statuses[i] = string(status)
+			}
+			form.status = milesapi.BookingStatus(cycleChoice(statuses, string(form.status), step))
+			return m, nil
+		}
+	}
+
+	// Everything else is typing into the focused text field
+	var cmd tea.Cmd
+	switch form.field {
+	case filterFieldUser:
+		form.user, cmd = form.user.Update(msg)
+	case filterFieldFrom:
+		form.from, cmd = form.from.Update(msg)
+	case filterFieldTo:
+		form.to, cmd = form.to.Update(msg)
+	}
+	form.error = ""
+	return m, cmd
+}
+
+// filterFormKeyMap returns the filter form's keys. ←→ change the location,
+// room and status; in the text fields they move the text cursor.
+func (m *AdminModel) filterFormKeyMap() formKeyMap {
+	k := newFormKeyMap("Apply")
+	field := m.filterForm.field
+	onChoice := field == filterFieldLocation || field == filterFieldRoom || field == filterFieldStatus
+	k.Decrease.SetEnabled(onChoice)
+	k.Increase.SetEnabled(onChoice)
+	return k
+}
+
+// focusFilterField moves the text cursor to the focused field, if it takes text
+func (m *AdminModel) focusFilterField() {
+	form := m.filterForm
+	for field, input := range map[int]*textinput.Model{
+		filterFieldUser: &form.user,
+		filterFieldFrom: &form.from,
+		filterFieldTo:   &form.to,
+	} {
+		if field == form.field {
+			input.Focus()
+		} else {
+			input.Blur()
+		}
+	}
+}
+
+// filterFromForm builds the filter the form describes
+func (m *AdminModel) filterFromForm() (api.BookingFilter, error) {
+	form := m.filterForm
+	filter := api.BookingFilter{
+		LocationID: form.location,
+		RoomID:     form.room,
+		User:       strings.TrimSpace(form.user.Value()),
+		Status:     form.status,
+	}
+
+	var err error
+	if filter.From, err = parseFilterDate(form.from.Value()); err != nil {
+		return filter, fmt.Errorf("from: %w", err)
+	}
+	to, err := parseFilterDate(form.to.Value())
+	if err != nil {
+		return filter, fmt.Errorf("to: %w", err)
+	}
+	if !to.IsZero() {
+		// Include the whole last day
+		filter.To = to.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return filter, fmt.Errorf("to is before from")
+	}
+	return filter, nil
+}
+
+// applyFilter reloads the bookings with a new filter
+func (m *AdminModel) applyFilter(filter api.BookingFilter) tea.Cmd {
+	m.filter = filter
+	m.cursor = 0
+	m.loading = true
+	m.error = ""
+	m.layout.GotoTop()
+	return m.loadAllBookings()
+}
+
+// applyPreset narrows the current filter to a common view. Presets combine,
+// so "today" then "pending approval" shows today's pending bookings.
+func (m *AdminModel) applyPreset(preset string) tea.Cmd {
+	filter := m.filter
+	today := startOfDay(time.Now())
+
+	switch preset {
+	case "today":
+		filter.From, filter.To = today, today.AddDate(0, 0, 1).Add(-time.Second)
+	case "week":
+		// Weeks start on Sunday, as in the calendar
+		start := today.AddDate(0, 0, -int(today.Weekday()))
+		filter.From, filter.To = start, start.AddDate(0, 0, 7).Add(-time.Second)
+	case "pending":
+		filter.Status = milesapi.BookingStatusPENDING
+	case "setup":
+		filter.HasSetup = true
+	}
+	return m.applyFilter(filter)
+}
+
+// loadFilterOptions loads the locations and rooms the filter can choose from
+func (m *AdminModel) loadFilterOptions() tea.Cmd {
+	ctx := m.requestCtx()
+	return func() tea.Msg {
+		locations, err := m.client.GetLocations(ctx)
+		if err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+		rooms, err := m.client.GetRooms(ctx, "")
+		if err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		return AdminFilterOptionsMsg{Locations: locations, Rooms: rooms}
+	}
+}
+
+// filterLocationIDs returns the location choices, starting with "any"
+func (m *AdminModel) filterLocationIDs() []string {
+	ids := []string{""}
+	for _, location := range m.locations {
+		ids = append(ids, location.GetId())
+	}
+	return ids
+}
+
+// filterRoomIDs returns the room choices at a location, starting with "any"
+func (m *AdminModel) filterRoomIDs(locationID string) []string {
+	ids := []string{""}
+	for _, room := range m.rooms {
+		if locationID == "" || room.GetLocationId() == locationID {
+			ids = append(ids, room.GetId())
+		}
+	}
+	return ids
+}
+
+// cycleChoice returns the choice step places from current, wrapping around
+func cycleChoice(choices []string, current string, step int) string {
+	for i, choice := range choices {
+		if choice == current {
+			return choices[(i+step+len(choices))%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
+// locationName returns a location's name, falling back to its ID
+func (m *AdminModel) locationName(id string) string {
+	for _, location := range m.locations {
+		if location.GetId() == id {
+			return location.GetName()
+		}
+	}
+	return id
+}
+
+// roomName returns a room's name, falling back to its ID
+func (m *AdminModel) roomName(id string) string {
+	for _, room := range m.rooms {
+		if room.GetId() == id {
+			return room.GetName()
+		}
+	}
+	return id
+}
+
+// describeFilter summarises the active filter in one line
+func (m *AdminModel) describeFilter() string {
+	f := m.filter
+	var parts []string
+	if f.LocationID != "" {
+		parts = append(parts, m.locationName(f.LocationID))
+	}
+	if f.RoomID != "" {
+		parts = append(parts, "Room "+m.roomName(f.RoomID))
+	}
+	if f.User != "" {
+		parts = append(parts, fmt.Sprintf("User %q", f.User))
+	}
+	switch {
+	case !f.From.IsZero() && !f.To.IsZero():
+		if startOfDay(f.From).Equal(startOfDay(f.To)) {
+			parts = append(parts, f.From.Format("Mon Jan 2"))
+		} else {
+			parts = append(parts, f.From.Format("Jan 2")+" – "+f.To.Format("Jan 2"))
+		}
+	case !f.From.IsZero():
+		parts = append(parts, "From "+f.From.Format("Jan 2"))
+	case !f.To.IsZero():
+		parts = append(parts, "Until "+f.To.Format("Jan 2"))
+	}
+	if f.Status != "" {
+		parts = append(parts, string(f.Status))
+	}
+	if f.HasSetup {
+		parts = append(parts, "Setup requests")
+	}
+	return strings.Join(parts, " • ")
+}
+
+// renderFilterForm renders the booking filter form
+func (m *AdminModel) renderFilterForm() string {
+	form := m.filterForm
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Filter Bookings"))
+	b.WriteString("\n\n")
+
+	choice := func(value string) string {
+		return "‹ " + value + " ›"
+	}
+	location, room, status := "Any location", "Any room", "Any status"
+	if form.location != "" {
+		location = m.locationName(form.location)
+	}
+	if form.room != "" {
+		room = m.roomName(form.room)
+	}
+	if form.status != "" {
+		status = string(form.status)
+	}
+
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"Location", choice(location)},
+		{"Room", choice(room)},
+		{"User", form.user.View()},
+		{"From", form.from.View()},
+		{"To", form.to.View()},
+		{"Status", choice(status)},
+	}
+	for i, row := range rows {
+		cursor, labelStyle := "  ", m.styles.TextMuted
+		if i == form.field {
+			cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+			labelStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
+		}
+		b.WriteString(cursor + labelStyle.Render(fmt.Sprintf("%-9s", row.label)) + " " + row.value + "\n")
+	}
+
+	if form.error != "" {
+		b.WriteString("\n" + m.styles.TextError.Render(form.error) + "\n")
+	}
+
+	b.WriteString("\n")
+	k := m.filterFormKeyMap()
+	b.WriteString(renderFooter(m.styles, m.width, k.Next, k.Decrease, k.Submit, k.Cancel))
+	return b.String()
+}
+
+// parseFilterDate parses a typed date; an empty field means no bound
+func parseFilterDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.ParseInLocation(filterDateFormat, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("use YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+// formatFilterDate formats a filter bound for editing
+func formatFilterDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(filterDateFormat)
+}
+
+// startOfDay returns midnight at the start of t's day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
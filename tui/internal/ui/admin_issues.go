@@ -0,0 +1,359 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// issueQueueViews are what t cycles the queue through: what still needs
+// doing, then what was done with the rest
+var issueQueueViews = []milesapi.FeedbackStatus{"", milesapi.RESOLVED, milesapi.DISMISSED}
+
+// issueStatusPrompt asks for the comment sent to the reporter when an
+// issue changes status
+type issueStatusPrompt struct {
+	issue   milesapi.Feedback
+	status  milesapi.FeedbackStatus
+	comment textinput.Model
+	error   string
+}
+
+// AdminIssuesMsg contains the chosen location's issue queue
+type AdminIssuesMsg struct {
+	Issues []milesapi.Feedback
+}
+
+// AdminIssueUpdatedMsg is sent once an issue has changed status, so the
+// queue is reloaded
+type AdminIssueUpdatedMsg struct {
+	Notice string
+}
+
+// openIssues starts the issue queue at the location picker
+func (m *AdminModel) openIssues() tea.Cmd {
+	m.issuesLocation = nil
+	m.issues = nil
+	m.issuesView = 0
+	m.issuePrompt = nil
+	m.issuesNotice = ""
+	m.loading = true
+	return m.loadLocations()
+}
+
+// handleIssuesKeys handles keys in the issue queue
+func (m *AdminModel) handleIssuesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.issuesKeyMap()
+	if m.error != "" {
+		switch {
+		case key.Matches(msg, k.Back):
+			m.error = ""
+			if m.issuesLocation == nil {
+				m.mode = AdminMenuMode
+				m.cursor = 0
+			}
+		case key.Matches(msg, k.Refresh):
+			m.error = ""
+			m.loading = true
+			if m.issuesLocation == nil {
+				return m, m.loadLocations()
+			}
+			return m, m.loadIssues()
+		}
+		return m, nil
+	}
+
+	if m.issuePrompt != nil {
+		return m.handleIssuePromptKeys(msg)
+	}
+
+	// Pick the location first
+	if m.issuesLocation == nil {
+		switch {
+		case key.Matches(msg, k.Back):
+			m.mode = AdminMenuMode
+			m.cursor = 0
+		case key.Matches(msg, k.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, k.Down):
+			if m.cursor < len(m.locations)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, k.Top):
+			m.cursor = 0
+		case key.Matches(msg, k.Bottom):
+			m.cursor = len(m.locations) - 1
+		case key.Matches(msg, k.SelectLocation):
+			location := m.locations[m.cursor]
+			m.issuesLocation = &location
+			m.cursor = 0
+			m.loading = true
+			return m, m.loadIssues()
+		}
+		return m, nil
+	}
+
+	if m.layout.Scroll(msg) {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, k.Back):
+		m.issuesLocation = nil
+		m.issues = nil
+		m.issuesNotice = ""
+		m.cursor = 0
+		return m, nil
+
+	case key.Matches(msg, k.Refresh):
+		m.loading = true
+		return m, m.loadIssues()
+
+	case key.Matches(msg, k.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Down):
+		if m.cursor < len(m.issues)-1 {
+			m.cursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Top):
+		m.cursor = 0
+		return m, nil
+
+	case key.Matches(msg, k.Bottom):
+		m.cursor = max(0, len(m.issues)-1)
+		return m, nil
+
+	case key.Matches(msg, k.SwitchView):
+		m.issuesView = (m.issuesView + 1) % len(issueQueueViews)
+		m.issuesNotice = ""
+		m.cursor = 0
+		m.loading = true
+		return m, m.loadIssues()
+
+	case key.Matches(msg, k.Start):
+		return m, m.openIssuePrompt(milesapi.INPROGRESS)
+
+	case key.Matches(msg, k.Resolve):
+		return m, m.openIssuePrompt(milesapi.RESOLVED)
+
+	case key.Matches(msg, k.Dismiss):
+		return m, m.openIssuePrompt(milesapi.DISMISSED)
+
+	case key.Matches(msg, k.Reopen):
+		return m, m.openIssuePrompt(milesapi.OPEN)
+	}
+
+	return m, nil
+}
+
+// adminIssuesKeyMap lists the keys of the issue queue: picking a location,
+// then working through its issues
+type adminIssuesKeyMap struct {
+	listKeyMap
+	SelectLocation key.Binding
+	Start          key.Binding
+	Resolve        key.Binding
+	Dismiss        key.Binding
+	Reopen         key.Binding
+	SwitchView     key.Binding
+	scrollKeyMap
+	Refresh key.Binding
+	Back    key.Binding
+	Comment promptKeyMap
+}
+
+// issuesKeyMap returns the queue's keys. Status changes need an issue
+// under the cursor that can make that move.
+func (m *AdminModel) issuesKeyMap() adminIssuesKeyMap {
+	k := adminIssuesKeyMap{
+		listKeyMap:     newListKeyMap(),
+		SelectLocation: newKey("Enter", "Select", "enter"),
+		Start:          newKey("s", "Start", "s"),
+		Resolve:        newKey("v", "Resolve", "v"),
+		Dismiss:        newKey("x", "Dismiss", "x"),
+		Reopen:         newKey("o", "Reopen", "o"),
+		SwitchView:     newKey("t", "Show resolved", "t"),
+		scrollKeyMap:   newScrollKeyMap(),
+		Refresh:        newKey("r", "Refresh", "r", "f5"),
+		Back:           newKey("Esc", "Back", "esc", "q"),
+		Comment:        newPromptKeyMap("Update"),
+	}
+	if m.error != "" {
+		// The error screen only takes Esc and r
+		k.Back.SetKeys("esc")
+		k.Refresh.SetKeys("r")
+	}
+	if m.issuesLocation == nil {
+		k.Back.SetHelp("Esc", "Back to menu")
+		k.SelectLocation.SetEnabled(m.cursor < len(m.locations))
+		return k
+	}
+
+	switch issueQueueViews[(m.issuesView+1)%len(issueQueueViews)] {
+	case milesapi.DISMISSED:
+		k.SwitchView.SetHelp("t", "Show dismissed")
+	case "":
+		k.SwitchView.SetHelp("t", "Show open")
+	}
+
+	var status milesapi.FeedbackStatus
+	if m.cursor < len(m.issues) {
+		status = m.issues[m.cursor].Status
+	}
+	k.Start.SetEnabled(status == milesapi.OPEN)
+	k.Resolve.SetEnabled(status != "" && !status.Closed())
+	k.Dismiss.SetEnabled(status != "" && !status.Closed())
+	k.Reopen.SetEnabled(status.Closed())
+	if m.issuePrompt != nil {
+		k.Comment.Submit.SetEnabled(strings.TrimSpace(m.issuePrompt.comment.Value()) != "")
+	}
+	return k
+}
+
+// openIssuePrompt asks for the comment to move the issue under the cursor
+// to status with
+func (m *AdminModel) openIssuePrompt(status milesapi.FeedbackStatus) tea.Cmd {
+	comment := textinput.New()
+	comment.Placeholder = "e.g. technician booked for Tuesday"
+	comment.CharLimit = 500
+	comment.Width = 50
+	comment.Focus()
+
+	m.issuesNotice = ""
+	m.issuePrompt = &issueStatusPrompt{
+		issue:   m.issues[m.cursor],
+		status:  status,
+		comment: comment,
+	}
+	return textinput.Blink
+}
+
+// handleIssuePromptKeys handles keys while typing the status comment
+func (m *AdminModel) handleIssuePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := m.issuePrompt
+
+	k := m.issuesKeyMap().Comment
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.issuePrompt = nil
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		m.issuePrompt = nil
+		m.loading = true
+		return m, m.updateIssue(prompt.issue, prompt.status, strings.TrimSpace(prompt.comment.Value()))
+	}
+
+	var cmd tea.Cmd
+	prompt.comment, cmd = prompt.comment.Update(msg)
+	return m, cmd
+}
+
+// renderIssues renders the issue queue
+func (m *AdminModel) renderIssues() string {
+	k := m.issuesKeyMap()
+
+	if m.issuesLocation == nil {
+		header := m.styles.Title.Render("Room Issues") + "\n" +
+			m.styles.Subtitle.Render("Pick a location") + "\n"
+
+		body, top, bottom := m.styles.TextMuted.Render("No locations found."), -1, -1
+		if len(m.locations) > 0 {
+			items := make([]string, len(m.locations))
+			for i, location := range m.locations {
+				cursor := "  "
+				nameStyle := m.styles.TextBold
+				mutedStyle := m.styles.TextMuted
+				if i == m.cursor {
+					cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+					nameStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
+					mutedStyle = m.styles.TextMuted.Foreground(m.styles.Colors.Primary)
+				}
+				items[i] = lipgloss.JoinHorizontal(lipgloss.Left,
+					cursor,
+					nameStyle.Render(location.GetName()),
+					" • ",
+					mutedStyle.Render(location.GetCity()),
+				)
+			}
+			body, top, bottom = joinItems(items, "\n", m.cursor)
+		}
+
+		footer := "\n" + renderFooter(m.styles, m.width, k.Up, k.SelectLocation, k.Back)
+		return m.layout.Render(header, body, footer, top, bottom)
+	}
+
+	showing := "Open and in progress, most severe first"
+	empty := "Nothing to look at. Reports people file for rooms here show up in this queue."
+	if status := issueQueueViews[m.issuesView]; status != "" {
+		showing = status.Label() + ", most severe first"
+		empty = "No " + strings.ToLower(status.Label()) + " issues."
+	}
+	header := m.styles.Title.Render("Room Issues: "+m.issuesLocation.GetName()) + "\n" +
+		m.styles.Subtitle.Render(fmt.Sprintf("%s • %d issue(s)", showing, len(m.issues)))
+	if m.issuesNotice != "" {
+		header += "\n" + m.styles.TextSuccess.Render(m.issuesNotice)
+	}
+	header += "\n"
+
+	body, top, bottom := m.styles.TextMuted.Render(empty), -1, -1
+	if len(m.issues) > 0 {
+		items := make([]string, len(m.issues))
+		for i, issue := range m.issues {
+			items[i] = renderIssue(m.styles, issue, i == m.cursor, true)
+		}
+		body, top, bottom = joinItems(items, "\n\n", m.cursor)
+	}
+
+	footer := "\n" + renderFooter(m.styles, m.width, k.Up, k.Start, k.Resolve, k.Dismiss, k.Reopen,
+		k.SwitchView, k.PageUp, k.Refresh, k.Back)
+	if prompt := m.issuePrompt; prompt != nil {
+		footer = "\n" + m.styles.Text.Render(fmt.Sprintf("Mark %s as %s. Note for %s: ",
+			prompt.issue.Room.Name, strings.ToLower(prompt.status.Label()), prompt.issue.User.GetFirstName())) +
+			prompt.comment.View() + "\n" +
+			renderFooter(m.styles, m.width, k.Comment.Submit, k.Comment.Cancel)
+	}
+
+	return m.layout.Render(header, body, footer, top, bottom)
+}
+
+// loadIssues loads the chosen location's issues for the current view
+func (m *AdminModel) loadIssues() tea.Cmd {
+	ctx := m.requestCtx()
+	locationID := m.issuesLocation.GetId()
+	status := issueQueueViews[m.issuesView]
+	return func() tea.Msg {
+		issues, err := m.client.GetLocationIssues(ctx, locationID, status)
+		if err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		return AdminIssuesMsg{Issues: issues}
+	}
+}
+
+// updateIssue moves an issue to status and tells its reporter why
+func (m *AdminModel) updateIssue(issue milesapi.Feedback, status milesapi.FeedbackStatus, comment string) tea.Cmd {
+	ctx := m.requestCtx()
+	return func() tea.Msg {
+		if _, err := m.client.UpdateIssueStatus(ctx, issue.Id, status, comment); err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		return AdminIssueUpdatedMsg{Notice: fmt.Sprintf("✓ %s: %s is now %s; %s has been told",
+			issue.Room.Name, issue.Message, strings.ToLower(status.Label()), issue.User.FullName())}
+	}
+}
@@ -0,0 +1,608 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Fields of the approval rule form, top to bottom
+const (
+	ruleFieldName = iota
+	ruleFieldMax
+	ruleFieldOutsideCore
+	ruleFieldCoreStart
+	ruleFieldCoreEnd
+	ruleFieldEnabled
+	ruleFieldCount
+)
+
+// clockPattern matches a time of day typed as HH:MM
+var clockPattern = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`)
+
+// approvalRuleForm adds or edits an approval rule
+type approvalRuleForm struct {
+	ruleID      string // "" when adding
+	field       int
+	name        textinput.Model
+	max         textinput.Model
+	outsideCore bool
+	coreStart   textinput.Model
+	coreEnd     textinput.Model
+	enabled     bool
+	error       string
+}
+
+// AdminApprovalRulesMsg contains the chosen location's approval rules
+type AdminApprovalRulesMsg struct {
+	Rules *milesapi.ApprovalRulesResponse
+}
+
+// AdminApprovalRulesChangedMsg is sent once rules or the approval setting
+// have changed, so they are reloaded
+type AdminApprovalRulesChangedMsg struct {
+	Notice string
+}
+
+// openRules starts the approval rules editor at the location picker
+func (m *AdminModel) openRules() tea.Cmd {
+	m.rulesLocation = nil
+	m.rules = nil
+	m.ruleForm = nil
+	m.rulesNotice = ""
+	m.confirmDelete = false
+	m.loading = true
+	return m.loadLocations()
+}
+
+// handleRulesKeys handles keys in the approval rules editor
+func (m *AdminModel) handleRulesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.rulesKeyMap()
+	if m.error != "" {
+		switch {
+		case key.Matches(msg, k.Back):
+			m.error = ""
+			if m.rulesLocation == nil {
+				m.mode = AdminMenuMode
+				m.cursor = 0
+			}
+		case key.Matches(msg, k.Refresh):
+			m.error = ""
+			m.loading = true
+			if m.rulesLocation == nil {
+				return m, m.loadLocations()
+			}
+			return m, m.loadRules()
+		}
+		return m, nil
+	}
+
+	if m.ruleForm != nil {
+		return m.handleRuleFormKeys(msg)
+	}
+
+	// Pick the location first
+	if m.rulesLocation == nil {
+		switch {
+		case key.Matches(msg, k.Back):
+			m.mode = AdminMenuMode
+			m.cursor = 0
+		case key.Matches(msg, k.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, k.Down):
+			if m.cursor < len(m.locations)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, k.Top):
+			m.cursor = 0
+		case key.Matches(msg, k.Bottom):
+			m.cursor = len(m.locations) - 1
+		case key.Matches(msg, k.SelectLocation):
+			location := m.locations[m.cursor]
+			m.rulesLocation = &location
+			m.cursor = 0
+			m.loading = true
+			return m, m.loadRules()
+		}
+		return m, nil
+	}
+
+	if m.confirmDelete {
+		m.confirmDelete = false
+		if key.Matches(msg, k.ConfirmDelete) {
+			rule := m.rules.Rules[m.cursor]
+			m.loading = true
+			return m, m.deleteRule(rule)
+		}
+		return m, nil
+	}
+
+	var rules []milesapi.ApprovalRule
+	if m.rules != nil {
+		rules = m.rules.Rules
+	}
+
+	switch {
+	case key.Matches(msg, k.Back):
+		m.rulesLocation = nil
+		m.rules = nil
+		m.rulesNotice = ""
+		m.cursor = 0
+		return m, nil
+
+	case key.Matches(msg, k.Refresh):
+		m.loading = true
+		return m, m.loadRules()
+
+	case key.Matches(msg, k.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Down):
+		if m.cursor < len(rules)-1 {
+			m.cursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Top):
+		m.cursor = 0
+		return m, nil
+
+	case key.Matches(msg, k.Bottom):
+		m.cursor = max(0, len(rules)-1)
+		return m, nil
+
+	case key.Matches(msg, k.ToggleApproval):
+		m.loading = true
+		return m, m.setRequiresApproval(!m.rules.RequiresApproval)
+
+	case key.Matches(msg, k.Add):
+		return m, m.openRuleForm(nil)
+
+	case key.Matches(msg, k.Edit):
+		return m, m.openRuleForm(&rules[m.cursor])
+
+	case key.Matches(msg, k.Toggle):
+		rule := rules[m.cursor]
+		req := rule.Input()
+		enabled := !rule.Enabled
+		req.Enabled = &enabled
+		m.loading = true
+		return m, m.saveRule(rule.Id, req)
+
+	case key.Matches(msg, k.Delete):
+		m.confirmDelete = true
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// adminRulesKeyMap lists the keys of the approval rules editor: picking a
+// location, then its rules
+type adminRulesKeyMap struct {
+	listKeyMap
+	SelectLocation key.Binding
+	Add            key.Binding
+	Edit           key.Binding
+	Toggle         key.Binding
+	Delete         key.Binding
+	ConfirmDelete  key.Binding
+	ToggleApproval key.Binding
+	Refresh        key.Binding
+	Back           key.Binding
+}
+
+// rulesKeyMap returns the editor's keys. Rule actions need a rule under
+// the cursor.
+func (m *AdminModel) rulesKeyMap() adminRulesKeyMap {
+	k := adminRulesKeyMap{
+		listKeyMap:     newListKeyMap(),
+		SelectLocation: newKey("Enter", "Select", "enter"),
+		Add:            newKey("a", "Add", "a"),
+		Edit:           newKey("e/Enter", "Edit", "e", "enter"),
+		Toggle:         newKey("Space", "On/off", " "),
+		Delete:         newKey("d", "Delete", "d", "delete"),
+		ConfirmDelete:  newKey("y", "Delete", "y"),
+		ToggleApproval: newKey("t", "Turn approval on", "t"),
+		Refresh:        newKey("r", "Refresh", "r", "f5"),
+		Back:           newKey("Esc", "Back", "esc", "q"),
+	}
+	if m.error != "" {
+		// The error screen only takes Esc and r
+		k.Back.SetKeys("esc")
+		k.Refresh.SetKeys("r")
+	}
+	if m.rulesLocation == nil {
+		k.Back.SetHelp("Esc", "Back to menu")
+		k.SelectLocation.SetEnabled(m.cursor < len(m.locations))
+		return k
+	}
+
+	hasRule := m.rules != nil && m.cursor < len(m.rules.Rules)
+	k.Edit.SetEnabled(hasRule)
+	k.Toggle.SetEnabled(hasRule)
+	k.Delete.SetEnabled(hasRule)
+	k.ConfirmDelete.SetEnabled(hasRule)
+	if m.rules != nil && m.rules.RequiresApproval {
+		k.ToggleApproval.SetHelp("t", "Turn approval off")
+	}
+	k.ToggleApproval.SetEnabled(m.rules != nil)
+	return k
+}
+
+// openRuleForm starts adding a rule, or editing rule when it is set
+func (m *AdminModel) openRuleForm(rule *milesapi.ApprovalRule) tea.Cmd {
+	newInput := func(placeholder string, value string, width int) textinput.Model {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholder
+		input.CharLimit = 100
+		input.Width = width
+		input.SetValue(value)
+		return input
+	}
+
+	form := &approvalRuleForm{
+		name:        newInput("Short after hours", "", 30),
+		max:         newInput("any length, or e.g. 2h", "", 30),
+		outsideCore: true,
+		coreStart:   newInput("09:00", "09:00", 5),
+		coreEnd:     newInput("16:00", "16:00", 5),
+		enabled:     true,
+	}
+	if rule != nil {
+		form.ruleID = rule.Id
+		form.name.SetValue(rule.Name)
+		if rule.MaxDurationMinutes != nil {
+			form.max.SetValue(formatRuleDuration(*rule.MaxDurationMinutes))
+		}
+		form.outsideCore = rule.OutsideCoreHours
+		form.coreStart.SetValue(rule.CoreStart)
+		form.coreEnd.SetValue(rule.CoreEnd)
+		form.enabled = rule.Enabled
+	}
+	m.ruleForm = form
+	m.focusRuleField()
+	return textinput.Blink
+}
+
+// handleRuleFormKeys handles keys while the rule form is open
+func (m *AdminModel) handleRuleFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := m.ruleForm
+
+	k := m.ruleFormKeyMap()
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.ruleForm = nil
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		req, err := form.request()
+		if err != nil {
+			form.error = err.Error()
+			return m, nil
+		}
+		m.ruleForm = nil
+		m.loading = true
+		return m, m.saveRule(form.ruleID, req)
+
+	case key.Matches(msg, k.Next):
+		form.field = (form.field + 1) % ruleFieldCount
+		m.focusRuleField()
+		return m, nil
+
+	case key.Matches(msg, k.Prev):
+		form.field = (form.field + ruleFieldCount - 1) % ruleFieldCount
+		m.focusRuleField()
+		return m, nil
+
+	case key.Matches(msg, k.Decrease, k.Increase):
+		if form.field == ruleFieldOutsideCore {
+			form.outsideCore = !form.outsideCore
+		} else {
+			form.enabled = !form.enabled
+		}
+		return m, nil
+	}
+
+	// Everything else is typing into the focused text field
+	var cmd tea.Cmd
+	switch form.field {
+	case ruleFieldName:
+		form.name, cmd = form.name.Update(msg)
+	case ruleFieldMax:
+		form.max, cmd = form.max.Update(msg)
+	case ruleFieldCoreStart:
+		form.coreStart, cmd = form.coreStart.Update(msg)
+	case ruleFieldCoreEnd:
+		form.coreEnd, cmd = form.coreEnd.Update(msg)
+	}
+	form.error = ""
+	return m, cmd
+}
+
+// ruleFormKeyMap returns the rule form's keys. ←→ and Space flip the
+// yes/no fields; in the text fields they are typed.
+func (m *AdminModel) ruleFormKeyMap() formKeyMap {
+	k := newFormKeyMap("Save")
+	k.Next.SetHelp("Tab/↑↓", "Field")
+	k.Decrease.SetKeys("left", " ")
+	k.Decrease.SetHelp("←/→/Space", "Toggle")
+	field := m.ruleForm.field
+	onToggle := field == ruleFieldOutsideCore || field == ruleFieldEnabled
+	k.Decrease.SetEnabled(onToggle)
+	k.Increase.SetEnabled(onToggle)
+	return k
+}
+
+// focusRuleField moves the text cursor to the focused field, if it takes text
+func (m *AdminModel) focusRuleField() {
+	form := m.ruleForm
+	for field, input := range map[int]*textinput.Model{
+		ruleFieldName:      &form.name,
+		ruleFieldMax:       &form.max,
+		ruleFieldCoreStart: &form.coreStart,
+		ruleFieldCoreEnd:   &form.coreEnd,
+	} {
+		if field == form.field {
+			input.Focus()
+		} else {
+			input.Blur()
+		}
+	}
+}
+
+// request builds the rule the form describes
+func (f *approvalRuleForm) request() (milesapi.ApprovalRuleInput, error) {
+	outsideCore, enabled := f.outsideCore, f.enabled
+	coreStart := strings.TrimSpace(f.coreStart.Value())
+	coreEnd := strings.TrimSpace(f.coreEnd.Value())
+	req := milesapi.ApprovalRuleInput{
+		Name:             strings.TrimSpace(f.name.Value()),
+		OutsideCoreHours: &outsideCore,
+		CoreStart:        &coreStart,
+		CoreEnd:          &coreEnd,
+		Enabled:          &enabled,
+	}
+	if req.Name == "" {
+		return req, fmt.Errorf("name is required")
+	}
+
+	if text := strings.TrimSpace(f.max.Value()); text != "" {
+		d, err := time.ParseDuration(text)
+		if err != nil || d < time.Minute {
+			return req, fmt.Errorf("max length: use e.g. 90m or 2h, or leave empty for any")
+		}
+		minutes := int(d.Round(time.Minute).Minutes())
+		req.MaxDurationMinutes = &minutes
+	}
+
+	if !clockPattern.MatchString(coreStart) || !clockPattern.MatchString(coreEnd) {
+		return req, fmt.Errorf("core hours: use HH:MM, e.g. 09:00")
+	}
+	if coreStart >= coreEnd {
+		return req, fmt.Errorf("core hours must end after they start")
+	}
+	return req, nil
+}
+
+// formatRuleDuration formats minutes as the form takes them, e.g. 1h30m
+func formatRuleDuration(minutes int) string {
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+	}
+}
+
+// renderRules renders the approval rules editor
+func (m *AdminModel) renderRules() string {
+	if m.ruleForm != nil {
+		return m.renderRuleForm()
+	}
+
+	if m.rulesLocation == nil {
+		header := m.styles.Title.Render("Approval Rules") + "\n" +
+			m.styles.Subtitle.Render("Pick a location") + "\n"
+
+		body, top, bottom := m.styles.TextMuted.Render("No locations found."), -1, -1
+		if len(m.locations) > 0 {
+			items := make([]string, len(m.locations))
+			for i, location := range m.locations {
+				cursor := "  "
+				nameStyle := m.styles.TextBold
+				mutedStyle := m.styles.TextMuted
+				if i == m.cursor {
+					cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+					nameStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
+					mutedStyle = m.styles.TextMuted.Foreground(m.styles.Colors.Primary)
+				}
+				items[i] = lipgloss.JoinHorizontal(lipgloss.Left,
+					cursor,
+					nameStyle.Render(location.GetName()),
+					" • ",
+					mutedStyle.Render(location.GetCity()),
+				)
+			}
+			body, top, bottom = joinItems(items, "\n", m.cursor)
+		}
+
+		k := m.rulesKeyMap()
+		footer := "\n" + renderFooter(m.styles, m.width, k.Up, k.SelectLocation, k.Back)
+		return m.layout.Render(header, body, footer, top, bottom)
+	}
+
+	header := m.styles.Title.Render("Approval Rules: "+m.rulesLocation.GetName()) + "\n"
+	if m.rules != nil && m.rules.RequiresApproval {
+		header += m.styles.Subtitle.Render("Approval required: bookings no enabled rule covers wait for a manager")
+	} else {
+		header += m.styles.Subtitle.Render("Approval not required: every booking is confirmed, so rules have no effect")
+	}
+	if m.rulesNotice != "" {
+		header += "\n" + m.styles.TextSuccess.Render(m.rulesNotice)
+	}
+	header += "\n"
+
+	body, top, bottom := m.styles.TextMuted.Render("No approval rules. Press a to add one."), -1, -1
+	if m.rules != nil && len(m.rules.Rules) > 0 {
+		items := make([]string, len(m.rules.Rules))
+		for i, rule := range m.rules.Rules {
+			cursor := "  "
+			nameStyle := m.styles.TextBold
+			mutedStyle := m.styles.TextMuted
+			if i == m.cursor {
+				cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+				nameStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
+				mutedStyle = m.styles.TextMuted.Foreground(m.styles.Colors.Primary)
+			}
+			badge := m.styles.BadgeSuccess.Render("ON")
+			if !rule.Enabled {
+				badge = m.styles.BadgeError.Render("OFF")
+			}
+			items[i] = lipgloss.JoinHorizontal(lipgloss.Left,
+				cursor,
+				nameStyle.Render(rule.Name),
+				"  ",
+				badge,
+			) + "\n" + "  " + mutedStyle.Render("Auto-approves "+milesapi.DescribeRule(rule))
+		}
+		body, top, bottom = joinItems(items, "\n\n", m.cursor)
+	}
+
+	k := m.rulesKeyMap()
+	footer := "\n" + renderFooter(m.styles, m.width, k.Add, k.Edit, k.Toggle, k.Delete, k.ToggleApproval,
+		k.Refresh, k.Back)
+	if m.confirmDelete && m.cursor < len(m.rules.Rules) {
+		footer = "\n" + m.styles.TextError.Render(fmt.Sprintf("Delete %q? y: Delete • any other key: Keep", m.rules.Rules[m.cursor].Name))
+	}
+
+	return m.layout.Render(header, body, footer, top, bottom)
+}
+
+// renderRuleForm renders the rule form
+func (m *AdminModel) renderRuleForm() string {
+	form := m.ruleForm
+	var b strings.Builder
+
+	title := "Add Approval Rule"
+	if form.ruleID != "" {
+		title = "Edit Approval Rule"
+	}
+	b.WriteString(m.styles.Title.Render(title))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Subtitle.Render(m.rulesLocation.GetName() + ": bookings meeting every condition are confirmed straight away"))
+	b.WriteString("\n\n")
+
+	onOff := func(on bool) string {
+		if on {
+			return "yes"
+		}
+		return "no"
+	}
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"Name", form.name.View()},
+		{"Max length", form.max.View()},
+		{"Outside core", "‹ " + onOff(form.outsideCore) + " ›"},
+		{"Core from", form.coreStart.View()},
+		{"Core until", form.coreEnd.View()},
+		{"Enabled", "‹ " + onOff(form.enabled) + " ›"},
+	}
+	for i, row := range rows {
+		label := m.styles.TextMuted.Render(fmt.Sprintf("  %-13s", row.label))
+		if i == form.field {
+			label = m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render(fmt.Sprintf("> %-13s", row.label))
+		}
+		b.WriteString(label + row.value + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.TextMuted.Render("Core hours are weekdays in the location's time zone."))
+	if form.error != "" {
+		b.WriteString("\n")
+		b.WriteString(m.styles.TextError.Render("✗ " + form.error))
+	}
+	b.WriteString("\n\n")
+	k := m.ruleFormKeyMap()
+	b.WriteString(renderFooter(m.styles, m.width, k.Next, k.Decrease, k.Submit, k.Cancel))
+
+	return b.String()
+}
+
+// loadRules loads the chosen location's approval rules
+func (m *AdminModel) loadRules() tea.Cmd {
+	ctx := m.requestCtx()
+	locationID := m.rulesLocation.GetId()
+	return func() tea.Msg {
+		rules, err := m.client.GetApprovalRules(ctx, locationID)
+		if err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		return AdminApprovalRulesMsg{Rules: rules}
+	}
+}
+
+// saveRule creates or replaces a rule
+func (m *AdminModel) saveRule(ruleID string, req milesapi.ApprovalRuleInput) tea.Cmd {
+	ctx := m.requestCtx()
+	locationID := m.rulesLocation.GetId()
+	return func() tea.Msg {
+		approved, err := m.client.SaveApprovalRule(ctx, locationID, ruleID, req)
+		if err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		notice := "✓ Saved " + req.Name
+		if approved > 0 {
+			notice += fmt.Sprintf(" and confirmed %d pending booking(s) it covers", approved)
+		}
+		return AdminApprovalRulesChangedMsg{Notice: notice}
+	}
+}
+
+// deleteRule removes a rule
+func (m *AdminModel) deleteRule(rule milesapi.ApprovalRule) tea.Cmd {
+	ctx := m.requestCtx()
+	locationID := m.rulesLocation.GetId()
+	return func() tea.Msg {
+		if err := m.client.DeleteApprovalRule(ctx, locationID, rule.Id); err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		return AdminApprovalRulesChangedMsg{Notice: "✓ Deleted " + rule.Name}
+	}
+}
+
+// setRequiresApproval turns approval at the chosen location on or off
+func (m *AdminModel) setRequiresApproval(required bool) tea.Cmd {
+	ctx := m.requestCtx()
+	locationID := m.rulesLocation.GetId()
+	return func() tea.Msg {
+		if err := m.client.SetRequiresApproval(ctx, locationID, required); err != nil {
+			return AdminErrorMsg{Error: err.Error()}
+		}
+
+		if required {
+			return AdminApprovalRulesChangedMsg{Notice: "✓ New bookings now need approval unless a rule covers them"}
+		}
+		return AdminApprovalRulesChangedMsg{Notice: "✓ New bookings are now confirmed straight away"}
+	}
+}
@@ -1,11 +1,18 @@
 package ui
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
+	"github.com/miles/booking-tui/internal/config"
 	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/reminder"
 )
 
 // ViewState represents the current view
@@ -21,11 +28,18 @@ const (
 	ViewBookingForm
 	ViewSearch
 	ViewAdmin
+	ViewSettings
+	ViewActivity
+	ViewDesks
+	ViewApprovals
+	ViewIssues
 	ViewHelp
 )
 
 // App is the main application model
 type App struct {
+	requests
+
 	// State
 	state        ViewState
 	width        int
@@ -33,13 +47,84 @@ type App struct {
 	ready        bool
 	authenticated bool
 
+	// Browsing without logging in: public views only, no booking
+	guest bool
+
+	// Server unreachable: the offline banner is up and reconnection is
+	// retried. offlineShell is set when a saved session was opened read-only.
+	offline          bool
+	offlineShell     bool
+	offlineSince     time.Time
+	reconnectGen     int
+	reconnectAttempt int
+
+	// Booking to open once signed in, from a link the app was started with
+	linkedBooking string
+
 	// API Client
 	client *api.Client
 
 	// User
-	user  *models.User
+	user  *milesapi.User
 	token string
 
+	// Preferences from ~/.miles-tui.json, reloaded when the file changes.
+	// configWatcher is nil when the file can't be watched; configProblem
+	// is what was wrong with it at startup.
+	cfg           *config.Config
+	configWatcher *config.Watcher
+	configProblem error
+
+	// Set when settings change so the dashboard is rebuilt on next visit
+	dashboardStale bool
+
+	// Impersonated user (ADMIN only), nil when acting as ourselves
+	impersonating *milesapi.User
+
+	// Toasts shown above every view, oldest first
+	toasts   []toast
+	toastSeq int
+
+	// Activity polling for toasts. activityGen changes whenever polling
+	// restarts, so responses and ticks from before are dropped.
+	activityGen    int
+	activityCursor string
+	activitySeen   map[string]bool
+
+	// Bookings waiting for my approval, counted in the status bar and
+	// polled along with activity so new requests are toasted
+	pendingApprovals int
+	approvalsSeen    map[string]bool
+
+	// Wrap-up warning near the end of my booking when someone else has the
+	// room next. handoverGen works like activityGen.
+	handoverGen int
+
+	// Booking changes streamed over gRPC, when the config asks for them.
+	// watchGen works like activityGen; watchCancel closes the stream.
+	watchGen    int
+	watchCancel context.CancelFunc
+	handover        *handoverNotice
+
+	// Reminders already shown, by key, and what works them out while
+	// milesd isn't giving them
+	reminded  map[string]bool
+	reminders *reminder.Scheduler
+
+	// Set once displayed times follow the server's clock
+	clockChecked bool
+
+	// Newer release found at startup, shown in the footer
+	latestVersion string
+
+	// What is wrong with the server's API version, found at startup and
+	// shown in the footer instead of the release hint
+	versionProblem string
+
+	// Optional features the server has on, asked for at startup. Empty
+	// means everything is on.
+	features milesapi.Features
+
 	// Views
 	login       tea.Model
 	dashboard   tea.Model
@@ -50,6 +135,11 @@ type App struct {
 	bookingForm tea.Model
 	search      tea.Model
 	admin       tea.Model
+	settings    tea.Model
+	activity    tea.Model
+	desks       tea.Model
+	approvals   tea.Model
+	issues      tea.Model
 
 	// UI Components
 	viewport viewport.Model
@@ -58,15 +148,24 @@ type App struct {
 
 // NewApp creates a new application instance
 func NewApp() *App {
-	client := api.NewClient("http://localhost:3000/api")
+	client := api.NewClient("http://localhost:3000")
 	styles := styles.DefaultStyles()
 
+	// A broken config file falls back to defaults rather than blocking startup
+	cfg, err := config.Load()
+
 	app := &App{
 		state:         ViewLogin,
 		client:        client,
 		styles:        styles,
+		cfg:           cfg,
 		authenticated: false,
 	}
+	if themeErr := app.applyConfig(); err == nil {
+		err = themeErr
+	}
+	app.configProblem = err
+	app.configWatcher = watchConfig(cfg)
 
 	// Initialize login view
 	app.login = NewLoginModel(client, styles)
@@ -77,47 +176,154 @@ func NewApp() *App {
 // Init initializes the application
 func (a *App) Init() tea.Cmd {
 	if a.login != nil {
-		return a.login.Init()
+		return tea.Batch(a.login.Init(), checkForUpdate(), a.probeServer(), a.checkServerVersion(), a.loadFeatures(), a.startConfigWatch())
 	}
-	return nil
+	return tea.Batch(checkForUpdate(), a.checkServerVersion(), a.loadFeatures(), a.startConfigWatch())
 }
 
 // Update handles messages and updates the model
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer a.leaveView(a.state)
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
 		a.ready = true
-		// Propagate window size to current view
-		return a, a.updateCurrentView(msg)
+		// Cached views get the new size too, so they fit when switched back to
+		return a, a.resizeViews()
+
+	case updateAvailableMsg:
+		a.latestVersion = msg.version
+		return a, a.resizeViews()
+
+	case serverVersionMsg:
+		a.versionProblem = versionAdvice(msg.err)
+		return a, a.resizeViews()
+
+	case featuresLoadedMsg:
+		a.features = msg.features
+		return a, nil
+
+	case serverProbedMsg:
+		if msg.err != nil && !a.authenticated && !a.guest {
+			return a, a.goOffline()
+		}
+		if msg.err == nil && !a.authenticated && !a.guest {
+			return a, tea.Batch(a.checkClockSkew(), a.resumeSession())
+		}
+		return a, a.checkClockSkew()
+
+	case reconnectTickMsg:
+		if msg.gen != a.reconnectGen {
+			return a, nil
+		}
+		return a, a.reconnect()
+
+	case reconnectFailedMsg:
+		if msg.gen != a.reconnectGen {
+			return a, nil
+		}
+		a.reconnectAttempt++
+		return a, a.scheduleReconnect()
+
+	case reconnectedMsg:
+		if msg.gen != a.reconnectGen {
+			return a, nil
+		}
+		// The server may have been reconfigured while it was away
+		return a, tea.Batch(a.backOnline(msg.user), a.loadFeatures())
 
 	case LoginSuccessMsg:
+		// Logging in proves the server is back
+		if a.offline {
+			a.offline = false
+			a.reconnectGen++
+		}
 		// User successfully logged in
 		a.authenticated = true
 		a.user = msg.User
 		a.token = msg.Token
 		a.state = ViewDashboard
 		// Initialize dashboard
-		a.dashboard = NewDashboardModel(a.client, a.user, a.styles)
-		return a, a.dashboard.Init()
+		a.dashboard = a.newDashboardModel()
+		return a, tea.Batch(a.initView(a.dashboard), a.startActivityPolling(), a.startHandoverChecks(), a.startBookingWatch(), a.checkClockSkew(), a.openLinkedBooking())
+
+	case sessionResumedMsg:
+		// Signing in by hand or as a guest meanwhile wins
+		if a.authenticated || a.guest || a.state != ViewLogin {
+			return a, nil
+		}
+		return a.Update(LoginSuccessMsg{User: msg.user, Token: msg.token})
+
+	case GuestLoginMsg:
+		a.guest = true
+		a.state = ViewLocations
+		a.locations = NewLocationsModel(a.client, a.styles)
+		return a, a.initView(a.locations)
 
 	case LocationSelectMsg:
 		// User selected a location, navigate to rooms view
 		a.state = ViewRooms
-		a.rooms = NewRoomsModel(a.client, a.styles, &msg.Location)
-		return a, a.rooms.Init()
+		a.rooms = a.newRoomsModel(&msg.Location)
+		return a, a.initView(a.rooms)
 
 	case RoomSelectMsg:
+		if a.offlineShell {
+			return a, a.showToast("Booking needs the server; it's offline", true)
+		}
+		// Guests can't book; show when the room is taken instead
+		if a.guest {
+			a.state = ViewCalendar
+			a.calendar = NewGuestCalendarModel(a.client, a.styles, &msg.Room)
+			return a, a.initView(a.calendar)
+		}
 		// User selected a room, navigate to booking form
 		a.state = ViewBookingForm
 		a.bookingForm = NewBookingFormModel(a.client, a.styles, &msg.Room)
-		return a, a.bookingForm.Init()
+		return a, a.initView(a.bookingForm)
+
+	case BookRoomAtMsg:
+		if a.offlineShell {
+			return a, a.showToast("Booking needs the server; it's offline", true)
+		}
+		a.state = ViewBookingForm
+		a.bookingForm = NewBookingFormAtModel(a.client, a.styles, msg.Room, msg.Start, msg.End)
+		return a, a.initView(a.bookingForm)
+
+	case ReportIssueMsg:
+		a.state = ViewIssues
+		issues, ok := a.issues.(*IssuesModel)
+		if !ok {
+			issues = NewIssuesModel(a.client, a.styles)
+			a.issues = issues
+			return a, tea.Batch(a.initView(issues), issues.openReport(msg.Room))
+		}
+		return a, issues.openReport(msg.Room)
+
+	case OpenCalendarDayMsg:
+		a.state = ViewCalendar
+		calendar, ok := a.calendar.(*CalendarModel)
+		if !ok {
+			calendar = NewCalendarModel(a.client, a.styles)
+			a.calendar = calendar
+			calendar.showDay(msg.Date)
+			return a, a.initView(calendar)
+		}
+		return a, calendar.showDay(msg.Date)
+
+	case EditBookingMsg:
+		a.state = ViewBookingForm
+		a.bookingForm = NewEditBookingFormModel(a.client, a.styles, msg.Booking)
+		return a, a.initView(a.bookingForm)
 
 	case BookingFormCompleteMsg:
 		// Booking created successfully, reload bookings and go back to list
 		a.state = ViewBookings
 		a.bookingForm = nil
+		// An edited booking goes back to its details, as changed
+		if bookings, ok := a.bookings.(*BookingsModel); ok && msg.Edited && msg.Booking != nil {
+			return a, tea.Batch(bookings.Init(), bookings.showDetails(*msg.Booking))
+		}
 		if a.bookings != nil {
 			// Reload bookings
 			return a, a.bookings.Init()
@@ -130,60 +336,280 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.bookingForm = nil
 		return a, nil
 
+	case DashboardRefreshTickMsg:
+		// Ticks from a replaced dashboard stop here
+		if a.dashboard == nil || tea.Model(msg.dashboard) != a.dashboard {
+			return a, nil
+		}
+		// Only refresh while the dashboard is on screen; responses for
+		// other views would be dropped
+		if a.state != ViewDashboard {
+			return a, msg.dashboard.scheduleRefresh()
+		}
+		// Otherwise the dashboard handles it below
+
+	case configReloadedMsg:
+		return a, a.reloadConfig(msg)
+
+	case SettingsChangedMsg:
+		a.dashboardStale = true
+		// Reopen the rooms view at the newly chosen office
+		if rooms, ok := a.rooms.(*RoomsModel); ok && rooms.startAtOffice && rooms.officeLocationID != a.cfg.OfficeLocationID {
+			a.rooms = nil
+		}
+		return a, nil
+
+	case ToastMsg:
+		return a, a.addToast(toast{text: msg.Text, error: msg.Error, actions: msg.Actions})
+
+	case OpenBookingMsg:
+		return a, a.openBooking(msg.Booking)
+
+	case ExtendBookingMsg:
+		return a, extendBooking(a.requestCtx(), a.client, msg.Booking, msg.By)
+
+	case toastExpiredMsg:
+		for i, t := range a.toasts {
+			if t.id == msg.id {
+				a.toasts = append(a.toasts[:i], a.toasts[i+1:]...)
+				return a, a.resizeViews()
+			}
+		}
+		return a, nil
+
+	case SubscriptionsChangedMsg:
+		var cmds []tea.Cmd
+		if a.state != ViewActivity {
+			cmds = append(cmds, a.showToast(msg.Notice, false))
+		}
+		if a.activity != nil {
+			var cmd tea.Cmd
+			a.activity, cmd = a.activity.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		return a, tea.Batch(cmds...)
+
+	case activityTickMsg:
+		if msg.gen != a.activityGen {
+			return a, nil
+		}
+		return a, tea.Batch(a.pollActivity(), a.pollApprovals())
+
+	case approvalsPolledMsg:
+		if msg.gen != a.activityGen {
+			return a, nil
+		}
+		return a, a.handleApprovals(msg)
+
+	case ApprovalsDataMsg:
+		// Keep the badge in step; the approvals view handles it below
+		if cmd := a.approvalsCount(msg.Bookings); cmd != nil {
+			return a, tea.Batch(cmd, a.updateCurrentView(msg))
+		}
+
+	case activityPolledMsg:
+		if msg.gen != a.activityGen {
+			return a, nil
+		}
+		return a, tea.Batch(a.handleActivity(msg), a.scheduleActivityPoll())
+
+	case bookingWatchMsg:
+		if msg.gen != a.watchGen {
+			return a, nil
+		}
+		return a, a.handleBookingWatch(msg)
+
+	case bookingWatchTickMsg:
+		if msg.gen != a.watchGen {
+			return a, nil
+		}
+		return a, a.startBookingWatch()
+
+	case handoverTickMsg:
+		if msg.gen != a.handoverGen {
+			return a, nil
+		}
+		return a, a.checkHandover()
+
+	case remindersDueMsg:
+		if msg.gen != a.handoverGen {
+			return a, nil
+		}
+		return a, a.showReminders(msg.reminders)
+
+	case ImpersonateMsg:
+		a.client.SetImpersonate(msg.Email)
+		return a, a.verifyImpersonation()
+
+	case ImpersonationStartedMsg:
+		a.impersonating = msg.User
+		return a, a.resetViews()
+
+	case ImpersonationFailedMsg:
+		a.client.SetImpersonate("")
+		a.impersonating = nil
+		if admin, ok := a.admin.(*AdminModel); ok {
+			admin.error = msg.Error
+		}
+		return a, nil
+
 	case tea.KeyMsg:
-		// Global shortcuts
+		// Retrying works even from the login form's inputs
+		if a.offline {
+			if handled, cmd := a.handleOfflineKey(msg.String()); handled {
+				return a, cmd
+			}
+		}
+
+		// Text inputs get every key except the quit shortcut
+		if capturer, ok := a.currentView().(inputCapturer); ok && capturer.CapturingInput() && msg.String() != "ctrl+c" {
+			break
+		}
+
+		// Quick replies on a toast win over the view shortcuts while it's up
 		if a.authenticated {
-			switch msg.String() {
+			if handled, cmd := a.handleToastKey(msg.String()); handled {
+				return a, cmd
+			}
+		}
+
+		// Guests only get the public views
+		if a.guest {
+			switch a.cfg.Shortcut(msg.String()) {
+			case "i":
+				return a, a.endGuest()
+			case "1", "5", "6", "7", "8", "0", "A", "R", "ctrl+x":
+				return a, nil
+			}
+		}
+
+		// Global shortcuts
+		if a.authenticated || a.guest || a.offlineShell {
+			switch a.cfg.Shortcut(msg.String()) {
+			case "ctrl+x":
+				if a.impersonating != nil {
+					a.client.SetImpersonate("")
+					a.impersonating = nil
+					return a, a.resetViews()
+				}
+				return a, nil
 			case "ctrl+c", "q":
 				return a, tea.Quit
+			case "L":
+				if a.authenticated || a.offlineShell {
+					return a, a.logout()
+				}
+				return a, nil
 			case "1":
 				a.state = ViewDashboard
+				if a.dashboardStale {
+					a.dashboardStale = false
+					a.dashboard = a.newDashboardModel()
+					return a, a.initView(a.dashboard)
+				}
 				return a, nil
 			case "2":
 				a.state = ViewLocations
 				// Initialize locations view if not already done
 				if a.locations == nil {
 					a.locations = NewLocationsModel(a.client, a.styles)
-					return a, a.locations.Init()
+					return a, a.initView(a.locations)
 				}
 				return a, nil
 			case "3":
 				a.state = ViewRooms
 				// Initialize rooms view if not already done (no location filter)
 				if a.rooms == nil {
-					a.rooms = NewRoomsModel(a.client, a.styles, nil)
-					return a, a.rooms.Init()
+					a.rooms = a.newRoomsModel(nil)
+					return a, a.initView(a.rooms)
 				}
 				return a, nil
 			case "4":
 				a.state = ViewCalendar
 				// Initialize calendar view if not already done
 				if a.calendar == nil {
-					a.calendar = NewCalendarModel(a.client, a.styles)
-					return a, a.calendar.Init()
+					if a.guest {
+						a.calendar = NewGuestCalendarModel(a.client, a.styles, nil)
+					} else {
+						a.calendar = NewCalendarModel(a.client, a.styles)
+					}
+					return a, a.initView(a.calendar)
 				}
 				return a, nil
 			case "5":
 				a.state = ViewBookings
 				// Initialize bookings view if not already done
 				if a.bookings == nil {
-					a.bookings = NewBookingsModel(a.client, a.styles)
-					return a, a.bookings.Init()
+					a.bookings = NewBookingsModel(a.client, a.cfg, a.styles)
+					return a, a.initView(a.bookings)
 				}
 				return a, nil
 			case "6":
 				a.state = ViewSearch
+				// Initialize search view if not already done
+				if a.search == nil {
+					a.search = NewSearchModel(a.client, a.cfg, a.styles)
+					return a, a.initView(a.search)
+				}
+				return a, nil
+			case "7":
+				a.state = ViewSettings
+				// Initialize settings view if not already done
+				if a.settings == nil {
+					a.settings = NewSettingsModel(a.client, a.cfg, a.styles)
+					return a, a.initView(a.settings)
+				}
+				return a, nil
+			case "8":
+				a.state = ViewActivity
+				// Initialize activity view if not already done
+				if a.activity == nil {
+					a.activity = NewActivityModel(a.client, a.styles)
+					return a, a.initView(a.activity)
+				}
+				return a, nil
+			case "9":
+				if ok, cmd := a.requireFeature(milesapi.FeatureDesks); !ok {
+					return a, cmd
+				}
+				a.state = ViewDesks
+				// Initialize desks view if not already done
+				if a.desks == nil {
+					a.desks = NewDesksModel(a.client, a.styles, a.guest)
+					return a, a.initView(a.desks)
+				}
 				return a, nil
 			case "0":
-				if a.user.Role == models.RoleAdmin || a.user.Role == models.RoleManager {
+				if a.effectiveRole().Allows(milesapi.MANAGER) {
 					a.state = ViewAdmin
 					// Initialize admin view if not already done
 					if a.admin == nil {
-						a.admin = NewAdminModel(a.client, a.user, a.styles)
-						return a, a.admin.Init()
+						a.admin = NewAdminModel(a.client, a.effectiveUser(), a.effectiveRole(), a.styles)
+						return a, a.initView(a.admin)
+					}
+				}
+				return a, nil
+			case "A":
+				if ok, cmd := a.requireFeature(milesapi.FeatureApprovals); !ok {
+					return a, cmd
+				}
+				if a.effectiveRole().Allows(milesapi.MANAGER) {
+					a.state = ViewApprovals
+					// Initialize approvals view if not already done
+					if a.approvals == nil {
+						a.approvals = NewApprovalsModel(a.client, a.styles)
+						return a, a.initView(a.approvals)
 					}
 				}
 				return a, nil
+			case "R":
+				a.state = ViewIssues
+				// Initialize reports view if not already done
+				if a.issues == nil {
+					a.issues = NewIssuesModel(a.client, a.styles)
+					return a, a.initView(a.issues)
+				}
+				return a, nil
 			case "?", "f1":
 				a.state = ViewHelp
 				return a, nil
@@ -202,7 +628,30 @@ func (a *App) View() string {
 		return "Initializing Miles Booking System..."
 	}
 
-	// Render current view
+	view := a.renderView()
+	if toasts := a.renderToasts(); toasts != "" {
+		view = toasts + "\n\n" + view
+	}
+	if badge := a.renderApprovalsBadge(); badge != "" {
+		view += "\n" + badge
+	}
+	if footer := a.renderUpdateFooter(); footer != "" {
+		view += "\n" + footer
+	}
+
+	if a.impersonating != nil {
+		view = a.renderImpersonationBanner() + "\n\n" + view
+	} else if a.guest {
+		view = a.renderGuestBanner() + "\n\n" + view
+	}
+	if a.offline {
+		view = a.renderOfflineBanner() + "\n\n" + view
+	}
+	return view
+}
+
+// renderView renders the current view
+func (a *App) renderView() string {
 	switch a.state {
 	case ViewLogin:
 		return a.renderLogin()
@@ -222,6 +671,16 @@ func (a *App) View() string {
 		return a.renderSearch()
 	case ViewAdmin:
 		return a.renderAdmin()
+	case ViewSettings:
+		return a.renderSettings()
+	case ViewActivity:
+		return a.renderActivity()
+	case ViewDesks:
+		return a.renderDesks()
+	case ViewApprovals:
+		return a.renderApprovals()
+	case ViewIssues:
+		return a.renderIssues()
 	case ViewHelp:
 		return a.renderHelp()
 	default:
@@ -229,6 +688,270 @@ func (a *App) View() string {
 	}
 }
 
+// renderImpersonationBanner renders the warning shown on every screen while impersonating
+func (a *App) renderImpersonationBanner() string {
+	text := fmt.Sprintf("⚠ IMPERSONATING %s (%s) • Ctrl+X to stop",
+		a.impersonating.FullName(), a.impersonating.GetEmail())
+
+	banner := a.styles.BadgeWarning.Margin(0)
+	if a.width > 0 {
+		banner = banner.Width(a.width)
+	}
+	return banner.Render(text)
+}
+
+// renderGuestBanner renders the call to action shown on every screen in guest mode
+func (a *App) renderGuestBanner() string {
+	text := "GUEST • Read-only view of rooms and availability • Press i to log in and book"
+
+	banner := a.styles.BadgeInfo.Margin(0)
+	if a.width > 0 {
+		banner = banner.Width(a.width)
+	}
+	return banner.Render(text)
+}
+
+// newRoomsModel creates the rooms view, read-only for guests
+func (a *App) newRoomsModel(location *milesapi.Location) *RoomsModel {
+	rooms := NewRoomsModel(a.client, a.styles, location)
+	rooms.readOnly = a.guest || a.offlineShell
+	rooms.offline = a.offlineShell
+	if location == nil {
+		rooms.startAtOffice = true
+		rooms.officeLocationID = a.cfg.OfficeLocationID
+	}
+	return rooms
+}
+
+// endGuest leaves guest mode and returns to the login screen
+func (a *App) endGuest() tea.Cmd {
+	a.guest = false
+	a.locations = nil
+	a.rooms = nil
+	a.calendar = nil
+	a.desks = nil
+
+	a.state = ViewLogin
+	a.login = NewLoginModel(a.client, a.styles)
+	return a.initView(a.login)
+}
+
+// ImpersonationStartedMsg is sent once the server accepts an impersonation
+type ImpersonationStartedMsg struct {
+	User *milesapi.User
+}
+
+// ImpersonationFailedMsg is sent when the server rejects an impersonation
+type ImpersonationFailedMsg struct {
+	Error string
+}
+
+// verifyImpersonation asks the server who we are now acting as
+func (a *App) verifyImpersonation() tea.Cmd {
+	ctx := a.requestCtx()
+	client := a.client
+	return func() tea.Msg {
+		user, err := client.GetCurrentUser(ctx)
+		if err != nil {
+			return ImpersonationFailedMsg{Error: fmt.Sprintf("Could not impersonate %s: %v", client.Impersonating(), err)}
+		}
+		return ImpersonationStartedMsg{User: user}
+	}
+}
+
+// resetViews drops cached views so they reload as the effective user and
+// returns to the dashboard
+func (a *App) resetViews() tea.Cmd {
+	a.locations = nil
+	a.rooms = nil
+	a.calendar = nil
+	a.bookings = nil
+	a.bookingForm = nil
+	a.search = nil
+	a.admin = nil
+	a.settings = nil
+	a.activity = nil
+	a.desks = nil
+	a.approvals = nil
+	a.issues = nil
+
+	a.state = ViewDashboard
+	a.dashboardStale = false
+	a.dashboard = a.newDashboardModel()
+	return tea.Batch(a.initView(a.dashboard), a.startActivityPolling(), a.startHandoverChecks(), a.startBookingWatch())
+}
+
+// effectiveUser returns the impersonated user, or ourselves when not impersonating
+func (a *App) effectiveUser() *milesapi.User {
+	if a.impersonating != nil {
+		return a.impersonating
+	}
+	return a.user
+}
+
+// effectiveRole returns the role requests are authorized with. The server
+// trusts the token's role claim, which can lag behind the user record, so
+// the less privileged of the two wins. Impersonation uses the target's role.
+func (a *App) effectiveRole() milesapi.UserRole {
+	if a.impersonating != nil {
+		return a.impersonating.GetRole()
+	}
+	if a.user == nil {
+		return ""
+	}
+	role := a.user.GetRole()
+	if tokenRole := a.client.TokenRole(); tokenRole != "" {
+		role = milesapi.LesserRole(role, tokenRole)
+	}
+	return role
+}
+
+// newDashboardModel creates the dashboard for the effective user
+func (a *App) newDashboardModel() *DashboardModel {
+	dashboard := NewDashboardModel(a.client, a.effectiveUser(), a.styles, a.cfg)
+	dashboard.showAdmin = a.effectiveRole().Allows(milesapi.MANAGER)
+	return dashboard
+}
+
+// viewSize is the screen area left for the current view below any banner
+func (a *App) viewSize() tea.WindowSizeMsg {
+	height := a.height
+	if a.impersonating != nil {
+		height -= lipgloss.Height(a.renderImpersonationBanner()) + 1
+	} else if a.guest {
+		height -= lipgloss.Height(a.renderGuestBanner()) + 1
+	}
+	if a.offline {
+		height -= lipgloss.Height(a.renderOfflineBanner()) + 1
+	}
+	if toasts := a.renderToasts(); toasts != "" {
+		height -= lipgloss.Height(toasts) + 1
+	}
+	if badge := a.renderApprovalsBadge(); badge != "" {
+		height -= lipgloss.Height(badge)
+	}
+	if footer := a.renderUpdateFooter(); footer != "" {
+		height -= lipgloss.Height(footer)
+	}
+	return tea.WindowSizeMsg{Width: a.width, Height: max(0, height)}
+}
+
+// initView starts a newly created view and tells it the screen size, which
+// it would otherwise only learn on the next resize
+func (a *App) initView(view tea.Model) tea.Cmd {
+	cmd := view.Init()
+	if a.width > 0 {
+		// Views are pointers, so the size sticks without reassigning
+		_, sizeCmd := view.Update(a.viewSize())
+		cmd = tea.Batch(cmd, sizeCmd)
+	}
+	return cmd
+}
+
+// resizeViews passes the screen size on to every view that has been created
+func (a *App) resizeViews() tea.Cmd {
+	size := a.viewSize()
+	views := []*tea.Model{
+		&a.login, &a.dashboard, &a.locations, &a.rooms, &a.calendar,
+		&a.bookings, &a.bookingForm, &a.search, &a.admin, &a.settings,
+		&a.activity, &a.desks, &a.approvals, &a.issues,
+	}
+
+	var cmds []tea.Cmd
+	for _, view := range views {
+		if *view == nil {
+			continue
+		}
+		var cmd tea.Cmd
+		*view, cmd = (*view).Update(size)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// inputCapturer is implemented by views that can have a focused text input
+type inputCapturer interface {
+	CapturingInput() bool
+}
+
+// currentView returns the model for the active view
+func (a *App) currentView() tea.Model {
+	return a.viewFor(a.state)
+}
+
+// viewFor returns the cached view shown in state, if any
+func (a *App) viewFor(state ViewState) tea.Model {
+	switch state {
+	case ViewLogin:
+		return a.login
+	case ViewDashboard:
+		return a.dashboard
+	case ViewLocations:
+		return a.locations
+	case ViewRooms:
+		return a.rooms
+	case ViewCalendar:
+		return a.calendar
+	case ViewBookings:
+		return a.bookings
+	case ViewBookingForm:
+		return a.bookingForm
+	case ViewSearch:
+		return a.search
+	case ViewAdmin:
+		return a.admin
+	case ViewSettings:
+		return a.settings
+	case ViewActivity:
+		return a.activity
+	case ViewDesks:
+		return a.desks
+	case ViewApprovals:
+		return a.approvals
+	case ViewIssues:
+		return a.issues
+	}
+	return nil
+}
+
+// leaveView cancels the requests of a view that was left while still
+// loading. Its response would only reach the view on screen, so the view
+// is dropped and loads again when it's next opened.
+func (a *App) leaveView(state ViewState) {
+	if a.state == state {
+		return
+	}
+	view, ok := a.viewFor(state).(loader)
+	if !ok || !view.isLoading() {
+		return
+	}
+	view.cancelRequests()
+
+	switch state {
+	case ViewDashboard:
+		// The dashboard is only rebuilt when stale
+		a.dashboardStale = true
+	case ViewLocations:
+		a.locations = nil
+	case ViewRooms:
+		a.rooms = nil
+	case ViewCalendar:
+		a.calendar = nil
+	case ViewBookings:
+		a.bookings = nil
+	case ViewAdmin:
+		a.admin = nil
+	case ViewActivity:
+		a.activity = nil
+	case ViewDesks:
+		a.desks = nil
+	case ViewApprovals:
+		a.approvals = nil
+	case ViewIssues:
+		a.issues = nil
+	}
+}
+
 // updateCurrentView delegates updates to the current view
 func (a *App) updateCurrentView(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
@@ -270,6 +993,26 @@ func (a *App) updateCurrentView(msg tea.Msg) tea.Cmd {
 		if a.admin != nil {
 			a.admin, cmd = a.admin.Update(msg)
 		}
+	case ViewSettings:
+		if a.settings != nil {
+			a.settings, cmd = a.settings.Update(msg)
+		}
+	case ViewActivity:
+		if a.activity != nil {
+			a.activity, cmd = a.activity.Update(msg)
+		}
+	case ViewDesks:
+		if a.desks != nil {
+			a.desks, cmd = a.desks.Update(msg)
+		}
+	case ViewApprovals:
+		if a.approvals != nil {
+			a.approvals, cmd = a.approvals.Update(msg)
+		}
+	case ViewIssues:
+		if a.issues != nil {
+			a.issues, cmd = a.issues.Update(msg)
+		}
 	}
 
 	return cmd
@@ -355,7 +1098,63 @@ func (a *App) renderAdmin() string {
 		a.styles.Help.Render("Press 1 to go back to dashboard")
 }
 
+func (a *App) renderSettings() string {
+	if a.settings != nil {
+		return a.settings.View()
+	}
+	return a.styles.Title.Render("Settings") + "\n\n" +
+		a.styles.TextMuted.Render("Loading...")
+}
+
+func (a *App) renderActivity() string {
+	if a.activity != nil {
+		return a.activity.View()
+	}
+	return a.styles.Title.Render("Activity") + "\n\n" +
+		a.styles.TextMuted.Render("Loading...")
+}
+
+func (a *App) renderDesks() string {
+	if a.desks != nil {
+		return a.desks.View()
+	}
+	return a.styles.Title.Render("Hot Desks") + "\n\n" +
+		a.styles.TextMuted.Render("Loading...")
+}
+
+func (a *App) renderApprovals() string {
+	if a.approvals != nil {
+		return a.approvals.View()
+	}
+	return a.styles.Title.Render("Approvals") + "\n\n" +
+		a.styles.TextMuted.Render("Loading...")
+}
+
+func (a *App) renderIssues() string {
+	if a.issues != nil {
+		return a.issues.View()
+	}
+	return a.styles.Title.Render("My Reports") + "\n\n" +
+		a.styles.TextMuted.Render("Loading...")
+}
+
 func (a *App) renderHelp() string {
+	if a.offlineShell {
+		return a.renderOfflineHelp()
+	}
+	if a.guest {
+		return a.styles.Title.Render("Help & Keyboard Shortcuts") + "\n\n" +
+			a.styles.Heading.Render("Guest Mode") + "\n" +
+			a.styles.Text.Render("  2 - Locations") + "\n" +
+			a.styles.Text.Render("  3 - Rooms (Enter shows a room's availability)") + "\n" +
+			a.styles.Text.Render("  4 - Calendar") + "\n" +
+			a.featureHelpLine("  9 - Hot desks (Enter shows a desk's availability)", milesapi.FeatureDesks, "") + "\n\n" +
+			a.styles.Heading.Render("Global Shortcuts") + "\n" +
+			a.styles.Text.Render("  i - Log in to book rooms") + "\n" +
+			a.styles.Text.Render("  ? - Show this help") + "\n" +
+			a.styles.Text.Render("  q - Quit application") + "\n\n" +
+			a.styles.Help.Render("Press 2 to go back to locations")
+	}
 	return a.styles.Title.Render("Help & Keyboard Shortcuts") + "\n\n" +
 		a.styles.Heading.Render("Navigation") + "\n" +
 		a.styles.Text.Render("  1 - Dashboard") + "\n" +
@@ -363,11 +1162,27 @@ func (a *App) renderHelp() string {
 		a.styles.Text.Render("  3 - Rooms") + "\n" +
 		a.styles.Text.Render("  4 - Calendar") + "\n" +
 		a.styles.Text.Render("  5 - My Bookings") + "\n" +
-		a.styles.Text.Render("  6 - Search") + "\n" +
-		a.styles.Text.Render("  0 - Admin Panel (Admin/Manager only)") + "\n\n" +
+		a.styles.Text.Render("  6 - Search rooms everywhere by name, amenity, location or seats (Ctrl+F finds a free one)") + "\n" +
+		a.styles.Text.Render("  7 - Settings (dashboard widgets, favorite room)") + "\n" +
+		a.styles.Text.Render("  8 - Activity (rooms and colleagues you follow)") + "\n" +
+		a.featureHelpLine("  9 - Hot desks (book a desk for the day, by floor)", milesapi.FeatureDesks, "") + "\n" +
+		a.helpLine("  0 - Admin Panel", milesapi.MANAGER) + "\n" +
+		a.featureHelpLine("  A - Approvals (bookings waiting in your locations)", milesapi.FeatureApprovals, milesapi.MANAGER) + "\n" +
+		a.styles.Text.Render("  R - My Reports (room issues you reported; ! on a room reports one)") + "\n\n" +
 		a.styles.Heading.Render("Global Shortcuts") + "\n" +
 		a.styles.Text.Render("  ? - Show this help") + "\n" +
 		a.styles.Text.Render("  q - Quit application") + "\n" +
+		a.helpLine("  Ctrl+X - Stop impersonating", milesapi.ADMIN) + "\n" +
+		a.styles.Text.Render("  L - Log out (forget the saved session)") + "\n" +
 		a.styles.Text.Render("  Ctrl+C - Quit application") + "\n\n" +
 		a.styles.Help.Render("Press 1 to go back to dashboard")
 }
+
+// helpLine renders a shortcut that needs a role, greyed out with the role
+// it requires when the effective role lacks it
+func (a *App) helpLine(text string, required milesapi.UserRole) string {
+	if a.effectiveRole().Allows(required) {
+		return a.styles.Text.Render(text)
+	}
+	return a.styles.TextMuted.Render(fmt.Sprintf("%s (requires %s role)", text, required))
+}
@@ -0,0 +1,585 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// approvalAction is what the note being typed is for
+type approvalAction int
+
+const (
+	approvalNone approvalAction = iota
+	approvalReject
+	approvalRequestChanges
+)
+
+// ApprovalsModel is the manager's queue of bookings waiting for approval
+// in the locations they manage (every location for admins)
+type ApprovalsModel struct {
+	requests
+
+	styles *styles.Styles
+	client *api.Client
+	width  int
+	height int
+
+	// Data
+	bookings []milesapi.Booking
+	marked   map[string]bool // Booking IDs picked for a bulk action
+	cursor   int
+	loading  bool
+	working  bool
+	error    string
+	notice   string
+
+	// A reason for rejecting or the changes asked for; open while action
+	// is set
+	action    approvalAction
+	noteInput textinput.Model
+
+	// Title and help stay put while the queue scrolls
+	layout stickyLayout
+}
+
+// ApprovalsDataMsg contains the pending bookings. The app's poller sends it
+// too, so the queue refreshes as new requests arrive.
+type ApprovalsDataMsg struct {
+	Bookings []milesapi.Booking
+}
+
+// ApprovalsErrorMsg contains error information
+type ApprovalsErrorMsg struct {
+	Error string
+}
+
+// ApprovalsChangedMsg is sent after approving, rejecting or asking for
+// changes, with a notice saying what was done
+type ApprovalsChangedMsg struct {
+	Notice string
+	Error  string
+}
+
+// NewApprovalsModel creates the approvals view
+func NewApprovalsModel(client *api.Client, styles *styles.Styles) *ApprovalsModel {
+	noteInput := textinput.New()
+	noteInput.CharLimit = 500
+	noteInput.Width = 60
+
+	return &ApprovalsModel{
+		styles:    styles,
+		client:    client,
+		marked:    make(map[string]bool),
+		loading:   true,
+		noteInput: noteInput,
+		layout:    newStickyLayout(),
+	}
+}
+
+// Init loads the pending bookings
+func (m *ApprovalsModel) Init() tea.Cmd {
+	return loadPendingApprovals(m.requestCtx(), m.client)
+}
+
+// isLoading reports whether the queue is still loading
+func (m *ApprovalsModel) isLoading() bool {
+	return m.loading
+}
+
+// Update handles messages for the approvals view
+func (m *ApprovalsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.layout.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case ApprovalsDataMsg:
+		m.setBookings(msg.Bookings)
+		m.loading = false
+		m.error = ""
+		return m, nil
+
+	case ApprovalsErrorMsg:
+		m.error = msg.Error
+		m.loading = false
+		return m, nil
+
+	case ApprovalsChangedMsg:
+		m.working = false
+		m.notice = msg.Notice
+		m.error = msg.Error
+		return m, loadPendingApprovals(m.requestCtx(), m.client)
+
+	case tea.KeyMsg:
+		if m.loading || m.working {
+			return m, nil
+		}
+		if m.action != approvalNone {
+			return m.handleNoteKeys(msg)
+		}
+		if m.layout.Scroll(msg) {
+			return m, nil
+		}
+
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Refresh):
+			m.loading = true
+			m.error = ""
+			m.notice = ""
+			return m, loadPendingApprovals(m.requestCtx(), m.client)
+
+		case key.Matches(msg, k.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, k.Down):
+			if m.cursor < len(m.bookings)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, k.Top):
+			m.cursor = 0
+
+		case key.Matches(msg, k.Bottom):
+			m.cursor = max(0, len(m.bookings)-1)
+
+		case key.Matches(msg, k.Mark):
+			// Mark for a bulk action and move on, so runs mark quickly
+			id := m.bookings[m.cursor].GetId()
+			m.marked[id] = !m.marked[id]
+			if !m.marked[id] {
+				delete(m.marked, id)
+			}
+			if m.cursor < len(m.bookings)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, k.MarkAll):
+			// Mark every booking, or clear the marks when all are marked
+			if len(m.marked) == len(m.bookings) {
+				m.marked = make(map[string]bool)
+			} else {
+				for _, booking := range m.bookings {
+					m.marked[booking.GetId()] = true
+				}
+			}
+
+		case key.Matches(msg, k.Approve):
+			m.working = true
+			m.notice = ""
+			return m, m.approve(m.targets())
+
+		case key.Matches(msg, k.Reject):
+			m.openNote(approvalReject, "Reason (optional, Enter to reject)")
+			return m, textinput.Blink
+
+		case key.Matches(msg, k.RequestChanges):
+			// Asking for changes is a comment to the booker, one at a time
+			m.openNote(approvalRequestChanges, "e.g. Please move it to the small room")
+			return m, textinput.Blink
+		}
+	}
+
+	return m, nil
+}
+
+// approvalsKeyMap lists the approvals queue's keys, with those of the note
+// prompt
+type approvalsKeyMap struct {
+	listKeyMap
+	Mark           key.Binding
+	MarkAll        key.Binding
+	Approve        key.Binding
+	Reject         key.Binding
+	RequestChanges key.Binding
+	Refresh        key.Binding
+	Note           promptKeyMap
+}
+
+// keyMap returns the queue's keys. Approve and Reject act on the marked
+// bookings when there are any, otherwise on the one under the cursor.
+func (m *ApprovalsModel) keyMap() approvalsKeyMap {
+	k := approvalsKeyMap{
+		listKeyMap:     newListKeyMap(),
+		Mark:           newKey("Space", "Mark", " "),
+		MarkAll:        newKey("v", "Mark all", "v"),
+		Approve:        newKey("a", "Approve", "a"),
+		Reject:         newKey("x", "Reject", "x"),
+		RequestChanges: newKey("c", "Request changes", "c"),
+		Refresh:        newKey("r", "Refresh", "r", "f5"),
+		Note:           newPromptKeyMap("Reject"),
+	}
+	if len(m.marked) > 0 {
+		k.Approve.SetHelp("a", "Approve marked")
+		k.Reject.SetHelp("x", "Reject marked")
+	}
+	hasBooking := m.cursor < len(m.bookings)
+	hasTargets := len(m.targets()) > 0
+	k.Mark.SetEnabled(hasBooking)
+	k.MarkAll.SetEnabled(len(m.bookings) > 0)
+	k.Approve.SetEnabled(hasTargets)
+	k.Reject.SetEnabled(hasTargets)
+	k.RequestChanges.SetEnabled(hasBooking)
+	if m.action == approvalRequestChanges {
+		// Changes are sent as a comment, so there has to be one
+		k.Note.Submit.SetHelp("Enter", "Send as a comment")
+		k.Note.Submit.SetEnabled(strings.TrimSpace(m.noteInput.Value()) != "")
+	}
+	return k
+}
+
+// CapturingInput reports whether keys should go to the note input rather
+// than the app's global shortcuts
+func (m *ApprovalsModel) CapturingInput() bool {
+	return m.action != approvalNone
+}
+
+// setBookings replaces the queue, keeping the marks and cursor on bookings
+// still waiting
+func (m *ApprovalsModel) setBookings(bookings []milesapi.Booking) {
+	current := ""
+	if m.cursor < len(m.bookings) {
+		current = m.bookings[m.cursor].GetId()
+	}
+
+	m.bookings = bookings
+	marked := make(map[string]bool)
+	m.cursor = min(m.cursor, max(0, len(bookings)-1))
+	for i, booking := range bookings {
+		if m.marked[booking.GetId()] {
+			marked[booking.GetId()] = true
+		}
+		if booking.GetId() == current {
+			m.cursor = i
+		}
+	}
+	m.marked = marked
+}
+
+// targets returns the marked bookings, or the one under the cursor when
+// none are marked
+func (m *ApprovalsModel) targets() []milesapi.Booking {
+	var targets []milesapi.Booking
+	for _, booking := range m.bookings {
+		if m.marked[booking.GetId()] {
+			targets = append(targets, booking)
+		}
+	}
+	if len(targets) == 0 && m.cursor < len(m.bookings) {
+		targets = append(targets, m.bookings[m.cursor])
+	}
+	return targets
+}
+
+// openNote starts typing a note for a reject or a change request
+func (m *ApprovalsModel) openNote(action approvalAction, placeholder string) {
+	m.action = action
+	m.notice = ""
+	m.noteInput.Placeholder = placeholder
+	m.noteInput.SetValue("")
+	m.noteInput.Focus()
+}
+
+// handleNoteKeys handles keys while a note is being typed
+func (m *ApprovalsModel) handleNoteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap().Note
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.action = approvalNone
+		m.noteInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		note := strings.TrimSpace(m.noteInput.Value())
+		action := m.action
+		m.action = approvalNone
+		m.noteInput.Blur()
+		m.working = true
+
+		if action == approvalReject {
+			return m, m.reject(m.targets(), note)
+		}
+		return m, m.requestChanges(m.bookings[m.cursor], note)
+	}
+
+	var cmd tea.Cmd
+	m.noteInput, cmd = m.noteInput.Update(msg)
+	return m, cmd
+}
+
+// approve confirms the bookings
+func (m *ApprovalsModel) approve(bookings []milesapi.Booking) tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		status := milesapi.PatchApiBookingsIdJSONBodyStatusCONFIRMED
+		done, failed := 0, 0
+		for _, booking := range bookings {
+			if _, err := client.UpdateBooking(ctx, booking.GetId(), milesapi.PatchApiBookingsIdJSONRequestBody{Status: &status}); err != nil {
+				failed++
+				continue
+			}
+			done++
+		}
+		return approvalsResult("Approved", done, failed)
+	}
+}
+
+// reject cancels the bookings, first telling each booker why when a reason
+// is given
+func (m *ApprovalsModel) reject(bookings []milesapi.Booking, reason string) tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		status := milesapi.PatchApiBookingsIdJSONBodyStatusCANCELLED
+		done, failed := 0, 0
+		for _, booking := range bookings {
+			if reason != "" {
+				if _, err := client.AddBookingComment(ctx, booking.GetId(), "Rejected: "+reason); err != nil {
+					failed++
+					continue
+				}
+			}
+			if _, err := client.UpdateBooking(ctx, booking.GetId(), milesapi.PatchApiBookingsIdJSONRequestBody{Status: &status}); err != nil {
+				failed++
+				continue
+			}
+			done++
+		}
+		return approvalsResult("Rejected", done, failed)
+	}
+}
+
+// requestChanges asks the booker for changes in the booking's comments.
+// The booking stays pending until it's approved or rejected.
+func (m *ApprovalsModel) requestChanges(booking milesapi.Booking, changes string) tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		if _, err := client.AddBookingComment(ctx, booking.GetId(), "Changes requested: "+changes); err != nil {
+			return ApprovalsChangedMsg{Error: err.Error()}
+		}
+		return ApprovalsChangedMsg{Notice: fmt.Sprintf("✓ Asked %s for changes to %q", booking.GetUser().FullName(), booking.GetTitle())}
+	}
+}
+
+// approvalsResult reports a bulk action that may have partly failed
+func approvalsResult(verb string, done, failed int) ApprovalsChangedMsg {
+	msg := ApprovalsChangedMsg{}
+	if done > 0 {
+		msg.Notice = fmt.Sprintf("✓ %s %d booking(s)", verb, done)
+	}
+	if failed > 0 {
+		msg.Error = fmt.Sprintf("%d booking(s) couldn't be changed; they may have been handled already", failed)
+	}
+	return msg
+}
+
+// View renders the approvals view
+func (m *ApprovalsModel) View() string {
+	title := m.styles.Title.Render("Approvals")
+	if m.loading {
+		return title + "\n\n" + m.styles.TextMuted.Render("Loading...")
+	}
+
+	subtitle := fmt.Sprintf("%d booking(s) waiting in the locations you manage", len(m.bookings))
+	if len(m.marked) > 0 {
+		subtitle += fmt.Sprintf(" • %d marked", len(m.marked))
+	}
+	header := title + "\n" + m.styles.Subtitle.Render(subtitle)
+	if m.working {
+		header += "\n" + m.styles.TextMuted.Render("Working...")
+	} else if m.notice != "" {
+		header += "\n" + m.styles.TextSuccess.Render(m.notice)
+	}
+	if m.error != "" {
+		header += "\n" + m.styles.TextError.Render("Error: "+m.error)
+	}
+	header += "\n"
+
+	if len(m.bookings) == 0 {
+		return header + "\n" +
+			m.styles.TextMuted.Render("  Nothing to approve. New requests show up here as they arrive.") + "\n\n" +
+			m.styles.Help.Render("r: Refresh")
+	}
+
+	var items []string
+	for i, booking := range m.bookings {
+		items = append(items, m.renderBooking(booking, i == m.cursor))
+	}
+	body, top, bottom := joinItems(items, "\n", m.cursor)
+
+	return m.layout.Render(header, body, "\n"+m.renderFooter(), top, bottom)
+}
+
+// renderBooking renders a pending booking, with its details when selected
+func (m *ApprovalsModel) renderBooking(booking milesapi.Booking, isSelected bool) string {
+	mark := "[ ]"
+	if m.marked[booking.GetId()] {
+		mark = "[x]"
+	}
+	when := fmt.Sprintf("%s %s-%s", booking.GetStartTime().Local().Format("Mon Jan 2"),
+		utils.FormatTime(booking.GetStartTime()), utils.FormatTime(booking.GetEndTime()))
+	where := booking.GetRoom().GetName()
+	if booking.GetRoom().GetLocation().GetName() != "" {
+		where += " (" + booking.GetRoom().GetLocation().GetName() + ")"
+	}
+	line := fmt.Sprintf("%s %s  %s  %s • %s", mark, when, booking.GetTitle(), where, booking.GetUser().FullName())
+
+	if !isSelected {
+		return "  " + m.styles.Text.Render(line)
+	}
+
+	result := m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ") +
+		m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render(line)
+	details := []string{booking.GetUser().GetEmail(), utils.FormatDuration(booking.GetStartTime(), booking.GetEndTime())}
+	if !booking.GetCreatedAt().IsZero() {
+		details = append(details, "requested "+utils.HumanizeTime(booking.GetCreatedAt()))
+	}
+	result += "\n    " + m.styles.TextDim.Render(strings.Join(details, " • "))
+	if description := m.client.RevealDescription(booking.GetDescription()); description != "" {
+		for _, line := range utils.Wrap(description, max(20, m.width-6)) {
+			result += "\n    " + m.styles.TextDim.Render(line)
+		}
+	}
+	return result
+}
+
+// renderFooter renders the note being typed or the help text
+func (m *ApprovalsModel) renderFooter() string {
+	k := m.keyMap()
+	switch m.action {
+	case approvalReject:
+		prompt := "Reject"
+		if n := len(m.targets()); n > 1 {
+			prompt = fmt.Sprintf("Reject %d bookings", n)
+		}
+		return m.styles.Text.Render(prompt+": ") + m.noteInput.View() + "\n" +
+			renderFooter(m.styles, m.width, k.Note.Submit, k.Note.Cancel)
+	case approvalRequestChanges:
+		return m.styles.Text.Render("Changes to ask for: ") + m.noteInput.View() + "\n" +
+			renderFooter(m.styles, m.width, k.Note.Submit, k.Note.Cancel)
+	}
+
+	return renderFooter(m.styles, m.width, k.Up, k.Mark, k.MarkAll, k.Approve, k.Reject,
+		k.RequestChanges, k.Refresh)
+}
+
+// loadPendingApprovals loads the upcoming bookings waiting for approval,
+// soonest first. The server limits managers to their locations.
+func loadPendingApprovals(ctx context.Context, client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		bookings, err := fetchPendingApprovals(ctx, client)
+		if err != nil {
+			return ApprovalsErrorMsg{Error: err.Error()}
+		}
+		return ApprovalsDataMsg{Bookings: bookings}
+	}
+}
+
+// fetchPendingApprovals returns the upcoming pending bookings, soonest first
+func fetchPendingApprovals(ctx context.Context, client *api.Client) ([]milesapi.Booking, error) {
+	bookings, err := client.GetBookingsFiltered(ctx, api.BookingFilter{
+		Status: milesapi.BookingStatusPENDING,
+		From:   utils.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(bookings, func(i, j int) bool {
+		return bookings[i].GetStartTime().Before(bookings[j].GetStartTime())
+	})
+	return bookings, nil
+}
+
+// approvalsPolledMsg carries the pending bookings found by the app's poller
+type approvalsPolledMsg struct {
+	gen      int
+	bookings []milesapi.Booking
+	err      error
+}
+
+// pollApprovals fetches the pending bookings for the badge, alongside the
+// activity poll. Only managers and admins have a queue.
+func (a *App) pollApprovals() tea.Cmd {
+	if a.guest || !a.effectiveRole().Allows(milesapi.MANAGER) || !a.features.Enabled(milesapi.FeatureApprovals) {
+		return nil
+	}
+	ctx := a.requestCtx()
+	client, gen := a.client, a.activityGen
+	return func() tea.Msg {
+		bookings, err := fetchPendingApprovals(ctx, client)
+		return approvalsPolledMsg{gen: gen, bookings: bookings, err: err}
+	}
+}
+
+// handleApprovals updates the badge and the queue, and toasts requests
+// that arrived since the last poll. Failed polls keep the last count.
+func (a *App) handleApprovals(msg approvalsPolledMsg) tea.Cmd {
+	if msg.err != nil {
+		return nil
+	}
+	first := a.approvalsSeen == nil
+	var fresh []milesapi.Booking
+	for _, booking := range msg.bookings {
+		if !a.approvalsSeen[booking.GetId()] {
+			fresh = append(fresh, booking)
+		}
+	}
+
+	cmds := []tea.Cmd{a.approvalsCount(msg.bookings)}
+	if first || len(fresh) == 0 {
+		return tea.Batch(cmds...)
+	}
+	if a.approvals != nil {
+		var cmd tea.Cmd
+		a.approvals, cmd = a.approvals.Update(ApprovalsDataMsg{Bookings: msg.bookings})
+		cmds = append(cmds, cmd)
+	}
+	for _, booking := range fresh {
+		cmds = append(cmds, a.showToast(fmt.Sprintf("%s asks to book %s %s: %s",
+			booking.GetUser().FullName(), booking.GetRoom().GetName(),
+			booking.GetStartTime().Local().Format("Mon Jan 2 15:04"), booking.GetTitle()), false))
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderApprovalsBadge renders the status bar line counting the bookings
+// waiting for approval, or nothing when there are none
+func (a *App) renderApprovalsBadge() string {
+	if a.pendingApprovals == 0 || a.guest || a.offline {
+		return ""
+	}
+	return a.styles.BadgeWarning.Render(fmt.Sprintf("✋ %d awaiting approval", a.pendingApprovals)) +
+		a.styles.TextMuted.Render(" A: Approvals")
+}
+
+// approvalsCount keeps the badge in step with the queue after the
+// approvals view loads or changes it
+func (a *App) approvalsCount(bookings []milesapi.Booking) tea.Cmd {
+	resized := len(bookings) == 0 != (a.pendingApprovals == 0)
+	a.pendingApprovals = len(bookings)
+	seen := make(map[string]bool, len(bookings))
+	for _, booking := range bookings {
+		seen[booking.GetId()] = true
+	}
+	a.approvalsSeen = seen
+	if resized {
+		return a.resizeViews()
+	}
+	return nil
+}
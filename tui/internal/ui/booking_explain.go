@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// availabilityStep is one check behind the booking form's availability
+// verdict, shown with Ctrl+E: "✗ Room free: held by Standup 14:00-14:30"
+type availabilityStep struct {
+	ok     bool
+	check  string
+	detail string
+}
+
+// explainAvailability lists the checks a slot went through: the room's
+// length limits, its location's closed days and the bookings holding it.
+// closure and available are what the availability check found; the
+// blocking bookings are only fetched when the room is taken.
+func explainAvailability(ctx context.Context, client *api.Client, room milesapi.Room, startTime, endTime time.Time, closure string, available bool, exceptID string) []availabilityStep {
+	minutes := int(endTime.Sub(startTime) / time.Minute)
+	steps := []availabilityStep{{
+		ok:     true,
+		check:  "Length",
+		detail: utils.FormatMinutes(minutes) + ", " + milesapi.DescribeDurationLimits(room.DurationLimits()) + " allowed",
+	}}
+
+	if closure != "" {
+		return append(steps, availabilityStep{check: "Closed days", detail: closure})
+	}
+	steps = append(steps, availabilityStep{ok: true, check: "Closed days", detail: "the location is open " + startTime.Format("Mon Jan 2")})
+
+	if available {
+		return append(steps, availabilityStep{ok: true, check: "Room free", detail: "no other bookings then"})
+	}
+	bookings, err := client.GetRoomAvailability(ctx, room.GetId(), startTime, endTime)
+	if err != nil {
+		return append(steps, availabilityStep{check: "Room free", detail: "taken; could not load by what"})
+	}
+	for _, booking := range bookings {
+		if booking.GetId() == exceptID || booking.GetStatus() == milesapi.BookingStatusCANCELLED ||
+			!booking.GetStartTime().Before(endTime) || !booking.GetEndTime().After(startTime) {
+			continue
+		}
+		steps = append(steps, availabilityStep{check: "Room free", detail: "held by " + milesapi.DescribeBlocker(booking)})
+	}
+	return steps
+}
+
+// renderExplanation renders the checks behind the availability verdict,
+// and the quota, under "Why:"
+func (m *BookingFormModel) renderExplanation() string {
+	var b strings.Builder
+	b.WriteString(m.styles.TextBold.Render("Why:"))
+	b.WriteString("\n")
+
+	steps := m.explanation
+	if m.quota != nil && m.quota.LimitHours > 0 {
+		usage := utils.FormatQuota(float64(m.quota.UsedHours), float64(m.quota.LimitHours), string(m.quota.Period))
+		blocked := m.quotaExceeded() && m.quota.Policy == milesapi.Block
+		steps = append(steps, availabilityStep{ok: !blocked, check: "Quota", detail: usage})
+	}
+	for _, step := range steps {
+		line := fmt.Sprintf("%-12s %s", step.check+":", step.detail)
+		if step.ok {
+			b.WriteString("  " + m.styles.TextSuccess.Render("✓") + " " + m.styles.Text.Render(line))
+		} else {
+			b.WriteString("  " + m.styles.TextError.Render("✗") + " " + m.styles.Text.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
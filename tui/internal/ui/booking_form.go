@@ -1,34 +1,43 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
 	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
 )
 
 // BookingFormModel represents the booking creation form
 type BookingFormModel struct {
+	requests
+
 	styles *styles.Styles
 	client *api.Client
 	width  int
 	height int
 
 	// Pre-selected room (optional)
-	selectedRoom *models.Room
+	selectedRoom *milesapi.Room
+
+	// Booking being changed; nil when creating a new one
+	editing *milesapi.Booking
 
 	// Form state
-	step int // 0=room, 1=date, 2=time, 3=details
+	step int // 0=room, 1=date, 2=time, 3=repeat, 4=details
 
 	// Room selection
-	rooms        []models.Room
-	roomCursor   int
+	roomPicker   roomPicker
 	loadingRooms bool
 
 	// Date selection
@@ -40,17 +49,43 @@ type BookingFormModel struct {
 	startMinute int
 	endHour     int
 	endMinute   int
-	timeFocus   int // 0=start hour, 1=start min, 2=end hour, 3=end min
+	timeFocus   int    // 0=start hour, 1=start min, 2=end hour, 3=end min
+	timeDigits  string // Digits typed into the focused box, not yet applied
+
+	// The organization's named time slots, offered as presets
+	timeSlots []milesapi.TimeSlot
+
+	// The room's bookings on the selected date, drawn under the time pickers
+	dayBookings []milesapi.Booking
+	dayLoading  bool
+
+	// Repeat: how often and how many times; series holds the occurrences
+	// once past the step, empty for a single booking
+	repeatIndex  int // 0 = does not repeat, else recurrence.Frequencies[repeatIndex-1]
+	repeatCount  int
+	repeatFocus  int // 0=frequency, 1=count
+	series       []seriesOccurrence
+	seriesBooked bool
 
 	// Details
 	titleInput       textinput.Model
 	descriptionInput textinput.Model
-	detailsFocus     int
+	setupIndex       int // 0 = no setup request, else milesapi.RoomSetups[setupIndex-1]
+	setupNotesInput  textinput.Model
+	detailsFocus     int // 0=title, 1=description, 2=setup, 3=setup notes
 
 	// Availability check
 	checkingAvailability bool
 	isAvailable          bool
 	availabilityError    string
+	nearbyRooms          []milesapi.Room // Free rooms near a busy one, nearest first
+
+	// The checks behind the verdict, shown under it with Ctrl+E
+	explanation []availabilityStep
+	explaining  bool
+
+	// Personal quota for the selected date's period, nil if unknown
+	quota *milesapi.Quota
 
 	// Submission
 	submitting bool
@@ -58,9 +93,11 @@ type BookingFormModel struct {
 	success    bool
 }
 
-// BookingFormCompleteMsg is sent when booking is successfully created
+// BookingFormCompleteMsg is sent when booking is successfully created, or
+// changed when Edited is set
 type BookingFormCompleteMsg struct {
-	Booking *models.Booking
+	Booking *milesapi.Booking
+	Edited  bool
 }
 
 // BookingFormCancelMsg is sent when form is cancelled
@@ -68,17 +105,34 @@ type BookingFormCancelMsg struct{}
 
 // RoomsLoadedMsg contains loaded rooms
 type RoomsLoadedMsg struct {
-	Rooms []models.Room
+	Rooms []milesapi.Room
+}
+
+// BookingQuotaMsg contains the user's quota for the selected date
+type BookingQuotaMsg struct {
+	Quota *milesapi.Quota
+}
+
+// RoomDayLoadedMsg contains the selected room's bookings on the selected date
+type RoomDayLoadedMsg struct {
+	Bookings []milesapi.Booking
+}
+
+// TimeSlotsLoadedMsg contains the organization's named time slots
+type TimeSlotsLoadedMsg struct {
+	Slots []milesapi.TimeSlot
 }
 
 // AvailabilityCheckedMsg contains availability check result
 type AvailabilityCheckedMsg struct {
-	Available bool
-	Error     string
+	Available   bool
+	Error       string
+	Nearby      []milesapi.Room
+	Explanation []availabilityStep
 }
 
 // NewBookingFormModel creates a new booking form
-func NewBookingFormModel(client *api.Client, styles *styles.Styles, room *models.Room) *BookingFormModel {
+func NewBookingFormModel(client *api.Client, styles *styles.Styles, room *milesapi.Room) *BookingFormModel {
 	// Initialize inputs
 	dateInput := textinput.New()
 	dateInput.Placeholder = "YYYY-MM-DD"
@@ -95,6 +149,11 @@ func NewBookingFormModel(client *api.Client, styles *styles.Styles, room *models
 	descriptionInput.CharLimit = 200
 	descriptionInput.Width = 40
 
+	setupNotesInput := textinput.New()
+	setupNotesInput.Placeholder = "e.g. water for 40, projector on"
+	setupNotesInput.CharLimit = 500
+	setupNotesInput.Width = 40
+
 	// Set default date to today
 	today := time.Now()
 	defaultDate := today.Format("2006-01-02")
@@ -116,8 +175,13 @@ func NewBookingFormModel(client *api.Client, styles *styles.Styles, room *models
 		endMinute:        0,
 		titleInput:       titleInput,
 		descriptionInput: descriptionInput,
+		setupNotesInput:  setupNotesInput,
+		repeatCount:      10,
+		roomPicker:       newRoomPicker(styles),
 	}
 
+	model.roomPicker.lockRestricted = true
+
 	// Set initial value for date input
 	model.dateInput.SetValue(defaultDate)
 
@@ -129,6 +193,7 @@ func NewBookingFormModel(client *api.Client, styles *styles.Styles, room *models
 	} else {
 		model.step = 1
 		model.dateInput.Focus()
+		model.fitDurationToRoom()
 	}
 
 	return model
@@ -137,9 +202,9 @@ func NewBookingFormModel(client *api.Client, styles *styles.Styles, room *models
 // Init initializes the form
 func (m *BookingFormModel) Init() tea.Cmd {
 	if m.selectedRoom == nil {
-		return m.loadRooms()
+		return tea.Batch(m.loadRooms(), m.loadTimeSlots(), textinput.Blink)
 	}
-	return textinput.Blink
+	return tea.Batch(m.loadTimeSlots(), textinput.Blink)
 }
 
 // Update handles messages
@@ -148,17 +213,41 @@ func (m *BookingFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		// Leave room for the header, filter input and help line
+		m.roomPicker.SetHeight(max(5, msg.Height-14))
+		m.roomPicker.SetWidth(msg.Width)
+		m.fitInputs()
 		return m, nil
 
 	case RoomsLoadedMsg:
-		m.rooms = msg.Rooms
+		m.roomPicker.SetRooms(msg.Rooms)
 		m.loadingRooms = false
 		return m, nil
 
+	case BookingQuotaMsg:
+		m.quota = msg.Quota
+		return m, nil
+
+	case TimeSlotsLoadedMsg:
+		m.timeSlots = msg.Slots
+		return m, nil
+
+	case RoomDayLoadedMsg:
+		m.dayBookings = msg.Bookings
+		m.dayLoading = false
+		return m, nil
+
 	case AvailabilityCheckedMsg:
 		m.checkingAvailability = false
 		m.isAvailable = msg.Available
 		m.availabilityError = msg.Error
+		m.nearbyRooms = msg.Nearby
+		m.explanation = msg.Explanation
+		return m, nil
+
+	case SeriesCheckedMsg:
+		m.checkingAvailability = false
+		m.series = msg.Series
 		return m, nil
 
 	case tea.KeyMsg:
@@ -171,79 +260,235 @@ func (m *BookingFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKeyPress handles keyboard input
 func (m *BookingFormModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	k := m.keyMap()
+
+	// The room picker owns all keys except Esc while it is shown
+	if m.step == 0 && !key.Matches(msg, k.Cancel) {
+		if key.Matches(msg, k.Picker.RequestAccess) {
+			return m, requestAccessCmd(m.requestCtx(), m.client, *m.roomPicker.Current())
+		}
+		room, cmd := m.roomPicker.Update(msg)
+		if room != nil {
+			m.selectedRoom = room
+			m.fitDurationToRoom()
+			m.step = 1
+			m.dateInput.Focus()
+			return m, textinput.Blink
+		}
+		return m, cmd
+	}
+
+	// Times can be typed as well as stepped
+	if m.step == 2 {
+		switch {
+		case key.Matches(msg, k.TypeTime):
+			m.typeTimeDigit(msg.String())
+			return m, nil
+		case key.Matches(msg, k.ClearTime):
+			m.timeDigits = ""
+			return m, nil
+		case key.Matches(msg, k.StepLater):
+			m.commitTimeDigits()
+			m.shiftFocusedTime(15)
+			return m, nil
+		case key.Matches(msg, k.StepEarlier):
+			m.commitTimeDigits()
+			m.shiftFocusedTime(-15)
+			return m, nil
+		default:
+			m.commitTimeDigits()
+		}
+	}
+
+	switch {
+	case key.Matches(msg, k.Cancel):
 		// Cancel form
 		return m, func() tea.Msg {
 			return BookingFormCancelMsg{}
 		}
 
-	case "tab", "shift+tab":
+	case key.Matches(msg, k.NextField, k.PrevField):
 		// Navigate between fields
-		return m.handleTabNavigation(msg.String() == "shift+tab")
+		return m.handleTabNavigation(key.Matches(msg, k.PrevField))
 
-	case "enter":
+	case key.Matches(msg, k.Submit):
 		// Progress to next step or submit
 		return m.handleEnter()
 
-	case "up", "k":
-		if m.step == 0 {
-			// Navigate rooms list
-			if m.roomCursor > 0 {
-				m.roomCursor--
-			}
-		} else if m.step == 2 {
-			// Increment time values
-			return m.incrementTime(), nil
-		}
-		return m, nil
+	case key.Matches(msg, k.Later):
+		// Increment time values
+		return m.incrementTime(), nil
 
-	case "down", "j":
-		if m.step == 0 {
-			// Navigate rooms list
-			if m.roomCursor < len(m.rooms)-1 {
-				m.roomCursor++
-			}
-		} else if m.step == 2 {
-			// Decrement time values
-			return m.decrementTime(), nil
+	case key.Matches(msg, k.Earlier):
+		// Decrement time values
+		return m.decrementTime(), nil
+
+	case key.Matches(msg, k.Preset, k.PrevPreset):
+		delta := 1
+		if key.Matches(msg, k.PrevPreset) {
+			delta = -1
 		}
-		return m, nil
+		m.cycleTimeSlot(delta)
 
-	case "left", "h":
-		if m.step == 2 {
-			// Move time focus left
-			if m.timeFocus > 0 {
-				m.timeFocus--
-			}
+	case key.Matches(msg, k.UseNearby):
+		// Take the nearest free room instead of the busy one
+		room := m.nearbyRooms[0]
+		m.selectedRoom = &room
+		m.nearbyRooms = nil
+		m.error = ""
+		return m, tea.Batch(m.checkAvailability(), m.loadRoomDay())
+
+	case key.Matches(msg, k.Explain):
+		m.explaining = !m.explaining
+
+	case key.Matches(msg, k.SetupPrev):
+		m.cycleSetup(-1)
+
+	case key.Matches(msg, k.SetupNext):
+		m.cycleSetup(1)
+
+	case key.Matches(msg, k.RepeatPrev):
+		m.changeRepeat(-1)
+
+	case key.Matches(msg, k.RepeatNext):
+		m.changeRepeat(1)
+
+	case key.Matches(msg, k.TimeLeft):
+		// Move time focus left
+		if m.timeFocus > 0 {
+			m.timeFocus--
 		}
-		return m, nil
 
-	case "right", "l":
-		if m.step == 2 {
-			// Move time focus right
-			if m.timeFocus < 3 {
-				m.timeFocus++
-			}
+	case key.Matches(msg, k.TimeRight):
+		// Move time focus right
+		if m.timeFocus < 3 {
+			m.timeFocus++
 		}
-		return m, nil
 	}
 
 	return m, nil
 }
 
+// bookingFormKeyMap lists the booking form's keys. Each step enables its
+// own; the room picker has its own keymap for the first step. TimeLeft,
+// Later, StepLater, Preset, SetupPrev and RepeatPrev carry the hints for
+// their pairs.
+// UseNearby swaps a busy room for the nearest free one; Explain shows the
+// checks behind the availability verdict.
+type bookingFormKeyMap struct {
+	Picker      roomPickerKeyMap
+	TypeDate    key.Binding
+	NextField   key.Binding
+	PrevField   key.Binding
+	TimeLeft    key.Binding
+	TimeRight   key.Binding
+	TypeTime    key.Binding
+	ClearTime   key.Binding
+	Later       key.Binding
+	Earlier     key.Binding
+	StepLater   key.Binding
+	StepEarlier key.Binding
+	Preset      key.Binding
+	PrevPreset  key.Binding
+	SetupPrev   key.Binding
+	SetupNext   key.Binding
+	RepeatPrev  key.Binding
+	RepeatNext  key.Binding
+	UseNearby   key.Binding
+	Explain     key.Binding
+	promptKeyMap
+}
+
+// keyMap returns the keys of the current step
+func (m *BookingFormModel) keyMap() bookingFormKeyMap {
+	k := bookingFormKeyMap{
+		Picker:       m.roomPicker.keyMap(),
+		TypeDate:     hintKey("Type date"),
+		NextField:    newKey("Tab", "Next field", "tab"),
+		PrevField:    hiddenKey("shift+tab"),
+		TimeLeft:     newKey("h/l", "Switch field", "left", "h"),
+		TimeRight:    hiddenKey("right", "l"),
+		TypeTime:     newKey("0-9", "Type time", "0", "1", "2", "3", "4", "5", "6", "7", "8", "9"),
+		ClearTime:    hiddenKey("backspace"),
+		Later:        newKey("j/k or ↑↓", "Adjust time", "up", "k"),
+		Earlier:      hiddenKey("down", "j"),
+		StepLater:    newKey("+/-", "±15 min", "+", "="),
+		StepEarlier:  hiddenKey("-"),
+		Preset:       newKey("p/P", "Preset", "p"),
+		PrevPreset:   hiddenKey("P"),
+		SetupPrev:    newKey("←/→", "Change setup", "left", "h"),
+		SetupNext:    hiddenKey("right", "l"),
+		RepeatPrev:   newKey("←/→", "Change", "left", "h"),
+		RepeatNext:   hiddenKey("right", "l"),
+		UseNearby:    newKey("Ctrl+N", "Nearest free room", "ctrl+n"),
+		Explain:      newKey("Ctrl+E", "Why?", "ctrl+e"),
+		promptKeyMap: newPromptKeyMap("Continue"),
+	}
+	if m.step == 4 && m.editing != nil {
+		k.Submit.SetHelp("Enter", "Save changes")
+	} else if m.step == 4 && m.seriesBooked {
+		k.Submit.SetHelp("Enter", "Done")
+	} else if m.step == 4 && len(m.series) > 0 {
+		k.Submit.SetHelp("Enter", "Book series")
+	} else if m.step == 4 {
+		k.Submit.SetHelp("Enter", "Create booking")
+	} else if m.step != 3 {
+		// Tab moves between steps too, but it is only worth a hint among
+		// the details and repeat fields
+		k.NextField.SetHelp("", "")
+	}
+
+	onTimes := m.step == 2
+	for _, binding := range []*key.Binding{&k.TimeLeft, &k.TimeRight, &k.TypeTime, &k.ClearTime,
+		&k.Later, &k.Earlier, &k.StepLater, &k.StepEarlier, &k.Preset, &k.PrevPreset} {
+		binding.SetEnabled(onTimes)
+	}
+	if len(m.timeSlots) == 0 {
+		k.Preset.Unbind()
+		k.PrevPreset.Unbind()
+	}
+	// The setup is a choice; the other details fields take text
+	onSetup := m.step == 4 && m.detailsFocus == 2
+	k.SetupPrev.SetEnabled(onSetup)
+	k.SetupNext.SetEnabled(onSetup)
+	onRepeat := m.step == 3
+	k.RepeatPrev.SetEnabled(onRepeat)
+	k.RepeatNext.SetEnabled(onRepeat)
+	// An edited booking stays in its room
+	if m.step != 4 || m.checkingAvailability || m.isAvailable || len(m.nearbyRooms) == 0 || m.editing != nil {
+		k.UseNearby.Unbind()
+	}
+	if m.step != 4 {
+		k.Explain.Unbind()
+	} else if m.explaining {
+		k.Explain.SetHelp("Ctrl+E", "Hide why")
+	}
+	k.Explain.SetEnabled(!m.checkingAvailability && len(m.explanation) > 0)
+	return k
+}
+
+// CapturingInput reports whether keys should go to a text input rather
+// than the app's global shortcuts
+func (m *BookingFormModel) CapturingInput() bool {
+	return !m.success
+}
+
 // handleTabNavigation handles tab/shift+tab navigation
 func (m *BookingFormModel) handleTabNavigation(reverse bool) (tea.Model, tea.Cmd) {
 	if m.step == 3 {
+		// Two fields: how often, and how many times
+		m.repeatFocus = 1 - m.repeatFocus
+	}
+	if m.step == 4 {
 		// Navigate between detail fields
 		if reverse {
 			m.detailsFocus--
 			if m.detailsFocus < 0 {
-				m.detailsFocus = 2
+				m.detailsFocus = m.detailsFields() - 1
 			}
 		} else {
 			m.detailsFocus++
-			if m.detailsFocus > 2 {
+			if m.detailsFocus >= m.detailsFields() {
 				m.detailsFocus = 0
 			}
 		}
@@ -255,15 +500,6 @@ func (m *BookingFormModel) handleTabNavigation(reverse bool) (tea.Model, tea.Cmd
 // handleEnter progresses to next step or submits
 func (m *BookingFormModel) handleEnter() (tea.Model, tea.Cmd) {
 	switch m.step {
-	case 0:
-		// Room selected
-		if m.roomCursor < len(m.rooms) {
-			m.selectedRoom = &m.rooms[m.roomCursor]
-			m.step = 1
-			m.dateInput.Focus()
-			return m, textinput.Blink
-		}
-
 	case 1:
 		// Date entered, parse and validate
 		dateStr := strings.TrimSpace(m.dateInput.Value())
@@ -279,36 +515,73 @@ func (m *BookingFormModel) handleEnter() (tea.Model, tea.Cmd) {
 		m.selectedDate = parsedDate
 		m.error = ""
 		m.step = 2
-		return m, nil
+		return m, m.loadRoomDay()
 
 	case 2:
-		// Time selected, check availability
+		// Time selected, enforce the room's length limits before checking availability
+		if err := m.validateDuration(); err != "" {
+			m.error = err
+			return m, nil
+		}
+		m.error = ""
+		if m.editing != nil {
+			// An edited booking stays a single booking and already counts
+			// towards the quota
+			m.enterDetails()
+			return m, tea.Batch(textinput.Blink, m.checkAvailability())
+		}
 		m.step = 3
-		m.titleInput.Focus()
-		m.detailsFocus = 0
-		return m, tea.Batch(textinput.Blink, m.checkAvailability())
+		return m, nil
 
 	case 3:
+		return m.handleRepeatEnter()
+
+	case 4:
+		if m.editing != nil {
+			return m, m.submitChanges()
+		}
+		// Block submission when the quota policy says so
+		if m.quotaExceeded() && m.quota.Policy == milesapi.Block {
+			m.error = "This booking would exceed your " + string(m.quota.Period) + "ly quota"
+			return m, nil
+		}
+		if m.seriesBooked {
+			return m, func() tea.Msg { return BookingFormCompleteMsg{} }
+		}
 		// Submit form
+		if len(m.series) > 0 {
+			return m, m.submitSeries()
+		}
 		return m, m.submitBooking()
 	}
 
 	return m, nil
 }
 
+// enterDetails moves on to the details step with the title focused
+func (m *BookingFormModel) enterDetails() {
+	m.step = 4
+	m.titleInput.Focus()
+	m.detailsFocus = 0
+}
+
 // updateActiveInput updates the currently active text input
 func (m *BookingFormModel) updateActiveInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch m.step {
+	case 0:
+		m.roomPicker.filter, cmd = m.roomPicker.filter.Update(msg)
 	case 1:
 		m.dateInput, cmd = m.dateInput.Update(msg)
-	case 3:
+	case 4:
 		switch m.detailsFocus {
 		case 0:
 			m.titleInput, cmd = m.titleInput.Update(msg)
 		case 1:
 			m.descriptionInput, cmd = m.descriptionInput.Update(msg)
+		case 3:
+			m.setupNotesInput, cmd = m.setupNotesInput.Update(msg)
 		}
 	}
 
@@ -319,13 +592,46 @@ func (m *BookingFormModel) updateActiveInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m *BookingFormModel) updateDetailsFocus() {
 	m.titleInput.Blur()
 	m.descriptionInput.Blur()
+	m.setupNotesInput.Blur()
 
 	switch m.detailsFocus {
 	case 0:
 		m.titleInput.Focus()
 	case 1:
 		m.descriptionInput.Focus()
+	case 3:
+		m.setupNotesInput.Focus()
+	}
+}
+
+// cycleSetup steps through no setup request and the room layouts
+func (m *BookingFormModel) cycleSetup(delta int) {
+	options := len(milesapi.RoomSetups) + 1
+	m.setupIndex = (m.setupIndex + delta + options) % options
+}
+
+// selectedSetup returns the chosen room layout, empty for none
+func (m *BookingFormModel) selectedSetup() milesapi.RoomSetup {
+	if m.setupIndex == 0 {
+		return ""
+	}
+	return milesapi.RoomSetups[m.setupIndex-1]
+}
+
+// bookingInput is the request to book the selected room as the form
+// describes, leaving out what was left empty. The caller sets the times.
+func (m *BookingFormModel) bookingInput(title string) milesapi.BookingInput {
+	req := milesapi.BookingInput{RoomId: m.selectedRoom.GetId(), Title: title}
+	if description := strings.TrimSpace(m.descriptionInput.Value()); description != "" {
+		req.Description = &description
+	}
+	if setup := m.selectedSetup(); setup != "" {
+		req.Setup = &setup
 	}
+	if notes := strings.TrimSpace(m.setupNotesInput.Value()); notes != "" {
+		req.SetupNotes = &notes
+	}
+	return req
 }
 
 // incrementTime increments the currently focused time value
@@ -358,10 +664,151 @@ func (m *BookingFormModel) decrementTime() tea.Model {
 	return m
 }
 
+// typeTimeDigit types a digit into the focused time box. Two digits fill
+// the box and move on to the next, so typing 0745 sets 07:45. A first digit
+// no valid value starts with, like 7 for hours, fills the box on its own.
+func (m *BookingFormModel) typeTimeDigit(digit string) {
+	limit, name := 24, "Hours"
+	if m.timeFocus%2 == 1 {
+		limit, name = 60, "Minutes"
+	}
+
+	m.timeDigits += digit
+	value, _ := strconv.Atoi(m.timeDigits)
+	if len(m.timeDigits) == 1 && value*10 < limit {
+		// Wait for the second digit
+		return
+	}
+	m.timeDigits = ""
+	if value >= limit {
+		m.error = fmt.Sprintf("%s go from 00 to %02d", name, limit-1)
+		return
+	}
+
+	m.error = ""
+	m.setFocusedTime(value)
+	if m.timeFocus < 3 {
+		m.timeFocus++
+	}
+}
+
+// commitTimeDigits applies a lone typed digit, so 9 then → means 09
+func (m *BookingFormModel) commitTimeDigits() {
+	if m.timeDigits == "" {
+		return
+	}
+	value, _ := strconv.Atoi(m.timeDigits)
+	m.timeDigits = ""
+	m.error = ""
+	m.setFocusedTime(value)
+}
+
+// setFocusedTime sets the focused hour or minute
+func (m *BookingFormModel) setFocusedTime(value int) {
+	switch m.timeFocus {
+	case 0:
+		m.startHour = value
+	case 1:
+		m.startMinute = value
+	case 2:
+		m.endHour = value
+	case 3:
+		m.endMinute = value
+	}
+}
+
+// shiftFocusedTime moves the focused time (start or end) by minutes,
+// carrying into the hour and wrapping around midnight
+func (m *BookingFormModel) shiftFocusedTime(minutes int) {
+	hour, minute := &m.startHour, &m.startMinute
+	if m.timeFocus >= 2 {
+		hour, minute = &m.endHour, &m.endMinute
+	}
+	total := ((*hour*60+*minute+minutes)%(24*60) + 24*60) % (24 * 60)
+	*hour, *minute = total/60, total%60
+}
+
+// parseClock reads a wall-clock "HH:MM"
+func parseClock(value string) (hour, minute int, ok bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, false
+	}
+	return t.Hour(), t.Minute(), true
+}
+
+// currentTimeSlot returns the index of the time slot the selected times
+// match, or -1
+func (m *BookingFormModel) currentTimeSlot() int {
+	for i, slot := range m.timeSlots {
+		startHour, startMinute, okStart := parseClock(slot.StartTime)
+		endHour, endMinute, okEnd := parseClock(slot.EndTime)
+		if okStart && okEnd && startHour == m.startHour && startMinute == m.startMinute &&
+			endHour == m.endHour && endMinute == m.endMinute {
+			return i
+		}
+	}
+	return -1
+}
+
+// cycleTimeSlot sets the times to the next (or previous) time slot
+func (m *BookingFormModel) cycleTimeSlot(delta int) {
+	if len(m.timeSlots) == 0 {
+		return
+	}
+	i := m.currentTimeSlot()
+	if i < 0 && delta < 0 {
+		i = 0
+	}
+	i = ((i+delta)%len(m.timeSlots) + len(m.timeSlots)) % len(m.timeSlots)
+
+	slot := m.timeSlots[i]
+	startHour, startMinute, okStart := parseClock(slot.StartTime)
+	endHour, endMinute, okEnd := parseClock(slot.EndTime)
+	if !okStart || !okEnd {
+		return
+	}
+	m.timeDigits = ""
+	m.error = ""
+	m.startHour, m.startMinute = startHour, startMinute
+	m.endHour, m.endMinute = endHour, endMinute
+}
+
+// renderTimeSlots lists the time slots, the one the times match
+// highlighted. Slots that don't fit on the line move to the next, lined up
+// under the first.
+func (m *BookingFormModel) renderTimeSlots() string {
+	label := m.styles.Text.Render("Presets (p): ")
+	indent := strings.Repeat(" ", lipgloss.Width(label))
+	sep := m.styles.TextMuted.Render(" • ")
+
+	current := m.currentTimeSlot()
+	line := label
+	var lines []string
+	for i, slot := range m.timeSlots {
+		text := fmt.Sprintf("%s %s–%s", slot.Name, slot.StartTime, slot.EndTime)
+		part := m.styles.TextMuted.Render(text)
+		if i == current {
+			part = m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render("▶ " + text)
+		}
+
+		switch {
+		case i == 0:
+			line += part
+		case m.width > 0 && lipgloss.Width(line+sep+part) > m.width:
+			lines = append(lines, line)
+			line = indent + part
+		default:
+			line += sep + part
+		}
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
 // View renders the form
 func (m *BookingFormModel) View() string {
 	if m.loadingRooms {
-		return m.styles.Title.Render("Create Booking") + "\n\n" +
+		return m.styles.Title.Render(m.formTitle()) + "\n\n" +
 			m.styles.TextMuted.Render("Loading rooms...")
 	}
 
@@ -384,6 +831,8 @@ func (m *BookingFormModel) View() string {
 	case 2:
 		b.WriteString(m.renderTimeSelection())
 	case 3:
+		b.WriteString(m.renderRepeatSelection())
+	case 4:
 		b.WriteString(m.renderDetailsForm())
 	}
 
@@ -396,14 +845,23 @@ func (m *BookingFormModel) View() string {
 	b.WriteString("\n\n")
 	b.WriteString(m.renderHelp())
 
-	return b.String()
+	return wrapToWidth(b.String(), m.width)
+}
+
+// fitInputs narrows the text inputs on small screens; the prompt and
+// cursor take the margin
+func (m *BookingFormModel) fitInputs() {
+	m.dateInput.Width = fitWidth(m.width, 4, 10, 20)
+	for _, input := range []*textinput.Model{&m.titleInput, &m.descriptionInput, &m.setupNotesInput} {
+		input.Width = fitWidth(m.width, 4, 10, 40)
+	}
 }
 
 // renderHeader renders the form header with progress
 func (m *BookingFormModel) renderHeader() string {
-	title := m.styles.Title.Render("Create Booking")
+	title := m.styles.Title.Render(m.formTitle())
 
-	stepNames := []string{"Room", "Date", "Time", "Details"}
+	stepNames := []string{"Room", "Date", "Time", "Repeat", "Details"}
 	var steps []string
 	for i, name := range stepNames {
 		if i < m.step {
@@ -416,6 +874,11 @@ func (m *BookingFormModel) renderHeader() string {
 	}
 
 	progress := strings.Join(steps, " ")
+	if m.width > 0 && lipgloss.Width(progress) > m.width {
+		// Only the current step when all five don't fit
+		progress = m.styles.TextBold.Foreground(m.styles.Colors.Primary).
+			Render(fmt.Sprintf("▶ %s (%d/%d)", stepNames[m.step], m.step+1, len(stepNames)))
+	}
 
 	return title + "\n" + progress
 }
@@ -426,28 +889,7 @@ func (m *BookingFormModel) renderRoomSelection() string {
 
 	b.WriteString(m.styles.Heading.Render("Select a Room"))
 	b.WriteString("\n\n")
-
-	if len(m.rooms) == 0 {
-		b.WriteString(m.styles.TextMuted.Render("No rooms available"))
-		return b.String()
-	}
-
-	// Show rooms list
-	for i, room := range m.rooms {
-		cursor := "  "
-		nameStyle := m.styles.Text
-		if i == m.roomCursor {
-			cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
-			nameStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
-		}
-
-		name := nameStyle.Render(room.Name)
-		location := m.styles.TextMuted.Render(room.Location.Name)
-		capacity := m.styles.TextMuted.Render(fmt.Sprintf("Capacity: %d", room.Capacity))
-
-		b.WriteString(cursor + name + " • " + location + " • " + capacity)
-		b.WriteString("\n")
-	}
+	b.WriteString(m.roomPicker.View())
 
 	return b.String()
 }
@@ -461,7 +903,7 @@ func (m *BookingFormModel) renderDateSelection() string {
 
 	if m.selectedRoom != nil {
 		b.WriteString(m.styles.Text.Render("Room: "))
-		b.WriteString(m.styles.TextBold.Render(m.selectedRoom.Name))
+		b.WriteString(m.styles.TextBold.Render(m.selectedRoom.GetName()))
 		b.WriteString("\n\n")
 	}
 
@@ -484,6 +926,11 @@ func (m *BookingFormModel) renderTimeSelection() string {
 	b.WriteString(m.styles.TextBold.Render(dateStr))
 	b.WriteString("\n\n")
 
+	if len(m.timeSlots) > 0 {
+		b.WriteString(m.renderTimeSlots())
+		b.WriteString("\n\n")
+	}
+
 	// Start time
 	b.WriteString(m.styles.Text.Render("Start Time:"))
 	b.WriteString("\n")
@@ -495,9 +942,130 @@ func (m *BookingFormModel) renderTimeSelection() string {
 	b.WriteString("\n")
 	b.WriteString(m.renderTimePicker(2, 3))
 
+	// Duration and room limits
+	if minutes := m.durationMinutes(); minutes > 0 {
+		b.WriteString("\n\n")
+		durationText := "Duration: " + utils.FormatMinutes(minutes)
+		if m.validateDuration() != "" {
+			b.WriteString(m.styles.TextWarning.Render("⚠ " + durationText))
+		} else {
+			b.WriteString(m.styles.TextMuted.Render(durationText))
+		}
+	}
+	if m.selectedRoom != nil {
+		if limits := utils.FormatDurationLimits(m.selectedRoom.GetMinDurationMinutes(), m.selectedRoom.GetMaxDurationMinutes()); limits != "" {
+			b.WriteString("\n")
+			b.WriteString(m.styles.TextMuted.Render("This room allows bookings of " + limits))
+		}
+	}
+
+	// The room's day with the chosen slot on top, updated as times change
+	b.WriteString("\n\n")
+	if m.dayLoading {
+		b.WriteString(m.styles.TextMuted.Render("Loading the room's schedule..."))
+	} else {
+		start, end := m.slotTimes()
+		b.WriteString(renderDayTimeline(m.styles, m.selectedDate, m.dayBookings, start, end, m.width))
+	}
+
 	return b.String()
 }
 
+// slotTimes returns the selected start and end on the selected date
+func (m *BookingFormModel) slotTimes() (start, end time.Time) {
+	date := m.selectedDate
+	start = time.Date(date.Year(), date.Month(), date.Day(), m.startHour, m.startMinute, 0, 0, date.Location())
+	end = time.Date(date.Year(), date.Month(), date.Day(), m.endHour, m.endMinute, 0, 0, date.Location())
+	return start, end
+}
+
+// loadRoomDay loads the room's bookings on the selected date for the
+// timeline. Failures leave it empty; the availability check still runs.
+func (m *BookingFormModel) loadRoomDay() tea.Cmd {
+	if m.selectedRoom == nil {
+		return nil
+	}
+	m.dayLoading = true
+	roomID := m.selectedRoom.GetId()
+	date := m.selectedDate
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	ctx := m.requestCtx()
+	return func() tea.Msg {
+		bookings, err := m.client.GetRoomAvailability(ctx, roomID, dayStart, dayStart.AddDate(0, 0, 1))
+		if err != nil {
+			return RoomDayLoadedMsg{}
+		}
+		return RoomDayLoadedMsg{Bookings: bookings}
+	}
+}
+
+// quotaExceeded returns whether the selected slot would go over the quota
+func (m *BookingFormModel) quotaExceeded() bool {
+	return m.quota != nil && m.quota.Exceeds(float64(m.durationMinutes())/60)
+}
+
+// loadQuota loads the user's quota for the selected date. Failures are
+// ignored; the server still enforces the quota on submit.
+func (m *BookingFormModel) loadQuota() tea.Cmd {
+	ctx := m.requestCtx()
+	date := m.selectedDate
+	return func() tea.Msg {
+		quota, err := m.client.GetQuota(ctx, date)
+		if err != nil {
+			return BookingQuotaMsg{}
+		}
+		return BookingQuotaMsg{Quota: quota}
+	}
+}
+
+// durationMinutes returns the length of the selected time slot in minutes
+func (m *BookingFormModel) durationMinutes() int {
+	return (m.endHour*60 + m.endMinute) - (m.startHour*60 + m.startMinute)
+}
+
+// fitDurationToRoom moves the end time so the default slot respects the
+// selected room's booking length limits
+func (m *BookingFormModel) fitDurationToRoom() {
+	if m.selectedRoom == nil {
+		return
+	}
+
+	minutes := m.durationMinutes()
+	if minutes <= 0 {
+		minutes = 60
+	}
+	if m.selectedRoom.GetMinDurationMinutes() > 0 {
+		minutes = max(minutes, m.selectedRoom.GetMinDurationMinutes())
+	}
+	if m.selectedRoom.GetMaxDurationMinutes() > 0 {
+		minutes = min(minutes, m.selectedRoom.GetMaxDurationMinutes())
+	}
+
+	end := (m.startHour*60 + m.startMinute + minutes) % (24 * 60)
+	m.endHour = end / 60
+	m.endMinute = end % 60
+}
+
+// validateDuration checks the selected slot against the room's booking
+// length limits and returns a user-facing error, or "" if it is allowed
+func (m *BookingFormModel) validateDuration() string {
+	minutes := m.durationMinutes()
+	if minutes <= 0 || m.selectedRoom == nil {
+		// Start/end ordering is reported by the availability check
+		return ""
+	}
+
+	room := m.selectedRoom
+	if room.GetMinDurationMinutes() > 0 && minutes < room.GetMinDurationMinutes() {
+		return fmt.Sprintf("%s must be booked for at least %s", room.GetName(), utils.FormatMinutes(room.GetMinDurationMinutes()))
+	}
+	if room.GetMaxDurationMinutes() > 0 && minutes > room.GetMaxDurationMinutes() {
+		return fmt.Sprintf("%s can be booked for at most %s", room.GetName(), utils.FormatMinutes(room.GetMaxDurationMinutes()))
+	}
+	return ""
+}
+
 // renderTimePicker renders a time picker (hour and minute)
 func (m *BookingFormModel) renderTimePicker(hourFocus, minuteFocus int) string {
 	var hour, minute int
@@ -519,8 +1087,18 @@ func (m *BookingFormModel) renderTimePicker(hourFocus, minuteFocus int) string {
 		minuteStyle = m.styles.Box.BorderForeground(m.styles.Colors.Primary)
 	}
 
-	hourBox := hourStyle.Render(fmt.Sprintf(" %02d ", hour))
-	minuteBox := minuteStyle.Render(fmt.Sprintf(" %02d ", minute))
+	hourText := fmt.Sprintf(" %02d ", hour)
+	minuteText := fmt.Sprintf(" %02d ", minute)
+	// Show a half-typed value with a cursor in place of the missing digit
+	if m.timeDigits != "" && m.timeFocus == hourFocus {
+		hourText = " " + m.timeDigits + "_ "
+	}
+	if m.timeDigits != "" && m.timeFocus == minuteFocus {
+		minuteText = " " + m.timeDigits + "_ "
+	}
+
+	hourBox := hourStyle.Render(hourText)
+	minuteBox := minuteStyle.Render(minuteText)
 
 	return lipgloss.JoinHorizontal(lipgloss.Left, hourBox, " : ", minuteBox)
 }
@@ -538,14 +1116,23 @@ func (m *BookingFormModel) renderDetailsForm() string {
 	endTime := fmt.Sprintf("%02d:%02d", m.endHour, m.endMinute)
 
 	b.WriteString(m.styles.Text.Render("Room: "))
-	b.WriteString(m.styles.TextBold.Render(m.selectedRoom.Name))
+	b.WriteString(m.styles.TextBold.Render(m.selectedRoom.GetName()))
 	b.WriteString("\n")
 	b.WriteString(m.styles.Text.Render("When: "))
 	b.WriteString(m.styles.TextBold.Render(fmt.Sprintf("%s from %s to %s", dateStr, startTime, endTime)))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if len(m.series) > 0 {
+		b.WriteString(m.styles.Text.Render("Repeats: "))
+		b.WriteString(m.styles.TextBold.Render(m.describeRepeat()))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
-	// Availability status
-	if m.checkingAvailability {
+	// Availability status, of each occurrence for a series
+	if len(m.series) > 0 {
+		b.WriteString(m.renderSeries())
+		b.WriteString("\n\n")
+	} else if m.checkingAvailability {
 		b.WriteString(m.styles.TextMuted.Render("Checking availability..."))
 		b.WriteString("\n\n")
 	} else if m.availabilityError != "" {
@@ -554,10 +1141,43 @@ func (m *BookingFormModel) renderDetailsForm() string {
 	} else if !m.isAvailable {
 		b.WriteString(m.styles.TextError.Render("✗ Room not available for this time slot"))
 		b.WriteString("\n\n")
+		if len(m.nearbyRooms) > 0 {
+			b.WriteString(m.styles.Text.Render("Free rooms nearby:"))
+			b.WriteString("\n")
+			for _, room := range m.nearbyRooms {
+				details := []string{fmt.Sprintf("%d seats", room.GetCapacity())}
+				if where := milesapi.DescribeNearness(*m.selectedRoom, room); where != "" {
+					details = append([]string{where}, details...)
+				}
+				b.WriteString("  " + m.styles.TextBold.Render(room.GetName()) + " " +
+					m.styles.TextMuted.Render("("+strings.Join(details, ", ")+")"))
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
 	} else {
 		b.WriteString(m.styles.TextSuccess.Render("✓ Room is available"))
 		b.WriteString("\n\n")
 	}
+	if m.explaining && !m.checkingAvailability && len(m.explanation) > 0 {
+		b.WriteString(m.renderExplanation())
+		b.WriteString("\n")
+	}
+
+	// Quota status
+	if m.quota != nil && m.quota.LimitHours > 0 {
+		usage := "Quota: " + utils.FormatQuota(float64(m.quota.UsedHours), float64(m.quota.LimitHours), string(m.quota.Period)) +
+			" (+" + utils.FormatMinutes(m.durationMinutes()) + ")"
+		switch {
+		case m.quotaExceeded() && m.quota.Policy == milesapi.Block:
+			b.WriteString(m.styles.TextError.Render("✗ " + usage + " - over quota"))
+		case m.quotaExceeded():
+			b.WriteString(m.styles.TextWarning.Render("⚠ " + usage + " - over quota"))
+		default:
+			b.WriteString(m.styles.TextMuted.Render(usage))
+		}
+		b.WriteString("\n\n")
+	}
 
 	// Title field
 	titleLabel := "Title:"
@@ -577,12 +1197,47 @@ func (m *BookingFormModel) renderDetailsForm() string {
 	b.WriteString(descriptionLabel)
 	b.WriteString("\n")
 	b.WriteString(m.descriptionInput.View())
+	if m.editing != nil {
+		return b.String()
+	}
+	b.WriteString("\n\n")
+
+	// Setup request for facilities
+	setupLabel := "Room setup:"
+	if m.detailsFocus == 2 {
+		setupLabel = m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render("Room setup:")
+	}
+	b.WriteString(setupLabel)
+	b.WriteString("\n")
+	b.WriteString("◀ " + m.selectedSetup().Label() + " ▶")
+	b.WriteString("\n\n")
+
+	setupNotesLabel := "Setup notes (optional):"
+	if m.detailsFocus == 3 {
+		setupNotesLabel = m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render("Setup notes (optional):")
+	}
+	b.WriteString(setupNotesLabel)
+	b.WriteString("\n")
+	b.WriteString(m.setupNotesInput.View())
 
 	return b.String()
 }
 
+// formTitle is the form's title: creating or editing
+func (m *BookingFormModel) formTitle() string {
+	if m.editing != nil {
+		return "Edit Booking"
+	}
+	return "Create Booking"
+}
+
 // renderSuccess renders success message
 func (m *BookingFormModel) renderSuccess() string {
+	if m.editing != nil {
+		return m.styles.Title.Render("Booking Updated!") + "\n\n" +
+			m.styles.TextSuccess.Render("✓ Your changes have been saved") + "\n\n" +
+			m.styles.Help.Render("Press any key to return to bookings...")
+	}
 	return m.styles.Title.Render("Booking Created!") + "\n\n" +
 		m.styles.TextSuccess.Render("✓ Your booking has been created successfully") + "\n\n" +
 		m.styles.Help.Render("Press any key to return to bookings...")
@@ -590,47 +1245,63 @@ func (m *BookingFormModel) renderSuccess() string {
 
 // renderHelp renders help text
 func (m *BookingFormModel) renderHelp() string {
-	var help []string
+	k := m.keyMap()
+	var bindings []key.Binding
 
 	switch m.step {
 	case 0:
-		help = []string{"j/k or ↑↓: Navigate", "Enter: Select", "Esc: Cancel"}
+		bindings = []key.Binding{k.Picker.Filter, k.Picker.Up, k.Picker.Collapse, k.Picker.Select, k.Picker.RequestAccess, k.Cancel}
 	case 1:
-		help = []string{"Type date", "Enter: Continue", "Esc: Cancel"}
+		bindings = []key.Binding{k.TypeDate, k.Submit, k.Cancel}
 	case 2:
-		help = []string{"h/l: Switch field", "j/k or ↑↓: Adjust time", "Enter: Continue", "Esc: Cancel"}
+		bindings = []key.Binding{k.TimeLeft, k.TypeTime, k.Later, k.StepLater, k.Preset, k.Submit, k.Cancel}
 	case 3:
-		help = []string{"Tab: Next field", "Enter: Create booking", "Esc: Cancel"}
+		bindings = []key.Binding{k.NextField, k.RepeatPrev, k.Submit, k.Cancel}
+	case 4:
+		bindings = []key.Binding{k.NextField, k.SetupPrev, k.UseNearby, k.Explain, k.Submit, k.Cancel}
 	}
 
-	return m.styles.Help.Render(strings.Join(help, " • "))
+	return renderFooter(m.styles, m.width, bindings...)
 }
 
 // loadRooms loads available rooms
 func (m *BookingFormModel) loadRooms() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
-		rooms, err := m.client.GetRooms(nil, nil, nil)
+		rooms, err := m.client.GetRooms(ctx, "")
 		if err != nil {
-			return RoomsLoadedMsg{Rooms: []models.Room{}}
+			return RoomsLoadedMsg{Rooms: []milesapi.Room{}}
 		}
-		return RoomsLoadedMsg{Rooms: rooms}
+		return RoomsLoadedMsg{Rooms: milesapi.MeetingRooms(rooms)}
+	}
+}
+
+// loadTimeSlots loads the organization's time slots. Failures only mean
+// no presets are offered.
+func (m *BookingFormModel) loadTimeSlots() tea.Cmd {
+	ctx := m.requestCtx()
+	return func() tea.Msg {
+		slots, err := m.client.GetTimeSlots(ctx)
+		if err != nil {
+			return TimeSlotsLoadedMsg{}
+		}
+		return TimeSlotsLoadedMsg{Slots: slots}
 	}
 }
 
 // checkAvailability checks if selected time slot is available
 func (m *BookingFormModel) checkAvailability() tea.Cmd {
+	ctx := m.requestCtx()
 	m.checkingAvailability = true
+	room := *m.selectedRoom
+	// An edited booking doesn't get in its own way
+	var editingID string
+	if m.editing != nil {
+		editingID = m.editing.GetId()
+	}
 
 	return func() tea.Msg {
-		// Build start and end times
-		startTime := time.Date(
-			m.selectedDate.Year(), m.selectedDate.Month(), m.selectedDate.Day(),
-			m.startHour, m.startMinute, 0, 0, m.selectedDate.Location(),
-		)
-		endTime := time.Date(
-			m.selectedDate.Year(), m.selectedDate.Month(), m.selectedDate.Day(),
-			m.endHour, m.endMinute, 0, 0, m.selectedDate.Location(),
-		)
+		startTime, endTime := m.slotTimes()
 
 		if startTime.After(endTime) || startTime.Equal(endTime) {
 			return AvailabilityCheckedMsg{
@@ -639,7 +1310,16 @@ func (m *BookingFormModel) checkAvailability() tea.Cmd {
 			}
 		}
 
-		available, err := m.client.CheckRoomAvailability(m.selectedRoom.ID, startTime, endTime)
+		// Nothing can be booked while the room's location is closed
+		if closure := roomClosure(ctx, m.client, room, startTime, endTime); closure != "" {
+			return AvailabilityCheckedMsg{
+				Available:   false,
+				Error:       closure,
+				Explanation: explainAvailability(ctx, m.client, room, startTime, endTime, closure, false, editingID),
+			}
+		}
+
+		available, err := m.client.CheckRoomAvailabilityExcept(ctx, room.GetId(), startTime, endTime, editingID)
 		if err != nil {
 			return AvailabilityCheckedMsg{
 				Available: false,
@@ -647,15 +1327,39 @@ func (m *BookingFormModel) checkAvailability() tea.Cmd {
 			}
 		}
 
+		// Offer the free rooms physically closest to a busy one
+		var nearby []milesapi.Room
+		if !available && editingID == "" {
+			nearby = findNearbyRooms(ctx, m.client, room, startTime, endTime, 3)
+		}
+
 		return AvailabilityCheckedMsg{
-			Available: available,
-			Error:     "",
+			Available:   available,
+			Error:       "",
+			Nearby:      nearby,
+			Explanation: explainAvailability(ctx, m.client, room, startTime, endTime, "", available, editingID),
 		}
 	}
 }
 
+// roomClosure explains why the room's location is closed during a slot,
+// e.g. "Office closed Dec 25, 2025 (Christmas Day)", or returns "". The
+// server has the final say, so failing to look returns "".
+func roomClosure(ctx context.Context, client *api.Client, room milesapi.Room, startTime, endTime time.Time) string {
+	if room.GetLocationId() == "" {
+		return ""
+	}
+	closures, err := client.GetLocationHolidays(ctx, room.GetLocationId(),
+		startTime.AddDate(0, 0, -1).Format(time.DateOnly), endTime.AddDate(0, 0, 1).Format(time.DateOnly))
+	if err != nil {
+		return ""
+	}
+	return closures.Closure(startTime, endTime)
+}
+
 // submitBooking submits the booking to the API
 func (m *BookingFormModel) submitBooking() tea.Cmd {
+	ctx := m.requestCtx()
 	m.submitting = true
 
 	return func() tea.Msg {
@@ -666,29 +1370,25 @@ func (m *BookingFormModel) submitBooking() tea.Cmd {
 			return nil
 		}
 
-		// Build start and end times
-		startTime := time.Date(
-			m.selectedDate.Year(), m.selectedDate.Month(), m.selectedDate.Day(),
-			m.startHour, m.startMinute, 0, 0, m.selectedDate.Location(),
-		)
-		endTime := time.Date(
-			m.selectedDate.Year(), m.selectedDate.Month(), m.selectedDate.Day(),
-			m.endHour, m.endMinute, 0, 0, m.selectedDate.Location(),
-		)
-
-		// Get description (optional)
-		description := strings.TrimSpace(m.descriptionInput.Value())
-
 		// Create booking request
-		req := models.CreateBookingRequest{
-			RoomID:      m.selectedRoom.ID,
-			StartTime:   startTime,
-			EndTime:     endTime,
-			Title:       title,
-			Description: description,
+		req := m.bookingInput(title)
+		req.StartTime, req.EndTime = m.slotTimes()
+
+		booking, err := m.client.CreateBooking(ctx, req)
+		var conflict *api.ConflictError
+		if errors.As(err, &conflict) {
+			// Someone got there first: say who, and that the slot is gone
+			m.error = "Could not book: " + conflict.Error()
+			m.isAvailable = false
+			m.submitting = false
+			return nil
+		}
+		var restricted *api.RestrictedRoomError
+		if errors.As(err, &restricted) {
+			m.error = "Could not book: " + restricted.Error()
+			m.submitting = false
+			return nil
 		}
-
-		booking, err := m.client.CreateBooking(req)
 		if err != nil {
 			m.error = fmt.Sprintf("Failed to create booking: %v", err)
 			m.submitting = false
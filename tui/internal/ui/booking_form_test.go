@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// ptr returns a pointer to v, for the API's optional fields
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestBookingFormResize(t *testing.T) {
+	rooms := []milesapi.Room{
+		{Id: ptr("r1"), Name: ptr("Møterom Ærlig"), Capacity: ptr(8), Amenities: &[]string{"Whiteboard", "Skjerm"}, Location: &milesapi.Location{Name: ptr("Oslo")}},
+		{Id: ptr("r2"), Name: ptr("Økonomiavdelingens store møterom 🎉"), Capacity: ptr(40), Amenities: &[]string{"Videokonferanse"}, Location: &milesapi.Location{Name: ptr("Stavanger")}},
+	}
+	steps := []string{"room", "date", "time", "repeat", "details"}
+
+	for step, name := range steps {
+		t.Run(name, func(t *testing.T) {
+			m := NewBookingFormModel(nil, styles.DefaultStyles(), nil)
+			m.Update(RoomsLoadedMsg{Rooms: rooms})
+			if step > 0 {
+				m.selectedRoom = &rooms[1]
+			}
+			m.step = step
+			m.error = "Rommet er allerede booket i dette tidsrommet"
+
+			for _, size := range resizes {
+				t.Run(fmt.Sprintf("%dx%d", size.Width, size.Height), func(t *testing.T) {
+					m.Update(size)
+					view := m.View()
+
+					checkFits(t, view, size.Width)
+					if !strings.HasPrefix(view, wrapToWidth(m.renderHeader(), size.Width)) {
+						t.Errorf("header not at the top:\n%s", view)
+					}
+					if !strings.HasSuffix(view, wrapToWidth(m.renderHelp(), size.Width)) {
+						t.Errorf("help not at the bottom:\n%s", view)
+					}
+				})
+			}
+		})
+	}
+}
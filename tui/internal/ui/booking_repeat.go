@@ -0,0 +1,311 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/recurrence"
+)
+
+// occurrenceStatus is where an occurrence of a series stands
+type occurrenceStatus int
+
+const (
+	occurrenceChecking occurrenceStatus = iota
+	occurrenceFree
+	occurrenceSkipped
+	occurrenceBooked
+	occurrenceFailed
+)
+
+// seriesOccurrence is one booking of a repeating series and, once checked
+// or booked, how that went
+type seriesOccurrence struct {
+	recurrence.Occurrence
+	status occurrenceStatus
+	reason string
+}
+
+// SeriesCheckedMsg contains the series with each occurrence free or
+// skipped
+type SeriesCheckedMsg struct {
+	Series []seriesOccurrence
+}
+
+// seriesListLimit caps how many occurrences the details step lists
+const seriesListLimit = 12
+
+// frequency returns the chosen frequency, empty when the booking does not
+// repeat
+func (m *BookingFormModel) frequency() recurrence.Frequency {
+	if m.repeatIndex == 0 {
+		return ""
+	}
+	return recurrence.Frequencies[m.repeatIndex-1]
+}
+
+// changeRepeat changes the focused repeat field: the frequency, or the
+// number of occurrences within 2 and recurrence.MaxOccurrences
+func (m *BookingFormModel) changeRepeat(delta int) {
+	if m.repeatFocus == 0 {
+		options := len(recurrence.Frequencies) + 1
+		m.repeatIndex = (m.repeatIndex + delta + options) % options
+		return
+	}
+	if m.repeatIndex != 0 {
+		m.repeatCount = min(max(m.repeatCount+delta, 2), recurrence.MaxOccurrences)
+	}
+}
+
+// expandRepeat returns the occurrences of the chosen series
+func (m *BookingFormModel) expandRepeat() ([]recurrence.Occurrence, error) {
+	start, end := m.slotTimes()
+	return recurrence.Expand(m.frequency(), start, end, m.repeatCount, time.Time{})
+}
+
+// handleRepeatEnter leaves the repeat step: a single booking is checked as
+// before, a series expanded and each occurrence checked. The quota is left
+// to the server for a series.
+func (m *BookingFormModel) handleRepeatEnter() (tea.Model, tea.Cmd) {
+	if m.repeatIndex == 0 {
+		m.series = nil
+		m.enterDetails()
+		return m, tea.Batch(textinput.Blink, m.checkAvailability(), m.loadQuota())
+	}
+
+	expanded, err := m.expandRepeat()
+	if err != nil {
+		m.error = err.Error()
+		return m, nil
+	}
+	m.series = make([]seriesOccurrence, len(expanded))
+	for i, o := range expanded {
+		m.series[i] = seriesOccurrence{Occurrence: o}
+	}
+	m.error = ""
+	m.enterDetails()
+	return m, tea.Batch(textinput.Blink, m.checkSeries())
+}
+
+// describeRepeat says how often and how many times the series repeats,
+// e.g. "every Monday, 10 times until Dec 22, 2025"
+func (m *BookingFormModel) describeRepeat() string {
+	start, _ := m.slotTimes()
+	description := m.frequency().Describe(start)
+	expanded, err := m.expandRepeat()
+	if err != nil {
+		return description
+	}
+	last := expanded[len(expanded)-1].Start
+	return fmt.Sprintf("%s, %d times until %s", description, len(expanded), last.Format("Jan 2, 2006"))
+}
+
+// renderRepeatSelection renders step 3
+func (m *BookingFormModel) renderRepeatSelection() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Heading.Render("Repeat"))
+	b.WriteString("\n\n")
+
+	start, end := m.slotTimes()
+	b.WriteString(m.styles.Text.Render("First: "))
+	b.WriteString(m.styles.TextBold.Render(fmt.Sprintf("%s from %s to %s",
+		start.Format("Mon, Jan 2, 2006"), start.Format("15:04"), end.Format("15:04"))))
+	b.WriteString("\n\n")
+
+	frequencyLabel := "Repeats:"
+	if m.repeatFocus == 0 {
+		frequencyLabel = m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render(frequencyLabel)
+	}
+	frequency := "Does not repeat"
+	if m.repeatIndex != 0 {
+		frequency = m.frequency().Describe(start)
+	}
+	b.WriteString(frequencyLabel)
+	b.WriteString("\n")
+	b.WriteString("◀ " + frequency + " ▶")
+
+	if m.repeatIndex == 0 {
+		return b.String()
+	}
+
+	countLabel := "Ends after:"
+	if m.repeatFocus == 1 {
+		countLabel = m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render(countLabel)
+	}
+	b.WriteString("\n\n")
+	b.WriteString(countLabel)
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("◀ %d times ▶", m.repeatCount))
+	if expanded, err := m.expandRepeat(); err == nil {
+		last := expanded[len(expanded)-1].Start
+		b.WriteString(m.styles.TextMuted.Render("  last on " + last.Format("Mon, Jan 2, 2006")))
+	}
+
+	return b.String()
+}
+
+// renderSeries lists the occurrences of the series with where each
+// stands, and how many can be booked
+func (m *BookingFormModel) renderSeries() string {
+	var b strings.Builder
+
+	free, booked := 0, 0
+	for i, occurrence := range m.series {
+		switch occurrence.status {
+		case occurrenceFree:
+			free++
+		case occurrenceBooked:
+			booked++
+		}
+		if i >= seriesListLimit {
+			continue
+		}
+		when := occurrence.Start.Format("Mon Jan 2 15:04") + "–" + occurrence.End.Format("15:04")
+		switch occurrence.status {
+		case occurrenceChecking:
+			b.WriteString(m.styles.TextMuted.Render("  · " + when + "  checking..."))
+		case occurrenceFree:
+			b.WriteString(m.styles.Text.Render("  · " + when + "  free"))
+		case occurrenceBooked:
+			b.WriteString(m.styles.TextSuccess.Render("  ✓ " + when + "  booked"))
+		case occurrenceFailed:
+			b.WriteString(m.styles.TextError.Render("  ✗ " + when + "  failed: " + occurrence.reason))
+		default:
+			b.WriteString(m.styles.TextWarning.Render("  - " + when + "  skipped: " + occurrence.reason))
+		}
+		b.WriteString("\n")
+	}
+	if hidden := len(m.series) - seriesListLimit; hidden > 0 {
+		b.WriteString(m.styles.TextMuted.Render(fmt.Sprintf("  … and %d more", hidden)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.checkingAvailability:
+		b.WriteString(m.styles.TextMuted.Render("Checking availability..."))
+	case m.seriesBooked:
+		b.WriteString(m.styles.TextSuccess.Render(fmt.Sprintf("✓ Booked %d of %d occurrences", booked, len(m.series))))
+	case free == 0:
+		b.WriteString(m.styles.TextError.Render("✗ No occurrence of the series is free"))
+	default:
+		b.WriteString(m.styles.TextSuccess.Render(fmt.Sprintf("✓ %d of %d can be booked", free, len(m.series))))
+	}
+	return b.String()
+}
+
+// checkSeries marks each occurrence of the series free, or skipped with
+// why: in the past, on a day the room's location is closed, or the room
+// taken. A failed check leaves it to the server.
+func (m *BookingFormModel) checkSeries() tea.Cmd {
+	ctx := m.requestCtx()
+	m.checkingAvailability = true
+	room := *m.selectedRoom
+	series := append([]seriesOccurrence(nil), m.series...)
+
+	return func() tea.Msg {
+		first, last := series[0], series[len(series)-1]
+		var closures *milesapi.LocationHolidays
+		if room.GetLocationId() != "" {
+			closures, _ = m.client.GetLocationHolidays(ctx, room.GetLocationId(),
+				first.Start.AddDate(0, 0, -1).Format(time.DateOnly), last.End.AddDate(0, 0, 1).Format(time.DateOnly))
+		}
+
+		now := time.Now()
+		for i := range series {
+			occurrence := &series[i]
+			occurrence.status = occurrenceSkipped
+			switch {
+			case occurrence.Start.Before(now):
+				occurrence.reason = "in the past"
+			case closures != nil && closures.Closure(occurrence.Start, occurrence.End) != "":
+				occurrence.reason = closures.Closure(occurrence.Start, occurrence.End)
+			default:
+				if available, err := m.client.CheckRoomAvailability(ctx, room.GetId(), occurrence.Start, occurrence.End); err == nil && !available {
+					occurrence.reason = "room taken"
+				} else {
+					occurrence.status = occurrenceFree
+				}
+			}
+		}
+		return SeriesCheckedMsg{Series: series}
+	}
+}
+
+// submitSeries books every free occurrence of the series. When the server
+// refuses some, the form stays open listing them; otherwise it is done.
+func (m *BookingFormModel) submitSeries() tea.Cmd {
+	if m.checkingAvailability {
+		return nil
+	}
+	title := strings.TrimSpace(m.titleInput.Value())
+	if title == "" {
+		m.error = "Title is required"
+		return nil
+	}
+	free := 0
+	for _, occurrence := range m.series {
+		if occurrence.status == occurrenceFree {
+			free++
+		}
+	}
+	if free == 0 {
+		m.error = "Nothing to book: no occurrence of the series is free"
+		return nil
+	}
+	m.submitting = true
+
+	ctx := m.requestCtx()
+	return func() tea.Msg {
+		req := m.bookingInput(title)
+
+		var first *milesapi.Booking
+		failed := 0
+		for i := range m.series {
+			occurrence := &m.series[i]
+			if occurrence.status != occurrenceFree {
+				continue
+			}
+			req.StartTime, req.EndTime = occurrence.Start, occurrence.End
+			booking, err := m.client.CreateBooking(ctx, req)
+			if err != nil {
+				occurrence.status, occurrence.reason = occurrenceFailed, describeSeriesError(err)
+				failed++
+				continue
+			}
+			occurrence.status = occurrenceBooked
+			if first == nil {
+				first = booking
+			}
+		}
+
+		m.submitting = false
+		m.seriesBooked = true
+		if failed > 0 {
+			m.error = fmt.Sprintf("%d of %d occurrences could not be booked", failed, free)
+			return nil
+		}
+		m.success = true
+		return BookingFormCompleteMsg{Booking: first}
+	}
+}
+
+// describeSeriesError says why the server refused an occurrence
+func describeSeriesError(err error) string {
+	var conflict *api.ConflictError
+	if errors.As(err, &conflict) {
+		return conflict.Error()
+	}
+	var restricted *api.RestrictedRoomError
+	if errors.As(err, &restricted) {
+		return restricted.Error()
+	}
+	return err.Error()
+}
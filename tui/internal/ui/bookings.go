@@ -5,12 +5,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
+	"github.com/miles/booking-tui/internal/config"
 	"github.com/miles/booking-tui/internal/styles"
 	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/deeplink"
+	"github.com/miles/booking-tui/pkg/milesapi"
 )
 
 // BookingsViewMode represents the current mode of the bookings view
@@ -24,30 +28,50 @@ const (
 
 // BookingsModel represents the bookings management view
 type BookingsModel struct {
+	requests
+
 	styles *styles.Styles
 	client *api.Client
+	cfg    *config.Config
 	width  int
 	height int
 
 	// Data
-	bookings []models.Booking
+	bookings []milesapi.Booking
 	cursor   int
 	loading  bool
 	error    string
 
+	// Freshness: existing data stays on screen while refreshing
+	asOf         time.Time
+	refreshing   bool
+	refreshError string
+
 	// View mode
 	mode              BookingsViewMode
-	selectedBooking   *models.Booking
+	selectedBooking   *milesapi.Booking
 	showUpcoming      bool
 	showPast          bool
 	showCancelled     bool
 	confirmingCancel  bool
 	cancelling        bool
+
+	// Discussion thread of the selected booking
+	comments        []milesapi.BookingComment
+	commentsLoading bool
+	commentsError   string
+	commenting      bool
+	postingComment  bool
+	commentInput    textinput.Model
+
+	// Title, filters and help stay put while the list scrolls
+	layout stickyLayout
 }
 
 // BookingsDataMsg contains loaded bookings data
 type BookingsDataMsg struct {
-	Bookings []models.Booking
+	Bookings []milesapi.Booking
+	AsOf     time.Time // When the data was fetched from the server
 }
 
 // BookingsErrorMsg contains error information
@@ -58,50 +82,87 @@ type BookingsErrorMsg struct {
 // BookingCancelledMsg is sent when a booking is cancelled
 type BookingCancelledMsg struct {
 	BookingID string
+	Warning   string // Set when the cancellation was recorded as late
 }
 
 // NewBookingsModel creates a new bookings view
-func NewBookingsModel(client *api.Client, styles *styles.Styles) *BookingsModel {
+func NewBookingsModel(client *api.Client, cfg *config.Config, styles *styles.Styles) *BookingsModel {
 	return &BookingsModel{
 		styles:       styles,
 		client:       client,
+		cfg:          cfg,
 		loading:      true,
 		mode:         BookingsListMode,
 		showUpcoming: true,
 		showPast:     false,
 		showCancelled: false,
+		layout:        newStickyLayout(),
+		commentInput:  newCommentInput(),
 	}
 }
 
 // Init initializes the bookings view
 func (m *BookingsModel) Init() tea.Cmd {
+	// Show the bookings from the last sync while fetching what changed
+	if bookings, asOf := m.client.CachedMyBookings(); !asOf.IsZero() {
+		m.bookings, m.asOf = bookings, asOf
+		m.loading = false
+		m.refreshing = true
+	}
 	return m.loadData()
 }
 
+// isLoading reports whether my bookings are still loading
+func (m *BookingsModel) isLoading() bool {
+	return m.loading
+}
+
 // Update handles messages for the bookings view
 func (m *BookingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.layout.SetSize(msg.Width, msg.Height)
 		return m, nil
 
 	case BookingsDataMsg:
 		m.bookings = msg.Bookings
+		m.asOf = msg.AsOf
 		m.loading = false
+		m.refreshing = false
+		m.refreshError = ""
 		return m, nil
 
+	case BookingsChangedMsg:
+		if m.loading || m.refreshing {
+			return m, nil
+		}
+		return m, m.refresh()
+
 	case BookingsErrorMsg:
-		m.error = msg.Error
 		m.loading = false
+		if m.refreshing {
+			m.refreshing = false
+			m.refreshError = msg.Error
+			return m, nil
+		}
+		m.error = msg.Error
 		return m, nil
 
+	case BookingCommentsMsg, BookingCommentPostedMsg:
+		return m, m.updateComments(msg)
+
 	case BookingCancelledMsg:
 		m.cancelling = false
 		m.confirmingCancel = false
 		m.mode = BookingsListMode
-		m.loading = true
-		return m, m.loadData()
+		if msg.Warning != "" {
+			return m, tea.Batch(m.refresh(), func() tea.Msg {
+				return ToastMsg{Text: "⚠ " + msg.Warning, Error: true}
+			})
+		}
+		return m, m.refresh()
 
 	case tea.KeyMsg:
 		if m.loading || m.cancelling {
@@ -124,88 +185,185 @@ func (m *BookingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleListKeys handles keys in list mode
 func (m *BookingsModel) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "r", "f5":
-		m.loading = true
-		m.error = ""
-		return m, m.loadData()
+	k := m.listKeyMap()
+	switch {
+	case key.Matches(msg, k.Refresh):
+		m.client.Refresh()
+		return m, m.refresh()
 
-	case "u":
+	case key.Matches(msg, k.Upcoming):
 		m.showUpcoming = !m.showUpcoming
 		return m, nil
 
-	case "p":
+	case key.Matches(msg, k.Past):
 		m.showPast = !m.showPast
 		return m, nil
 
-	case "c":
+	case key.Matches(msg, k.Cancelled):
 		m.showCancelled = !m.showCancelled
 		return m, nil
 
-	case "n":
+	case key.Matches(msg, k.Export):
+		return m, exportBookingsCmd(m.client, m.getVisibleBookings())
+
+	case key.Matches(msg, k.New):
 		// Create new booking - switch to create mode
 		m.mode = BookingCreateMode
 		return m, nil
 
-	case "up", "k":
+	case key.Matches(msg, k.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		}
 		return m, nil
 
-	case "down", "j":
+	case key.Matches(msg, k.Down):
 		visibleBookings := m.getVisibleBookings()
 		if m.cursor < len(visibleBookings)-1 {
 			m.cursor++
 		}
 		return m, nil
 
-	case "g":
+	case key.Matches(msg, k.Top):
 		m.cursor = 0
 		return m, nil
 
-	case "G":
+	case key.Matches(msg, k.Bottom):
 		visibleBookings := m.getVisibleBookings()
 		m.cursor = len(visibleBookings) - 1
 		return m, nil
 
-	case "enter":
+	case key.Matches(msg, k.View):
 		visibleBookings := m.getVisibleBookings()
-		if m.cursor < len(visibleBookings) {
-			m.selectedBooking = &visibleBookings[m.cursor]
-			m.mode = BookingDetailsMode
-		}
-		return m, nil
+		m.selectedBooking = &visibleBookings[m.cursor]
+		m.mode = BookingDetailsMode
+		m.layout.GotoTop()
+		return m, m.openComments()
 	}
 
 	return m, nil
 }
 
+// showDetails opens booking's details from outside the list, e.g. from a
+// toast
+func (m *BookingsModel) showDetails(booking milesapi.Booking) tea.Cmd {
+	m.selectedBooking = &booking
+	m.mode = BookingDetailsMode
+	m.confirmingCancel = false
+	m.layout.GotoTop()
+	return m.openComments()
+}
+
+// bookingsListKeyMap lists the keys of the bookings list. Upcoming carries
+// the hint for all three filter toggles.
+type bookingsListKeyMap struct {
+	listKeyMap
+	View      key.Binding
+	Upcoming  key.Binding
+	Past      key.Binding
+	Cancelled key.Binding
+	New       key.Binding
+	Export    key.Binding
+	Refresh   key.Binding
+}
+
+// listKeyMap returns the list's keys; View needs a booking under the cursor
+func (m *BookingsModel) listKeyMap() bookingsListKeyMap {
+	k := bookingsListKeyMap{
+		listKeyMap: newListKeyMap(),
+		View:       newKey("Enter", "View details", "enter"),
+		Upcoming:   newKey("u/p/c", "Toggle filters", "u"),
+		Past:       hiddenKey("p"),
+		Cancelled:  hiddenKey("c"),
+		New:        newKey("n", "New booking", "n"),
+		Export:     newKey("x", "Export .ics", "x"),
+		Refresh:    newKey("r", "Refresh", "r", "f5"),
+	}
+	k.View.SetEnabled(m.cursor < len(m.getVisibleBookings()))
+	k.Export.SetEnabled(len(m.getVisibleBookings()) > 0)
+	return k
+}
+
+// bookingDetailsKeyMap lists the keys of a booking's details
+type bookingDetailsKeyMap struct {
+	Edit    key.Binding
+	Comment key.Binding
+	Reload  key.Binding
+	Cancel  key.Binding
+	Back    key.Binding
+	scrollKeyMap
+	Confirm       confirmKeyMap
+	CommentPrompt promptKeyMap
+}
+
+// detailsKeyMap returns the details keys for the selected booking. A
+// cancelled booking can't be cancelled again, so d is left out for it, and
+// neither it nor one that has ended can be edited.
+func (m *BookingsModel) detailsKeyMap() bookingDetailsKeyMap {
+	k := bookingDetailsKeyMap{
+		Edit:          newKey("e", "Edit", "e"),
+		Comment:       newKey("m", "Comment", "m"),
+		Reload:        newKey("r", "Reload comments", "r"),
+		Cancel:        newKey("d", "Cancel booking", "d"),
+		Back:          newKey("Esc", "Back to list", "esc", "q"),
+		scrollKeyMap:  newScrollKeyMap(),
+		Confirm:       newConfirmKeyMap("Confirm", "Keep booking"),
+		CommentPrompt: newPromptKeyMap("Post comment"),
+	}
+	if m.selectedBooking == nil || m.selectedBooking.GetStatus() == milesapi.BookingStatusCANCELLED {
+		k.Cancel.Unbind()
+		k.Edit.Unbind()
+	} else if !m.selectedBooking.GetEndTime().After(utils.Now()) {
+		k.Edit.Unbind()
+	}
+	k.Comment.SetEnabled(!m.postingComment)
+	k.Reload.SetEnabled(m.selectedBooking != nil)
+	k.CommentPrompt.Submit.SetEnabled(strings.TrimSpace(m.commentInput.Value()) != "")
+	return k
+}
+
 // handleDetailsKeys handles keys in details mode
 func (m *BookingsModel) handleDetailsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.detailsKeyMap()
 	if m.confirmingCancel {
-		switch msg.String() {
-		case "y", "Y":
+		switch {
+		case key.Matches(msg, k.Confirm.Yes):
 			m.cancelling = true
 			return m, m.cancelBooking()
-		case "n", "N", "esc":
+		case key.Matches(msg, k.Confirm.No):
 			m.confirmingCancel = false
 			return m, nil
 		}
 		return m, nil
 	}
+	if m.commenting {
+		return m.handleCommentKeys(msg)
+	}
 
-	switch msg.String() {
-	case "esc", "q":
+	// Long descriptions can push the card past the screen
+	if m.layout.Scroll(msg) {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, k.Back):
 		m.mode = BookingsListMode
 		m.selectedBooking = nil
 		return m, nil
 
-	case "d":
+	case key.Matches(msg, k.Edit):
+		booking := *m.selectedBooking
+		return m, func() tea.Msg { return EditBookingMsg{Booking: booking} }
+
+	case key.Matches(msg, k.Comment):
+		return m, m.startComment()
+
+	case key.Matches(msg, k.Reload):
+		return m, m.openComments()
+
+	case key.Matches(msg, k.Cancel):
 		// Cancel booking - show confirmation
-		if m.selectedBooking != nil && m.selectedBooking.Status != models.BookingStatusCancelled {
-			m.confirmingCancel = true
-		}
+		m.confirmingCancel = true
 		return m, nil
 	}
 
@@ -247,32 +405,18 @@ func (m *BookingsModel) View() string {
 
 // renderList renders the bookings list
 func (m *BookingsModel) renderList() string {
-	var b strings.Builder
+	header := m.renderHeader() + "\n\n" + m.renderFilterButtons() + "\n"
+	footer := "\n" + m.renderListHelp()
 
-	// Header
-	b.WriteString(m.renderHeader())
-	b.WriteString("\n\n")
-
-	// Filters
-	b.WriteString(m.renderFilterButtons())
-	b.WriteString("\n\n")
-
-	// Bookings list
 	visibleBookings := m.getVisibleBookings()
 	if len(visibleBookings) == 0 {
-		b.WriteString(m.styles.TextMuted.Render("No bookings found."))
-		b.WriteString("\n\n")
-		b.WriteString(m.styles.Text.Render("Press 'n' to create a new booking, or press '3' to browse rooms."))
-	} else {
-		b.WriteString(m.renderBookingsList(visibleBookings))
+		body := m.styles.TextMuted.Render("No bookings found.") + "\n\n" +
+			m.styles.Text.Render("Press 'n' to create a new booking, or press '3' to browse rooms.")
+		return m.layout.Render(header, body, footer, -1, -1)
 	}
 
-	b.WriteString("\n\n")
-
-	// Help
-	b.WriteString(m.renderListHelp())
-
-	return b.String()
+	body, top, bottom := m.renderBookingsList(visibleBookings)
+	return m.layout.Render(header, body, footer, top, bottom)
 }
 
 // renderHeader renders the header
@@ -280,7 +424,11 @@ func (m *BookingsModel) renderHeader() string {
 	title := m.styles.Title.Render("My Bookings")
 	subtitle := m.styles.Subtitle.Render(fmt.Sprintf("%d total bookings", len(m.bookings)))
 
-	return title + "\n" + subtitle
+	header := title + "\n" + subtitle
+	if age := renderDataAge(m.styles, m.asOf, m.refreshing, m.client.LowBandwidth(), m.refreshError); age != "" {
+		header += "\n" + age
+	}
+	return header
 }
 
 // renderFilterButtons renders filter toggle buttons
@@ -308,26 +456,18 @@ func (m *BookingsModel) renderFilterButtons() string {
 	return strings.Join(buttons, "  ")
 }
 
-// renderBookingsList renders the list of bookings
-func (m *BookingsModel) renderBookingsList(bookings []models.Booking) string {
-	var b strings.Builder
-
+// renderBookingsList renders the list of bookings and returns the lines
+// the selected booking spans
+func (m *BookingsModel) renderBookingsList(bookings []milesapi.Booking) (string, int, int) {
+	items := make([]string, len(bookings))
 	for i, booking := range bookings {
-		if i == m.cursor {
-			b.WriteString(m.renderBookingItem(booking, true))
-		} else {
-			b.WriteString(m.renderBookingItem(booking, false))
-		}
-		if i < len(bookings)-1 {
-			b.WriteString("\n")
-		}
+		items[i] = m.renderBookingItem(booking, i == m.cursor)
 	}
-
-	return b.String()
+	return joinItems(items, "\n", m.cursor)
 }
 
 // renderBookingItem renders a single booking item
-func (m *BookingsModel) renderBookingItem(booking models.Booking, isSelected bool) string {
+func (m *BookingsModel) renderBookingItem(booking milesapi.Booking, isSelected bool) string {
 	nameStyle := m.styles.TextBold
 	timeStyle := m.styles.Text
 	statusStyle := m.styles.TextMuted
@@ -346,29 +486,29 @@ func (m *BookingsModel) renderBookingItem(booking models.Booking, isSelected boo
 	}
 
 	// Room and location
-	roomName := nameStyle.Render(booking.Room.Name)
-	location := timeStyle.Render(booking.Room.Location.Name)
+	roomName := nameStyle.Render(booking.GetRoom().GetName())
+	location := timeStyle.Render(booking.GetRoom().GetLocation().GetName())
 
 	// Time
 	var timeStr string
-	if utils.IsToday(booking.StartTime) {
-		timeStr = m.styles.TextSuccess.Render("Today " + utils.FormatTime(booking.StartTime))
-	} else if utils.IsPast(booking.StartTime) {
-		timeStr = m.styles.TextMuted.Render(utils.FormatDateTime(booking.StartTime))
+	if utils.IsToday(booking.GetStartTime()) {
+		timeStr = m.styles.TextSuccess.Render("Today " + utils.FormatTime(booking.GetStartTime()))
+	} else if utils.IsPast(booking.GetStartTime()) {
+		timeStr = m.styles.TextMuted.Render(utils.FormatDateTime(booking.GetStartTime()))
 	} else {
-		timeStr = timeStyle.Render(utils.FormatDateTime(booking.StartTime))
+		timeStr = timeStyle.Render(utils.FormatDateTime(booking.GetStartTime()))
 	}
 
-	duration := statusStyle.Render(utils.FormatDuration(booking.StartTime, booking.EndTime))
+	duration := statusStyle.Render(utils.FormatDuration(booking.GetStartTime(), booking.GetEndTime()))
 
 	// Status badge
 	var statusBadge string
-	switch booking.Status {
-	case models.BookingStatusConfirmed:
+	switch booking.GetStatus() {
+	case milesapi.BookingStatusCONFIRMED:
 		statusBadge = m.styles.BadgeSuccess.Render("CONFIRMED")
-	case models.BookingStatusPending:
+	case milesapi.BookingStatusPENDING:
 		statusBadge = m.styles.BadgeWarning.Render("PENDING")
-	case models.BookingStatusCancelled:
+	case milesapi.BookingStatusCANCELLED:
 		statusBadge = m.styles.BadgeError.Render("CANCELLED")
 	}
 
@@ -378,6 +518,15 @@ func (m *BookingsModel) renderBookingItem(booking models.Booking, isSelected boo
 	return line1 + "\n" + line2
 }
 
+// detailTextWidth is the width long text is wrapped to in the details card
+func (m *BookingsModel) detailTextWidth() int {
+	if m.width <= 0 {
+		return 60
+	}
+	// Leave room for the card's border and padding
+	return max(20, min(60, m.width-8))
+}
+
 // renderDetails renders booking details
 func (m *BookingsModel) renderDetails() string {
 	if m.selectedBooking == nil {
@@ -388,73 +537,101 @@ func (m *BookingsModel) renderDetails() string {
 	booking := m.selectedBooking
 
 	// Header
-	b.WriteString(m.styles.Title.Render("Booking Details"))
-	b.WriteString("\n\n")
+	header := m.styles.Title.Render("Booking Details") + "\n"
 
 	// Booking info in a card
 	var card strings.Builder
 
 	// Room and location
-	card.WriteString(m.styles.Heading.Render(booking.Room.Name))
+	card.WriteString(m.styles.Heading.Render(booking.GetRoom().GetName()))
 	card.WriteString("\n")
-	card.WriteString(m.styles.Text.Render(booking.Room.Location.Name))
+	card.WriteString(m.styles.Text.Render(booking.GetRoom().GetLocation().GetName()))
 	card.WriteString("\n\n")
 
 	// Time details
 	card.WriteString(m.styles.TextBold.Render("When"))
 	card.WriteString("\n")
-	card.WriteString(m.styles.Text.Render(utils.FormatDateTime(booking.StartTime)))
+	card.WriteString(m.styles.Text.Render(utils.FormatDateTime(booking.GetStartTime())))
 	card.WriteString("\n")
-	card.WriteString(m.styles.Text.Render(utils.FormatDateTime(booking.EndTime)))
+	card.WriteString(m.styles.Text.Render(utils.FormatDateTime(booking.GetEndTime())))
 	card.WriteString("\n")
-	card.WriteString(m.styles.TextMuted.Render(fmt.Sprintf("Duration: %s", utils.FormatDuration(booking.StartTime, booking.EndTime))))
+	card.WriteString(m.styles.TextMuted.Render(fmt.Sprintf("Duration: %s", utils.FormatDuration(booking.GetStartTime(), booking.GetEndTime()))))
+	if booking.GetBufferMinutes() > 0 {
+		bufferEnd := booking.GetEndTime().Add(time.Duration(booking.GetBufferMinutes()) * time.Minute)
+		card.WriteString("\n")
+		card.WriteString(m.styles.TextDim.Render(fmt.Sprintf("Buffer: %s until %s (others can claim it)",
+			utils.FormatMinutes(booking.GetBufferMinutes()), utils.FormatTime(bufferEnd))))
+	}
 	card.WriteString("\n\n")
 
 	// Title and Description
 	card.WriteString(m.styles.TextBold.Render("Title"))
 	card.WriteString("\n")
-	card.WriteString(m.styles.Text.Render(booking.Title))
+	card.WriteString(m.styles.Text.Render(utils.WrapString(booking.GetTitle(), m.detailTextWidth())))
 	card.WriteString("\n\n")
 
-	if booking.Description != "" {
+	if description := m.client.RevealDescription(booking.GetDescription()); description != "" {
 		card.WriteString(m.styles.TextBold.Render("Description"))
 		card.WriteString("\n")
-		card.WriteString(m.styles.Text.Render(booking.Description))
+		card.WriteString(m.styles.Text.Render(utils.WrapString(description, m.detailTextWidth())))
+		card.WriteString("\n\n")
+	}
+
+	if booking.HasSetupRequest() {
+		card.WriteString(m.styles.TextBold.Render("Room setup"))
+		card.WriteString("\n")
+		card.WriteString(m.styles.Text.Render(booking.GetSetup().Label()))
+		if booking.GetSetupNotes() != "" {
+			card.WriteString("\n")
+			card.WriteString(m.styles.TextMuted.Render(utils.WrapString(booking.GetSetupNotes(), m.detailTextWidth())))
+		}
 		card.WriteString("\n\n")
 	}
 
 	// Status
 	card.WriteString(m.styles.TextBold.Render("Status"))
 	card.WriteString("\n")
-	switch booking.Status {
-	case models.BookingStatusConfirmed:
+	switch booking.GetStatus() {
+	case milesapi.BookingStatusCONFIRMED:
 		card.WriteString(m.styles.BadgeSuccess.Render("CONFIRMED"))
-	case models.BookingStatusPending:
+	case milesapi.BookingStatusPENDING:
 		card.WriteString(m.styles.BadgeWarning.Render("PENDING"))
-	case models.BookingStatusCancelled:
+	case milesapi.BookingStatusCANCELLED:
 		card.WriteString(m.styles.BadgeError.Render("CANCELLED"))
 	}
+	card.WriteString("\n\n")
+
+	// Links to send to others
+	card.WriteString(m.styles.TextBold.Render("Share"))
+	card.WriteString("\n")
+	card.WriteString(m.styles.Text.Render(deeplink.Booking(booking.GetId())))
+	card.WriteString("\n")
+	card.WriteString(m.styles.TextMuted.Render(m.cfg.BookingWebLink(booking.GetId())))
+	card.WriteString("\n\n")
+	card.WriteString(m.renderComments())
 
-	b.WriteString(m.styles.Panel.Render(card.String()))
-	b.WriteString("\n\n")
+	body := m.styles.Panel.Render(card.String())
+	b.WriteString("\n")
 
 	// Confirmation dialog for cancellation
+	k := m.detailsKeyMap()
 	if m.confirmingCancel {
 		b.WriteString(m.styles.TextWarning.Render("⚠ Are you sure you want to cancel this booking?"))
 		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render("Press 'y' to confirm, 'n' to cancel"))
+		b.WriteString(renderFooter(m.styles, m.width, k.Confirm.Yes, k.Confirm.No))
 	} else if m.cancelling {
 		b.WriteString(m.styles.TextMuted.Render("Cancelling booking..."))
+	} else if m.commenting {
+		b.WriteString(m.commentInput.View())
+		b.WriteString("\n")
+		b.WriteString(renderFooter(m.styles, m.width, k.CommentPrompt.Submit, k.CommentPrompt.Cancel))
+	} else if m.postingComment {
+		b.WriteString(m.styles.TextMuted.Render("Posting comment..."))
 	} else {
-		// Help
-		if booking.Status != models.BookingStatusCancelled {
-			b.WriteString(m.styles.Help.Render("d: Cancel booking • Esc: Back to list"))
-		} else {
-			b.WriteString(m.styles.Help.Render("Esc: Back to list"))
-		}
+		b.WriteString(renderFooter(m.styles, m.width, k.Edit, k.Comment, k.Reload, k.Cancel, k.PageUp, k.Back))
 	}
 
-	return b.String()
+	return m.layout.Render(header, body, b.String(), -1, -1)
 }
 
 // renderCreate renders the create booking form redirect
@@ -468,14 +645,8 @@ func (m *BookingsModel) renderCreate() string {
 
 // renderListHelp renders help for list mode
 func (m *BookingsModel) renderListHelp() string {
-	help := []string{
-		"j/k or ↑↓: Navigate",
-		"Enter: View details",
-		"u/p/c: Toggle filters",
-		"n: New booking",
-		"r: Refresh",
-	}
-	return m.styles.Help.Render(strings.Join(help, " • "))
+	k := m.listKeyMap()
+	return renderFooter(m.styles, m.width, k.Up, k.View, k.Upcoming, k.New, k.Export, k.Refresh)
 }
 
 // renderLoading renders the loading state
@@ -491,54 +662,68 @@ func (m *BookingsModel) renderError() string {
 		m.styles.Help.Render("Press r to retry")
 }
 
+// refresh reloads bookings, keeping the current list visible when there is one
+func (m *BookingsModel) refresh() tea.Cmd {
+	if m.asOf.IsZero() {
+		m.loading = true
+	} else {
+		m.refreshing = true
+	}
+	m.error = ""
+	return m.loadData()
+}
+
 // loadData loads bookings from the API
 func (m *BookingsModel) loadData() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
-		bookings, err := m.client.GetMyBookings()
+		started := time.Now()
+		bookings, err := m.client.GetMyBookings(ctx)
 		if err != nil {
 			return BookingsErrorMsg{Error: err.Error()}
 		}
 
-		return BookingsDataMsg{Bookings: bookings}
+		return BookingsDataMsg{Bookings: bookings, AsOf: m.client.DataAsOf(started)}
 	}
 }
 
 // cancelBooking cancels the selected booking
 func (m *BookingsModel) cancelBooking() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
 		if m.selectedBooking == nil {
 			return BookingsErrorMsg{Error: "No booking selected"}
 		}
 
-		err := m.client.CancelBooking(m.selectedBooking.ID)
+		warning, err := m.client.CancelBooking(ctx, m.selectedBooking.GetId())
 		if err != nil {
 			return BookingsErrorMsg{Error: err.Error()}
 		}
 
-		return BookingCancelledMsg{BookingID: m.selectedBooking.ID}
+		return BookingCancelledMsg{BookingID: m.selectedBooking.GetId(), Warning: warning}
 	}
 }
 
 // getVisibleBookings returns bookings filtered by current settings
-func (m *BookingsModel) getVisibleBookings() []models.Booking {
-	var visible []models.Booking
-	now := time.Now()
+func (m *BookingsModel) getVisibleBookings() []milesapi.Booking {
+	var visible []milesapi.Booking
+	now := utils.Now()
 
 	for _, booking := range m.bookings {
 		// Filter by status
-		if booking.Status == models.BookingStatusCancelled && !m.showCancelled {
+		if booking.GetStatus() == milesapi.BookingStatusCANCELLED && !m.showCancelled {
 			continue
 		}
 
 		// Filter by time
-		isUpcoming := booking.StartTime.After(now) && booking.Status == models.BookingStatusConfirmed
-		isPast := booking.StartTime.Before(now)
+		isUpcoming := booking.GetStartTime().After(now) && booking.GetStatus() == milesapi.BookingStatusCONFIRMED
+		isPast := booking.GetStartTime().Before(now)
 
 		if isUpcoming && m.showUpcoming {
 			visible = append(visible, booking)
 		} else if isPast && m.showPast {
 			visible = append(visible, booking)
-		} else if booking.Status == models.BookingStatusCancelled && m.showCancelled {
+		} else if booking.GetStatus() == milesapi.BookingStatusCANCELLED && m.showCancelled {
 			visible = append(visible, booking)
 		}
 	}
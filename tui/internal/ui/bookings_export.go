@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/pkg/ical"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// exportBookingsCmd writes bookings to an .ics file in ~/Downloads, or the
+// home directory without one, and toasts where it went
+func exportBookingsCmd(client *api.Client, bookings []milesapi.Booking) tea.Cmd {
+	return func() tea.Msg {
+		path, err := exportPath()
+		if err == nil {
+			err = writeBookingsICS(client, bookings, path)
+		}
+		if err != nil {
+			return ToastMsg{Text: "Export failed: " + err.Error(), Error: true}
+		}
+		noun := "bookings"
+		if len(bookings) == 1 {
+			noun = "booking"
+		}
+		return ToastMsg{Text: fmt.Sprintf("✓ Exported %d %s to %s", len(bookings), noun, path)}
+	}
+}
+
+// exportPath returns where today's export goes
+func exportPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, "Downloads")
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = home
+	}
+	return filepath.Join(dir, "miles-bookings-"+time.Now().Format(time.DateOnly)+".ics"), nil
+}
+
+// writeBookingsICS writes bookings as an iCalendar file, each in its
+// location's time zone. Pending bookings are tentative events.
+func writeBookingsICS(client *api.Client, bookings []milesapi.Booking, path string) error {
+	events := make([]ical.Event, 0, len(bookings))
+	for _, booking := range bookings {
+		event := ical.Event{
+			UID:         booking.GetId() + "@" + ical.UIDDomain,
+			Start:       booking.GetStartTime(),
+			End:         booking.GetEndTime(),
+			Summary:     booking.GetTitle(),
+			Description: client.RevealDescription(booking.GetDescription()),
+			Status:      ical.Confirmed,
+			Updated:     booking.GetUpdatedAt(),
+		}
+		switch booking.GetStatus() {
+		case milesapi.BookingStatusPENDING:
+			event.Status = ical.Tentative
+		case milesapi.BookingStatusCANCELLED:
+			event.Status = ical.Cancelled
+		}
+
+		var where []string
+		for _, name := range []string{booking.GetRoom().GetName(), booking.GetRoom().GetLocation().GetName()} {
+			if name != "" {
+				where = append(where, name)
+			}
+		}
+		event.Location = strings.Join(where, ", ")
+		if tz := booking.GetRoom().GetLocation().GetTimezone(); tz != "" {
+			if zone, err := time.LoadLocation(tz); err == nil {
+				event.Zone = zone
+			}
+		}
+		events = append(events, event)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := ical.Write(f, events); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
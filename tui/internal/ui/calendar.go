@@ -1,16 +1,21 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
 	"github.com/miles/booking-tui/internal/styles"
 	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
 )
 
 // CalendarViewMode represents the current calendar view
@@ -24,6 +29,8 @@ const (
 
 // CalendarModel represents the calendar view
 type CalendarModel struct {
+	requests
+
 	styles *styles.Styles
 	client *api.Client
 	width  int
@@ -37,21 +44,62 @@ type CalendarModel struct {
 	today        time.Time
 
 	// Data
-	bookings []models.Booking
+	bookings []milesapi.Booking
 	loading  bool
 	error    string
 
+	// Reloading after a streamed change keeps the grid where it's scrolled
+	reloading bool
+
+	// Days the shown location is closed, greyed out; nil when unknown
+	closures *milesapi.LocationHolidays
+
 	// Filters
 	locationID *string
 	roomID     *string
 
+	// Guests see one room's public availability instead of bookings.
+	// Titles and names are hidden.
+	guest     bool
+	guestRoom *milesapi.Room
+
 	// Cursor for day view
 	cursor int
+
+	// Scrollable 00-24 time grid for the day and week views
+	grid viewport.Model
+
+	// Title and help stay put while the month or time grid scrolls
+	layout stickyLayout
+
+	// "Go to date" prompt, opened with : or g d
+	gotoActive bool
+	gotoInput  textinput.Model
+	gotoError  string
+	pendingG   bool
 }
 
+// dayGridSlot is the length of one row in the day view time grid
+const dayGridSlot = 30 * time.Minute
+
+// Column widths of the week grid: the hour labels, then each day at up to
+// weekColumnMax characters, shrinking to weekColumnMin on narrow screens
+const (
+	weekLabelWidth = 6
+	weekColumnMax  = 10
+	weekColumnMin  = 2
+)
+
+// Cell widths of the month grid, which shrinks the same way
+const (
+	monthCellMax = 4
+	monthCellMin = 3
+)
+
 // CalendarDataMsg contains loaded calendar data
 type CalendarDataMsg struct {
-	Bookings []models.Booking
+	Bookings []milesapi.Booking
+	Closures *milesapi.LocationHolidays
 }
 
 // CalendarErrorMsg contains error information
@@ -69,70 +117,118 @@ func NewCalendarModel(client *api.Client, styles *styles.Styles) *CalendarModel
 		selectedDate: now,
 		today:        now,
 		loading:      true,
+		grid:         viewport.New(80, 20),
+		layout:       newStickyLayout(),
+		gotoInput:    newGotoInput(),
 	}
 }
 
+// NewGuestCalendarModel creates a read-only calendar showing when a room is
+// booked, for users who are not logged in. room may be nil until one is chosen.
+func NewGuestCalendarModel(client *api.Client, styles *styles.Styles, room *milesapi.Room) *CalendarModel {
+	m := NewCalendarModel(client, styles)
+	m.mode = CalendarWeekMode
+	m.guest = true
+	m.guestRoom = room
+	return m
+}
+
 // Init initializes the calendar view
 func (m *CalendarModel) Init() tea.Cmd {
 	return m.loadData()
 }
 
+// isLoading reports whether the shown range is still loading
+func (m *CalendarModel) isLoading() bool {
+	return m.loading
+}
+
 // Update handles messages for the calendar view
 func (m *CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.grid.Width = msg.Width
+		m.layout.SetSize(msg.Width, msg.Height)
+		m.refreshGrid(false)
 		return m, nil
 
 	case CalendarDataMsg:
 		m.bookings = msg.Bookings
+		m.closures = msg.Closures
 		m.loading = false
+		m.refreshGrid(!m.reloading)
+		m.reloading = false
 		return m, nil
 
 	case CalendarErrorMsg:
 		m.error = msg.Error
 		m.loading = false
+		m.reloading = false
 		return m, nil
 
+	case BookingsChangedMsg:
+		if m.loading || m.reloading || m.guest {
+			return m, nil
+		}
+		m.reloading = true
+		return m, m.loadData()
+
 	case tea.KeyMsg:
 		if m.loading {
 			return m, nil
 		}
+		if m.gotoActive {
+			return m.handleGotoKeys(msg)
+		}
+
+		// "g d" opens the prompt; a lone g keeps its meaning in each mode
+		pendingG := m.pendingG
+		m.pendingG = msg.String() == "g"
+		if pendingG && msg.String() == "d" {
+			return m, m.openGoto()
+		}
 
 		// Global calendar keys
-		switch msg.String() {
-		case "r", "f5":
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Goto):
+			return m, m.openGoto()
+
+		case key.Matches(msg, k.Refresh):
 			m.loading = true
 			m.error = ""
 			return m, m.loadData()
 
-		case "m":
+		case key.Matches(msg, k.Month):
 			// Switch to month view
 			m.mode = CalendarMonthMode
 			return m, nil
 
-		case "w":
+		case key.Matches(msg, k.Week):
 			// Switch to week view
 			m.mode = CalendarWeekMode
+			m.refreshGrid(true)
 			return m, nil
 
-		case "d":
+		case key.Matches(msg, k.Day):
 			// Switch to day view
 			m.mode = CalendarDayMode
 			m.cursor = 0
+			m.refreshGrid(true)
 			return m, nil
 
-		case "t":
+		case key.Matches(msg, k.Today):
 			// Jump to today
 			m.selectedDate = m.today
 			m.loading = true
 			return m, m.loadData()
 
-		case "left", "h":
+		case key.Matches(msg, k.Prev):
 			return m.navigatePrevious()
 
-		case "right", "l":
+		case key.Matches(msg, k.Next):
 			return m.navigateNext()
 		}
 
@@ -152,13 +248,26 @@ func (m *CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleMonthKeys handles keys in month mode
 func (m *CalendarModel) handleMonthKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Navigation is handled by global keys
+	// Navigation is handled by global keys; short screens can page the grid
+	m.layout.Scroll(msg)
 	return m, nil
 }
 
 // handleWeekKeys handles keys in week mode
 func (m *CalendarModel) handleWeekKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Navigation is handled by global keys
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Up):
+		m.grid.ScrollUp(1)
+	case key.Matches(msg, k.Down):
+		m.grid.ScrollDown(1)
+	case key.Matches(msg, k.Top):
+		m.grid.GotoTop()
+	case key.Matches(msg, k.Bottom):
+		m.grid.GotoBottom()
+	default:
+		m.scrollGrid(msg)
+	}
 	return m, nil
 }
 
@@ -166,31 +275,103 @@ func (m *CalendarModel) handleWeekKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *CalendarModel) handleDayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	dayBookings := m.getBookingsForDate(m.selectedDate)
 
-	switch msg.String() {
-	case "up", "k":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		}
-		return m, nil
 
-	case "down", "j":
+	case key.Matches(msg, k.Down):
 		if m.cursor < len(dayBookings)-1 {
 			m.cursor++
 		}
-		return m, nil
 
-	case "g":
+	case key.Matches(msg, k.Top):
 		m.cursor = 0
-		return m, nil
 
-	case "G":
+	case key.Matches(msg, k.Bottom):
 		m.cursor = len(dayBookings) - 1
+
+	default:
+		m.scrollGrid(msg)
 		return m, nil
 	}
 
+	// Re-render to move the highlight and keep the selected booking in view
+	m.refreshGrid(false)
+	if m.cursor >= 0 && m.cursor < len(dayBookings) {
+		m.ensureGridLineVisible(m.dayGridLine(dayBookings[m.cursor].GetStartTime()))
+	}
 	return m, nil
 }
 
+// scrollGrid handles page-wise scrolling of the time grid
+func (m *CalendarModel) scrollGrid(msg tea.KeyMsg) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.PageUp):
+		m.grid.HalfPageUp()
+	case key.Matches(msg, k.PageDown):
+		m.grid.HalfPageDown()
+	case key.Matches(msg, k.Now):
+		m.scrollGridToNow()
+	}
+}
+
+// calendarKeyMap lists the calendar's keys. Prev and Month carry the hints
+// for their groups.
+type calendarKeyMap struct {
+	listKeyMap
+	scrollKeyMap
+	Now     key.Binding
+	Prev    key.Binding
+	Next    key.Binding
+	Month   key.Binding
+	Week    key.Binding
+	Day     key.Binding
+	Today   key.Binding
+	Goto    key.Binding
+	Refresh key.Binding
+}
+
+// keyMap returns the calendar's keys for the current view. j/k move
+// between bookings in the day view and scroll the week's time grid; the
+// month view has neither a cursor nor a grid.
+func (m *CalendarModel) keyMap() calendarKeyMap {
+	k := calendarKeyMap{
+		listKeyMap:   newListKeyMap(),
+		scrollKeyMap: newScrollKeyMap(),
+		Now:          newKey("n", "Now", "n"),
+		Prev:         newKey("h/l or ←→", "Prev/Next", "left", "h"),
+		Next:         hiddenKey("right", "l"),
+		Month:        newKey("m/w/d", "Month/Week/Day view", "m"),
+		Week:         hiddenKey("w"),
+		Day:          hiddenKey("d"),
+		Today:        newKey("t", "Today", "t"),
+		Goto:         newKey(": or gd", "Go to date", ":"),
+		Refresh:      newKey("r", "Refresh", "r", "f5"),
+	}
+	switch m.mode {
+	case CalendarDayMode:
+		k.Up.SetHelp("j/k or ↑↓", "Navigate bookings")
+		k.Up.SetEnabled(len(m.getBookingsForDate(m.selectedDate)) > 0)
+	case CalendarWeekMode:
+		k.Up.SetHelp("j/k or ↑↓", "Scroll")
+		// The week view already shows where the grid is scrolled to
+		k.PageUp.SetHelp("", "")
+	default:
+		k.Up.Unbind()
+		k.Down.Unbind()
+		k.Top.Unbind()
+		k.Bottom.Unbind()
+		k.Now.Unbind()
+		// The month grid only pages on screens too short for it
+		k.PageUp.SetHelp("", "")
+	}
+	return k
+}
+
 // navigatePrevious navigates to the previous time period
 func (m *CalendarModel) navigatePrevious() (tea.Model, tea.Cmd) {
 	switch m.mode {
@@ -233,6 +414,12 @@ func (m *CalendarModel) View() string {
 		return m.renderError()
 	}
 
+	if m.guest && m.guestRoom == nil {
+		return m.styles.Title.Render("Calendar") + "\n\n" +
+			m.styles.Text.Render("Pick a room to see when it's free.") + "\n\n" +
+			m.styles.Help.Render("3: Rooms • Enter on a room: View availability")
+	}
+
 	switch m.mode {
 	case CalendarMonthMode:
 		return m.renderMonthView()
@@ -247,76 +434,274 @@ func (m *CalendarModel) View() string {
 
 // renderMonthView renders the month calendar view
 func (m *CalendarModel) renderMonthView() string {
-	var b strings.Builder
+	// Month grid and bookings summary
+	monthBookings := m.getBookingsForMonth(m.selectedDate)
+	summary := m.styles.Heading.Render(fmt.Sprintf("Bookings this month: %d", len(monthBookings)))
+	if holidays := m.monthHolidays(); holidays != "" {
+		summary += "\n" + m.styles.TextMuted.Render("Closed: "+holidays)
+	}
+	body := m.renderMonthGrid() + "\n\n" + wrapToWidth(summary, m.width)
 
-	// Header
-	b.WriteString(m.renderHeader())
-	b.WriteString("\n\n")
+	return m.layout.Render(m.renderHeader()+"\n", body, "\n"+m.renderHelp(), -1, -1)
+}
 
-	// Month grid
-	b.WriteString(m.renderMonthGrid())
-	b.WriteString("\n\n")
+// renderWeekView renders the week calendar view
+func (m *CalendarModel) renderWeekView() string {
+	header, footer := m.weekViewChrome()
+	return m.layout.Render(header, m.grid.View(), footer, -1, -1)
+}
 
-	// Bookings summary
-	monthBookings := m.getBookingsForMonth(m.selectedDate)
-	b.WriteString(m.styles.Heading.Render(fmt.Sprintf("Bookings this month: %d", len(monthBookings))))
-	b.WriteString("\n\n")
+// renderDayView renders the day time grid
+func (m *CalendarModel) renderDayView() string {
+	header, footer := m.dayViewChrome()
+	return m.layout.Render(header, m.grid.View(), footer, -1, -1)
+}
 
-	// Help
-	b.WriteString(m.renderHelp())
+// weekViewChrome renders what sits above and below the week's time grid.
+// The column headers stay put while the hours scroll underneath.
+func (m *CalendarModel) weekViewChrome() (string, string) {
+	weekStart := m.getWeekStart(m.selectedDate)
+	header := m.renderHeader() + "\n\n" + m.renderWeekColumnHeaders(weekStart)
+	footer := m.renderGridFooter(fmt.Sprintf("Bookings this week: %d", len(m.getBookingsForWeek(weekStart)))) +
+		"\n\n" + m.renderHelp()
+	return header, footer
+}
 
-	return b.String()
+// dayViewChrome renders what sits above and below the day's time grid
+func (m *CalendarModel) dayViewChrome() (string, string) {
+	dayBookings := m.getBookingsForDate(m.selectedDate)
+	summary := "No bookings for this day."
+	if len(dayBookings) > 0 {
+		summary = fmt.Sprintf("%d bookings", len(dayBookings))
+	}
+	header := m.renderHeader() + "\n\n" + m.styles.Heading.Render(summary)
+	if reason := m.closedOn(m.selectedDate); reason != "" {
+		header += "  " + m.styles.TextWarning.Render("Office closed ("+reason+")")
+	}
+	footer := m.renderGridFooter(m.hiddenBookingsHint(dayBookings)) + "\n\n" + m.renderHelp()
+	return header, footer
 }
 
-// renderWeekView renders the week calendar view
-func (m *CalendarModel) renderWeekView() string {
-	var b strings.Builder
+// resizeGrid fits the time grid between the header and footer of the
+// current view
+func (m *CalendarModel) resizeGrid() {
+	var header, footer string
+	switch m.mode {
+	case CalendarDayMode:
+		header, footer = m.dayViewChrome()
+	case CalendarWeekMode:
+		header, footer = m.weekViewChrome()
+	default:
+		return
+	}
+	if height := m.layout.BodyHeight(header, footer); height > 0 {
+		m.grid.Height = max(5, height)
+	}
+}
+
+// renderGridFooter renders a scroll position hint below the time grid
+func (m *CalendarModel) renderGridFooter(label string) string {
+	position := ""
+	if !m.grid.AtTop() || !m.grid.AtBottom() {
+		position = fmt.Sprintf("%3.0f%%", m.grid.ScrollPercent()*100)
+	}
+	footer := m.styles.Heading.Render(label) + m.styles.TextDim.Render(position)
+	if label != "" && position != "" {
+		footer = m.styles.Heading.Render(label) + "  " + m.styles.TextDim.Render(position)
+	}
+	return wrapToWidth(footer, m.width)
+}
+
+// hiddenBookingsHint points at bookings scrolled out of the day grid,
+// e.g. "↑ 1 earlier • ↓ 2 later"
+func (m *CalendarModel) hiddenBookingsHint(dayBookings []milesapi.Booking) string {
+	earlier, later := 0, 0
+	for _, booking := range dayBookings {
+		line := m.dayGridLine(booking.GetStartTime())
+		if line < m.grid.YOffset {
+			earlier++
+		} else if line >= m.grid.YOffset+m.grid.Height {
+			later++
+		}
+	}
+
+	var hints []string
+	if earlier > 0 {
+		hints = append(hints, fmt.Sprintf("↑ %d earlier", earlier))
+	}
+	if later > 0 {
+		hints = append(hints, fmt.Sprintf("↓ %d later", later))
+	}
+	return strings.Join(hints, " • ")
+}
+
+// refreshGrid re-renders the time grid content for the current mode. When
+// scrollToNow is set, the grid is scrolled so the current time (or the
+// first booking on other days) is in view.
+func (m *CalendarModel) refreshGrid(scrollToNow bool) {
+	m.resizeGrid()
 
-	// Header
-	b.WriteString(m.renderHeader())
-	b.WriteString("\n\n")
+	switch m.mode {
+	case CalendarDayMode:
+		m.grid.SetContent(m.renderDayGrid())
+	case CalendarWeekMode:
+		m.grid.SetContent(m.renderWeekGrid())
+	default:
+		return
+	}
 
-	// Week grid
-	b.WriteString(m.renderWeekGrid())
-	b.WriteString("\n\n")
+	if scrollToNow {
+		m.scrollGridToNow()
+	}
+}
 
-	// Help
-	b.WriteString(m.renderHelp())
+// scrollGridToNow scrolls the grid so "now" sits in the upper third. On
+// days other than today it scrolls to the first booking, or 08:00.
+func (m *CalendarModel) scrollGridToNow() {
+	now := time.Now()
+	target := time.Date(m.selectedDate.Year(), m.selectedDate.Month(), m.selectedDate.Day(), 8, 0, 0, 0, m.selectedDate.Location())
 
-	return b.String()
+	switch m.mode {
+	case CalendarDayMode:
+		if m.isSameDay(m.selectedDate, now) {
+			target = now
+		} else if dayBookings := m.getBookingsForDate(m.selectedDate); len(dayBookings) > 0 {
+			target = dayBookings[0].GetStartTime()
+		}
+		m.grid.SetYOffset(m.dayGridLine(target) - m.grid.Height/3)
+	case CalendarWeekMode:
+		weekStart := m.getWeekStart(m.selectedDate)
+		if !now.Before(weekStart) && now.Before(weekStart.AddDate(0, 0, 7)) {
+			target = now
+		}
+		m.grid.SetYOffset(target.Hour() - m.grid.Height/3)
+	}
 }
 
-// renderDayView renders the day list view
-func (m *CalendarModel) renderDayView() string {
-	var b strings.Builder
+// ensureGridLineVisible scrolls the grid the minimum amount to show line
+func (m *CalendarModel) ensureGridLineVisible(line int) {
+	if line < m.grid.YOffset {
+		m.grid.SetYOffset(line)
+	} else if line >= m.grid.YOffset+m.grid.Height {
+		m.grid.SetYOffset(line - m.grid.Height + 1)
+	}
+}
 
-	// Header
-	b.WriteString(m.renderHeader())
-	b.WriteString("\n\n")
+// dayGridLine returns the day grid row for a time of day
+func (m *CalendarModel) dayGridLine(t time.Time) int {
+	return (t.Hour()*60 + t.Minute()) / int(dayGridSlot.Minutes())
+}
 
-	// Day's bookings
+// renderDayGrid renders the selected day as rows of dayGridSlot from 00:00 to 24:00
+func (m *CalendarModel) renderDayGrid() string {
 	dayBookings := m.getBookingsForDate(m.selectedDate)
+	dayStart := time.Date(m.selectedDate.Year(), m.selectedDate.Month(), m.selectedDate.Day(), 0, 0, 0, 0, m.selectedDate.Location())
+	now := utils.Now()
+
+	var rows []string
+	for slotStart := dayStart; slotStart.Before(dayStart.AddDate(0, 0, 1)); slotStart = slotStart.Add(dayGridSlot) {
+		slotEnd := slotStart.Add(dayGridSlot)
+		isNow := !now.Before(slotStart) && now.Before(slotEnd)
+
+		// Time label on the hour, a quieter marker on the half hour
+		label := m.styles.TextMuted.Render(slotStart.Format("15:04"))
+		if slotStart.Minute() != 0 {
+			label = m.styles.TextDim.Render("  :" + slotStart.Format("04"))
+		}
+		if isNow {
+			label = m.styles.TextWarning.Bold(true).Render(now.Format("15:04"))
+		}
 
-	if len(dayBookings) == 0 {
-		b.WriteString(m.styles.TextMuted.Render("No bookings for this day."))
-	} else {
-		b.WriteString(m.styles.Heading.Render(fmt.Sprintf("%d bookings:", len(dayBookings))))
-		b.WriteString("\n\n")
-
+		var cells []string
 		for i, booking := range dayBookings {
-			b.WriteString(m.renderDayBookingItem(booking, i == m.cursor))
-			if i < len(dayBookings)-1 {
-				b.WriteString("\n\n")
+			if !booking.GetStartTime().Before(slotEnd) || !booking.GetEndTime().After(slotStart) {
+				continue
 			}
+			cells = append(cells, m.renderDayGridCell(booking, slotStart, i == m.cursor))
+		}
+
+		row := label + " " + m.styles.TextDim.Render("│") + " "
+		if len(cells) > 0 {
+			row += strings.Join(cells, "  ")
+		} else if isNow {
+			row += m.styles.TextWarning.Render("── now ──")
 		}
+		rows = append(rows, row)
 	}
 
-	b.WriteString("\n\n")
+	return strings.Join(rows, "\n")
+}
+
+// renderDayGridCell renders one booking's part of a day grid row: the
+// title on its first row, a continuation bar afterwards
+func (m *CalendarModel) renderDayGridCell(booking milesapi.Booking, slotStart time.Time, isSelected bool) string {
+	style := m.styles.TextSuccess
+	switch booking.GetStatus() {
+	case milesapi.BookingStatusPENDING:
+		style = m.styles.TextWarning
+	case milesapi.BookingStatusCANCELLED:
+		style = m.styles.TextMuted.Strikethrough(true)
+	}
+	if isSelected {
+		style = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
+	}
 
-	// Help
-	b.WriteString(m.renderHelp())
+	// Title on the row the booking starts in
+	if !booking.GetStartTime().Before(slotStart) {
+		cursor := "┃ "
+		if isSelected {
+			cursor = "▶ "
+		}
+		text := fmt.Sprintf("%s%s - %s  %s • %s", cursor,
+			utils.FormatTime(booking.GetStartTime()), utils.FormatTime(booking.GetEndTime()),
+			booking.GetTitle(), booking.GetRoom().GetName())
+		return style.Render(utils.TruncateString(text, max(10, m.grid.Width-12)))
+	}
 
-	return b.String()
+	return style.Render("┃")
+}
+
+// renderWeekColumnHeaders renders the fixed day headers above the week grid
+func (m *CalendarModel) renderWeekColumnHeaders(weekStart time.Time) string {
+	var b strings.Builder
+
+	column := m.weekColumnWidth()
+	b.WriteString(m.styles.Text.Width(weekLabelWidth).Render("Time"))
+	for i := 0; i < 7; i++ {
+		date := weekStart.AddDate(0, 0, i)
+		dayStr := date.Format("Mon 2")
+		switch {
+		case column < 3:
+			dayStr = date.Format("2")
+		case len(dayStr) > column:
+			// Weekday initial and date, e.g. "M20"
+			dayStr = date.Format("Mon")[:1] + date.Format("2")
+		}
+
+		isToday := m.isSameDay(date, m.today)
+		style := m.styles.TextBold
+		if m.closedOn(date) != "" {
+			style = m.styles.TextDim
+		}
+		if isToday {
+			style = style.Foreground(m.styles.Colors.Success)
+		}
+
+		b.WriteString(" ")
+		b.WriteString(style.Width(column).Align(lipgloss.Center).Render(dayStr))
+	}
+	b.WriteString("\n")
+
+	// Separator
+	b.WriteString(strings.Repeat("─", weekLabelWidth+7*(column+1)))
+
+	// Below the narrowest columns the grid runs off the screen; the
+	// viewport clips the hours under it, so clip the headers to match
+	clip := lipgloss.NewStyle()
+	if m.width > 0 {
+		clip = clip.MaxWidth(m.width)
+	}
+	return clip.Render(b.String())
 }
 
 // renderHeader renders the calendar header
@@ -344,8 +729,12 @@ func (m *CalendarModel) renderHeader() string {
 		viewMode = "[Day]"
 	}
 
-	return m.styles.Title.Render("Calendar") + " " + m.styles.Badge.Render(viewMode) + "\n" +
-		m.styles.Subtitle.Render(title)
+	if m.guestRoom != nil {
+		title = m.guestRoom.GetName() + " • " + title
+	}
+
+	return wrapToWidth(m.styles.Title.Render("Calendar")+" "+m.styles.Badge.Render(viewMode)+"\n"+
+		m.styles.Subtitle.Render(title), m.width)
 }
 
 // renderMonthGrid renders an ASCII calendar grid for the month
@@ -358,6 +747,7 @@ func (m *CalendarModel) renderMonthGrid() string {
 
 	// Build calendar grid
 	var b strings.Builder
+	cell, panel := m.monthCellWidth()
 
 	// Day headers
 	dayHeaders := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
@@ -365,12 +755,12 @@ func (m *CalendarModel) renderMonthGrid() string {
 		if i > 0 {
 			b.WriteString(" ")
 		}
-		b.WriteString(m.styles.TextBold.Width(4).Align(lipgloss.Center).Render(day))
+		b.WriteString(m.styles.TextBold.Width(cell).Align(lipgloss.Center).Render(day))
 	}
 	b.WriteString("\n")
 
 	// Separator
-	b.WriteString(strings.Repeat("─", 35))
+	b.WriteString(strings.Repeat("─", 7*cell+6))
 	b.WriteString("\n")
 
 	// Calculate starting position (0 = Sunday, 6 = Saturday)
@@ -397,8 +787,11 @@ func (m *CalendarModel) renderMonthGrid() string {
 				isToday := m.isSameDay(date, m.today)
 				isSelected := m.isSameDay(date, m.selectedDate)
 
-				// Style the day
+				// Style the day; closed days are greyed out
 				style := m.styles.Text
+				if m.closedOn(date) != "" {
+					style = m.styles.TextDim
+				}
 				if isToday {
 					style = m.styles.TextSuccess.Bold(true)
 				}
@@ -411,10 +804,10 @@ func (m *CalendarModel) renderMonthGrid() string {
 					dayStr = dayStr + " "
 				}
 
-				b.WriteString(style.Width(4).Align(lipgloss.Center).Render(dayStr))
+				b.WriteString(style.Width(cell).Align(lipgloss.Center).Render(dayStr))
 			} else {
 				// Empty cell for days outside current month
-				b.WriteString(m.styles.TextMuted.Width(4).Align(lipgloss.Center).Render("  "))
+				b.WriteString(m.styles.TextMuted.Width(cell).Align(lipgloss.Center).Render("  "))
 			}
 
 			currentDay++
@@ -427,44 +820,51 @@ func (m *CalendarModel) renderMonthGrid() string {
 		}
 	}
 
+	if !panel {
+		return b.String()
+	}
 	return m.styles.Panel.Render(b.String())
 }
 
-// renderWeekGrid renders an ASCII calendar grid for the week
+// monthCellWidth returns how wide the month grid's day cells are for the
+// screen, and whether the grid still fits inside its panel
+func (m *CalendarModel) monthCellWidth() (cell int, panel bool) {
+	if m.width <= 0 {
+		return monthCellMax, true
+	}
+	frame := m.styles.Panel.GetHorizontalFrameSize()
+	cell = max(monthCellMin, min(monthCellMax, (m.width-frame-6)/7))
+	return cell, 7*cell+6+frame <= m.width
+}
+
+// weekColumnWidth returns how wide each day's column of the week grid is
+// for the screen
+func (m *CalendarModel) weekColumnWidth() int {
+	if m.width <= 0 {
+		return weekColumnMax
+	}
+	return max(weekColumnMin, min(weekColumnMax, (m.width-weekLabelWidth)/7-1))
+}
+
+// renderWeekGrid renders the hour rows (00-23) of the week grid
 func (m *CalendarModel) renderWeekGrid() string {
 	weekStart := m.getWeekStart(m.selectedDate)
+	now := utils.Now()
+	column := m.weekColumnWidth()
 
 	var b strings.Builder
 
-	// Time slots (from 8 AM to 6 PM)
-	startHour := 8
-	endHour := 18
-
-	// Day headers
-	b.WriteString(m.styles.Text.Width(6).Render("Time"))
-	for i := 0; i < 7; i++ {
-		date := weekStart.AddDate(0, 0, i)
-		dayStr := date.Format("Mon 2")
-
-		isToday := m.isSameDay(date, m.today)
-		style := m.styles.TextBold
-		if isToday {
-			style = style.Foreground(m.styles.Colors.Success)
+	// Time slots, the whole day so early and late bookings are visible
+	for hour := 0; hour < 24; hour++ {
+		if hour > 0 {
+			b.WriteString("\n")
 		}
 
-		b.WriteString(" ")
-		b.WriteString(style.Width(10).Align(lipgloss.Center).Render(dayStr))
-	}
-	b.WriteString("\n")
-
-	// Separator
-	b.WriteString(strings.Repeat("─", 83))
-	b.WriteString("\n")
-
-	// Time slots
-	for hour := startHour; hour <= endHour; hour++ {
-		timeStr := fmt.Sprintf("%2d:00", hour)
-		b.WriteString(m.styles.Text.Width(6).Render(timeStr))
+		timeStyle := m.styles.Text
+		if hour == now.Hour() && !now.Before(weekStart) && now.Before(weekStart.AddDate(0, 0, 7)) {
+			timeStyle = m.styles.TextWarning.Bold(true)
+		}
+		b.WriteString(timeStyle.Width(weekLabelWidth).Render(fmt.Sprintf("%02d:00", hour)))
 
 		for i := 0; i < 7; i++ {
 			date := weekStart.AddDate(0, 0, i)
@@ -477,81 +877,26 @@ func (m *CalendarModel) renderWeekGrid() string {
 			b.WriteString(" ")
 			if booking != nil {
 				// Show booking indicator
-				b.WriteString(m.styles.TextSuccess.Width(10).Align(lipgloss.Center).Render("●"))
+				b.WriteString(m.styles.TextSuccess.Width(column).Align(lipgloss.Center).Render("●"))
+			} else if m.closedOn(date) != "" {
+				b.WriteString(m.styles.TextDim.Width(column).Align(lipgloss.Center).Render(strings.Repeat("░", min(3, column))))
 			} else {
-				b.WriteString(m.styles.TextMuted.Width(10).Align(lipgloss.Center).Render("·"))
+				b.WriteString(m.styles.TextMuted.Width(column).Align(lipgloss.Center).Render("·"))
 			}
 		}
-		b.WriteString("\n")
 	}
 
-	// Bookings legend
-	b.WriteString("\n")
-	weekBookings := m.getBookingsForWeek(weekStart)
-	b.WriteString(m.styles.Heading.Render(fmt.Sprintf("Bookings this week: %d", len(weekBookings))))
-
-	return m.styles.Panel.Render(b.String())
-}
-
-// renderDayBookingItem renders a single booking item for day view
-func (m *CalendarModel) renderDayBookingItem(booking models.Booking, isSelected bool) string {
-	cursor := "  "
-	titleStyle := m.styles.TextBold
-	textStyle := m.styles.Text
-	mutedStyle := m.styles.TextMuted
-
-	if isSelected {
-		cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
-		titleStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
-		textStyle = m.styles.Text.Foreground(m.styles.Colors.Primary)
-		mutedStyle = m.styles.TextMuted.Foreground(m.styles.Colors.Primary)
-	}
-
-	// Status badge
-	var statusBadge string
-	switch booking.Status {
-	case models.BookingStatusConfirmed:
-		statusBadge = m.styles.BadgeSuccess.Render("CONFIRMED")
-	case models.BookingStatusPending:
-		statusBadge = m.styles.BadgeWarning.Render("PENDING")
-	case models.BookingStatusCancelled:
-		statusBadge = m.styles.BadgeError.Render("CANCELLED")
-	}
-
-	// Time range
-	timeStr := utils.FormatTime(booking.StartTime) + " - " + utils.FormatTime(booking.EndTime)
-
-	line1 := lipgloss.JoinHorizontal(lipgloss.Left,
-		cursor,
-		titleStyle.Render(booking.Title),
-		"  ",
-		statusBadge,
-	)
-
-	line2 := lipgloss.JoinHorizontal(lipgloss.Left,
-		"  ",
-		textStyle.Render(timeStr),
-		" • ",
-		mutedStyle.Render(booking.Room.Name),
-	)
-
-	return line1 + "\n" + line2
+	return b.String()
 }
 
 // renderHelp renders help text
 func (m *CalendarModel) renderHelp() string {
-	help := []string{
-		"h/l or ←→: Prev/Next",
-		"m/w/d: Month/Week/Day view",
-		"t: Today",
-		"r: Refresh",
-	}
-
-	if m.mode == CalendarDayMode {
-		help = append([]string{"j/k or ↑↓: Navigate bookings"}, help...)
+	if m.gotoActive {
+		return m.renderGoto()
 	}
 
-	return m.styles.Help.Render(strings.Join(help, " • "))
+	k := m.keyMap()
+	return renderFooter(m.styles, m.width, k.Up, k.PageUp, k.Now, k.Prev, k.Month, k.Today, k.Goto, k.Refresh)
 }
 
 // renderLoading renders the loading state
@@ -569,6 +914,7 @@ func (m *CalendarModel) renderError() string {
 
 // loadData loads calendar data for the current view
 func (m *CalendarModel) loadData() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
 		var startDate, endDate time.Time
 
@@ -591,13 +937,108 @@ func (m *CalendarModel) loadData() tea.Cmd {
 			endDate = startDate.AddDate(0, 0, 1)
 		}
 
-		bookings, err := m.client.GetBookings(m.roomID, m.locationID, &startDate, &endDate)
+		if m.guest {
+			return m.loadGuestData(ctx, startDate, endDate)
+		}
+
+		bookings, err := m.client.GetBookings(ctx, m.roomID, m.locationID, &startDate, &endDate)
 		if err != nil {
 			return CalendarErrorMsg{Error: err.Error()}
 		}
 
-		return CalendarDataMsg{Bookings: bookings}
+		return CalendarDataMsg{
+			Bookings: bookings,
+			Closures: m.loadClosures(ctx, m.closuresLocation(bookings), startDate, endDate),
+		}
+	}
+}
+
+// loadGuestData loads the guest room's public availability. Month and week
+// ranges end on their last day, so that day is included in full.
+func (m *CalendarModel) loadGuestData(ctx context.Context, startDate, endDate time.Time) tea.Msg {
+	if m.guestRoom == nil {
+		return CalendarDataMsg{}
+	}
+	if m.mode != CalendarDayMode {
+		endDate = endDate.AddDate(0, 0, 1)
+	}
+
+	bookings, err := m.client.GetRoomAvailability(ctx, m.guestRoom.GetId(), startDate, endDate)
+	if err != nil {
+		return CalendarErrorMsg{Error: err.Error()}
+	}
+
+	// Only show that the room is taken, not by whom or for what
+	booked := "Booked"
+	for i := range bookings {
+		bookings[i].Title = &booked
+		bookings[i].Description = nil
+		bookings[i].User = nil
+		bookings[i].Room = m.guestRoom
+	}
+	return CalendarDataMsg{
+		Bookings: bookings,
+		Closures: m.loadClosures(ctx, m.guestRoom.GetLocationId(), startDate, endDate),
+	}
+}
+
+// closuresLocation picks the location whose closed days the calendar shows:
+// the one it is filtered to, or else where most of its bookings are
+func (m *CalendarModel) closuresLocation(bookings []milesapi.Booking) string {
+	if m.locationID != nil {
+		return *m.locationID
+	}
+	counts := make(map[string]int)
+	best := ""
+	for _, booking := range bookings {
+		id := booking.GetRoom().GetLocationId()
+		if id == "" {
+			continue
+		}
+		counts[id]++
+		if counts[id] > counts[best] {
+			best = id
+		}
+	}
+	return best
+}
+
+// loadClosures fetches a location's closed days in the shown range. The
+// calendar is still useful without them, so failing to fetch returns nil.
+func (m *CalendarModel) loadClosures(ctx context.Context, locationID string, startDate, endDate time.Time) *milesapi.LocationHolidays {
+	if locationID == "" {
+		return nil
+	}
+	// A day either side covers locations in other time zones
+	closures, err := m.client.GetLocationHolidays(ctx, locationID,
+		startDate.AddDate(0, 0, -1).Format(time.DateOnly), endDate.AddDate(0, 0, 1).Format(time.DateOnly))
+	if err != nil {
+		return nil
+	}
+	return closures
+}
+
+// closedOn returns why the shown location is closed on a calendar day, or ""
+func (m *CalendarModel) closedOn(date time.Time) string {
+	// Midday keeps the day the same in nearby time zones
+	return m.closures.ClosedOn(time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, date.Location()))
+}
+
+// monthHolidays lists the shown month's holidays, e.g. "Dec 25 Christmas Day"
+func (m *CalendarModel) monthHolidays() string {
+	if m.closures == nil {
+		return ""
+	}
+	month := m.selectedDate.Format("2006-01")
+	var names []string
+	for _, holiday := range m.closures.Holidays {
+		day, err := time.Parse(time.DateOnly, holiday.Date)
+		if err != nil || !strings.HasPrefix(holiday.Date, month) {
+			continue
+		}
+		names = append(names, day.Format("Jan 2")+" "+holiday.Name)
 	}
+	return strings.Join(names, " • ")
 }
 
 // Helper functions
@@ -618,30 +1059,33 @@ func (m *CalendarModel) isSameDay(date1, date2 time.Time) bool {
 // hasBookingsOnDate checks if there are any bookings on the given date
 func (m *CalendarModel) hasBookingsOnDate(date time.Time) bool {
 	for _, booking := range m.bookings {
-		if m.isSameDay(booking.StartTime, date) {
+		if m.isSameDay(booking.GetStartTime(), date) {
 			return true
 		}
 	}
 	return false
 }
 
-// getBookingsForDate returns all bookings for the given date
-func (m *CalendarModel) getBookingsForDate(date time.Time) []models.Booking {
-	var result []models.Booking
+// getBookingsForDate returns all bookings for the given date, ordered by start time
+func (m *CalendarModel) getBookingsForDate(date time.Time) []milesapi.Booking {
+	var result []milesapi.Booking
 	for _, booking := range m.bookings {
-		if m.isSameDay(booking.StartTime, date) {
+		if m.isSameDay(booking.GetStartTime(), date) {
 			result = append(result, booking)
 		}
 	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].GetStartTime().Before(result[j].GetStartTime())
+	})
 	return result
 }
 
 // getBookingsForMonth returns all bookings for the month
-func (m *CalendarModel) getBookingsForMonth(date time.Time) []models.Booking {
-	var result []models.Booking
+func (m *CalendarModel) getBookingsForMonth(date time.Time) []milesapi.Booking {
+	var result []milesapi.Booking
 	for _, booking := range m.bookings {
-		if booking.StartTime.Year() == date.Year() &&
-			booking.StartTime.Month() == date.Month() {
+		if booking.GetStartTime().Year() == date.Year() &&
+			booking.GetStartTime().Month() == date.Month() {
 			result = append(result, booking)
 		}
 	}
@@ -649,11 +1093,11 @@ func (m *CalendarModel) getBookingsForMonth(date time.Time) []models.Booking {
 }
 
 // getBookingsForWeek returns all bookings for the week
-func (m *CalendarModel) getBookingsForWeek(weekStart time.Time) []models.Booking {
+func (m *CalendarModel) getBookingsForWeek(weekStart time.Time) []milesapi.Booking {
 	weekEnd := weekStart.AddDate(0, 0, 7)
-	var result []models.Booking
+	var result []milesapi.Booking
 	for _, booking := range m.bookings {
-		if booking.StartTime.After(weekStart) && booking.StartTime.Before(weekEnd) {
+		if booking.GetStartTime().After(weekStart) && booking.GetStartTime().Before(weekEnd) {
 			result = append(result, booking)
 		}
 	}
@@ -661,10 +1105,10 @@ func (m *CalendarModel) getBookingsForWeek(weekStart time.Time) []models.Booking
 }
 
 // getBookingInSlot returns a booking that overlaps with the given time slot
-func (m *CalendarModel) getBookingInSlot(slotStart, slotEnd time.Time) *models.Booking {
+func (m *CalendarModel) getBookingInSlot(slotStart, slotEnd time.Time) *milesapi.Booking {
 	for _, booking := range m.bookings {
 		// Check if booking overlaps with slot
-		if booking.StartTime.Before(slotEnd) && booking.EndTime.After(slotStart) {
+		if booking.GetStartTime().Before(slotEnd) && booking.GetEndTime().After(slotStart) {
 			return &booking
 		}
 	}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/pkg/snippet"
+)
+
+// newGotoInput creates the input of the "go to date" prompt
+func newGotoInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "next friday, 21/10, in 3 weeks"
+	input.CharLimit = 40
+	input.Width = 30
+	return input
+}
+
+// openGoto shows the "go to date" prompt
+func (m *CalendarModel) openGoto() tea.Cmd {
+	m.gotoActive = true
+	m.gotoError = ""
+	m.gotoInput.SetValue("")
+	m.gotoInput.Focus()
+	m.resizeGrid()
+	return textinput.Blink
+}
+
+// closeGoto hides the "go to date" prompt
+func (m *CalendarModel) closeGoto() {
+	m.gotoActive = false
+	m.gotoError = ""
+	m.gotoInput.Blur()
+	m.resizeGrid()
+}
+
+// handleGotoKeys handles keys while the "go to date" prompt is open
+func (m *CalendarModel) handleGotoKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := newPromptKeyMap("Go")
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.closeGoto()
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		text := strings.TrimSpace(m.gotoInput.Value())
+		if text == "" {
+			m.closeGoto()
+			return m, nil
+		}
+		date, err := snippet.ParseDate(text, time.Now())
+		if err != nil {
+			m.gotoError = err.Error()
+			m.resizeGrid()
+			return m, nil
+		}
+		m.closeGoto()
+		m.selectedDate = date
+		m.cursor = 0
+		m.loading = true
+		return m, m.loadData()
+	}
+
+	var cmd tea.Cmd
+	m.gotoInput, cmd = m.gotoInput.Update(msg)
+	return m, cmd
+}
+
+// showDay switches to the day view on date and loads its bookings
+func (m *CalendarModel) showDay(date time.Time) tea.Cmd {
+	m.mode = CalendarDayMode
+	m.selectedDate = date
+	m.cursor = 0
+	m.loading = true
+	m.error = ""
+	m.refreshGrid(true)
+	return m.loadData()
+}
+
+// CapturingInput reports whether keys should go to the "go to date" prompt
+// rather than the app's global shortcuts
+func (m *CalendarModel) CapturingInput() bool {
+	return m.gotoActive
+}
+
+// renderGoto renders the "go to date" prompt in place of the help line
+func (m *CalendarModel) renderGoto() string {
+	var b strings.Builder
+	b.WriteString(m.styles.TextBold.Render("Go to: "))
+	b.WriteString(m.gotoInput.View())
+	if m.gotoError != "" {
+		b.WriteString("\n")
+		b.WriteString(m.styles.TextError.Render(m.gotoError))
+	}
+	b.WriteString("\n")
+	k := newPromptKeyMap("Go")
+	b.WriteString(renderFooter(m.styles, m.width, k.Submit, k.Cancel))
+	return b.String()
+}
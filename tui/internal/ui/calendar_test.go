@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// loadedCalendar is a calendar in mode with a couple of today's bookings
+// loaded, as if the API had answered
+func loadedCalendar(mode CalendarViewMode) *CalendarModel {
+	m := NewCalendarModel(nil, styles.DefaultStyles())
+	m.mode = mode
+
+	today := time.Now()
+	at := func(hour int) time.Time {
+		return time.Date(today.Year(), today.Month(), today.Day(), hour, 0, 0, 0, today.Location())
+	}
+	m.Update(CalendarDataMsg{Bookings: []milesapi.Booking{
+		{Id: ptr("b1"), Title: ptr("Standup"), Room: &milesapi.Room{Name: ptr("Møterom Ærlig")}, StartTime: ptr(at(9)), EndTime: ptr(at(10)), Status: ptr(milesapi.BookingStatusCONFIRMED)},
+		{Id: ptr("b2"), Title: ptr("Planlegging av sommerfesten 🎉"), Room: &milesapi.Room{Name: ptr("Blåbær")}, StartTime: ptr(at(13)), EndTime: ptr(at(15)), Status: ptr(milesapi.BookingStatusCONFIRMED)},
+	}})
+	return m
+}
+
+// calendarChrome returns what the calendar's current mode pins above and
+// below its body
+func calendarChrome(m *CalendarModel) (header, footer string) {
+	switch m.mode {
+	case CalendarWeekMode:
+		return m.weekViewChrome()
+	case CalendarDayMode:
+		return m.dayViewChrome()
+	}
+	return m.renderHeader() + "\n", "\n" + m.renderHelp()
+}
+
+func TestCalendarResize(t *testing.T) {
+	modes := map[string]CalendarViewMode{
+		"month": CalendarMonthMode,
+		"week":  CalendarWeekMode,
+		"day":   CalendarDayMode,
+	}
+	for name, mode := range modes {
+		t.Run(name, func(t *testing.T) {
+			m := loadedCalendar(mode)
+			for _, size := range resizes {
+				t.Run(fmt.Sprintf("%dx%d", size.Width, size.Height), func(t *testing.T) {
+					m.Update(size)
+					view := m.View()
+					header, footer := calendarChrome(m)
+
+					checkPinned(t, view, header, footer)
+					checkFits(t, view, size.Width)
+					// The time grids keep at least 5 hours on screen, so
+					// only screens with room for them are filled exactly
+					if m.layout.lines(header)+m.layout.lines(footer)+5 > size.Height {
+						return
+					}
+					if got := lipgloss.Height(view); got > size.Height {
+						t.Errorf("view is %d lines, more than the %d of the screen", got, size.Height)
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/utils"
+)
+
+// clockSkewWarning is how far the local clock may be off the server's
+// before the user is warned. The Date header only has whole seconds.
+const clockSkewWarning = time.Minute
+
+// checkClockSkew makes displayed times follow the server's clock once the
+// client has measured it, and warns when the local clock is well off
+func (a *App) checkClockSkew() tea.Cmd {
+	if a.clockChecked {
+		return nil
+	}
+	skew, ok := a.client.ClockSkew()
+	if !ok {
+		return nil
+	}
+	a.clockChecked = true
+	utils.SetClockSkew(skew)
+
+	if skew.Abs() < clockSkewWarning {
+		return nil
+	}
+	direction := "behind"
+	if skew < 0 {
+		direction, skew = "ahead of", -skew
+	}
+	return a.showToast(fmt.Sprintf("Your clock is %s %s the server; times shown follow the server", skew, direction), true)
+}
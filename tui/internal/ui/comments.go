@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// shownComments is how many of a booking's latest comments the details
+// view shows
+const shownComments = 5
+
+// BookingCommentsMsg carries a booking's latest comments
+type BookingCommentsMsg struct {
+	BookingID string
+	Comments  []milesapi.BookingComment
+	Err       error
+}
+
+// BookingCommentPostedMsg is sent after posting a comment
+type BookingCommentPostedMsg struct {
+	BookingID string
+	Comment   *milesapi.BookingComment
+	Err       error
+}
+
+// newCommentInput creates the input for writing a comment
+func newCommentInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "Running 5 minutes late"
+	input.CharLimit = 500
+	input.Width = 50
+	return input
+}
+
+// loadComments fetches the latest comments on a booking
+func loadComments(ctx context.Context, client *api.Client, bookingID string) tea.Cmd {
+	return func() tea.Msg {
+		comments, err := client.GetBookingComments(ctx, bookingID, shownComments)
+		return BookingCommentsMsg{BookingID: bookingID, Comments: comments, Err: err}
+	}
+}
+
+// postComment adds a comment to a booking's thread
+func postComment(ctx context.Context, client *api.Client, bookingID, message string) tea.Cmd {
+	return func() tea.Msg {
+		comment, err := client.AddBookingComment(ctx, bookingID, message)
+		return BookingCommentPostedMsg{BookingID: bookingID, Comment: comment, Err: err}
+	}
+}
+
+// openComments starts loading the thread of the booking just selected
+func (m *BookingsModel) openComments() tea.Cmd {
+	ctx := m.requestCtx()
+	m.comments = nil
+	m.commentsError = ""
+	m.commentsLoading = true
+	m.commenting = false
+	return loadComments(ctx, m.client, m.selectedBooking.GetId())
+}
+
+// updateComments handles comment messages, ignoring those for a booking
+// that is no longer selected
+func (m *BookingsModel) updateComments(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case BookingCommentsMsg:
+		if m.selectedBooking == nil || msg.BookingID != m.selectedBooking.GetId() {
+			return nil
+		}
+		m.commentsLoading = false
+		if msg.Err != nil {
+			m.commentsError = msg.Err.Error()
+			return nil
+		}
+		m.comments = msg.Comments
+		m.commentsError = ""
+
+	case BookingCommentPostedMsg:
+		m.postingComment = false
+		if m.selectedBooking == nil || msg.BookingID != m.selectedBooking.GetId() {
+			return nil
+		}
+		if msg.Err != nil {
+			m.commentsError = msg.Err.Error()
+			return nil
+		}
+		m.comments = append(m.comments, *msg.Comment)
+		if len(m.comments) > shownComments {
+			m.comments = m.comments[len(m.comments)-shownComments:]
+		}
+		m.commentsError = ""
+	}
+	return nil
+}
+
+// handleCommentKeys handles keys while writing a comment
+func (m *BookingsModel) handleCommentKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.detailsKeyMap().CommentPrompt
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.commenting = false
+		m.commentInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		message := strings.TrimSpace(m.commentInput.Value())
+		if m.selectedBooking == nil {
+			return m, nil
+		}
+		m.commenting = false
+		m.postingComment = true
+		m.commentInput.Blur()
+		return m, postComment(m.requestCtx(), m.client, m.selectedBooking.GetId(), message)
+	}
+
+	var cmd tea.Cmd
+	m.commentInput, cmd = m.commentInput.Update(msg)
+	return m, cmd
+}
+
+// startComment focuses the comment input
+func (m *BookingsModel) startComment() tea.Cmd {
+	m.commenting = true
+	m.commentsError = ""
+	m.commentInput.SetValue("")
+	m.commentInput.Focus()
+	return textinput.Blink
+}
+
+// CapturingInput reports whether keys should go to the comment input rather
+// than the app's global shortcuts
+func (m *BookingsModel) CapturingInput() bool {
+	return m.commenting
+}
+
+// renderComments renders the latest comments for the details card
+func (m *BookingsModel) renderComments() string {
+	var b strings.Builder
+	b.WriteString(m.styles.TextBold.Render("Comments"))
+	b.WriteString("\n")
+
+	switch {
+	case m.commentsLoading:
+		b.WriteString(m.styles.TextMuted.Render("Loading comments..."))
+	case len(m.comments) == 0 && m.commentsError == "":
+		b.WriteString(m.styles.TextMuted.Render("No comments yet"))
+	}
+
+	for i, comment := range m.comments {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(m.styles.TextMuted.Render(fmt.Sprintf("%s • %s",
+			comment.Author.ShortName(), utils.HumanizeTime(comment.CreatedAt))))
+		b.WriteString("\n")
+		b.WriteString(m.styles.Text.Render(utils.WrapString(comment.Message, m.detailTextWidth())))
+	}
+
+	if m.commentsError != "" {
+		if len(m.comments) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(m.styles.TextError.Render(m.commentsError))
+	}
+	return b.String()
+}
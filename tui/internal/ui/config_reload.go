@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"errors"
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/config"
+	"github.com/miles/booking-tui/internal/styles"
+)
+
+// configReloadedMsg carries the config file as changed on disk, and what
+// is wrong with it if it can't be read
+type configReloadedMsg struct {
+	cfg *config.Config
+	err error
+}
+
+// watchConfig watches the config file for changes, or returns nil when it
+// can't; the config then only changes through Settings
+func watchConfig(cfg *config.Config) *config.Watcher {
+	if cfg.Path() == "" {
+		return nil
+	}
+	w, err := config.Watch(cfg)
+	if err != nil {
+		return nil
+	}
+	return w
+}
+
+// startConfigWatch reports a config problem found at startup and waits
+// for the first change to the file
+func (a *App) startConfigWatch() tea.Cmd {
+	var cmds []tea.Cmd
+	if a.configProblem != nil {
+		cmds = append(cmds, a.showToast("Config: "+a.configProblem.Error(), true))
+	}
+	return tea.Batch(append(cmds, a.nextConfigChange())...)
+}
+
+// nextConfigChange waits for the config file to change
+func (a *App) nextConfigChange() tea.Cmd {
+	w := a.configWatcher
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		cfg, err := w.Next()
+		if errors.Is(err, config.ErrWatcherClosed) {
+			return nil
+		}
+		return configReloadedMsg{cfg: cfg, err: err}
+	}
+}
+
+// reloadConfig applies a changed config file while the app runs: the
+// theme restyles every view, and key aliases, refresh intervals, the
+// network mode and the transport take effect right away. A file that
+// doesn't parse, say halfway through an edit, leaves the config as it was.
+func (a *App) reloadConfig(msg configReloadedMsg) tea.Cmd {
+	next := a.nextConfigChange()
+	if msg.err != nil {
+		return tea.Batch(next, a.showToast("Config not reloaded: "+msg.err.Error(), true))
+	}
+	// Saving from Settings changes the file too
+	if reflect.DeepEqual(msg.cfg, a.cfg) {
+		return next
+	}
+
+	// Views share the config and styles, so changing them in place
+	// reaches every one
+	rewatch := msg.cfg.WatchAddr() != a.cfg.WatchAddr() || msg.cfg.GRPCInsecure != a.cfg.GRPCInsecure
+	*a.cfg = *msg.cfg
+	themeErr := a.applyConfig()
+
+	// Settings rebuilds from the new config
+	cmds := []tea.Cmd{next}
+	if rewatch {
+		cmds = append(cmds, a.startBookingWatch())
+	}
+	if a.settings != nil {
+		a.settings = NewSettingsModel(a.client, a.cfg, a.styles)
+		cmds = append(cmds, a.initView(a.settings))
+	}
+	cmds = append(cmds, func() tea.Msg { return SettingsChangedMsg{} })
+	if themeErr != nil {
+		cmds = append(cmds, a.showToast("Config reloaded, but "+themeErr.Error(), true))
+	} else {
+		cmds = append(cmds, a.showToast("✓ Config reloaded", false))
+	}
+	return tea.Batch(cmds...)
+}
+
+// applyConfig applies the parts of the config the app itself holds: the
+// network mode, the description key and the theme. A bad key only means
+// encrypted descriptions stay unreadable; theme problems are returned with
+// the rest of the theme applied.
+func (a *App) applyConfig() error {
+	a.client.SetNetworkMode(api.NetworkMode(a.cfg.NetworkMode))
+	a.client.SetDescriptionKey(a.cfg.DescriptionKey, a.cfg.EncryptDescriptions)
+	colors, err := styles.ThemedColors(a.cfg.Theme)
+	*a.styles = *styles.New(colors)
+	return err
+}
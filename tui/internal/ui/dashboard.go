@@ -5,33 +5,63 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
+	"github.com/miles/booking-tui/internal/config"
 	"github.com/miles/booking-tui/internal/styles"
-	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
 )
 
 // DashboardModel represents the dashboard view
 type DashboardModel struct {
+	requests
+
 	styles *styles.Styles
 	client *api.Client
-	user   *models.User
+	cfg    *config.Config
+	user   *milesapi.User
 	width  int
 	height int
 
 	// Data
-	bookings  []models.Booking
-	locations []models.Location
+	bookings  []milesapi.Booking
+	locations []milesapi.Location
+	quota     *milesapi.Quota // nil if the server doesn't report one
 	loading   bool
 	error     string
+
+	// Freshness: existing data stays on screen while refreshing
+	asOf         time.Time
+	refreshing   bool
+	refreshError string
+
+	// Panels chosen in settings, in display order
+	widgets []DashboardWidget
+
+	// Whether the role allows the admin panel
+	showAdmin bool
+
+	// Day of the week picked in the meeting load sparkline, 0 is Sunday
+	loadDay int
 }
 
 // DashboardDataMsg contains loaded dashboard data
 type DashboardDataMsg struct {
-	Bookings  []models.Booking
-	Locations []models.Location
+	Bookings  []milesapi.Booking
+	Locations []milesapi.Location
+	AsOf      time.Time // When the data was fetched from the server
+}
+
+// DashboardRefreshTickMsg triggers the periodic dashboard refresh
+type DashboardRefreshTickMsg struct {
+	dashboard *DashboardModel
+}
+
+// DashboardQuotaMsg contains the user's booking quota
+type DashboardQuotaMsg struct {
+	Quota *milesapi.Quota
 }
 
 // DashboardErrorMsg contains error information
@@ -39,19 +69,68 @@ type DashboardErrorMsg struct {
 	Error string
 }
 
-// NewDashboardModel creates a new dashboard view
-func NewDashboardModel(client *api.Client, user *models.User, styles *styles.Styles) *DashboardModel {
-	return &DashboardModel{
+// NewDashboardModel creates a new dashboard view with the widgets enabled in cfg
+func NewDashboardModel(client *api.Client, user *milesapi.User, styles *styles.Styles, cfg *config.Config) *DashboardModel {
+	m := &DashboardModel{
 		styles:  styles,
 		client:  client,
+		cfg:     cfg,
 		user:    user,
 		loading: true,
+		loadDay: int(time.Now().Weekday()),
+	}
+
+	for _, id := range cfg.DashboardWidgets {
+		if def, ok := findWidget(id); ok {
+			m.widgets = append(m.widgets, def.New(client, styles, cfg))
+		}
 	}
+
+	return m
 }
 
 // Init initializes the dashboard
 func (m *DashboardModel) Init() tea.Cmd {
-	return m.loadData()
+	// Show the bookings from the last sync while fetching what changed
+	if bookings, asOf := m.client.CachedMyBookings(); !asOf.IsZero() {
+		m.bookings, m.asOf = bookings, asOf
+		m.loading = false
+		m.refreshing = true
+	}
+	return tea.Batch(m.load(), m.scheduleRefresh())
+}
+
+// isLoading reports whether the dashboard's data is still loading
+func (m *DashboardModel) isLoading() bool {
+	return m.loading
+}
+
+// scheduleRefresh schedules the next auto-refresh tick, as often as the
+// config says. Auto-refresh is paused in low-bandwidth mode.
+func (m *DashboardModel) scheduleRefresh() tea.Cmd {
+	return tea.Tick(m.cfg.DashboardRefresh(), func(time.Time) tea.Msg {
+		return DashboardRefreshTickMsg{dashboard: m}
+	})
+}
+
+// refresh reloads the data, keeping the current data visible when there is some
+func (m *DashboardModel) refresh() tea.Cmd {
+	if m.asOf.IsZero() {
+		m.loading = true
+	} else {
+		m.refreshing = true
+	}
+	m.error = ""
+	return m.load()
+}
+
+// load fetches the shared dashboard data and each widget's own data
+func (m *DashboardModel) load() tea.Cmd {
+	cmds := []tea.Cmd{m.loadData(), m.loadQuota()}
+	for _, widget := range m.widgets {
+		cmds = append(cmds, widget.Load(m.requestCtx()))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages for the dashboard
@@ -65,23 +144,59 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case DashboardDataMsg:
 		m.bookings = msg.Bookings
 		m.locations = msg.Locations
+		m.asOf = msg.AsOf
 		m.loading = false
+		m.refreshing = false
+		m.refreshError = ""
+		return m, nil
+
+	case DashboardRefreshTickMsg:
+		if m.client.LowBandwidth() || m.loading || m.refreshing {
+			return m, m.scheduleRefresh()
+		}
+		return m, tea.Batch(m.refresh(), m.scheduleRefresh())
+
+	case BookingsChangedMsg:
+		if m.loading || m.refreshing {
+			return m, nil
+		}
+		return m, m.refresh()
+
+	case DashboardQuotaMsg:
+		m.quota = msg.Quota
 		return m, nil
 
 	case DashboardErrorMsg:
-		m.error = msg.Error
 		m.loading = false
+		if m.refreshing {
+			m.refreshing = false
+			m.refreshError = msg.Error
+			return m, nil
+		}
+		m.error = msg.Error
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "r", "f5":
-			m.loading = true
-			m.error = ""
-			return m, m.loadData()
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Refresh):
+			m.client.Refresh()
+			return m, m.refresh()
+		case key.Matches(msg, k.PrevDay):
+			m.loadDay = (m.loadDay + 6) % 7
+			return m, nil
+		case key.Matches(msg, k.NextDay):
+			m.loadDay = (m.loadDay + 1) % 7
+			return m, nil
+		case key.Matches(msg, k.OpenDay):
+			return m, m.openLoadDay()
 		}
 	}
 
+	for _, widget := range m.widgets {
+		widget.Update(msg)
+	}
+
 	return m, nil
 }
 
@@ -101,18 +216,12 @@ func (m *DashboardModel) View() string {
 	b.WriteString(m.renderHeader())
 	b.WriteString("\n\n")
 
-	// Two-column layout
-	leftColumn := m.renderStats()
-	rightColumn := m.renderUpcomingBookings()
-
-	columns := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		leftColumn,
-		"  ",
-		rightColumn,
-	)
+	// This week's meeting load
+	b.WriteString(m.renderMeetingLoad())
+	b.WriteString("\n\n")
 
-	b.WriteString(columns)
+	// Widgets
+	b.WriteString(m.renderWidgets())
 	b.WriteString("\n\n")
 
 	// Quick actions
@@ -131,132 +240,55 @@ func (m *DashboardModel) renderHeader() string {
 
 	title := m.styles.Title.Render("Dashboard")
 	welcome := m.styles.Text.Render(fmt.Sprintf("Welcome back, %s!", m.user.FullName()))
-	role := m.styles.Badge.Render(string(m.user.Role))
+	role := m.styles.Badge.Render(string(m.user.GetRole()))
 
 	b.WriteString(title)
 	b.WriteString("\n")
 	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Left, welcome, "  ", role))
 
-	return b.String()
-}
-
-// renderStats renders statistics cards
-func (m *DashboardModel) renderStats() string {
-	var b strings.Builder
-
-	// Calculate stats
-	upcomingCount := 0
-	todayCount := 0
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
-	for _, booking := range m.bookings {
-		if booking.Status == models.BookingStatusConfirmed && booking.StartTime.After(now) {
-			upcomingCount++
-			if booking.StartTime.After(today) && booking.StartTime.Before(today.Add(24*time.Hour)) {
-				todayCount++
-			}
-		}
+	if age := renderDataAge(m.styles, m.asOf, m.refreshing, m.client.LowBandwidth(), m.refreshError); age != "" {
+		b.WriteString("\n")
+		b.WriteString(age)
 	}
 
-	// Stats cards
-	upcomingCard := m.renderStatCard("Upcoming Bookings", fmt.Sprintf("%d", upcomingCount), m.styles.Colors.Primary)
-	todayCard := m.renderStatCard("Today", fmt.Sprintf("%d", todayCount), m.styles.Colors.Success)
-	locationsCard := m.renderStatCard("Locations", fmt.Sprintf("%d", len(m.locations)), m.styles.Colors.Info)
-
-	b.WriteString(m.styles.Heading.Render("Quick Stats"))
-	b.WriteString("\n\n")
-	b.WriteString(upcomingCard)
-	b.WriteString("\n")
-	b.WriteString(todayCard)
-	b.WriteString("\n")
-	b.WriteString(locationsCard)
-
-	return m.styles.Panel.Width(40).Render(b.String())
-}
-
-// renderStatCard renders a single stat card
-func (m *DashboardModel) renderStatCard(label, value string, color lipgloss.Color) string {
-	valueStyle := lipgloss.NewStyle().
-		Foreground(color).
-		Bold(true).
-		Width(8).
-		Align(lipgloss.Right)
-
-	labelStyle := m.styles.TextMuted.Width(20)
-
-	return lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		labelStyle.Render(label),
-		valueStyle.Render(value),
-	)
+	return b.String()
 }
 
-// renderUpcomingBookings renders the list of upcoming bookings
-func (m *DashboardModel) renderUpcomingBookings() string {
-	var b strings.Builder
-
-	b.WriteString(m.styles.Heading.Render("Upcoming Bookings"))
-	b.WriteString("\n\n")
+// renderWidgets lays the widgets out left to right, wrapping to a new row
+// when the next panel doesn't fit the terminal width
+func (m *DashboardModel) renderWidgets() string {
+	if len(m.widgets) == 0 {
+		return m.styles.TextMuted.Render("No widgets enabled. Choose some in Settings (7).")
+	}
 
-	// Filter and sort upcoming bookings
-	now := time.Now()
-	upcoming := []models.Booking{}
-	for _, booking := range m.bookings {
-		if booking.Status == models.BookingStatusConfirmed && booking.StartTime.After(now) {
-			upcoming = append(upcoming, booking)
-		}
+	data := DashboardData{
+		User:      m.user,
+		Bookings:  m.bookings,
+		Locations: m.locations,
+		Quota:     m.quota,
 	}
 
-	if len(upcoming) == 0 {
-		b.WriteString(m.styles.TextMuted.Render("No upcoming bookings"))
-	} else {
-		// Show up to 5 upcoming bookings
-		count := len(upcoming)
-		if count > 5 {
-			count = 5
-		}
+	var rows []string
+	var row []string
+	rowWidth := 0
+	for _, widget := range m.widgets {
+		panel := widget.View(data)
+		panelWidth := lipgloss.Width(panel)
 
-		for i := 0; i < count; i++ {
-			booking := upcoming[i]
-			b.WriteString(m.renderBookingItem(booking))
-			if i < count-1 {
-				b.WriteString("\n")
-			}
+		if len(row) > 0 && m.width > 0 && rowWidth+2+panelWidth > m.width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
 		}
-
-		if len(upcoming) > 5 {
-			b.WriteString("\n")
-			b.WriteString(m.styles.TextMuted.Render(fmt.Sprintf("...and %d more", len(upcoming)-5)))
+		if len(row) > 0 {
+			row = append(row, "  ")
+			rowWidth += 2
 		}
+		row = append(row, panel)
+		rowWidth += panelWidth
 	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
 
-	return m.styles.Panel.Width(60).Render(b.String())
-}
-
-// renderBookingItem renders a single booking item
-func (m *DashboardModel) renderBookingItem(booking models.Booking) string {
-	// Room name
-	roomName := m.styles.TextBold.Render(booking.Room.Name)
-
-	// Time
-	timeStr := utils.FormatDateTime(booking.StartTime)
-	if utils.IsToday(booking.StartTime) {
-		timeStr = m.styles.TextSuccess.Render("Today at " + utils.FormatTime(booking.StartTime))
-	} else {
-		timeStr = m.styles.TextMuted.Render(timeStr)
-	}
-
-	// Duration
-	duration := utils.FormatDuration(booking.StartTime, booking.EndTime)
-
-	// Location
-	location := m.styles.TextDim.Render(booking.Room.Location.Name)
-
-	line1 := lipgloss.JoinHorizontal(lipgloss.Left, roomName, " • ", location)
-	line2 := lipgloss.JoinHorizontal(lipgloss.Left, timeStr, " • ", m.styles.TextMuted.Render(duration))
-
-	return line1 + "\n" + line2
+	return strings.Join(rows, "\n")
 }
 
 // renderQuickActions renders quick action buttons
@@ -266,15 +298,20 @@ func (m *DashboardModel) renderQuickActions() string {
 	b.WriteString(m.styles.Heading.Render("Quick Actions"))
 	b.WriteString("\n\n")
 
-	actions := []struct {
+	type quickAction struct {
 		key   string
 		label string
-	}{
+	}
+	actions := []quickAction{
 		{"2", "Browse Locations"},
 		{"3", "Browse Rooms"},
 		{"4", "View Calendar"},
 		{"5", "My Bookings"},
 		{"6", "Search Rooms"},
+		{"7", "Settings"},
+	}
+	if m.showAdmin {
+		actions = append(actions, quickAction{"0", "Admin Panel"})
 	}
 
 	for i, action := range actions {
@@ -288,14 +325,33 @@ func (m *DashboardModel) renderQuickActions() string {
 	return b.String()
 }
 
-// renderHelp renders help text
-func (m *DashboardModel) renderHelp() string {
-	help := []string{
-		"r/F5: Refresh",
-		"?: Help",
-		"q: Quit",
+// dashboardKeyMap lists the dashboard's keys. Help and Quit are the app's;
+// they're here for the footer.
+type dashboardKeyMap struct {
+	PrevDay key.Binding
+	NextDay key.Binding
+	OpenDay key.Binding
+	Refresh key.Binding
+	Help    key.Binding
+	Quit    key.Binding
+}
+
+// keyMap returns the dashboard's keys
+func (m *DashboardModel) keyMap() dashboardKeyMap {
+	return dashboardKeyMap{
+		PrevDay: newKey("←/→", "Pick day", "left", "h"),
+		NextDay: hiddenKey("right", "l"),
+		OpenDay: newKey("enter", "Open day", "enter"),
+		Refresh: newKey("r/F5", "Refresh", "r", "f5"),
+		Help:    newKey("?", "Help", "?"),
+		Quit:    newKey("q", "Quit", "q"),
 	}
-	return m.styles.Help.Render(strings.Join(help, " • "))
+}
+
+// renderHelp renders the key hints
+func (m *DashboardModel) renderHelp() string {
+	k := m.keyMap()
+	return renderFooter(m.styles, m.width, k.PrevDay, k.OpenDay, k.Refresh, k.Help, k.Quit)
 }
 
 // renderLoading renders the loading state
@@ -311,17 +367,33 @@ func (m *DashboardModel) renderError() string {
 		m.styles.Help.Render("Press r to retry")
 }
 
+// loadQuota loads the user's booking quota. Failures are ignored so the
+// dashboard still works against servers without quota support.
+func (m *DashboardModel) loadQuota() tea.Cmd {
+	ctx := m.requestCtx()
+	return func() tea.Msg {
+		quota, err := m.client.GetQuota(ctx, time.Now())
+		if err != nil {
+			return DashboardQuotaMsg{}
+		}
+		return DashboardQuotaMsg{Quota: quota}
+	}
+}
+
 // loadData loads dashboard data from the API
 func (m *DashboardModel) loadData() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
+		started := time.Now()
+
 		// Load bookings and locations in parallel
-		bookingsChan := make(chan []models.Booking)
-		locationsChan := make(chan []models.Location)
+		bookingsChan := make(chan []milesapi.Booking)
+		locationsChan := make(chan []milesapi.Location)
 		errChan := make(chan error, 2)
 
 		// Load bookings
 		go func() {
-			bookings, err := m.client.GetMyBookings()
+			bookings, err := m.client.GetMyBookings(ctx)
 			if err != nil {
 				errChan <- err
 				return
@@ -331,7 +403,7 @@ func (m *DashboardModel) loadData() tea.Cmd {
 
 		// Load locations
 		go func() {
-			locations, err := m.client.GetLocations()
+			locations, err := m.client.GetLocations(ctx)
 			if err != nil {
 				errChan <- err
 				return
@@ -340,8 +412,8 @@ func (m *DashboardModel) loadData() tea.Cmd {
 		}()
 
 		// Wait for results
-		var bookings []models.Booking
-		var locations []models.Location
+		var bookings []milesapi.Booking
+		var locations []milesapi.Location
 		var errors []error
 
 		for i := 0; i < 2; i++ {
@@ -362,6 +434,7 @@ func (m *DashboardModel) loadData() tea.Cmd {
 		return DashboardDataMsg{
 			Bookings:  bookings,
 			Locations: locations,
+			AsOf:      m.client.DataAsOf(started),
 		}
 	}
 }
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// OpenCalendarDayMsg opens the calendar's day view on a date
+type OpenCalendarDayMsg struct {
+	Date time.Time
+}
+
+// sparkBlocks draw a day's booked hours, from under an hour to a full day
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// fullMeetingDay is the booked time that fills a sparkline column.
+// The scale is fixed so a quiet week looks quiet.
+const fullMeetingDay = 8 * time.Hour
+
+// Weekly totals above these are a moderate or heavy meeting load
+const (
+	moderateMeetingLoad = 10 * time.Hour
+	heavyMeetingLoad    = 20 * time.Hour
+)
+
+// weekStart returns the Sunday the week of date starts on, as in the calendar
+func weekStart(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return day.AddDate(0, 0, -int(day.Weekday()))
+}
+
+// weeklyLoad adds up the booked time on each day of the week starting at
+// start. Bookings running past midnight count toward both days.
+func weeklyLoad(bookings []milesapi.Booking, start time.Time) [7]time.Duration {
+	var load [7]time.Duration
+	for _, booking := range bookings {
+		if booking.GetStatus() == milesapi.BookingStatusCANCELLED {
+			continue
+		}
+		bookingStart, bookingEnd := booking.GetStartTime().In(start.Location()), booking.GetEndTime().In(start.Location())
+		for i := range load {
+			dayStart := start.AddDate(0, 0, i)
+			dayEnd := dayStart.AddDate(0, 0, 1)
+			from, to := bookingStart, bookingEnd
+			if from.Before(dayStart) {
+				from = dayStart
+			}
+			if to.After(dayEnd) {
+				to = dayEnd
+			}
+			if to.After(from) {
+				load[i] += to.Sub(from)
+			}
+		}
+	}
+	return load
+}
+
+// meetingLoadLevel names how busy a week's worth of booked time is
+func meetingLoadLevel(total time.Duration) string {
+	switch {
+	case total >= heavyMeetingLoad:
+		return "heavy"
+	case total >= moderateMeetingLoad:
+		return "moderate"
+	default:
+		return "light"
+	}
+}
+
+// sparkBlock draws one day's booked time, or a dot for a free day
+func sparkBlock(booked time.Duration) string {
+	if booked <= 0 {
+		return "·"
+	}
+	level := int(booked * time.Duration(len(sparkBlocks)) / fullMeetingDay)
+	level = min(level, len(sparkBlocks)-1)
+	return string(sparkBlocks[level])
+}
+
+// formatHours shows booked time as hours, e.g. 12.5h
+func formatHours(d time.Duration) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", d.Hours()), ".0") + "h"
+}
+
+// renderMeetingLoad draws this week's booked hours per day with the selected
+// day highlighted, and names the week's load
+func (m *DashboardModel) renderMeetingLoad() string {
+	start := weekStart(time.Now())
+	load := weeklyLoad(m.bookings, start)
+
+	var total time.Duration
+	var spark, days strings.Builder
+	for i, booked := range load {
+		total += booked
+		block := sparkBlock(booked) + " "
+		day := start.AddDate(0, 0, i).Format("Mon")[:1] + " "
+		if i == m.loadDay {
+			spark.WriteString(m.styles.TextInfo.Render(block))
+			days.WriteString(m.styles.TextInfo.Render(day))
+		} else {
+			spark.WriteString(m.styles.Text.Render(block))
+			days.WriteString(m.styles.TextMuted.Render(day))
+		}
+	}
+
+	level := meetingLoadLevel(total)
+	qualifier := m.styles.TextSuccess
+	switch level {
+	case "heavy":
+		qualifier = m.styles.TextError
+	case "moderate":
+		qualifier = m.styles.TextWarning
+	}
+
+	selected := start.AddDate(0, 0, m.loadDay)
+	label := m.styles.TextBold.Render("This week  ")
+	return label + spark.String() + " " +
+		m.styles.TextMuted.Render(formatHours(total)+" booked, ") +
+		qualifier.Render("meeting load: "+level) + "\n" +
+		strings.Repeat(" ", len("This week  ")) + days.String() + " " +
+		m.styles.TextMuted.Render(selected.Format("Mon 2 Jan")+": "+formatHours(load[m.loadDay]))
+}
+
+// openLoadDay opens the day picked in the sparkline in the calendar
+func (m *DashboardModel) openLoadDay() tea.Cmd {
+	date := weekStart(time.Now()).AddDate(0, 0, m.loadDay)
+	return func() tea.Msg {
+		return OpenCalendarDayMsg{Date: date}
+	}
+}
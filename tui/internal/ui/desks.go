@@ -0,0 +1,391 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// deskFloor is one floor of one location, the choices of the floor filter
+type deskFloor struct {
+	LocationID   string
+	LocationName string
+	Floor        string
+}
+
+// Label names the floor, e.g. "Oslo HQ, floor 3"
+func (f deskFloor) Label() string {
+	if f.Floor == "" {
+		return f.LocationName
+	}
+	return fmt.Sprintf("%s, floor %s", f.LocationName, f.Floor)
+}
+
+// DesksModel lists the hot desks floor by floor with how each is booked
+// for a day. Desks are rooms with type DESK, so Enter books one with the
+// same form as a meeting room.
+type DesksModel struct {
+	requests
+
+	styles   *styles.Styles
+	client   *api.Client
+	width    int
+	height   int
+	readOnly bool // Guests see when desks are taken but can't book
+
+	// Data
+	desks    []milesapi.Room
+	bookings map[string][]milesapi.Booking // By desk ID, for day
+	floors   []deskFloor
+	floor    int // Index into floors, -1 for every floor
+	day      time.Time
+	cursor   int
+	loading  bool
+	error    string
+
+	// Title, floor and help stay put while the desks scroll
+	layout stickyLayout
+}
+
+// DesksDataMsg contains the loaded desks and their bookings for a day
+type DesksDataMsg struct {
+	Desks    []milesapi.Room
+	Bookings map[string][]milesapi.Booking
+	Day      time.Time
+}
+
+// DesksErrorMsg contains error information
+type DesksErrorMsg struct {
+	Error string
+}
+
+// NewDesksModel creates the desks view for today
+func NewDesksModel(client *api.Client, styles *styles.Styles, readOnly bool) *DesksModel {
+	now := utils.Now()
+	return &DesksModel{
+		styles:   styles,
+		client:   client,
+		readOnly: readOnly,
+		floor:    -1,
+		day:      time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local),
+		loading:  true,
+		layout:   newStickyLayout(),
+	}
+}
+
+// Init loads the desks
+func (m *DesksModel) Init() tea.Cmd {
+	return m.loadData()
+}
+
+// isLoading reports whether the desk map is still loading
+func (m *DesksModel) isLoading() bool {
+	return m.loading
+}
+
+// Update handles messages for the desks view
+func (m *DesksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.layout.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case DesksDataMsg:
+		// A reply for a day we've since moved on from
+		if !msg.Day.Equal(m.day) {
+			return m, nil
+		}
+		m.desks = msg.Desks
+		m.bookings = msg.Bookings
+		m.floors = deskFloors(msg.Desks)
+		if m.floor >= len(m.floors) {
+			m.floor = -1
+		}
+		m.cursor = min(m.cursor, max(0, len(m.visibleDesks())-1))
+		m.loading = false
+		m.error = ""
+		return m, nil
+
+	case DesksErrorMsg:
+		m.error = msg.Error
+		m.loading = false
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+		if m.layout.Scroll(msg) {
+			return m, nil
+		}
+
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Refresh):
+			m.loading = true
+			m.error = ""
+			return m, m.loadData()
+
+		case key.Matches(msg, k.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, k.Down):
+			if m.cursor < len(m.visibleDesks())-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, k.Top):
+			m.cursor = 0
+
+		case key.Matches(msg, k.Bottom):
+			m.cursor = max(0, len(m.visibleDesks())-1)
+
+		case key.Matches(msg, k.NextFloor):
+			m.cycleFloor(1)
+
+		case key.Matches(msg, k.PrevFloor):
+			m.cycleFloor(-1)
+
+		case key.Matches(msg, k.PrevDay):
+			return m, m.setDay(m.day.AddDate(0, 0, -1))
+
+		case key.Matches(msg, k.NextDay):
+			return m, m.setDay(m.day.AddDate(0, 0, 1))
+
+		case key.Matches(msg, k.Today):
+			now := utils.Now()
+			return m, m.setDay(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local))
+
+		case key.Matches(msg, k.Select):
+			desk := m.visibleDesks()[m.cursor]
+			return m, func() tea.Msg {
+				return RoomSelectMsg{Room: desk}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// cycleFloor moves the floor filter by delta, through "every floor"
+func (m *DesksModel) cycleFloor(delta int) {
+	n := len(m.floors) + 1
+	m.floor = (m.floor+1+delta+n)%n - 1
+	m.cursor = 0
+	m.layout.GotoTop()
+}
+
+// setDay shows another day, reloading the bookings
+func (m *DesksModel) setDay(day time.Time) tea.Cmd {
+	m.day = day
+	m.loading = true
+	m.error = ""
+	return m.loadData()
+}
+
+// visibleDesks returns the desks on the chosen floor
+func (m *DesksModel) visibleDesks() []milesapi.Room {
+	if m.floor < 0 {
+		return m.desks
+	}
+	floor := m.floors[m.floor]
+	return utils.Filter(m.desks, func(desk milesapi.Room) bool {
+		return desk.GetLocationId() == floor.LocationID && desk.GetFloor() == floor.Floor
+	})
+}
+
+// View renders the desks view
+func (m *DesksModel) View() string {
+	title := m.styles.Title.Render("Hot Desks")
+	if m.loading {
+		return title + "\n\n" + m.styles.TextMuted.Render("Loading...")
+	}
+	if m.error != "" {
+		return title + "\n\n" +
+			m.styles.TextError.Render("Error: "+m.error) + "\n\n" +
+			m.styles.Help.Render("r: Retry")
+	}
+
+	desks := m.visibleDesks()
+	free := 0
+	for _, desk := range desks {
+		if len(m.bookings[desk.GetId()]) == 0 {
+			free++
+		}
+	}
+
+	where := "Every floor"
+	if m.floor >= 0 {
+		where = m.floors[m.floor].Label()
+	}
+	header := title + "\n" +
+		m.styles.Subtitle.Render(fmt.Sprintf("%s • %s • %d of %d free all day",
+			m.day.Format("Monday Jan 2"), where, free, len(desks))) + "\n"
+
+	if len(desks) == 0 {
+		return header + "\n" +
+			m.styles.TextMuted.Render("  No hot desks here. Admins add them as rooms of type DESK.") + "\n\n" +
+			m.renderHelp()
+	}
+
+	var items []string
+	lastFloor := ""
+	for i, desk := range desks {
+		item := ""
+		// Group by floor when showing them all
+		if label := m.floorLabel(desk); m.floor < 0 && label != lastFloor {
+			if lastFloor != "" {
+				item = "\n"
+			}
+			item += m.styles.Heading.Render(label) + "\n"
+			lastFloor = label
+		}
+		items = append(items, item+m.renderDesk(desk, i == m.cursor))
+	}
+	body, top, bottom := joinItems(items, "\n", m.cursor)
+
+	return m.layout.Render(header, body, "\n"+m.renderHelp(), top, bottom)
+}
+
+// floorLabel names a desk's floor with its location
+func (m *DesksModel) floorLabel(desk milesapi.Room) string {
+	for _, floor := range m.floors {
+		if floor.LocationID == desk.GetLocationId() && floor.Floor == desk.GetFloor() {
+			return floor.Label()
+		}
+	}
+	return desk.GetFloor()
+}
+
+// renderDesk renders a desk on one line, with the day's timeline under the
+// selected one
+func (m *DesksModel) renderDesk(desk milesapi.Room, isSelected bool) string {
+	bookings := m.bookings[desk.GetId()]
+	status := m.styles.TextSuccess.Render("free all day")
+	if len(bookings) > 0 {
+		var taken []string
+		for _, booking := range bookings {
+			taken = append(taken, utils.FormatTime(booking.GetStartTime())+"-"+utils.FormatTime(booking.GetEndTime()))
+		}
+		status = m.styles.TextWarning.Render("taken " + strings.Join(taken, ", "))
+	}
+
+	if !isSelected {
+		return "  " + m.styles.Text.Render(desk.GetName()) + "  " + status
+	}
+
+	line := m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ") +
+		m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render(desk.GetName()) + "  " + status
+	width := m.width - 4
+	if width <= 0 {
+		width = 76
+	}
+	return line + "\n  " + renderDayTimeline(m.styles, m.day, bookings, m.day, m.day, width)
+}
+
+// desksKeyMap lists the desks view's keys. NextFloor and PrevDay carry
+// the hints for their pairs.
+type desksKeyMap struct {
+	listKeyMap
+	Select    key.Binding
+	NextFloor key.Binding
+	PrevFloor key.Binding
+	PrevDay   key.Binding
+	NextDay   key.Binding
+	Today     key.Binding
+	Refresh   key.Binding
+}
+
+// keyMap returns the desks view's keys for what is on screen
+func (m *DesksModel) keyMap() desksKeyMap {
+	k := desksKeyMap{
+		listKeyMap: newListKeyMap(),
+		Select:     newKey("Enter", "Book", "enter"),
+		NextFloor:  newKey("f/F", "Floor", "f"),
+		PrevFloor:  hiddenKey("F"),
+		PrevDay:    newKey("←/→", "Day", "left", "h"),
+		NextDay:    hiddenKey("right", "l"),
+		Today:      newKey("t", "Today", "t"),
+		Refresh:    newKey("r", "Refresh", "r", "f5"),
+	}
+	if m.readOnly {
+		k.Select.SetHelp("Enter", "View availability")
+	}
+	k.Select.SetEnabled(m.cursor < len(m.visibleDesks()))
+	// With one floor or none there is nothing to switch between
+	k.NextFloor.SetEnabled(len(m.floors) > 1)
+	k.PrevFloor.SetEnabled(len(m.floors) > 1)
+	return k
+}
+
+// renderHelp renders help text
+func (m *DesksModel) renderHelp() string {
+	k := m.keyMap()
+	return renderFooter(m.styles, m.width, k.Up, k.Select, k.NextFloor, k.PrevDay, k.Today, k.Refresh)
+}
+
+// loadData loads the desks and each one's active bookings for the day
+func (m *DesksModel) loadData() tea.Cmd {
+	ctx := m.requestCtx()
+	client, day := m.client, m.day
+	return func() tea.Msg {
+		desks, err := client.GetDesks(ctx, "", "")
+		if err != nil {
+			return DesksErrorMsg{Error: err.Error()}
+		}
+		desks = utils.Filter(desks, func(desk milesapi.Room) bool { return desk.GetIsActive() })
+		sort.SliceStable(desks, func(i, j int) bool {
+			a, b := desks[i], desks[j]
+			if a.GetLocation().GetName() != b.GetLocation().GetName() {
+				return a.GetLocation().GetName() < b.GetLocation().GetName()
+			}
+			if a.GetFloor() != b.GetFloor() {
+				return a.GetFloor() < b.GetFloor()
+			}
+			return a.GetName() < b.GetName()
+		})
+
+		bookings := make(map[string][]milesapi.Booking)
+		for _, desk := range desks {
+			dayBookings, err := client.GetRoomAvailability(ctx, desk.GetId(), day, day.AddDate(0, 0, 1))
+			if err != nil {
+				return DesksErrorMsg{Error: err.Error()}
+			}
+			bookings[desk.GetId()] = utils.Filter(dayBookings, func(booking milesapi.Booking) bool {
+				return booking.GetStatus() != milesapi.BookingStatusCANCELLED
+			})
+		}
+
+		return DesksDataMsg{Desks: desks, Bookings: bookings, Day: day}
+	}
+}
+
+// deskFloors lists the floors the desks are on, in the desks' order
+func deskFloors(desks []milesapi.Room) []deskFloor {
+	var floors []deskFloor
+	seen := make(map[deskFloor]bool)
+	for _, desk := range desks {
+		name := desk.GetLocation().GetName()
+		if name == "" {
+			name = desk.GetLocationId()
+		}
+		floor := deskFloor{LocationID: desk.GetLocationId(), LocationName: name, Floor: desk.GetFloor()}
+		if !seen[floor] {
+			seen[floor] = true
+			floors = append(floors, floor)
+		}
+	}
+	return floors
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// featureNames name features in messages, e.g. "Hot desks are turned off"
+var featureNames = map[string]string{
+	milesapi.FeatureApprovals: "Approvals",
+	milesapi.FeatureDesks:     "Hot desks",
+}
+
+// featuresLoadedMsg carries the features the server has on
+type featuresLoadedMsg struct {
+	features milesapi.Features
+}
+
+// loadFeatures asks the server which optional features it has on. Until
+// it answers, and if it can't, every feature counts as on; the server
+// still refuses what it doesn't support.
+func (a *App) loadFeatures() tea.Cmd {
+	ctx := a.requestCtx()
+	client := a.client
+	return func() tea.Msg {
+		features, err := client.GetFeatures(ctx)
+		if err != nil {
+			return nil
+		}
+		return featuresLoadedMsg{features: features}
+	}
+}
+
+// requireFeature toasts and returns false when the server has turned a
+// feature off
+func (a *App) requireFeature(name string) (bool, tea.Cmd) {
+	if a.features.Enabled(name) {
+		return true, nil
+	}
+	return false, a.showToast(featureNames[name]+" are turned off on this server", true)
+}
+
+// featureHelpLine renders a shortcut for a feature, greyed out when the
+// server has turned the feature off and otherwise like helpLine. An empty
+// role means anyone may use it.
+func (a *App) featureHelpLine(text, feature string, required milesapi.UserRole) string {
+	switch {
+	case !a.features.Enabled(feature):
+		return a.styles.TextMuted.Render(text + " (turned off on this server)")
+	case required == "":
+		return a.styles.Text.Render(text)
+	}
+	return a.helpLine(text, required)
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/miles/booking-tui/internal/styles"
+)
+
+// staleAfter is how old data gets before views say when it was fetched
+const staleAfter = time.Minute
+
+// renderDataAge renders a "data as of 14:02" indicator. Views keep showing
+// their previous data while refreshing instead of a loading screen, so this
+// tells the user how current it is. It returns "" for fresh data in normal mode.
+func renderDataAge(s *styles.Styles, asOf time.Time, refreshing, lowBandwidth bool, refreshError string) string {
+	if asOf.IsZero() {
+		return ""
+	}
+
+	text := "data as of " + asOf.Format("15:04")
+	switch {
+	case refreshError != "":
+		return s.TextWarning.Render("⚠ Refresh failed, showing " + text)
+	case refreshing:
+		text += " • refreshing…"
+	case !lowBandwidth && time.Since(asOf) < staleAfter:
+		return ""
+	}
+
+	if lowBandwidth {
+		return s.TextMuted.Render("🐢 Low-bandwidth mode • " + text)
+	}
+	return s.TextMuted.Render(text)
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/reminder"
+)
+
+// handoverCheckInterval is how often the app looks for reminders due
+const handoverCheckInterval = 30 * time.Second
+
+// handoverTickMsg triggers the next reminder check
+type handoverTickMsg struct {
+	gen int
+}
+
+// remindersDueMsg carries the reminders the app worked out itself while
+// milesd isn't giving them
+type remindersDueMsg struct {
+	gen       int
+	reminders []reminder.Reminder
+}
+
+// handoverNotice is the wrap-up warning shown until my booking ends
+type handoverNotice struct {
+	text  string
+	until time.Time
+}
+
+// startHandoverChecks (re)starts reminding me of my bookings: those about
+// to start, newly approved or about to end
+func (a *App) startHandoverChecks() tea.Cmd {
+	a.handoverGen++
+	a.handover = nil
+	a.reminded = map[string]bool{}
+	a.reminders = reminder.NewScheduler(a.effectiveUser().GetId(), nextBooking(a.client))
+	if a.guest {
+		return nil
+	}
+	return a.checkHandover()
+}
+
+// scheduleHandoverCheck schedules the next reminder check
+func (a *App) scheduleHandoverCheck() tea.Cmd {
+	gen := a.handoverGen
+	return tea.Tick(handoverCheckInterval, func(time.Time) tea.Msg {
+		return handoverTickMsg{gen: gen}
+	})
+}
+
+// checkHandover clears a notice whose booking has ended and shows the
+// reminders milesd has cached. Without the daemon the app works them out
+// from my bookings as last fetched, the same way milesd does.
+func (a *App) checkHandover() tea.Cmd {
+	now := utils.Now()
+	var cmds []tea.Cmd
+	if a.handover != nil && !now.Before(a.handover.until) {
+		a.handover = nil
+		cmds = append(cmds, a.resizeViews())
+	}
+
+	if reminders, ok := a.client.DaemonReminders(); ok {
+		cmds = append(cmds, a.showReminders(reminders))
+	} else {
+		bookings, _ := a.client.CachedMyBookings()
+		cmds = append(cmds, dueReminders(a.requestCtx(), a.reminders, a.handoverGen, bookings, now))
+	}
+
+	return tea.Batch(append(cmds, a.scheduleHandoverCheck())...)
+}
+
+// dueReminders asks scheduler for the reminders bookings call for. Looking
+// up who has a room next takes a request, so it runs off the update loop.
+func dueReminders(ctx context.Context, scheduler *reminder.Scheduler, gen int, bookings []milesapi.Booking, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return remindersDueMsg{gen: gen, reminders: scheduler.Due(ctx, bookings, now)}
+	}
+}
+
+// nextBooking looks up who has a booking's room after it, for the
+// handover reminder
+func nextBooking(client *api.Client) reminder.NextFunc {
+	return func(ctx context.Context, booking milesapi.Booking) (*milesapi.Booking, error) {
+		end := booking.GetEndTime()
+		next, err := client.GetRoomAvailability(ctx, booking.GetRoomId(), end, end.Add(reminder.HandoverWindow))
+		if err != nil {
+			return nil, err
+		}
+		return reminder.Successor(booking, next), nil
+	}
+}
+
+// showReminders toasts the reminders not shown yet, offering to open the
+// booking, or to extend it when nobody has the room next. When someone
+// does, the wrap-up notice stays above every view until my booking ends.
+func (a *App) showReminders(reminders []reminder.Reminder) tea.Cmd {
+	now := utils.Now()
+	var cmds []tea.Cmd
+	for _, r := range reminders {
+		if a.reminded[r.Key()] || r.Expired(now) {
+			continue
+		}
+		a.reminded[r.Key()] = true
+
+		switch {
+		case r.Kind != reminder.Ending:
+			cmds = append(cmds, a.showActionToast(r.Text(), detailsAction(r.Booking)))
+		case r.Successor == nil:
+			cmds = append(cmds, a.showActionToast(r.Text(), extendAction(r.Booking), detailsAction(r.Booking)))
+		default:
+			a.handover = &handoverNotice{text: r.Text(), until: r.Booking.GetEndTime()}
+			cmds = append(cmds, a.resizeViews())
+		}
+	}
+	return tea.Batch(cmds...)
+}
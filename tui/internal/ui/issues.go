@@ -0,0 +1,463 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Fields of the issue report form, top to bottom
+const (
+	issueFieldMessage = iota
+	issueFieldSeverity
+	issueFieldCount
+)
+
+// IssuesModel lists the room issues the user has reported and how far the
+// managers have got with them
+type IssuesModel struct {
+	requests
+
+	styles *styles.Styles
+	client *api.Client
+	width  int
+	height int
+
+	// Data
+	issues  []milesapi.Feedback
+	cursor  int
+	loading bool
+	error   string
+	notice  string
+
+	// Form for reporting an issue with a room, open while it is set
+	report *issueReportForm
+
+	// Title and help stay put while the list scrolls
+	layout stickyLayout
+}
+
+// issueReportForm describes what is wrong with a room
+type issueReportForm struct {
+	room     milesapi.Room
+	field    int
+	message  textinput.Model
+	severity int // Index into milesapi.FeedbackSeverities
+	sending  bool
+	error    string
+}
+
+// ReportIssueMsg asks the app to open the issue report form for a room
+type ReportIssueMsg struct {
+	Room milesapi.Room
+}
+
+// IssuesDataMsg contains the user's issue reports
+type IssuesDataMsg struct {
+	Issues []milesapi.Feedback
+}
+
+// IssuesErrorMsg contains error information
+type IssuesErrorMsg struct {
+	Error string
+}
+
+// IssueReportedMsg is sent once an issue report has been filed
+type IssueReportedMsg struct {
+	Issue *milesapi.Feedback
+}
+
+// issueReportFailedMsg is sent when filing a report failed; the form stays
+// open so nothing typed is lost
+type issueReportFailedMsg struct {
+	Error string
+}
+
+// NewIssuesModel creates the "My reports" view
+func NewIssuesModel(client *api.Client, styles *styles.Styles) *IssuesModel {
+	return &IssuesModel{
+		styles:  styles,
+		client:  client,
+		loading: true,
+		layout:  newStickyLayout(),
+	}
+}
+
+// Init loads the user's reports
+func (m *IssuesModel) Init() tea.Cmd {
+	return m.loadIssues()
+}
+
+// isLoading reports whether the reports are still loading
+func (m *IssuesModel) isLoading() bool {
+	return m.loading
+}
+
+// Update handles messages for the reports view
+func (m *IssuesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.layout.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case IssuesDataMsg:
+		m.issues = msg.Issues
+		m.cursor = min(m.cursor, max(0, len(m.issues)-1))
+		m.loading = false
+		return m, nil
+
+	case IssuesErrorMsg:
+		m.error = msg.Error
+		m.loading = false
+		return m, nil
+
+	case IssueReportedMsg:
+		m.report = nil
+		m.cursor = 0
+		m.notice = fmt.Sprintf("✓ Reported to the managers of %s", msg.Issue.Room.Name)
+		return m, m.loadIssues()
+
+	case issueReportFailedMsg:
+		if m.report != nil {
+			m.report.sending = false
+			m.report.error = msg.Error
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		// The form can be filled in while the list is still loading
+		if m.report != nil {
+			return m.handleReportKeys(msg)
+		}
+		if m.loading {
+			return m, nil
+		}
+		if m.layout.Scroll(msg) {
+			return m, nil
+		}
+
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Refresh):
+			m.loading = true
+			m.error = ""
+			m.notice = ""
+			return m, m.loadIssues()
+
+		case key.Matches(msg, k.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, k.Down):
+			if m.cursor < len(m.issues)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, k.Top):
+			m.cursor = 0
+			return m, nil
+
+		case key.Matches(msg, k.Bottom):
+			m.cursor = max(0, len(m.issues)-1)
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// openReport opens the report form for room
+func (m *IssuesModel) openReport(room milesapi.Room) tea.Cmd {
+	message := textinput.New()
+	message.Prompt = ""
+	message.Placeholder = "e.g. projector dead"
+	message.CharLimit = 500
+	message.Width = 50
+	message.Focus()
+
+	m.notice = ""
+	m.report = &issueReportForm{
+		room:     room,
+		message:  message,
+		severity: 1, // Medium, as the server defaults to
+	}
+	return textinput.Blink
+}
+
+// handleReportKeys handles keys while the report form is open
+func (m *IssuesModel) handleReportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := m.report
+	if form.sending {
+		return m, nil
+	}
+
+	k := m.reportKeyMap()
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.report = nil
+		return m, nil
+
+	case key.Matches(msg, k.Submit):
+		text := strings.TrimSpace(form.message.Value())
+		if text == "" {
+			form.error = "say what is wrong with the room"
+			return m, nil
+		}
+		form.sending = true
+		severity := milesapi.FeedbackSeverities[form.severity]
+		return m, m.fileReport(milesapi.FeedbackInput{
+			RoomId:   form.room.GetId(),
+			Message:  text,
+			Severity: &severity,
+		})
+
+	case key.Matches(msg, k.Next, k.Prev):
+		form.field = (form.field + 1) % issueFieldCount
+		if form.field == issueFieldMessage {
+			form.message.Focus()
+		} else {
+			form.message.Blur()
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Decrease):
+		form.severity = max(0, form.severity-1)
+		return m, nil
+
+	case key.Matches(msg, k.Increase):
+		form.severity = min(len(milesapi.FeedbackSeverities)-1, form.severity+1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if form.field == issueFieldMessage {
+		form.message, cmd = form.message.Update(msg)
+		form.error = ""
+	}
+	return m, cmd
+}
+
+// issuesKeyMap lists the reports view's keys
+type issuesKeyMap struct {
+	listKeyMap
+	scrollKeyMap
+	Refresh key.Binding
+}
+
+// keyMap returns the reports view's keys
+func (m *IssuesModel) keyMap() issuesKeyMap {
+	k := issuesKeyMap{
+		listKeyMap:   newListKeyMap(),
+		scrollKeyMap: newScrollKeyMap(),
+		Refresh:      newKey("r", "Refresh", "r", "f5"),
+	}
+	k.Up.SetEnabled(len(m.issues) > 1)
+	return k
+}
+
+// reportKeyMap returns the report form's keys. ←→ only change the
+// severity; in the description they move the text cursor.
+func (m *IssuesModel) reportKeyMap() formKeyMap {
+	k := newFormKeyMap("Report")
+	k.Next.SetHelp("Tab/↑↓", "Field")
+	k.Decrease.SetHelp("←→", "Severity")
+	onSeverity := m.report.field == issueFieldSeverity
+	k.Decrease.SetEnabled(onSeverity)
+	k.Increase.SetEnabled(onSeverity)
+	return k
+}
+
+// CapturingInput reports whether keys should go to the report form rather
+// than the app's global shortcuts
+func (m *IssuesModel) CapturingInput() bool {
+	return m.report != nil
+}
+
+// View renders the reports view
+func (m *IssuesModel) View() string {
+	if m.report != nil {
+		return m.renderReport()
+	}
+
+	if m.loading {
+		return m.styles.Title.Render("My Reports") + "\n\n" +
+			m.styles.TextMuted.Render("Loading...")
+	}
+
+	if m.error != "" {
+		return m.styles.Title.Render("My Reports") + "\n\n" +
+			m.styles.TextError.Render("Error: "+m.error) + "\n\n" +
+			m.styles.Help.Render("r: Retry")
+	}
+
+	open := 0
+	for _, issue := range m.issues {
+		if !issue.Status.Closed() {
+			open++
+		}
+	}
+	header := m.styles.Title.Render("My Reports") + "\n" +
+		m.styles.Subtitle.Render(fmt.Sprintf("%d reported, %d still being looked at", len(m.issues), open))
+	if m.notice != "" {
+		header += "\n" + m.styles.TextSuccess.Render(m.notice)
+	}
+	header += "\n"
+
+	body, top, bottom := m.styles.TextMuted.Render("Nothing reported. Press ! on a room in Rooms (3) to report a problem with it."), -1, -1
+	if len(m.issues) > 0 {
+		items := make([]string, len(m.issues))
+		for i, issue := range m.issues {
+			items[i] = renderIssue(m.styles, issue, i == m.cursor, false)
+		}
+		body, top, bottom = joinItems(items, "\n\n", m.cursor)
+	}
+
+	k := m.keyMap()
+	footer := "\n" + renderFooter(m.styles, m.width, k.Up, k.PageUp, k.Refresh)
+	return m.layout.Render(header, body, footer, top, bottom)
+}
+
+// renderReport renders the report form
+func (m *IssuesModel) renderReport() string {
+	form := m.report
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Report an Issue"))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Subtitle.Render(form.room.GetName() + ": the location's managers are told straight away"))
+	b.WriteString("\n\n")
+
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"What's wrong", form.message.View()},
+		{"Severity", "‹ " + milesapi.FeedbackSeverities[form.severity].Label() + " ›"},
+	}
+	for i, row := range rows {
+		label := m.styles.TextMuted.Render(fmt.Sprintf("  %-13s", row.label))
+		if i == form.field {
+			label = m.styles.TextBold.Foreground(m.styles.Colors.Primary).Render(fmt.Sprintf("> %-13s", row.label))
+		}
+		b.WriteString(label + row.value + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.TextMuted.Render("To attach photos, use: miles report issue ROOM --attach FILE"))
+	if form.sending {
+		b.WriteString("\n")
+		b.WriteString(m.styles.TextMuted.Render("Reporting..."))
+	} else if form.error != "" {
+		b.WriteString("\n")
+		b.WriteString(m.styles.TextError.Render("✗ " + form.error))
+	}
+	b.WriteString("\n\n")
+	k := m.reportKeyMap()
+	b.WriteString(renderFooter(m.styles, m.width, k.Next, k.Decrease, k.Submit, k.Cancel))
+
+	return b.String()
+}
+
+// renderIssue renders an issue report over a few lines. The queue shows who
+// reported it; the reporter's own list doesn't need to.
+func renderIssue(s *styles.Styles, issue milesapi.Feedback, selected, showReporter bool) string {
+	cursor := "  "
+	nameStyle := s.TextBold
+	textStyle := s.Text
+	mutedStyle := s.TextMuted
+	if selected {
+		cursor = s.Text.Foreground(s.Colors.Primary).Render("> ")
+		nameStyle = s.TextBold.Foreground(s.Colors.Primary)
+		textStyle = s.Text.Foreground(s.Colors.Primary)
+		mutedStyle = s.TextMuted.Foreground(s.Colors.Primary)
+	}
+
+	lines := []string{
+		lipgloss.JoinHorizontal(lipgloss.Left,
+			cursor,
+			nameStyle.Render(issue.Room.Name),
+			"  ",
+			issueStatusBadge(s, issue.Status),
+			" ",
+			issueSeverityBadge(s, issue.Severity),
+		),
+		"  " + textStyle.Render(issue.Message),
+	}
+
+	reported := "Reported " + issue.CreatedAt.Local().Format("Mon Jan 2 15:04")
+	if showReporter {
+		reported += " by " + issue.User.FullName()
+	}
+	if n := len(issue.Attachments); n > 0 {
+		reported += fmt.Sprintf(" • 📎 %d attachment(s)", n)
+	}
+	lines = append(lines, "  "+mutedStyle.Render(reported))
+
+	if issue.ResolutionComment != nil && *issue.ResolutionComment != "" {
+		by := "Manager"
+		if issue.Resolver != nil {
+			by = issue.Resolver.FullName()
+		}
+		lines = append(lines, "  "+mutedStyle.Render("↳ "+by+": ")+textStyle.Render(*issue.ResolutionComment))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// issueStatusBadge renders an issue's status as a badge
+func issueStatusBadge(s *styles.Styles, status milesapi.FeedbackStatus) string {
+	switch status {
+	case milesapi.OPEN:
+		return s.BadgeWarning.Render(status.Label())
+	case milesapi.INPROGRESS:
+		return s.BadgeInfo.Render(status.Label())
+	case milesapi.RESOLVED:
+		return s.BadgeSuccess.Render(status.Label())
+	}
+	return s.Badge.Render(status.Label())
+}
+
+// issueSeverityBadge renders how severe an issue is, loudest for high
+func issueSeverityBadge(s *styles.Styles, severity milesapi.FeedbackSeverity) string {
+	if severity == milesapi.HIGH {
+		return s.BadgeError.Render(severity.Label())
+	}
+	return s.TextMuted.Render(severity.Label())
+}
+
+// loadIssues loads the user's reports
+func (m *IssuesModel) loadIssues() tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		issues, err := client.GetMyIssues(ctx)
+		if err != nil {
+			return IssuesErrorMsg{Error: err.Error()}
+		}
+		return IssuesDataMsg{Issues: issues}
+	}
+}
+
+// fileReport sends an issue report to the server
+func (m *IssuesModel) fileReport(req milesapi.FeedbackInput) tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		issue, err := client.ReportIssue(ctx, req)
+		if err != nil {
+			return issueReportFailedMsg{Error: err.Error()}
+		}
+		return IssueReportedMsg{Issue: issue}
+	}
+}
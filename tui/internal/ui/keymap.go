@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles/booking-tui/internal/styles"
+)
+
+// Each view declares its keys as a keymap of bindings. Update matches keys
+// against the keymap and the footer is drawn from it, so the hints can't
+// drift from what the keys do. A view's keyMap method returns the keymap
+// for its current state: actions that don't apply right now are disabled,
+// which ignores their keys and greys their hint out, and actions that make
+// no sense for what is on screen are unbound, which hides them.
+
+// footerSeparator goes between the hints in a footer
+const footerSeparator = " • "
+
+// newKey defines a binding and the hint the footer shows for it
+func newKey(help, desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(help, desc))
+}
+
+// hiddenKey defines a binding the footer doesn't show, such as one that
+// shares another binding's hint
+func hiddenKey(keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...))
+}
+
+// hintKey is a footer hint that isn't a key, such as "Type to filter"
+func hintKey(help string) key.Binding {
+	return key.NewBinding(key.WithHelp(help, ""))
+}
+
+// listKeyMap moves a cursor through a list. Up carries the hint for all of
+// them.
+type listKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Top    key.Binding
+	Bottom key.Binding
+}
+
+func newListKeyMap() listKeyMap {
+	return listKeyMap{
+		Up:     newKey("j/k or ↑↓", "Navigate", "up", "k"),
+		Down:   hiddenKey("down", "j"),
+		Top:    hiddenKey("g", "home"),
+		Bottom: hiddenKey("G", "end"),
+	}
+}
+
+// scrollKeyMap pages the body of a sticky layout. PageUp carries the hint
+// for both.
+type scrollKeyMap struct {
+	PageUp   key.Binding
+	PageDown key.Binding
+}
+
+func newScrollKeyMap() scrollKeyMap {
+	return scrollKeyMap{
+		PageUp:   newKey("PgUp/PgDn", "Scroll", "pgup", "ctrl+u"),
+		PageDown: hiddenKey("pgdown", "ctrl+d"),
+	}
+}
+
+// confirmKeyMap answers a yes/no question
+type confirmKeyMap struct {
+	Yes key.Binding
+	No  key.Binding
+}
+
+func newConfirmKeyMap(yes, no string) confirmKeyMap {
+	return confirmKeyMap{
+		Yes: newKey("y", yes, "y", "Y"),
+		No:  newKey("n", no, "n", "N", "esc"),
+	}
+}
+
+// promptKeyMap answers a text prompt or a form
+type promptKeyMap struct {
+	Submit key.Binding
+	Cancel key.Binding
+}
+
+func newPromptKeyMap(submit string) promptKeyMap {
+	return promptKeyMap{
+		Submit: newKey("Enter", submit, "enter"),
+		Cancel: newKey("Esc", "Cancel", "esc"),
+	}
+}
+
+// formKeyMap moves between a form's fields and changes the focused choice.
+// Next and Decrease carry the hints for their pairs.
+type formKeyMap struct {
+	Next     key.Binding
+	Prev     key.Binding
+	Decrease key.Binding
+	Increase key.Binding
+	promptKeyMap
+}
+
+func newFormKeyMap(submit string) formKeyMap {
+	return formKeyMap{
+		Next:         newKey("↑↓/Tab", "Move", "tab", "down"),
+		Prev:         hiddenKey("shift+tab", "up"),
+		Decrease:     newKey("←→", "Change", "left"),
+		Increase:     hiddenKey("right"),
+		promptKeyMap: newPromptKeyMap(submit),
+	}
+}
+
+// renderFooter renders key hints on one line in the help style. Disabled
+// bindings are greyed out and bindings without a hint, including unbound
+// ones, are left out. With a width, hints that don't fit are dropped from
+// the end and marked with "…".
+func renderFooter(s *styles.Styles, width int, bindings ...key.Binding) string {
+	var hints []string
+	for _, binding := range bindings {
+		help := binding.Help()
+		if help.Key == "" {
+			continue
+		}
+		hint := help.Key + ": " + help.Desc
+		if help.Desc == "" {
+			hint = help.Key
+		}
+		// Hints without keys have nothing to disable
+		if binding.Enabled() || len(binding.Keys()) == 0 {
+			hints = append(hints, s.Help.UnsetPadding().Render(hint))
+		} else {
+			hints = append(hints, s.HelpDisabled.Render(hint))
+		}
+	}
+
+	separator := s.Help.UnsetPadding().Render(footerSeparator)
+	line := strings.Join(hints, separator)
+	if width > 0 && lipgloss.Width(line) > width {
+		ellipsis := s.Help.UnsetPadding().Render("…")
+		for len(hints) > 0 {
+			hints = hints[:len(hints)-1]
+			line = strings.Join(append(hints, ellipsis), separator)
+			if lipgloss.Width(line) <= width {
+				break
+			}
+		}
+		if len(hints) == 0 {
+			line = ellipsis
+		}
+	}
+	return lipgloss.NewStyle().Padding(s.Help.GetPadding()).Render(line)
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// stickyLayout pins a view's header (title, filters) and footer (help) to
+// the top and bottom of the screen while the body between them scrolls.
+// Views size it from tea.WindowSizeMsg.
+type stickyLayout struct {
+	body   viewport.Model
+	width  int
+	height int
+}
+
+func newStickyLayout() stickyLayout {
+	return stickyLayout{body: viewport.New(0, 0)}
+}
+
+// SetSize sets the screen area the view is drawn in
+func (l *stickyLayout) SetSize(width, height int) {
+	l.width = width
+	l.height = height
+	l.body.Width = width
+}
+
+// GotoTop scrolls the body back to the start, e.g. when switching screens
+func (l *stickyLayout) GotoTop() {
+	l.body.GotoTop()
+}
+
+// Scroll pages the body for PgUp/PgDn and Ctrl+U/Ctrl+D, reporting
+// whether the key was one of them
+func (l *stickyLayout) Scroll(msg tea.KeyMsg) bool {
+	k := newScrollKeyMap()
+	switch {
+	case key.Matches(msg, k.PageUp):
+		l.body.HalfPageUp()
+	case key.Matches(msg, k.PageDown):
+		l.body.HalfPageDown()
+	default:
+		return false
+	}
+	return true
+}
+
+// BodyHeight returns the lines left for the body between header and footer.
+// It is 0 until the screen size is known.
+func (l *stickyLayout) BodyHeight(header, footer string) int {
+	if l.height <= 0 {
+		return 0
+	}
+	return max(1, l.height-l.lines(header)-l.lines(footer))
+}
+
+// Render stacks header, body and footer on separate lines; callers add
+// blank lines as part of the header or footer. When the body doesn't fit
+// between them it scrolls, keeping body lines top through bottom (usually
+// the selected item) in view. Pass -1 to leave the scroll position alone.
+func (l *stickyLayout) Render(header, body, footer string, top, bottom int) string {
+	height := l.BodyHeight(header, footer)
+	if height == 0 || lipgloss.Height(body) <= height {
+		l.body.GotoTop()
+		return header + "\n" + body + "\n" + footer
+	}
+
+	l.body.Height = height
+	l.body.SetContent(body)
+	if top >= 0 {
+		if bottom < top || bottom-top >= height {
+			bottom = top
+		}
+		if top < l.body.YOffset {
+			l.body.SetYOffset(top)
+		} else if bottom >= l.body.YOffset+height {
+			l.body.SetYOffset(bottom - height + 1)
+		}
+	}
+	return header + "\n" + l.body.View() + "\n" + footer
+}
+
+// lines counts the screen lines s takes up once long lines wrap
+func (l *stickyLayout) lines(s string) int {
+	n := 0
+	for _, line := range strings.Split(s, "\n") {
+		w := lipgloss.Width(line)
+		if l.width > 0 && w > l.width {
+			n += (w + l.width - 1) / l.width
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// joinItems joins rendered list items with sep and returns the body lines
+// the selected item spans, or -1 when nothing is selected
+func joinItems(items []string, sep string, selected int) (body string, top, bottom int) {
+	top, bottom = -1, -1
+	line := 0
+	// Blank lines between items; the first newline only ends the item
+	gap := max(0, strings.Count(sep, "\n")-1)
+	for i, item := range items {
+		height := lipgloss.Height(item)
+		if i == selected {
+			top, bottom = line, line+height-1
+		}
+		line += height + gap
+	}
+	return strings.Join(items, sep), top, bottom
+}
+
+// fitWidth returns how wide to draw something that would like to be
+// preferred characters wide on a screen width wide, leaving margin free
+// and never going below least. Before the screen size is known (width 0)
+// it is preferred.
+func fitWidth(width, margin, least, preferred int) int {
+	if width <= 0 {
+		return preferred
+	}
+	return max(least, min(preferred, width-margin))
+}
+
+// wrapToWidth wraps the lines of s wider than width, so text laid out for a
+// wider screen doesn't spill past the edge after a resize. Lines that fit
+// are left alone, as is everything while width is 0.
+func wrapToWidth(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	wrap := lipgloss.NewStyle().Width(width)
+	for i, line := range lines {
+		if lipgloss.Width(line) > width {
+			lines[i] = wrap.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
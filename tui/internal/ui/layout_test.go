@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resizes shrinks, grows and then squeezes the terminal, as a user
+// dragging the window about would
+var resizes = []tea.WindowSizeMsg{
+	{Width: 80, Height: 24},
+	{Width: 50, Height: 16},
+	{Width: 120, Height: 40},
+	{Width: 30, Height: 10},
+	{Width: 20, Height: 6},
+	{Width: 100, Height: 30},
+}
+
+// checkFits fails when a line of view is wider than width
+func checkFits(t *testing.T, view string, width int) {
+	t.Helper()
+	for i, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > width {
+			t.Errorf("line %d is %d cells wide, more than %d: %q", i, w, width, line)
+		}
+	}
+}
+
+// checkPinned fails unless view starts with header and ends with footer
+func checkPinned(t *testing.T, view, header, footer string) {
+	t.Helper()
+	if !strings.HasPrefix(view, header+"\n") {
+		t.Errorf("header not at the top:\n%s", view)
+	}
+	if !strings.HasSuffix(view, "\n"+footer) {
+		t.Errorf("footer not at the bottom:\n%s", view)
+	}
+}
+
+func TestStickyLayoutResize(t *testing.T) {
+	header := "Bookings\nFilter: all\n"
+	footer := "\n↑/↓: move • q: quit"
+	items := make([]string, 40)
+	for i := range items {
+		items[i] = fmt.Sprintf("Room %02d", i)
+	}
+	body, top, bottom := joinItems(items, "\n", 25)
+
+	l := newStickyLayout()
+	for _, size := range resizes {
+		t.Run(fmt.Sprintf("%dx%d", size.Width, size.Height), func(t *testing.T) {
+			l.SetSize(size.Width, size.Height)
+			view := l.Render(header, body, footer, top, bottom)
+
+			checkPinned(t, view, header, footer)
+			checkFits(t, view, size.Width)
+			if got := lipgloss.Height(view); got != size.Height {
+				t.Errorf("view is %d lines, want the %d of the screen", got, size.Height)
+			}
+			if !strings.Contains(view, items[25]) {
+				t.Errorf("selected item scrolled out of view:\n%s", view)
+			}
+		})
+	}
+}
+
+func TestStickyLayoutTinyTerminal(t *testing.T) {
+	header, footer := "Bookings\n", "\nq: quit"
+	body := "Room 1\nRoom 2\nRoom 3"
+
+	l := newStickyLayout()
+	l.SetSize(20, 3)
+	if got := l.BodyHeight(header, footer); got != 1 {
+		t.Errorf("BodyHeight() = %d, want 1 line however small the screen", got)
+	}
+	view := l.Render(header, body, footer, 2, 2)
+	checkPinned(t, view, header, footer)
+	if !strings.Contains(view, "Room 3") {
+		t.Errorf("selected item not shown:\n%s", view)
+	}
+}
+
+func TestStickyLayoutBeforeSize(t *testing.T) {
+	l := newStickyLayout()
+	if got := l.BodyHeight("a", "b"); got != 0 {
+		t.Errorf("BodyHeight() = %d before the size is known, want 0", got)
+	}
+	body := strings.Repeat("line\n", 50) + "end"
+	if got, want := l.Render("a", body, "b", -1, -1), "a\n"+body+"\nb"; got != want {
+		t.Errorf("Render() before the size is known changed the body")
+	}
+}
+
+func TestStickyLayoutCountsWrappedHeader(t *testing.T) {
+	l := newStickyLayout()
+	l.SetSize(10, 20)
+	// 25 cells wrap onto 3 lines of 10
+	if got := l.lines(strings.Repeat("x", 25) + "\nfoot"); got != 4 {
+		t.Errorf("lines() = %d, want 4", got)
+	}
+	if got := l.BodyHeight(strings.Repeat("x", 25), "foot"); got != 16 {
+		t.Errorf("BodyHeight() = %d, want 16", got)
+	}
+}
+
+func TestJoinItems(t *testing.T) {
+	items := []string{"one", "two\nlines", "three"}
+	tests := []struct {
+		sep         string
+		selected    int
+		top, bottom int
+	}{
+		{"\n", 0, 0, 0},
+		{"\n", 1, 1, 2},
+		{"\n", 2, 3, 3},
+		{"\n\n", 2, 5, 5},
+		{"\n", -1, -1, -1},
+	}
+	for _, tt := range tests {
+		_, top, bottom := joinItems(items, tt.sep, tt.selected)
+		if top != tt.top || bottom != tt.bottom {
+			t.Errorf("joinItems(%q, %d) spans %d-%d, want %d-%d", tt.sep, tt.selected, top, bottom, tt.top, tt.bottom)
+		}
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	tests := []struct {
+		width, margin, least, preferred int
+		want                            int
+	}{
+		{0, 4, 20, 60, 60},   // size not known yet
+		{120, 4, 20, 60, 60}, // room to spare
+		{50, 4, 20, 60, 46},  // shrunk to the screen
+		{15, 4, 20, 60, 20},  // never below least
+	}
+	for _, tt := range tests {
+		if got := fitWidth(tt.width, tt.margin, tt.least, tt.preferred); got != tt.want {
+			t.Errorf("fitWidth(%d, %d, %d, %d) = %d, want %d", tt.width, tt.margin, tt.least, tt.preferred, got, tt.want)
+		}
+	}
+}
+
+func TestWrapToWidth(t *testing.T) {
+	s := "Møterom Ærlig\n" + strings.Repeat("Blåbær og fløte ", 8) + "\n\nkort"
+	if got := wrapToWidth(s, 0); got != s {
+		t.Errorf("wrapToWidth(s, 0) = %q, want s unchanged", got)
+	}
+	for _, width := range []int{80, 40, 20, 14} {
+		got := wrapToWidth(s, width)
+		checkFits(t, got, width)
+		if !strings.HasPrefix(got, "Møterom Ærlig\n") || !strings.HasSuffix(got, "\n\nkort") {
+			t.Errorf("wrapToWidth(s, %d) changed lines that fit:\n%s", width, got)
+		}
+	}
+	checkFits(t, wrapToWidth(s, 8), 8)
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenLinkedBooking makes the app open the booking with id once signed in,
+// for `miles-booking --booking` and miles://booking links
+func (a *App) OpenLinkedBooking(id string) {
+	a.linkedBooking = id
+}
+
+// openLinkedBooking fetches the booking the app was started with and opens
+// its details. Only the first login opens it.
+func (a *App) openLinkedBooking() tea.Cmd {
+	ctx := a.requestCtx()
+	id := a.linkedBooking
+	if id == "" {
+		return nil
+	}
+	a.linkedBooking = ""
+
+	client := a.client
+	return func() tea.Msg {
+		booking, err := client.GetBooking(ctx, id)
+		if err != nil {
+			return ToastMsg{Text: "Can't open linked booking: " + err.Error(), Error: true}
+		}
+		return OpenBookingMsg{Booking: *booking}
+	}
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// serviceIcons mark each category in the services directory
+var serviceIcons = map[milesapi.LocationServiceCategory]string{
+	milesapi.LocationServiceCategoryParking:   "🅿",
+	milesapi.LocationServiceCategoryLockers:   "🔒",
+	milesapi.LocationServiceCategoryBikeRoom:  "🚲",
+	milesapi.LocationServiceCategoryShowers:   "🚿",
+	milesapi.LocationServiceCategoryReception: "🛎",
+}
+
+// LocationServicesMsg contains a location's services directory
+type LocationServicesMsg struct {
+	LocationID string
+	Services   []milesapi.LocationService
+	Err        error
+}
+
+// openDetail shows the location under the cursor with its services
+func (m *LocationsModel) openDetail() tea.Cmd {
+	if m.cursor >= len(m.locations) {
+		return nil
+	}
+	location := m.locations[m.cursor]
+	m.detail = &location
+	m.services = nil
+	m.servicesError = ""
+	m.serviceCursor = 0
+	m.servicesLoading = true
+	return m.loadServices(location.GetId())
+}
+
+// handleDetailKeys handles keys while a location's details are shown
+func (m *LocationsModel) handleDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.detailKeyMap()
+	switch {
+	case key.Matches(msg, k.Back):
+		m.detail = nil
+		return m, nil
+
+	case key.Matches(msg, k.Refresh):
+		m.servicesError = ""
+		m.servicesLoading = true
+		return m, m.loadServices(m.detail.GetId())
+
+	case key.Matches(msg, k.Up):
+		if m.serviceCursor > 0 {
+			m.serviceCursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Down):
+		if m.serviceCursor < len(m.services)-1 {
+			m.serviceCursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Book):
+		// Reservable services are booked through their room, with the
+		// same flow as any other room
+		room := m.rooms[m.services[m.serviceCursor].GetRoomId()]
+		return m, func() tea.Msg {
+			return RoomSelectMsg{Room: room}
+		}
+
+	case key.Matches(msg, k.Rooms):
+		// View the location's rooms, as Enter does in the list
+		location := *m.detail
+		return m, func() tea.Msg {
+			return LocationSelectMsg{Location: location}
+		}
+	}
+	return m, nil
+}
+
+// renderDetail renders a location's details and services directory
+func (m *LocationsModel) renderDetail() string {
+	location := m.detail
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render(location.GetName()))
+	b.WriteString("\n")
+	place := location.GetCity()
+	if location.GetCountry() != "" {
+		place += ", " + location.GetCountry()
+	}
+	b.WriteString(m.styles.Subtitle.Render(place))
+	b.WriteString("\n\n")
+
+	if location.GetAddress() != "" {
+		b.WriteString(m.styles.Text.Render("  Address:   " + location.GetAddress()))
+		b.WriteString("\n")
+	}
+	if location.GetTimezone() != "" {
+		b.WriteString(m.styles.Text.Render("  Time zone: " + location.GetTimezone()))
+		b.WriteString("\n")
+	}
+	b.WriteString(m.styles.Text.Render(fmt.Sprintf("  Rooms:     %d", m.roomCounts[location.GetId()])))
+	b.WriteString("\n")
+	if location.GetRequiresApproval() {
+		b.WriteString(m.styles.TextMuted.Render("  Bookings here wait for a manager's approval"))
+		b.WriteString("\n")
+	}
+	if location.GetDescription() != "" {
+		b.WriteString("\n  ")
+		b.WriteString(m.styles.TextDim.Render(location.GetDescription()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Heading.Render("Services"))
+	b.WriteString("\n\n")
+	b.WriteString(m.renderServices())
+	b.WriteString("\n\n")
+	b.WriteString(m.renderDetailHelp())
+
+	return b.String()
+}
+
+// renderServices renders the services directory
+func (m *LocationsModel) renderServices() string {
+	switch {
+	case m.servicesLoading:
+		return m.styles.TextMuted.Render("  Loading...")
+	case m.servicesError != "":
+		return m.styles.TextError.Render("  " + m.servicesError)
+	case len(m.services) == 0:
+		return m.styles.TextMuted.Render("  No services listed")
+	}
+
+	var lines []string
+	for i, service := range m.services {
+		style := m.styles.Text
+		cursor := "  "
+		if i == m.serviceCursor {
+			style = m.styles.Text.Foreground(m.styles.Colors.Primary)
+			cursor = style.Render("> ")
+		}
+
+		icon := serviceIcons[service.Category]
+		if icon == "" {
+			icon = "•"
+		}
+		line := fmt.Sprintf("%s %s", icon, service.Name)
+		if service.Quantity != nil {
+			line += fmt.Sprintf(" (%d)", *service.Quantity)
+		}
+		if room, ok := m.rooms[service.GetRoomId()]; ok {
+			line += " " + m.styles.BadgeInfo.Render("bookable: "+room.GetName())
+		}
+		lines = append(lines, cursor+style.Render(line))
+
+		var details []string
+		if description := service.GetDescription(); description != "" {
+			details = append(details, description)
+		}
+		if contact := service.GetContact(); contact != "" {
+			details = append(details, "Contact: "+contact)
+		}
+		if len(details) > 0 {
+			lines = append(lines, "    "+m.styles.TextDim.Render(strings.Join(details, " • ")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// locationDetailKeyMap lists the keys of a location's details
+type locationDetailKeyMap struct {
+	listKeyMap
+	Book    key.Binding
+	Rooms   key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+// detailKeyMap returns the details keys. Only services that can be
+// reserved through a room can be booked.
+func (m *LocationsModel) detailKeyMap() locationDetailKeyMap {
+	k := locationDetailKeyMap{
+		listKeyMap: newListKeyMap(),
+		Book:       newKey("Enter", "Book", "enter"),
+		Rooms:      newKey("v", "View rooms", "v"),
+		Refresh:    newKey("r", "Refresh", "r", "f5"),
+		Back:       newKey("Esc", "Back", "esc", "backspace", "d"),
+	}
+	k.Top.Unbind()
+	k.Bottom.Unbind()
+	reservable := false
+	if m.serviceCursor < len(m.services) {
+		_, reservable = m.rooms[m.services[m.serviceCursor].GetRoomId()]
+	}
+	k.Book.SetEnabled(reservable)
+	return k
+}
+
+// renderDetailHelp renders help text for the location details
+func (m *LocationsModel) renderDetailHelp() string {
+	k := m.detailKeyMap()
+	return renderFooter(m.styles, m.width, k.Up, k.Book, k.Rooms, k.Refresh, k.Back)
+}
+
+// loadServices loads a location's services directory
+func (m *LocationsModel) loadServices(locationID string) tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		services, err := client.GetLocationServices(ctx, locationID)
+		return LocationServicesMsg{LocationID: locationID, Services: services, Err: err}
+	}
+}
+
+// servicesErrorText explains why the services directory couldn't load
+func servicesErrorText(err error) string {
+	if errors.Is(err, api.ErrOffline) {
+		return "Services aren't saved for offline use; they'll show once the server is back"
+	}
+	return "Error: " + err.Error()
+}
@@ -4,32 +4,44 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
 	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/pkg/milesapi"
 )
 
 // LocationsModel represents the locations browser view
 type LocationsModel struct {
+	requests
+
 	styles *styles.Styles
 	client *api.Client
 	width  int
 	height int
 
 	// Data
-	locations []models.Location
+	locations []milesapi.Location
 	roomCounts map[string]int
+	rooms     map[string]milesapi.Room // by ID, for booking reservable services
 	cursor    int
 	loading   bool
 	error     string
+
+	// Details of one location and its services directory
+	detail          *milesapi.Location
+	services        []milesapi.LocationService
+	servicesLoading bool
+	servicesError   string
+	serviceCursor   int
 }
 
 // LocationsDataMsg contains loaded locations data
 type LocationsDataMsg struct {
-	Locations  []models.Location
+	Locations  []milesapi.Location
 	RoomCounts map[string]int
+	Rooms      map[string]milesapi.Room
 }
 
 // LocationsErrorMsg contains error information
@@ -39,7 +51,7 @@ type LocationsErrorMsg struct {
 
 // LocationSelectMsg is sent when a location is selected
 type LocationSelectMsg struct {
-	Location models.Location
+	Location milesapi.Location
 }
 
 // NewLocationsModel creates a new locations browser view
@@ -57,6 +69,11 @@ func (m *LocationsModel) Init() tea.Cmd {
 	return m.loadData()
 }
 
+// isLoading reports whether locations are still loading
+func (m *LocationsModel) isLoading() bool {
+	return m.loading
+}
+
 // Update handles messages for the locations view
 func (m *LocationsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -68,9 +85,23 @@ func (m *LocationsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case LocationsDataMsg:
 		m.locations = msg.Locations
 		m.roomCounts = msg.RoomCounts
+		m.rooms = msg.Rooms
 		m.loading = false
 		return m, nil
 
+	case LocationServicesMsg:
+		if m.detail == nil || m.detail.GetId() != msg.LocationID {
+			return m, nil
+		}
+		m.servicesLoading = false
+		if msg.Err != nil {
+			m.servicesError = servicesErrorText(msg.Err)
+			return m, nil
+		}
+		m.services = msg.Services
+		m.serviceCursor = min(m.serviceCursor, max(len(m.services)-1, 0))
+		return m, nil
+
 	case LocationsErrorMsg:
 		m.error = msg.Error
 		m.loading = false
@@ -80,41 +111,45 @@ func (m *LocationsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.loading {
 			return m, nil
 		}
+		if m.detail != nil {
+			return m.handleDetailKeys(msg)
+		}
 
-		switch msg.String() {
-		case "r", "f5":
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Refresh):
 			m.loading = true
 			m.error = ""
 			return m, m.loadData()
 
-		case "up", "k":
+		case key.Matches(msg, k.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
 			return m, nil
 
-		case "down", "j":
+		case key.Matches(msg, k.Down):
 			if m.cursor < len(m.locations)-1 {
 				m.cursor++
 			}
 			return m, nil
 
-		case "g":
+		case key.Matches(msg, k.Top):
 			m.cursor = 0
 			return m, nil
 
-		case "G":
+		case key.Matches(msg, k.Bottom):
 			m.cursor = len(m.locations) - 1
 			return m, nil
 
-		case "enter":
-			if m.cursor < len(m.locations) {
-				// Return message to view rooms for this location
-				return m, func() tea.Msg {
-					return LocationSelectMsg{Location: m.locations[m.cursor]}
-				}
+		case key.Matches(msg, k.Rooms):
+			// Return message to view rooms for this location
+			return m, func() tea.Msg {
+				return LocationSelectMsg{Location: m.locations[m.cursor]}
 			}
-			return m, nil
+
+		case key.Matches(msg, k.Details):
+			return m, m.openDetail()
 		}
 	}
 
@@ -131,6 +166,10 @@ func (m *LocationsModel) View() string {
 		return m.renderError()
 	}
 
+	if m.detail != nil {
+		return m.renderDetail()
+	}
+
 	var b strings.Builder
 
 	// Header
@@ -164,11 +203,11 @@ func (m *LocationsModel) renderLocationsList() string {
 	var b strings.Builder
 
 	// Group by country
-	norway := []models.Location{}
-	international := []models.Location{}
+	norway := []milesapi.Location{}
+	international := []milesapi.Location{}
 
 	for _, loc := range m.locations {
-		if loc.Country == "Norway" {
+		if loc.GetCountry() == "Norway" {
 			norway = append(norway, loc)
 		} else {
 			international = append(international, loc)
@@ -200,18 +239,18 @@ func (m *LocationsModel) renderLocationsList() string {
 }
 
 // renderLocationItem renders a single location item
-func (m *LocationsModel) renderLocationItem(location models.Location) string {
+func (m *LocationsModel) renderLocationItem(location milesapi.Location) string {
 	// Find if this is the cursor position
 	isCursor := false
 	for i, loc := range m.locations {
-		if loc.ID == location.ID && i == m.cursor {
+		if loc.GetId() == location.GetId() && i == m.cursor {
 			isCursor = true
 			break
 		}
 	}
 
 	// Room count
-	roomCount := m.roomCounts[location.ID]
+	roomCount := m.roomCounts[location.GetId()]
 	roomsText := fmt.Sprintf("%d rooms", roomCount)
 	if roomCount == 1 {
 		roomsText = "1 room"
@@ -235,30 +274,50 @@ func (m *LocationsModel) renderLocationItem(location models.Location) string {
 		cursor = cursorStyle.Render("> ")
 	}
 
-	name := nameStyle.Render(location.Name)
-	city := cityStyle.Render(location.City)
+	name := nameStyle.Render(location.GetName())
+	city := cityStyle.Render(location.GetCity())
 	rooms := roomsStyle.Render(roomsText)
 
 	line1 := lipgloss.JoinHorizontal(lipgloss.Left, cursor, name, " • ", city)
 	line2 := lipgloss.JoinHorizontal(lipgloss.Left, "  ", rooms)
 
-	if location.Description != "" {
-		desc := m.styles.TextDim.Render(location.Description)
+	if location.GetDescription() != "" {
+		desc := m.styles.TextDim.Render(location.GetDescription())
 		return line1 + "\n" + line2 + "\n  " + desc
 	}
 
 	return line1 + "\n" + line2
 }
 
+// locationsKeyMap lists the locations list's keys. Back is the app's; it's
+// here for the footer.
+type locationsKeyMap struct {
+	listKeyMap
+	Rooms   key.Binding
+	Details key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+// keyMap returns the list's keys; most need a location under the cursor
+func (m *LocationsModel) keyMap() locationsKeyMap {
+	k := locationsKeyMap{
+		listKeyMap: newListKeyMap(),
+		Rooms:      newKey("Enter", "View rooms", "enter"),
+		Details:    newKey("d", "Details & services", "d"),
+		Refresh:    newKey("r", "Refresh", "r", "f5"),
+		Back:       newKey("1", "Back to dashboard", "1"),
+	}
+	hasLocation := m.cursor < len(m.locations)
+	k.Rooms.SetEnabled(hasLocation)
+	k.Details.SetEnabled(hasLocation)
+	return k
+}
+
 // renderHelp renders help text
 func (m *LocationsModel) renderHelp() string {
-	help := []string{
-		"j/k or ↑↓: Navigate",
-		"Enter: View rooms",
-		"r: Refresh",
-		"1: Back to dashboard",
-	}
-	return m.styles.Help.Render(strings.Join(help, " • "))
+	k := m.keyMap()
+	return renderFooter(m.styles, m.width, k.Up, k.Rooms, k.Details, k.Refresh, k.Back)
 }
 
 // renderLoading renders the loading state
@@ -276,28 +335,32 @@ func (m *LocationsModel) renderError() string {
 
 // loadData loads locations data from the API
 func (m *LocationsModel) loadData() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
 		// Load locations
-		locations, err := m.client.GetLocations()
+		locations, err := m.client.GetLocations(ctx)
 		if err != nil {
 			return LocationsErrorMsg{Error: err.Error()}
 		}
 
 		// Load rooms to count per location
-		rooms, err := m.client.GetRooms(nil, nil, nil)
+		rooms, err := m.client.GetRooms(ctx, "")
 		if err != nil {
 			return LocationsErrorMsg{Error: err.Error()}
 		}
 
 		// Count rooms per location
 		roomCounts := make(map[string]int)
+		byID := make(map[string]milesapi.Room)
 		for _, room := range rooms {
-			roomCounts[room.LocationID]++
+			roomCounts[room.GetLocationId()]++
+			byID[room.GetId()] = room
 		}
 
 		return LocationsDataMsg{
 			Locations:  locations,
 			RoomCounts: roomCounts,
+			Rooms:      byID,
 		}
 	}
 }
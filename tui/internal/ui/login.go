@@ -4,16 +4,19 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
 	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/pkg/milesapi"
 )
 
 // LoginModel represents the login view state
 type LoginModel struct {
+	requests
+
 	styles *styles.Styles
 	client *api.Client
 	width  int
@@ -24,20 +27,31 @@ type LoginModel struct {
 	passwordInput textinput.Model
 	focusIndex    int
 
+	// Set when the server allows browsing without an account
+	guestAllowed bool
+
 	// State
 	loading      bool
 	error        string
 	authenticated bool
-	user         *models.User
+	user         *milesapi.User
 	token        string
 }
 
 // LoginSuccessMsg is sent when login succeeds
 type LoginSuccessMsg struct {
-	User  *models.User
+	User  *milesapi.User
 	Token string
 }
 
+// GuestLoginMsg is sent when the user chooses to browse as a guest
+type GuestLoginMsg struct{}
+
+// guestAccessMsg reports whether the server allows guest access
+type guestAccessMsg struct {
+	Allowed bool
+}
+
 // LoginErrorMsg is sent when login fails
 type LoginErrorMsg struct {
 	Error string
@@ -69,7 +83,11 @@ func NewLoginModel(client *api.Client, styles *styles.Styles) *LoginModel {
 
 // Init initializes the login model
 func (m *LoginModel) Init() tea.Cmd {
-	return textinput.Blink
+	ctx := m.requestCtx()
+	client := m.client
+	return tea.Batch(textinput.Blink, func() tea.Msg {
+		return guestAccessMsg{Allowed: client.GuestAccessAllowed(ctx)}
+	})
 }
 
 // Update handles messages for the login view
@@ -82,18 +100,25 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case guestAccessMsg:
+		m.guestAllowed = msg.Allowed
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.loading {
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "ctrl+c", "esc":
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Quit):
 			return m, tea.Quit
 
-		case "tab", "shift+tab", "up", "down":
-			s := msg.String()
-			if s == "up" || s == "shift+tab" {
+		case key.Matches(msg, k.Guest):
+			return m, func() tea.Msg { return GuestLoginMsg{} }
+
+		case key.Matches(msg, k.Next, k.Prev):
+			if key.Matches(msg, k.Prev) {
 				m.focusIndex--
 			} else {
 				m.focusIndex++
@@ -108,7 +133,7 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateFocus()
 			return m, nil
 
-		case "enter":
+		case key.Matches(msg, k.Login):
 			if m.focusIndex == 2 { // Login button
 				return m, m.login()
 			}
@@ -147,6 +172,32 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// loginKeyMap lists the login form's keys. Enter also moves to the next
+// field until the login button is focused.
+type loginKeyMap struct {
+	Next  key.Binding
+	Prev  key.Binding
+	Login key.Binding
+	Guest key.Binding
+	Quit  key.Binding
+}
+
+// keyMap returns the login keys; browsing as a guest needs the server to
+// allow it
+func (m *LoginModel) keyMap() loginKeyMap {
+	k := loginKeyMap{
+		Next:  newKey("Tab", "Next field", "tab", "down"),
+		Prev:  hiddenKey("shift+tab", "up"),
+		Login: newKey("Enter", "Login", "enter"),
+		Guest: newKey("Ctrl+G", "Browse as guest", "ctrl+g"),
+		Quit:  newKey("Ctrl+C", "Quit", "ctrl+c", "esc"),
+	}
+	if !m.guestAllowed {
+		k.Guest.Unbind()
+	}
+	return k
+}
+
 // View renders the login view
 func (m *LoginModel) View() string {
 	if m.width == 0 {
@@ -210,12 +261,19 @@ func (m *LoginModel) View() string {
 		form.WriteString(lipgloss.Place(44, 1, lipgloss.Center, lipgloss.Top, errorMsg))
 	}
 
+	if m.guestAllowed {
+		form.WriteString("\n")
+		guest := m.styles.TextMuted.Render("Just looking? Ctrl+G to browse as a guest")
+		form.WriteString(lipgloss.Place(44, 1, lipgloss.Center, lipgloss.Top, guest))
+	}
+
 	formBox := formStyle.Render(form.String())
 	b.WriteString(lipgloss.Place(m.width, m.height-10, lipgloss.Center, lipgloss.Top, formBox))
 	b.WriteString("\n\n")
 
 	// Help
-	help := m.styles.Help.Render("Tab: Next field • Enter: Login • Ctrl+C: Quit")
+	k := m.keyMap()
+	help := renderFooter(m.styles, m.width, k.Next, k.Login, k.Guest, k.Quit)
 	b.WriteString(lipgloss.Place(m.width, 1, lipgloss.Center, lipgloss.Top, help))
 
 	// Test account hint
@@ -242,6 +300,7 @@ func (m *LoginModel) updateFocus() {
 
 // login performs the login API call
 func (m *LoginModel) login() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
 		m.loading = true
 		m.error = ""
@@ -258,7 +317,7 @@ func (m *LoginModel) login() tea.Cmd {
 		}
 
 		// Call API
-		response, err := m.client.Login(email, password)
+		response, err := m.client.Login(ctx, email, password)
 		if err != nil {
 			return LoginErrorMsg{Error: fmt.Sprintf("Login failed: %v", err)}
 		}
@@ -267,7 +326,7 @@ func (m *LoginModel) login() tea.Cmd {
 		m.client.SetToken(response.Token)
 
 		return LoginSuccessMsg{
-			User:  &response.User,
+			User:  response.User,
 			Token: response.Token,
 		}
 	}
@@ -279,7 +338,7 @@ func (m *LoginModel) IsAuthenticated() bool {
 }
 
 // GetUser returns the authenticated user
-func (m *LoginModel) GetUser() *models.User {
+func (m *LoginModel) GetUser() *milesapi.User {
 	return m.user
 }
 
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// findNearbyRooms returns up to limit free rooms near the given one, as
+// milesapi.NearbyRooms ranks them. Failures return nothing; the
+// suggestions are only a hint.
+func findNearbyRooms(ctx context.Context, client *api.Client, room milesapi.Room, start, end time.Time, limit int) []milesapi.Room {
+	rooms, err := client.GetRooms(ctx, room.GetLocationId())
+	if err != nil {
+		return nil
+	}
+
+	return milesapi.NearbyRooms(room, rooms, start, end, limit, func(other milesapi.Room) bool {
+		available, err := client.CheckRoomAvailability(ctx, other.GetId(), start, end)
+		return err == nil && available
+	})
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// reconnectDelays are the waits between reconnection attempts while the
+// server is down; the last one repeats
+var reconnectDelays = []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second}
+
+// serverProbedMsg reports whether the server answered at launch
+type serverProbedMsg struct {
+	err error
+}
+
+// reconnectTickMsg triggers the next reconnection attempt
+type reconnectTickMsg struct {
+	gen int
+}
+
+// reconnectFailedMsg is sent when the server still can't be reached
+type reconnectFailedMsg struct {
+	gen int
+}
+
+// reconnectedMsg is sent once the server answers again. user is who the
+// saved session belongs to, or nil when it has to be signed in again.
+type reconnectedMsg struct {
+	gen  int
+	user *milesapi.User
+}
+
+// probeServer checks the server can be reached before the user tries to log in
+func (a *App) probeServer() tea.Cmd {
+	ctx := a.requestCtx()
+	client := a.client
+	return func() tea.Msg {
+		return serverProbedMsg{err: client.Ping(ctx)}
+	}
+}
+
+// goOffline shows the offline banner and starts reconnecting. When an
+// earlier run left a session behind, it opens the offline shell on it: the
+// bookings as of the last sync and the saved rooms and locations, read-only.
+func (a *App) goOffline() tea.Cmd {
+	a.offline = true
+	a.reconnectAttempt = 0
+	cmds := []tea.Cmd{a.scheduleReconnect()}
+
+	if user, savedAt, ok := a.client.OpenOffline(); ok {
+		a.offlineShell = true
+		a.offlineSince = savedAt
+		a.user = user
+		a.state = ViewBookings
+		a.bookings = NewBookingsModel(a.client, a.cfg, a.styles)
+		cmds = append(cmds, a.initView(a.bookings))
+	}
+	return tea.Batch(append(cmds, a.resizeViews())...)
+}
+
+// scheduleReconnect schedules the next reconnection attempt, backing off
+// while the server stays down
+func (a *App) scheduleReconnect() tea.Cmd {
+	a.reconnectGen++
+	gen := a.reconnectGen
+	delay := reconnectDelays[min(a.reconnectAttempt, len(reconnectDelays)-1)]
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return reconnectTickMsg{gen: gen}
+	})
+}
+
+// reconnect tries the server and, in the offline shell, whether the saved
+// session is still good
+func (a *App) reconnect() tea.Cmd {
+	ctx := a.requestCtx()
+	client, gen, shell := a.client, a.reconnectGen, a.offlineShell
+	return func() tea.Msg {
+		if err := client.Reconnect(ctx); err != nil {
+			return reconnectFailedMsg{gen: gen}
+		}
+		if !shell {
+			return reconnectedMsg{gen: gen}
+		}
+		// The session may have expired while the server was down
+		user, err := client.GetCurrentUser(ctx)
+		if err != nil {
+			return reconnectedMsg{gen: gen}
+		}
+		return reconnectedMsg{gen: gen, user: user}
+	}
+}
+
+// retryNow skips the wait before the next reconnection attempt
+func (a *App) retryNow() tea.Cmd {
+	a.reconnectGen++
+	a.reconnectAttempt = 0
+	return tea.Batch(a.showToast("Reconnecting…", false), a.reconnect())
+}
+
+// backOnline leaves offline mode. A still valid session carries on as if
+// just logged in; otherwise the user signs in again.
+func (a *App) backOnline(user *milesapi.User) tea.Cmd {
+	a.offline = false
+	a.reconnectGen++
+
+	if !a.offlineShell {
+		return tea.Batch(a.showToast("Back online", false), a.checkClockSkew())
+	}
+	a.offlineShell = false
+
+	if user != nil {
+		a.authenticated = true
+		a.user = user
+		a.token = a.client.GetToken()
+		return tea.Batch(a.resetViews(), a.showToast("Back online", false), a.checkClockSkew())
+	}
+
+	a.client.ClearToken()
+	a.user = nil
+	a.locations = nil
+	a.rooms = nil
+	a.calendar = nil
+	a.bookings = nil
+	a.settings = nil
+	a.desks = nil
+	a.approvals = nil
+	a.issues = nil
+	a.state = ViewLogin
+	a.login = NewLoginModel(a.client, a.styles)
+	return tea.Batch(a.initView(a.login), a.showToast("Back online — sign in to continue", false))
+}
+
+// handleOfflineKey restricts the offline shell to the views that work from
+// saved data. It returns true when the key was handled.
+func (a *App) handleOfflineKey(key string) (bool, tea.Cmd) {
+	switch key {
+	case "ctrl+r":
+		return true, a.retryNow()
+	case "1", "4", "6", "8", "9", "0", "A", "R", "ctrl+x":
+		if a.offlineShell {
+			return true, a.showToast("Not available offline", true)
+		}
+	}
+	return false, nil
+}
+
+// renderOfflineBanner renders the warning shown on every screen while the
+// server can't be reached
+func (a *App) renderOfflineBanner() string {
+	text := "⚠ OFFLINE — reconnecting…"
+	if a.offlineShell {
+		text += fmt.Sprintf(" • showing data saved %s", a.offlineSince.Local().Format("Jan 2 15:04"))
+	} else {
+		text += " • can't reach the server"
+	}
+	text += " • Ctrl+R: retry now"
+
+	banner := a.styles.BadgeWarning.Margin(0)
+	if a.width > 0 {
+		banner = banner.Width(a.width)
+	}
+	return banner.Render(text)
+}
+
+// renderOfflineHelp lists what works in the offline shell
+func (a *App) renderOfflineHelp() string {
+	return a.styles.Title.Render("Help & Keyboard Shortcuts") + "\n\n" +
+		a.styles.Heading.Render("Offline") + "\n" +
+		a.styles.Text.Render("  The server can't be reached. Saved data is shown read-only") + "\n" +
+		a.styles.Text.Render("  and everything comes back once it answers again.") + "\n\n" +
+		a.styles.Text.Render("  2 - Locations (saved)") + "\n" +
+		a.styles.Text.Render("  3 - Rooms (saved)") + "\n" +
+		a.styles.Text.Render("  5 - My Bookings (as of the last sync)") + "\n" +
+		a.styles.Text.Render("  7 - Settings") + "\n\n" +
+		a.styles.Heading.Render("Global Shortcuts") + "\n" +
+		a.styles.Text.Render("  Ctrl+R - Retry connecting now") + "\n" +
+		a.styles.Text.Render("  L - Log out (forget the saved session)") + "\n" +
+		a.styles.Text.Render("  ? - Show this help") + "\n" +
+		a.styles.Text.Render("  q - Quit application") + "\n\n" +
+		a.styles.Help.Render("Press 5 to go back to your bookings")
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"context"
+	"sync"
+)
+
+// requests ties a view's API calls to the view. Views embed it and pass
+// requestCtx() to the client; leaving a view while it is still loading
+// cancels its requests in flight (see App.leaveView).
+type requests struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// requestCtx returns the context the view's requests run under
+func (r *requests) requestCtx() context.Context {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ctx == nil {
+		r.ctx, r.cancel = context.WithCancel(context.Background())
+	}
+	return r.ctx
+}
+
+// cancelRequests cancels the requests in flight. Later requests get a
+// fresh context.
+func (r *requests) cancelRequests() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.ctx, r.cancel = nil, nil
+}
+
+// loader is a view that loads its data when opened
+type loader interface {
+	isLoading() bool
+	cancelRequests()
+}
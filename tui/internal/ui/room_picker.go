@@ -0,0 +1,430 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// roomPicker is a filterable room list grouped by location with
+// collapsible sections. Typing fuzzy-filters the list; matches are
+// highlighted and groups are ranked by their best match.
+type roomPicker struct {
+	styles *styles.Styles
+
+	filter    textinput.Model
+	rooms     []milesapi.Room
+	collapsed map[string]bool
+
+	rows   []pickerRow
+	cursor int
+	height int // Maximum number of rows to render, 0 = unlimited
+	width  int // Rows are cut to this width, 0 = unlimited
+
+	// Locked rooms can't be selected, only asked for access to
+	lockRestricted bool
+}
+
+// pickerRow is either a location header (room == nil) or a room entry
+type pickerRow struct {
+	location string
+	count    int // Rooms in the group, for headers
+
+	room       *milesapi.Room
+	score      int
+	nameMatch  []int
+	placeMatch []int
+}
+
+func newRoomPicker(styles *styles.Styles) roomPicker {
+	filter := textinput.New()
+	filter.Placeholder = "Type to search rooms..."
+	filter.Prompt = "/ "
+	filter.CharLimit = 50
+	filter.Width = 40
+	filter.Focus()
+
+	return roomPicker{
+		styles:    styles,
+		filter:    filter,
+		collapsed: make(map[string]bool),
+	}
+}
+
+// SetRooms replaces the room list
+func (p *roomPicker) SetRooms(rooms []milesapi.Room) {
+	p.rooms = rooms
+	p.rebuild()
+}
+
+// SetHeight limits the number of visible rows
+func (p *roomPicker) SetHeight(height int) {
+	p.height = height
+}
+
+// SetWidth fits the filter and rows to the screen width. Rows are cut
+// rather than wrapped so the list keeps one line per row.
+func (p *roomPicker) SetWidth(width int) {
+	p.width = width
+	p.filter.Width = fitWidth(width, len(p.filter.Prompt)+2, 10, 40)
+}
+
+// roomPickerKeyMap lists the picker's keys. Letters go to the filter, so
+// only arrows and control keys move the cursor.
+type roomPickerKeyMap struct {
+	Filter        key.Binding
+	Up            key.Binding
+	Down          key.Binding
+	PageUp        key.Binding
+	PageDown      key.Binding
+	Collapse      key.Binding
+	Select        key.Binding
+	RequestAccess key.Binding
+}
+
+// keyMap returns the picker's keys. Tab and Enter need a row, and groups
+// can't be collapsed while filtering. With lockRestricted, locked rooms
+// offer a request for access instead of Enter; the owner of the picker
+// handles that key.
+func (p *roomPicker) keyMap() roomPickerKeyMap {
+	k := roomPickerKeyMap{
+		Filter:        hintKey("Type to filter"),
+		Up:            newKey("↑↓", "Navigate", "up", "ctrl+p"),
+		Down:          hiddenKey("down", "ctrl+n"),
+		PageUp:        hiddenKey("pgup"),
+		PageDown:      hiddenKey("pgdown"),
+		Collapse:      newKey("Tab", "Collapse location", "tab"),
+		Select:        newKey("Enter", "Select", "enter"),
+		RequestAccess: newKey("Ctrl+R", "Request access", "ctrl+r"),
+	}
+	hasRow := p.cursor < len(p.rows)
+	locked := p.lockRestricted && p.Current() != nil && p.Current().Locked()
+	k.Collapse.SetEnabled(hasRow && !p.filtering())
+	k.Select.SetEnabled(hasRow && !locked)
+	k.RequestAccess.SetEnabled(locked)
+	return k
+}
+
+// Current returns the room under the cursor, nil on a location header
+func (p *roomPicker) Current() *milesapi.Room {
+	if p.cursor >= len(p.rows) {
+		return nil
+	}
+	return p.rows[p.cursor].room
+}
+
+// Update handles a key press. It returns the selected room when the user
+// confirms a room row.
+func (p *roomPicker) Update(msg tea.KeyMsg) (*milesapi.Room, tea.Cmd) {
+	k := p.keyMap()
+	switch {
+	case key.Matches(msg, k.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return nil, nil
+
+	case key.Matches(msg, k.Down):
+		if p.cursor < len(p.rows)-1 {
+			p.cursor++
+		}
+		return nil, nil
+
+	case key.Matches(msg, k.PageUp):
+		p.cursor = max(0, p.cursor-p.pageSize())
+		return nil, nil
+
+	case key.Matches(msg, k.PageDown):
+		p.cursor = min(len(p.rows)-1, p.cursor+p.pageSize())
+		return nil, nil
+
+	case key.Matches(msg, k.Collapse):
+		p.toggleGroupAtCursor()
+		return nil, nil
+
+	case key.Matches(msg, k.Select):
+		row := p.rows[p.cursor]
+		if row.room == nil {
+			p.toggleGroupAtCursor()
+			return nil, nil
+		}
+		return row.room, nil
+	}
+
+	// Everything else edits the filter
+	previous := p.filter.Value()
+	var cmd tea.Cmd
+	p.filter, cmd = p.filter.Update(msg)
+	if p.filter.Value() != previous {
+		p.rebuild()
+		p.cursor = p.firstRoomRow()
+	}
+	return nil, cmd
+}
+
+// filtering returns whether a filter query is active
+func (p *roomPicker) filtering() bool {
+	return strings.TrimSpace(p.filter.Value()) != ""
+}
+
+// toggleGroupAtCursor collapses or expands the location group under the cursor
+func (p *roomPicker) toggleGroupAtCursor() {
+	if p.cursor >= len(p.rows) || p.filtering() {
+		return
+	}
+	location := p.rows[p.cursor].location
+	p.collapsed[location] = !p.collapsed[location]
+	p.rebuild()
+
+	// Keep the cursor on the group header
+	for i, row := range p.rows {
+		if row.room == nil && row.location == location {
+			p.cursor = i
+			break
+		}
+	}
+}
+
+// rebuild recomputes the visible rows from rooms, filter and collapse state
+func (p *roomPicker) rebuild() {
+	query := p.filter.Value()
+
+	type group struct {
+		location string
+		best     int
+		rows     []pickerRow
+	}
+	groups := make(map[string]*group)
+
+	for i := range p.rooms {
+		room := &p.rooms[i]
+		location := roomLocationName(*room)
+
+		// Match against "name location" so either can be searched
+		candidate := room.GetName() + " " + location
+		score, positions, ok := utils.FuzzyMatch(query, candidate)
+		if !ok {
+			continue
+		}
+
+		nameLen := len([]rune(room.GetName()))
+		row := pickerRow{location: location, room: room, score: score}
+		for _, pos := range positions {
+			if pos < nameLen {
+				row.nameMatch = append(row.nameMatch, pos)
+			} else if pos > nameLen {
+				row.placeMatch = append(row.placeMatch, pos-nameLen-1)
+			}
+		}
+
+		g, exists := groups[location]
+		if !exists {
+			g = &group{location: location, best: score}
+			groups[location] = g
+		}
+		if score > g.best {
+			g.best = score
+		}
+		g.rows = append(g.rows, row)
+	}
+
+	ordered := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		ordered = append(ordered, g)
+	}
+
+	filtering := p.filtering()
+	sort.Slice(ordered, func(i, j int) bool {
+		if filtering && ordered[i].best != ordered[j].best {
+			return ordered[i].best > ordered[j].best
+		}
+		return ordered[i].location < ordered[j].location
+	})
+
+	p.rows = p.rows[:0]
+	for _, g := range ordered {
+		sort.SliceStable(g.rows, func(i, j int) bool {
+			if filtering && g.rows[i].score != g.rows[j].score {
+				return g.rows[i].score > g.rows[j].score
+			}
+			return g.rows[i].room.GetName() < g.rows[j].room.GetName()
+		})
+
+		p.rows = append(p.rows, pickerRow{location: g.location, count: len(g.rows)})
+		if p.collapsed[g.location] && !filtering {
+			continue
+		}
+		p.rows = append(p.rows, g.rows...)
+	}
+
+	if p.cursor >= len(p.rows) {
+		p.cursor = max(0, len(p.rows)-1)
+	}
+}
+
+// firstRoomRow returns the index of the first room row, or 0
+func (p *roomPicker) firstRoomRow() int {
+	for i, row := range p.rows {
+		if row.room != nil {
+			return i
+		}
+	}
+	return 0
+}
+
+func (p *roomPicker) pageSize() int {
+	if p.height > 0 {
+		return p.height
+	}
+	return 10
+}
+
+// View renders the filter input and the visible rows
+func (p *roomPicker) View() string {
+	var b strings.Builder
+
+	b.WriteString(p.filter.View())
+	b.WriteString("\n\n")
+
+	if len(p.rooms) == 0 {
+		b.WriteString(p.styles.TextMuted.Render("No rooms available"))
+		return b.String()
+	}
+	if len(p.rows) == 0 {
+		b.WriteString(p.styles.TextMuted.Render(fmt.Sprintf("No rooms match %q", p.filter.Value())))
+		return b.String()
+	}
+
+	// Window the rows around the cursor
+	start, end := 0, len(p.rows)
+	if p.height > 0 && len(p.rows) > p.height {
+		start = p.cursor - p.height/2
+		if start < 0 {
+			start = 0
+		}
+		end = start + p.height
+		if end > len(p.rows) {
+			end = len(p.rows)
+			start = end - p.height
+		}
+	}
+
+	if start > 0 {
+		b.WriteString(p.styles.TextDim.Render(fmt.Sprintf("  ↑ %d more", start)))
+		b.WriteString("\n")
+	}
+
+	clip := lipgloss.NewStyle()
+	if p.width > 0 {
+		clip = clip.MaxWidth(p.width)
+	}
+	for i := start; i < end; i++ {
+		b.WriteString(clip.Render(p.renderRow(p.rows[i], i == p.cursor)))
+		b.WriteString("\n")
+	}
+
+	if end < len(p.rows) {
+		b.WriteString(p.styles.TextDim.Render(fmt.Sprintf("  ↓ %d more", len(p.rows)-end)))
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderRow renders a header or room row
+func (p *roomPicker) renderRow(row pickerRow, selected bool) string {
+	cursor := "  "
+	if selected {
+		cursor = p.styles.Text.Foreground(p.styles.Colors.Primary).Render("> ")
+	}
+
+	if row.room == nil {
+		marker := "▾"
+		if p.collapsed[row.location] && !p.filtering() {
+			marker = "▸"
+		}
+		headerStyle := p.styles.TextBold
+		if selected {
+			headerStyle = headerStyle.Foreground(p.styles.Colors.Primary)
+		}
+		return cursor + headerStyle.Render(fmt.Sprintf("%s %s", marker, row.location)) +
+			p.styles.TextDim.Render(fmt.Sprintf(" (%d)", row.count))
+	}
+
+	nameStyle := p.styles.Text
+	if selected {
+		nameStyle = p.styles.TextBold.Foreground(p.styles.Colors.Primary)
+	}
+	highlight := nameStyle.Underline(true).Foreground(p.styles.Colors.Accent)
+
+	name := highlightRunes(row.room.GetName(), row.nameMatch, nameStyle, highlight)
+	capacity := p.styles.TextMuted.Render(fmt.Sprintf("Capacity: %d", row.room.GetCapacity()))
+
+	line := cursor + "  " + name + " • " + capacity
+	if row.room.Locked() {
+		line = cursor + "🔒" + name + " • " + capacity + " • " + p.styles.TextMuted.Render(describeRestriction(*row.room))
+	}
+	if len(row.placeMatch) > 0 {
+		// The match came from the location name - show it so the hit makes sense
+		location := highlightRunes(row.location, row.placeMatch, p.styles.TextMuted, highlight)
+		line += " • " + location
+	}
+	return line
+}
+
+// highlightRunes renders s with the runes at positions in the highlight style
+func highlightRunes(s string, positions []int, base, highlight lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(s)
+	}
+
+	marked := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		marked[pos] = true
+	}
+
+	var b strings.Builder
+	var run []rune
+	runHighlighted := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runHighlighted {
+			b.WriteString(highlight.Render(string(run)))
+		} else {
+			b.WriteString(base.Render(string(run)))
+		}
+		run = run[:0]
+	}
+
+	for i, r := range []rune(s) {
+		if marked[i] != runHighlighted {
+			flush()
+			runHighlighted = marked[i]
+		}
+		run = append(run, r)
+	}
+	flush()
+
+	return b.String()
+}
+
+// roomLocationName returns the display name of a room's location
+func roomLocationName(room milesapi.Room) string {
+	if room.GetLocation().GetName() != "" {
+		return room.GetLocation().GetName()
+	}
+	if room.GetLocationId() != "" {
+		return room.GetLocationId()
+	}
+	return "Other"
+}
@@ -1,41 +1,66 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/miles/booking-tui/internal/api"
-	"github.com/miles/booking-tui/internal/models"
 	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/office"
 )
 
 // RoomsModel represents the rooms browser view
 type RoomsModel struct {
+	requests
+
 	styles *styles.Styles
 	client *api.Client
 	width  int
 	height int
 
-	// Filters
-	selectedLocation *models.Location
+	// Filters, applied to allRooms to give rooms
+	selectedLocation *milesapi.Location
 	minCapacity      *int
 	equipment        []string
 
+	// Start at the user's office: the configured one, or else the one the
+	// network points to. officeSource says how the location was chosen.
+	startAtOffice    bool
+	officeLocationID string
+	officeSource     string
+
 	// Data
-	rooms   []models.Room
-	cursor  int
-	loading bool
-	error   string
+	allRooms []milesapi.Room
+	rooms    []milesapi.Room
+	cursor   int
+	loading  bool
+	error    string
+
+	// The filter panel while it is open, and the facet selected in the
+	// summary bar for removal (-1 for none)
+	filterPanel *roomFilterPanel
+	facetCursor int
+
+	// Guests can look at availability but not book
+	readOnly bool
+
+	// Showing saved rooms while the server is down
+	offline bool
 
-	// Filter mode
-	filterMode bool
+	// Title, filters and help stay put while the list scrolls
+	layout stickyLayout
 }
 
 // RoomsDataMsg contains loaded rooms data
 type RoomsDataMsg struct {
-	Rooms []models.Room
+	Rooms []milesapi.Room
 }
 
 // RoomsErrorMsg contains error information
@@ -43,36 +68,62 @@ type RoomsErrorMsg struct {
 	Error string
 }
 
+// RoomsOfficeMsg contains the office the rooms view starts at, nil if unknown
+type RoomsOfficeMsg struct {
+	Location *milesapi.Location
+	Source   string
+}
+
+// officeDetectTimeout bounds office detection when the rooms view opens
+const officeDetectTimeout = 3 * time.Second
+
 // RoomSelectMsg is sent when a room is selected
 type RoomSelectMsg struct {
-	Room models.Room
+	Room milesapi.Room
 }
 
 // NewRoomsModel creates a new rooms browser view
-func NewRoomsModel(client *api.Client, styles *styles.Styles, location *models.Location) *RoomsModel {
+func NewRoomsModel(client *api.Client, styles *styles.Styles, location *milesapi.Location) *RoomsModel {
 	return &RoomsModel{
 		styles:           styles,
 		client:           client,
 		selectedLocation: location,
 		loading:          true,
+		facetCursor:      -1,
+		layout:           newStickyLayout(),
 	}
 }
 
 // Init initializes the rooms view
 func (m *RoomsModel) Init() tea.Cmd {
+	if m.startAtOffice && m.selectedLocation == nil {
+		return m.loadOffice()
+	}
 	return m.loadData()
 }
 
+// isLoading reports whether rooms are still loading
+func (m *RoomsModel) isLoading() bool {
+	return m.loading
+}
+
 // Update handles messages for the rooms view
 func (m *RoomsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.layout.SetSize(msg.Width, msg.Height)
 		return m, nil
 
+	case RoomsOfficeMsg:
+		m.selectedLocation = msg.Location
+		m.officeSource = msg.Source
+		return m, m.loadData()
+
 	case RoomsDataMsg:
-		m.rooms = msg.Rooms
+		m.allRooms = msg.Rooms
+		m.applyFilters()
 		m.loading = false
 		return m, nil
 
@@ -86,80 +137,74 @@ func (m *RoomsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		if m.filterMode {
-			return m.handleFilterKeys(msg)
+		if m.filterPanel != nil {
+			return m.handleFilterPanelKeys(msg)
+		}
+		if m.facetCursor >= 0 {
+			return m.handleFacetKeys(msg)
 		}
 
-		switch msg.String() {
-		case "r", "f5":
+		k := m.keyMap()
+		switch {
+		case key.Matches(msg, k.Refresh):
 			m.loading = true
 			m.error = ""
 			return m, m.loadData()
 
-		case "f":
-			m.filterMode = true
+		case key.Matches(msg, k.Filter):
+			m.openFilterPanel()
+			return m, nil
+
+		case key.Matches(msg, k.RemoveFilter):
+			// Pick a filter in the summary bar to remove
+			m.facetCursor = 0
 			return m, nil
 
-		case "c":
-			// Clear filters
+		case key.Matches(msg, k.ClearFilters):
 			m.selectedLocation = nil
+			m.officeSource = ""
 			m.minCapacity = nil
 			m.equipment = []string{}
-			m.loading = true
-			return m, m.loadData()
+			m.applyFilters()
+			return m, nil
 
-		case "up", "k":
+		case key.Matches(msg, k.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
 			return m, nil
 
-		case "down", "j":
+		case key.Matches(msg, k.Down):
 			if m.cursor < len(m.rooms)-1 {
 				m.cursor++
 			}
 			return m, nil
 
-		case "g":
+		case key.Matches(msg, k.Top):
 			m.cursor = 0
 			return m, nil
 
-		case "G":
+		case key.Matches(msg, k.Bottom):
 			m.cursor = len(m.rooms) - 1
 			return m, nil
 
-		case "enter":
-			if m.cursor < len(m.rooms) {
-				return m, func() tea.Msg {
-					return RoomSelectMsg{Room: m.rooms[m.cursor]}
-				}
-			}
-			return m, nil
-		}
-	}
+		case key.Matches(msg, k.Follow):
+			return m, followCmd(m.requestCtx(), m.client, m.rooms[m.cursor].GetId(), "")
 
-	return m, nil
-}
+		case key.Matches(msg, k.Select):
+			return m, func() tea.Msg {
+				return RoomSelectMsg{Room: m.rooms[m.cursor]}
+			}
 
-// handleFilterKeys handles key presses in filter mode
-func (m *RoomsModel) handleFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.filterMode = false
-		return m, nil
+		case key.Matches(msg, k.RequestAccess):
+			return m, requestAccessCmd(m.requestCtx(), m.client, m.rooms[m.cursor])
 
-	case "1", "2", "3", "4":
-		// Set minimum capacity
-		capacity := map[string]int{
-			"1": 2,
-			"2": 4,
-			"3": 6,
-			"4": 10,
-		}[msg.String()]
-		m.minCapacity = &capacity
-		m.loading = true
-		m.filterMode = false
-		return m, m.loadData()
+		case key.Matches(msg, k.ReportIssue):
+			room := m.rooms[m.cursor]
+			return m, func() tea.Msg {
+				return ReportIssueMsg{Room: room}
+			}
+		}
 	}
 
 	return m, nil
@@ -175,30 +220,22 @@ func (m *RoomsModel) View() string {
 		return m.renderError()
 	}
 
-	if m.filterMode {
-		return m.renderFilterMenu()
+	if m.filterPanel != nil {
+		return m.renderFilterPanel()
 	}
 
-	var b strings.Builder
-
 	// Header
-	b.WriteString(m.renderHeader())
-	b.WriteString("\n\n")
+	header := m.renderHeader() + "\n"
 
 	// Active filters
 	if m.hasFilters() {
-		b.WriteString(m.renderActiveFilters())
-		b.WriteString("\n\n")
+		header += "\n" + m.renderActiveFilters() + "\n"
 	}
 
 	// Rooms list
-	b.WriteString(m.renderRoomsList())
-	b.WriteString("\n\n")
-
-	// Help
-	b.WriteString(m.renderHelp())
+	body, top, bottom := m.renderRoomsList()
 
-	return b.String()
+	return m.layout.Render(header, body, "\n"+m.renderHelp(), top, bottom)
 }
 
 // renderHeader renders the header
@@ -207,7 +244,7 @@ func (m *RoomsModel) renderHeader() string {
 	var subtitle string
 
 	if m.selectedLocation != nil {
-		subtitle = m.styles.Subtitle.Render(fmt.Sprintf("%s • %d rooms", m.selectedLocation.Name, len(m.rooms)))
+		subtitle = m.styles.Subtitle.Render(fmt.Sprintf("%s • %d rooms", m.selectedLocation.GetName(), len(m.rooms)))
 	} else {
 		subtitle = m.styles.Subtitle.Render(fmt.Sprintf("%d rooms", len(m.rooms)))
 	}
@@ -215,51 +252,22 @@ func (m *RoomsModel) renderHeader() string {
 	return title + "\n" + subtitle
 }
 
-// renderActiveFilters renders the active filters
-func (m *RoomsModel) renderActiveFilters() string {
-	var filters []string
-
-	if m.selectedLocation != nil {
-		filters = append(filters, m.styles.BadgeInfo.Render("Location: "+m.selectedLocation.Name))
-	}
-	if m.minCapacity != nil {
-		filters = append(filters, m.styles.BadgeInfo.Render(fmt.Sprintf("Min capacity: %d", *m.minCapacity)))
-	}
-	if len(m.equipment) > 0 {
-		filters = append(filters, m.styles.BadgeInfo.Render(fmt.Sprintf("Equipment: %s", strings.Join(m.equipment, ", "))))
-	}
-
-	if len(filters) == 0 {
-		return ""
-	}
-
-	return m.styles.TextMuted.Render("Active filters: ") + strings.Join(filters, " ")
-}
-
-// renderRoomsList renders the list of rooms
-func (m *RoomsModel) renderRoomsList() string {
+// renderRoomsList renders the list of rooms and returns the lines the
+// selected room spans
+func (m *RoomsModel) renderRoomsList() (string, int, int) {
 	if len(m.rooms) == 0 {
-		return m.styles.TextMuted.Render("No rooms found. Try adjusting your filters.")
+		return m.styles.TextMuted.Render("No rooms found. Try adjusting your filters."), -1, -1
 	}
 
-	var b strings.Builder
-
+	items := make([]string, len(m.rooms))
 	for i, room := range m.rooms {
-		if i == m.cursor {
-			b.WriteString(m.renderRoomItem(room, true))
-		} else {
-			b.WriteString(m.renderRoomItem(room, false))
-		}
-		if i < len(m.rooms)-1 {
-			b.WriteString("\n\n")
-		}
+		items[i] = m.renderRoomItem(room, i == m.cursor)
 	}
-
-	return b.String()
+	return joinItems(items, "\n\n", m.cursor)
 }
 
 // renderRoomItem renders a single room item
-func (m *RoomsModel) renderRoomItem(room models.Room, isSelected bool) string {
+func (m *RoomsModel) renderRoomItem(room milesapi.Room, isSelected bool) string {
 	nameStyle := m.styles.TextBold
 	locationStyle := m.styles.Text
 	capacityStyle := m.styles.TextMuted
@@ -277,9 +285,13 @@ func (m *RoomsModel) renderRoomItem(room models.Room, isSelected bool) string {
 		cursor = cursorStyle.Render("> ")
 	}
 
-	name := nameStyle.Render(room.Name)
-	location := locationStyle.Render(room.Location.Name)
-	capacity := capacityStyle.Render(fmt.Sprintf("Capacity: %d", room.Capacity))
+	name := nameStyle.Render(room.GetName())
+	location := locationStyle.Render(room.GetLocation().GetName())
+	capacityText := fmt.Sprintf("Capacity: %d", room.GetCapacity())
+	if limits := utils.FormatDurationLimits(room.GetMinDurationMinutes(), room.GetMaxDurationMinutes()); limits != "" {
+		capacityText += " • Booking length: " + limits
+	}
+	capacity := capacityStyle.Render(capacityText)
 
 	line1 := lipgloss.JoinHorizontal(lipgloss.Left, cursor, name, " • ", location)
 	line2 := lipgloss.JoinHorizontal(lipgloss.Left, "  ", capacity)
@@ -287,9 +299,9 @@ func (m *RoomsModel) renderRoomItem(room models.Room, isSelected bool) string {
 	result := line1 + "\n" + line2
 
 	// Amenities
-	if len(room.Amenities) > 0 {
+	if len(room.GetAmenities()) > 0 {
 		amenityBadges := []string{}
-		for _, amenity := range room.Amenities {
+		for _, amenity := range room.GetAmenities() {
 			badge := m.styles.Badge.Render(amenity)
 			amenityBadges = append(amenityBadges, badge)
 		}
@@ -297,65 +309,90 @@ func (m *RoomsModel) renderRoomItem(room models.Room, isSelected bool) string {
 		result += "\n" + line3
 	}
 
+	// Restricted rooms the user can't book
+	if room.Locked() {
+		result += "\n  " + m.styles.TextMuted.Render("🔒 "+describeRestriction(room))
+	}
+
 	// Description
-	if room.Description != "" && isSelected {
-		desc := m.styles.TextDim.Render(room.Description)
-		result += "\n  " + desc
+	if room.GetDescription() != "" && isSelected {
+		for _, line := range utils.Wrap(room.GetDescription(), m.descriptionWidth()) {
+			result += "\n  " + m.styles.TextDim.Render(line)
+		}
 	}
 
 	return result
 }
 
-// renderFilterMenu renders the filter selection menu
-func (m *RoomsModel) renderFilterMenu() string {
-	var b strings.Builder
-
-	b.WriteString(m.styles.Title.Render("Filter Rooms"))
-	b.WriteString("\n\n")
+// descriptionWidth is the width room descriptions are wrapped to
+func (m *RoomsModel) descriptionWidth() int {
+	if m.width <= 0 {
+		return 76
+	}
+	return max(20, m.width-4)
+}
 
-	b.WriteString(m.styles.Heading.Render("Filter by Capacity"))
-	b.WriteString("\n\n")
+// roomsKeyMap lists the rooms view's keys. Back is the app's; it's here
+// for the footer.
+type roomsKeyMap struct {
+	listKeyMap
+	Select        key.Binding
+	RequestAccess key.Binding
+	Follow        key.Binding
+	ReportIssue   key.Binding
+	Filter        key.Binding
+	RemoveFilter  key.Binding
+	ClearFilters  key.Binding
+	Refresh       key.Binding
+	Back          key.Binding
+}
 
-	options := []struct {
-		key   string
-		label string
-	}{
-		{"1", "At least 2 people"},
-		{"2", "At least 4 people"},
-		{"3", "At least 6 people"},
-		{"4", "At least 10 people"},
+// keyMap returns the rooms view's keys for what is on screen
+func (m *RoomsModel) keyMap() roomsKeyMap {
+	k := roomsKeyMap{
+		listKeyMap:    newListKeyMap(),
+		Select:        newKey("Enter", "Select room", "enter"),
+		RequestAccess: newKey("a", "Request access", "a"),
+		Follow:        newKey("s", "Follow", "s"),
+		ReportIssue:   newKey("!", "Report issue", "!"),
+		Filter:        newKey("f", "Filter", "f"),
+		RemoveFilter:  newKey("x", "Remove a filter", "x"),
+		ClearFilters:  newKey("c", "Clear filters", "c"),
+		Refresh:       newKey("r", "Refresh", "r", "f5"),
+		Back:          newKey("2", "Back to locations", "2"),
 	}
-
-	for _, opt := range options {
-		button := fmt.Sprintf("[%s] %s", opt.key, opt.label)
-		b.WriteString(m.styles.Button.Render(button))
-		b.WriteString("\n")
+	if m.readOnly {
+		k.Select.SetHelp("Enter", "View availability")
+		// Guests have no account to follow or ask for access with
+		k.Follow.Unbind()
+		k.RequestAccess.Unbind()
+		k.ReportIssue.Unbind()
 	}
-
-	b.WriteString("\n")
-	b.WriteString(m.styles.Help.Render("Press a number to filter • Esc to cancel"))
-
-	return b.String()
+	hasRoom := m.cursor < len(m.rooms)
+	// Locked rooms can't be booked, only asked for
+	locked := hasRoom && m.rooms[m.cursor].Locked()
+	// Booking needs the server
+	k.Select.SetEnabled(hasRoom && !m.offline && (m.readOnly || !locked))
+	k.RequestAccess.SetEnabled(locked && !m.offline)
+	k.Follow.SetEnabled(hasRoom)
+	k.ReportIssue.SetEnabled(hasRoom && !m.offline)
+	k.RemoveFilter.SetEnabled(m.hasFilters())
+	k.ClearFilters.SetEnabled(m.hasFilters())
+	return k
 }
 
-// renderHelp renders help text
+// renderHelp renders the key hints
 func (m *RoomsModel) renderHelp() string {
-	help := []string{
-		"j/k or ↑↓: Navigate",
-		"Enter: Select room",
-		"f: Filter",
-		"c: Clear filters",
-		"r: Refresh",
-		"2: Back to locations",
-	}
-	return m.styles.Help.Render(strings.Join(help, " • "))
+	k := m.keyMap()
+	return renderFooter(m.styles, m.width, k.Up, k.Select, k.RequestAccess, k.Follow, k.ReportIssue, k.Filter,
+		k.RemoveFilter, k.ClearFilters, k.Refresh, k.Back)
 }
 
 // renderLoading renders the loading state
 func (m *RoomsModel) renderLoading() string {
 	title := "Meeting Rooms"
 	if m.selectedLocation != nil {
-		title = fmt.Sprintf("Meeting Rooms - %s", m.selectedLocation.Name)
+		title = fmt.Sprintf("Meeting Rooms - %s", m.selectedLocation.GetName())
 	}
 	return m.styles.Title.Render(title) + "\n\n" +
 		m.styles.TextMuted.Render("Loading...")
@@ -368,23 +405,67 @@ func (m *RoomsModel) renderError() string {
 		m.styles.Help.Render("Press r to retry")
 }
 
-// loadData loads rooms data from the API
+// loadData loads every room from the API. Facets are applied here rather
+// than on the server, so the filter panel can offer every location and
+// amenity and count the rooms each choice leaves.
 func (m *RoomsModel) loadData() tea.Cmd {
+	ctx := m.requestCtx()
 	return func() tea.Msg {
-		var locationID *string
-		if m.selectedLocation != nil {
-			locationID = &m.selectedLocation.ID
+		rooms, err := m.client.GetRooms(ctx, "")
+		if err != nil {
+			return RoomsErrorMsg{Error: err.Error()}
 		}
 
-		rooms, err := m.client.GetRooms(locationID, m.minCapacity, m.equipment)
+		// Hot desks have their own view
+		return RoomsDataMsg{Rooms: milesapi.MeetingRooms(rooms)}
+	}
+}
+
+// loadOffice works out which location to start at: the one set in
+// Settings, or else the office the network points to
+func (m *RoomsModel) loadOffice() tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	configured := m.officeLocationID
+
+	return func() tea.Msg {
+		locations, err := client.GetLocations(ctx)
 		if err != nil {
-			return RoomsErrorMsg{Error: err.Error()}
+			return RoomsOfficeMsg{}
+		}
+		find := func(query string) *milesapi.Location {
+			for i, location := range locations {
+				if strings.EqualFold(query, location.GetId()) || strings.EqualFold(query, location.GetName()) {
+					return &locations[i]
+				}
+			}
+			return nil
 		}
 
-		return RoomsDataMsg{Rooms: rooms}
+		if configured != "" {
+			return RoomsOfficeMsg{Location: find(configured), Source: "set in Settings"}
+		}
+
+		cfg, err := office.Load(office.DefaultPath())
+		if err != nil {
+			return RoomsOfficeMsg{}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), officeDetectTimeout)
+		defer cancel()
+		match := cfg.Detect(ctx)
+		if match == nil {
+			return RoomsOfficeMsg{}
+		}
+		return RoomsOfficeMsg{Location: find(match.Location), Source: "detected from " + match.Reason}
 	}
 }
 
+// CapturingInput reports whether keys should go to the filter panel or the
+// summary bar rather than the app's global shortcuts
+func (m *RoomsModel) CapturingInput() bool {
+	return m.filterPanel != nil || m.facetCursor >= 0
+}
+
 // hasFilters returns whether any filters are active
 func (m *RoomsModel) hasFilters() bool {
 	return m.selectedLocation != nil || m.minCapacity != nil || len(m.equipment) > 0
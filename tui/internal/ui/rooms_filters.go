@@ -0,0 +1,411 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Fixed rows of the room filter panel; amenities follow, one row each
+const (
+	roomFilterLocation = iota
+	roomFilterCapacity
+	roomFilterAmenities
+)
+
+// roomFilterPanel edits the room facets before they are applied
+type roomFilterPanel struct {
+	field     int
+	location  string // Location ID, "" for any
+	capacity  int    // Minimum people, 0 for any
+	amenities map[string]bool
+}
+
+// Kinds of applied facet
+const (
+	facetLocation = iota
+	facetCapacity
+	facetAmenity
+)
+
+// roomFacet is one applied filter, as shown in the summary bar
+type roomFacet struct {
+	kind  int
+	value string // The amenity, for amenity facets
+	label string
+}
+
+// openFilterPanel starts editing the applied facets
+func (m *RoomsModel) openFilterPanel() {
+	panel := &roomFilterPanel{amenities: map[string]bool{}}
+	if m.selectedLocation != nil {
+		panel.location = m.selectedLocation.GetId()
+	}
+	if m.minCapacity != nil {
+		panel.capacity = *m.minCapacity
+	}
+	for _, amenity := range m.equipment {
+		panel.amenities[amenity] = true
+	}
+	m.filterPanel = panel
+}
+
+// handleFilterPanelKeys handles keys while the filter panel is open
+func (m *RoomsModel) handleFilterPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.filterPanel
+	amenities := m.amenityCatalogue()
+	fields := roomFilterAmenities + len(amenities)
+
+	k := m.filterPanelKeyMap()
+	switch {
+	case key.Matches(msg, k.Cancel):
+		m.filterPanel = nil
+
+	case key.Matches(msg, k.Submit):
+		m.applyFilterPanel()
+
+	case key.Matches(msg, k.Next):
+		panel.field = (panel.field + 1) % fields
+
+	case key.Matches(msg, k.Prev):
+		panel.field = (panel.field + fields - 1) % fields
+
+	case key.Matches(msg, k.Decrease, k.Increase):
+		step := 1
+		if key.Matches(msg, k.Decrease) {
+			step = -1
+		}
+		switch panel.field {
+		case roomFilterLocation:
+			panel.location = cycleChoice(m.locationChoices(), panel.location, step)
+		case roomFilterCapacity:
+			panel.capacity = min(max(panel.capacity+step, 0), m.maxCapacity())
+		}
+
+	case key.Matches(msg, k.Toggle):
+		amenity := amenities[panel.field-roomFilterAmenities]
+		panel.amenities[amenity] = !panel.amenities[amenity]
+
+	case key.Matches(msg, k.Clear):
+		m.filterPanel = &roomFilterPanel{field: panel.field, amenities: map[string]bool{}}
+	}
+	return m, nil
+}
+
+// roomFilterPanelKeyMap lists the filter panel's keys
+type roomFilterPanelKeyMap struct {
+	formKeyMap
+	Toggle key.Binding
+	Clear  key.Binding
+}
+
+// filterPanelKeyMap returns the filter panel's keys for the focused field
+func (m *RoomsModel) filterPanelKeyMap() roomFilterPanelKeyMap {
+	k := roomFilterPanelKeyMap{
+		formKeyMap: newFormKeyMap("Apply"),
+		Toggle:     newKey("Space", "Toggle amenity", " ", "x"),
+		Clear:      newKey("c", "Clear", "c"),
+	}
+	k.Next.SetKeys("tab", "down", "j")
+	k.Prev.SetKeys("shift+tab", "up", "k")
+	k.Decrease.SetKeys("left", "h", "-")
+	k.Decrease.SetHelp("←→ or +/-", "Change")
+	k.Increase.SetKeys("right", "l", "+")
+	onChoice := m.filterPanel.field < roomFilterAmenities
+	k.Decrease.SetEnabled(onChoice)
+	k.Increase.SetEnabled(onChoice)
+	k.Toggle.SetEnabled(!onChoice)
+	return k
+}
+
+// applyFilterPanel makes the panel's choices the applied facets
+func (m *RoomsModel) applyFilterPanel() {
+	panel := m.filterPanel
+	m.filterPanel = nil
+
+	if m.selectedLocation == nil || m.selectedLocation.GetId() != panel.location {
+		m.selectedLocation = m.findLocation(panel.location)
+		m.officeSource = ""
+	}
+	m.minCapacity = nil
+	if panel.capacity > 0 {
+		capacity := panel.capacity
+		m.minCapacity = &capacity
+	}
+	m.equipment = []string{}
+	for _, amenity := range m.amenityCatalogue() {
+		if panel.amenities[amenity] {
+			m.equipment = append(m.equipment, amenity)
+		}
+	}
+	m.applyFilters()
+}
+
+// applyFilters narrows the loaded rooms to the applied facets. A room
+// matches when it is at the location, seats enough people and has every
+// chosen amenity.
+func (m *RoomsModel) applyFilters() {
+	locationID := ""
+	if m.selectedLocation != nil {
+		locationID = m.selectedLocation.GetId()
+	}
+	capacity := 0
+	if m.minCapacity != nil {
+		capacity = *m.minCapacity
+	}
+
+	m.rooms = filterRooms(m.allRooms, locationID, capacity, m.equipment)
+	m.cursor = min(m.cursor, max(len(m.rooms)-1, 0))
+	m.layout.GotoTop()
+}
+
+// filterRooms returns the rooms matching the facets; "" and 0 match any
+func filterRooms(rooms []milesapi.Room, locationID string, capacity int, amenities []string) []milesapi.Room {
+	matches := []milesapi.Room{}
+	for _, room := range rooms {
+		if locationID != "" && room.GetLocationId() != locationID {
+			continue
+		}
+		if room.GetCapacity() < capacity {
+			continue
+		}
+		if !hasAmenities(room, amenities) {
+			continue
+		}
+		matches = append(matches, room)
+	}
+	return matches
+}
+
+// hasAmenities reports whether the room has every one of the amenities
+func hasAmenities(room milesapi.Room, amenities []string) bool {
+	for _, want := range amenities {
+		if !slices.ContainsFunc(room.GetAmenities(), func(have string) bool { return strings.EqualFold(have, want) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// amenityCatalogue returns every amenity the rooms offer, sorted by name
+func (m *RoomsModel) amenityCatalogue() []string {
+	seen := map[string]bool{}
+	var amenities []string
+	for _, room := range m.allRooms {
+		for _, amenity := range room.GetAmenities() {
+			key := strings.ToLower(amenity)
+			if !seen[key] {
+				seen[key] = true
+				amenities = append(amenities, amenity)
+			}
+		}
+	}
+	slices.SortFunc(amenities, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return amenities
+}
+
+// roomLocations returns the locations that have rooms, sorted by name
+func (m *RoomsModel) roomLocations() []milesapi.Location {
+	seen := map[string]bool{}
+	var locations []milesapi.Location
+	for _, room := range m.allRooms {
+		if room.GetLocationId() == "" || seen[room.GetLocationId()] {
+			continue
+		}
+		seen[room.GetLocationId()] = true
+		name := room.GetLocation().GetName()
+		locations = append(locations, milesapi.Location{Id: room.LocationId, Name: &name})
+	}
+	slices.SortFunc(locations, func(a, b milesapi.Location) int { return strings.Compare(a.GetName(), b.GetName()) })
+	return locations
+}
+
+// locationChoices returns the location IDs to cycle through, starting with "any"
+func (m *RoomsModel) locationChoices() []string {
+	ids := []string{""}
+	for _, location := range m.roomLocations() {
+		ids = append(ids, location.GetId())
+	}
+	return ids
+}
+
+// findLocation returns the location with the ID, nil for "" or unknown
+func (m *RoomsModel) findLocation(id string) *milesapi.Location {
+	for _, location := range m.roomLocations() {
+		if location.GetId() == id {
+			return &location
+		}
+	}
+	return nil
+}
+
+// maxCapacity is the most people any room seats, the capacity stepper's top
+func (m *RoomsModel) maxCapacity() int {
+	most := 0
+	for _, room := range m.allRooms {
+		most = max(most, room.GetCapacity())
+	}
+	return most
+}
+
+// facets returns the applied filters in the order the summary bar shows them
+func (m *RoomsModel) facets() []roomFacet {
+	var facets []roomFacet
+	if m.selectedLocation != nil {
+		facets = append(facets, roomFacet{kind: facetLocation, label: "Location: " + m.selectedLocation.GetName()})
+	}
+	if m.minCapacity != nil {
+		facets = append(facets, roomFacet{kind: facetCapacity, label: fmt.Sprintf("Min capacity: %d", *m.minCapacity)})
+	}
+	for _, amenity := range m.equipment {
+		facets = append(facets, roomFacet{kind: facetAmenity, value: amenity, label: amenity})
+	}
+	return facets
+}
+
+// removeFacet drops one applied filter and leaves the rest
+func (m *RoomsModel) removeFacet(facet roomFacet) {
+	switch facet.kind {
+	case facetLocation:
+		m.selectedLocation = nil
+		m.officeSource = ""
+	case facetCapacity:
+		m.minCapacity = nil
+	case facetAmenity:
+		m.equipment = slices.DeleteFunc(m.equipment, func(amenity string) bool { return amenity == facet.value })
+	}
+	m.applyFilters()
+}
+
+// handleFacetKeys handles keys while a facet in the summary bar is selected
+func (m *RoomsModel) handleFacetKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	facets := m.facets()
+	k := facetKeys()
+	switch {
+	case key.Matches(msg, k.Done):
+		m.facetCursor = -1
+
+	case key.Matches(msg, k.Prev):
+		m.facetCursor = (m.facetCursor + len(facets) - 1) % len(facets)
+
+	case key.Matches(msg, k.Next):
+		m.facetCursor = (m.facetCursor + 1) % len(facets)
+
+	case key.Matches(msg, k.Remove):
+		m.removeFacet(facets[m.facetCursor])
+		if remaining := len(m.facets()); remaining == 0 {
+			m.facetCursor = -1
+		} else {
+			m.facetCursor = min(m.facetCursor, remaining-1)
+		}
+	}
+	return m, nil
+}
+
+// facetKeyMap lists the keys for picking a filter in the summary bar to
+// remove. Prev carries the hint for Next too.
+type facetKeyMap struct {
+	Prev   key.Binding
+	Next   key.Binding
+	Remove key.Binding
+	Done   key.Binding
+}
+
+func facetKeys() facetKeyMap {
+	return facetKeyMap{
+		Prev:   newKey("←→", "Choose filter", "left", "h", "shift+tab"),
+		Next:   hiddenKey("right", "l", "tab"),
+		Remove: newKey("x", "Remove", "x", "delete", "backspace"),
+		Done:   newKey("Esc", "Done", "esc", "enter"),
+	}
+}
+
+// renderActiveFilters renders the summary bar of applied facets, with the
+// one selected for removal highlighted
+func (m *RoomsModel) renderActiveFilters() string {
+	facets := m.facets()
+	if len(facets) == 0 {
+		return ""
+	}
+
+	badges := make([]string, len(facets))
+	for i, facet := range facets {
+		label := facet.label
+		if facet.kind == facetLocation && m.officeSource != "" {
+			label = "📍 " + label
+		}
+		if i == m.facetCursor {
+			badges[i] = m.styles.BadgeWarning.Render(label + " ×")
+		} else {
+			badges[i] = m.styles.BadgeInfo.Render(label)
+		}
+		if facet.kind == facetLocation && m.officeSource != "" {
+			badges[i] += m.styles.TextMuted.Render(" (" + m.officeSource + ")")
+		}
+	}
+
+	bar := m.styles.TextMuted.Render(fmt.Sprintf("Filters (%d of %d rooms): ", len(m.rooms), len(m.allRooms))) +
+		strings.Join(badges, " ")
+	if m.facetCursor >= 0 {
+		k := facetKeys()
+		bar += "\n" + renderFooter(m.styles, m.width, k.Prev, k.Remove, k.Done)
+	}
+	return bar
+}
+
+// renderFilterPanel renders the facet filter panel
+func (m *RoomsModel) renderFilterPanel() string {
+	panel := m.filterPanel
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Filter Rooms"))
+	b.WriteString("\n\n")
+
+	row := func(field int, label, value string) {
+		cursor, labelStyle := "  ", m.styles.TextMuted
+		if field == panel.field {
+			cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+			labelStyle = m.styles.TextBold.Foreground(m.styles.Colors.Primary)
+		}
+		b.WriteString(cursor + labelStyle.Render(fmt.Sprintf("%-9s", label)) + " " + value + "\n")
+	}
+
+	location := "Any location"
+	if found := m.findLocation(panel.location); found != nil {
+		location = found.GetName()
+	}
+	capacity := "Any size"
+	if panel.capacity > 0 {
+		capacity = fmt.Sprintf("%d+ people", panel.capacity)
+	}
+	row(roomFilterLocation, "Location", "‹ "+location+" ›")
+	row(roomFilterCapacity, "Capacity", "‹ "+capacity+" ›")
+
+	b.WriteString("\n" + m.styles.Heading.Render("Amenities") + "\n\n")
+	amenities := m.amenityCatalogue()
+	if len(amenities) == 0 {
+		b.WriteString(m.styles.TextMuted.Render("  No rooms list any amenities") + "\n")
+	}
+	var chosen []string
+	for i, amenity := range amenities {
+		box := "[ ]"
+		if panel.amenities[amenity] {
+			box = "[x]"
+			chosen = append(chosen, amenity)
+		}
+		offering := len(filterRooms(m.allRooms, "", 0, []string{amenity}))
+		row(roomFilterAmenities+i, "", box+" "+amenity+m.styles.TextMuted.Render(fmt.Sprintf(" (%d)", offering)))
+	}
+
+	matches := len(filterRooms(m.allRooms, panel.location, panel.capacity, chosen))
+	b.WriteString("\n" + m.styles.TextMuted.Render(fmt.Sprintf("%d of %d rooms match", matches, len(m.allRooms))) + "\n\n")
+	k := m.filterPanelKeyMap()
+	b.WriteString(renderFooter(m.styles, m.width, k.Next, k.Decrease, k.Toggle, k.Clear, k.Submit, k.Cancel))
+	return b.String()
+}
@@ -0,0 +1,490 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/config"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// searchDebounce is how long typing has to pause before the query runs,
+// so the list doesn't jump around on every keystroke
+const searchDebounce = 150 * time.Millisecond
+
+// SearchModel finds meeting rooms in every location by name, amenity,
+// capacity or location, typed as one free-text query
+type SearchModel struct {
+	requests
+
+	styles *styles.Styles
+	client *api.Client
+	cfg    *config.Config
+	width  int
+	height int
+
+	// Ctrl+F switches between searching by name and finding a room free
+	// for a meeting
+	finding bool
+	finder  roomFinder
+
+	// The query as typed, and the one results were last worked out for.
+	// Each edit bumps gen; only the tick for the latest edit runs it.
+	input   textinput.Model
+	applied string
+	gen     int
+
+	// Every meeting room, loaded once, and those matching the query, best
+	// first
+	allRooms []milesapi.Room
+	results  []searchResult
+	cursor   int
+	loading  bool
+	error    string
+
+	// Title, query and help stay put while the results scroll
+	layout stickyLayout
+}
+
+// searchResult is a room matching the query and how well it does
+type searchResult struct {
+	room  milesapi.Room
+	score int
+
+	// What matched, e.g. "amenity: Projector", for rooms that didn't
+	// match on name
+	reason string
+}
+
+// SearchRoomsMsg carries every room for the search view
+type SearchRoomsMsg struct {
+	Rooms []milesapi.Room
+}
+
+// SearchErrorMsg reports that rooms couldn't be loaded for searching
+type SearchErrorMsg struct {
+	Error string
+}
+
+// searchQueryMsg runs the query once typing has paused
+type searchQueryMsg struct {
+	gen int
+}
+
+// NewSearchModel creates the room search view. Finding a room ranks rooms
+// near the favorite room and office from Settings first.
+func NewSearchModel(client *api.Client, cfg *config.Config, styles *styles.Styles) *SearchModel {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "e.g. fjord, projector oslo, 8+"
+	input.CharLimit = 100
+	input.Width = 40
+	input.Focus()
+
+	return &SearchModel{
+		styles:  styles,
+		client:  client,
+		cfg:     cfg,
+		input:   input,
+		finder:  newRoomFinder(),
+		loading: true,
+		layout:  newStickyLayout(),
+	}
+}
+
+// Init loads the rooms to search
+func (m *SearchModel) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, m.loadRooms())
+}
+
+// isLoading reports whether rooms are still loading
+func (m *SearchModel) isLoading() bool {
+	return m.loading
+}
+
+// CapturingInput reports whether keys go to the query rather than the
+// app's global shortcuts. Esc leaves the query so they work again.
+func (m *SearchModel) CapturingInput() bool {
+	if m.finding {
+		return m.finder.typing()
+	}
+	return m.input.Focused()
+}
+
+// Update handles messages for the search view
+func (m *SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.layout.SetSize(msg.Width, msg.Height)
+		m.input.Width = fitWidth(msg.Width, len(m.input.Prompt)+2, 10, 40)
+		for i := range m.finder.inputs {
+			m.finder.inputs[i].Width = fitWidth(msg.Width, 15, 10, 30)
+		}
+		return m, nil
+
+	case SearchRoomsMsg:
+		m.allRooms = msg.Rooms
+		m.loading = false
+		m.results = nil
+		m.runQuery()
+		return m, nil
+
+	case SearchErrorMsg:
+		m.error = msg.Error
+		m.loading = false
+		return m, nil
+
+	case searchQueryMsg:
+		if msg.gen == m.gen {
+			m.runQuery()
+		}
+		return m, nil
+
+	case findResultsMsg:
+		return m.updateFind(msg)
+
+	case tea.KeyMsg:
+		if m.finding {
+			return m.handleFindKey(msg)
+		}
+		return m.handleKey(msg)
+	}
+
+	if m.finding {
+		return m.updateFind(msg)
+	}
+	// Cursor blink
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// handleKey handles a key press. While the query is focused, keys that
+// aren't for the list edit it.
+func (m *SearchModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Refresh):
+		m.loading = true
+		m.error = ""
+		return m, m.loadRooms()
+
+	case key.Matches(msg, k.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Down):
+		if m.cursor < len(m.results)-1 {
+			m.cursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Top):
+		m.cursor = 0
+		return m, nil
+
+	case key.Matches(msg, k.Bottom):
+		m.cursor = max(0, len(m.results)-1)
+		return m, nil
+
+	case key.Matches(msg, k.Select):
+		// Enter straight after typing opens what the query will show
+		m.runQuery()
+		if m.cursor >= len(m.results) || m.results[m.cursor].room.Locked() {
+			return m, nil
+		}
+		room := m.results[m.cursor].room
+		return m, func() tea.Msg {
+			return RoomSelectMsg{Room: room}
+		}
+
+	case key.Matches(msg, k.Focus):
+		return m, m.input.Focus()
+
+	case key.Matches(msg, k.Find):
+		m.finding = true
+		m.input.Blur()
+		return m, m.finder.setFocus(m.finder.focus)
+
+	case key.Matches(msg, k.Blur):
+		m.input.Blur()
+		return m, nil
+
+	case key.Matches(msg, k.Clear):
+		m.input.SetValue("")
+		m.runQuery()
+		return m, nil
+	}
+
+	if !m.input.Focused() {
+		return m, nil
+	}
+	previous := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() == previous {
+		return m, cmd
+	}
+	m.gen++
+	gen := m.gen
+	return m, tea.Batch(cmd, tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchQueryMsg{gen: gen}
+	}))
+}
+
+// runQuery works out the results for the query as typed, keeping the
+// cursor on the same room when it still matches
+func (m *SearchModel) runQuery() {
+	query := m.input.Value()
+	if query == m.applied && m.results != nil {
+		return
+	}
+
+	var selected string
+	if m.cursor < len(m.results) {
+		selected = m.results[m.cursor].room.GetId()
+	}
+
+	m.applied = query
+	m.results = searchRooms(m.allRooms, query)
+	m.cursor = 0
+	for i, result := range m.results {
+		if result.room.GetId() == selected {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// searchRooms returns the rooms matching every term of query, best first.
+// A term like "8+" asks for at least that many seats; any other term has
+// to appear in the room's name, amenities, location, floor or description,
+// or fuzzily in its name. An empty query matches every room, by name.
+func searchRooms(rooms []milesapi.Room, query string) []searchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	results := []searchResult{}
+
+	for _, room := range rooms {
+		result := searchResult{room: room}
+		matched := true
+		for _, term := range terms {
+			score, reason, ok := matchSearchTerm(room, term)
+			if !ok {
+				matched = false
+				break
+			}
+			result.score += score
+			if result.reason == "" {
+				result.reason = reason
+			}
+		}
+		if matched {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].room.GetName() < results[j].room.GetName()
+	})
+	return results
+}
+
+// matchSearchTerm scores how well room matches one lower-case term, and
+// says what matched when it wasn't the name
+func matchSearchTerm(room milesapi.Room, term string) (score int, reason string, ok bool) {
+	if seats, isCapacity := strings.CutSuffix(term, "+"); isCapacity {
+		if n, err := strconv.Atoi(seats); err == nil {
+			if room.GetCapacity() < n {
+				return 0, "", false
+			}
+			return 1, "", true
+		}
+	}
+
+	name := strings.ToLower(room.GetName())
+	switch {
+	case strings.HasPrefix(name, term):
+		return 10, "", true
+	case strings.Contains(name, term):
+		return 8, "", true
+	}
+	for _, amenity := range room.GetAmenities() {
+		if strings.Contains(strings.ToLower(amenity), term) {
+			return 5, "amenity: " + amenity, true
+		}
+	}
+	if strings.Contains(strings.ToLower(room.GetLocation().GetName()), term) ||
+		strings.Contains(strings.ToLower(room.GetLocation().GetCity()), term) {
+		return 4, "", true
+	}
+	if room.GetFloor() != "" && strings.EqualFold(room.GetFloor(), term) {
+		return 3, "floor " + room.GetFloor(), true
+	}
+	if strings.Contains(strings.ToLower(room.GetDescription()), term) {
+		return 2, "description", true
+	}
+	// Typos and abbreviations in the name, e.g. "mtgrm"
+	if score, _, ok := utils.FuzzyMatch(term, room.GetName()); ok && len(term) > 2 {
+		return min(max(score/4, 1), 3), "", true
+	}
+	return 0, "", false
+}
+
+// View renders the search view
+func (m *SearchModel) View() string {
+	if m.loading {
+		return m.styles.Title.Render("Search Rooms") + "\n\n" +
+			m.styles.TextMuted.Render("Loading rooms...")
+	}
+	if m.error != "" {
+		return m.styles.Title.Render("Search Rooms") + "\n\n" +
+			m.styles.TextError.Render("Error: "+m.error) + "\n\n" +
+			m.styles.Help.Render("Press r to retry")
+	}
+
+	if m.finding {
+		return m.renderFind()
+	}
+	header := m.renderHeader() + "\n"
+	body, top, bottom := m.renderResults()
+	return m.layout.Render(header, body, "\n"+m.renderHelp(), top, bottom)
+}
+
+// renderHeader renders the title, the query and how many rooms match
+func (m *SearchModel) renderHeader() string {
+	title := m.styles.Title.Render("Search Rooms")
+	count := fmt.Sprintf("%d of %d rooms", len(m.results), len(m.allRooms))
+	if strings.TrimSpace(m.applied) == "" {
+		count = fmt.Sprintf("%d rooms in every location • name, amenity, location or seats like 8+", len(m.allRooms))
+	}
+	return title + "\n" + m.input.View() + "\n" + m.styles.Subtitle.Render(count)
+}
+
+// renderResults renders the matching rooms and returns the lines the
+// selected one spans
+func (m *SearchModel) renderResults() (string, int, int) {
+	if len(m.results) == 0 {
+		return m.styles.TextMuted.Render("No rooms match. Try fewer words or a different spelling."), -1, -1
+	}
+
+	items := make([]string, len(m.results))
+	for i, result := range m.results {
+		items[i] = m.renderResult(result, i == m.cursor)
+	}
+	return joinItems(items, "\n\n", m.cursor)
+}
+
+// renderResult renders one matching room
+func (m *SearchModel) renderResult(result searchResult, selected bool) string {
+	room := result.room
+	nameStyle, textStyle, cursor := m.styles.TextBold, m.styles.TextMuted, "  "
+	if selected {
+		nameStyle = nameStyle.Foreground(m.styles.Colors.Primary)
+		textStyle = textStyle.Foreground(m.styles.Colors.Primary)
+		cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+	}
+
+	place := room.GetLocation().GetName()
+	if room.GetLocation().GetCity() != "" && !strings.EqualFold(room.GetLocation().GetCity(), room.GetLocation().GetName()) {
+		place += ", " + room.GetLocation().GetCity()
+	}
+	details := fmt.Sprintf("%s • %d seats", place, room.GetCapacity())
+	if room.GetFloor() != "" {
+		details += " • floor " + room.GetFloor()
+	}
+
+	lines := []string{
+		cursor + nameStyle.Render(room.GetName()),
+		"  " + textStyle.Render(details),
+	}
+	if len(room.GetAmenities()) > 0 {
+		badges := make([]string, len(room.GetAmenities()))
+		for i, amenity := range room.GetAmenities() {
+			badges[i] = m.styles.Badge.Render(amenity)
+		}
+		lines = append(lines, "  "+strings.Join(badges, " "))
+	}
+	if result.reason != "" && selected {
+		lines = append(lines, "  "+m.styles.TextDim.Render("Matched "+result.reason))
+	}
+	if room.Locked() {
+		lines = append(lines, "  "+m.styles.TextMuted.Render("🔒 "+describeRestriction(room)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// searchKeyMap lists the search view's keys. While the query is focused,
+// letters type into it, so the list is moved with the arrow keys only.
+type searchKeyMap struct {
+	listKeyMap
+	Select  key.Binding
+	Focus   key.Binding
+	Blur    key.Binding
+	Clear   key.Binding
+	Find    key.Binding
+	Refresh key.Binding
+}
+
+// keyMap returns the search view's keys for what is on screen
+func (m *SearchModel) keyMap() searchKeyMap {
+	k := searchKeyMap{
+		listKeyMap: newListKeyMap(),
+		Select:     newKey("Enter", "Book", "enter"),
+		Focus:      newKey("/", "Search", "/"),
+		Blur:       newKey("Esc", "Done typing", "esc"),
+		Clear:      newKey("Ctrl+L", "Clear", "ctrl+l"),
+		Find:       newKey("Ctrl+F", "Find a free room", "ctrl+f"),
+		Refresh:    newKey("r", "Refresh", "r", "f5"),
+	}
+	if m.input.Focused() {
+		k.Up = newKey("↑↓", "Navigate", "up")
+		k.Down = hiddenKey("down")
+		k.Top.Unbind()
+		k.Bottom.Unbind()
+		k.Focus.Unbind()
+		k.Refresh = hiddenKey("f5")
+	} else {
+		k.Blur.Unbind()
+	}
+
+	hasRoom := m.cursor < len(m.results)
+	k.Select.SetEnabled(hasRoom && !m.results[m.cursor].room.Locked())
+	k.Clear.SetEnabled(m.input.Value() != "")
+	return k
+}
+
+// renderHelp renders the key hints
+func (m *SearchModel) renderHelp() string {
+	k := m.keyMap()
+	return renderFooter(m.styles, m.width, k.Up, k.Select, k.Focus, k.Blur, k.Clear, k.Find, k.Refresh)
+}
+
+// loadRooms loads every meeting room. The query runs on them here rather
+// than on the server, so each keystroke costs no request.
+func (m *SearchModel) loadRooms() tea.Cmd {
+	ctx := m.requestCtx()
+	return func() tea.Msg {
+		rooms, err := m.client.GetRooms(ctx, "")
+		if err != nil {
+			return SearchErrorMsg{Error: err.Error()}
+		}
+		// Hot desks have their own view
+		return SearchRoomsMsg{Rooms: milesapi.MeetingRooms(rooms)}
+	}
+}
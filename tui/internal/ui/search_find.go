@@ -0,0 +1,559 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Fields of the find form, top to bottom
+const (
+	findDateField = iota
+	findHoursField
+	findLengthField
+	findAttendeesField
+	findAmenitiesField
+	findFields
+)
+
+// Fit penalties, as 'miles find' ranks rooms: lower is better. A spare
+// seat costs little, a floor away a bit more, and another location more
+// than most spare seats.
+const (
+	spareSeatPenalty     = 2
+	distancePenalty      = 3
+	otherLocationPenalty = 30
+)
+
+// findHoursPattern matches the hours field, e.g. 08:00-17:00
+var findHoursPattern = regexp.MustCompile(`^([01]\d|2[0-3]):([0-5]\d)-([01]\d|2[0-3]):([0-5]\d)$`)
+
+// roomFinder is the search view's find mode: a time, a length, how many
+// people and what the room needs, answered with the rooms free then, best
+// fit first
+type roomFinder struct {
+	inputs [findFields]textinput.Model
+	focus  int
+
+	// Rooms that suit the meeting whether or not they're free, and those
+	// free in the window, best first. gen drops results of an older search.
+	suitable  int
+	results   []findResult
+	cursor    int
+	searching bool
+	searched  bool
+	error     string
+	gen       int
+}
+
+// findResult is a room free for the meeting and how well it fits
+type findResult struct {
+	room       milesapi.Room
+	start, end time.Time
+	spareSeats int
+	nearness   string
+	penalty    int
+}
+
+// findQuery is the find form, read
+type findQuery struct {
+	from, to  time.Time
+	length    time.Duration
+	attendees int
+	amenities []string
+}
+
+// findResultsMsg carries the rooms free for the meeting, best first
+type findResultsMsg struct {
+	gen     int
+	Results []findResult
+	Error   string
+}
+
+// BookRoomAtMsg opens the booking form for a room with the time filled in
+type BookRoomAtMsg struct {
+	Room       milesapi.Room
+	Start, End time.Time
+}
+
+// newRoomFinder creates the find form, for an hour for one today
+func newRoomFinder() roomFinder {
+	fields := []struct{ placeholder, value string }{
+		findDateField:      {"today, tomorrow or YYYY-MM-DD", "today"},
+		findHoursField:     {"HH:MM-HH:MM", "08:00-17:00"},
+		findLengthField:    {"e.g. 30m, 1h30m or 45", "1h"},
+		findAttendeesField: {"people, including you", "1"},
+		findAmenitiesField: {"e.g. projector, whiteboard", ""},
+	}
+	var f roomFinder
+	for i, field := range fields {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = field.placeholder
+		input.CharLimit = 100
+		input.Width = 30
+		input.SetValue(field.value)
+		f.inputs[i] = input
+	}
+	// Usually just the number of people changes
+	f.focus = findAttendeesField
+	return f
+}
+
+// setFocus moves the cursor to field i of the form
+func (f *roomFinder) setFocus(i int) tea.Cmd {
+	f.focus = (i + findFields) % findFields
+	for j := range f.inputs {
+		f.inputs[j].Blur()
+	}
+	return f.inputs[f.focus].Focus()
+}
+
+// typing reports whether a field of the form has the cursor
+func (f *roomFinder) typing() bool {
+	return f.inputs[f.focus].Focused()
+}
+
+// parse reads the form into a query, starting no earlier than now
+func (f *roomFinder) parse(now time.Time) (findQuery, error) {
+	var q findQuery
+	value := func(field int) string { return strings.TrimSpace(f.inputs[field].Value()) }
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	var day time.Time
+	switch date := strings.ToLower(value(findDateField)); date {
+	case "", "today":
+		day = today
+	case "tomorrow":
+		day = today.AddDate(0, 0, 1)
+	default:
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return q, errors.New("Date: use today, tomorrow or YYYY-MM-DD")
+		}
+		day = parsed
+	}
+
+	m := findHoursPattern.FindStringSubmatch(value(findHoursField))
+	if m == nil {
+		return q, errors.New("Between: use HH:MM-HH:MM, e.g. 08:00-17:00")
+	}
+	at := func(hour, minute string) time.Time {
+		h, _ := strconv.Atoi(hour)
+		mm, _ := strconv.Atoi(minute)
+		return day.Add(time.Duration(h)*time.Hour + time.Duration(mm)*time.Minute)
+	}
+	q.from, q.to = at(m[1], m[2]), at(m[3], m[4])
+	if !q.to.After(q.from) {
+		return q, errors.New("Between: the end must be after the start")
+	}
+	if q.from.Before(now) {
+		q.from = now
+	}
+
+	length := value(findLengthField)
+	if minutes, err := strconv.Atoi(length); err == nil {
+		q.length = time.Duration(minutes) * time.Minute
+	} else if q.length, err = time.ParseDuration(length); err != nil {
+		return q, errors.New("Length: use e.g. 30m, 1h30m or minutes")
+	}
+	if q.length < 15*time.Minute {
+		return q, errors.New("Length: at least 15 minutes")
+	}
+	if q.from.Add(q.length).After(q.to) {
+		return q, errors.New("That time has passed or is too short for the meeting")
+	}
+
+	attendees, err := strconv.Atoi(value(findAttendeesField))
+	if err != nil || attendees < 1 {
+		return q, errors.New("Attendees: a number, at least 1")
+	}
+	q.attendees = attendees
+
+	for _, amenity := range strings.Split(value(findAmenitiesField), ",") {
+		if amenity = strings.TrimSpace(amenity); amenity != "" {
+			q.amenities = append(q.amenities, amenity)
+		}
+	}
+	return q, nil
+}
+
+// suits reports whether a room seats everyone, has every amenity asked for
+// and can be booked for the meeting's length, whether or not it's free
+func (q findQuery) suits(room milesapi.Room) bool {
+	if !room.GetIsActive() || room.Locked() || room.GetCapacity() < q.attendees || !room.AllowsDuration(q.length) {
+		return false
+	}
+	for _, want := range q.amenities {
+		found := false
+		for _, amenity := range room.GetAmenities() {
+			if strings.Contains(strings.ToLower(amenity), strings.ToLower(want)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// firstFreeSlot returns the earliest quarter hour in the query's window
+// from which the room is free for the meeting's length
+func firstFreeSlot(bookings []milesapi.Booking, q findQuery) (time.Time, bool) {
+	start := q.from.Truncate(15 * time.Minute)
+	if start.Before(q.from) {
+		start = start.Add(15 * time.Minute)
+	}
+	for ; !start.Add(q.length).After(q.to); start = start.Add(15 * time.Minute) {
+		end := start.Add(q.length)
+		free := true
+		for _, booking := range bookings {
+			if booking.GetStatus() != milesapi.BookingStatusCANCELLED && booking.GetStartTime().Before(end) && booking.GetEndTime().After(start) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// rankFindResults finds when each room is first free and orders those
+// that are by fit, then time. near is the favorite room, whose floor and
+// wing rooms are measured from; office is the location rooms should be
+// at. Either may be unset.
+func rankFindResults(rooms []milesapi.Room, bookings map[string][]milesapi.Booking, q findQuery, near *milesapi.Room, office string) []findResult {
+	results := []findResult{}
+	for _, room := range rooms {
+		start, ok := firstFreeSlot(bookings[room.GetId()], q)
+		if !ok {
+			continue
+		}
+		result := findResult{
+			room:       room,
+			start:      start,
+			end:        start.Add(q.length),
+			spareSeats: room.GetCapacity() - q.attendees,
+		}
+		result.penalty = result.spareSeats * spareSeatPenalty
+		if near != nil {
+			if distance, ok := milesapi.RoomDistance(*near, room); ok {
+				result.penalty += distance * distancePenalty
+				result.nearness = milesapi.DescribeNearness(*near, room)
+				if room.GetId() == near.GetId() {
+					result.nearness = "your favorite"
+				}
+			}
+		}
+		if office != "" && room.GetLocationId() != office {
+			result.penalty += otherLocationPenalty
+		}
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.penalty != b.penalty {
+			return a.penalty < b.penalty
+		}
+		if !a.start.Equal(b.start) {
+			return a.start.Before(b.start)
+		}
+		return a.room.GetName() < b.room.GetName()
+	})
+	return results
+}
+
+// findReference returns the favorite room to measure nearness from and
+// the office rooms should be at, from Settings
+func (m *SearchModel) findReference() (*milesapi.Room, string) {
+	office := m.cfg.OfficeLocationID
+	for i := range m.allRooms {
+		if m.allRooms[i].GetId() == m.cfg.FavoriteRoomID {
+			if office == "" {
+				office = m.allRooms[i].GetLocationId()
+			}
+			return &m.allRooms[i], office
+		}
+	}
+	return nil, office
+}
+
+// runFind reads the form and looks up when the suitable rooms are free
+func (m *SearchModel) runFind() tea.Cmd {
+	f := &m.finder
+	q, err := f.parse(utils.Now())
+	if err != nil {
+		f.error = err.Error()
+		return nil
+	}
+
+	var rooms []milesapi.Room
+	for _, room := range m.allRooms {
+		if q.suits(room) {
+			rooms = append(rooms, room)
+		}
+	}
+	f.error = ""
+	f.suitable = len(rooms)
+	f.searching = true
+	f.gen++
+
+	gen := f.gen
+	ctx := m.requestCtx()
+	client := m.client
+	near, office := m.findReference()
+	return func() tea.Msg {
+		bookings := map[string][]milesapi.Booking{}
+		for _, room := range rooms {
+			taken, err := client.GetRoomAvailability(ctx, room.GetId(), q.from, q.to)
+			if err != nil {
+				return findResultsMsg{gen: gen, Error: err.Error()}
+			}
+			bookings[room.GetId()] = taken
+		}
+		return findResultsMsg{gen: gen, Results: rankFindResults(rooms, bookings, q, near, office)}
+	}
+}
+
+// updateFind handles messages while finding
+func (m *SearchModel) updateFind(msg tea.Msg) (tea.Model, tea.Cmd) {
+	f := &m.finder
+	switch msg := msg.(type) {
+	case findResultsMsg:
+		if msg.gen != f.gen {
+			return m, nil
+		}
+		f.searching = false
+		f.searched = true
+		f.error = msg.Error
+		f.results = msg.Results
+		f.cursor = 0
+		// The rooms are next; Tab goes back to the form
+		f.inputs[f.focus].Blur()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleFindKey(msg)
+	}
+
+	// Cursor blink
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return m, cmd
+}
+
+// handleFindKey handles a key press while finding. While a field has the
+// cursor, keys that aren't for the form edit it.
+func (m *SearchModel) handleFindKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.finder
+	k := m.findKeyMap()
+	switch {
+	case key.Matches(msg, k.Mode):
+		m.finding = false
+		return m, m.input.Focus()
+
+	case key.Matches(msg, k.Next):
+		if !f.typing() {
+			return m, f.setFocus(f.focus)
+		}
+		return m, f.setFocus(f.focus + 1)
+
+	case key.Matches(msg, k.Prev):
+		return m, f.setFocus(f.focus - 1)
+
+	case key.Matches(msg, k.Find):
+		return m, m.runFind()
+
+	case key.Matches(msg, k.Blur):
+		f.inputs[f.focus].Blur()
+		return m, nil
+
+	case key.Matches(msg, k.Up):
+		if f.cursor > 0 {
+			f.cursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Down):
+		if f.cursor < len(f.results)-1 {
+			f.cursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, k.Top):
+		f.cursor = 0
+		return m, nil
+
+	case key.Matches(msg, k.Bottom):
+		f.cursor = max(0, len(f.results)-1)
+		return m, nil
+
+	case key.Matches(msg, k.Book):
+		result := f.results[f.cursor]
+		return m, func() tea.Msg {
+			return BookRoomAtMsg{Room: result.room, Start: result.start, End: result.end}
+		}
+	}
+
+	if !f.typing() {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return m, cmd
+}
+
+// renderFind renders the find form and the rooms it found
+func (m *SearchModel) renderFind() string {
+	f := &m.finder
+	header := m.styles.Title.Render("Find a Room") + "\n" +
+		m.styles.Subtitle.Render("When, how long, how many and what the room needs") + "\n\n"
+
+	labels := [findFields]string{"Date", "Between", "Length", "Attendees", "Amenities"}
+	for i, label := range labels {
+		style := m.styles.TextBold
+		if i == f.focus && f.typing() {
+			style = style.Foreground(m.styles.Colors.Primary)
+		}
+		header += "  " + style.Render(fmt.Sprintf("%-11s", label)) + f.inputs[i].View() + "\n"
+	}
+
+	var status string
+	switch {
+	case f.error != "":
+		status = m.styles.TextError.Render("✗ " + f.error)
+	case f.searching:
+		status = m.styles.TextMuted.Render(fmt.Sprintf("Checking %d rooms...", f.suitable))
+	case !f.searched:
+		status = m.styles.TextMuted.Render("Press Enter to find rooms")
+	case f.suitable == 0:
+		status = m.styles.TextMuted.Render("No room seats that many with those amenities. Try fewer.")
+	case len(f.results) == 0:
+		status = m.styles.TextMuted.Render(fmt.Sprintf("None of the %d rooms that fit is free then. Try a wider window or another day.", f.suitable))
+	default:
+		status = m.styles.Subtitle.Render(fmt.Sprintf("%d of %d rooms that fit are free • best fit first", len(f.results), f.suitable))
+	}
+	header += "\n" + status + "\n"
+
+	body, top, bottom := "", -1, -1
+	if !f.searching && len(f.results) > 0 {
+		items := make([]string, len(f.results))
+		for i, result := range f.results {
+			items[i] = m.renderFindResult(i, result, i == f.cursor && !f.typing())
+		}
+		body, top, bottom = joinItems(items, "\n\n", f.cursor)
+	}
+	return m.layout.Render(header, body, "\n"+m.renderFindHelp(), top, bottom)
+}
+
+// renderFindResult renders one room found, with when it's free and why
+// it ranks where it does
+func (m *SearchModel) renderFindResult(i int, result findResult, selected bool) string {
+	room := result.room
+	nameStyle, textStyle, cursor := m.styles.TextBold, m.styles.TextMuted, "  "
+	if selected {
+		nameStyle = nameStyle.Foreground(m.styles.Colors.Primary)
+		textStyle = textStyle.Foreground(m.styles.Colors.Primary)
+		cursor = m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> ")
+	}
+
+	details := []string{fmt.Sprintf("%d seats", room.GetCapacity())}
+	if room.GetLocation().GetName() != "" {
+		details = append([]string{room.GetLocation().GetName()}, details...)
+	}
+	if room.GetFloor() != "" {
+		details = append(details, "floor "+room.GetFloor())
+	}
+
+	fit := "exact fit"
+	if result.spareSeats > 0 {
+		fit = fmt.Sprintf("%d seat%s spare", result.spareSeats, plural(result.spareSeats))
+	}
+	if result.nearness != "" {
+		fit += ", " + result.nearness
+	}
+	when := "Free " + result.start.Format("Mon Jan 2 15:04") + "-" + result.end.Format("15:04")
+
+	return strings.Join([]string{
+		cursor + nameStyle.Render(fmt.Sprintf("%d. %s", i+1, room.GetName())),
+		"  " + textStyle.Render(strings.Join(details, " • ")),
+		"  " + m.styles.TextInfo.Render(when) + m.styles.TextDim.Render(" • "+fit),
+	}, "\n")
+}
+
+// findKeyMap lists the find mode's keys. While a field has the cursor,
+// letters type into it and Enter finds; after a search the rooms get the
+// keys, and Enter books.
+type findKeyMap struct {
+	listKeyMap
+	Next key.Binding
+	Prev key.Binding
+	Find key.Binding
+	Blur key.Binding
+	Book key.Binding
+	Mode key.Binding
+}
+
+// findKeyMap returns the find mode's keys for what is on screen
+func (m *SearchModel) findKeyMap() findKeyMap {
+	f := &m.finder
+	k := findKeyMap{
+		listKeyMap: newListKeyMap(),
+		Next:       newKey("Tab", "Next field", "tab"),
+		Prev:       hiddenKey("shift+tab"),
+		Find:       newKey("Enter", "Find", "enter"),
+		Blur:       newKey("Esc", "To the rooms", "esc"),
+		Book:       newKey("Enter", "Book", "enter"),
+		Mode:       newKey("Ctrl+F", "Search by name", "ctrl+f"),
+	}
+	if f.typing() {
+		k.Up.Unbind()
+		k.Down.Unbind()
+		k.Top.Unbind()
+		k.Bottom.Unbind()
+		k.Book.Unbind()
+		k.Blur.SetEnabled(len(f.results) > 0)
+	} else {
+		k.Next = newKey("Tab", "Edit", "tab")
+		k.Find.Unbind()
+		k.Blur.Unbind()
+		k.Book.SetEnabled(f.cursor < len(f.results))
+	}
+	return k
+}
+
+// renderFindHelp renders the find mode's key hints
+func (m *SearchModel) renderFindHelp() string {
+	k := m.findKeyMap()
+	return renderFooter(m.styles, m.width, k.Up, k.Next, k.Find, k.Book, k.Blur, k.Mode)
+}
+
+// NewBookingFormAtModel creates a booking form for room with the date and
+// times of a free slot filled in, starting at the date step
+func NewBookingFormAtModel(client *api.Client, styles *styles.Styles, room milesapi.Room, start, end time.Time) *BookingFormModel {
+	m := NewBookingFormModel(client, styles, &room)
+	start, end = start.Local(), end.Local()
+	m.selectedDate = start
+	m.dateInput.SetValue(start.Format("2006-01-02"))
+	m.dateInput.CursorEnd()
+	m.startHour, m.startMinute = start.Hour(), start.Minute()
+	m.endHour, m.endMinute = end.Hour(), end.Minute()
+	return m
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// sessionResumedMsg is sent when the saved session is still good
+type sessionResumedMsg struct {
+	user  *milesapi.User
+	token string
+}
+
+// resumeSession signs back in with the session saved by an earlier run,
+// skipping the login screen while the server still accepts it
+func (a *App) resumeSession() tea.Cmd {
+	ctx := a.requestCtx()
+	client := a.client
+	return func() tea.Msg {
+		user, err := client.ResumeSession(ctx)
+		if err != nil || user == nil {
+			return nil
+		}
+		return sessionResumedMsg{user: user, token: client.GetToken()}
+	}
+}
+
+// logout signs out and forgets the saved session, so the next launch asks
+// for a login again
+func (a *App) logout() tea.Cmd {
+	a.client.SetImpersonate("")
+	a.client.ClearToken()
+	a.impersonating = nil
+	a.authenticated = false
+	a.offlineShell = false
+	a.user = nil
+	a.token = ""
+	// Stop polling for the signed-out user
+	a.activityGen++
+	a.handoverGen++
+	a.stopBookingWatch()
+	a.cancelRequests()
+
+	a.dashboard = nil
+	a.locations = nil
+	a.rooms = nil
+	a.calendar = nil
+	a.bookings = nil
+	a.bookingForm = nil
+	a.search = nil
+	a.admin = nil
+	a.settings = nil
+	a.activity = nil
+	a.desks = nil
+	a.approvals = nil
+	a.issues = nil
+
+	a.state = ViewLogin
+	a.login = NewLoginModel(a.client, a.styles)
+	return tea.Batch(a.initView(a.login), a.showToast("Signed out", false))
+}
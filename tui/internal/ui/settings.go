@@ -0,0 +1,408 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/config"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/office"
+)
+
+// SettingsModel shows the user's booking stats and lets them choose and
+// order dashboard widgets, pick a favorite room and set their office.
+// Changes are saved to the config file immediately.
+type SettingsModel struct {
+	requests
+
+	styles *styles.Styles
+	client *api.Client
+	cfg    *config.Config
+	width  int
+	height int
+
+	// All widget IDs in display order; disabled ones keep their position
+	order   []string
+	enabled map[string]bool
+	cursor  int // 0..len(order)-1 are widgets, then the favorite room and office rows
+
+	// The user's booking stats, shown above the settings
+	stats      *milesapi.MyStats
+	statsError string
+
+	rooms      []milesapi.Room
+	picker     roomPicker
+	picking    bool
+	status     string
+	statusIsOK bool
+}
+
+// SettingsChangedMsg is sent after settings are saved so views can rebuild
+type SettingsChangedMsg struct{}
+
+// settingsRoomsMsg contains the rooms available as favorite
+type settingsRoomsMsg struct {
+	Rooms []milesapi.Room
+	Error string
+}
+
+// NewSettingsModel creates a new settings view
+func NewSettingsModel(client *api.Client, cfg *config.Config, styles *styles.Styles) *SettingsModel {
+	m := &SettingsModel{
+		styles:  styles,
+		client:  client,
+		cfg:     cfg,
+		enabled: make(map[string]bool),
+		picker:  newRoomPicker(styles),
+	}
+
+	for _, id := range cfg.DashboardWidgets {
+		if _, ok := findWidget(id); ok && !m.enabled[id] {
+			m.order = append(m.order, id)
+			m.enabled[id] = true
+		}
+	}
+	for _, def := range dashboardWidgets {
+		if !m.enabled[def.ID] {
+			m.order = append(m.order, def.ID)
+		}
+	}
+
+	return m
+}
+
+// Init loads the rooms for the favorite room picker and the user's stats
+func (m *SettingsModel) Init() tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return tea.Batch(func() tea.Msg {
+		rooms, err := client.GetRooms(ctx, "")
+		if err != nil {
+			return settingsRoomsMsg{Error: err.Error()}
+		}
+		return settingsRoomsMsg{Rooms: rooms}
+	}, m.loadStats())
+}
+
+// CapturingInput reports whether the room picker's filter has focus
+func (m *SettingsModel) CapturingInput() bool {
+	return m.picking
+}
+
+// Update handles messages
+func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.picker.SetHeight(max(5, msg.Height-10))
+		return m, nil
+
+	case settingsRoomsMsg:
+		if msg.Error != "" {
+			m.status = "Could not load rooms: " + msg.Error
+			m.statusIsOK = false
+			return m, nil
+		}
+		m.rooms = msg.Rooms
+		m.picker.SetRooms(msg.Rooms)
+		return m, nil
+
+	case settingsStatsMsg:
+		m.stats, m.statsError = msg.Stats, msg.Error
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.picking {
+			return m.handlePickerKey(msg)
+		}
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+// handleKey handles keys while browsing the settings list
+func (m *SettingsModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, k.Down):
+		if m.cursor < m.officeRow() {
+			m.cursor++
+		}
+	case key.Matches(msg, k.MoveUp):
+		if m.cursor > 0 {
+			m.order[m.cursor-1], m.order[m.cursor] = m.order[m.cursor], m.order[m.cursor-1]
+			m.cursor--
+			return m, m.save()
+		}
+	case key.Matches(msg, k.MoveDown):
+		if m.cursor < len(m.order)-1 {
+			m.order[m.cursor+1], m.order[m.cursor] = m.order[m.cursor], m.order[m.cursor+1]
+			m.cursor++
+			return m, m.save()
+		}
+	case key.Matches(msg, k.PrevOffice, k.NextOffice):
+		step := 1
+		if key.Matches(msg, k.PrevOffice) {
+			step = -1
+		}
+		m.cfg.OfficeLocationID = cycleChoice(m.officeLocationIDs(), m.cfg.OfficeLocationID, step)
+		return m, m.save()
+	case key.Matches(msg, k.ChooseRoom):
+		if len(m.rooms) == 0 {
+			m.status = "No rooms available"
+			m.statusIsOK = false
+			return m, nil
+		}
+		m.picking = true
+		return m, nil
+	case key.Matches(msg, k.Toggle):
+		id := m.order[m.cursor]
+		m.enabled[id] = !m.enabled[id]
+		return m, m.save()
+	case key.Matches(msg, k.RefreshStats):
+		m.stats, m.statsError = nil, ""
+		return m, m.loadStats()
+	case key.Matches(msg, k.Clear):
+		if m.cursor == len(m.order) {
+			m.cfg.FavoriteRoomID = ""
+		} else {
+			m.cfg.OfficeLocationID = ""
+		}
+		return m, m.save()
+	}
+	return m, nil
+}
+
+// settingsKeyMap lists the settings keys. Space and Enter act on the row
+// under the cursor, so each row's action has its own binding. Back is the
+// app's; it's here for the footer.
+type settingsKeyMap struct {
+	Up           key.Binding
+	Down         key.Binding
+	Toggle       key.Binding
+	MoveUp       key.Binding
+	MoveDown     key.Binding
+	ChooseRoom   key.Binding
+	PrevOffice   key.Binding
+	NextOffice   key.Binding
+	Clear        key.Binding
+	RefreshStats key.Binding
+	Back         key.Binding
+	Picker       roomPickerKeyMap
+	PickerBack   key.Binding
+}
+
+// keyMap returns the settings keys for the row under the cursor
+func (m *SettingsModel) keyMap() settingsKeyMap {
+	k := settingsKeyMap{
+		Up:           newKey("↑/↓", "Navigate", "up", "k"),
+		Down:         hiddenKey("down", "j"),
+		Toggle:       newKey("Space", "Toggle", " ", "enter"),
+		MoveUp:       newKey("Shift+↑/↓ or K/J", "Reorder", "shift+up", "K"),
+		MoveDown:     hiddenKey("shift+down", "J"),
+		ChooseRoom:   newKey("Enter", "Choose room", " ", "enter"),
+		PrevOffice:   newKey("←/→", "Change office", "left", "h"),
+		NextOffice:   hiddenKey("right", "l", " ", "enter"),
+		Clear:        newKey("x", "Clear", "x", "delete", "backspace"),
+		RefreshStats: newKey("r", "Refresh stats", "r"),
+		Back:         newKey("1", "Dashboard", "1"),
+		Picker:       m.picker.keyMap(),
+		PickerBack:   newKey("Esc", "Back", "esc"),
+	}
+	k.Picker.Select.SetHelp("Enter", "Choose")
+
+	onWidget := m.cursor < len(m.order)
+	onRoom := m.cursor == len(m.order)
+	onOffice := m.cursor == m.officeRow()
+	k.Toggle.SetEnabled(onWidget)
+	k.MoveUp.SetEnabled(onWidget)
+	k.MoveDown.SetEnabled(onWidget)
+	k.ChooseRoom.SetEnabled(onRoom)
+	k.PrevOffice.SetEnabled(onOffice)
+	k.NextOffice.SetEnabled(onOffice)
+	k.Clear.SetEnabled(onRoom && m.cfg.FavoriteRoomID != "" || onOffice && m.cfg.OfficeLocationID != "")
+	k.RefreshStats.SetEnabled(m.stats != nil || m.statsError != "")
+	return k
+}
+
+// handlePickerKey handles keys while choosing a favorite room
+func (m *SettingsModel) handlePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keyMap().PickerBack) {
+		m.picking = false
+		return m, nil
+	}
+
+	room, cmd := m.picker.Update(msg)
+	if room == nil {
+		return m, cmd
+	}
+
+	m.picking = false
+	m.cfg.FavoriteRoomID = room.GetId()
+	// Picking a favorite room implies wanting to see it
+	m.enabled[config.WidgetFavoriteRoom] = true
+	return m, m.save()
+}
+
+// save writes the current choices to the config file
+func (m *SettingsModel) save() tea.Cmd {
+	widgets := []string{}
+	for _, id := range m.order {
+		if m.enabled[id] {
+			widgets = append(widgets, id)
+		}
+	}
+	m.cfg.DashboardWidgets = widgets
+
+	if err := m.cfg.Save(); err != nil {
+		m.status = "Could not save settings: " + err.Error()
+		m.statusIsOK = false
+	} else {
+		m.status = "✓ Saved"
+		m.statusIsOK = true
+	}
+
+	return func() tea.Msg {
+		return SettingsChangedMsg{}
+	}
+}
+
+// View renders the settings view
+func (m *SettingsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Settings"))
+	b.WriteString("\n\n")
+
+	if m.picking {
+		b.WriteString(m.styles.Heading.Render("Choose Favorite Room"))
+		b.WriteString("\n\n")
+		b.WriteString(m.picker.View())
+		b.WriteString("\n\n")
+		k := m.keyMap()
+		b.WriteString(renderFooter(m.styles, m.width, k.Picker.Filter, k.Picker.Up, k.Picker.Collapse,
+			k.Picker.Select, k.PickerBack))
+		return b.String()
+	}
+
+	b.WriteString(m.renderStats())
+	b.WriteString("\n")
+
+	b.WriteString(m.styles.Heading.Render("Dashboard Widgets"))
+	b.WriteString("\n\n")
+
+	for i, id := range m.order {
+		def, _ := findWidget(id)
+
+		check := "[ ]"
+		if m.enabled[id] {
+			check = "[✓]"
+		}
+		line := check + " " + utils.PadRight(def.Name, 20)
+
+		b.WriteString(m.renderRow(line, i == m.cursor))
+		b.WriteString(m.styles.TextDim.Render(" " + def.Description))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Heading.Render("Favorite Room"))
+	b.WriteString("\n\n")
+
+	line := "Favorite room: " + m.favoriteRoomName()
+	b.WriteString(m.renderRow(line, m.cursor == len(m.order)))
+	b.WriteString("\n\n")
+
+	b.WriteString(m.styles.Heading.Render("Office"))
+	b.WriteString("\n\n")
+
+	line = "Rooms start at: ‹ " + m.officeName() + " ›"
+	b.WriteString(m.renderRow(line, m.cursor == m.officeRow()))
+	if m.cfg.OfficeLocationID == "" {
+		b.WriteString(m.styles.TextDim.Render(" Wi-Fi and IP ranges from ~/" + office.FileName))
+	}
+	b.WriteString("\n")
+
+	if m.status != "" {
+		b.WriteString("\n")
+		if m.statusIsOK {
+			b.WriteString(m.styles.TextSuccess.Render(m.status))
+		} else {
+			b.WriteString(m.styles.TextError.Render(m.status))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	k := m.keyMap()
+	b.WriteString(renderFooter(m.styles, m.width, k.Up, k.Toggle, k.MoveUp, k.ChooseRoom, k.PrevOffice,
+		k.Clear, k.RefreshStats, k.Back))
+
+	return b.String()
+}
+
+// renderRow renders a settings row with the cursor marker
+func (m *SettingsModel) renderRow(line string, isSelected bool) string {
+	if isSelected {
+		return m.styles.Text.Foreground(m.styles.Colors.Primary).Render("> " + line)
+	}
+	return m.styles.Text.Render("  " + line)
+}
+
+// favoriteRoomName returns the display name of the configured favorite room
+func (m *SettingsModel) favoriteRoomName() string {
+	if m.cfg.FavoriteRoomID == "" {
+		return "none"
+	}
+	for _, room := range m.rooms {
+		if room.GetId() == m.cfg.FavoriteRoomID {
+			return room.GetName() + " (" + roomLocationName(room) + ")"
+		}
+	}
+	return m.cfg.FavoriteRoomID
+}
+
+// officeRow is the cursor position of the office row
+func (m *SettingsModel) officeRow() int {
+	return len(m.order) + 1
+}
+
+// officeLocationIDs returns the office choices, starting with detection
+func (m *SettingsModel) officeLocationIDs() []string {
+	var ids []string
+	names := make(map[string]string)
+	for _, room := range m.rooms {
+		if _, seen := names[room.GetLocationId()]; room.GetLocationId() != "" && !seen {
+			names[room.GetLocationId()] = roomLocationName(room)
+			ids = append(ids, room.GetLocationId())
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return names[ids[i]] < names[ids[j]]
+	})
+	return append([]string{""}, ids...)
+}
+
+// officeName returns the display name of the configured office
+func (m *SettingsModel) officeName() string {
+	if m.cfg.OfficeLocationID == "" {
+		return "Detect from network"
+	}
+	for _, room := range m.rooms {
+		if room.GetLocationId() == m.cfg.OfficeLocationID {
+			return roomLocationName(room)
+		}
+	}
+	return m.cfg.OfficeLocationID
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// settingsStatsMsg contains the user's booking stats for the settings view
+type settingsStatsMsg struct {
+	Stats *milesapi.MyStats
+	Error string
+}
+
+// Meetings averaging this long or more get a nudge to keep them shorter
+const longMeetingAverage = 60
+
+// loadStats fetches the user's booking stats from the reports API
+func (m *SettingsModel) loadStats() tea.Cmd {
+	ctx := m.requestCtx()
+	client := m.client
+	return func() tea.Msg {
+		stats, err := client.GetMyStats(ctx)
+		if err != nil {
+			return settingsStatsMsg{Error: err.Error()}
+		}
+		return settingsStatsMsg{Stats: stats}
+	}
+}
+
+// renderStats renders the My Stats section: this month's numbers, the
+// room booked most, hours per month as a chart and a nudge
+func (m *SettingsModel) renderStats() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Heading.Render("My Stats"))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.statsError != "":
+		b.WriteString(m.styles.TextMuted.Render("  Stats unavailable: " + m.statsError))
+		return b.String() + "\n"
+	case m.stats == nil:
+		b.WriteString(m.styles.TextMuted.Render("  Loading..."))
+		return b.String() + "\n"
+	}
+
+	month := m.stats.Month
+	summary := fmt.Sprintf("%d meeting%s", month.Meetings, plural(month.Meetings))
+	if month.Meetings > 0 {
+		summary += fmt.Sprintf(" • %s • avg %s", formatHours(hoursDuration(month.Hours)),
+			utils.FormatMinutes(month.AverageMinutes))
+	}
+	if month.Cancelled > 0 {
+		summary += fmt.Sprintf(" • %d cancelled", month.Cancelled)
+	}
+	b.WriteString(m.statsRow("This month", m.styles.Text.Render(summary)))
+
+	if room := m.stats.MostUsedRoom; room != nil {
+		line := fmt.Sprintf("%s (%s) • %d booking%s in %d months", room.Name, room.Location, room.Bookings,
+			plural(room.Bookings), len(m.stats.Trend))
+		b.WriteString(m.statsRow("Most used", m.styles.Text.Render(line)))
+	}
+
+	if chart, labels := m.renderTrend(); chart != "" {
+		b.WriteString(m.statsRow("Trend", chart+" "+m.styles.TextMuted.Render("hours per month")))
+		b.WriteString(m.statsRow("", labels))
+	}
+
+	if nudge := statsNudge(*m.stats); nudge != "" {
+		b.WriteString("  " + m.styles.TextInfo.Render(nudge) + "\n")
+	}
+	return b.String()
+}
+
+// statsRow renders a label and its value on one line
+func (m *SettingsModel) statsRow(label, value string) string {
+	return "  " + m.styles.TextBold.Render(fmt.Sprintf("%-12s", label)) + value + "\n"
+}
+
+// renderTrend draws hours per month as blocks, scaled to the busiest month
+// so any use shows, with the months' initials below
+func (m *SettingsModel) renderTrend() (chart, labels string) {
+	var busiest float32
+	for _, month := range m.stats.Trend {
+		busiest = max(busiest, month.Hours)
+	}
+	if busiest == 0 {
+		return "", ""
+	}
+
+	var blocks, initials strings.Builder
+	for _, month := range m.stats.Trend {
+		block := "·"
+		if month.Hours > 0 {
+			level := int(month.Hours / busiest * float32(len(sparkBlocks)-1))
+			block = string(sparkBlocks[level])
+		}
+		blocks.WriteString(block + " ")
+
+		initial := "?"
+		if t, err := time.Parse("2006-01", month.Month); err == nil {
+			initial = t.Format("Jan")[:1]
+		}
+		initials.WriteString(initial + " ")
+	}
+	return m.styles.TextInfo.Render(strings.TrimSpace(blocks.String())),
+		m.styles.TextMuted.Render(strings.TrimSpace(initials.String()))
+}
+
+// statsNudge is a word on meeting habits the numbers suggest, if any
+func statsNudge(stats milesapi.MyStats) string {
+	month := stats.Month
+	switch {
+	case month.Meetings == 0:
+		return "No meetings this month. Your calendar thanks you."
+	case month.Cancelled*4 > month.Meetings+month.Cancelled:
+		return "Many cancellations: dropping rooms early lets others book them."
+	case month.AverageMinutes >= longMeetingAverage:
+		return "Long meetings: try 25 or 50 minutes to leave room for a breather."
+	case month.AverageMinutes <= 30:
+		return "Short and sweet: nicely kept meetings."
+	}
+	return ""
+}
+
+// hoursDuration turns fractional hours into a duration
+func hoursDuration(hours float32) time.Duration {
+	return time.Duration(float64(hours) * float64(time.Hour))
+}
+
+// plural is "s" unless n is 1
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
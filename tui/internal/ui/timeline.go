@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Hours a day timeline shows at least; it widens to fit bookings outside them
+const (
+	timelineStartHour = 7
+	timelineEndHour   = 19
+)
+
+// renderDayTimeline draws a room's day as a strip of time cells with the
+// proposed slot [start, end) laid over the existing bookings. Cells where
+// they overlap are red, so a clash is visible before submitting. width is
+// the space available; the cell size grows to fit.
+func renderDayTimeline(s *styles.Styles, day time.Time, bookings []milesapi.Booking, start, end time.Time, width int) string {
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	proposed := end.After(start)
+
+	// Widen the window to whole hours covering everything shown
+	first, last := timelineStartHour, timelineEndHour
+	cover := func(from, to time.Time) {
+		if !to.After(dayStart) || !from.Before(dayStart.AddDate(0, 0, 1)) {
+			return
+		}
+		fromHour := max(0, int(from.Sub(dayStart).Hours()))
+		toHour := min(24, int((to.Sub(dayStart) + time.Hour - time.Nanosecond).Hours()))
+		first, last = min(first, fromHour), max(last, toHour)
+	}
+	var active []milesapi.Booking
+	for _, booking := range bookings {
+		if booking.GetStatus() == milesapi.BookingStatusCANCELLED {
+			continue
+		}
+		active = append(active, booking)
+		cover(booking.GetStartTime(), booking.GetEndTime())
+	}
+	if proposed {
+		cover(start, end)
+	}
+
+	// Quarter-hour cells when they fit, otherwise half or whole hours
+	hours := last - first
+	cell := 15 * time.Minute
+	for _, size := range []time.Duration{15 * time.Minute, 30 * time.Minute, time.Hour} {
+		cell = size
+		if width <= 0 || hours*int(time.Hour/size) <= width {
+			break
+		}
+	}
+	cells := hours * int(time.Hour/cell)
+	windowStart := dayStart.Add(time.Duration(first) * time.Hour)
+
+	overlaps := func(aStart, aEnd, bStart, bEnd time.Time) bool {
+		return aStart.Before(bEnd) && bStart.Before(aEnd)
+	}
+
+	// Hour labels above the strip, skipping any that would collide
+	labels := []rune(strings.Repeat(" ", cells+2))
+	next := 0
+	for hour := first; hour < last; hour++ {
+		i := (hour - first) * int(time.Hour/cell)
+		if i < next {
+			continue
+		}
+		copy(labels[i:], []rune(fmt.Sprintf("%02d", hour)))
+		next = i + 3
+	}
+
+	busyStyle := lipgloss.NewStyle().Foreground(s.Colors.Secondary)
+	mineStyle := lipgloss.NewStyle().Foreground(s.Colors.Primary)
+	clashStyle := lipgloss.NewStyle().Foreground(s.Colors.Error)
+	freeStyle := lipgloss.NewStyle().Foreground(s.Colors.TextDim)
+
+	var strip strings.Builder
+	for i := 0; i < cells; i++ {
+		cellStart := windowStart.Add(time.Duration(i) * cell)
+		cellEnd := cellStart.Add(cell)
+
+		busy := false
+		for _, booking := range active {
+			if overlaps(cellStart, cellEnd, booking.GetStartTime(), booking.GetEndTime()) {
+				busy = true
+				break
+			}
+		}
+		mine := proposed && overlaps(cellStart, cellEnd, start, end)
+
+		switch {
+		case busy && mine:
+			strip.WriteString(clashStyle.Render("█"))
+		case mine:
+			strip.WriteString(mineStyle.Render("█"))
+		case busy:
+			strip.WriteString(busyStyle.Render("█"))
+		default:
+			strip.WriteString(freeStyle.Render("·"))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(s.TextMuted.Render(strings.TrimRight(string(labels), " ")))
+	b.WriteString("\n")
+	b.WriteString(strip.String())
+	b.WriteString("\n")
+	b.WriteString(busyStyle.Render("█") + s.TextMuted.Render(" booked  ") +
+		mineStyle.Render("█") + s.TextMuted.Render(" yours  ") +
+		clashStyle.Render("█") + s.TextMuted.Render(" clash"))
+
+	// Name what is in the way
+	if proposed {
+		for _, booking := range active {
+			if !overlaps(start, end, booking.GetStartTime(), booking.GetEndTime()) {
+				continue
+			}
+			title := booking.GetTitle()
+			if title == "" {
+				title = "a booking"
+			} else {
+				title = "“" + title + "”"
+			}
+			b.WriteString("\n")
+			b.WriteString(s.TextError.Render(fmt.Sprintf("✗ Clashes with %s %s–%s", title,
+				booking.GetStartTime().In(day.Location()).Format("15:04"),
+				booking.GetEndTime().In(day.Location()).Format("15:04"))))
+		}
+	}
+
+	return b.String()
+}
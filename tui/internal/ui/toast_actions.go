@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// ToastAction is a quick reply offered on a toast. Pressing Key while the
+// toast is the newest one with actions dismisses it and sends Msg into the
+// app, as if a view had sent it.
+type ToastAction struct {
+	Key   string
+	Label string
+	Msg   tea.Msg
+}
+
+// Quick replies use the same key on every toast that offers them
+const (
+	toastKeyExtend  = "2"
+	toastKeyDetails = "3"
+)
+
+// extendBy is how much the extend quick reply adds to a booking
+const extendBy = 30 * time.Minute
+
+// OpenBookingMsg opens a booking's details in the bookings view
+type OpenBookingMsg struct {
+	Booking milesapi.Booking
+}
+
+// ExtendBookingMsg moves a booking's end later by By
+type ExtendBookingMsg struct {
+	Booking milesapi.Booking
+	By      time.Duration
+}
+
+// detailsAction opens booking's details
+func detailsAction(booking milesapi.Booking) ToastAction {
+	return ToastAction{Key: toastKeyDetails, Label: "details", Msg: OpenBookingMsg{Booking: booking}}
+}
+
+// extendAction extends booking by extendBy
+func extendAction(booking milesapi.Booking) ToastAction {
+	return ToastAction{
+		Key:   toastKeyExtend,
+		Label: "extend " + utils.FormatMinutes(int(extendBy.Minutes())),
+		Msg:   ExtendBookingMsg{Booking: booking, By: extendBy},
+	}
+}
+
+// actionToast returns the newest toast on screen with quick replies, or -1.
+// Only that one takes keys, so older toasts can't shadow it.
+func (a *App) actionToast() int {
+	for i := len(a.toasts) - 1; i >= 0; i-- {
+		if len(a.toasts[i].actions) > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// handleToastKey runs the quick reply bound to key on the newest toast with
+// actions. Until that toast expires its keys win over the view shortcuts.
+func (a *App) handleToastKey(key string) (bool, tea.Cmd) {
+	i := a.actionToast()
+	if i < 0 {
+		return false, nil
+	}
+	for _, action := range a.toasts[i].actions {
+		if action.Key != key {
+			continue
+		}
+		a.toasts = append(a.toasts[:i], a.toasts[i+1:]...)
+		msg := action.Msg
+		return true, tea.Batch(a.resizeViews(), func() tea.Msg { return msg })
+	}
+	return false, nil
+}
+
+// renderToastActions renders the keys of a toast's quick replies
+func renderToastActions(actions []ToastAction) string {
+	hints := make([]string, len(actions))
+	for i, action := range actions {
+		hints[i] = action.Key + " " + action.Label
+	}
+	return strings.Join(hints, " · ")
+}
+
+// openBooking shows booking's details, creating the bookings view if needed
+func (a *App) openBooking(booking milesapi.Booking) tea.Cmd {
+	a.state = ViewBookings
+	bookings, ok := a.bookings.(*BookingsModel)
+	if !ok {
+		bookings = NewBookingsModel(a.client, a.cfg, a.styles)
+		a.bookings = bookings
+		return tea.Batch(a.initView(bookings), bookings.showDetails(booking))
+	}
+	return bookings.showDetails(booking)
+}
+
+// extendBooking moves booking's end later by by. The server refuses when
+// the room is taken or the booking would get too long.
+func extendBooking(ctx context.Context, client *api.Client, booking milesapi.Booking, by time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		end := booking.GetEndTime().Add(by)
+		if _, err := client.UpdateBooking(ctx, booking.GetId(), milesapi.PatchApiBookingsIdJSONRequestBody{EndTime: &end}); err != nil {
+			return ToastMsg{Text: "Could not extend: " + err.Error(), Error: true}
+		}
+		return ToastMsg{Text: fmt.Sprintf("✓ “%s” now ends at %s", booking.GetTitle(), end.Local().Format("15:04"))}
+	}
+}
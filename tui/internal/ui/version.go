@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/pkg/milesapi"
+	"github.com/miles/booking-tui/pkg/update"
+)
+
+// Version is the TUI's version, set at build time with
+// -ldflags "-X github.com/miles/booking-tui/internal/ui.Version=1.2.0"
+var Version = "1.0.0"
+
+// versionCheckTimeout bounds the startup check of the server's API version
+const versionCheckTimeout = 5 * time.Second
+
+// updateAvailableMsg carries a newer release found in the background
+type updateAvailableMsg struct {
+	version string
+}
+
+// checkForUpdate looks for a newer release without holding up startup. The
+// release endpoint can be overridden with MILES_UPDATE_URL, as for the CLI.
+func checkForUpdate() tea.Cmd {
+	return func() tea.Msg {
+		latest, ok := update.Available(Version, os.Getenv("MILES_UPDATE_URL"))
+		if !ok {
+			return nil
+		}
+		return updateAvailableMsg{version: latest}
+	}
+}
+
+// serverVersionMsg carries what is wrong with the server's API version
+type serverVersionMsg struct {
+	err error
+}
+
+// checkServerVersion asks the server which API version it speaks, as the
+// CLI does before its commands, without holding up startup
+func (a *App) checkServerVersion() tea.Cmd {
+	ctx := a.requestCtx()
+	client := a.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, versionCheckTimeout)
+		defer cancel()
+		if err := client.CheckServerVersion(ctx); err != nil {
+			return serverVersionMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// versionAdvice follows a failed check with what to do about it
+func versionAdvice(err error) string {
+	var incompatible *milesapi.IncompatibleError
+	switch {
+	case !errors.As(err, &incompatible):
+		return err.Error()
+	case incompatible.Newer:
+		return err.Error() + ". Run 'miles upgrade --tui'"
+	default:
+		return err.Error() + ". Ask your admin to upgrade the server"
+	}
+}
+
+// renderUpdateFooter renders the hint shown at the bottom of every screen
+// when the server speaks an API version the TUI doesn't, or else when a
+// newer release exists
+func (a *App) renderUpdateFooter() string {
+	if a.versionProblem != "" {
+		return a.styles.TextError.Render("✗ " + a.versionProblem)
+	}
+	if a.latestVersion == "" {
+		return ""
+	}
+	return a.styles.TextMuted.Render(fmt.Sprintf(
+		"⬆ miles-booking %s is available (you have %s) • run 'miles upgrade --tui'",
+		a.latestVersion, Version))
+}
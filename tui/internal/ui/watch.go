@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// watchRetryDelay is how long the app waits before watching bookings again
+// after the stream ended or couldn't be opened
+const watchRetryDelay = 30 * time.Second
+
+// BookingsChangedMsg tells the view on screen that the server streamed a
+// change to a booking, so views showing bookings reload
+type BookingsChangedMsg struct {
+	Event milesapi.BookingEvent
+}
+
+// bookingWatchMsg carries the next change from the booking stream, or
+// closed when the stream ended or couldn't be opened
+type bookingWatchMsg struct {
+	gen    int
+	events <-chan milesapi.BookingEvent
+	event  milesapi.BookingEvent
+	closed bool
+}
+
+// bookingWatchTickMsg triggers watching bookings again
+type bookingWatchTickMsg struct {
+	gen int
+}
+
+// startBookingWatch (re)starts streaming booking changes over gRPC when the
+// config asks for it (see config.WatchAddr). watchGen changes whenever the
+// stream restarts, so changes and ticks from before are dropped.
+func (a *App) startBookingWatch() tea.Cmd {
+	a.stopBookingWatch()
+	addr := a.cfg.WatchAddr()
+	if addr == "" || a.guest || !a.authenticated {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.watchCancel = cancel
+	client, gen, insecure := a.client, a.watchGen, a.cfg.GRPCInsecure
+	return func() tea.Msg {
+		events, err := client.WatchBookings(ctx, addr, insecure)
+		if err != nil {
+			return bookingWatchMsg{gen: gen, closed: true}
+		}
+		return nextBookingEvent(gen, events)()
+	}
+}
+
+// stopBookingWatch closes the booking stream, if there is one
+func (a *App) stopBookingWatch() {
+	a.watchGen++
+	if a.watchCancel != nil {
+		a.watchCancel()
+		a.watchCancel = nil
+	}
+}
+
+// nextBookingEvent waits for the next change on events
+func nextBookingEvent(gen int, events <-chan milesapi.BookingEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		return bookingWatchMsg{gen: gen, events: events, event: event, closed: !ok}
+	}
+}
+
+// handleBookingWatch passes a streamed change on to the view on screen and
+// waits for the next one. The dashboard is rebuilt when next opened. A
+// stream that ended, say because the server restarted, is opened again
+// after watchRetryDelay without bothering the user.
+func (a *App) handleBookingWatch(msg bookingWatchMsg) tea.Cmd {
+	if msg.closed {
+		gen := a.watchGen
+		return tea.Tick(watchRetryDelay, func(time.Time) tea.Msg {
+			return bookingWatchTickMsg{gen: gen}
+		})
+	}
+
+	if a.state != ViewDashboard {
+		a.dashboardStale = true
+	}
+	return tea.Batch(
+		nextBookingEvent(msg.gen, msg.events),
+		a.updateCurrentView(BookingsChangedMsg{Event: msg.event}),
+	)
+}
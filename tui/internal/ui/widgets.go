@@ -0,0 +1,434 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles/booking-tui/internal/api"
+	"github.com/miles/booking-tui/internal/config"
+	"github.com/miles/booking-tui/internal/styles"
+	"github.com/miles/booking-tui/internal/utils"
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// DashboardWidget is a panel on the dashboard. Widgets render from the data
+// the dashboard already loads and can fetch their own data via Load.
+type DashboardWidget interface {
+	// Load returns a command that fetches the widget's own data under the
+	// dashboard's ctx, or nil
+	Load(ctx context.Context) tea.Cmd
+
+	// Update receives every dashboard message; widgets ignore the ones they don't own
+	Update(msg tea.Msg)
+
+	// View renders the panel
+	View(data DashboardData) string
+}
+
+// DashboardData is the data shared by all dashboard widgets
+type DashboardData struct {
+	User      *milesapi.User
+	Bookings  []milesapi.Booking
+	Locations []milesapi.Location
+	Quota     *milesapi.Quota // nil if the server doesn't report one
+}
+
+// widgetDef describes a widget that can be enabled in settings
+type widgetDef struct {
+	ID          string
+	Name        string
+	Description string
+	New         func(client *api.Client, styles *styles.Styles, cfg *config.Config) DashboardWidget
+}
+
+// dashboardWidgets lists every available widget. Add new panels here.
+var dashboardWidgets = []widgetDef{
+	{
+		ID:          config.WidgetStats,
+		Name:        "Quick stats",
+		Description: "Booking counts and quota usage",
+		New: func(_ *api.Client, styles *styles.Styles, _ *config.Config) DashboardWidget {
+			return &statsWidget{styles: styles}
+		},
+	},
+	{
+		ID:          config.WidgetUpcoming,
+		Name:        "Upcoming bookings",
+		Description: "Your next five bookings",
+		New: func(_ *api.Client, styles *styles.Styles, _ *config.Config) DashboardWidget {
+			return &upcomingWidget{styles: styles}
+		},
+	},
+	{
+		ID:          config.WidgetFavoriteRoom,
+		Name:        "Favorite room",
+		Description: "Today's availability for one room",
+		New: func(client *api.Client, styles *styles.Styles, cfg *config.Config) DashboardWidget {
+			return &favoriteRoomWidget{client: client, styles: styles, roomID: cfg.FavoriteRoomID, loading: cfg.FavoriteRoomID != ""}
+		},
+	},
+	{
+		ID:          config.WidgetAnnouncements,
+		Name:        "Announcements",
+		Description: "Office-wide announcements",
+		New: func(client *api.Client, styles *styles.Styles, _ *config.Config) DashboardWidget {
+			return &announcementsWidget{client: client, styles: styles, loading: true}
+		},
+	},
+}
+
+// findWidget looks up a widget definition by ID
+func findWidget(id string) (widgetDef, bool) {
+	for _, def := range dashboardWidgets {
+		if def.ID == id {
+			return def, true
+		}
+	}
+	return widgetDef{}, false
+}
+
+// statsWidget shows booking counts and quota usage
+type statsWidget struct {
+	styles *styles.Styles
+}
+
+func (w *statsWidget) Load(context.Context) tea.Cmd { return nil }
+func (w *statsWidget) Update(msg tea.Msg)           {}
+
+func (w *statsWidget) View(data DashboardData) string {
+	var b strings.Builder
+
+	// Calculate stats
+	upcomingCount := 0
+	todayCount := 0
+	now := utils.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	for _, booking := range data.Bookings {
+		if booking.GetStatus() == milesapi.BookingStatusCONFIRMED && booking.GetStartTime().After(now) {
+			upcomingCount++
+			if booking.GetStartTime().After(today) && booking.GetStartTime().Before(today.Add(24*time.Hour)) {
+				todayCount++
+			}
+		}
+	}
+
+	// Stats cards
+	upcomingCard := w.renderStatCard("Upcoming Bookings", fmt.Sprintf("%d", upcomingCount), w.styles.Colors.Primary)
+	todayCard := w.renderStatCard("Today", fmt.Sprintf("%d", todayCount), w.styles.Colors.Success)
+	locationsCard := w.renderStatCard("Locations", fmt.Sprintf("%d", len(data.Locations)), w.styles.Colors.Info)
+
+	b.WriteString(w.styles.Heading.Render("Quick Stats"))
+	b.WriteString("\n\n")
+	b.WriteString(upcomingCard)
+	b.WriteString("\n")
+	b.WriteString(todayCard)
+	b.WriteString("\n")
+	b.WriteString(locationsCard)
+
+	if data.Quota != nil && data.Quota.LimitHours > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(w.renderQuota(data.Quota))
+	}
+
+	return w.styles.Panel.Width(40).Render(b.String())
+}
+
+// renderQuota renders quota usage, e.g. "7.5/10h used this week"
+func (w *statsWidget) renderQuota(quota *milesapi.Quota) string {
+	usage := utils.FormatQuota(float64(quota.UsedHours), float64(quota.LimitHours), string(quota.Period))
+
+	style := w.styles.TextSuccess
+	switch {
+	case quota.UsedHours >= quota.LimitHours:
+		style = w.styles.TextError
+	case quota.UsedHours >= 0.8*quota.LimitHours:
+		style = w.styles.TextWarning
+	}
+
+	return w.styles.TextMuted.Render("Quota ") + style.Render(usage)
+}
+
+// renderStatCard renders a single stat card
+func (w *statsWidget) renderStatCard(label, value string, color lipgloss.Color) string {
+	valueStyle := lipgloss.NewStyle().
+		Foreground(color).
+		Bold(true).
+		Width(8).
+		Align(lipgloss.Right)
+
+	labelStyle := w.styles.TextMuted.Width(20)
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		labelStyle.Render(label),
+		valueStyle.Render(value),
+	)
+}
+
+// upcomingWidget lists the user's next bookings
+type upcomingWidget struct {
+	styles *styles.Styles
+}
+
+func (w *upcomingWidget) Load(context.Context) tea.Cmd { return nil }
+func (w *upcomingWidget) Update(msg tea.Msg)           {}
+
+func (w *upcomingWidget) View(data DashboardData) string {
+	var b strings.Builder
+
+	b.WriteString(w.styles.Heading.Render("Upcoming Bookings"))
+	b.WriteString("\n\n")
+
+	// Filter and sort upcoming bookings
+	now := utils.Now()
+	upcoming := []milesapi.Booking{}
+	for _, booking := range data.Bookings {
+		if booking.GetStatus() == milesapi.BookingStatusCONFIRMED && booking.GetStartTime().After(now) {
+			upcoming = append(upcoming, booking)
+		}
+	}
+
+	if len(upcoming) == 0 {
+		b.WriteString(w.styles.TextMuted.Render("No upcoming bookings"))
+	} else {
+		// Show up to 5 upcoming bookings
+		count := len(upcoming)
+		if count > 5 {
+			count = 5
+		}
+
+		for i := 0; i < count; i++ {
+			booking := upcoming[i]
+			b.WriteString(w.renderBookingItem(booking))
+			if i < count-1 {
+				b.WriteString("\n")
+			}
+		}
+
+		if len(upcoming) > 5 {
+			b.WriteString("\n")
+			b.WriteString(w.styles.TextMuted.Render(fmt.Sprintf("...and %d more", len(upcoming)-5)))
+		}
+	}
+
+	return w.styles.Panel.Width(60).Render(b.String())
+}
+
+// renderBookingItem renders a single booking item
+func (w *upcomingWidget) renderBookingItem(booking milesapi.Booking) string {
+	// Room name
+	roomName := w.styles.TextBold.Render(booking.GetRoom().GetName())
+
+	// Time
+	timeStr := utils.FormatDateTime(booking.GetStartTime())
+	if utils.IsToday(booking.GetStartTime()) {
+		timeStr = w.styles.TextSuccess.Render("Today at " + utils.FormatTime(booking.GetStartTime()))
+	} else {
+		timeStr = w.styles.TextMuted.Render(timeStr)
+	}
+
+	// Duration
+	duration := utils.FormatDuration(booking.GetStartTime(), booking.GetEndTime())
+
+	// Location
+	location := w.styles.TextDim.Render(booking.GetRoom().GetLocation().GetName())
+
+	line1 := lipgloss.JoinHorizontal(lipgloss.Left, roomName, " • ", location)
+	line2 := lipgloss.JoinHorizontal(lipgloss.Left, timeStr, " • ", w.styles.TextMuted.Render(duration))
+
+	return line1 + "\n" + line2
+}
+
+// favoriteRoomWidget shows today's schedule for the user's favorite room
+type favoriteRoomWidget struct {
+	client *api.Client
+	styles *styles.Styles
+	roomID string
+
+	room     *milesapi.Room
+	bookings []milesapi.Booking
+	loading  bool
+	error    string
+}
+
+// favoriteRoomMsg carries the favorite room and today's bookings for it
+type favoriteRoomMsg struct {
+	Room     *milesapi.Room
+	Bookings []milesapi.Booking
+	Error    string
+}
+
+func (w *favoriteRoomWidget) Load(ctx context.Context) tea.Cmd {
+	if w.roomID == "" {
+		return nil
+	}
+	client, roomID := w.client, w.roomID
+	return func() tea.Msg {
+		room, err := client.GetRoom(ctx, roomID)
+		if err != nil {
+			return favoriteRoomMsg{Error: err.Error()}
+		}
+
+		now := time.Now()
+		dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		dayEnd := dayStart.Add(24 * time.Hour)
+		bookings, err := client.GetBookings(ctx, &roomID, nil, &dayStart, &dayEnd)
+		if err != nil {
+			return favoriteRoomMsg{Room: room, Error: err.Error()}
+		}
+		return favoriteRoomMsg{Room: room, Bookings: bookings}
+	}
+}
+
+func (w *favoriteRoomWidget) Update(msg tea.Msg) {
+	if msg, ok := msg.(favoriteRoomMsg); ok {
+		w.loading = false
+		w.room = msg.Room
+		w.error = msg.Error
+
+		w.bookings = nil
+		for _, booking := range msg.Bookings {
+			if booking.GetStatus() != milesapi.BookingStatusCANCELLED {
+				w.bookings = append(w.bookings, booking)
+			}
+		}
+		sort.Slice(w.bookings, func(i, j int) bool {
+			return w.bookings[i].GetStartTime().Before(w.bookings[j].GetStartTime())
+		})
+	}
+}
+
+func (w *favoriteRoomWidget) View(data DashboardData) string {
+	var b strings.Builder
+
+	b.WriteString(w.styles.Heading.Render("Favorite Room"))
+	b.WriteString("\n\n")
+
+	switch {
+	case w.roomID == "":
+		b.WriteString(w.styles.TextMuted.Render("No favorite room set.\nPick one in Settings (7)."))
+	case w.loading:
+		b.WriteString(w.styles.TextMuted.Render("Loading..."))
+	case w.room == nil:
+		b.WriteString(w.styles.TextError.Render("Error: " + w.error))
+	default:
+		b.WriteString(w.renderRoom())
+	}
+
+	return w.styles.Panel.Width(40).Render(b.String())
+}
+
+// renderRoom renders the room's current status and remaining bookings today
+func (w *favoriteRoomWidget) renderRoom() string {
+	var b strings.Builder
+
+	b.WriteString(w.styles.TextBold.Render(w.room.GetName()))
+	if w.room.GetLocation().GetName() != "" {
+		b.WriteString(w.styles.TextDim.Render(" • " + w.room.GetLocation().GetName()))
+	}
+	b.WriteString("\n")
+
+	now := utils.Now()
+	var current *milesapi.Booking
+	var remaining []milesapi.Booking
+	for i, booking := range w.bookings {
+		if !booking.GetStartTime().After(now) && booking.GetEndTime().After(now) {
+			current = &w.bookings[i]
+		}
+		if booking.GetEndTime().After(now) {
+			remaining = append(remaining, booking)
+		}
+	}
+
+	switch {
+	case current != nil:
+		b.WriteString(w.styles.TextError.Render("In use until " + utils.FormatTime(current.GetEndTime())))
+	case len(remaining) > 0:
+		b.WriteString(w.styles.TextSuccess.Render("Free until " + utils.FormatTime(remaining[0].GetStartTime())))
+	default:
+		b.WriteString(w.styles.TextSuccess.Render("Free for the rest of the day"))
+	}
+
+	if w.error != "" {
+		b.WriteString("\n" + w.styles.TextWarning.Render("Could not load bookings"))
+		return b.String()
+	}
+
+	const maxShown = 4
+	for i, booking := range remaining {
+		if i == maxShown {
+			b.WriteString("\n" + w.styles.TextMuted.Render(fmt.Sprintf("...and %d more today", len(remaining)-maxShown)))
+			break
+		}
+		slot := utils.FormatTime(booking.GetStartTime()) + "–" + utils.FormatTime(booking.GetEndTime())
+		b.WriteString("\n" + w.styles.TextMuted.Render(slot) + " " + w.styles.Text.Render(utils.TruncateString(booking.GetTitle(), 24)))
+	}
+
+	return b.String()
+}
+
+// announcementsWidget shows office-wide announcements from the server
+type announcementsWidget struct {
+	client *api.Client
+	styles *styles.Styles
+
+	announcements []milesapi.Announcement
+	loading       bool
+	error         string
+}
+
+// announcementsMsg carries the current announcements
+type announcementsMsg struct {
+	Announcements []milesapi.Announcement
+	Error         string
+}
+
+func (w *announcementsWidget) Load(ctx context.Context) tea.Cmd {
+	client := w.client
+	return func() tea.Msg {
+		announcements, err := client.GetAnnouncements(ctx)
+		if err != nil {
+			return announcementsMsg{Error: err.Error()}
+		}
+		return announcementsMsg{Announcements: announcements}
+	}
+}
+
+func (w *announcementsWidget) Update(msg tea.Msg) {
+	if msg, ok := msg.(announcementsMsg); ok {
+		w.loading = false
+		w.announcements = msg.Announcements
+		w.error = msg.Error
+	}
+}
+
+func (w *announcementsWidget) View(data DashboardData) string {
+	var b strings.Builder
+
+	b.WriteString(w.styles.Heading.Render("Announcements"))
+	b.WriteString("\n\n")
+
+	switch {
+	case w.loading:
+		b.WriteString(w.styles.TextMuted.Render("Loading..."))
+	case w.error != "":
+		b.WriteString(w.styles.TextMuted.Render("Announcements unavailable"))
+	case len(w.announcements) == 0:
+		b.WriteString(w.styles.TextMuted.Render("Nothing new"))
+	default:
+		for i, announcement := range w.announcements {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(w.styles.Text.Width(56).Render("• " + announcement.Message))
+		}
+	}
+
+	return w.styles.Panel.Width(60).Render(b.String())
+}
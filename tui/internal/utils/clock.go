@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// clockSkew is how far the server's clock is ahead of the local one
+var clockSkew atomic.Int64
+
+// SetClockSkew makes Now follow the server's clock, so "starts in…" and
+// what counts as past agree with the server when the local clock is off
+func SetClockSkew(skew time.Duration) {
+	clockSkew.Store(int64(skew))
+}
+
+// Now returns the current time by the server's clock
+func Now() time.Time {
+	return time.Now().Add(time.Duration(clockSkew.Load()))
+}
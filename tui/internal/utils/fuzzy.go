@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch reports whether all runes of pattern appear in s in order
+// (case-insensitive). It returns a score where higher is better, and the
+// rune positions in s that matched, for highlighting.
+//
+// Consecutive matches and matches at word starts score higher, so "bk"
+// ranks "Bookshelf" below "Big Kitchen".
+func FuzzyMatch(pattern, s string) (score int, positions []int, ok bool) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return 0, nil, true
+	}
+
+	// Spaces in the pattern only separate words, they don't need to match
+	p := []rune(strings.ReplaceAll(strings.ToLower(pattern), " ", ""))
+	r := []rune(s)
+	lower := make([]rune, len(r))
+	for i, c := range r {
+		lower[i] = unicode.ToLower(c)
+	}
+
+	pi := 0
+	prev := -2
+	for i := 0; i < len(lower) && pi < len(p); i++ {
+		if lower[i] != p[pi] {
+			continue
+		}
+
+		score++
+		if i == prev+1 {
+			score += 5 // consecutive run
+		}
+		if i == 0 || !unicode.IsLetter(r[i-1]) && !unicode.IsDigit(r[i-1]) {
+			score += 3 // start of a word
+		}
+
+		positions = append(positions, i)
+		prev = i
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, nil, false
+	}
+
+	// Prefer shorter candidates when everything else is equal
+	score -= len(r) / 10
+	return score, positions, true
+}
@@ -2,7 +2,8 @@ package utils
 
 import (
 	"fmt"
-	"strings"
+	"math"
+	"strconv"
 	"time"
 )
 
@@ -35,69 +36,62 @@ func FormatDuration(start, end time.Time) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
-// TruncateString truncates a string to a maximum length and adds ellipsis
-func TruncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	if maxLen <= 3 {
-		return "..."
-	}
-	return s[:maxLen-3] + "..."
+// FormatMinutes formats a number of minutes, e.g. "45m", "1h" or "1h 30m"
+func FormatMinutes(minutes int) string {
+	start := time.Time{}
+	return FormatDuration(start, start.Add(time.Duration(minutes)*time.Minute))
 }
 
-// PadRight pads a string to the right with spaces
-func PadRight(s string, length int) string {
-	if len(s) >= length {
-		return s
+// FormatDurationLimits describes booking length limits in minutes (0 = no limit),
+// e.g. "1h–4h", "min 1h" or "max 1h". It returns "" when there are no limits.
+func FormatDurationLimits(minMinutes, maxMinutes int) string {
+	switch {
+	case minMinutes > 0 && maxMinutes > 0:
+		return FormatMinutes(minMinutes) + "–" + FormatMinutes(maxMinutes)
+	case minMinutes > 0:
+		return "min " + FormatMinutes(minMinutes)
+	case maxMinutes > 0:
+		return "max " + FormatMinutes(maxMinutes)
 	}
-	return s + strings.Repeat(" ", length-len(s))
+	return ""
 }
 
-// PadLeft pads a string to the left with spaces
-func PadLeft(s string, length int) string {
-	if len(s) >= length {
-		return s
-	}
-	return strings.Repeat(" ", length-len(s)) + s
+// FormatQuota formats quota usage, e.g. "7.5/10h used this week"
+func FormatQuota(usedHours, limitHours float64, period string) string {
+	return fmt.Sprintf("%s/%sh used this %s", formatHours(usedHours), formatHours(limitHours), period)
 }
 
-// Center centers a string within a given width
-func Center(s string, width int) string {
-	if len(s) >= width {
-		return s
-	}
-	leftPad := (width - len(s)) / 2
-	rightPad := width - len(s) - leftPad
-	return strings.Repeat(" ", leftPad) + s + strings.Repeat(" ", rightPad)
+// formatHours formats hours to one decimal without trailing zeros
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(math.Round(hours*10)/10, 'f', -1, 64)
 }
 
 // IsToday checks if a given time is today
 func IsToday(t time.Time) bool {
-	now := time.Now()
+	now := Now()
 	return t.Year() == now.Year() && t.YearDay() == now.YearDay()
 }
 
 // IsPast checks if a given time is in the past
 func IsPast(t time.Time) bool {
-	return t.Before(time.Now())
+	return t.Before(Now())
 }
 
 // IsFuture checks if a given time is in the future
 func IsFuture(t time.Time) bool {
-	return t.After(time.Now())
+	return t.After(Now())
 }
 
 // DaysUntil calculates the number of days until a given time
 func DaysUntil(t time.Time) int {
-	now := time.Now()
+	now := Now()
 	duration := t.Sub(now)
 	return int(duration.Hours() / 24)
 }
 
 // HumanizeTime returns a human-readable relative time string
 func HumanizeTime(t time.Time) string {
-	now := time.Now()
+	now := Now()
 	duration := t.Sub(now)
 
 	if duration < 0 {
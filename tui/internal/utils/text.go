@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// The helpers in this file measure plain (unstyled) text in terminal cells
+// rather than bytes, so room names like "Møterom Ærlig" and titles with
+// emoji or CJK characters line up in columns. Apply styles after layout;
+// use lipgloss.Width for text that already contains escape sequences.
+
+// DisplayWidth returns the number of terminal cells s occupies
+func DisplayWidth(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// TruncateString truncates a string to a maximum display width and adds ellipsis
+func TruncateString(s string, maxLen int) string {
+	if DisplayWidth(s) <= maxLen {
+		return s
+	}
+	if maxLen <= 3 {
+		return "..."
+	}
+	return runewidth.Truncate(s, maxLen, "...")
+}
+
+// PadRight pads a string to the right with spaces up to a display width
+func PadRight(s string, length int) string {
+	width := DisplayWidth(s)
+	if width >= length {
+		return s
+	}
+	return s + strings.Repeat(" ", length-width)
+}
+
+// PadLeft pads a string to the left with spaces up to a display width
+func PadLeft(s string, length int) string {
+	width := DisplayWidth(s)
+	if width >= length {
+		return s
+	}
+	return strings.Repeat(" ", length-width) + s
+}
+
+// Center centers a string within a given display width
+func Center(s string, width int) string {
+	textWidth := DisplayWidth(s)
+	if textWidth >= width {
+		return s
+	}
+	leftPad := (width - textWidth) / 2
+	rightPad := width - textWidth - leftPad
+	return strings.Repeat(" ", leftPad) + s + strings.Repeat(" ", rightPad)
+}
+
+// FitString truncates or pads s so it occupies exactly width cells
+func FitString(s string, width int) string {
+	return PadRight(TruncateString(s, width), width)
+}
+
+// Wrap breaks text into lines of at most width cells, splitting between
+// words where possible. Existing line breaks are kept and words longer than
+// a line are split.
+func Wrap(s string, width int) []string {
+	if width <= 0 {
+		return strings.Split(s, "\n")
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		var line strings.Builder
+		lineWidth := 0
+
+		flush := func() {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineWidth = 0
+		}
+
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		for _, word := range words {
+			wordWidth := DisplayWidth(word)
+
+			if lineWidth > 0 && lineWidth+1+wordWidth > width {
+				flush()
+			}
+			if lineWidth > 0 {
+				line.WriteByte(' ')
+				lineWidth++
+			}
+
+			// Split words that don't fit on a line of their own
+			for wordWidth > width-lineWidth && lineWidth == 0 {
+				head := runewidth.Truncate(word, width, "")
+				if head == "" {
+					// A single wide rune in a very narrow column
+					_, size := utf8.DecodeRuneInString(word)
+					head = word[:size]
+				}
+				line.WriteString(head)
+				flush()
+				word = word[len(head):]
+				wordWidth = DisplayWidth(word)
+			}
+
+			line.WriteString(word)
+			lineWidth += wordWidth
+		}
+		// A word split to its last rune has already been flushed
+		if lineWidth > 0 {
+			flush()
+		}
+	}
+	return lines
+}
+
+// WrapString is Wrap joined back into a single string
+func WrapString(s string, width int) string {
+	return strings.Join(Wrap(s, width), "\n")
+}
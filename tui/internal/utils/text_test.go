@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// TestMain measures as a western locale does, whatever the one running the
+// tests; East Asian locales count ambiguous characters as two cells
+func TestMain(m *testing.M) {
+	runewidth.DefaultCondition.EastAsianWidth = false
+	os.Exit(m.Run())
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"Oslo", 4},
+		{"Møterom Ærlig", 13},
+		{"æøå ÆØÅ", 7},
+		{"Blåbær", 6},
+		{"Fika ☕", 7},
+		{"🎉 Launch", 9},
+		{"会議室", 6},
+		{"e\u0301", 1}, // e and a combining acute accent
+	}
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.s); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		s      string
+		maxLen int
+		want   string
+	}{
+		{"Oslo", 10, "Oslo"},
+		{"Oslo", 4, "Oslo"},
+		{"Møterom Ærlig", 13, "Møterom Ærlig"},
+		{"Møterom Ærlig", 10, "Møterom..."},
+		{"Blåbærsyltetøy", 8, "Blåbæ..."},
+		{"🎉🎉🎉🎉", 8, "🎉🎉🎉🎉"},
+		{"🎉🎉🎉🎉", 7, "🎉🎉..."},
+		{"🎉🎉🎉🎉", 6, "🎉..."},
+		{"会議室の予約", 9, "会議室..."},
+		{"会議室の予約", 8, "会議..."},
+		{"Ærlig", 3, "..."},
+		{"Ærlig", 0, "..."},
+	}
+	for _, tt := range tests {
+		got := TruncateString(tt.s, tt.maxLen)
+		if got != tt.want {
+			t.Errorf("TruncateString(%q, %d) = %q, want %q", tt.s, tt.maxLen, got, tt.want)
+		}
+		if tt.maxLen >= 3 && DisplayWidth(got) > tt.maxLen {
+			t.Errorf("TruncateString(%q, %d) is %d cells wide", tt.s, tt.maxLen, DisplayWidth(got))
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		s      string
+		length int
+		right  string
+		left   string
+		center string
+	}{
+		{"Oslo", 6, "Oslo  ", "  Oslo", " Oslo "},
+		{"Ærlig", 8, "Ærlig   ", "   Ærlig", " Ærlig  "},
+		{"æøå", 5, "æøå  ", "  æøå", " æøå "},
+		{"🎉 Fest", 9, "🎉 Fest  ", "  🎉 Fest", " 🎉 Fest "},
+		{"会議", 6, "会議  ", "  会議", " 会議 "},
+		{"Møterom", 7, "Møterom", "Møterom", "Møterom"},
+		{"Møterom", 3, "Møterom", "Møterom", "Møterom"},
+	}
+	for _, tt := range tests {
+		if got := PadRight(tt.s, tt.length); got != tt.right {
+			t.Errorf("PadRight(%q, %d) = %q, want %q", tt.s, tt.length, got, tt.right)
+		}
+		if got := PadLeft(tt.s, tt.length); got != tt.left {
+			t.Errorf("PadLeft(%q, %d) = %q, want %q", tt.s, tt.length, got, tt.left)
+		}
+		if got := Center(tt.s, tt.length); got != tt.center {
+			t.Errorf("Center(%q, %d) = %q, want %q", tt.s, tt.length, got, tt.center)
+		}
+	}
+}
+
+func TestFitString(t *testing.T) {
+	for _, s := range []string{"", "Oslo", "Møterom Ærlig", "Blåbærsyltetøy på skiva", "🎉 Sommerfest 🎉", "会議室の予約", "Fika ☕"} {
+		for width := 3; width <= 16; width++ {
+			if got := FitString(s, width); DisplayWidth(got) != width {
+				t.Errorf("FitString(%q, %d) = %q, %d cells wide", s, width, got, DisplayWidth(got))
+			}
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  []string
+	}{
+		{"Blåbær og fløte på bordet", 10, []string{"Blåbær og", "fløte på", "bordet"}},
+		{"Møte\n\nÆrlig", 10, []string{"Møte", "", "Ærlig"}},
+		{"Blåbærsyltetøy", 5, []string{"Blåbæ", "rsylt", "etøy"}},
+		{"🎉🎉🎉 fest", 4, []string{"🎉🎉", "🎉", "fest"}},
+		{"会議室の予約", 5, []string{"会議", "室の", "予約"}},
+		{"会議", 1, []string{"会", "議"}},
+		{"a b", 0, []string{"a b"}},
+	}
+	for _, tt := range tests {
+		got := Wrap(tt.s, tt.width)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		for _, line := range got {
+			if tt.width > 1 && DisplayWidth(line) > tt.width {
+				t.Errorf("Wrap(%q, %d) line %q is %d cells wide", tt.s, tt.width, line, DisplayWidth(line))
+			}
+		}
+	}
+}
+
+// TestColumnsGolden renders rooms as a table the way the views do, with
+// FitString columns, and compares it to testdata/columns.golden. Run with
+// -update after an intended change.
+func TestColumnsGolden(t *testing.T) {
+	rooms := [][3]string{
+		{"Møterom Ærlig", "Oslo", "Whiteboard, skjerm"},
+		{"Blåbær", "Bergen", "☕ Kaffemaskin"},
+		{"🎉 Festsalen", "Trondheim", "Scene, lyd 🎤"},
+		{"会議室", "Tokyo", "プロジェクター"},
+		{"Økonomiavdelingens store møterom", "Stavanger", "Videokonferanse"},
+	}
+
+	var b strings.Builder
+	for _, room := range rooms {
+		line := "|" + FitString(room[0], 16) + "|" + Center(TruncateString(room[1], 9), 9) + "|" + PadLeft(TruncateString(room[2], 14), 14) + "|"
+		if DisplayWidth(line) != 43 {
+			t.Errorf("row %q is %d cells wide, want 43", line, DisplayWidth(line))
+		}
+		b.WriteString(line + "\n")
+	}
+
+	golden := filepath.Join("testdata", "columns.golden")
+	if *update {
+		if err := os.WriteFile(golden, []byte(b.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if b.String() != string(want) {
+		t.Errorf("columns differ from %s:\ngot:\n%s\nwant:\n%s", golden, b.String(), want)
+	}
+}
@@ -0,0 +1,71 @@
+// Package deeplink makes and reads links to a booking, shared by
+// `miles share`, `miles open-link` and the TUI. A miles://booking/<id> link
+// opens the booking in the TUI; a web link opens it in the browser.
+package deeplink
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Scheme is the URL scheme `miles open-link --register` hands to the CLI
+const Scheme = "miles"
+
+// DefaultWebURL is where the web app runs in development
+const DefaultWebURL = "http://localhost:5173"
+
+// ErrNotBookingLink is returned for links that don't point at a booking
+var ErrNotBookingLink = errors.New("not a link to a booking")
+
+// Booking returns the miles:// link to the booking with id
+func Booking(id string) string {
+	return Scheme + "://booking/" + url.PathEscape(id)
+}
+
+// BookingWeb returns the web app's link to the booking with id, webURL
+// being where the web app runs
+func BookingWeb(webURL, id string) string {
+	return strings.TrimRight(webURL, "/") + "/bookings?booking=" + url.QueryEscape(id)
+}
+
+// ParseBooking returns the booking ID in a link: miles://booking/<id>, a
+// web link from BookingWeb, or a web link to /bookings/<id>
+func ParseBooking(link string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", fmt.Errorf("invalid link: %w", err)
+	}
+
+	var id string
+	switch u.Scheme {
+	case Scheme:
+		// miles://booking/<id> puts "booking" in the host, miles:booking/<id>
+		// in the opaque part
+		path := u.Host + u.Path
+		if u.Opaque != "" {
+			path = u.Opaque
+		}
+		kind, rest, _ := strings.Cut(strings.Trim(path, "/"), "/")
+		if kind != "booking" {
+			return "", ErrNotBookingLink
+		}
+		id = rest
+	case "http", "https":
+		path := strings.TrimRight(u.Path, "/")
+		switch {
+		case strings.HasSuffix(path, "/bookings"):
+			id = u.Query().Get("booking")
+		case strings.Contains(path, "/bookings/"):
+			id = path[strings.LastIndex(path, "/bookings/")+len("/bookings/"):]
+		}
+	default:
+		return "", ErrNotBookingLink
+	}
+
+	if id, err = url.PathUnescape(id); err != nil || id == "" || strings.Contains(id, "/") {
+		return "", ErrNotBookingLink
+	}
+	return id, nil
+}
@@ -0,0 +1,247 @@
+// Package ical writes bookings as an iCalendar (.ics) file, RFC 5545, for
+// `miles bookings -o ics` and the TUI's export, so they can be imported
+// into Outlook or Google Calendar.
+//
+// Each event's times are written in its zone, the booked room's location,
+// with a VTIMEZONE describing the zone's offsets over the years the events
+// span. Events without a zone are written in UTC.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status is how sure an event is to happen
+type Status string
+
+const (
+	Confirmed Status = "CONFIRMED"
+	Tentative Status = "TENTATIVE"
+	Cancelled Status = "CANCELLED"
+)
+
+// Event is one VEVENT
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Zone        *time.Location // nil for UTC
+	Summary     string
+	Description string
+	Location    string
+	Status      Status
+	Updated     time.Time // LAST-MODIFIED, left out when zero
+}
+
+// UIDDomain follows booking IDs in UIDs, so they are unique among other
+// calendars' events and importing again updates rather than duplicates
+const UIDDomain = "booking.miles.no"
+
+// productID names the calendar's producer
+const productID = "-//Miles//Booking//EN"
+
+// Write writes events as a VCALENDAR
+func Write(w io.Writer, events []Event) error {
+	out := &writer{w: bufio.NewWriter(w)}
+	now := time.Now().UTC()
+
+	out.line("BEGIN", "VCALENDAR")
+	out.line("VERSION", "2.0")
+	out.line("PRODID", productID)
+	out.line("CALSCALE", "GREGORIAN")
+	out.line("METHOD", "PUBLISH")
+	for _, zone := range zonesOf(events) {
+		out.timezone(zone, events)
+	}
+	for _, event := range events {
+		out.line("BEGIN", "VEVENT")
+		out.line("UID", escape(event.UID))
+		out.line("DTSTAMP", formatUTC(now))
+		out.dateTime("DTSTART", event.Start, event.Zone)
+		out.dateTime("DTEND", event.End, event.Zone)
+		out.line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			out.line("DESCRIPTION", escape(event.Description))
+		}
+		if event.Location != "" {
+			out.line("LOCATION", escape(event.Location))
+		}
+		if event.Status != "" {
+			out.line("STATUS", string(event.Status))
+		}
+		if !event.Updated.IsZero() {
+			out.line("LAST-MODIFIED", formatUTC(event.Updated))
+		}
+		out.line("END", "VEVENT")
+	}
+	out.line("END", "VCALENDAR")
+
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+// writer writes content lines, folded at 75 octets, and keeps the first
+// error
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes "NAME:value", folding it onto continuation lines that start
+// with a space. Folds never split a UTF-8 character.
+func (o *writer) line(name, value string) {
+	if o.err != nil {
+		return
+	}
+	text := name + ":" + value
+	// Continuation lines spend an octet on the leading space
+	limit := 75
+	for len(text) > limit {
+		cut := limit
+		for cut > 0 && !isCharStart(text[cut]) {
+			cut--
+		}
+		_, o.err = o.w.WriteString(text[:cut] + "\r\n ")
+		text, limit = text[cut:], 74
+		if o.err != nil {
+			return
+		}
+	}
+	_, o.err = o.w.WriteString(text + "\r\n")
+}
+
+// dateTime writes a DATE-TIME in zone, or in UTC without one
+func (o *writer) dateTime(name string, t time.Time, zone *time.Location) {
+	if zone == nil || zone == time.UTC {
+		o.line(name, formatUTC(t))
+		return
+	}
+	o.line(name+";TZID="+zone.String(), t.In(zone).Format("20060102T150405"))
+}
+
+// timezone writes a VTIMEZONE for zone: the offset in effect when the
+// earliest event starts, then every change until the latest ends
+func (o *writer) timezone(zone *time.Location, events []Event) {
+	from, to := span(zone, events)
+	// Start on January 1st so events at the very start have an offset
+	from = time.Date(from.Year(), time.January, 1, 0, 0, 0, 0, zone)
+	to = time.Date(to.Year()+1, time.January, 1, 0, 0, 0, 0, zone)
+
+	o.line("BEGIN", "VTIMEZONE")
+	o.line("TZID", zone.String())
+	name, offset := from.Zone()
+	o.observance(from, name, offset, offset, from.IsDST())
+	for _, change := range transitions(from, to) {
+		_, before := change.Add(-time.Second).Zone()
+		name, after := change.Zone()
+		// Onsets are local times in the offset being left
+		o.observance(change.In(time.FixedZone("", before)), name, before, after, change.IsDST())
+	}
+	o.line("END", "VTIMEZONE")
+}
+
+// observance writes a STANDARD or DAYLIGHT component starting at onset
+func (o *writer) observance(onset time.Time, name string, from, to int, daylight bool) {
+	kind := "STANDARD"
+	if daylight {
+		kind = "DAYLIGHT"
+	}
+	o.line("BEGIN", kind)
+	o.line("DTSTART", onset.Format("20060102T150405"))
+	o.line("TZOFFSETFROM", formatOffset(from))
+	o.line("TZOFFSETTO", formatOffset(to))
+	if name != "" && !strings.ContainsAny(name[:1], "+-") {
+		o.line("TZNAME", escape(name))
+	}
+	o.line("END", kind)
+}
+
+// zonesOf returns the zones events are in, other than UTC, by name
+func zonesOf(events []Event) []*time.Location {
+	seen := map[string]*time.Location{}
+	for _, event := range events {
+		if event.Zone != nil && event.Zone != time.UTC {
+			seen[event.Zone.String()] = event.Zone
+		}
+	}
+	zones := make([]*time.Location, 0, len(seen))
+	for _, zone := range seen {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].String() < zones[j].String() })
+	return zones
+}
+
+// span returns when the earliest event in zone starts and the latest ends
+func span(zone *time.Location, events []Event) (from, to time.Time) {
+	for _, event := range events {
+		if event.Zone == nil || event.Zone.String() != zone.String() {
+			continue
+		}
+		if from.IsZero() || event.Start.Before(from) {
+			from = event.Start
+		}
+		if to.IsZero() || event.End.After(to) {
+			to = event.End
+		}
+	}
+	return from.In(zone), to.In(zone)
+}
+
+// transitions returns the instants in [from, to) when the zone's offset
+// changes, to the second. Days are stepped through and a change narrowed
+// down by halving, as time.Location has no list of them.
+func transitions(from, to time.Time) []time.Time {
+	var changes []time.Time
+	for day := from; day.Before(to); {
+		next := day.Add(24 * time.Hour)
+		_, before := day.Zone()
+		_, after := next.Zone()
+		if before != after {
+			lo, hi := day, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+				if _, offset := mid.Zone(); offset == before {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			changes = append(changes, hi)
+		}
+		day = next
+	}
+	return changes
+}
+
+// formatUTC formats a DATE-TIME in UTC, e.g. 20251020T070000Z
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatOffset formats a UTC offset in seconds as +HHMM
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// escape escapes a TEXT value: backslashes, semicolons, commas and line
+// breaks
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// isCharStart reports whether b begins a UTF-8 character
+func isCharStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
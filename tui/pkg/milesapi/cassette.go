@@ -0,0 +1,269 @@
+package milesapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Cassette holds recorded HTTP interactions with the REST API, VCR-style.
+// Recording saves every request and response the client makes to a YAML
+// file; replaying answers requests from that file without touching the
+// network, so a command can be rerun deterministically against the same
+// server responses.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+
+	path string
+	mu   sync.Mutex
+	used []bool
+}
+
+// Interaction is one recorded request and the response to it. Request
+// headers, including the Authorization token, are never recorded, and
+// passwords, tokens and secrets in JSON bodies are replaced with Redacted,
+// so a recorded login doesn't leave credentials on disk.
+type Interaction struct {
+	Request  RecordedRequest  `yaml:"request"`
+	Response RecordedResponse `yaml:"response"`
+}
+
+// RecordedRequest is the request side of an Interaction
+type RecordedRequest struct {
+	Method string `yaml:"method"`
+	URL    string `yaml:"url"` // Path and query, without the host
+	Body   string `yaml:"body,omitempty"`
+}
+
+// RecordedResponse is the response side of an Interaction
+type RecordedResponse struct {
+	Status      int    `yaml:"status"`
+	ContentType string `yaml:"contentType,omitempty"`
+	Body        string `yaml:"body,omitempty"`
+}
+
+// Redacted stands in for secrets left out of recorded bodies
+const Redacted = "REDACTED"
+
+// NewCassette starts an empty cassette that records to path
+func NewCassette(path string) *Cassette {
+	return &Cassette{path: path}
+}
+
+// LoadCassette reads a recorded cassette for replaying
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	c := &Cassette{path: path}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	c.used = make([]bool, len(c.Interactions))
+	return c, nil
+}
+
+// Record makes the client save every interaction with the server to a new
+// cassette at path
+func (c *Client) Record(path string) {
+	c.http.SetTransport(NewCassette(path).Recorder(http.DefaultTransport))
+}
+
+// Replay makes the client answer requests from the cassette at path
+// instead of the server
+func (c *Client) Replay(path string) error {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return err
+	}
+	c.http.SetTransport(cassette.Replayer())
+	return nil
+}
+
+// Recorder returns a transport that sends requests through next and saves
+// each interaction to the cassette file as it completes
+func (c *Cassette) Recorder(next http.RoundTripper) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		reqBody, err := readBody(&req.Body)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		respBody, err := readBody(&resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.Interactions = append(c.Interactions, Interaction{
+			Request: RecordedRequest{Method: req.Method, URL: req.URL.RequestURI(), Body: redactBody(reqBody)},
+			Response: RecordedResponse{
+				Status:      resp.StatusCode,
+				ContentType: resp.Header.Get("Content-Type"),
+				Body:        redactBody(respBody),
+			},
+		})
+		if err := c.save(); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return resp, nil
+	})
+}
+
+// Replayer returns a transport that answers requests from the cassette.
+// Requests are matched by method and URL, in recorded order, so a request
+// made twice gets the two recorded responses; once they are used up the
+// last one repeats. When no URL matches exactly, the query is ignored, as
+// it often holds the current time.
+func (c *Cassette) Replayer() http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		i := c.find(req.Method, req.URL.RequestURI(), false)
+		if i < 0 {
+			i = c.find(req.Method, req.URL.Path, true)
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("no recorded response for %s %s in %s", req.Method, req.URL.RequestURI(), c.path)
+		}
+		c.used[i] = true
+
+		recorded := c.Interactions[i].Response
+		header := http.Header{}
+		if recorded.ContentType != "" {
+			header.Set("Content-Type", recorded.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+			StatusCode:    recorded.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewBufferString(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	})
+}
+
+// find returns the first unused interaction for the request, or the last
+// used one when all matches are used up. With pathOnly, url is compared to
+// recorded paths without their query.
+func (c *Cassette) find(method, url string, pathOnly bool) int {
+	last := -1
+	for i, interaction := range c.Interactions {
+		recorded := interaction.Request.URL
+		if pathOnly {
+			recorded, _, _ = strings.Cut(recorded, "?")
+		}
+		if interaction.Request.Method != method || recorded != url {
+			continue
+		}
+		if !c.used[i] {
+			return i
+		}
+		last = i
+	}
+	return last
+}
+
+// save writes the cassette to its file
+func (c *Cassette) save() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save cassette: %w", err)
+	}
+	return nil
+}
+
+// readBody reads a request or response body and puts back a fresh reader
+// so it can still be sent or parsed
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return "", err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
+
+// redactBody replaces secrets in a JSON body with Redacted. Bodies without
+// any, and bodies that aren't JSON, are kept as they are.
+func redactBody(body string) string {
+	var value any
+	if body == "" || json.Unmarshal([]byte(body), &value) != nil || !redact(value) {
+		return body
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// redact replaces the values of secret keys anywhere in a decoded JSON
+// value, reporting whether it found any
+func redact(value any) bool {
+	found := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if secretKey(key) {
+				if field != nil && field != "" {
+					v[key] = Redacted
+					found = true
+				}
+				continue
+			}
+			found = redact(field) || found
+		}
+	case []any:
+		for _, item := range v {
+			found = redact(item) || found
+		}
+	}
+	return found
+}
+
+// secretKeys are JSON keys holding credentials, in lower case: the login
+// password and the token issued for it, and webhook signing secrets
+var secretKeys = map[string]bool{
+	"password":     true,
+	"token":        true,
+	"accesstoken":  true,
+	"refreshtoken": true,
+	"secret":       true,
+}
+
+// secretKey reports whether a JSON key holds a credential
+func secretKey(key string) bool {
+	return secretKeys[strings.ToLower(key)]
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
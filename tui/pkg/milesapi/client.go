@@ -0,0 +1,1691 @@
+package milesapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Client is the typed client for the Miles booking API, shared by the CLI
+// and the TUI
+type Client struct {
+	BaseURL string
+	Token   string
+	http    *resty.Client
+}
+
+// NewClient creates a client for the server at baseURL, e.g.
+// http://localhost:3000. Requests go to its /api routes.
+func NewClient(baseURL, token string) *Client {
+	client := resty.New()
+	client.SetTimeout(10 * time.Second)
+	client.SetBaseURL(strings.TrimRight(baseURL, "/") + "/api")
+
+	if token != "" {
+		client.SetAuthToken(token)
+	}
+
+	return &Client{
+		BaseURL: baseURL,
+		Token:   token,
+		http:    client,
+	}
+}
+
+// SetToken authenticates every request with token. An empty token signs out.
+func (c *Client) SetToken(token string) {
+	c.Token = token
+	c.http.SetAuthToken(token)
+}
+
+// ImpersonateHeader carries the impersonated user's email on every request
+const ImpersonateHeader = "X-Impersonate-User"
+
+// IdempotencyKeyHeader carries the key that makes retrying a create safe:
+// the server answers a repeated key with what the first request made
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// NewIdempotencyKey returns a random key for one create request
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SetImpersonate makes every request act as the user with the given email.
+// An empty email turns impersonation off.
+func (c *Client) SetImpersonate(email string) {
+	if email == "" {
+		c.http.Header.Del(ImpersonateHeader)
+		return
+	}
+	c.http.SetHeader(ImpersonateHeader, email)
+}
+
+// Resty returns the underlying HTTP client, already pointed at /api and
+// carrying the token and impersonation headers. Frontends use it to swap
+// the transport, hook responses, and call endpoints into their own types.
+func (c *Client) Resty() *resty.Client {
+	return c.http
+}
+
+// LoginResponse represents the login API response
+type LoginResponse struct {
+	Token string `json:"token"`
+	User  *User  `json:"user,omitempty"`
+}
+
+// API response wrappers - the API returns data wrapped in objects
+type LocationsResponse struct {
+	Locations []Location `json:"locations"`
+}
+
+type ManagersResponse struct {
+	Managers []User `json:"managers"`
+}
+
+type RoomsResponse struct {
+	Rooms []Room `json:"rooms"`
+}
+
+// RoomAccessContact is someone an access request went to
+type RoomAccessContact struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type RoomAccessResponse struct {
+	Message   string              `json:"message"`
+	Contacted []RoomAccessContact `json:"contacted"`
+}
+
+type BookingsResponse struct {
+	Bookings  []Booking `json:"bookings"`
+	SyncToken *string   `json:"syncToken,omitempty"`
+}
+
+// NextCursor picks the cursor to resume from after this response: the
+// server's sync token when it sends one, otherwise the newest updatedAt seen
+func (r BookingsResponse) NextCursor(cursor string) string {
+	if r.SyncToken != nil && *r.SyncToken != "" {
+		return *r.SyncToken
+	}
+
+	var newest time.Time
+	for _, booking := range r.Bookings {
+		if booking.UpdatedAt != nil && booking.UpdatedAt.After(newest) {
+			newest = *booking.UpdatedAt
+		}
+	}
+	if newest.IsZero() {
+		return cursor
+	}
+	return newest.UTC().Format(time.RFC3339Nano)
+}
+
+type UserResponse struct {
+	User User `json:"user"`
+}
+
+type QuotaResponse struct {
+	Quota Quota `json:"quota"`
+}
+
+// CancelResult is what cancelling did. LateCancellations counts the
+// bookings cancelled inside their location's late-cancellation window, and
+// Warning is the server's note about them.
+type CancelResult struct {
+	Cancelled         int    `json:"cancelled"`
+	LateCancellations int    `json:"lateCancellations"`
+	Warning           string `json:"warning,omitempty"`
+}
+
+type CancelBookingResponse struct {
+	LateCancellation bool   `json:"lateCancellation"`
+	Warning          string `json:"warning,omitempty"`
+}
+
+// Result counts the one booking cancelled
+func (r CancelBookingResponse) Result() *CancelResult {
+	result := &CancelResult{Cancelled: 1, Warning: r.Warning}
+	if r.LateCancellation {
+		result.LateCancellations = 1
+	}
+	return result
+}
+
+type BusyTimesResponse struct {
+	Busy []BusyTime `json:"busy"`
+}
+
+type RoomMergeResponse struct {
+	Merge RoomMerge `json:"merge"`
+}
+
+type ApprovalRulesResponse struct {
+	RequiresApproval bool           `json:"requiresApproval"`
+	Rules            []ApprovalRule `json:"rules"`
+}
+
+// Login authenticates a user and returns a token
+func (c *Client) Login(ctx context.Context, email, password string) (*LoginResponse, error) {
+	var result LoginResponse
+
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(map[string]string{
+			"email":    email,
+			"password": password,
+		}).
+		SetResult(&result).
+		Post("/auth/login")
+
+	if err != nil {
+		return nil, fmt.Errorf("login request failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("login failed: %s", resp.Status())
+	}
+
+	c.SetToken(result.Token)
+
+	return &result, nil
+}
+
+// Register creates a user account and signs in as it
+func (c *Client) Register(ctx context.Context, input PostApiAuthRegisterJSONRequestBody) (*LoginResponse, error) {
+	var result LoginResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&result).
+		Post("/auth/register")
+
+	if err != nil {
+		return nil, fmt.Errorf("registration failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("registration", resp)
+	}
+
+	c.SetToken(result.Token)
+
+	return &result, nil
+}
+
+// GetCurrentUser retrieves the authenticated user's profile
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	var response UserResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get("/auth/me")
+
+	if err != nil {
+		return nil, fmt.Errorf("get current user failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get current user", resp)
+	}
+
+	return &response.User, nil
+}
+
+// GetLocations retrieves all locations
+func (c *Client) GetLocations(ctx context.Context) ([]Location, error) {
+	var response LocationsResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get("/locations")
+
+	if err != nil {
+		return nil, fmt.Errorf("get locations failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("get locations failed: %s", resp.Status())
+	}
+
+	return response.Locations, nil
+}
+
+// GetLocation retrieves a location by ID
+func (c *Client) GetLocation(ctx context.Context, locationID string) (*Location, error) {
+	var response struct {
+		Location Location `json:"location"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("/locations/%s", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get location failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get location", resp)
+	}
+
+	return &response.Location, nil
+}
+
+// GetLocationManagers retrieves the managers of a location
+func (c *Client) GetLocationManagers(ctx context.Context, locationID string) ([]User, error) {
+	var response ManagersResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("/locations/%s/managers", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get location managers failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get location managers", resp)
+	}
+
+	return response.Managers, nil
+}
+
+// RequestRoomAccess asks who looks after a restricted room for access
+func (c *Client) RequestRoomAccess(ctx context.Context, roomID, message string) ([]RoomAccessContact, error) {
+	var response RoomAccessResponse
+	body := PostApiRoomsIdAccessRequestsJSONRequestBody{}
+	if message != "" {
+		body.Message = &message
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(body).
+		SetResult(&response).
+		Post(fmt.Sprintf("/rooms/%s/access-requests", roomID))
+
+	if err != nil {
+		return nil, fmt.Errorf("request room access failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusAccepted {
+		return nil, ResponseError("request room access", resp)
+	}
+
+	return response.Contacted, nil
+}
+
+// GetRooms retrieves rooms, optionally filtered by location
+func (c *Client) GetRooms(ctx context.Context, locationID string) ([]Room, error) {
+	var response RoomsResponse
+	req := c.http.R().SetContext(ctx).SetResult(&response)
+
+	if locationID != "" {
+		req.SetQueryParam("locationId", locationID)
+	}
+
+	resp, err := req.Get("/rooms")
+	if err != nil {
+		return nil, fmt.Errorf("get rooms failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("get rooms failed: %s", resp.Status())
+	}
+
+	return response.Rooms, nil
+}
+
+// GetDesks retrieves hot desks, optionally filtered by location and floor
+func (c *Client) GetDesks(ctx context.Context, locationID, floor string) ([]Room, error) {
+	var response RoomsResponse
+	req := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		SetQueryParam("type", string(DESK))
+
+	if locationID != "" {
+		req.SetQueryParam("locationId", locationID)
+	}
+	if floor != "" {
+		req.SetQueryParam("floor", floor)
+	}
+
+	resp, err := req.Get("/rooms")
+	if err != nil {
+		return nil, fmt.Errorf("get desks failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get desks", resp)
+	}
+
+	return response.Rooms, nil
+}
+
+// GetRoom retrieves a room by ID
+func (c *Client) GetRoom(ctx context.Context, roomID string) (*Room, error) {
+	var response struct {
+		Room Room `json:"room"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("/rooms/%s", roomID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get room failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get room", resp)
+	}
+
+	return &response.Room, nil
+}
+
+// GetBookings retrieves bookings for the authenticated user
+func (c *Client) GetBookings(ctx context.Context) ([]Booking, error) {
+	return c.GetBookingsFiltered(ctx, "", "")
+}
+
+// GetBookingsFiltered retrieves bookings with optional filters
+func (c *Client) GetBookingsFiltered(ctx context.Context, roomID, locationID string) ([]Booking, error) {
+	var response BookingsResponse
+	req := c.http.R().SetContext(ctx).SetResult(&response)
+
+	if roomID != "" {
+		req.SetQueryParam("roomId", roomID)
+	}
+	if locationID != "" {
+		req.SetQueryParam("locationId", locationID)
+	}
+
+	resp, err := req.Get("/bookings")
+
+	if err != nil {
+		return nil, fmt.Errorf("get bookings failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("get bookings failed: %s", resp.Status())
+	}
+
+	return response.Bookings, nil
+}
+
+// ListBookings retrieves the bookings matching params. Which bookings are
+// visible at all still depends on the user's role.
+func (c *Client) ListBookings(ctx context.Context, params GetApiBookingsParams) ([]Booking, error) {
+	var response BookingsResponse
+	req := c.http.R().SetContext(ctx).SetResult(&response)
+
+	if params.RoomId != nil {
+		req.SetQueryParam("roomId", *params.RoomId)
+	}
+	if params.LocationId != nil {
+		req.SetQueryParam("locationId", *params.LocationId)
+	}
+	if params.StartDate != nil {
+		req.SetQueryParam("startDate", params.StartDate.UTC().Format(time.RFC3339))
+	}
+	if params.EndDate != nil {
+		req.SetQueryParam("endDate", params.EndDate.UTC().Format(time.RFC3339))
+	}
+	if params.User != nil {
+		req.SetQueryParam("user", *params.User)
+	}
+	if params.Status != nil {
+		req.SetQueryParam("status", *params.Status)
+	}
+	if params.HasSetup != nil {
+		req.SetQueryParam("hasSetup", fmt.Sprint(*params.HasSetup))
+	}
+	if params.UpdatedSince != nil {
+		req.SetQueryParam("updatedSince", *params.UpdatedSince)
+	}
+
+	resp, err := req.Get("/bookings")
+
+	if err != nil {
+		return nil, fmt.Errorf("get bookings failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get bookings", resp)
+	}
+
+	return response.Bookings, nil
+}
+
+// GetBooking retrieves a booking by ID
+func (c *Client) GetBooking(ctx context.Context, bookingID string) (*Booking, error) {
+	var response struct {
+		Booking Booking `json:"booking"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("/bookings/%s", bookingID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get booking failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get booking", resp)
+	}
+
+	return &response.Booking, nil
+}
+
+// GetBookingsBetween retrieves the bookings overlapping start to end
+func (c *Client) GetBookingsBetween(ctx context.Context, start, end time.Time) ([]Booking, error) {
+	var response BookingsResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetQueryParam("startDate", start.UTC().Format(time.RFC3339)).
+		SetQueryParam("endDate", end.UTC().Format(time.RFC3339)).
+		SetResult(&response).
+		Get("/bookings")
+
+	if err != nil {
+		return nil, fmt.Errorf("get bookings failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get bookings", resp)
+	}
+
+	return response.Bookings, nil
+}
+
+// GetBookingsSince retrieves bookings changed since cursor
+func (c *Client) GetBookingsSince(ctx context.Context, cursor string) ([]Booking, string, error) {
+	var response BookingsResponse
+	req := c.http.R().SetContext(ctx).SetResult(&response)
+
+	if cursor != "" {
+		req.SetQueryParam("updatedSince", cursor)
+	}
+
+	resp, err := req.Get("/bookings")
+
+	if err != nil {
+		return nil, "", fmt.Errorf("get bookings failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, "", fmt.Errorf("get bookings failed: %s", resp.Status())
+	}
+
+	return response.Bookings, response.NextCursor(cursor), nil
+}
+
+// GetRoomAvailability checks availability for a room within a date range
+func (c *Client) GetRoomAvailability(ctx context.Context, roomID string, startDate, endDate time.Time) ([]Booking, error) {
+	var response BookingsResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetQueryParam("startDate", startDate.Format(time.RFC3339)).
+		SetQueryParam("endDate", endDate.Format(time.RFC3339)).
+		SetResult(&response).
+		Get(fmt.Sprintf("/rooms/%s/availability", roomID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get room availability failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("get room availability failed: %s", resp.Status())
+	}
+
+	return response.Bookings, nil
+}
+
+// GetQuota retrieves the user's booking quota for the period containing at
+func (c *Client) GetQuota(ctx context.Context, at time.Time) (*Quota, error) {
+	var response QuotaResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetQueryParam("date", at.Format(time.RFC3339)).
+		SetResult(&response).
+		Get("/bookings/quota")
+
+	if err != nil {
+		return nil, fmt.Errorf("get quota failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("get quota failed: %s", resp.Status())
+	}
+
+	return &response.Quota, nil
+}
+
+// GetBusyTimes retrieves when colleagues are booked between start and end
+func (c *Client) GetBusyTimes(ctx context.Context, emails []string, start, end time.Time) ([]BusyTime, error) {
+	var response BusyTimesResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetQueryParam("emails", strings.Join(emails, ",")).
+		SetQueryParam("startDate", start.UTC().Format(time.RFC3339)).
+		SetQueryParam("endDate", end.UTC().Format(time.RFC3339)).
+		SetResult(&response).
+		Get("/bookings/busy")
+
+	if err != nil {
+		return nil, fmt.Errorf("get busy times failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get busy times", resp)
+	}
+
+	return response.Busy, nil
+}
+
+// CreateBooking creates a new booking. When the request fails before a
+// response arrives it is retried once with the same Idempotency-Key, so a
+// lost response never books twice.
+func (c *Client) CreateBooking(ctx context.Context, req BookingInput) (*Booking, error) {
+	var result Booking
+	key := NewIdempotencyKey()
+	post := func() (*resty.Response, error) {
+		return c.http.R().SetContext(ctx).
+			SetHeader(IdempotencyKeyHeader, key).
+			SetBody(req).
+			SetResult(&result).
+			Post("/bookings")
+	}
+
+	resp, err := post()
+	if err != nil {
+		resp, err = post()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create booking failed: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusConflict {
+		return nil, ReadConflictError(resp)
+	}
+
+	if err := ReadRestrictedRoomError(resp); err != nil {
+		return nil, err
+	}
+
+	if err := ReadPermissionError("create booking", resp); err != nil {
+		return nil, err
+	}
+
+	// 200 is a retry the server recognised, answered with the first booking
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		var errResp map[string]interface{}
+		json.Unmarshal(resp.Body(), &errResp)
+
+		// Check if we have an error message
+		if msg, ok := errResp["error"].(string); ok {
+			// Check if we have validation details
+			if details, ok := errResp["details"].([]interface{}); ok && len(details) > 0 {
+				// Build detailed error message from validation errors
+				var messages []string
+				for _, detail := range details {
+					if detailMap, ok := detail.(map[string]interface{}); ok {
+						if message, ok := detailMap["message"].(string); ok {
+							if path, ok := detailMap["path"].([]interface{}); ok && len(path) > 0 {
+								fieldName := fmt.Sprintf("%v", path[0])
+								messages = append(messages, fmt.Sprintf("%s: %s", fieldName, message))
+							} else {
+								messages = append(messages, message)
+							}
+						}
+					}
+				}
+				if len(messages) > 0 {
+					return nil, fmt.Errorf("%s:\n  - %s", msg, strings.Join(messages, "\n  - "))
+				}
+			}
+			// Just return the error message if no details
+			return nil, fmt.Errorf("%s", msg)
+		}
+		return nil, fmt.Errorf("create booking failed: %s", resp.Status())
+	}
+
+	return &result, nil
+}
+
+// CancelBooking cancels a booking by ID
+func (c *Client) CancelBooking(ctx context.Context, bookingID string) (*CancelResult, error) {
+	var response CancelBookingResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Delete(fmt.Sprintf("/bookings/%s", bookingID))
+
+	if err != nil {
+		return nil, fmt.Errorf("cancel booking failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("cancel booking", resp)
+	}
+
+	return response.Result(), nil
+}
+
+// UpdateBooking changes an existing booking
+func (c *Client) UpdateBooking(ctx context.Context, bookingID string, update PatchApiBookingsIdJSONRequestBody) (*Booking, error) {
+	var response struct {
+		Booking Booking `json:"booking"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(update).
+		SetResult(&response).
+		Patch(fmt.Sprintf("/bookings/%s", bookingID))
+
+	if err != nil {
+		return nil, fmt.Errorf("update booking failed: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusConflict {
+		return nil, ReadConflictError(resp)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("update booking", resp)
+	}
+
+	return &response.Booking, nil
+}
+
+// MergeRoom moves a room's bookings into another room and retires it
+func (c *Client) MergeRoom(ctx context.Context, sourceID, targetID string, dryRun bool) (*RoomMerge, error) {
+	var response RoomMergeResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(PostApiRoomsIdMergeJSONRequestBody{
+			TargetRoomId: targetID,
+			DryRun:       &dryRun,
+		}).
+		SetResult(&response).
+		Post(fmt.Sprintf("/rooms/%s/merge", sourceID))
+
+	if err != nil {
+		return nil, fmt.Errorf("merge rooms failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("merge rooms", resp)
+	}
+
+	return &response.Merge, nil
+}
+
+// GetApprovalRules retrieves a location's auto-approval rules
+func (c *Client) GetApprovalRules(ctx context.Context, locationID string) (*ApprovalRulesResponse, error) {
+	var response ApprovalRulesResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("/locations/%s/approval-rules", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get approval rules failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get approval rules", resp)
+	}
+
+	return &response, nil
+}
+
+// CreateApprovalRule adds an auto-approval rule to a location
+func (c *Client) CreateApprovalRule(ctx context.Context, locationID string, rule ApprovalRuleInput) (*ApprovalRuleResult, error) {
+	var result ApprovalRuleResult
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(rule).
+		SetResult(&result).
+		Post(fmt.Sprintf("/locations/%s/approval-rules", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("create approval rule failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("create approval rule", resp)
+	}
+
+	return &result, nil
+}
+
+// UpdateApprovalRule replaces an auto-approval rule
+func (c *Client) UpdateApprovalRule(ctx context.Context, locationID, ruleID string, rule ApprovalRuleInput) (*ApprovalRuleResult, error) {
+	var result ApprovalRuleResult
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(rule).
+		SetResult(&result).
+		Patch(fmt.Sprintf("/locations/%s/approval-rules/%s", locationID, ruleID))
+
+	if err != nil {
+		return nil, fmt.Errorf("update approval rule failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("update approval rule", resp)
+	}
+
+	return &result, nil
+}
+
+// DeleteApprovalRule removes an auto-approval rule
+func (c *Client) DeleteApprovalRule(ctx context.Context, locationID, ruleID string) error {
+	resp, err := c.http.R().SetContext(ctx).
+		Delete(fmt.Sprintf("/locations/%s/approval-rules/%s", locationID, ruleID))
+
+	if err != nil {
+		return fmt.Errorf("delete approval rule failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("delete approval rule", resp)
+	}
+
+	return nil
+}
+
+// SetRequiresApproval turns approval of new bookings at a location on or off
+func (c *Client) SetRequiresApproval(ctx context.Context, locationID string, required bool) error {
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(map[string]bool{"requiresApproval": required}).
+		Patch(fmt.Sprintf("/locations/%s", locationID))
+
+	if err != nil {
+		return fmt.Errorf("update location failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("update location", resp)
+	}
+
+	return nil
+}
+
+// GetSubscriptions retrieves the rooms and colleagues the user follows
+func (c *Client) GetSubscriptions(ctx context.Context) ([]Subscription, error) {
+	var response struct {
+		Subscriptions []Subscription `json:"subscriptions"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get("/subscriptions")
+
+	if err != nil {
+		return nil, fmt.Errorf("get subscriptions failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get subscriptions", resp)
+	}
+
+	return response.Subscriptions, nil
+}
+
+// Follow starts following a room or colleague
+func (c *Client) Follow(ctx context.Context, input SubscriptionInput) (*Subscription, error) {
+	var response struct {
+		Subscription Subscription `json:"subscription"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post("/subscriptions")
+
+	if err != nil {
+		return nil, fmt.Errorf("follow failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("follow", resp)
+	}
+
+	return &response.Subscription, nil
+}
+
+// Unfollow removes a subscription
+func (c *Client) Unfollow(ctx context.Context, subscriptionID string) error {
+	resp, err := c.http.R().SetContext(ctx).
+		Delete(fmt.Sprintf("/subscriptions/%s", subscriptionID))
+
+	if err != nil {
+		return fmt.Errorf("unfollow failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("unfollow", resp)
+	}
+
+	return nil
+}
+
+// GetActivity retrieves new and cancelled bookings for what the user follows
+func (c *Client) GetActivity(ctx context.Context, cursor string) ([]ActivityItem, string, error) {
+	var response struct {
+		Activity  []ActivityItem `json:"activity"`
+		SyncToken string         `json:"syncToken"`
+	}
+	req := c.http.R().SetContext(ctx).SetResult(&response)
+
+	if cursor != "" {
+		req.SetQueryParam("since", cursor)
+	}
+
+	resp, err := req.Get("/subscriptions/activity")
+
+	if err != nil {
+		return nil, "", fmt.Errorf("get activity failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, "", ResponseError("get activity", resp)
+	}
+
+	return response.Activity, response.SyncToken, nil
+}
+
+// GetWebhooks retrieves every booking webhook
+func (c *Client) GetWebhooks(ctx context.Context) ([]Webhook, error) {
+	var response struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get("/webhooks")
+
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get webhooks", resp)
+	}
+
+	return response.Webhooks, nil
+}
+
+// CreateWebhook adds a booking webhook
+func (c *Client) CreateWebhook(ctx context.Context, input WebhookInput) (*Webhook, error) {
+	var response struct {
+		Webhook Webhook `json:"webhook"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post("/webhooks")
+
+	if err != nil {
+		return nil, fmt.Errorf("create webhook failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("create webhook", resp)
+	}
+
+	return &response.Webhook, nil
+}
+
+// DeleteWebhook removes a booking webhook
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
+	resp, err := c.http.R().SetContext(ctx).
+		Delete(fmt.Sprintf("/webhooks/%s", webhookID))
+
+	if err != nil {
+		return fmt.Errorf("delete webhook failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("delete webhook", resp)
+	}
+
+	return nil
+}
+
+// TestWebhook sends a sample delivery to a webhook
+func (c *Client) TestWebhook(ctx context.Context, webhookID string) (*WebhookDelivery, error) {
+	var response struct {
+		Delivery WebhookDelivery `json:"delivery"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Post(fmt.Sprintf("/webhooks/%s/test", webhookID))
+
+	if err != nil {
+		return nil, fmt.Errorf("test webhook failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("test webhook", resp)
+	}
+
+	return &response.Delivery, nil
+}
+
+// GetZones retrieves every zone with its rooms
+func (c *Client) GetZones(ctx context.Context) ([]Zone, error) {
+	var response struct {
+		Zones []Zone `json:"zones"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get("/zones")
+
+	if err != nil {
+		return nil, fmt.Errorf("get zones failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get zones", resp)
+	}
+
+	return response.Zones, nil
+}
+
+// CreateZone defines a zone
+func (c *Client) CreateZone(ctx context.Context, input ZoneInput) (*Zone, error) {
+	var response struct {
+		Zone Zone `json:"zone"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post("/zones")
+
+	if err != nil {
+		return nil, fmt.Errorf("create zone failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("create zone", resp)
+	}
+
+	return &response.Zone, nil
+}
+
+// UpdateZone changes a zone
+func (c *Client) UpdateZone(ctx context.Context, zoneID string, input ZoneInput) (*Zone, error) {
+	var response struct {
+		Zone Zone `json:"zone"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Patch(fmt.Sprintf("/zones/%s", zoneID))
+
+	if err != nil {
+		return nil, fmt.Errorf("update zone failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("update zone", resp)
+	}
+
+	return &response.Zone, nil
+}
+
+// DeleteZone removes a zone, keeping its rooms and bookings
+func (c *Client) DeleteZone(ctx context.Context, zoneID string) error {
+	resp, err := c.http.R().SetContext(ctx).
+		Delete(fmt.Sprintf("/zones/%s", zoneID))
+
+	if err != nil {
+		return fmt.Errorf("delete zone failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("delete zone", resp)
+	}
+
+	return nil
+}
+
+// BookZone books every room in a zone as one group
+func (c *Client) BookZone(ctx context.Context, zoneID string, input ZoneBookingInput) (*ZoneBookingResponse, error) {
+	var response ZoneBookingResponse
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		SetError(&response).
+		Post(fmt.Sprintf("/zones/%s/bookings", zoneID))
+
+	if err != nil {
+		return nil, fmt.Errorf("book zone failed: %w", err)
+	}
+
+	// A 409 lists which rooms are taken; nothing was booked
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusConflict {
+		return nil, ResponseError("book zone", resp)
+	}
+
+	return &response, nil
+}
+
+// CancelBookingGroup cancels every booking in a group
+func (c *Client) CancelBookingGroup(ctx context.Context, groupID string) (*CancelResult, error) {
+	var result CancelResult
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&result).
+		Delete(fmt.Sprintf("/bookings/groups/%s", groupID))
+
+	if err != nil {
+		return nil, fmt.Errorf("cancel booking group failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("cancel booking group", resp)
+	}
+
+	return &result, nil
+}
+
+// GetUtilizationReport retrieves room use and cancellations over a period
+func (c *Client) GetUtilizationReport(ctx context.Context, start, end time.Time, locationID string) (*UtilizationReport, error) {
+	var report UtilizationReport
+	req := c.http.R().SetContext(ctx).SetResult(&report)
+	if !start.IsZero() {
+		req.SetQueryParam("startDate", start.UTC().Format(time.RFC3339))
+	}
+	if !end.IsZero() {
+		req.SetQueryParam("endDate", end.UTC().Format(time.RFC3339))
+	}
+	if locationID != "" {
+		req.SetQueryParam("locationId", locationID)
+	}
+	resp, err := req.Get("/reports/utilization")
+
+	if err != nil {
+		return nil, fmt.Errorf("get utilization report failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get utilization report", resp)
+	}
+
+	return &report, nil
+}
+
+// GetMyStats retrieves the signed-in user's booking stats: this month, the
+// room they book most and the last six months
+func (c *Client) GetMyStats(ctx context.Context) (*MyStats, error) {
+	var stats MyStats
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&stats).
+		Get("/reports/me")
+
+	if err != nil {
+		return nil, fmt.Errorf("get booking stats failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get booking stats", resp)
+	}
+
+	return &stats, nil
+}
+
+// GetTimeSlots retrieves the organization's time slots
+func (c *Client) GetTimeSlots(ctx context.Context) ([]TimeSlot, error) {
+	var response struct {
+		Slots []TimeSlot `json:"slots"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get("/time-slots")
+
+	if err != nil {
+		return nil, fmt.Errorf("get time slots failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get time slots", resp)
+	}
+
+	return response.Slots, nil
+}
+
+// GetFeatures retrieves the optional client features the server has on
+func (c *Client) GetFeatures(ctx context.Context) ([]Feature, error) {
+	var response struct {
+		Features []Feature `json:"features"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get("/meta/features")
+
+	if err != nil {
+		return nil, fmt.Errorf("get features failed: %w", err)
+	}
+
+	// Servers from before feature flags have everything on
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get features", resp)
+	}
+
+	return response.Features, nil
+}
+
+// GetAnnouncements retrieves the current office-wide announcements
+func (c *Client) GetAnnouncements(ctx context.Context) ([]Announcement, error) {
+	var response struct {
+		Announcements []Announcement `json:"announcements"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get("/announcements")
+
+	if err != nil {
+		return nil, fmt.Errorf("get announcements failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get announcements", resp)
+	}
+
+	return response.Announcements, nil
+}
+
+// GetVersion retrieves the API version the server speaks. Servers from
+// before versioning return nil.
+func (c *Client) GetVersion(ctx context.Context) (*ServerVersion, error) {
+	var version ServerVersion
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&version).
+		Get("/meta/version")
+
+	if err != nil {
+		return nil, fmt.Errorf("get server version failed: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get server version", resp)
+	}
+
+	return &version, nil
+}
+
+// CheckServerVersion returns an *IncompatibleError when the server speaks an
+// API version this client doesn't support. Servers that don't say, and
+// failures to ask, pass: the client's own requests report a server that's
+// down.
+func (c *Client) CheckServerVersion(ctx context.Context) error {
+	version, err := c.GetVersion(ctx)
+	if err != nil || version == nil {
+		return nil
+	}
+	return CheckAPIVersion(version.Version)
+}
+
+// CreateTimeSlot defines a time slot
+func (c *Client) CreateTimeSlot(ctx context.Context, input TimeSlotInput) (*TimeSlot, error) {
+	var response struct {
+		Slot TimeSlot `json:"slot"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post("/time-slots")
+
+	if err != nil {
+		return nil, fmt.Errorf("create time slot failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("create time slot", resp)
+	}
+
+	return &response.Slot, nil
+}
+
+// UpdateTimeSlot changes a time slot
+func (c *Client) UpdateTimeSlot(ctx context.Context, slotID string, input TimeSlotInput) (*TimeSlot, error) {
+	var response struct {
+		Slot TimeSlot `json:"slot"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Patch(fmt.Sprintf("/time-slots/%s", slotID))
+
+	if err != nil {
+		return nil, fmt.Errorf("update time slot failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("update time slot", resp)
+	}
+
+	return &response.Slot, nil
+}
+
+// DeleteTimeSlot removes a time slot
+func (c *Client) DeleteTimeSlot(ctx context.Context, slotID string) error {
+	resp, err := c.http.R().SetContext(ctx).
+		Delete(fmt.Sprintf("/time-slots/%s", slotID))
+
+	if err != nil {
+		return fmt.Errorf("delete time slot failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("delete time slot", resp)
+	}
+
+	return nil
+}
+
+// GetLocationServices retrieves a location's services directory
+func (c *Client) GetLocationServices(ctx context.Context, locationID string) ([]LocationService, error) {
+	var response struct {
+		Services []LocationService `json:"services"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("/locations/%s/services", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get location services failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get location services", resp)
+	}
+
+	return response.Services, nil
+}
+
+// CreateLocationService lists a service at a location
+func (c *Client) CreateLocationService(ctx context.Context, locationID string, input LocationServiceInput) (*LocationService, error) {
+	var response struct {
+		Service LocationService `json:"service"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post(fmt.Sprintf("/locations/%s/services", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("create location service failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("create location service", resp)
+	}
+
+	return &response.Service, nil
+}
+
+// UpdateLocationService changes a listed service
+func (c *Client) UpdateLocationService(ctx context.Context, locationID, serviceID string, input LocationServiceInput) (*LocationService, error) {
+	var response struct {
+		Service LocationService `json:"service"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Patch(fmt.Sprintf("/locations/%s/services/%s", locationID, serviceID))
+
+	if err != nil {
+		return nil, fmt.Errorf("update location service failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("update location service", resp)
+	}
+
+	return &response.Service, nil
+}
+
+// DeleteLocationService removes a service from a location's directory
+func (c *Client) DeleteLocationService(ctx context.Context, locationID, serviceID string) error {
+	resp, err := c.http.R().SetContext(ctx).
+		Delete(fmt.Sprintf("/locations/%s/services/%s", locationID, serviceID))
+
+	if err != nil {
+		return fmt.Errorf("delete location service failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("delete location service", resp)
+	}
+
+	return nil
+}
+
+// GetLocationHolidays retrieves the days a location is closed
+func (c *Client) GetLocationHolidays(ctx context.Context, locationID, from, to string) (*LocationHolidays, error) {
+	var result LocationHolidays
+	req := c.http.R().SetContext(ctx).SetResult(&result)
+	if from != "" {
+		req.SetQueryParam("from", from)
+	}
+	if to != "" {
+		req.SetQueryParam("to", to)
+	}
+	resp, err := req.Get(fmt.Sprintf("/locations/%s/holidays", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get location holidays failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get location holidays", resp)
+	}
+
+	return &result, nil
+}
+
+// CreateLocationHoliday closes a location for a day
+func (c *Client) CreateLocationHoliday(ctx context.Context, locationID string, input LocationHolidayInput) (*LocationHoliday, error) {
+	var response struct {
+		Holiday LocationHoliday `json:"holiday"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post(fmt.Sprintf("/locations/%s/holidays", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("create location holiday failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("create location holiday", resp)
+	}
+
+	return &response.Holiday, nil
+}
+
+// DeleteLocationHoliday opens a location again on a holiday's day
+func (c *Client) DeleteLocationHoliday(ctx context.Context, locationID, holidayID string) error {
+	resp, err := c.http.R().SetContext(ctx).
+		Delete(fmt.Sprintf("/locations/%s/holidays/%s", locationID, holidayID))
+
+	if err != nil {
+		return fmt.Errorf("delete location holiday failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("delete location holiday", resp)
+	}
+
+	return nil
+}
+
+// SetClosedWeekdays sets the weekdays a location is closed every week
+func (c *Client) SetClosedWeekdays(ctx context.Context, locationID string, weekdays []int) error {
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(map[string][]int{"closedWeekdays": weekdays}).
+		Patch(fmt.Sprintf("/locations/%s", locationID))
+
+	if err != nil {
+		return fmt.Errorf("update location failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("update location", resp)
+	}
+
+	return nil
+}
+
+// GetPriorityRules retrieves who may bump bookings at a location
+func (c *Client) GetPriorityRules(ctx context.Context, locationID string) ([]PriorityRule, error) {
+	var response struct {
+		Rules []PriorityRule `json:"rules"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("/locations/%s/priority-rules", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get priority rules failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get priority rules", resp)
+	}
+
+	return response.Rules, nil
+}
+
+// CreatePriorityRule lets a user bump bookings at a location
+func (c *Client) CreatePriorityRule(ctx context.Context, locationID string, input PriorityRuleInput) (*PriorityRule, error) {
+	var result PriorityRuleResult
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&result).
+		Post(fmt.Sprintf("/locations/%s/priority-rules", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("create priority rule failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("create priority rule", resp)
+	}
+
+	return result.Rule, nil
+}
+
+// UpdatePriorityRule changes a priority rule
+func (c *Client) UpdatePriorityRule(ctx context.Context, locationID, ruleID string, update PriorityRuleUpdate) (*PriorityRule, error) {
+	var result PriorityRuleResult
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(update).
+		SetResult(&result).
+		Patch(fmt.Sprintf("/locations/%s/priority-rules/%s", locationID, ruleID))
+
+	if err != nil {
+		return nil, fmt.Errorf("update priority rule failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("update priority rule", resp)
+	}
+
+	return result.Rule, nil
+}
+
+// DeletePriorityRule removes a priority rule
+func (c *Client) DeletePriorityRule(ctx context.Context, locationID, ruleID string) error {
+	resp, err := c.http.R().SetContext(ctx).
+		Delete(fmt.Sprintf("/locations/%s/priority-rules/%s", locationID, ruleID))
+
+	if err != nil {
+		return fmt.Errorf("delete priority rule failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ResponseError("delete priority rule", resp)
+	}
+
+	return nil
+}
+
+// GetDisplacements retrieves the bookings bumped at a location, newest first
+func (c *Client) GetDisplacements(ctx context.Context, locationID string) ([]BookingDisplacement, error) {
+	var response struct {
+		Displacements []BookingDisplacement `json:"displacements"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("/locations/%s/displacements", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get displacements failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get displacements", resp)
+	}
+
+	return response.Displacements, nil
+}
+
+// BumpBooking moves someone else's booking to make way for a priority booking
+func (c *Client) BumpBooking(ctx context.Context, id string, input BumpInput) (*Booking, error) {
+	var response struct {
+		Booking Booking `json:"booking"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post(fmt.Sprintf("/bookings/%s/bump", id))
+
+	if err != nil {
+		return nil, fmt.Errorf("bump booking failed: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusConflict {
+		return nil, ReadConflictError(resp)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("bump booking", resp)
+	}
+
+	return &response.Booking, nil
+}
+
+// GetBookingComments retrieves the latest comments on a booking, oldest
+// first
+func (c *Client) GetBookingComments(ctx context.Context, bookingID string, params GetApiBookingsIdCommentsParams) ([]BookingComment, error) {
+	var response struct {
+		Comments []BookingComment `json:"comments"`
+	}
+	req := c.http.R().SetContext(ctx).SetResult(&response)
+	if params.Limit != nil {
+		req.SetQueryParam("limit", fmt.Sprint(*params.Limit))
+	}
+	resp, err := req.Get(fmt.Sprintf("/bookings/%s/comments", bookingID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get comments failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get comments", resp)
+	}
+
+	return response.Comments, nil
+}
+
+// AddBookingComment posts a comment to a booking's discussion thread
+func (c *Client) AddBookingComment(ctx context.Context, bookingID string, input BookingCommentInput) (*BookingComment, error) {
+	var response struct {
+		Comment BookingComment `json:"comment"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post(fmt.Sprintf("/bookings/%s/comments", bookingID))
+
+	if err != nil {
+		return nil, fmt.Errorf("post comment failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("post comment", resp)
+	}
+
+	return &response.Comment, nil
+}
+
+// ReportIssue reports an issue in a room to its location's managers
+func (c *Client) ReportIssue(ctx context.Context, input FeedbackInput) (*Feedback, error) {
+	var response struct {
+		Feedback Feedback `json:"feedback"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(input).
+		SetResult(&response).
+		Post("/feedback")
+
+	if err != nil {
+		return nil, fmt.Errorf("report issue failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, ResponseError("report issue", resp)
+	}
+
+	return &response.Feedback, nil
+}
+
+// GetMyIssues retrieves the issues the user reported, newest first. An
+// empty status returns them all.
+func (c *Client) GetMyIssues(ctx context.Context, status FeedbackStatus) ([]Feedback, error) {
+	var result FeedbackList
+	req := c.http.R().SetContext(ctx).SetResult(&result)
+	if status != "" {
+		req.SetQueryParam("status", string(status))
+	}
+	resp, err := req.Get("/feedback/mine")
+
+	if err != nil {
+		return nil, fmt.Errorf("get my issues failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get my issues", resp)
+	}
+
+	return result.Feedback, nil
+}
+
+// GetLocationIssues retrieves a location's issue queue, most severe first.
+// An empty status returns the issues still open or in progress.
+func (c *Client) GetLocationIssues(ctx context.Context, locationID string, status FeedbackStatus) ([]Feedback, error) {
+	var result FeedbackList
+	req := c.http.R().SetContext(ctx).SetResult(&result)
+	if status != "" {
+		req.SetQueryParam("status", string(status))
+	}
+	resp, err := req.Get(fmt.Sprintf("/locations/%s/feedback", locationID))
+
+	if err != nil {
+		return nil, fmt.Errorf("get location issues failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("get location issues", resp)
+	}
+
+	return result.Feedback, nil
+}
+
+// UpdateIssueStatus moves an issue along, saying what was done
+func (c *Client) UpdateIssueStatus(ctx context.Context, id string, update FeedbackStatusUpdate) (*Feedback, error) {
+	var response struct {
+		Feedback Feedback `json:"feedback"`
+	}
+	resp, err := c.http.R().SetContext(ctx).
+		SetBody(update).
+		SetResult(&response).
+		Patch(fmt.Sprintf("/feedback/%s/status", id))
+
+	if err != nil {
+		return nil, fmt.Errorf("update issue failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ResponseError("update issue", resp)
+	}
+
+	return &response.Feedback, nil
+}
+
+// Close is a no-op; the client holds no connections
+func (c *Client) Close() error {
+	return nil
+}
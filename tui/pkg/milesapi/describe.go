@@ -0,0 +1,87 @@
+package milesapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// formatDuration formats a duration the way both frontends show lengths,
+// e.g. "45m", "1h" or "1h 30m"
+func formatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// DescribeDurationLimits formats booking length limits, 0 meaning no
+// limit, e.g. "30m to 4h", "at most 1h" or "any length"
+func DescribeDurationLimits(minDuration, maxDuration time.Duration) string {
+	switch {
+	case minDuration > 0 && maxDuration > 0:
+		return formatDuration(minDuration) + " to " + formatDuration(maxDuration)
+	case minDuration > 0:
+		return "at least " + formatDuration(minDuration)
+	case maxDuration > 0:
+		return "at most " + formatDuration(maxDuration)
+	}
+	return "any length"
+}
+
+// DescribeBlocker names a booking holding a slot, e.g.
+// `"Standup" Mon Oct 20 14:00-14:30 (K. Hansen, pending)`. Titles and
+// owners the server withholds are left out.
+func DescribeBlocker(booking Booking) string {
+	title := booking.GetTitle()
+	if title == "" {
+		title = "Booked"
+	}
+	text := fmt.Sprintf("%q %s-%s", title,
+		booking.GetStartTime().Local().Format("Mon Jan 2 15:04"), booking.GetEndTime().Local().Format("15:04"))
+
+	var notes []string
+	if booking.GetUser().GetLastName() != "" {
+		notes = append(notes, booking.GetUser().ShortName())
+	}
+	if booking.GetStatus() == BookingStatusPENDING {
+		notes = append(notes, "pending")
+	}
+	if len(notes) > 0 {
+		text += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return text
+}
+
+// DescribeRule summarises what an approval rule auto-approves, e.g.
+// "up to 2h, outside 09:00-16:00 on weekdays"
+func DescribeRule(rule ApprovalRule) string {
+	var conditions []string
+	if rule.MaxDurationMinutes != nil {
+		conditions = append(conditions, "up to "+formatDuration(time.Duration(*rule.MaxDurationMinutes)*time.Minute))
+	}
+	if rule.OutsideCoreHours {
+		conditions = append(conditions, "outside "+rule.CoreStart+"-"+rule.CoreEnd+" on weekdays")
+	}
+	if len(conditions) == 0 {
+		return "any booking"
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// MeetingRooms leaves out the hot desks, which the rooms endpoint lists too
+func MeetingRooms(rooms []Room) []Room {
+	var meeting []Room
+	for _, room := range rooms {
+		if !room.IsDesk() {
+			meeting = append(meeting, room)
+		}
+	}
+	return meeting
+}
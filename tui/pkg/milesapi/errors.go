@@ -0,0 +1,160 @@
+package milesapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ConflictError is returned when a booking's time slot is already taken.
+// Booking is the booking holding it, when the server says which one.
+type ConflictError struct {
+	Message string
+	Booking *ConflictingBooking
+}
+
+// Error says who holds the slot and until when, e.g. "room is taken by
+// K. Hansen until 15:30", falling back to the server's message
+func (e *ConflictError) Error() string {
+	if e.Booking == nil {
+		return e.Message
+	}
+
+	owner := e.Booking.Owner.LastName
+	if initial := []rune(e.Booking.Owner.FirstName); len(initial) > 0 {
+		owner = string(initial[0]) + ". " + owner
+	}
+	start, end := e.Booking.StartTime.Local(), e.Booking.EndTime.Local()
+	until := end.Format("15:04")
+	if end.YearDay() != start.YearDay() || end.Year() != start.Year() {
+		until = end.Format("Mon Jan 2 15:04")
+	}
+	return fmt.Sprintf("room is taken by %s until %s", owner, until)
+}
+
+// PermissionError is returned when the server refuses a request and says
+// which roles may make it. LocationID is set when the permission is scoped
+// to one location, whose managers can grant it.
+type PermissionError struct {
+	Message       string
+	RequiredRoles []string
+	LocationID    string
+}
+
+func (e *PermissionError) Error() string {
+	if e.Message == "" {
+		return "insufficient permissions"
+	}
+	return e.Message
+}
+
+// RestrictedRoomError is returned when a room is restricted to departments
+// the user isn't in. RequestRoomAccess asks for access.
+type RestrictedRoomError struct {
+	Message     string
+	RoomID      string
+	Departments []string
+}
+
+func (e *RestrictedRoomError) Error() string {
+	if e.Message == "" {
+		return "this room is restricted to other departments"
+	}
+	return e.Message
+}
+
+// StatusError is a request the server turned down. Message is the server's
+// error message, or the HTTP status when it sent none.
+type StatusError struct {
+	Operation  string
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s failed: %s", e.Operation, e.Message)
+}
+
+// StatusCode returns the HTTP status the server turned a request down with,
+// or 0 when err isn't a response from the server
+func StatusCode(err error) int {
+	var statusErr *StatusError
+	var permissionErr *PermissionError
+	var conflictErr *ConflictError
+	switch {
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode
+	case errors.As(err, &permissionErr):
+		return http.StatusForbidden
+	case errors.As(err, &conflictErr):
+		return http.StatusConflict
+	}
+	return 0
+}
+
+// ResponseError prefers the server's error message over the HTTP status
+func ResponseError(operation string, resp *resty.Response) error {
+	if err := ReadPermissionError(operation, resp); err != nil {
+		return err
+	}
+	err := &StatusError{Operation: operation, StatusCode: resp.StatusCode(), Message: resp.Status()}
+	var errResp map[string]interface{}
+	json.Unmarshal(resp.Body(), &errResp)
+	if msg, ok := errResp["error"].(string); ok {
+		err.Message = msg
+	}
+	return err
+}
+
+// ReadPermissionError reads a 403 that says which roles would have been
+// allowed, and returns nil for any other response
+func ReadPermissionError(operation string, resp *resty.Response) error {
+	if resp.StatusCode() != http.StatusForbidden {
+		return nil
+	}
+	var body PermissionDenied
+	json.Unmarshal(resp.Body(), &body)
+	if body.RequiredRoles == nil {
+		return nil
+	}
+
+	err := &PermissionError{RequiredRoles: *body.RequiredRoles}
+	if body.Error != nil {
+		err.Message = *body.Error
+	}
+	if body.LocationId != nil {
+		err.LocationID = *body.LocationId
+	}
+	return fmt.Errorf("%s failed: %w", operation, err)
+}
+
+// ReadRestrictedRoomError reads a 403 for a room restricted to other
+// departments, and returns nil for any other response
+func ReadRestrictedRoomError(resp *resty.Response) error {
+	if resp.StatusCode() != http.StatusForbidden {
+		return nil
+	}
+	var body struct {
+		Error       string   `json:"error"`
+		RoomID      string   `json:"roomId"`
+		Departments []string `json:"departments"`
+	}
+	json.Unmarshal(resp.Body(), &body)
+	if body.Departments == nil {
+		return nil
+	}
+	return &RestrictedRoomError{Message: body.Error, RoomID: body.RoomID, Departments: body.Departments}
+}
+
+// ReadConflictError reads the 409 body of a booking whose slot is taken
+func ReadConflictError(resp *resty.Response) error {
+	var body BookingConflict
+	json.Unmarshal(resp.Body(), &body)
+	if body.Error == "" {
+		body.Error = "room is not available for the selected time slot"
+	}
+	return &ConflictError{Message: body.Error, Booking: body.Conflict}
+}
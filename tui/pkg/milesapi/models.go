@@ -0,0 +1,571 @@
+package milesapi
+
+import "time"
+
+// The generated types leave optional fields as pointers. The getters below
+// return a field's zero value when it, or the value holding it, is nil, so
+// frontends can read nested fields like b.GetRoom().GetName() directly.
+
+// value returns what p points to, or the zero value when p is nil
+func value[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func (b *Booking) GetId() string {
+	if b == nil {
+		return ""
+	}
+	return value(b.Id)
+}
+
+func (b *Booking) GetRoomId() string {
+	if b == nil {
+		return ""
+	}
+	return value(b.RoomId)
+}
+
+func (b *Booking) GetUserId() string {
+	if b == nil {
+		return ""
+	}
+	return value(b.UserId)
+}
+
+func (b *Booking) GetTitle() string {
+	if b == nil {
+		return ""
+	}
+	return value(b.Title)
+}
+
+func (b *Booking) GetDescription() string {
+	if b == nil {
+		return ""
+	}
+	return value(b.Description)
+}
+
+func (b *Booking) GetStartTime() time.Time {
+	if b == nil {
+		return time.Time{}
+	}
+	return value(b.StartTime)
+}
+
+func (b *Booking) GetEndTime() time.Time {
+	if b == nil {
+		return time.Time{}
+	}
+	return value(b.EndTime)
+}
+
+func (b *Booking) GetStatus() BookingStatus {
+	if b == nil {
+		return ""
+	}
+	return value(b.Status)
+}
+
+func (b *Booking) GetBufferMinutes() int {
+	if b == nil {
+		return 0
+	}
+	return value(b.BufferMinutes)
+}
+
+func (b *Booking) GetSetup() RoomSetup {
+	if b == nil {
+		return ""
+	}
+	return value(b.Setup)
+}
+
+func (b *Booking) GetSetupNotes() string {
+	if b == nil {
+		return ""
+	}
+	return value(b.SetupNotes)
+}
+
+func (b *Booking) GetCreatedAt() time.Time {
+	if b == nil {
+		return time.Time{}
+	}
+	return value(b.CreatedAt)
+}
+
+func (b *Booking) GetUpdatedAt() time.Time {
+	if b == nil {
+		return time.Time{}
+	}
+	return value(b.UpdatedAt)
+}
+
+func (b *Booking) GetRoom() *Room {
+	if b == nil {
+		return nil
+	}
+	return b.Room
+}
+
+func (b *Booking) GetUser() *User {
+	if b == nil {
+		return nil
+	}
+	return b.User
+}
+
+// HasSetupRequest reports whether facilities need to prepare the room
+func (b *Booking) HasSetupRequest() bool {
+	return b.GetSetup() != "" || b.GetSetupNotes() != ""
+}
+
+func (r *Room) GetId() string {
+	if r == nil {
+		return ""
+	}
+	return value(r.Id)
+}
+
+func (r *Room) GetName() string {
+	if r == nil {
+		return ""
+	}
+	return value(r.Name)
+}
+
+func (r *Room) GetLocationId() string {
+	if r == nil {
+		return ""
+	}
+	return value(r.LocationId)
+}
+
+func (r *Room) GetLocation() *Location {
+	if r == nil {
+		return nil
+	}
+	return r.Location
+}
+
+func (r *Room) GetCapacity() int {
+	if r == nil {
+		return 0
+	}
+	return value(r.Capacity)
+}
+
+func (r *Room) GetAmenities() []string {
+	if r == nil {
+		return nil
+	}
+	return value(r.Amenities)
+}
+
+func (r *Room) GetDescription() string {
+	if r == nil {
+		return ""
+	}
+	return value(r.Description)
+}
+
+func (r *Room) GetIsActive() bool {
+	if r == nil {
+		return false
+	}
+	return value(r.IsActive)
+}
+
+func (r *Room) GetMinDurationMinutes() int {
+	if r == nil {
+		return 0
+	}
+	return value(r.MinDurationMinutes)
+}
+
+func (r *Room) GetMaxDurationMinutes() int {
+	if r == nil {
+		return 0
+	}
+	return value(r.MaxDurationMinutes)
+}
+
+func (r *Room) GetType() RoomType {
+	if r == nil {
+		return ""
+	}
+	return value(r.Type)
+}
+
+func (r *Room) GetFloor() string {
+	if r == nil {
+		return ""
+	}
+	return value(r.Floor)
+}
+
+func (r *Room) GetWing() string {
+	if r == nil {
+		return ""
+	}
+	return value(r.Wing)
+}
+
+func (r *Room) GetDepartments() []string {
+	if r == nil {
+		return nil
+	}
+	return value(r.Departments)
+}
+
+func (r *Room) GetCreatedAt() time.Time {
+	if r == nil {
+		return time.Time{}
+	}
+	return value(r.CreatedAt)
+}
+
+// IsDesk reports whether the room is a hot desk rather than a meeting room
+func (r *Room) IsDesk() bool {
+	return r.GetType() == DESK
+}
+
+// Locked reports whether the room is restricted to departments the user
+// isn't in. Servers from before restricted rooms leave canBook out.
+func (r *Room) Locked() bool {
+	return r != nil && r.CanBook != nil && !*r.CanBook
+}
+
+// DurationLimits returns the shortest and longest booking the room takes,
+// 0 meaning no limit
+func (r *Room) DurationLimits() (minDuration, maxDuration time.Duration) {
+	return time.Duration(r.GetMinDurationMinutes()) * time.Minute, time.Duration(r.GetMaxDurationMinutes()) * time.Minute
+}
+
+// AllowsDuration reports whether the room's booking length limits allow d
+func (r *Room) AllowsDuration(d time.Duration) bool {
+	minDuration, maxDuration := r.DurationLimits()
+	return (minDuration <= 0 || d >= minDuration) && (maxDuration <= 0 || d <= maxDuration)
+}
+
+func (l *Location) GetId() string {
+	if l == nil {
+		return ""
+	}
+	return value(l.Id)
+}
+
+func (l *Location) GetName() string {
+	if l == nil {
+		return ""
+	}
+	return value(l.Name)
+}
+
+func (l *Location) GetAddress() string {
+	if l == nil {
+		return ""
+	}
+	return value(l.Address)
+}
+
+func (l *Location) GetCity() string {
+	if l == nil {
+		return ""
+	}
+	return value(l.City)
+}
+
+func (l *Location) GetCountry() string {
+	if l == nil {
+		return ""
+	}
+	return value(l.Country)
+}
+
+func (l *Location) GetTimezone() string {
+	if l == nil {
+		return ""
+	}
+	return value(l.Timezone)
+}
+
+func (l *Location) GetDescription() string {
+	if l == nil {
+		return ""
+	}
+	return value(l.Description)
+}
+
+func (l *Location) GetRequiresApproval() bool {
+	if l == nil {
+		return false
+	}
+	return value(l.RequiresApproval)
+}
+
+func (l *Location) GetClosedWeekdays() []int {
+	if l == nil {
+		return nil
+	}
+	return value(l.ClosedWeekdays)
+}
+
+func (s *LocationService) GetRoomId() string {
+	if s == nil {
+		return ""
+	}
+	return value(s.RoomId)
+}
+
+func (s *LocationService) GetDescription() string {
+	if s == nil {
+		return ""
+	}
+	return value(s.Description)
+}
+
+func (s *LocationService) GetContact() string {
+	if s == nil {
+		return ""
+	}
+	return value(s.Contact)
+}
+
+func (u *User) GetId() string {
+	if u == nil {
+		return ""
+	}
+	return value(u.Id)
+}
+
+func (u *User) GetEmail() string {
+	if u == nil {
+		return ""
+	}
+	return string(value(u.Email))
+}
+
+func (u *User) GetFirstName() string {
+	if u == nil {
+		return ""
+	}
+	return value(u.FirstName)
+}
+
+func (u *User) GetLastName() string {
+	if u == nil {
+		return ""
+	}
+	return value(u.LastName)
+}
+
+func (u *User) GetRole() UserRole {
+	if u == nil {
+		return ""
+	}
+	return value(u.Role)
+}
+
+func (u *User) GetDepartment() string {
+	if u == nil {
+		return ""
+	}
+	return value(u.Department)
+}
+
+// FullName returns the user's full name
+func (u *User) FullName() string {
+	return u.GetFirstName() + " " + u.GetLastName()
+}
+
+// ShortName returns the user's initial and last name, e.g. "K. Hansen"
+func (u *User) ShortName() string {
+	initial := []rune(u.GetFirstName())
+	if len(initial) == 0 {
+		return u.GetLastName()
+	}
+	return string(initial[0]) + ". " + u.GetLastName()
+}
+
+// roleRank orders roles from least to most privileged
+var roleRank = map[UserRole]int{
+	USER:    1,
+	MANAGER: 2,
+	ADMIN:   3,
+}
+
+// Allows reports whether r has at least the privileges of required
+func (r UserRole) Allows(required UserRole) bool {
+	return roleRank[r] > 0 && roleRank[r] >= roleRank[required]
+}
+
+// LesserRole returns the less privileged of two roles. An unknown role
+// counts as no privileges at all.
+func LesserRole(a, b UserRole) UserRole {
+	if roleRank[a] <= roleRank[b] {
+		return a
+	}
+	return b
+}
+
+// RoomSetups lists the layouts in the order booking forms offer them
+var RoomSetups = []RoomSetup{THEATRE, BOARDROOM, USHAPE}
+
+// Label returns the setup as shown to people, e.g. "U-shape"
+func (s RoomSetup) Label() string {
+	switch s {
+	case THEATRE:
+		return "Theatre"
+	case BOARDROOM:
+		return "Boardroom"
+	case USHAPE:
+		return "U-shape"
+	case "":
+		return "None"
+	}
+	return string(s)
+}
+
+// Exceeds returns whether adding extraHours would go over the quota
+func (q *Quota) Exceeds(extraHours float64) bool {
+	limit, used := float64(q.LimitHours), float64(q.UsedHours)
+	return limit > 0 && used+extraHours > limit
+}
+
+// Optional features a deployment can turn off
+const (
+	FeatureApprovals = "approvals"
+	FeatureDesks     = "desks"
+)
+
+// Features maps feature names to whether they are on
+type Features map[string]bool
+
+// Enabled reports whether a feature is on. Features the server didn't
+// list, including every feature on servers from before feature flags,
+// are on.
+func (f Features) Enabled(name string) bool {
+	enabled, ok := f[name]
+	return !ok || enabled
+}
+
+// zone is the location's time zone, which its days are in
+func (h *LocationHolidays) zone() *time.Location {
+	if zone, err := time.LoadLocation(h.Timezone); err == nil {
+		return zone
+	}
+	return time.UTC
+}
+
+// ClosedOn returns why the location is closed on the day at falls on there,
+// e.g. "Christmas Day" or "closed on Saturdays", or "" when it's open
+func (h *LocationHolidays) ClosedOn(at time.Time) string {
+	if h == nil {
+		return ""
+	}
+	at = at.In(h.zone())
+	day := at.Format(time.DateOnly)
+	for _, holiday := range h.Holidays {
+		if holiday.Date == day {
+			return holiday.Name
+		}
+	}
+	for _, weekday := range h.ClosedWeekdays {
+		if weekday == int(at.Weekday()) {
+			return "closed on " + at.Weekday().String() + "s"
+		}
+	}
+	return ""
+}
+
+// Closure explains the first closed day [start, end) touches, like the
+// server's "Office closed Dec 25, 2025 (Christmas Day)", or returns "" when
+// the location is open throughout
+func (h *LocationHolidays) Closure(start, end time.Time) string {
+	if h == nil {
+		return ""
+	}
+	last := end.Add(-time.Nanosecond)
+	if last.Before(start) {
+		last = start
+	}
+	lastDay := last.In(h.zone()).Format(time.DateOnly)
+	for day := start.In(h.zone()); ; day = day.AddDate(0, 0, 1) {
+		if reason := h.ClosedOn(day); reason != "" {
+			return "Office closed " + day.Format("Jan 2, 2006") + " (" + reason + ")"
+		}
+		if day.Format(time.DateOnly) >= lastDay {
+			return ""
+		}
+	}
+}
+
+// Name returns what is followed: the room and its location, or the colleague
+func (s Subscription) Name() string {
+	if s.Room != nil {
+		return s.Room.Name + " (" + s.Room.Location.Name + ")"
+	}
+	if s.FollowedUser != nil {
+		return s.FollowedUser.FullName()
+	}
+	return s.Id
+}
+
+// Key identifies an activity item across polls
+func (a ActivityItem) Key() string {
+	return string(a.Type) + "/" + a.Booking.Id
+}
+
+// Label returns the status as shown to people, e.g. "In progress"
+func (s FeedbackStatus) Label() string {
+	switch s {
+	case OPEN:
+		return "Open"
+	case INPROGRESS:
+		return "In progress"
+	case RESOLVED:
+		return "Resolved"
+	case DISMISSED:
+		return "Dismissed"
+	}
+	return string(s)
+}
+
+// Closed reports whether managers are done with the issue
+func (s FeedbackStatus) Closed() bool {
+	return s == RESOLVED || s == DISMISSED
+}
+
+// FeedbackSeverities lists the severities from least to most severe
+var FeedbackSeverities = []FeedbackSeverity{LOW, MEDIUM, HIGH}
+
+// Label returns the severity as shown to people, e.g. "High"
+func (s FeedbackSeverity) Label() string {
+	switch s {
+	case LOW:
+		return "Low"
+	case MEDIUM:
+		return "Medium"
+	case HIGH:
+		return "High"
+	}
+	return string(s)
+}
+
+// Input converts a rule back into the input that recreates it
+func (r ApprovalRule) Input() ApprovalRuleInput {
+	return ApprovalRuleInput{
+		Name:               r.Name,
+		MaxDurationMinutes: r.MaxDurationMinutes,
+		OutsideCoreHours:   &r.OutsideCoreHours,
+		CoreStart:          &r.CoreStart,
+		CoreEnd:            &r.CoreEnd,
+		Enabled:            &r.Enabled,
+	}
+}
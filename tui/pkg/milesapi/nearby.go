@@ -0,0 +1,125 @@
+package milesapi
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Distances between rooms at the same location. They only rank rooms, so
+// the steps just need to order sensibly: the same wing beats the same
+// floor, which beats a floor away, and rooms without a floor come last.
+const (
+	otherWingDistance    = 1
+	floorDistance        = 2
+	unknownFloorDistance = 10
+)
+
+// RoomDistance estimates how far apart two rooms at the same location are.
+// Rooms at different locations are never near each other.
+func RoomDistance(from, to Room) (int, bool) {
+	if from.GetLocationId() != to.GetLocationId() {
+		return 0, false
+	}
+
+	fromFloor, toFloor := strings.TrimSpace(from.GetFloor()), strings.TrimSpace(to.GetFloor())
+	distance := unknownFloorDistance
+	if a, ok := parseFloor(fromFloor); ok {
+		if b, ok := parseFloor(toFloor); ok {
+			distance = floorDistance * max(a-b, b-a)
+		}
+	} else if fromFloor != "" && strings.EqualFold(fromFloor, toFloor) {
+		distance = 0
+	}
+
+	if distance == 0 && !strings.EqualFold(strings.TrimSpace(from.GetWing()), strings.TrimSpace(to.GetWing())) {
+		distance = otherWingDistance
+	}
+	return distance, true
+}
+
+// parseFloor reads a floor as a level, counting ground floors as 0 and
+// basements as -1, so "2", "2nd" and "Floor 2" are all level 2
+func parseFloor(floor string) (int, bool) {
+	floor = strings.ToLower(strings.TrimSpace(floor))
+	switch floor {
+	case "":
+		return 0, false
+	case "g", "gf", "ground", "ground floor":
+		return 0, true
+	case "b", "basement":
+		return -1, true
+	}
+	floor = strings.TrimSpace(strings.TrimPrefix(floor, "floor"))
+	end := 0
+	for end < len(floor) && (floor[end] >= '0' && floor[end] <= '9' || end == 0 && floor[end] == '-') {
+		end++
+	}
+	level, err := strconv.Atoi(floor[:end])
+	if err != nil {
+		return 0, false
+	}
+	return level, true
+}
+
+// DescribeNearness says where a room is relative to another one, e.g.
+// "same floor", or "" when it can't tell
+func DescribeNearness(from, to Room) string {
+	distance, ok := RoomDistance(from, to)
+	switch {
+	case !ok || distance >= unknownFloorDistance:
+		return ""
+	case distance == 0 && to.GetWing() != "":
+		return "same floor, same wing"
+	case distance <= otherWingDistance:
+		return "same floor"
+	default:
+		return "floor " + to.GetFloor()
+	}
+}
+
+// NearbyRooms picks up to limit of rooms, the location's rooms, near room
+// that could stand in for it from start to end, nearest first. Rooms that
+// seat at least as many come before smaller ones at the same distance.
+// Inactive and locked rooms, desks standing in for meeting rooms (and the
+// other way round) and rooms that don't take a booking this long are left
+// out. free is asked about candidates in order until limit say yes.
+func NearbyRooms(room Room, rooms []Room, start, end time.Time, limit int, free func(Room) bool) []Room {
+	type candidate struct {
+		room     Room
+		distance int
+	}
+	var candidates []candidate
+	for _, other := range rooms {
+		if other.GetId() == room.GetId() || other.IsDesk() != room.IsDesk() || other.Locked() ||
+			(other.IsActive != nil && !*other.IsActive) || !other.AllowsDuration(end.Sub(start)) {
+			continue
+		}
+		if distance, ok := RoomDistance(room, other); ok {
+			candidates = append(candidates, candidate{other, distance})
+		}
+	}
+
+	seats := room.GetCapacity()
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		if fitsI, fitsJ := candidates[i].room.GetCapacity() >= seats, candidates[j].room.GetCapacity() >= seats; fitsI != fitsJ {
+			return fitsI
+		}
+		return candidates[i].room.GetName() < candidates[j].room.GetName()
+	})
+
+	var nearby []Room
+	for _, c := range candidates {
+		if len(nearby) == limit {
+			break
+		}
+		if free(c.room) {
+			nearby = append(nearby, c.room)
+		}
+	}
+	return nearby
+}
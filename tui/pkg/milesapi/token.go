@@ -0,0 +1,55 @@
+package milesapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// TokenClaims are the JWT claims clients look at
+type TokenClaims struct {
+	UserID string   `json:"userId"`
+	Role   UserRole `json:"role"`
+}
+
+// DecodeToken reads a JWT's claims without verifying it. The server still
+// decides what the token may do; the claims only let clients offer what it
+// will allow. It returns false when the token can't be decoded.
+func DecodeToken(token string) (TokenClaims, bool) {
+	var claims TokenClaims
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, false
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, false
+	}
+	return claims, true
+}
+
+// TokenRole reads the role claim from a JWT without verifying it. It
+// returns "" when the token can't be decoded or names a role this client
+// doesn't know.
+func TokenRole(token string) UserRole {
+	claims, ok := DecodeToken(token)
+	if !ok {
+		return ""
+	}
+	if _, ok := roleRank[claims.Role]; !ok {
+		return ""
+	}
+	return claims.Role
+}
+
+// TokenUserID reads the user ID claim from a JWT without verifying it, or
+// returns "" when the token can't be decoded
+func TokenUserID(token string) string {
+	claims, _ := DecodeToken(token)
+	return claims.UserID
+}
@@ -0,0 +1,93 @@
+package milesapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The API versions this client speaks: from MinAPIVersion up to, but not
+// including, the next major version after MaxAPIMajor. Servers add to the
+// API in minor versions, so a client works with newer minor versions than
+// it knows; a new major version may break it.
+const (
+	MinAPIVersion = "1.0.0"
+	MaxAPIMajor   = 1
+)
+
+// SupportedAPIVersions describes the range for messages, e.g. ">= 1.0.0, < 2"
+func SupportedAPIVersions() string {
+	return fmt.Sprintf(">= %s, < %d", MinAPIVersion, MaxAPIMajor+1)
+}
+
+// IncompatibleError is returned by CheckAPIVersion when the server speaks an
+// API version outside what this client supports
+type IncompatibleError struct {
+	Version string
+
+	// Newer is set when the server is ahead of the client, so upgrading the
+	// client helps; otherwise the server is too old for it
+	Newer bool
+}
+
+func (e *IncompatibleError) Error() string {
+	if e.Newer {
+		return fmt.Sprintf("the server speaks API %s, newer than this client supports (%s)", e.Version, SupportedAPIVersions())
+	}
+	return fmt.Sprintf("the server speaks API %s, older than this client supports (%s)", e.Version, SupportedAPIVersions())
+}
+
+// CheckAPIVersion returns an *IncompatibleError when version is outside
+// the supported range. Versions that can't be read, such as a development
+// server's, pass.
+func CheckAPIVersion(version string) error {
+	v, ok := parseAPIVersion(version)
+	if !ok {
+		return nil
+	}
+	least, _ := parseAPIVersion(MinAPIVersion)
+
+	switch {
+	case v[0] > MaxAPIMajor:
+		return &IncompatibleError{Version: version, Newer: true}
+	case compareAPIVersions(v, least) < 0:
+		return &IncompatibleError{Version: version}
+	}
+	return nil
+}
+
+// parseAPIVersion reads "1.2.3" or "v1.2" as [major, minor, patch],
+// ignoring any "-beta" style suffix
+func parseAPIVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	fields := strings.Split(version, ".")
+	if version == "" || len(fields) > len(parts) {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// compareAPIVersions returns -1, 0 or 1 as a is lower than, equal to or
+// higher than b
+func compareAPIVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,240 @@
+// Package office works out which office the user is in from the network
+// they are on, so booking can start at the right location. Offices are
+// described in ~/.miles-offices.yaml, shared by the CLI and the TUI:
+//
+//	# Optional: also match the public address this URL returns as plain
+//	# text. Without it only the machine's own addresses are checked.
+//	public_ip_url: https://api.ipify.org
+//	offices:
+//	  - location: Oslo            # location ID or name
+//	    ssids: [Miles, Miles-Guest]
+//	    ip_ranges: [10.20.0.0/16, 203.0.113.7]
+//
+// Wi-Fi names are checked before addresses, and offices in file order.
+package office
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the offices file in the home directory
+const FileName = ".miles-offices.yaml"
+
+// lookupTimeout bounds the public address lookup so detection never
+// holds up booking for long
+const lookupTimeout = 2 * time.Second
+
+// Office maps a location to the networks that identify it
+type Office struct {
+	Location string   `yaml:"location"`
+	SSIDs    []string `yaml:"ssids"`
+	IPRanges []string `yaml:"ip_ranges"`
+
+	networks []*net.IPNet
+}
+
+// Config is the offices file
+type Config struct {
+	PublicIPURL string   `yaml:"public_ip_url"`
+	Offices     []Office `yaml:"offices"`
+}
+
+// Match is a detected office and how it was recognised
+type Match struct {
+	// Location is the location ID or name from the offices file
+	Location string
+	// Reason says what matched, e.g. `Wi-Fi "Miles"`
+	Reason string
+}
+
+// DefaultPath returns ~/.miles-offices.yaml
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, FileName)
+}
+
+// Load reads an offices file. A missing file is not an error: it returns
+// nil, and detection is off.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for i := range cfg.Offices {
+		office := &cfg.Offices[i]
+		if office.Location == "" {
+			return nil, fmt.Errorf("%s: office %d has no location", path, i+1)
+		}
+		for _, r := range office.IPRanges {
+			network, err := parseRange(r)
+			if err != nil {
+				return nil, fmt.Errorf("%s: office %q: %w", path, office.Location, err)
+			}
+			office.networks = append(office.networks, network)
+		}
+	}
+	return &cfg, nil
+}
+
+// parseRange accepts a CIDR range or a single address
+func parseRange(r string) (*net.IPNet, error) {
+	r = strings.TrimSpace(r)
+	if !strings.Contains(r, "/") {
+		ip := net.ParseIP(r)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP range %q", r)
+		}
+		bits := 128
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, network, err := net.ParseCIDR(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP range %q", r)
+	}
+	return network, nil
+}
+
+// Detect returns the office the current network belongs to, or nil when
+// none matches
+func (c *Config) Detect(ctx context.Context) *Match {
+	if c == nil || len(c.Offices) == 0 {
+		return nil
+	}
+
+	if ssid := CurrentSSID(ctx); ssid != "" {
+		for _, office := range c.Offices {
+			for _, s := range office.SSIDs {
+				if strings.EqualFold(s, ssid) {
+					return &Match{Location: office.Location, Reason: fmt.Sprintf("Wi-Fi %q", ssid)}
+				}
+			}
+		}
+	}
+
+	addrs := localAddrs()
+	if c.PublicIPURL != "" {
+		if ip, err := publicAddr(ctx, c.PublicIPURL); err == nil {
+			addrs = append([]net.IP{ip}, addrs...)
+		}
+	}
+	for _, office := range c.Offices {
+		for _, network := range office.networks {
+			for _, ip := range addrs {
+				if network.Contains(ip) {
+					return &Match{Location: office.Location, Reason: fmt.Sprintf("IP %s in %s", ip, network)}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CurrentSSID returns the name of the Wi-Fi network the machine is on, or
+// "" when it isn't on Wi-Fi or the name can't be read
+func CurrentSSID(ctx context.Context) string {
+	run := func(name string, args ...string) string {
+		out, err := exec.CommandContext(ctx, name, args...).Output()
+		if err != nil {
+			return ""
+		}
+		return string(out)
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		if ssid := strings.TrimSpace(run("iwgetid", "-r")); ssid != "" {
+			return ssid
+		}
+		// nmcli lists visible networks as "yes:Name" for the active one
+		for _, line := range strings.Split(run("nmcli", "-t", "-f", "active,ssid", "dev", "wifi"), "\n") {
+			if ssid, ok := strings.CutPrefix(line, "yes:"); ok {
+				return strings.TrimSpace(ssid)
+			}
+		}
+	case "darwin":
+		out := run("networksetup", "-getairportnetwork", "en0")
+		if _, ssid, ok := strings.Cut(out, "Network: "); ok {
+			return strings.TrimSpace(ssid)
+		}
+	case "windows":
+		for _, line := range strings.Split(run("netsh", "wlan", "show", "interfaces"), "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if ok && strings.TrimSpace(key) == "SSID" {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
+
+// localAddrs returns the machine's unicast addresses, loopback excluded
+func localAddrs() []net.IP {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	var ips []net.IP
+	for _, addr := range addrs {
+		if network, ok := addr.(*net.IPNet); ok && !network.IP.IsLoopback() {
+			ips = append(ips, network.IP)
+		}
+	}
+	return ips
+}
+
+// publicAddr asks url for the address requests come from, returned as text
+func publicAddr(ctx context.Context, url string) (net.IP, error) {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, fmt.Errorf("%s did not return an IP address", url)
+	}
+	return ip, nil
+}
@@ -0,0 +1,172 @@
+// Package recurrence expands a repeating booking into its occurrences, for
+// `miles book --repeat` and the TUI booking form's Repeat step:
+//
+//	daily      every day
+//	weekdays   Monday to Friday
+//	weekly     the same weekday every week
+//	biweekly   the same weekday every other week
+//	monthly    the same day every month; months without that day are skipped
+//
+// A series ends after a number of occurrences or on a last day, which is
+// included. Occurrences keep the first one's wall-clock times and length,
+// across daylight saving changes too.
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is how often a series repeats
+type Frequency string
+
+const (
+	Daily    Frequency = "daily"
+	Weekdays Frequency = "weekdays"
+	Weekly   Frequency = "weekly"
+	Biweekly Frequency = "biweekly"
+	Monthly  Frequency = "monthly"
+)
+
+// Frequencies are the frequencies in the order they are offered
+var Frequencies = []Frequency{Daily, Weekdays, Weekly, Biweekly, Monthly}
+
+// MaxOccurrences is the most bookings one series makes, a year of weekly
+// meetings
+const MaxOccurrences = 52
+
+// Occurrence is one booking of a series
+type Occurrence struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ParseFrequency parses a frequency name; "every 2 weeks" and "fortnightly"
+// are taken for biweekly
+func ParseFrequency(s string) (Frequency, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "daily", "day":
+		return Daily, nil
+	case "weekdays", "weekday", "workdays":
+		return Weekdays, nil
+	case "weekly", "week":
+		return Weekly, nil
+	case "biweekly", "fortnightly", "every 2 weeks":
+		return Biweekly, nil
+	case "monthly", "month":
+		return Monthly, nil
+	}
+	return "", fmt.Errorf("unknown repeat %q: use daily, weekdays, weekly, biweekly or monthly", s)
+}
+
+// ParseEnd reads how a series ends: a number of occurrences ("10") or a
+// last day ("2025-12-31"). Exactly one of count and until is set.
+func ParseEnd(s string) (count int, until time.Time, err error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return 0, time.Time{}, fmt.Errorf("a series needs at least one occurrence")
+		}
+		return n, time.Time{}, nil
+	}
+	until, err = time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid end %q: use a number of times, e.g. 10, or a last day, e.g. 2025-12-31", s)
+	}
+	return 0, until, nil
+}
+
+// Describe says how often a series starting at start repeats, e.g.
+// "every Monday" or "on the 15th of every month"
+func (f Frequency) Describe(start time.Time) string {
+	switch f {
+	case Daily:
+		return "every day"
+	case Weekdays:
+		return "every weekday"
+	case Weekly:
+		return "every " + start.Weekday().String()
+	case Biweekly:
+		return "every other " + start.Weekday().String()
+	case Monthly:
+		return "on the " + ordinal(start.Day()) + " of every month"
+	}
+	return string(f)
+}
+
+// Expand returns the occurrences of a series whose first occurrence is
+// [start, end): count of them when count is set, else those starting on
+// or before until's day. The first is included even on a weekend for
+// Weekdays. A series longer than MaxOccurrences is an error.
+func Expand(f Frequency, start, end time.Time, count int, until time.Time) ([]Occurrence, error) {
+	if count == 0 && until.IsZero() {
+		return nil, fmt.Errorf("a series needs an end: a number of times or a last day")
+	}
+	if count > MaxOccurrences {
+		return nil, fmt.Errorf("a series can have at most %d occurrences", MaxOccurrences)
+	}
+	if !until.IsZero() && dayOf(until) < dayOf(start) {
+		return nil, fmt.Errorf("the last day %s is before the first occurrence", until.Format(time.DateOnly))
+	}
+	length := end.Sub(start)
+
+	occurrences := []Occurrence{{Start: start, End: end}}
+	// Monthly series skip months without the day; a year of steps is enough
+	// for any series that stays under MaxOccurrences
+	for i := 1; i <= 366 && (count == 0 || len(occurrences) < count); i++ {
+		next, ok := f.nth(start, i)
+		if !ok {
+			continue
+		}
+		if !until.IsZero() && dayOf(next) > dayOf(until) {
+			break
+		}
+		if len(occurrences) == MaxOccurrences {
+			return nil, fmt.Errorf("the series has more than %d occurrences; end it sooner", MaxOccurrences)
+		}
+		occurrences = append(occurrences, Occurrence{Start: next, End: next.Add(length)})
+	}
+	return occurrences, nil
+}
+
+// nth returns the i-th start after start, and false when the series skips it
+func (f Frequency) nth(start time.Time, i int) (time.Time, bool) {
+	switch f {
+	case Daily:
+		return start.AddDate(0, 0, i), true
+	case Weekdays:
+		next := start.AddDate(0, 0, i)
+		return next, next.Weekday() != time.Saturday && next.Weekday() != time.Sunday
+	case Weekly:
+		return start.AddDate(0, 0, 7*i), true
+	case Biweekly:
+		return start.AddDate(0, 0, 14*i), true
+	case Monthly:
+		// AddDate turns Jan 31 + 1 month into Mar 3; such months are skipped
+		next := start.AddDate(0, i, 0)
+		return next, next.Day() == start.Day()
+	}
+	return time.Time{}, false
+}
+
+// dayOf is a time's day, YYYY-MM-DD, for comparing days
+func dayOf(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+// ordinal formats a day of the month, e.g. 1st, 22nd or 15th
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
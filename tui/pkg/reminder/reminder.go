@@ -0,0 +1,195 @@
+// Package reminder decides when to remind the user of their bookings: one
+// about to start, one a manager has just approved, and one about to end,
+// saying whether someone else has the room next. milesd runs a Scheduler
+// as it polls and caches what is due for the TUI, which runs one itself
+// only while no daemon is keeping the cache.
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+// Lead is how long before a booking starts it is reminded of
+const Lead = 10 * time.Minute
+
+// HandoverLead is how long before a booking ends the room's next booking
+// is looked up
+const HandoverLead = 5 * time.Minute
+
+// HandoverWindow is how soon after a booking ends the next one has to
+// start to count as taking the room over
+const HandoverWindow = 15 * time.Minute
+
+// approvedFor is how long an approval stays news
+const approvedFor = Lead
+
+// Kind is what a reminder is about
+type Kind string
+
+const (
+	Starting Kind = "starting" // The booking starts within Lead
+	Approved Kind = "approved" // A manager approved the pending booking
+	Ending   Kind = "ending"   // The booking ends within HandoverLead
+)
+
+// Reminder is one thing to tell the user about one of their bookings
+type Reminder struct {
+	Kind    Kind             `json:"kind"`
+	At      time.Time        `json:"at"`
+	Booking milesapi.Booking `json:"booking"`
+
+	// Successor is who has the room after an Ending booking, or nil when
+	// the room is free after it
+	Successor *milesapi.Booking `json:"successor,omitempty"`
+}
+
+// Key identifies a reminder: each booking gets each kind once
+func (r Reminder) Key() string {
+	return string(r.Kind) + " " + r.Booking.GetId()
+}
+
+// Expired reports whether the reminder is no longer worth showing at now:
+// its booking has started or ended, or the approval is old news
+func (r Reminder) Expired(now time.Time) bool {
+	switch r.Kind {
+	case Starting:
+		return !now.Before(r.Booking.GetStartTime())
+	case Ending:
+		return !now.Before(r.Booking.GetEndTime())
+	}
+	return now.Sub(r.At) > approvedFor
+}
+
+// Text says what the reminder is about, e.g. `"Standup" in Teamrommet
+// starts at 14:00`
+func (r Reminder) Text() string {
+	booking := r.Booking
+	switch r.Kind {
+	case Starting:
+		return fmt.Sprintf("“%s” in %s starts at %s", booking.GetTitle(),
+			booking.GetRoom().GetName(), booking.GetStartTime().Local().Format("15:04"))
+	case Approved:
+		return fmt.Sprintf("%s approved for “%s” (%s)", booking.GetRoom().GetName(),
+			booking.GetTitle(), booking.GetStartTime().Local().Format("Mon 15:04"))
+	}
+	if r.Successor == nil {
+		return fmt.Sprintf("“%s” ends at %s and %s is free after", booking.GetTitle(),
+			booking.GetEndTime().Local().Format("15:04"), booking.GetRoom().GetName())
+	}
+	who := r.Successor.GetUser().GetFirstName()
+	if who == "" {
+		who = "Someone else"
+	}
+	return fmt.Sprintf("Wrap up: %s has this room at %s", who, r.Successor.GetStartTime().Local().Format("15:04"))
+}
+
+// NextFunc looks up the booking that follows booking in its room within
+// HandoverWindow, or nil when the room is free after it
+type NextFunc func(ctx context.Context, booking milesapi.Booking) (*milesapi.Booking, error)
+
+// Scheduler turns successive polls of the user's bookings into reminders.
+// It remembers what it has reminded of, so each reminder comes once, and
+// which bookings were pending, so their approval can be noticed.
+type Scheduler struct {
+	userID string
+	next   NextFunc
+
+	mu       sync.Mutex
+	sent     map[string]bool
+	pending  map[string]bool
+	handover map[string]bool
+}
+
+// NewScheduler returns a Scheduler for the user with ID userID, looking up
+// who has a room next with next
+func NewScheduler(userID string, next NextFunc) *Scheduler {
+	return &Scheduler{
+		userID:   userID,
+		next:     next,
+		sent:     map[string]bool{},
+		pending:  map[string]bool{},
+		handover: map[string]bool{},
+	}
+}
+
+// Given records reminders given before, e.g. by an earlier run, so Due
+// doesn't give them again
+func (s *Scheduler) Given(reminders []Reminder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range reminders {
+		s.sent[r.Key()] = true
+		if r.Kind == Ending {
+			s.handover[r.Booking.GetId()] = true
+		}
+	}
+}
+
+// Due returns the reminders bookings call for at now that haven't been
+// given yet. Looking up who has a room next can fail; that booking's
+// Ending reminder is then skipped. A room the user has next themselves
+// needs no reminder either.
+func (s *Scheduler) Due(ctx context.Context, bookings []milesapi.Booking, now time.Time) []Reminder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []Reminder
+	remind := func(r Reminder) {
+		if !s.sent[r.Key()] {
+			s.sent[r.Key()] = true
+			due = append(due, r)
+		}
+	}
+
+	for _, booking := range bookings {
+		id := booking.GetId()
+		switch booking.GetStatus() {
+		case milesapi.BookingStatusPENDING:
+			s.pending[id] = true
+			continue
+		case milesapi.BookingStatusCANCELLED:
+			delete(s.pending, id)
+			continue
+		}
+
+		if s.pending[id] {
+			delete(s.pending, id)
+			remind(Reminder{Kind: Approved, At: now, Booking: booking})
+		}
+
+		start, end := booking.GetStartTime(), booking.GetEndTime()
+		if start.After(now) && start.Sub(now) <= Lead {
+			remind(Reminder{Kind: Starting, At: now, Booking: booking})
+		}
+
+		if s.handover[id] || start.After(now) || !now.Before(end) || end.Sub(now) > HandoverLead {
+			continue
+		}
+		s.handover[id] = true
+		successor, err := s.next(ctx, booking)
+		if err != nil || successor != nil && successor.GetUserId() == s.userID {
+			continue
+		}
+		remind(Reminder{Kind: Ending, At: now, Booking: booking, Successor: successor})
+	}
+	return due
+}
+
+// Successor picks the booking that follows booking out of the room's
+// bookings from its end, as NextFunc implementations fetch them
+func Successor(booking milesapi.Booking, next []milesapi.Booking) *milesapi.Booking {
+	for _, candidate := range next {
+		if candidate.GetId() == booking.GetId() || candidate.GetStartTime().Before(booking.GetEndTime()) ||
+			candidate.GetStatus() == milesapi.BookingStatusCANCELLED {
+			continue
+		}
+		// Bookings come by start time, so this is the one that follows
+		return &candidate
+	}
+	return nil
+}
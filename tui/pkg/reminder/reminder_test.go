@@ -0,0 +1,101 @@
+package reminder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/miles/booking-tui/pkg/milesapi"
+)
+
+func booking(id string, status milesapi.BookingStatus, start, end time.Time) milesapi.Booking {
+	return milesapi.Booking{Id: &id, Status: &status, StartTime: &start, EndTime: &end}
+}
+
+func keys(reminders []Reminder) []string {
+	var keys []string
+	for _, r := range reminders {
+		keys = append(keys, r.Key())
+	}
+	return keys
+}
+
+func TestSchedulerDue(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	mine, theirs := "me", "them"
+	var successor *milesapi.Booking
+	s := NewScheduler(mine, func(context.Context, milesapi.Booking) (*milesapi.Booking, error) {
+		return successor, nil
+	})
+
+	soon := booking("soon", milesapi.BookingStatusCONFIRMED, now.Add(5*time.Minute), now.Add(time.Hour))
+	later := booking("later", milesapi.BookingStatusCONFIRMED, now.Add(time.Hour), now.Add(2*time.Hour))
+	pending := booking("pending", milesapi.BookingStatusPENDING, now.Add(time.Hour), now.Add(2*time.Hour))
+	ending := booking("ending", milesapi.BookingStatusCONFIRMED, now.Add(-time.Hour), now.Add(3*time.Minute))
+	successor = &milesapi.Booking{UserId: &theirs}
+
+	got := keys(s.Due(context.Background(), []milesapi.Booking{soon, later, pending, ending}, now))
+	want := []string{"starting soon", "ending ending"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("first poll = %v, want %v", got, want)
+	}
+
+	// Nothing is given twice; the pending booking's approval is news
+	approved := booking("pending", milesapi.BookingStatusCONFIRMED, now.Add(time.Hour), now.Add(2*time.Hour))
+	got = keys(s.Due(context.Background(), []milesapi.Booking{soon, later, approved, ending}, now))
+	if len(got) != 1 || got[0] != "approved pending" {
+		t.Fatalf("second poll = %v, want [approved pending]", got)
+	}
+}
+
+func TestSchedulerOwnSuccessor(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	mine := "me"
+	s := NewScheduler(mine, func(context.Context, milesapi.Booking) (*milesapi.Booking, error) {
+		return &milesapi.Booking{UserId: &mine}, nil
+	})
+
+	ending := booking("ending", milesapi.BookingStatusCONFIRMED, now.Add(-time.Hour), now.Add(3*time.Minute))
+	if got := s.Due(context.Background(), []milesapi.Booking{ending}, now); len(got) != 0 {
+		t.Errorf("Due = %v, want nothing when I have the room next", keys(got))
+	}
+}
+
+func TestSchedulerGiven(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	s := NewScheduler("me", func(context.Context, milesapi.Booking) (*milesapi.Booking, error) {
+		return nil, nil
+	})
+
+	soon := booking("soon", milesapi.BookingStatusCONFIRMED, now.Add(5*time.Minute), now.Add(time.Hour))
+	s.Given([]Reminder{{Kind: Starting, At: now, Booking: soon}})
+	if got := s.Due(context.Background(), []milesapi.Booking{soon}, now); len(got) != 0 {
+		t.Errorf("Due = %v, want nothing already given", keys(got))
+	}
+}
+
+func TestReminderExpired(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	b := booking("b", milesapi.BookingStatusCONFIRMED, now.Add(5*time.Minute), now.Add(time.Hour))
+
+	tests := []struct {
+		name     string
+		reminder Reminder
+		at       time.Time
+		want     bool
+	}{
+		{"starting, before the start", Reminder{Kind: Starting, At: now, Booking: b}, now, false},
+		{"starting, once started", Reminder{Kind: Starting, At: now, Booking: b}, now.Add(5 * time.Minute), true},
+		{"ending, before the end", Reminder{Kind: Ending, At: now, Booking: b}, now.Add(30 * time.Minute), false},
+		{"ending, once ended", Reminder{Kind: Ending, At: now, Booking: b}, now.Add(time.Hour), true},
+		{"approved, fresh", Reminder{Kind: Approved, At: now, Booking: b}, now.Add(time.Minute), false},
+		{"approved, old news", Reminder{Kind: Approved, At: now, Booking: b}, now.Add(time.Hour), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reminder.Expired(tt.at); got != tt.want {
+				t.Errorf("Expired = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
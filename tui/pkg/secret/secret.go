@@ -0,0 +1,113 @@
+// Package secret encrypts booking descriptions on the client with a key the
+// team shares, so the server only ever stores ciphertext. Clients with the
+// key decrypt them transparently; the rest show Placeholder.
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Prefix marks an encrypted description and the format version
+const Prefix = "enc:v1:"
+
+// Placeholder is shown instead of a description that can't be decrypted
+const Placeholder = "[encrypted]"
+
+// KeySize is the key length in bytes (AES-256)
+const KeySize = 32
+
+// ErrNoKey is returned when encrypting without a key configured
+var ErrNoKey = errors.New("no description key configured")
+
+// ParseKey decodes a base64 key, as made by `openssl rand -base64 32`. An
+// empty string is no key.
+func ParseKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("description key is not valid base64: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("description key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// IsEncrypted reports whether s was made by Encrypt
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, Prefix)
+}
+
+// Encrypt seals plaintext with AES-GCM under key
+func Encrypt(key []byte, plaintext string) (string, error) {
+	if key == nil {
+		return "", ErrNoKey
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return Prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a string made by Encrypt. It fails for a different key.
+func Decrypt(key []byte, s string) (string, error) {
+	if !IsEncrypted(s) {
+		return "", errors.New("not an encrypted description")
+	}
+	if key == nil {
+		return "", ErrNoKey
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, Prefix))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted description is truncated")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.New("description was encrypted with a different key")
+	}
+	return string(plaintext), nil
+}
+
+// Reveal returns s decrypted when it is encrypted, Placeholder when it
+// can't be, and s itself otherwise
+func Reveal(key []byte, s string) string {
+	if !IsEncrypted(s) {
+		return s
+	}
+	plaintext, err := Decrypt(key, s)
+	if err != nil {
+		return Placeholder
+	}
+	return plaintext
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
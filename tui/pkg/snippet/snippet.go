@@ -0,0 +1,411 @@
+// Package snippet reads a meeting from a line of free text, such as one
+// copied from chat or an email, for `miles book --from-text`:
+//
+//	Tue 14:00-15:00 design review with Anna
+//	tomorrow at 9 for 30m standup
+//	2025-10-21 2pm-3:30pm planning
+//
+// Days can be today, tomorrow, a weekday (the coming one; "next" skips
+// today), 2025-10-21, 21.10.2025, 21/10 (day first) or Oct 21 / 21 Oct.
+// Times are 14:00, 14.00, 2pm or "at 14", alone or as a range joined by -,
+// to or until. A duration such as "for 45m" or "1h30" can stand in for the
+// end time, which otherwise defaults to an hour after the start. Hours
+// before 7 without am/pm are taken as afternoon. The words that are left
+// become the title.
+//
+// ParseDate reads a day alone the same way, for jumping to a date.
+package snippet
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDuration is the meeting length when the text only gives a start
+const DefaultDuration = time.Hour
+
+// Snippet is a meeting read from text
+type Snippet struct {
+	Start time.Time
+	End   time.Time
+	Title string
+}
+
+var (
+	isoDateRe   = regexp.MustCompile(`(?i)\b(?:on\s+)?(\d{4})-(\d{1,2})-(\d{1,2})\b`)
+	dmyDateRe   = regexp.MustCompile(`(?i)\b(?:on\s+)?(\d{1,2})[./](\d{1,2})[./](\d{4}|\d{2})\b`)
+	dmDateRe    = regexp.MustCompile(`(?i)\b(?:on\s+)?(\d{1,2})/(\d{1,2})\b`)
+	dayMonthRe  = regexp.MustCompile(`(?i)\b(?:on\s+)?(\d{1,2})(?:st|nd|rd|th)?\.?\s+(` + monthNames + `)[a-z]*\.?(?:\s+(\d{4}))?\b`)
+	monthDayRe  = regexp.MustCompile(`(?i)\b(?:on\s+)?(` + monthNames + `)[a-z]*\.?\s+(\d{1,2})(?:st|nd|rd|th)?\b(?:,?\s+(\d{4})\b)?`)
+	dayWordRe   = regexp.MustCompile(`(?i)\b(?:on\s+)?(?:(today|tonight)|(tomorrow|tmrw)|(next\s+)?(` + weekdayNames + `))\b\.?`)
+	timeRangeRe = regexp.MustCompile(`(?i)\b(?:from\s+|at\s+|kl\.?\s*)?` + clockPattern + `\s*(?:-|–|—|to|until|till)\s*` + clockPattern + `\b`)
+	hourMinRe   = regexp.MustCompile(`(?i)\b(?:for\s+)?(\d+)\s*h\s*(\d{1,2})\s*(?:m|min|mins)?\b`)
+	durationRe  = regexp.MustCompile(`(?i)\b(?:for\s+)?(\d+(?:[.,]\d+)?)\s*(hours?|hrs?|h|minutes?|mins?|m)\b`)
+	anHourRe    = regexp.MustCompile(`(?i)\bfor\s+(?:(half)\s+an|an?|one)\s+hour\b`)
+	clockRe     = regexp.MustCompile(`(?i)\b(?:at\s+|@\s*|kl\.?\s*)?(\d{1,2})(?:[:.](\d{2})\s*([ap]m)?|\s*([ap]m))\b`)
+	atHourRe    = regexp.MustCompile(`(?i)(?:\bat\s+|@\s*|\bkl\.?\s*)(\d{1,2})\b`)
+	relativeRe  = regexp.MustCompile(`(?i)^\s*(?:in\s+(\d+)\s+(day|week|month)s?|next\s+(week|month)|(yesterday))\s*$`)
+)
+
+const (
+	monthNames   = `jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec`
+	weekdayNames = `monday|mon|tuesday|tues|tue|wednesday|wed|thursday|thurs|thur|thu|friday|fri|saturday|sat|sunday|sun`
+	clockPattern = `(\d{1,2})(?:[:.](\d{2}))?\s*([ap]m)?`
+)
+
+var months = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+var weekdays = map[string]time.Weekday{
+	"mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+	"fri": time.Friday, "sat": time.Saturday, "sun": time.Sunday,
+}
+
+// Parse reads a meeting from text. now anchors relative days and decides
+// whether a day without a date means today or tomorrow.
+func Parse(text string, now time.Time) (*Snippet, error) {
+	p := &parser{rest: []byte(text), now: now}
+
+	if err := p.parseDate(); err != nil {
+		return nil, err
+	}
+	if err := p.parseTimes(); err != nil {
+		return nil, err
+	}
+	if !p.hasStart {
+		return nil, fmt.Errorf("no start time found in %q", text)
+	}
+
+	start, end := p.resolve()
+	return &Snippet{Start: start, End: end, Title: cleanTitle(string(p.rest))}, nil
+}
+
+// ParseDate reads a day from text such as "next friday", "21/10", "Oct 21",
+// "tomorrow", "next month" or "in 3 weeks", as midnight in now's location.
+// Weekdays are the coming one, today included; "next" skips today.
+func ParseDate(text string, now time.Time) (time.Time, error) {
+	p := &parser{rest: []byte(text), now: now}
+	today := p.today()
+
+	if m := p.take(relativeRe); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch unit := strings.ToLower(m[2] + m[3]); {
+		case m[4] != "":
+			return today.AddDate(0, 0, -1), nil
+		case m[3] != "" && unit == "week":
+			return today.AddDate(0, 0, 7), nil
+		case m[3] != "":
+			return addMonths(today, 1), nil
+		case unit == "day":
+			return today.AddDate(0, 0, n), nil
+		case unit == "week":
+			return today.AddDate(0, 0, 7*n), nil
+		default:
+			return addMonths(today, n), nil
+		}
+	}
+
+	if err := p.parseDate(); err != nil {
+		return time.Time{}, err
+	}
+	if strings.TrimSpace(string(p.rest)) != "" || (!p.hasDate && !p.onWeekday) {
+		return time.Time{}, fmt.Errorf("can't read %q as a date", strings.TrimSpace(text))
+	}
+	if p.hasDate {
+		return p.date, nil
+	}
+	days := (int(p.weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 && p.skipToday {
+		days = 7
+	}
+	return today.AddDate(0, 0, days), nil
+}
+
+// addMonths moves a day n months on, keeping to the last day of shorter
+// months rather than spilling into the next
+func addMonths(day time.Time, n int) time.Time {
+	first := time.Date(day.Year(), day.Month()+time.Month(n), 1, 0, 0, 0, 0, day.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day.Day(), last)-1)
+}
+
+// parser consumes recognised parts of the text, blanking them out of rest
+// so that what is left is the title
+type parser struct {
+	rest []byte
+	now  time.Time
+
+	// Day, when given
+	date      time.Time
+	hasDate   bool
+	weekday   time.Weekday
+	onWeekday bool
+	skipToday bool
+
+	// Time of day in minutes after midnight
+	start, end       int
+	hasStart, hasEnd bool
+	duration         time.Duration
+}
+
+// take finds re in what is left of the text and blanks out the match
+func (p *parser) take(re *regexp.Regexp) []string {
+	loc := re.FindSubmatchIndex(p.rest)
+	if loc == nil {
+		return nil
+	}
+	groups := make([]string, len(loc)/2)
+	for i := range groups {
+		if loc[2*i] >= 0 {
+			groups[i] = string(p.rest[loc[2*i]:loc[2*i+1]])
+		}
+	}
+	for i := loc[0]; i < loc[1]; i++ {
+		p.rest[i] = ' '
+	}
+	return groups
+}
+
+func (p *parser) parseDate() error {
+	year, month, day := 0, time.Month(0), 0
+
+	if m := p.take(isoDateRe); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		n, _ := strconv.Atoi(m[2])
+		month = time.Month(n)
+		day, _ = strconv.Atoi(m[3])
+	} else if m := p.take(dmyDateRe); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		n, _ := strconv.Atoi(m[2])
+		month = time.Month(n)
+		year, _ = strconv.Atoi(m[3])
+		if year < 100 {
+			year += 2000
+		}
+	} else if m := p.take(dmDateRe); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		n, _ := strconv.Atoi(m[2])
+		month = time.Month(n)
+	} else if m := p.take(dayMonthRe); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		month = months[strings.ToLower(m[2][:3])]
+		year, _ = strconv.Atoi(m[3])
+	} else if m := p.take(monthDayRe); m != nil {
+		month = months[strings.ToLower(m[1][:3])]
+		day, _ = strconv.Atoi(m[2])
+		year, _ = strconv.Atoi(m[3])
+	} else if m := p.take(dayWordRe); m != nil {
+		switch {
+		case m[1] != "":
+			p.date, p.hasDate = p.today(), true
+		case m[2] != "":
+			p.date, p.hasDate = p.today().AddDate(0, 0, 1), true
+		default:
+			p.weekday = weekdays[strings.ToLower(m[4][:3])]
+			p.onWeekday = true
+			p.skipToday = m[3] != ""
+		}
+		return nil
+	} else {
+		return nil
+	}
+
+	if month < time.January || month > time.December || day < 1 || day > 31 {
+		return fmt.Errorf("invalid date: day %d of month %d", day, month)
+	}
+	if year == 0 {
+		// Dates without a year are the next one to come
+		year = p.now.Year()
+		if time.Date(year, month, day, 0, 0, 0, 0, p.now.Location()).Before(p.today()) {
+			year++
+		}
+	}
+	p.date = time.Date(year, month, day, 0, 0, 0, 0, p.now.Location())
+	if p.date.Day() != day {
+		return fmt.Errorf("invalid date: %s %d has no day %d", month, year, day)
+	}
+	p.hasDate = true
+	return nil
+}
+
+func (p *parser) parseTimes() error {
+	if m := p.take(timeRangeRe); m != nil {
+		start, err := clock(m[1], m[2], m[3])
+		if err != nil {
+			return err
+		}
+		end, err := clock(m[4], m[5], m[6])
+		if err != nil {
+			return err
+		}
+		// "2-3pm": the start shares the end's am/pm unless that puts it after the end
+		if m[3] == "" && m[6] != "" {
+			if shared, err := clock(m[1], m[2], m[6]); err == nil && shared < end {
+				start = shared
+			}
+		}
+		if m[3] == "" && m[6] == "" {
+			start, end = afternoon(start, m[1]), afternoon(end, m[4])
+		}
+		p.start, p.end, p.hasStart, p.hasEnd = start, end, true, true
+		return nil
+	}
+
+	if m := p.take(hourMinRe); m != nil {
+		h, _ := strconv.Atoi(m[1])
+		min, _ := strconv.Atoi(m[2])
+		p.duration = time.Duration(h)*time.Hour + time.Duration(min)*time.Minute
+	} else if m := p.take(anHourRe); m != nil {
+		p.duration = time.Hour
+		if m[1] != "" {
+			p.duration = 30 * time.Minute
+		}
+	} else if m := p.take(durationRe); m != nil {
+		n, err := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", m[1])
+		}
+		unit := time.Minute
+		if strings.HasPrefix(strings.ToLower(m[2]), "h") {
+			unit = time.Hour
+		}
+		p.duration = time.Duration(n * float64(unit))
+	}
+
+	if m := p.take(clockRe); m != nil {
+		suffix := m[3] + m[4]
+		start, err := clock(m[1], m[2], suffix)
+		if err != nil {
+			return err
+		}
+		if suffix == "" {
+			start = afternoon(start, m[1])
+		}
+		p.start, p.hasStart = start, true
+	} else if m := p.take(atHourRe); m != nil {
+		start, err := clock(m[1], "", "")
+		if err != nil {
+			return err
+		}
+		p.start, p.hasStart = afternoon(start, m[1]), true
+	}
+	return nil
+}
+
+// resolve turns the parsed day and times into the meeting's start and end
+func (p *parser) resolve() (time.Time, time.Time) {
+	today := p.today()
+	at := func(day time.Time, minutes int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, p.now.Location())
+	}
+
+	var day time.Time
+	switch {
+	case p.hasDate:
+		day = p.date
+	case p.onWeekday:
+		days := (int(p.weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 && (p.skipToday || !at(today, p.start).After(p.now)) {
+			days = 7
+		}
+		day = today.AddDate(0, 0, days)
+	default:
+		// A bare time is the next time the clock shows it
+		day = today
+		if !at(today, p.start).After(p.now) {
+			day = today.AddDate(0, 0, 1)
+		}
+	}
+
+	start := at(day, p.start)
+	switch {
+	case p.hasEnd:
+		end := at(day, p.end)
+		if !end.After(start) {
+			// Ranges past midnight end the next day
+			end = end.AddDate(0, 0, 1)
+		}
+		return start, end
+	case p.duration > 0:
+		return start, start.Add(p.duration)
+	}
+	return start, start.Add(DefaultDuration)
+}
+
+func (p *parser) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+// clock converts an hour, optional minutes and optional am/pm to minutes
+// after midnight
+func clock(hour, minute, suffix string) (int, error) {
+	h, err := strconv.Atoi(hour)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hour %q", hour)
+	}
+	m := 0
+	if minute != "" {
+		if m, err = strconv.Atoi(minute); err != nil || m > 59 {
+			return 0, fmt.Errorf("invalid minutes %q", minute)
+		}
+	}
+
+	switch strings.ToLower(suffix) {
+	case "am":
+		if h < 1 || h > 12 {
+			return 0, fmt.Errorf("invalid hour %s%s", hour, suffix)
+		}
+		h %= 12
+	case "pm":
+		if h < 1 || h > 12 {
+			return 0, fmt.Errorf("invalid hour %s%s", hour, suffix)
+		}
+		h = h%12 + 12
+	default:
+		if h > 24 || (h == 24 && m > 0) {
+			return 0, fmt.Errorf("invalid hour %q", hour)
+		}
+		h %= 24
+	}
+	return h*60 + m, nil
+}
+
+// afternoon moves hours 1-6 written without am/pm to the afternoon, since
+// nobody books a room at 2 in the night. Zero-padded hours ("02:00") are
+// taken as written.
+func afternoon(minutes int, hour string) int {
+	if minutes >= 60 && minutes < 7*60 && !strings.HasPrefix(hour, "0") {
+		return minutes + 12*60
+	}
+	return minutes
+}
+
+// titleTrim is what is stripped from the ends of the leftover text
+const titleTrim = " \t,;:-–—|@•*\"'()[]"
+
+// fillers are joining words left at the ends once times are taken out
+var fillers = map[string]bool{"on": true, "at": true, "from": true, "for": true, "kl": true, "kl.": true}
+
+// cleanTitle tidies the text left after dates and times are taken out
+func cleanTitle(rest string) string {
+	words := strings.Fields(rest)
+	for {
+		for len(words) > 0 && (fillers[strings.ToLower(words[0])] || strings.Trim(words[0], titleTrim) == "") {
+			words = words[1:]
+		}
+		for len(words) > 0 && (fillers[strings.ToLower(words[len(words)-1])] || strings.Trim(words[len(words)-1], titleTrim) == "") {
+			words = words[:len(words)-1]
+		}
+		title := strings.Trim(strings.Join(words, " "), titleTrim)
+		if title == strings.Join(words, " ") {
+			return title
+		}
+		words = strings.Fields(title)
+	}
+}
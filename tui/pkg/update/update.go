@@ -0,0 +1,360 @@
+// Package update finds new releases of the CLI (miles) and TUI
+// (miles-booking) and installs them. Releases come from GitHub, or from any
+// endpoint serving the same JSON as GitHub's "latest release" API. Each
+// release carries binaries named like miles_linux_amd64, a checksums.txt of
+// SHA-256 sums and its Ed25519 signature in checksums.txt.sig. Nothing is
+// installed without a valid signature by the release key: DefaultPublicKey
+// when the build was stamped with one, or a key given by the user.
+//
+// Hints about new versions are checked at most once a day and cached in
+// ~/.miles-update.json; set MILES_NO_UPDATE_CHECK=1 to turn them off.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultURL is GitHub's latest release of the assistant repository
+const DefaultURL = "https://api.github.com/repos/miles-no/assistant/releases/latest"
+
+// Release assets holding the checksums and their signature
+const (
+	ChecksumsAsset = "checksums.txt"
+	SignatureAsset = "checksums.txt.sig"
+)
+
+// DefaultPublicKey is the base64 Ed25519 key release checksums are signed
+// with. The release maintainers hold the private half and stamp the public
+// one into release builds with -ldflags "-X <package>.DefaultPublicKey=...";
+// other builds have none, and need a key given to Download.
+var DefaultPublicKey = ""
+
+// ErrNoPublicKey is returned by Download when there is no release key to
+// check the signature with: the build wasn't stamped with one and none was
+// given
+var ErrNoPublicKey = errors.New("no release key to verify the download with")
+
+// CacheFileName is the hint cache in the home directory
+const CacheFileName = ".miles-update.json"
+
+// CheckInterval is how long a hint check is trusted before asking again
+const CheckInterval = 24 * time.Hour
+
+// hintTimeout bounds the background check so a slow network never holds
+// up 'miles --version' or the TUI for long
+const hintTimeout = 2 * time.Second
+
+// maxAssetSize guards against downloading something that can't be a binary
+const maxAssetSize = 200 << 20
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a published version
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Version returns the release's version without the leading "v"
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Asset returns the asset with the given name
+func (r *Release) Asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Latest fetches the latest release from url (DefaultURL when empty)
+func Latest(ctx context.Context, url string) (*Release, error) {
+	if url == "" {
+		url = DefaultURL
+	}
+	body, err := fetch(ctx, url, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("failed to check for updates: invalid release: %w", err)
+	}
+	if release.Tag == "" {
+		return nil, fmt.Errorf("failed to check for updates: release has no version")
+	}
+	return &release, nil
+}
+
+// Newer reports whether latest is a higher version than current. Versions
+// are dotted numbers ("1.2.0", optionally with a "v"); anything else, such
+// as a development build, is never considered outdated.
+func Newer(current, latest string) bool {
+	a, okA := parseVersion(current)
+	b, okB := parseVersion(latest)
+	if !okA || !okB {
+		return false
+	}
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return y > x
+		}
+	}
+	return false
+}
+
+// parseVersion splits "v1.2.3" into its numbers, ignoring any "-rc1"
+// style suffix
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return nil, false
+	}
+	var parts []int
+	for _, part := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
+
+// AssetName is the release asset holding binary for this platform, e.g.
+// miles_darwin_arm64
+func AssetName(binary string) string {
+	name := fmt.Sprintf("%s_%s_%s", binary, runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Download fetches binary for this platform from release and verifies it
+// against the release's checksums, which must carry a valid signature by
+// the base64 Ed25519 publicKey (DefaultPublicKey when empty). The checksums
+// come from the same place as the binary, so without the signature they
+// would only catch a corrupt download.
+func Download(ctx context.Context, release *Release, binary, publicKey string) ([]byte, error) {
+	if publicKey == "" {
+		publicKey = DefaultPublicKey
+	}
+	if publicKey == "" {
+		return nil, ErrNoPublicKey
+	}
+
+	name := AssetName(binary)
+	asset, ok := release.Asset(name)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s", release.Tag, name)
+	}
+	sums, ok := release.Asset(ChecksumsAsset)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s, refusing to install an unverified binary", release.Tag, ChecksumsAsset)
+	}
+
+	checksums, err := fetch(ctx, sums.URL, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", ChecksumsAsset, err)
+	}
+	sig, ok := release.Asset(SignatureAsset)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s, refusing to install an unsigned binary", release.Tag, SignatureAsset)
+	}
+	signature, err := fetch(ctx, sig.URL, 4096)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", SignatureAsset, err)
+	}
+	if err := VerifySignature(publicKey, checksums, signature); err != nil {
+		return nil, err
+	}
+
+	data, err := fetch(ctx, asset.URL, maxAssetSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	if err := VerifyChecksum(checksums, name, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// VerifyChecksum checks data against name's SHA-256 in a checksums file of
+// "<hex>  <name>" lines, as sha256sum writes them
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum mismatch for %s: the download is corrupt or has been tampered with", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s has no checksum for %s", ChecksumsAsset, name)
+}
+
+// VerifySignature checks a base64 Ed25519 signature of the checksums file
+// against a base64 public key
+func VerifySignature(publicKey string, checksums, signature []byte) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid update public key: expected %d base64-encoded bytes", ed25519.PublicKeySize)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil || !ed25519.Verify(key, checksums, sig) {
+		return fmt.Errorf("invalid signature on %s: refusing to install", ChecksumsAsset)
+	}
+	return nil
+}
+
+// Replace swaps the executable at path for data. The new binary is written
+// next to it and renamed over it, so a failure leaves the old one intact.
+func Replace(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("cannot write next to %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	// Windows can't replace a running executable, but it can rename it
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// cache remembers the last hint check
+type cache struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Latest    string    `json:"latest"`
+}
+
+// cachePath returns ~/.miles-update.json
+func cachePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, CacheFileName)
+}
+
+// Available returns the latest version when it is newer than current, for
+// a "new version available" hint. It asks url at most once a day and gives
+// up quickly; any failure just means no hint.
+func Available(current, url string) (string, bool) {
+	if os.Getenv("MILES_NO_UPDATE_CHECK") != "" {
+		return "", false
+	}
+
+	path := cachePath()
+	var c cache
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &c)
+	}
+
+	if time.Since(c.CheckedAt) > CheckInterval {
+		ctx, cancel := context.WithTimeout(context.Background(), hintTimeout)
+		defer cancel()
+		release, err := Latest(ctx, url)
+		if err != nil {
+			return "", false
+		}
+		c = cache{CheckedAt: time.Now(), Latest: release.Version()}
+		if data, err := json.Marshal(c); err == nil && path != "" {
+			os.WriteFile(path, data, 0o600)
+		}
+	}
+
+	if !Newer(current, c.Latest) {
+		return "", false
+	}
+	return c.Latest, true
+}
+
+// fetch GETs url, reading at most limit bytes
+func fetch(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json, application/octet-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("response larger than %d bytes", limit)
+	}
+	return data, nil
+}
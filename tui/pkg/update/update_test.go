@@ -0,0 +1,92 @@
+package update
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// releaseServer serves a binary, its checksums and the given signature
+// (none when empty), and returns the release describing them
+func releaseServer(t *testing.T, binary, checksums []byte, signature string) *Release {
+	t.Helper()
+	files := map[string][]byte{
+		"/" + AssetName("miles"): binary,
+		"/" + ChecksumsAsset:     checksums,
+	}
+	if signature != "" {
+		files["/"+SignatureAsset] = []byte(signature)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+
+	release := &Release{Tag: "v9.9.9"}
+	for path := range files {
+		release.Assets = append(release.Assets, Asset{Name: strings.TrimPrefix(path, "/"), URL: srv.URL + path})
+	}
+	return release
+}
+
+func TestDownloadRequiresSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := base64.StdEncoding.EncodeToString(publicKey)
+
+	binary := []byte("new miles")
+	sum := sha256.Sum256(binary)
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  " + AssetName("miles") + "\n")
+	signed := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, checksums))
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+	forged := base64.StdEncoding.EncodeToString(ed25519.Sign(otherKey, checksums))
+
+	tests := []struct {
+		name       string
+		signature  string
+		publicKey  string
+		defaultKey string // Stamped into the build
+		wantErr    string
+	}{
+		{name: "signed", signature: signed, publicKey: key},
+		{name: "signed, key stamped into the build", signature: signed, defaultKey: key},
+		{name: "unsigned", publicKey: key, wantErr: "unsigned binary"},
+		{name: "signed by another key", signature: forged, publicKey: key, wantErr: "invalid signature"},
+		{name: "no key", signature: signed, wantErr: ErrNoPublicKey.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultKey := DefaultPublicKey
+			DefaultPublicKey = tt.defaultKey
+			t.Cleanup(func() { DefaultPublicKey = defaultKey })
+
+			release := releaseServer(t, binary, checksums, tt.signature)
+			data, err := Download(context.Background(), release, "miles", tt.publicKey)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Download() error = %v", err)
+				}
+				if string(data) != string(binary) {
+					t.Fatalf("Download() = %q, want %q", data, binary)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Download() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
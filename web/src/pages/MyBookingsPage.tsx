@@ -1,6 +1,7 @@
 import { format } from "date-fns";
 import { Calendar, Clock, DoorOpen, MapPin, Trash2 } from "lucide-react";
-import { useState } from "react";
+import { useEffect, useState } from "react";
+import { useSearchParams } from "react-router-dom";
 import type { Booking, Location, Room, User } from "@/api-generated/types.gen";
 import Layout from "@/components/Layout";
 import {
@@ -11,6 +12,7 @@ import {
 	LoadingSpinner,
 } from "@/components/ui";
 import { useBookings, useCancelBooking } from "@/hooks/useBookings";
+import { cn } from "@/lib/utils";
 
 type BookingWithRelations = Booking & {
 	room?: Room & {
@@ -23,6 +25,9 @@ export default function MyBookingsPage() {
 	const { data: bookings, isLoading, error } = useBookings();
 	const cancelBooking = useCancelBooking();
 	const [cancelModalOpen, setCancelModalOpen] = useState(false);
+	// Shared links (miles share) point here with ?booking=<id>
+	const [searchParams] = useSearchParams();
+	const linkedBookingId = searchParams.get("booking");
 	const [selectedBooking, setSelectedBooking] =
 		useState<BookingWithRelations | null>(null);
 
@@ -63,6 +68,13 @@ export default function MyBookingsPage() {
 			(b.endTime && new Date(b.endTime) <= now) || b.status === "CANCELLED",
 	);
 
+	useEffect(() => {
+		if (!linkedBookingId || !bookings) return;
+		document
+			.getElementById(`booking-${linkedBookingId}`)
+			?.scrollIntoView({ behavior: "smooth", block: "center" });
+	}, [linkedBookingId, bookings]);
+
 	if (isLoading) {
 		return (
 			<Layout>
@@ -92,8 +104,16 @@ export default function MyBookingsPage() {
 		const isUpcoming = startDate > now && booking.status !== "CANCELLED";
 		const canCancel = isUpcoming;
 
+		const isLinked = booking.id === linkedBookingId;
+
 		return (
-			<Card className="transition-shadow hover:shadow-md">
+			<Card
+				id={`booking-${booking.id}`}
+				className={cn(
+					"transition-shadow hover:shadow-md",
+					isLinked && "ring-2 ring-primary-500",
+				)}
+			>
 				<div className="flex items-start justify-between">
 					<div className="flex-1">
 						<div className="flex items-start justify-between">